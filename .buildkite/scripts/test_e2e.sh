@@ -25,14 +25,14 @@ fi
 node_binary="${WORKDIR}/go/oasis-node/oasis-node"
 test_runner_binary="${WORKDIR}/go/oasis-test-runner/oasis-test-runner"
 
-# Use e2e-coverage-wrapper.sh as node binary if we need to compute E2E
-# tests' coverage.
+# Use coverage-instrumented binaries if we need to compute E2E tests' coverage.
+coverage_dir="${WORKDIR}/coverage-e2e"
 if [[ ${OASIS_E2E_COVERAGE:-""} != "" ]]; then
     test_runner_binary="${WORKDIR}/scripts/e2e-coverage-wrapper-arg.sh ${test_runner_binary}.test"
 
-    # Use -env version of the wrapper as we can't pass additional arguments there.
-    export E2E_COVERAGE_BINARY=${node_binary}.test
-    node_binary="${WORKDIR}/scripts/e2e-coverage-wrapper-env.sh"
+    # Nodes write their coverage data into per-scenario directories, which the
+    # test runner merges after each scenario.
+    node_binary="${node_binary}.cover"
 fi
 
 ias_mock="true"
@@ -59,6 +59,7 @@ ${test_runner_binary} \
     --plugin-signer.name example \
     --plugin-signer.binary ${WORKDIR}/go/oasis-test-runner/scenario/pluginsigner/example_signer_plugin/example_signer_plugin \
     --log.level debug \
+    ${OASIS_E2E_COVERAGE:+--coverage.dir ${coverage_dir}} \
     ${BUILDKITE_PARALLEL_JOB_COUNT:+--parallel.job_count ${BUILDKITE_PARALLEL_JOB_COUNT}} \
     ${BUILDKITE_PARALLEL_JOB:+--parallel.job_index ${BUILDKITE_PARALLEL_JOB}} \
     "$@"
@@ -70,6 +71,6 @@ if [[ "${BUILDKITE:-""}" != "" ]]; then
         step_tag="${BUILDKITE_STEP_KEY:+-${BUILDKITE_STEP_KEY}}"
         parallel_tag="-${BUILDKITE_PARALLEL_JOB:-0}"
         merged_file="coverage-merged-e2e${hw_tag}${step_tag}${parallel_tag}.txt"
-        gocovmerge coverage-e2e-*.txt >"$merged_file"
+        gocovmerge coverage-e2e-*.txt $(find "${coverage_dir}" -name coverage.txt) >"$merged_file"
     fi
 fi
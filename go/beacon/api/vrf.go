@@ -123,3 +123,7 @@ type VRFBackend interface {
 	// Upon subscription the current epoch event is sent immediately.
 	WatchLatestVRFEvent(ctx context.Context) (<-chan *VRFEvent, *pubsub.Subscription, error)
 }
+
+func init() {
+	transaction.RegisterGasOps(ModuleName, GasOpVRFProve)
+}
@@ -0,0 +1,259 @@
+package cbor
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// maxCanonicalNestingLevel is the maximum nesting level of arrays and maps
+// accepted by VerifyCanonical. It matches the default limit of the decoder.
+const maxCanonicalNestingLevel = 32
+
+// ErrNonCanonical is the error returned when a serialized blob is not in the
+// canonical form produced by Marshal.
+var ErrNonCanonical = errors.New("cbor: non-canonical encoding")
+
+// VerifyCanonical verifies that the given CBOR byte vector contains exactly one
+// well-formed data item in the canonical form produced by Marshal.
+//
+// In addition to well-formedness it rejects indefinite lengths, non-minimal
+// integer and length encodings, non-shortest floats, tags, unsorted or
+// duplicate map keys and trailing data.
+func VerifyCanonical(data []byte) error {
+	v := canonicalVerifier{data: data}
+	if err := v.verifyItem(0); err != nil {
+		return err
+	}
+	if v.offset != len(data) {
+		return fmt.Errorf("%w: trailing data at offset %d", ErrNonCanonical, v.offset)
+	}
+	return nil
+}
+
+// UnmarshalStrict deserializes a CBOR byte vector into a given type, rejecting
+// any encoding that is not in canonical form.
+func UnmarshalStrict(data []byte, dst interface{}) error {
+	if err := VerifyCanonical(data); err != nil {
+		return err
+	}
+	return decMode.Unmarshal(data, dst)
+}
+
+// Canonicalize converts a serialized blob containing exactly one well-formed data item into the
+// canonical form produced by Marshal. Blobs that are already canonical are returned unchanged.
+func Canonicalize(raw RawMessage) (RawMessage, error) {
+	if VerifyCanonical(raw) == nil {
+		return raw, nil
+	}
+
+	var v interface{}
+	dec := decModeCanonicalize.NewDecoder(bytes.NewReader(raw))
+	if err := dec.Decode(&v); err != nil {
+		return nil, fmt.Errorf("cbor: failed to canonicalize: %w", err)
+	}
+	if dec.NumBytesRead() != len(raw) {
+		return nil, fmt.Errorf("cbor: failed to canonicalize: trailing data at offset %d", dec.NumBytesRead())
+	}
+	canonical, err := encMode.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("cbor: failed to canonicalize: %w", err)
+	}
+	return canonical, nil
+}
+
+// CanonicalHash returns the SHA-512/256 hash of the canonical form of the given serialized blob.
+//
+// Blobs which only differ in their encoding (e.g., in map key order or integer encoding length)
+// have the same canonical hash.
+func CanonicalHash(raw RawMessage) ([sha512.Size256]byte, error) {
+	canonical, err := Canonicalize(raw)
+	if err != nil {
+		return [sha512.Size256]byte{}, err
+	}
+	return sha512.Sum512_256(canonical), nil
+}
+
+// CanonicalEqual returns true iff the given serialized blobs are well-formed and have the same
+// canonical form. Two empty blobs are considered equal.
+func CanonicalEqual(a, b RawMessage) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	if bytes.Equal(a, b) {
+		return VerifyCanonical(a) == nil
+	}
+	ca, err := Canonicalize(a)
+	if err != nil {
+		return false
+	}
+	cb, err := Canonicalize(b)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(ca, cb)
+}
+
+type canonicalVerifier struct {
+	data   []byte
+	offset int
+}
+
+func (v *canonicalVerifier) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s at offset %d", ErrNonCanonical, fmt.Sprintf(format, args...), v.offset)
+}
+
+func (v *canonicalVerifier) read(n uint64) ([]byte, error) {
+	if uint64(len(v.data)-v.offset) < n {
+		return nil, v.errorf("unexpected end of data")
+	}
+	b := v.data[v.offset : v.offset+int(n)]
+	v.offset += int(n)
+	return b, nil
+}
+
+// readHead reads the initial byte and argument of a data item, verifying that
+// the argument uses the shortest possible encoding.
+func (v *canonicalVerifier) readHead() (byte, byte, uint64, error) {
+	b, err := v.read(1)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	major, info := b[0]>>5, b[0]&0x1f
+
+	var (
+		arg uint64
+		min uint64
+	)
+	switch {
+	case info < 24:
+		return major, info, uint64(info), nil
+	case info == 24:
+		if b, err = v.read(1); err != nil {
+			return 0, 0, 0, err
+		}
+		arg, min = uint64(b[0]), 24
+	case info == 25:
+		if b, err = v.read(2); err != nil {
+			return 0, 0, 0, err
+		}
+		arg, min = uint64(binary.BigEndian.Uint16(b)), math.MaxUint8+1
+	case info == 26:
+		if b, err = v.read(4); err != nil {
+			return 0, 0, 0, err
+		}
+		arg, min = uint64(binary.BigEndian.Uint32(b)), math.MaxUint16+1
+	case info == 27:
+		if b, err = v.read(8); err != nil {
+			return 0, 0, 0, err
+		}
+		arg, min = binary.BigEndian.Uint64(b), math.MaxUint32+1
+	case info == 31:
+		return 0, 0, 0, v.errorf("indefinite length")
+	default:
+		return 0, 0, 0, v.errorf("reserved additional information %d", info)
+	}
+
+	// Floating point numbers and simple values are checked separately.
+	if major != 7 && arg < min {
+		return 0, 0, 0, v.errorf("non-minimal argument encoding")
+	}
+	return major, info, arg, nil
+}
+
+func (v *canonicalVerifier) verifyItem(depth int) error {
+	if depth > maxCanonicalNestingLevel {
+		return v.errorf("exceeded max nesting level")
+	}
+
+	start := v.offset
+	major, info, arg, err := v.readHead()
+	if err != nil {
+		return err
+	}
+
+	switch major {
+	case 0, 1:
+		// Unsigned and negative integers.
+		return nil
+	case 2:
+		// Byte strings.
+		_, err = v.read(arg)
+		return err
+	case 3:
+		// Text strings.
+		_, err = v.read(arg)
+		return err
+	case 4:
+		// Arrays.
+		for i := uint64(0); i < arg; i++ {
+			if err = v.verifyItem(depth + 1); err != nil {
+				return err
+			}
+		}
+		return nil
+	case 5:
+		// Maps, keys must be sorted in length-first order without duplicates.
+		var prevKey []byte
+		for i := uint64(0); i < arg; i++ {
+			keyStart := v.offset
+			if err = v.verifyItem(depth + 1); err != nil {
+				return err
+			}
+			key := v.data[keyStart:v.offset]
+			if prevKey != nil && !canonicalKeyLess(prevKey, key) {
+				return v.errorf("unsorted or duplicate map key")
+			}
+			prevKey = key
+
+			if err = v.verifyItem(depth + 1); err != nil {
+				return err
+			}
+		}
+		return nil
+	case 6:
+		return v.errorf("tags are not allowed")
+	default:
+		return v.verifySimpleOrFloat(start, info, arg)
+	}
+}
+
+func (v *canonicalVerifier) verifySimpleOrFloat(start int, info byte, arg uint64) error {
+	switch info {
+	case 24:
+		// Simple values below 32 must use the short form.
+		if arg < 32 {
+			return v.errorf("non-minimal simple value encoding")
+		}
+		return nil
+	case 25, 26, 27:
+		// Floats must use the shortest encoding which preserves their value.
+		item := v.data[start:v.offset]
+		var f float64
+		if err := decModeTrusted.Unmarshal(item, &f); err != nil {
+			return v.errorf("malformed float: %s", err)
+		}
+		canonical, err := encMode.Marshal(f)
+		if err != nil {
+			return v.errorf("malformed float: %s", err)
+		}
+		if !bytes.Equal(canonical, item) {
+			return v.errorf("non-shortest float")
+		}
+		return nil
+	default:
+		return nil
+	}
+}
+
+// canonicalKeyLess returns true iff the encoded map key a sorts before b in
+// length-first canonical order.
+func canonicalKeyLess(a, b []byte) bool {
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+	return bytes.Compare(a, b) < 0
+}
@@ -0,0 +1,150 @@
+package cbor
+
+import (
+	"encoding/hex"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyCanonical(t *testing.T) {
+	require := require.New(t)
+
+	type nested struct {
+		Values []uint64          `json:"values"`
+		Map    map[string]string `json:"map"`
+		Float  float64           `json:"float"`
+	}
+	for _, v := range []interface{}{
+		uint64(0),
+		uint64(math.MaxUint64),
+		int64(math.MinInt64),
+		"hello",
+		[]byte{1, 2, 3},
+		[]interface{}{true, false, nil},
+		0.5,
+		1.1,
+		math.Inf(1),
+		math.NaN(),
+		&nested{
+			Values: []uint64{1, 1000, 100_000, 10_000_000_000},
+			Map:    map[string]string{"a": "1", "bb": "2", "c": "3"},
+			Float:  3.25,
+		},
+	} {
+		raw := Marshal(v)
+		require.NoError(VerifyCanonical(raw), "canonical encoding of %v should verify", v)
+	}
+
+	for _, tc := range []struct {
+		msg string
+		raw string
+	}{
+		{"empty input", ""},
+		{"truncated input", "19"},
+		{"trailing data", "0000"},
+		{"non-minimal integer", "1801"},
+		{"non-minimal integer (16-bit)", "190001"},
+		{"non-minimal length", "5801ff"},
+		{"indefinite length byte string", "5f41ffff"},
+		{"indefinite length array", "9f01ff"},
+		{"unsorted map keys", "a2616201616101"},
+		{"length-first map key order", "a262616101616201"},
+		{"duplicate map keys", "a2616101616101"},
+		{"tag", "c11a514b67b0"},
+		{"non-shortest float (double)", "fb3ff8000000000000"},
+		{"non-shortest float (single)", "fa3fc00000"},
+		{"non-canonical NaN", "f97e01"},
+		{"non-canonical infinity", "fa7f800000"},
+		{"non-minimal simple value", "f814"},
+		{"reserved additional information", "1c"},
+	} {
+		raw, err := hex.DecodeString(tc.raw)
+		require.NoError(err, "hex.DecodeString")
+		err = VerifyCanonical(raw)
+		require.ErrorIs(err, ErrNonCanonical, tc.msg)
+	}
+
+	// Exceed the maximum nesting level.
+	deep := make([]byte, 0, maxCanonicalNestingLevel+2)
+	for i := 0; i < maxCanonicalNestingLevel+2; i++ {
+		deep = append(deep, 0x81)
+	}
+	deep = append(deep, 0x00)
+	require.ErrorIs(VerifyCanonical(deep), ErrNonCanonical, "deeply nested input should fail")
+}
+
+func TestUnmarshalStrict(t *testing.T) {
+	require := require.New(t)
+
+	var v uint64
+	require.NoError(UnmarshalStrict([]byte{0x18, 0x18}, &v), "canonical encoding should decode")
+	require.EqualValues(24, v)
+
+	err := UnmarshalStrict([]byte{0x19, 0x00, 0x18}, &v)
+	require.ErrorIs(err, ErrNonCanonical, "non-canonical encoding should fail")
+	require.NoError(Unmarshal([]byte{0x19, 0x00, 0x18}, &v), "default decoding should accept non-minimal integers")
+}
+
+func TestCanonicalize(t *testing.T) {
+	require := require.New(t)
+
+	for _, tc := range []struct {
+		msg       string
+		raw       string
+		canonical string
+	}{
+		{"canonical input", "a2616101616202", "a2616101616202"},
+		{"non-minimal integer", "1801", "01"},
+		{"indefinite length byte string", "5f41ffff", "41ff"},
+		{"indefinite length array", "9f01ff", "8101"},
+		{"unsorted map keys", "a2616201616101", "a2616101616201"},
+		{"length-first map key order", "a262616101616201", "a261620162616101"},
+		{"non-shortest float", "fb3ff8000000000000", "f93e00"},
+		{"nested", "a1616181a2616201616101", "a1616181a2616101616201"},
+	} {
+		raw, err := hex.DecodeString(tc.raw)
+		require.NoError(err, "hex.DecodeString")
+		expected, err := hex.DecodeString(tc.canonical)
+		require.NoError(err, "hex.DecodeString")
+
+		canonical, err := Canonicalize(raw)
+		require.NoError(err, tc.msg)
+		require.EqualValues(expected, canonical, tc.msg)
+		require.NoError(VerifyCanonical(canonical), tc.msg)
+
+		h1, err := CanonicalHash(raw)
+		require.NoError(err, tc.msg)
+		h2, err := CanonicalHash(expected)
+		require.NoError(err, tc.msg)
+		require.Equal(h1, h2, "canonical hashes should match (%s)", tc.msg)
+
+		require.True(CanonicalEqual(raw, expected), tc.msg)
+	}
+
+	for _, tc := range []struct {
+		msg string
+		raw string
+	}{
+		{"empty input", ""},
+		{"truncated input", "19"},
+		{"trailing data", "0000"},
+		{"duplicate map keys", "a2616101616101"},
+		{"tag", "c11a514b67b0"},
+	} {
+		raw, err := hex.DecodeString(tc.raw)
+		require.NoError(err, "hex.DecodeString")
+		_, err = Canonicalize(raw)
+		require.Error(err, tc.msg)
+		_, err = CanonicalHash(raw)
+		require.Error(err, tc.msg)
+		if len(raw) > 0 {
+			require.False(CanonicalEqual(raw, raw), tc.msg)
+		}
+	}
+
+	require.True(CanonicalEqual(nil, RawMessage{}), "empty blobs should be equal")
+	require.False(CanonicalEqual(Marshal(1), Marshal(2)), "different values should not be equal")
+	require.False(CanonicalEqual(Marshal("a"), Marshal([]byte("a"))), "different types should not be equal")
+}
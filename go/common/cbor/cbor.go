@@ -63,10 +63,21 @@ var (
 		MaxMapPairs:      10_000_000, // Usually limited by blob size limits anyway.
 	}
 
+	// decOptionsCanonicalize are decoding options used when converting possibly non-canonical
+	// inputs into canonical form. They are only used by the Canonicalize method.
+	decOptionsCanonicalize = cbor.DecOptions{
+		DupMapKey:        cbor.DupMapKeyEnforcedAPF,
+		TagsMd:           cbor.TagsForbidden,
+		MaxArrayElements: 10_000_000, // Usually limited by blob size limits anyway.
+		MaxMapPairs:      10_000_000, // Usually limited by blob size limits anyway.
+	}
+
 	encMode        cbor.EncMode
 	decMode        cbor.DecMode
 	decModeTrusted cbor.DecMode
 	decModeRPC     cbor.DecMode
+
+	decModeCanonicalize cbor.DecMode
 )
 
 func init() {
@@ -83,6 +94,9 @@ func init() {
 	if decModeRPC, err = decOptionsRPC.DecMode(); err != nil {
 		panic(err)
 	}
+	if decModeCanonicalize, err = decOptionsCanonicalize.DecMode(); err != nil {
+		panic(err)
+	}
 }
 
 // Marshal serializes a given type into a CBOR byte vector.
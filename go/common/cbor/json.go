@@ -0,0 +1,306 @@
+package cbor
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+)
+
+// maxToJSONNestingLevel is the maximum nesting level of arrays, maps and tags accepted by ToJSON.
+const maxToJSONNestingLevel = 64
+
+// ErrMalformed is the error returned when a serialized blob is not well-formed CBOR.
+var ErrMalformed = errors.New("cbor: malformed data")
+
+// ToJSON transcodes a CBOR byte vector containing a single data item into JSON for diagnostic
+// purposes. The transcoding is lossy and the result is not meant to be converted back.
+//
+// Byte strings are encoded as 0x-prefixed hex strings, tags are annotated as objects of the
+// form {"@tag": <number>, "@value": <content>} and non-string map keys are converted into their
+// JSON representation. Map entries are emitted in their serialized order.
+func ToJSON(data []byte) ([]byte, error) {
+	t := jsonTranscoder{data: data}
+	if err := t.transcodeItem(0); err != nil {
+		return nil, err
+	}
+	if t.offset != len(data) {
+		return nil, fmt.Errorf("%w: trailing data at offset %d", ErrMalformed, t.offset)
+	}
+	return t.out.Bytes(), nil
+}
+
+type jsonTranscoder struct {
+	data   []byte
+	offset int
+	out    bytes.Buffer
+}
+
+func (t *jsonTranscoder) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s at offset %d", ErrMalformed, fmt.Sprintf(format, args...), t.offset)
+}
+
+func (t *jsonTranscoder) read(n uint64) ([]byte, error) {
+	if uint64(len(t.data)-t.offset) < n {
+		return nil, t.errorf("unexpected end of data")
+	}
+	b := t.data[t.offset : t.offset+int(n)]
+	t.offset += int(n)
+	return b, nil
+}
+
+// readHead reads the initial byte and argument of a data item. The returned flag is set in case
+// the item uses indefinite length encoding.
+func (t *jsonTranscoder) readHead() (byte, byte, uint64, bool, error) {
+	b, err := t.read(1)
+	if err != nil {
+		return 0, 0, 0, false, err
+	}
+	major, info := b[0]>>5, b[0]&0x1f
+
+	switch {
+	case info < 24:
+		return major, info, uint64(info), false, nil
+	case info <= 27:
+		if b, err = t.read(1 << (info - 24)); err != nil {
+			return 0, 0, 0, false, err
+		}
+		var buf [8]byte
+		copy(buf[8-len(b):], b)
+		return major, info, binary.BigEndian.Uint64(buf[:]), false, nil
+	case info == 31:
+		switch major {
+		case 2, 3, 4, 5:
+			return major, info, 0, true, nil
+		default:
+			return 0, 0, 0, false, t.errorf("unexpected indefinite length")
+		}
+	default:
+		return 0, 0, 0, false, t.errorf("reserved additional information %d", info)
+	}
+}
+
+// isBreak returns true and consumes the break marker in case it is next in the input.
+func (t *jsonTranscoder) isBreak() (bool, error) {
+	if t.offset >= len(t.data) {
+		return false, t.errorf("unexpected end of data")
+	}
+	if t.data[t.offset] != 0xff {
+		return false, nil
+	}
+	t.offset++
+	return true, nil
+}
+
+func (t *jsonTranscoder) writeString(s string) {
+	// Marshalling a string can never fail.
+	raw, _ := json.Marshal(s)
+	t.out.Write(raw)
+}
+
+func (t *jsonTranscoder) transcodeItem(depth int) error {
+	if depth > maxToJSONNestingLevel {
+		return t.errorf("exceeded max nesting level")
+	}
+
+	major, info, arg, indefinite, err := t.readHead()
+	if err != nil {
+		return err
+	}
+
+	switch major {
+	case 0:
+		// Unsigned integers.
+		t.out.WriteString(strconv.FormatUint(arg, 10))
+	case 1:
+		// Negative integers.
+		n := new(big.Int).SetUint64(arg)
+		n.Add(n, big.NewInt(1))
+		n.Neg(n)
+		t.out.WriteString(n.String())
+	case 2, 3:
+		// Byte and text strings.
+		s, err := t.readString(major, arg, indefinite)
+		if err != nil {
+			return err
+		}
+		if major == 2 {
+			t.writeString("0x" + hex.EncodeToString(s))
+		} else {
+			t.writeString(string(s))
+		}
+	case 4:
+		// Arrays.
+		t.out.WriteByte('[')
+		for i := uint64(0); indefinite || i < arg; i++ {
+			if indefinite {
+				var done bool
+				if done, err = t.isBreak(); err != nil {
+					return err
+				}
+				if done {
+					break
+				}
+			}
+			if i > 0 {
+				t.out.WriteByte(',')
+			}
+			if err = t.transcodeItem(depth + 1); err != nil {
+				return err
+			}
+		}
+		t.out.WriteByte(']')
+	case 5:
+		// Maps.
+		t.out.WriteByte('{')
+		for i := uint64(0); indefinite || i < arg; i++ {
+			if indefinite {
+				var done bool
+				if done, err = t.isBreak(); err != nil {
+					return err
+				}
+				if done {
+					break
+				}
+			}
+			if i > 0 {
+				t.out.WriteByte(',')
+			}
+			if err = t.transcodeMapKey(depth + 1); err != nil {
+				return err
+			}
+			t.out.WriteByte(':')
+			if err = t.transcodeItem(depth + 1); err != nil {
+				return err
+			}
+		}
+		t.out.WriteByte('}')
+	case 6:
+		// Tags.
+		t.out.WriteString(`{"@tag":`)
+		t.out.WriteString(strconv.FormatUint(arg, 10))
+		t.out.WriteString(`,"@value":`)
+		if err = t.transcodeItem(depth + 1); err != nil {
+			return err
+		}
+		t.out.WriteByte('}')
+	default:
+		return t.transcodeSimpleOrFloat(info, arg)
+	}
+	return nil
+}
+
+func (t *jsonTranscoder) readString(major byte, arg uint64, indefinite bool) ([]byte, error) {
+	if !indefinite {
+		return t.read(arg)
+	}
+
+	// Indefinite length strings consist of definite length chunks of the same type.
+	var s []byte
+	for {
+		done, err := t.isBreak()
+		if err != nil {
+			return nil, err
+		}
+		if done {
+			return s, nil
+		}
+		chunkMajor, _, chunkLen, chunkIndefinite, err := t.readHead()
+		if err != nil {
+			return nil, err
+		}
+		if chunkMajor != major || chunkIndefinite {
+			return nil, t.errorf("malformed indefinite length string chunk")
+		}
+		chunk, err := t.read(chunkLen)
+		if err != nil {
+			return nil, err
+		}
+		s = append(s, chunk...)
+	}
+}
+
+func (t *jsonTranscoder) transcodeMapKey(depth int) error {
+	// Transcode the key first so that non-string keys can be converted into strings.
+	start := t.out.Len()
+	if err := t.transcodeItem(depth); err != nil {
+		return err
+	}
+	raw := bytes.Clone(t.out.Bytes()[start:])
+	t.out.Truncate(start)
+
+	if len(raw) > 0 && raw[0] == '"' {
+		t.out.Write(raw)
+		return nil
+	}
+	t.writeString(string(raw))
+	return nil
+}
+
+func (t *jsonTranscoder) transcodeSimpleOrFloat(info byte, arg uint64) error {
+	var f float64
+	switch info {
+	case 20:
+		t.out.WriteString("false")
+		return nil
+	case 21:
+		t.out.WriteString("true")
+		return nil
+	case 22, 23:
+		// Null and undefined.
+		t.out.WriteString("null")
+		return nil
+	case 25:
+		f = float16ToFloat64(uint16(arg))
+	case 26:
+		f = float64(math.Float32frombits(uint32(arg)))
+	case 27:
+		f = math.Float64frombits(arg)
+	default:
+		// Other simple values.
+		t.out.WriteString(`{"@simple":`)
+		t.out.WriteString(strconv.FormatUint(arg, 10))
+		t.out.WriteByte('}')
+		return nil
+	}
+
+	switch {
+	case math.IsNaN(f):
+		t.writeString("NaN")
+	case math.IsInf(f, 1):
+		t.writeString("Infinity")
+	case math.IsInf(f, -1):
+		t.writeString("-Infinity")
+	default:
+		t.out.WriteString(strconv.FormatFloat(f, 'g', -1, 64))
+	}
+	return nil
+}
+
+// float16ToFloat64 converts an IEEE 754 half-precision float into a float64.
+func float16ToFloat64(h uint16) float64 {
+	sign := 1.0
+	if h&0x8000 != 0 {
+		sign = -1.0
+	}
+	exp := int(h>>10) & 0x1f
+	mant := float64(h & 0x3ff)
+
+	switch exp {
+	case 0:
+		// Zero and subnormal numbers.
+		return sign * math.Ldexp(mant, -24)
+	case 0x1f:
+		if mant == 0 {
+			return math.Inf(int(sign))
+		}
+		return math.NaN()
+	default:
+		return sign * math.Ldexp(mant+1024, exp-25)
+	}
+}
@@ -0,0 +1,101 @@
+package cbor
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestToJSON(t *testing.T) {
+	require := require.New(t)
+
+	type inner struct {
+		Data []byte `json:"data"`
+	}
+	type test struct {
+		A uint64            `json:"a"`
+		B int64             `json:"b"`
+		C string            `json:"c"`
+		D []inner           `json:"d"`
+		E map[uint8]bool    `json:"e"`
+		F *uint64           `json:"f"`
+		G float64           `json:"g"`
+		H map[string]string `json:"h,omitempty"`
+	}
+
+	for _, tc := range []struct {
+		cbor     []byte
+		expected string
+	}{
+		{Marshal(uint64(math.MaxUint64)), `18446744073709551615`},
+		{Marshal(int64(math.MinInt64)), `-9223372036854775808`},
+		{[]byte{0x3b, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, `-18446744073709551616`},
+		{Marshal([]byte{0xde, 0xad}), `"0xdead"`},
+		{Marshal("hello \"world\""), `"hello \"world\""`},
+		{Marshal(1.5), `1.5`},
+		{Marshal(float32(0.1)), `0.10000000149011612`},
+		{Marshal(math.NaN()), `"NaN"`},
+		{Marshal(math.Inf(-1)), `"-Infinity"`},
+		{Marshal(nil), `null`},
+		{
+			Marshal(&test{A: 1, B: -2, C: "x", D: []inner{{Data: []byte{1}}}, E: map[uint8]bool{1: true}, G: 0.5}),
+			`{"a":1,"b":-2,"c":"x","d":[{"data":"0x01"}],"e":{"1":true},"f":null,"g":0.5}`,
+		},
+		// Tagged values.
+		{[]byte{0xc2, 0x41, 0x01}, `{"@tag":2,"@value":"0x01"}`},
+		// Simple values.
+		{[]byte{0xf8, 0x20}, `{"@simple":32}`},
+		// Indefinite lengths.
+		{[]byte{0x9f, 0x01, 0x02, 0xff}, `[1,2]`},
+		{[]byte{0xbf, 0x61, 0x61, 0x01, 0xff}, `{"a":1}`},
+		{[]byte{0x5f, 0x41, 0x01, 0x41, 0x02, 0xff}, `"0x0102"`},
+		// Byte string map keys.
+		{[]byte{0xa1, 0x41, 0x01, 0xf5}, `{"0x01":true}`},
+	} {
+		raw, err := ToJSON(tc.cbor)
+		require.NoError(err, "ToJSON(%s)", hex.EncodeToString(tc.cbor))
+		require.Equal(tc.expected, string(raw), "ToJSON(%s)", hex.EncodeToString(tc.cbor))
+		require.True(json.Valid(raw), "ToJSON should produce valid JSON")
+	}
+
+	for _, data := range [][]byte{
+		// Empty input.
+		{},
+		// Truncated input.
+		{0x82, 0x01},
+		// Trailing data.
+		{0x01, 0x02},
+		// Reserved additional information.
+		{0x1c},
+		// Unexpected break.
+		{0xff},
+		// Mismatched indefinite length string chunks.
+		{0x5f, 0x61, 0x61, 0xff},
+	} {
+		_, err := ToJSON(data)
+		require.ErrorIs(err, ErrMalformed, "ToJSON(%s) should fail", hex.EncodeToString(data))
+	}
+}
+
+func TestFloat16ToFloat64(t *testing.T) {
+	require := require.New(t)
+
+	for _, tc := range []struct {
+		h        uint16
+		expected float64
+	}{
+		{0x0000, 0},
+		{0x0001, 5.960464477539063e-08},
+		{0x3c00, 1},
+		{0xc000, -2},
+		{0x7bff, 65504},
+		{0x7c00, math.Inf(1)},
+		{0xfc00, math.Inf(-1)},
+	} {
+		require.Equal(tc.expected, float16ToFloat64(tc.h), "float16ToFloat64(%04x)", tc.h)
+	}
+	require.True(math.IsNaN(float16ToFloat64(0x7e00)))
+}
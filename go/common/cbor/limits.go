@@ -0,0 +1,66 @@
+package cbor
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+// Limits are resource limits applied when decoding untrusted inputs.
+//
+// A zero value for any of the limits means that the corresponding default limit for untrusted
+// inputs (as used by Unmarshal) is applied.
+type Limits struct {
+	// MaxArrayElements is the maximum number of elements of any array (must be at least 16).
+	MaxArrayElements int
+	// MaxMapPairs is the maximum number of key-value pairs of any map (must be at least 16).
+	MaxMapPairs int
+	// MaxNesting is the maximum nesting level of arrays and maps (must be between 4 and 256).
+	MaxNesting int
+}
+
+// limitsDecModes is a cache of decoding modes for different limits.
+var limitsDecModes sync.Map
+
+// UnmarshalWithLimits deserializes a CBOR byte vector into a given type, applying the given
+// resource limits instead of the default limits for untrusted inputs.
+//
+// All other decoding restrictions are the same as for Unmarshal.
+func UnmarshalWithLimits(data []byte, dst interface{}, limits Limits) error {
+	if data == nil {
+		return nil
+	}
+
+	dm, err := limits.decMode()
+	if err != nil {
+		return err
+	}
+	return dm.Unmarshal(data, dst)
+}
+
+func (l Limits) decMode() (cbor.DecMode, error) {
+	if l == (Limits{}) {
+		return decMode, nil
+	}
+	if dm, ok := limitsDecModes.Load(l); ok {
+		return dm.(cbor.DecMode), nil
+	}
+
+	opts := decOptions
+	if l.MaxArrayElements != 0 {
+		opts.MaxArrayElements = l.MaxArrayElements
+	}
+	if l.MaxMapPairs != 0 {
+		opts.MaxMapPairs = l.MaxMapPairs
+	}
+	if l.MaxNesting != 0 {
+		opts.MaxNestedLevels = l.MaxNesting
+	}
+	dm, err := opts.DecMode()
+	if err != nil {
+		return nil, fmt.Errorf("cbor: invalid limits: %w", err)
+	}
+	actual, _ := limitsDecModes.LoadOrStore(l, dm)
+	return actual.(cbor.DecMode), nil
+}
@@ -0,0 +1,72 @@
+package cbor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnmarshalWithLimits(t *testing.T) {
+	require := require.New(t)
+
+	arr := Marshal(make([]uint64, 32))
+	m := make(map[uint64]uint64)
+	for i := uint64(0); i < 32; i++ {
+		m[i] = i
+	}
+	mp := Marshal(m)
+	nested := Marshal([][][][][]uint64{{{{{1}}}}})
+
+	// Default limits.
+	var (
+		a []uint64
+		b map[uint64]uint64
+		c [][][][][]uint64
+	)
+	err := UnmarshalWithLimits(arr, &a, Limits{})
+	require.NoError(err, "UnmarshalWithLimits should succeed with default limits")
+	require.Len(a, 32)
+	err = UnmarshalWithLimits(mp, &b, Limits{})
+	require.NoError(err, "UnmarshalWithLimits should succeed with default limits")
+	require.EqualValues(m, b)
+	err = UnmarshalWithLimits(nested, &c, Limits{})
+	require.NoError(err, "UnmarshalWithLimits should succeed with default limits")
+
+	// Tighter limits.
+	limits := Limits{
+		MaxArrayElements: 16,
+		MaxMapPairs:      16,
+		MaxNesting:       4,
+	}
+	err = UnmarshalWithLimits(arr, &a, limits)
+	require.Error(err, "UnmarshalWithLimits should fail when exceeding array limits")
+	err = UnmarshalWithLimits(mp, &b, limits)
+	require.Error(err, "UnmarshalWithLimits should fail when exceeding map limits")
+	err = UnmarshalWithLimits(nested, &c, limits)
+	require.Error(err, "UnmarshalWithLimits should fail when exceeding nesting limits")
+
+	err = UnmarshalWithLimits(Marshal(make([]uint64, 16)), &a, limits)
+	require.NoError(err, "UnmarshalWithLimits should succeed within limits")
+	require.Len(a, 16)
+
+	// Same limits should reuse the cached decoding mode.
+	dm1, err := limits.decMode()
+	require.NoError(err, "decMode")
+	dm2, err := limits.decMode()
+	require.NoError(err, "decMode")
+	require.Equal(dm1, dm2)
+
+	// Other decoding restrictions should still apply.
+	type test struct {
+		A uint64
+	}
+	var s test
+	err = UnmarshalWithLimits(Marshal(map[string]uint64{"A": 1, "B": 2}), &s, limits)
+	require.Error(err, "UnmarshalWithLimits should reject unknown fields")
+
+	// Invalid limits.
+	err = UnmarshalWithLimits(arr, &a, Limits{MaxArrayElements: 1})
+	require.Error(err, "UnmarshalWithLimits should fail with invalid limits")
+	err = UnmarshalWithLimits(arr, &a, Limits{MaxNesting: 1000})
+	require.Error(err, "UnmarshalWithLimits should fail with invalid limits")
+}
@@ -0,0 +1,83 @@
+package cbor
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// MigrationFunc upgrades a versioned serialized blob to a newer version. The returned blob must be
+// a versioned serialized blob of the target version.
+type MigrationFunc func(data []byte) ([]byte, error)
+
+type migrationChain struct {
+	latest     uint16
+	migrations map[uint16]migration
+}
+
+type migration struct {
+	toV uint16
+	fn  MigrationFunc
+}
+
+var migrations sync.Map
+
+// RegisterMigration registers a function that upgrades versioned serialized blobs of type T from
+// version fromV to version toV. The latest version of T is the highest registered target version.
+//
+// This method may only be called during initialization and panics in case of invalid or
+// duplicate registrations.
+func RegisterMigration[T any](fromV, toV uint16, fn MigrationFunc) {
+	if fromV >= toV || toV == invalidVersion {
+		panic(fmt.Sprintf("cbor: invalid migration from version %d to version %d", fromV, toV))
+	}
+
+	typ := reflect.TypeFor[T]()
+	v, _ := migrations.LoadOrStore(typ, &migrationChain{
+		migrations: make(map[uint16]migration),
+	})
+	chain := v.(*migrationChain)
+	if _, ok := chain.migrations[fromV]; ok {
+		panic(fmt.Sprintf("cbor: migration of %s from version %d already registered", typ, fromV))
+	}
+	chain.migrations[fromV] = migration{toV: toV, fn: fn}
+	chain.latest = max(chain.latest, toV)
+}
+
+// DecodeLatest deserializes a versioned serialized blob into the latest version of T, running any
+// registered migrations needed to upgrade the blob from its serialized version.
+//
+// In case T implements a custom deserializer which calls DecodeLatest, migrations should be
+// registered for (and DecodeLatest called with) an alias type to avoid infinite recursion.
+func DecodeLatest[T any](data []byte, dst *T) error {
+	typ := reflect.TypeFor[T]()
+	v, ok := migrations.Load(typ)
+	if !ok {
+		return fmt.Errorf("cbor: no migrations registered for %s", typ)
+	}
+	chain := v.(*migrationChain)
+
+	version, err := GetVersion(data)
+	if err != nil {
+		return err
+	}
+	for version != chain.latest {
+		m, ok := chain.migrations[version]
+		if !ok {
+			return fmt.Errorf("%w: %d", ErrUnsupportedVersion, version)
+		}
+		if data, err = m.fn(data); err != nil {
+			return fmt.Errorf("cbor: failed to migrate %s from version %d: %w", typ, version, err)
+		}
+
+		// Make sure the migration actually produced the target version to prevent loops.
+		if version, err = GetVersion(data); err != nil {
+			return err
+		}
+		if version != m.toV {
+			return fmt.Errorf("cbor: migration of %s produced version %d (expected: %d)", typ, version, m.toV)
+		}
+	}
+
+	return Unmarshal(data, dst)
+}
@@ -0,0 +1,108 @@
+package cbor
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type migrationTestV1 struct {
+	Versioned
+	Name string `json:"name"`
+}
+
+type migrationTestV2 struct {
+	Versioned
+	FirstName string `json:"first_name"`
+}
+
+type migrationTestV3 struct {
+	Versioned
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name,omitempty"`
+}
+
+func TestMigrations(t *testing.T) {
+	require := require.New(t)
+
+	RegisterMigration[migrationTestV3](1, 2, func(data []byte) ([]byte, error) {
+		var v1 migrationTestV1
+		if err := Unmarshal(data, &v1); err != nil {
+			return nil, err
+		}
+		if v1.Name == "" {
+			return nil, fmt.Errorf("missing name")
+		}
+		return Marshal(&migrationTestV2{Versioned: NewVersioned(2), FirstName: v1.Name}), nil
+	})
+	RegisterMigration[migrationTestV3](2, 3, func(data []byte) ([]byte, error) {
+		var v2 migrationTestV2
+		if err := Unmarshal(data, &v2); err != nil {
+			return nil, err
+		}
+		return Marshal(&migrationTestV3{Versioned: NewVersioned(3), FirstName: v2.FirstName}), nil
+	})
+	RegisterMigration[migrationTestV3](4, 5, func([]byte) ([]byte, error) {
+		// Broken migration which does not produce the target version.
+		return Marshal(&migrationTestV1{Versioned: NewVersioned(4)}), nil
+	})
+
+	require.Panics(func() {
+		RegisterMigration[migrationTestV3](1, 3, nil)
+	}, "duplicate migrations should panic")
+	require.Panics(func() {
+		RegisterMigration[migrationTestV3](3, 3, nil)
+	}, "migrations to the same version should panic")
+
+	expected := migrationTestV3{Versioned: NewVersioned(5), FirstName: "Zarathustra"}
+	for _, data := range [][]byte{
+		Marshal(&migrationTestV1{Versioned: NewVersioned(1), Name: "Zarathustra"}),
+		Marshal(&migrationTestV2{Versioned: NewVersioned(2), FirstName: "Zarathustra"}),
+		Marshal(&migrationTestV3{Versioned: NewVersioned(3), FirstName: "Zarathustra"}),
+	} {
+		var dst migrationTestV3
+		err := DecodeLatest(data, &dst)
+		// The chain is broken at version 3 as there is no migration to version 4.
+		require.ErrorIs(err, ErrUnsupportedVersion, "DecodeLatest should fail with a broken chain")
+	}
+
+	RegisterMigration[migrationTestV3](3, 4, func(data []byte) ([]byte, error) {
+		var v3 migrationTestV3
+		if err := Unmarshal(data, &v3); err != nil {
+			return nil, err
+		}
+		v3.V = 4
+		return Marshal(&v3), nil
+	})
+
+	// Migration 4 -> 5 produces the wrong version.
+	var dst migrationTestV3
+	err := DecodeLatest(Marshal(&migrationTestV3{Versioned: NewVersioned(3)}), &dst)
+	require.ErrorContains(err, "produced version 4 (expected: 5)")
+
+	// Latest version should decode directly.
+	raw := Marshal(&expected)
+	err = DecodeLatest(raw, &dst)
+	require.NoError(err, "DecodeLatest")
+	require.Equal(expected, dst)
+
+	// Unsupported versions.
+	for _, v := range []uint16{0, 6} {
+		err = DecodeLatest(Marshal(&migrationTestV3{Versioned: NewVersioned(v)}), &dst)
+		require.ErrorIs(err, ErrUnsupportedVersion, "DecodeLatest should fail with unsupported versions")
+	}
+
+	// Missing version.
+	err = DecodeLatest(Marshal(map[string]string{"first_name": "x"}), &dst)
+	require.ErrorIs(err, ErrInvalidVersion, "DecodeLatest should fail with missing versions")
+
+	// Failing migrations.
+	err = DecodeLatest(Marshal(&migrationTestV1{Versioned: NewVersioned(1)}), &dst)
+	require.ErrorContains(err, "missing name")
+
+	// Unregistered types.
+	var v1 migrationTestV1
+	err = DecodeLatest(Marshal(&migrationTestV1{Versioned: NewVersioned(1)}), &v1)
+	require.ErrorContains(err, "no migrations registered")
+}
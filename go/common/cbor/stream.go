@@ -0,0 +1,162 @@
+package cbor
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+var (
+	// ErrArrayLength is the error returned when the number of streamed array
+	// elements does not match the declared array length.
+	ErrArrayLength = errors.New("cbor: array length mismatch")
+
+	// ErrNotArray is the error returned when a streamed value is not an array.
+	ErrNotArray = errors.New("cbor: value is not an array")
+)
+
+// ArrayEncoder encodes a definite-length array element by element without
+// materializing the whole array in memory.
+//
+// The result is the same as serializing a slice of all elements at once.
+type ArrayEncoder struct {
+	enc       *cbor.Encoder
+	remaining uint64
+}
+
+// NewArrayEncoder writes the header of an array with the given number of
+// elements and returns an encoder for its elements.
+func NewArrayEncoder(w io.Writer, length uint64) (*ArrayEncoder, error) {
+	if _, err := w.Write(encodeHead(4, length)); err != nil {
+		return nil, err
+	}
+	return &ArrayEncoder{
+		enc:       encMode.NewEncoder(w),
+		remaining: length,
+	}, nil
+}
+
+// Encode encodes the next array element.
+func (e *ArrayEncoder) Encode(v interface{}) error {
+	if e.remaining == 0 {
+		return fmt.Errorf("%w: too many elements", ErrArrayLength)
+	}
+	if err := e.enc.Encode(v); err != nil {
+		return err
+	}
+	e.remaining--
+	return nil
+}
+
+// Close verifies that all declared array elements have been encoded.
+func (e *ArrayEncoder) Close() error {
+	if e.remaining != 0 {
+		return fmt.Errorf("%w: %d elements missing", ErrArrayLength, e.remaining)
+	}
+	return nil
+}
+
+// ArrayDecoder decodes a definite-length array element by element without
+// materializing the whole array in memory.
+type ArrayDecoder struct {
+	dec       *cbor.Decoder
+	length    uint64
+	remaining uint64
+}
+
+// NewArrayDecoder reads the header of an array and returns a decoder for its
+// elements.
+//
+// Elements are decoded using the default (untrusted) decoding options.
+func NewArrayDecoder(r io.Reader) (*ArrayDecoder, error) {
+	br := bufio.NewReader(r)
+	length, err := decodeArrayHead(br)
+	if err != nil {
+		return nil, err
+	}
+	if length > uint64(decOptions.MaxArrayElements) {
+		return nil, fmt.Errorf("%w: too many elements (%d)", ErrArrayLength, length)
+	}
+	return &ArrayDecoder{
+		dec:       decMode.NewDecoder(br),
+		length:    length,
+		remaining: length,
+	}, nil
+}
+
+// Len returns the declared number of array elements.
+func (d *ArrayDecoder) Len() uint64 {
+	return d.length
+}
+
+// Decode decodes the next array element. It returns io.EOF once all elements
+// have been decoded.
+func (d *ArrayDecoder) Decode(dst interface{}) error {
+	if d.remaining == 0 {
+		return io.EOF
+	}
+	if err := d.dec.Decode(dst); err != nil {
+		if errors.Is(err, io.EOF) {
+			return io.ErrUnexpectedEOF
+		}
+		return err
+	}
+	d.remaining--
+	return nil
+}
+
+func encodeHead(major byte, arg uint64) []byte {
+	switch {
+	case arg < 24:
+		return []byte{major<<5 | byte(arg)}
+	case arg <= 0xff:
+		return []byte{major<<5 | 24, byte(arg)}
+	case arg <= 0xffff:
+		return binary.BigEndian.AppendUint16([]byte{major<<5 | 25}, uint16(arg))
+	case arg <= 0xffffffff:
+		return binary.BigEndian.AppendUint32([]byte{major<<5 | 26}, uint32(arg))
+	default:
+		return binary.BigEndian.AppendUint64([]byte{major<<5 | 27}, arg)
+	}
+}
+
+func decodeArrayHead(r io.Reader) (uint64, error) {
+	var ib [1]byte
+	if _, err := io.ReadFull(r, ib[:]); err != nil {
+		return 0, err
+	}
+	if ib[0]>>5 != 4 {
+		return 0, ErrNotArray
+	}
+
+	var n int
+	switch info := ib[0] & 0x1f; {
+	case info < 24:
+		return uint64(info), nil
+	case info == 24:
+		n = 1
+	case info == 25:
+		n = 2
+	case info == 26:
+		n = 4
+	case info == 27:
+		n = 8
+	case info == 31:
+		return 0, fmt.Errorf("%w: indefinite length", ErrArrayLength)
+	default:
+		return 0, ErrNotArray
+	}
+
+	var buf [8]byte
+	if _, err := io.ReadFull(r, buf[8-n:]); err != nil {
+		if errors.Is(err, io.EOF) {
+			return 0, io.ErrUnexpectedEOF
+		}
+		return 0, err
+	}
+	return binary.BigEndian.Uint64(buf[:]), nil
+}
@@ -0,0 +1,73 @@
+package cbor
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestArrayEncoderDecoder(t *testing.T) {
+	require := require.New(t)
+
+	type item struct {
+		ID   uint64 `json:"id"`
+		Data []byte `json:"data"`
+	}
+
+	for _, n := range []uint64{0, 1, 23, 24, 255, 256, 1000} {
+		items := make([]item, 0, n)
+		for i := uint64(0); i < n; i++ {
+			items = append(items, item{ID: i, Data: []byte{byte(i)}})
+		}
+
+		var buf bytes.Buffer
+		enc, err := NewArrayEncoder(&buf, n)
+		require.NoError(err, "NewArrayEncoder")
+		for i := range items {
+			err = enc.Encode(&items[i])
+			require.NoError(err, "Encode")
+		}
+		require.NoError(enc.Close(), "Close")
+		require.Equal(Marshal(items), buf.Bytes(), "streamed encoding should match Marshal")
+
+		dec, err := NewArrayDecoder(&buf)
+		require.NoError(err, "NewArrayDecoder")
+		require.EqualValues(n, dec.Len())
+		decoded := make([]item, 0, n)
+		for {
+			var it item
+			err = dec.Decode(&it)
+			if err == io.EOF {
+				break
+			}
+			require.NoError(err, "Decode")
+			decoded = append(decoded, it)
+		}
+		require.Equal(items, decoded, "decoded items should match")
+	}
+}
+
+func TestArrayEncoderDecoderErrors(t *testing.T) {
+	require := require.New(t)
+
+	var buf bytes.Buffer
+	enc, err := NewArrayEncoder(&buf, 1)
+	require.NoError(err, "NewArrayEncoder")
+	require.ErrorIs(enc.Close(), ErrArrayLength, "Close should fail with missing elements")
+	require.NoError(enc.Encode(1), "Encode")
+	require.ErrorIs(enc.Encode(2), ErrArrayLength, "Encode should fail with too many elements")
+
+	_, err = NewArrayDecoder(bytes.NewReader(Marshal(42)))
+	require.ErrorIs(err, ErrNotArray, "NewArrayDecoder should fail on non-arrays")
+
+	_, err = NewArrayDecoder(bytes.NewReader([]byte{0x9f, 0x01, 0xff}))
+	require.Error(err, "NewArrayDecoder should fail on indefinite length arrays")
+
+	dec, err := NewArrayDecoder(bytes.NewReader([]byte{0x82, 0x01}))
+	require.NoError(err, "NewArrayDecoder")
+	var x uint64
+	require.NoError(dec.Decode(&x), "Decode")
+	require.ErrorIs(dec.Decode(&x), io.ErrUnexpectedEOF, "Decode should fail on truncated input")
+}
@@ -2,6 +2,7 @@ package cbor
 
 import (
 	"errors"
+	"fmt"
 	"math"
 
 	"github.com/fxamacker/cbor/v2"
@@ -14,6 +15,10 @@ var (
 	// serialized blob is either missing, or has an invalid version.
 	ErrInvalidVersion = errors.New("cbor: missing or invalid version")
 
+	// ErrUnsupportedVersion is the error returned when a versioned
+	// serialized blob has a version for which no decoder is available.
+	ErrUnsupportedVersion = errors.New("cbor: unsupported version")
+
 	decOptionsVersioned = decOptions
 
 	decModeVersioned cbor.DecMode
@@ -47,6 +52,50 @@ func NewVersioned(v uint16) Versioned {
 	return Versioned{V: v}
 }
 
+// UnmarshalVersioned deserializes a versioned serialized blob by dispatching
+// to the decoder registered for its version.
+func UnmarshalVersioned[T any](data []byte, decoders map[uint16]func([]byte) (T, error)) (T, error) {
+	var empty T
+	v, err := GetVersion(data)
+	if err != nil {
+		return empty, err
+	}
+	decoder, ok := decoders[v]
+	if !ok {
+		return empty, fmt.Errorf("%w: %d", ErrUnsupportedVersion, v)
+	}
+	return decoder(data)
+}
+
+// MarshalVersioned serializes the given body, which must serialize into a
+// map, together with the specified version.
+//
+// The result is the same as serializing a structure that embeds Versioned
+// alongside the fields of the body.
+func MarshalVersioned(v uint16, body any) ([]byte, error) {
+	if v == invalidVersion {
+		return nil, ErrInvalidVersion
+	}
+
+	raw, err := encMode.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]RawMessage
+	if err = decModeTrusted.Unmarshal(raw, &fields); err != nil {
+		return nil, fmt.Errorf("cbor: versioned body must serialize into a map: %w", err)
+	}
+	if _, ok := fields["v"]; ok {
+		return nil, fmt.Errorf("cbor: versioned body must not contain a version field")
+	}
+	if fields == nil {
+		fields = make(map[string]RawMessage)
+	}
+	fields["v"] = Marshal(v)
+
+	return encMode.Marshal(fields)
+}
+
 func init() {
 	// Use the untrusted decode options, but ignore unknown fields.
 	decOptionsVersioned.ExtraReturnErrors = cbor.ExtraDecErrorNone
@@ -35,3 +35,67 @@ func TestVersioned(t *testing.T) {
 	require.NoError(err, "versioned blobs should deserialize")
 	require.Equal(testVersion, version, "the version should be correct")
 }
+
+func TestVersionedHelpers(t *testing.T) {
+	require := require.New(t)
+
+	type bodyV1 struct {
+		A string `json:"a"`
+	}
+	type bodyV2 struct {
+		Versioned
+		A string `json:"a"`
+		B uint64 `json:"b,omitempty"`
+	}
+
+	raw, err := MarshalVersioned(1, &bodyV1{A: "old"})
+	require.NoError(err, "MarshalVersioned")
+	require.Equal(Marshal(&struct {
+		Versioned
+		bodyV1
+	}{NewVersioned(1), bodyV1{A: "old"}}), raw, "encoding should match embedded Versioned")
+
+	_, err = MarshalVersioned(2, &bodyV2{A: "new"})
+	require.Error(err, "MarshalVersioned should fail on bodies with a version field")
+	_, err = MarshalVersioned(1, "not a map")
+	require.Error(err, "MarshalVersioned should fail on non-map bodies")
+	_, err = MarshalVersioned(invalidVersion, &bodyV1{})
+	require.ErrorIs(err, ErrInvalidVersion, "MarshalVersioned should fail on invalid versions")
+
+	decoders := map[uint16]func([]byte) (*bodyV2, error){
+		1: func(data []byte) (*bodyV2, error) {
+			var b struct {
+				Versioned
+				bodyV1
+			}
+			if err := Unmarshal(data, &b); err != nil {
+				return nil, err
+			}
+			return &bodyV2{Versioned: NewVersioned(2), A: b.A}, nil
+		},
+		2: func(data []byte) (*bodyV2, error) {
+			var b bodyV2
+			if err := Unmarshal(data, &b); err != nil {
+				return nil, err
+			}
+			return &b, nil
+		},
+	}
+
+	b, err := UnmarshalVersioned(raw, decoders)
+	require.NoError(err, "UnmarshalVersioned v1")
+	require.Equal(&bodyV2{Versioned: NewVersioned(2), A: "old"}, b)
+
+	v2 := &bodyV2{Versioned: NewVersioned(2), A: "new", B: 42}
+	b, err = UnmarshalVersioned(Marshal(v2), decoders)
+	require.NoError(err, "UnmarshalVersioned v2")
+	require.Equal(v2, b)
+
+	raw, err = MarshalVersioned(3, &bodyV1{A: "future"})
+	require.NoError(err, "MarshalVersioned")
+	_, err = UnmarshalVersioned(raw, decoders)
+	require.ErrorIs(err, ErrUnsupportedVersion, "UnmarshalVersioned should fail on unsupported versions")
+
+	_, err = UnmarshalVersioned(Marshal(&bodyV1{A: "unversioned"}), decoders)
+	require.ErrorIs(err, ErrInvalidVersion, "UnmarshalVersioned should fail on missing versions")
+}
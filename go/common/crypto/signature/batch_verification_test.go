@@ -2,6 +2,7 @@ package signature
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/oasisprotocol/curve25519-voi/primitives/ed25519"
@@ -65,7 +66,49 @@ func TestBatchVerifier(t *testing.T) {
 	})
 }
 
-func genTestKeypair(t *testing.T) (PublicKey, ed25519.PrivateKey) {
+func BenchmarkBatchVerifier(b *testing.B) {
+	ctx := NewContext("batch verifier benchmark context")
+
+	for _, n := range []int{1, 10, 100, 1000} {
+		pubKeys := make([]PublicKey, 0, n)
+		msgs := make([][]byte, 0, n)
+		sigs := make([][]byte, 0, n)
+		for i := 0; i < n; i++ {
+			msg := []byte(fmt.Sprintf("benchmark message %d", i))
+			data, err := PrepareSignerMessage(ctx, msg)
+			require.NoError(b, err, "PrepareSignerMessage")
+
+			pubKey, privKey := genTestKeypair(b)
+			pubKeys = append(pubKeys, pubKey)
+			msgs = append(msgs, msg)
+			sigs = append(sigs, ed25519.Sign(privKey, data))
+		}
+
+		b.Run(fmt.Sprintf("Single/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				for j := range pubKeys {
+					if !pubKeys[j].Verify(ctx, msgs[j], sigs[j]) {
+						b.Fatal("signature verification failed")
+					}
+				}
+			}
+		})
+
+		b.Run(fmt.Sprintf("Batch/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				v := NewBatchVerifierWithCapacity(n)
+				for j := range pubKeys {
+					v.Add(pubKeys[j], ctx, msgs[j], sigs[j])
+				}
+				if allOk, _ := v.Verify(); !allOk {
+					b.Fatal("batch signature verification failed")
+				}
+			}
+		})
+	}
+}
+
+func genTestKeypair(t testing.TB) (PublicKey, ed25519.PrivateKey) {
 	// Can't use the memory signer because of import loops.
 	rawPubKey, privKey, err := ed25519.GenerateKey(nil)
 	require.NoError(t, err, "GenerateKey")
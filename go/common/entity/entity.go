@@ -9,10 +9,13 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"slices"
 
+	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
 	"github.com/oasisprotocol/oasis-core/go/common/prettyprint"
 )
 
@@ -27,17 +30,23 @@ var (
 	testEntitySigner signature.Signer
 
 	_ prettyprint.PrettyPrinter = (*SignedEntity)(nil)
+	_ prettyprint.PrettyPrinter = (*MultiSignedEntity)(nil)
 )
 
 const (
 	// LatestDescriptorVersion is the latest descriptor version that should be
 	// used for all new descriptors. Using earlier versions may be rejected.
-	LatestDescriptorVersion = 2
+	LatestDescriptorVersion = 3
 
 	// MinDescriptorVersion is the minimum descriptor version that is allowed.
 	MinDescriptorVersion = 1
 	// MaxDescriptorVersion is the maximum descriptor version that is allowed.
 	MaxDescriptorVersion = LatestDescriptorVersion
+
+	// MaxSignerSetSize is the maximum number of signers in an entity signer set.
+	MaxSignerSetSize = 16
+	// MaxNodeAuthorizationRuntimes is the maximum number of runtimes in a node authorization.
+	MaxNodeAuthorizationRuntimes = 32
 )
 
 // Entity represents an entity that controls one or more Nodes and or
@@ -52,46 +61,139 @@ type Entity struct { // nolint: maligned
 	// will sign the descriptor with the node signing key rather than the
 	// entity signing key.
 	Nodes []signature.PublicKey `json:"nodes,omitempty"`
+
+	// NodeAuthorizations optionally restrict the roles and runtimes for which
+	// nodes from the node list are authorized. Nodes without an authorization
+	// are authorized for all roles and runtimes.
+	NodeAuthorizations []*NodeAuthorization `json:"node_authorizations,omitempty"`
+
+	// SignerSet is the optional M-of-N signer set that controls the entity. When set, changes
+	// to the entity descriptor must be multi-signed by the signer set.
+	SignerSet *SignerSet `json:"signer_set,omitempty"`
 }
 
-// UnmarshalCBOR is a custom deserializer that handles both v1 and v2 Entity
-// structures.  A v1 structure is converted to v2 seamlessly if the field
-// AllowEntitySignedNodes is false or missing, otherwise an error is returned.
-func (e *Entity) UnmarshalCBOR(data []byte) error {
-	// Determine Entity structure version.
-	v, err := cbor.GetVersion(data)
-	if err != nil {
-		return err
+// NodeAuthorization is a role-scoped authorization of an entity's node.
+type NodeAuthorization struct {
+	// Node is the node identity key.
+	Node signature.PublicKey `json:"node"`
+
+	// Roles are the roles the node is authorized for.
+	Roles node.RolesMask `json:"roles"`
+
+	// Runtimes are the runtimes the node is authorized for. If empty, the node
+	// is authorized for all runtimes.
+	Runtimes []common.Namespace `json:"runtimes,omitempty"`
+}
+
+// ValidateBasic performs basic node authorization validity checks.
+func (a *NodeAuthorization) ValidateBasic() error {
+	if !a.Node.IsValid() {
+		return fmt.Errorf("malformed node id: %s", a.Node)
 	}
-	switch v {
-	case 1:
-		// Old version had an extra field that was used only for debugging/tests.
-		type EntityV1 struct { // nolint: maligned
-			cbor.Versioned
-			ID                     signature.PublicKey   `json:"id"`
-			Nodes                  []signature.PublicKey `json:"nodes,omitempty"`
-			AllowEntitySignedNodes bool                  `json:"allow_entity_signed_nodes,omitempty"`
+	if a.Roles.IsEmptyRole() || a.Roles&node.RoleReserved != 0 {
+		return fmt.Errorf("invalid roles: %s", a.Roles)
+	}
+	if len(a.Runtimes) > MaxNodeAuthorizationRuntimes {
+		return fmt.Errorf("too many runtimes (max: %d got: %d)", MaxNodeAuthorizationRuntimes, len(a.Runtimes))
+	}
+	seen := make(map[common.Namespace]struct{})
+	for _, id := range a.Runtimes {
+		if _, ok := seen[id]; ok {
+			return fmt.Errorf("duplicate runtime: %s", id)
 		}
-		var ev1 EntityV1
-		if err = cbor.Unmarshal(data, &ev1); err != nil {
-			return err
+		seen[id] = struct{}{}
+	}
+	return nil
+}
+
+// Authorizes checks whether the authorization permits the given roles and runtimes.
+func (a *NodeAuthorization) Authorizes(roles node.RolesMask, runtimes []common.Namespace) bool {
+	if roles&^a.Roles != 0 {
+		return false
+	}
+	if len(a.Runtimes) == 0 {
+		return true
+	}
+	for _, id := range runtimes {
+		if !slices.Contains(a.Runtimes, id) {
+			return false
 		}
-		// Make sure that AllowEntitySignedNodes is not enabled.
-		if ev1.AllowEntitySignedNodes {
-			return fmt.Errorf("entity descriptor must have allow_entity_signed_nodes set to false")
+	}
+	return true
+}
+
+// SignerSet is an M-of-N set of signers that controls an entity.
+type SignerSet struct {
+	// Signers are the public keys that can authorize entity descriptor changes.
+	Signers []signature.PublicKey `json:"signers"`
+
+	// Threshold is the minimum number of signers that must authorize a change.
+	Threshold uint8 `json:"threshold"`
+}
+
+// ValidateBasic performs basic signer set validity checks.
+func (s *SignerSet) ValidateBasic() error {
+	if len(s.Signers) == 0 {
+		return fmt.Errorf("no signers")
+	}
+	if len(s.Signers) > MaxSignerSetSize {
+		return fmt.Errorf("too many signers (max: %d got: %d)", MaxSignerSetSize, len(s.Signers))
+	}
+	if s.Threshold == 0 {
+		return fmt.Errorf("threshold cannot be zero")
+	}
+	if int(s.Threshold) > len(s.Signers) {
+		return fmt.Errorf("threshold is larger than the number of signers")
+	}
+
+	seen := make(map[signature.PublicKey]struct{})
+	for _, pk := range s.Signers {
+		if !pk.IsValid() {
+			return fmt.Errorf("malformed signer: %s", pk)
+		}
+		if _, ok := seen[pk]; ok {
+			return fmt.Errorf("duplicate signer: %s", pk)
+		}
+		seen[pk] = struct{}{}
+	}
+	return nil
+}
+
+// Contains checks whether the signer set contains the given public key.
+func (s *SignerSet) Contains(pk signature.PublicKey) bool {
+	return slices.Contains(s.Signers, pk)
+}
+
+// Verify checks whether the given signatures are sufficient to authorize a change.
+//
+// Note: This does not verify the signatures.
+func (s *SignerSet) Verify(signatures []signature.Signature) bool {
+	remaining := make(map[signature.PublicKey]struct{})
+	for _, pk := range s.Signers {
+		remaining[pk] = struct{}{}
+	}
+	var count int
+	for _, sig := range signatures {
+		if _, ok := remaining[sig.PublicKey]; !ok {
+			continue // Ignore unknown or duplicate signers.
+		}
+		delete(remaining, sig.PublicKey)
+
+		count++
+		if count >= int(s.Threshold) {
+			return true
 		}
-		// Convert into new format.
-		e.Versioned = cbor.NewVersioned(2)
-		e.ID = ev1.ID
-		e.Nodes = ev1.Nodes
-		return nil
-	case 2:
-		// New version, call the default unmarshaler.
-		type ev2 Entity
-		return cbor.Unmarshal(data, (*ev2)(e))
-	default:
-		return fmt.Errorf("invalid entity descriptor version: %v", v)
 	}
+	return false
+}
+
+// latestEntity is an alias of Entity without the custom deserializer.
+type latestEntity Entity
+
+// UnmarshalCBOR is a custom deserializer that migrates older Entity
+// structures to the latest version.
+func (e *Entity) UnmarshalCBOR(data []byte) error {
+	return cbor.DecodeLatest(data, (*latestEntity)(e))
 }
 
 // ValidateBasic performs basic descriptor validity checks.
@@ -116,6 +218,27 @@ func (e *Entity) ValidateBasic(strictVersion bool) error {
 			)
 		}
 	}
+	if e.SignerSet != nil {
+		if err := e.SignerSet.ValidateBasic(); err != nil {
+			return fmt.Errorf("invalid entity signer set: %w", err)
+		}
+	}
+	if len(e.NodeAuthorizations) > 0 && v < 3 {
+		return fmt.Errorf("node authorizations require entity descriptor version 3")
+	}
+	seen := make(map[signature.PublicKey]struct{})
+	for _, auth := range e.NodeAuthorizations {
+		if auth == nil {
+			return fmt.Errorf("invalid node authorization: missing authorization")
+		}
+		if err := auth.ValidateBasic(); err != nil {
+			return fmt.Errorf("invalid node authorization: %w", err)
+		}
+		if _, ok := seen[auth.Node]; ok {
+			return fmt.Errorf("invalid node authorization: duplicate node: %s", auth.Node)
+		}
+		seen[auth.Node] = struct{}{}
+	}
 	return nil
 }
 
@@ -129,6 +252,20 @@ func (e *Entity) HasNode(id signature.PublicKey) bool {
 	return false
 }
 
+// AuthorizesNode checks if the given node is in this entity's node whitelist
+// and is authorized to register with the given roles and runtimes.
+func (e *Entity) AuthorizesNode(id signature.PublicKey, roles node.RolesMask, runtimes []common.Namespace) bool {
+	if !e.HasNode(id) {
+		return false
+	}
+	for _, auth := range e.NodeAuthorizations {
+		if auth.Node.Equal(id) {
+			return auth.Authorizes(roles, runtimes)
+		}
+	}
+	return true
+}
+
 // String returns a string representation of itself.
 func (e Entity) String() string {
 	return "<Entity id=" + e.ID.String() + ">"
@@ -195,6 +332,7 @@ func GenerateWithSigner(baseDir string, signer signature.Signer, template *Entit
 	}
 	if template != nil {
 		ent.Nodes = template.Nodes
+		ent.NodeAuthorizations = template.NodeAuthorizations
 	}
 
 	if err := ent.Save(baseDir); err != nil {
@@ -265,9 +403,98 @@ func SignEntity(signer signature.Signer, context signature.Context, entity *Enti
 	}, nil
 }
 
+// MultiSignedEntity is a blob signed by multiple members of an entity's signer set containing a
+// CBOR-serialized Entity.
+type MultiSignedEntity struct {
+	signature.MultiSigned
+}
+
+// Open first verifies the blob signatures and then unmarshals the blob.
+func (s *MultiSignedEntity) Open(context signature.Context, entity *Entity) error { // nolint: interfacer
+	return s.MultiSigned.Open(context, entity)
+}
+
+// PrettyPrint writes a pretty-printed representation of the type
+// to the given writer.
+func (s MultiSignedEntity) PrettyPrint(ctx context.Context, prefix string, w io.Writer) {
+	pt, err := s.PrettyType()
+	if err != nil {
+		fmt.Fprintf(w, "%s<error: %s>\n", prefix, err)
+		return
+	}
+
+	pt.(prettyprint.PrettyPrinter).PrettyPrint(ctx, prefix, w)
+}
+
+// PrettyType returns a representation of the type that can be used for pretty printing.
+func (s MultiSignedEntity) PrettyType() (interface{}, error) {
+	var e Entity
+	if err := cbor.Unmarshal(s.MultiSigned.Blob, &e); err != nil {
+		return nil, fmt.Errorf("malformed signed blob: %w", err)
+	}
+	return signature.NewPrettyMultiSigned(s.MultiSigned, e)
+}
+
+// MultiSignEntity serializes the Entity and signs the result using all of the given signers.
+func MultiSignEntity(signers []signature.Signer, context signature.Context, entity *Entity) (*MultiSignedEntity, error) {
+	signed, err := signature.SignMultiSigned(signers, context, entity)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MultiSignedEntity{
+		MultiSigned: *signed,
+	}, nil
+}
+
 func init() {
 	testEntitySigner = memorySigner.NewTestSigner("ekiden test entity key seed")
 
 	testEntity.Versioned = cbor.NewVersioned(LatestDescriptorVersion)
 	testEntity.ID = testEntitySigner.Public()
+
+	// A v1 structure is converted to v2 seamlessly if the field AllowEntitySignedNodes is false or
+	// missing, otherwise an error is returned.
+	cbor.RegisterMigration[latestEntity](1, 2, func(data []byte) ([]byte, error) {
+		// Old version had an extra field that was used only for debugging/tests.
+		type EntityV1 struct { // nolint: maligned
+			cbor.Versioned
+			ID                     signature.PublicKey   `json:"id"`
+			Nodes                  []signature.PublicKey `json:"nodes,omitempty"`
+			AllowEntitySignedNodes bool                  `json:"allow_entity_signed_nodes,omitempty"`
+		}
+		var ev1 EntityV1
+		if err := cbor.Unmarshal(data, &ev1); err != nil {
+			return nil, err
+		}
+		// Make sure that AllowEntitySignedNodes is not enabled.
+		if ev1.AllowEntitySignedNodes {
+			return nil, fmt.Errorf("entity descriptor must have allow_entity_signed_nodes set to false")
+		}
+		// Convert into new format.
+		type EntityV2 struct {
+			cbor.Versioned
+			ID    signature.PublicKey   `json:"id"`
+			Nodes []signature.PublicKey `json:"nodes,omitempty"`
+		}
+		return cbor.Marshal(&EntityV2{
+			Versioned: cbor.NewVersioned(2),
+			ID:        ev1.ID,
+			Nodes:     ev1.Nodes,
+		}), nil
+	})
+
+	// A v2 structure is converted to v3 seamlessly as all nodes are authorized for all roles and
+	// runtimes in the absence of node authorizations.
+	cbor.RegisterMigration[latestEntity](2, 3, func(data []byte) ([]byte, error) {
+		var ev2 latestEntity
+		if err := cbor.Unmarshal(data, &ev2); err != nil {
+			return nil, err
+		}
+		if len(ev2.NodeAuthorizations) > 0 {
+			return nil, fmt.Errorf("node authorizations require entity descriptor version 3")
+		}
+		ev2.Versioned = cbor.NewVersioned(3)
+		return cbor.Marshal(&ev2), nil
+	})
 }
@@ -1,13 +1,16 @@
 package entity
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
 )
 
 func TestEntityDescriptorVersioning(t *testing.T) {
@@ -30,14 +33,14 @@ func TestEntityDescriptorVersioning(t *testing.T) {
 	require.NoError(cbor.Unmarshal(cbor.Marshal(ev1), &uv1t1), "v1 unmarshal with no AllowEntitySignedNodes field should pass")
 	require.EqualValues(ev1.ID, uv1t1.ID)
 	require.EqualValues(ev1.Nodes, uv1t1.Nodes)
-	require.EqualValues(cbor.NewVersioned(2), uv1t1.Versioned)
+	require.EqualValues(cbor.NewVersioned(3), uv1t1.Versioned)
 
 	var uv1t2 Entity
 	ev1.AllowEntitySignedNodes = false
 	require.NoError(cbor.Unmarshal(cbor.Marshal(ev1), &uv1t2), "v1 unmarshal with AllowEntitySignedNodes field set to false should pass")
 	require.EqualValues(ev1.ID, uv1t2.ID)
 	require.EqualValues(ev1.Nodes, uv1t2.Nodes)
-	require.EqualValues(cbor.NewVersioned(2), uv1t2.Versioned)
+	require.EqualValues(cbor.NewVersioned(3), uv1t2.Versioned)
 
 	var uv1t3 Entity
 	ev1.AllowEntitySignedNodes = true
@@ -56,5 +59,93 @@ func TestEntityDescriptorVersioning(t *testing.T) {
 	require.NoError(cbor.Unmarshal(cbor.Marshal(ev2), &uv2t1), "v2 unmarshal should pass")
 	require.EqualValues(ev2.ID, uv2t1.ID)
 	require.EqualValues(ev2.Nodes, uv2t1.Nodes)
-	require.EqualValues(cbor.NewVersioned(2), uv2t1.Versioned)
+	require.EqualValues(cbor.NewVersioned(3), uv2t1.Versioned)
+
+	var uv2t2 Entity
+	ev2.NodeAuthorizations = []*NodeAuthorization{{Node: k2n1.Public(), Roles: node.RoleValidator}}
+	require.Error(cbor.Unmarshal(cbor.Marshal(ev2), &uv2t2), "v2 unmarshal with node authorizations should fail")
+}
+
+func TestNodeAuthorizations(t *testing.T) {
+	require := require.New(t)
+
+	var rt1, rt2 common.Namespace
+	require.NoError(rt1.UnmarshalHex("8000000000000000000000000000000000000000000000000000000000000001"))
+	require.NoError(rt2.UnmarshalHex("8000000000000000000000000000000000000000000000000000000000000002"))
+
+	k := memorySigner.NewTestSigner("common/entity: node authorizations entity")
+	n1 := memorySigner.NewTestSigner("common/entity: node authorizations node 1")
+	n2 := memorySigner.NewTestSigner("common/entity: node authorizations node 2")
+	n3 := memorySigner.NewTestSigner("common/entity: node authorizations node 3")
+	ent := Entity{
+		Versioned: cbor.NewVersioned(LatestDescriptorVersion),
+		ID:        k.Public(),
+		Nodes:     []signature.PublicKey{n1.Public(), n2.Public()},
+		NodeAuthorizations: []*NodeAuthorization{
+			{Node: n1.Public(), Roles: node.RoleComputeWorker, Runtimes: []common.Namespace{rt1}},
+		},
+	}
+	require.NoError(ent.ValidateBasic(true), "ValidateBasic")
+
+	// Blanket authorization for nodes without an authorization.
+	require.True(ent.AuthorizesNode(n2.Public(), node.RoleValidator|node.RoleComputeWorker, []common.Namespace{rt1, rt2}))
+	// Role-scoped authorization.
+	require.True(ent.AuthorizesNode(n1.Public(), node.RoleComputeWorker, []common.Namespace{rt1}))
+	require.False(ent.AuthorizesNode(n1.Public(), node.RoleValidator, nil), "unauthorized role")
+	require.False(ent.AuthorizesNode(n1.Public(), node.RoleComputeWorker|node.RoleValidator, []common.Namespace{rt1}), "unauthorized role")
+	require.False(ent.AuthorizesNode(n1.Public(), node.RoleComputeWorker, []common.Namespace{rt1, rt2}), "unauthorized runtime")
+	// Nodes not in the node list are never authorized.
+	require.False(ent.AuthorizesNode(n3.Public(), node.RoleValidator, nil))
+
+	for _, invalid := range [][]*NodeAuthorization{
+		{nil},
+		{{Node: n1.Public()}},
+		{{Node: n1.Public(), Roles: node.RoleReserved}},
+		{{Node: n1.Public(), Roles: node.RoleValidator, Runtimes: []common.Namespace{rt1, rt1}}},
+		{{Node: n1.Public(), Roles: node.RoleValidator}, {Node: n1.Public(), Roles: node.RoleComputeWorker}},
+	} {
+		invalidEnt := ent
+		invalidEnt.NodeAuthorizations = invalid
+		require.Error(invalidEnt.ValidateBasic(true), "ValidateBasic should fail for invalid node authorizations")
+	}
+
+	v2Ent := ent
+	v2Ent.Versioned = cbor.NewVersioned(2)
+	require.Error(v2Ent.ValidateBasic(false), "node authorizations should require descriptor version 3")
+}
+
+func TestSignerSet(t *testing.T) {
+	require := require.New(t)
+
+	signers := make([]signature.Signer, 3)
+	for i := range signers {
+		signers[i] = memorySigner.NewTestSigner(fmt.Sprintf("common/entity: signer set signer %d", i))
+	}
+	other := memorySigner.NewTestSigner("common/entity: signer set other signer")
+
+	ss := SignerSet{
+		Signers:   []signature.PublicKey{signers[0].Public(), signers[1].Public(), signers[2].Public()},
+		Threshold: 2,
+	}
+	require.NoError(ss.ValidateBasic(), "ValidateBasic")
+
+	for _, invalid := range []SignerSet{
+		{Threshold: 1},
+		{Signers: ss.Signers, Threshold: 0},
+		{Signers: ss.Signers, Threshold: 4},
+		{Signers: []signature.PublicKey{signers[0].Public(), signers[0].Public()}, Threshold: 1},
+	} {
+		require.Error(invalid.ValidateBasic(), "ValidateBasic should fail for invalid signer sets")
+	}
+
+	sigCtx := signature.NewContext("common/entity: signer set test")
+	sign := func(signers ...signature.Signer) []signature.Signature {
+		ms, err := signature.SignMultiSigned(signers, sigCtx, "test")
+		require.NoError(err, "SignMultiSigned")
+		return ms.Signatures
+	}
+	require.True(ss.Verify(sign(signers[0], signers[2])), "threshold signatures should verify")
+	require.False(ss.Verify(sign(signers[0])), "less than threshold signatures should not verify")
+	require.False(ss.Verify(sign(signers[0], signers[0])), "duplicate signatures should not count")
+	require.False(ss.Verify(sign(signers[0], other)), "unknown signatures should not count")
 }
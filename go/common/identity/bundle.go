@@ -0,0 +1,338 @@
+package identity
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/oasisprotocol/deoxysii"
+	"golang.org/x/crypto/argon2"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/mrae/api"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	fileSigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/file"
+)
+
+const (
+	// BundleVersion is the current identity bundle structure version.
+	BundleVersion = 1
+
+	// BundleKeySize is the size of an externally provided bundle encryption
+	// key (e.g., a data key obtained from a KMS).
+	BundleKeySize = deoxysii.KeySize
+
+	// BundleSchemePassphrase is the bundle encryption scheme where the
+	// encryption key is derived from a passphrase via Argon2id.
+	BundleSchemePassphrase = "passphrase"
+	// BundleSchemeKey is the bundle encryption scheme where the encryption
+	// key is provided externally.
+	BundleSchemeKey = "key"
+
+	bundleArgon2Time    = 3
+	bundleArgon2Memory  = 64 * 1024
+	bundleArgon2Threads = 4
+	bundleArgon2SaltLen = 32
+
+	bundleKeyFilePerm  = 0o600
+	bundleCertFilePerm = 0o644
+)
+
+// ErrBundleDecryptionFailed is the error returned when an identity bundle
+// fails to decrypt, either due to an invalid passphrase/key or due to the
+// bundle being corrupted.
+var ErrBundleDecryptionFailed = errors.New("identity: bundle decryption failed (invalid key or corrupted bundle)")
+
+// bundleFile describes a file that is part of the identity bundle.
+type bundleFile struct {
+	name     string
+	perm     os.FileMode
+	optional bool
+}
+
+// bundleFiles are all the files that are part of the node identity bundle.
+var bundleFiles = []bundleFile{
+	{fileSigner.FileIdentityKey, bundleKeyFilePerm, false},
+	{NodeKeyPubFilename, bundleKeyFilePerm, false},
+	{fileSigner.FileP2PKey, bundleKeyFilePerm, false},
+	{fileSigner.FileP2PStaticEntropy, bundleKeyFilePerm, true},
+	{P2PKeyPubFilename, bundleKeyFilePerm, false},
+	{fileSigner.FileConsensusKey, bundleKeyFilePerm, false},
+	{ConsensusKeyPubFilename, bundleKeyFilePerm, false},
+	{fileSigner.FileVRFKey, bundleKeyFilePerm, false},
+	{VRFKeyPubFilename, bundleKeyFilePerm, false},
+	{tlsKeyFilename, bundleKeyFilePerm, false},
+	{tlsCertFilename, bundleCertFilePerm, true},
+	{tlsSentryClientKeyFilename, bundleKeyFilePerm, true},
+	{tlsSentryClientCertFilename, bundleCertFilePerm, true},
+}
+
+// BundleEncryption is the identity bundle encryption configuration.
+//
+// Exactly one of the fields must be set.
+type BundleEncryption struct {
+	// Passphrase is the passphrase used to derive the encryption key.
+	Passphrase []byte
+	// Key is an externally provided encryption key of BundleKeySize bytes.
+	Key []byte
+}
+
+func (e *BundleEncryption) scheme() (string, error) {
+	switch {
+	case e == nil:
+		return "", fmt.Errorf("identity: bundle encryption not configured")
+	case len(e.Passphrase) > 0 && len(e.Key) > 0:
+		return "", fmt.Errorf("identity: bundle passphrase and key are mutually exclusive")
+	case len(e.Passphrase) > 0:
+		return BundleSchemePassphrase, nil
+	case len(e.Key) > 0:
+		if len(e.Key) != BundleKeySize {
+			return "", fmt.Errorf("identity: malformed bundle key (expected %d bytes, got %d)", BundleKeySize, len(e.Key))
+		}
+		return BundleSchemeKey, nil
+	default:
+		return "", fmt.Errorf("identity: bundle passphrase or key must be set")
+	}
+}
+
+// BundleKDF are the passphrase key derivation function parameters.
+type BundleKDF struct {
+	// Salt is the Argon2id salt.
+	Salt []byte `json:"salt"`
+	// Time is the Argon2id number of passes.
+	Time uint32 `json:"time"`
+	// Memory is the Argon2id memory size in KiB.
+	Memory uint32 `json:"memory"`
+	// Threads is the Argon2id degree of parallelism.
+	Threads uint8 `json:"threads"`
+}
+
+// BundleHeader is the unencrypted, but authenticated, identity bundle header.
+type BundleHeader struct {
+	cbor.Versioned
+
+	// NodeID is the public key of the node identity contained in the bundle.
+	NodeID signature.PublicKey `json:"node_id"`
+	// Scheme is the bundle encryption scheme.
+	Scheme string `json:"scheme"`
+	// KDF are the key derivation function parameters for passphrase-based
+	// encryption.
+	KDF *BundleKDF `json:"kdf,omitempty"`
+}
+
+// Bundle is an encrypted node identity bundle, used to migrate a node
+// identity between hosts.
+type Bundle struct {
+	BundleHeader
+
+	// Nonce is the AEAD nonce.
+	Nonce []byte `json:"nonce"`
+	// Ciphertext is the encrypted bundle payload.
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+type bundlePayload struct {
+	Files map[string][]byte `json:"files"`
+}
+
+func (b *Bundle) deriveKey(enc *BundleEncryption) ([]byte, error) {
+	scheme, err := enc.scheme()
+	if err != nil {
+		return nil, err
+	}
+	if scheme != b.Scheme {
+		return nil, fmt.Errorf("identity: bundle encryption scheme mismatch (expected: %s got: %s)", b.Scheme, scheme)
+	}
+
+	switch b.Scheme {
+	case BundleSchemePassphrase:
+		if b.KDF == nil {
+			return nil, fmt.Errorf("identity: missing bundle KDF parameters")
+		}
+		return argon2.IDKey(enc.Passphrase, b.KDF.Salt, b.KDF.Time, b.KDF.Memory, b.KDF.Threads, BundleKeySize), nil
+	case BundleSchemeKey:
+		return append([]byte{}, enc.Key...), nil
+	default:
+		return nil, fmt.Errorf("identity: unsupported bundle encryption scheme: %s", b.Scheme)
+	}
+}
+
+// ExportBundle exports the file-backed node identity stored in the given data
+// directory into an encrypted identity bundle.
+func ExportBundle(dataDir string, enc *BundleEncryption) (*Bundle, error) {
+	scheme, err := enc.scheme()
+	if err != nil {
+		return nil, err
+	}
+
+	// Make sure the identity is complete and consistent before exporting it.
+	factory, err := fileSigner.NewFactory(dataDir, RequiredSignerRoles...)
+	if err != nil {
+		return nil, err
+	}
+	id, err := Load(dataDir, factory)
+	if err != nil {
+		return nil, fmt.Errorf("identity: failed to load identity: %w", err)
+	}
+
+	payload := bundlePayload{
+		Files: make(map[string][]byte),
+	}
+	for _, f := range bundleFiles {
+		var data []byte
+		data, err = os.ReadFile(filepath.Join(dataDir, f.name))
+		switch {
+		case err == nil:
+		case os.IsNotExist(err) && f.optional:
+			continue
+		default:
+			return nil, fmt.Errorf("identity: failed to read '%s': %w", f.name, err)
+		}
+		payload.Files[f.name] = data
+	}
+
+	b := &Bundle{
+		BundleHeader: BundleHeader{
+			Versioned: cbor.NewVersioned(BundleVersion),
+			NodeID:    id.NodeSigner.Public(),
+			Scheme:    scheme,
+		},
+		Nonce: make([]byte, deoxysii.NonceSize),
+	}
+	if scheme == BundleSchemePassphrase {
+		b.KDF = &BundleKDF{
+			Salt:    make([]byte, bundleArgon2SaltLen),
+			Time:    bundleArgon2Time,
+			Memory:  bundleArgon2Memory,
+			Threads: bundleArgon2Threads,
+		}
+		if _, err = rand.Read(b.KDF.Salt); err != nil {
+			return nil, err
+		}
+	}
+	if _, err = rand.Read(b.Nonce); err != nil {
+		return nil, err
+	}
+
+	k, err := b.deriveKey(enc)
+	if err != nil {
+		return nil, err
+	}
+	defer api.Bzero(k)
+	aead, err := deoxysii.New(k)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext := cbor.Marshal(payload)
+	defer api.Bzero(plaintext)
+	b.Ciphertext = aead.Seal(nil, b.Nonce, plaintext, cbor.Marshal(b.BundleHeader))
+
+	return b, nil
+}
+
+// ImportBundle decrypts the given identity bundle and writes the node identity
+// into the given data directory.
+//
+// Unless overwrite is set, importing fails in case any of the identity files
+// already exist in the data directory. When overwriting, optional identity
+// files which are not part of the bundle are removed.
+func ImportBundle(dataDir string, b *Bundle, enc *BundleEncryption, overwrite bool) error {
+	if b.V != BundleVersion {
+		return fmt.Errorf("identity: unsupported bundle version: %d", b.V)
+	}
+
+	k, err := b.deriveKey(enc)
+	if err != nil {
+		return err
+	}
+	defer api.Bzero(k)
+	aead, err := deoxysii.New(k)
+	if err != nil {
+		return err
+	}
+	if len(b.Nonce) != aead.NonceSize() {
+		return fmt.Errorf("identity: malformed bundle nonce")
+	}
+
+	plaintext, err := aead.Open(nil, b.Nonce, b.Ciphertext, cbor.Marshal(b.BundleHeader))
+	if err != nil {
+		return ErrBundleDecryptionFailed
+	}
+	defer api.Bzero(plaintext)
+
+	var payload bundlePayload
+	if err = cbor.Unmarshal(plaintext, &payload); err != nil {
+		return fmt.Errorf("identity: malformed bundle payload: %w", err)
+	}
+
+	// Validate the payload before touching the data directory.
+	known := make(map[string]bool)
+	for _, f := range bundleFiles {
+		known[f.name] = true
+		if _, ok := payload.Files[f.name]; !ok && !f.optional {
+			return fmt.Errorf("identity: bundle is missing '%s'", f.name)
+		}
+		if overwrite {
+			continue
+		}
+		if _, err = os.Stat(filepath.Join(dataDir, f.name)); err == nil {
+			return fmt.Errorf("identity: refusing to overwrite existing '%s'", f.name)
+		}
+	}
+	for name := range payload.Files {
+		if !known[name] {
+			return fmt.Errorf("identity: bundle contains unexpected file '%s'", name)
+		}
+	}
+
+	// Stage the identity in a temporary directory and make sure it loads and
+	// matches the bundle header before moving it into place.
+	stagingDir, err := os.MkdirTemp(dataDir, ".identity-import-")
+	if err != nil {
+		return fmt.Errorf("identity: failed to create staging directory: %w", err)
+	}
+	defer os.RemoveAll(stagingDir)
+
+	for _, f := range bundleFiles {
+		data, ok := payload.Files[f.name]
+		if !ok {
+			continue
+		}
+		if err = os.WriteFile(filepath.Join(stagingDir, f.name), data, f.perm); err != nil {
+			return fmt.Errorf("identity: failed to write '%s': %w", f.name, err)
+		}
+	}
+
+	factory, err := fileSigner.NewFactory(stagingDir, RequiredSignerRoles...)
+	if err != nil {
+		return err
+	}
+	id, err := Load(stagingDir, factory)
+	if err != nil {
+		return fmt.Errorf("identity: failed to load imported identity: %w", err)
+	}
+	if !id.NodeSigner.Public().Equal(b.NodeID) {
+		return fmt.Errorf("identity: imported node identity does not match bundle (expected: %s got: %s)", b.NodeID, id.NodeSigner.Public())
+	}
+
+	for _, f := range bundleFiles {
+		src := filepath.Join(stagingDir, f.name)
+		dst := filepath.Join(dataDir, f.name)
+		if _, err = os.Stat(src); os.IsNotExist(err) {
+			// Remove stale optional files of the previous identity (e.g., a TLS certificate
+			// that does not match the imported TLS key).
+			if err = os.Remove(dst); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("identity: failed to remove stale '%s': %w", f.name, err)
+			}
+			continue
+		}
+		if err = os.Rename(src, dst); err != nil {
+			return fmt.Errorf("identity: failed to move '%s' into place: %w", f.name, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,117 @@
+package identity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	fileSigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/file"
+)
+
+func TestBundle(t *testing.T) {
+	dataDir := t.TempDir()
+	factory, err := fileSigner.NewFactory(dataDir, RequiredSignerRoles...)
+	require.NoError(t, err, "NewFactory")
+	identity, err := LoadOrGenerate(dataDir, factory)
+	require.NoError(t, err, "LoadOrGenerate")
+
+	for _, tc := range []struct {
+		name     string
+		enc      *BundleEncryption
+		wrongEnc *BundleEncryption
+	}{
+		{
+			name:     "Passphrase",
+			enc:      &BundleEncryption{Passphrase: []byte("correct horse battery staple")},
+			wrongEnc: &BundleEncryption{Passphrase: []byte("incorrect horse battery staple")},
+		},
+		{
+			name:     "Key",
+			enc:      &BundleEncryption{Key: make([]byte, BundleKeySize)},
+			wrongEnc: &BundleEncryption{Key: append(make([]byte, BundleKeySize-1), 0x01)},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require := require.New(t)
+
+			bundle, err := ExportBundle(dataDir, tc.enc)
+			require.NoError(err, "ExportBundle")
+			require.EqualValues(identity.NodeSigner.Public(), bundle.NodeID)
+
+			// Round-trip the bundle through serialization.
+			var decBundle Bundle
+			err = cbor.Unmarshal(cbor.Marshal(bundle), &decBundle)
+			require.NoError(err, "Unmarshal")
+
+			importDir := t.TempDir()
+			err = ImportBundle(importDir, &decBundle, tc.wrongEnc, false)
+			require.ErrorIs(err, ErrBundleDecryptionFailed, "ImportBundle should fail with wrong key")
+
+			tampered := decBundle
+			tampered.NodeID = identity.P2PSigner.Public()
+			err = ImportBundle(importDir, &tampered, tc.enc, false)
+			require.ErrorIs(err, ErrBundleDecryptionFailed, "ImportBundle should fail with tampered header")
+
+			err = ImportBundle(importDir, &decBundle, tc.enc, false)
+			require.NoError(err, "ImportBundle")
+
+			importFactory, err := fileSigner.NewFactory(importDir, RequiredSignerRoles...)
+			require.NoError(err, "NewFactory")
+			imported, err := Load(importDir, importFactory)
+			require.NoError(err, "Load")
+			require.EqualValues(identity.NodeSigner.Public(), imported.NodeSigner.Public())
+			require.EqualValues(identity.P2PSigner.Public(), imported.P2PSigner.Public())
+			require.EqualValues(identity.ConsensusSigner.Public(), imported.ConsensusSigner.Public())
+			require.EqualValues(identity.VRFSigner.Public(), imported.VRFSigner.Public())
+			require.EqualValues(identity.TLSSigner.Public(), imported.TLSSigner.Public())
+
+			err = ImportBundle(importDir, &decBundle, tc.enc, false)
+			require.Error(err, "ImportBundle should refuse to overwrite an existing identity")
+			err = ImportBundle(importDir, &decBundle, tc.enc, true)
+			require.NoError(err, "ImportBundle with overwrite")
+		})
+	}
+}
+
+func TestBundleOverwrite(t *testing.T) {
+	require := require.New(t)
+
+	dataDir := t.TempDir()
+	factory, err := fileSigner.NewFactory(dataDir, RequiredSignerRoles...)
+	require.NoError(err, "NewFactory")
+	identity, err := LoadOrGenerate(dataDir, factory)
+	require.NoError(err, "LoadOrGenerate")
+
+	enc := &BundleEncryption{Key: make([]byte, BundleKeySize)}
+	bundle, err := ExportBundle(dataDir, enc)
+	require.NoError(err, "ExportBundle")
+
+	// Populate the import directory with a different identity.
+	importDir := t.TempDir()
+	otherFactory, err := fileSigner.NewFactory(importDir, RequiredSignerRoles...)
+	require.NoError(err, "NewFactory")
+	other, err := LoadOrGenerate(importDir, otherFactory)
+	require.NoError(err, "LoadOrGenerate")
+
+	err = ImportBundle(importDir, bundle, enc, true)
+	require.NoError(err, "ImportBundle with overwrite")
+
+	importFactory, err := fileSigner.NewFactory(importDir, RequiredSignerRoles...)
+	require.NoError(err, "NewFactory")
+	imported, err := Load(importDir, importFactory)
+	require.NoError(err, "Load")
+	require.EqualValues(identity.NodeSigner.Public(), imported.NodeSigner.Public())
+	require.EqualValues(identity.TLSSigner.Public(), imported.TLSSigner.Public())
+
+	// No optional files of the previous identity should remain.
+	require.NotEqualValues(other.TLSCertificate.Certificate, imported.TLSCertificate.Certificate, "TLS certificate should be replaced")
+	require.NotEqualValues(other.TLSSentryClientCertificate.Certificate, imported.TLSSentryClientCertificate.Certificate, "sentry client certificate should be replaced")
+
+	entropy, err := identity.P2PSigner.(signature.StaticEntropyProvider).StaticEntropy()
+	require.NoError(err, "StaticEntropy")
+	importedEntropy, err := imported.P2PSigner.(signature.StaticEntropyProvider).StaticEntropy()
+	require.NoError(err, "StaticEntropy")
+	require.EqualValues(entropy, importedEntropy, "P2P static entropy should be replaced")
+}
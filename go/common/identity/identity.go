@@ -196,6 +196,33 @@ func doLoadOrGenerate(dataDir string, signerFactory signature.SignerFactory, sho
 	}, nil
 }
 
+// Ephemeral returns a copy of the identity with the node, P2P, consensus and VRF signers replaced
+// by freshly generated in-memory signers that are never persisted.
+//
+// This is used when the identity loaded from the data directory must not be used to act on the
+// network, for example when the data directory is a copy of one used by another node.
+func (i *Identity) Ephemeral() (*Identity, error) {
+	factory := memory.NewFactory()
+	ephemeral := *i
+	for _, v := range []struct {
+		role   signature.SignerRole
+		signer *signature.Signer
+	}{
+		{signature.SignerNode, &ephemeral.NodeSigner},
+		{signature.SignerP2P, &ephemeral.P2PSigner},
+		{signature.SignerConsensus, &ephemeral.ConsensusSigner},
+		{signature.SignerVRF, &ephemeral.VRFSigner},
+	} {
+		signer, err := factory.Generate(v.role, rand.Reader)
+		if err != nil {
+			return nil, fmt.Errorf("identity: failed to generate ephemeral signer: %w", err)
+		}
+		*v.signer = signer
+	}
+
+	return &ephemeral, nil
+}
+
 func ephemeralKeyPath(dataDir, generation string) string {
 	return filepath.Join(dataDir, fmt.Sprintf("%s%s.pem", tlsEphemeralKeyBaseFilename, generation))
 }
@@ -81,3 +81,32 @@ func TestLoadOrGenerate(t *testing.T) {
 	require.NotEqual(t, identity3.TLSSentryClientCertificate, identity4.TLSSentryClientCertificate)
 	require.EqualValues(t, identity4.TLSSentryClientCertificate.PrivateKey, identity4.TLSSentryClientCertificate.PrivateKey)
 }
+
+func TestEphemeral(t *testing.T) {
+	dataDir, err := os.MkdirTemp("", "oasis-identity-test_")
+	require.NoError(t, err, "create data dir")
+	defer os.RemoveAll(dataDir)
+
+	factory, err := fileSigner.NewFactory(dataDir, RequiredSignerRoles...)
+	require.NoError(t, err, "NewFactory")
+
+	identity, err := LoadOrGenerate(dataDir, factory)
+	require.NoError(t, err, "LoadOrGenerate")
+
+	ephemeral, err := identity.Ephemeral()
+	require.NoError(t, err, "Ephemeral")
+	require.NotEqual(t, identity.NodeSigner.Public(), ephemeral.NodeSigner.Public())
+	require.NotEqual(t, identity.P2PSigner.Public(), ephemeral.P2PSigner.Public())
+	require.NotEqual(t, identity.ConsensusSigner.Public(), ephemeral.ConsensusSigner.Public())
+	require.NotEqual(t, identity.VRFSigner.Public(), ephemeral.VRFSigner.Public())
+	require.Equal(t, identity.TLSSigner, ephemeral.TLSSigner)
+	require.Equal(t, identity.TLSCertificate, ephemeral.TLSCertificate)
+
+	// The persisted identity must remain unchanged.
+	identity2, err := Load(dataDir, factory)
+	require.NoError(t, err, "Load")
+	require.EqualValues(t, identity.NodeSigner, identity2.NodeSigner)
+	require.EqualValues(t, identity.P2PSigner, identity2.P2PSigner)
+	require.EqualValues(t, identity.ConsensusSigner, identity2.ConsensusSigner)
+	require.EqualValues(t, identity.VRFSigner, identity2.VRFSigner)
+}
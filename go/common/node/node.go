@@ -63,6 +63,12 @@ const (
 	maxNodeDescriptorVersion = LatestNodeDescriptorVersion
 
 	nodeSoftwareVersionMaxLength = 128
+
+	// nodeMetadataKeyMaxLength is the maximum length of a node metadata key.
+	nodeMetadataKeyMaxLength = 64
+
+	// MaxP2PRelays is the maximum number of circuit relays a node can advertise.
+	MaxP2PRelays = 4
 )
 
 // Node represents public connectivity information about an Oasis node.
@@ -101,6 +107,9 @@ type Node struct { // nolint: maligned
 
 	// SoftwareVersion is the node's oasis-node software version.
 	SoftwareVersion SoftwareVersion `json:"software_version,omitempty"`
+
+	// Metadata is the optional operator-provided node metadata.
+	Metadata Metadata `json:"metadata,omitempty"`
 }
 
 // nodeV2 represents (to be deprecated) V2 version of node descriptors.
@@ -173,6 +182,48 @@ func (sw SoftwareVersion) ValidateBasic() error {
 	return nil
 }
 
+const (
+	// MetadataKeyContact is the node metadata key for the operator contact.
+	MetadataKeyContact = "contact"
+	// MetadataKeyWebsite is the node metadata key for the operator website.
+	MetadataKeyWebsite = "website"
+	// MetadataKeyHardwareClass is the node metadata key for the node hardware class.
+	MetadataKeyHardwareClass = "hardware_class"
+	// MetadataKeyRegion is the node metadata key for the region the node is located in.
+	MetadataKeyRegion = "region"
+)
+
+// Metadata is the operator-provided node metadata (e.g., operator contact, website, hardware class,
+// region) attached to the node descriptor.
+//
+// Since metadata is part of the node descriptor, it is signed together with the rest of the
+// descriptor. Size limits and the allowed schema are enforced by the registry.
+type Metadata map[string]string
+
+// Size returns the size of the metadata, computed as the sum of the lengths of all keys and values.
+func (m Metadata) Size() uint64 {
+	var size uint64
+	for k, v := range m {
+		size += uint64(len(k) + len(v))
+	}
+	return size
+}
+
+// ValidateBasic performs basic node metadata validity checks.
+func (m Metadata) ValidateBasic() error {
+	for k := range m {
+		if l := len(k); l == 0 || l > nodeMetadataKeyMaxLength {
+			return fmt.Errorf("malformed node metadata: invalid key length (max length: %d, length: %d)", nodeMetadataKeyMaxLength, l)
+		}
+		for _, c := range k {
+			if (c < 'a' || c > 'z') && (c < '0' || c > '9') && c != '_' && c != '.' && c != '-' {
+				return fmt.Errorf("malformed node metadata: invalid key '%s'", k)
+			}
+		}
+	}
+	return nil
+}
+
 // RolesMask is Oasis node roles bitmask.
 type RolesMask uint32
 
@@ -304,30 +355,13 @@ func (m *RolesMask) UnmarshalText(text []byte) error {
 	return nil
 }
 
-// UnmarshalCBOR is a custom deserializer that handles both V2 and V3 Node descriptors.
-func (n *Node) UnmarshalCBOR(data []byte) error {
-	// Determine Entity structure version.
-	v, err := cbor.GetVersion(data)
-	if err != nil {
-		return err
-	}
-	switch v {
-	case 2:
-		// Version 2 has an extra supported role (consensus-rpc) and TLS addresses.
-		var nv2 nodeV2
-		if err := cbor.Unmarshal(data, &nv2); err != nil {
-			return err
-		}
+// latestNode is an alias of Node without the custom deserializer.
+type latestNode Node
 
-		*n = *nv2.ToV3()
-		return nil
-	case 3:
-		// New version, call the default unmarshaler.
-		type nv3 Node
-		return cbor.Unmarshal(data, (*nv3)(n))
-	default:
-		return fmt.Errorf("invalid node descriptor version: %d", v)
-	}
+// UnmarshalCBOR is a custom deserializer that migrates older Node descriptors to the latest
+// version.
+func (n *Node) UnmarshalCBOR(data []byte) error {
+	return cbor.DecodeLatest(data, (*latestNode)(n))
 }
 
 // ValidateBasic performs basic descriptor validity checks.
@@ -357,6 +391,16 @@ func (n *Node) ValidateBasic(strictVersion bool) error {
 		return err
 	}
 
+	// Validate metadata.
+	if err := n.Metadata.ValidateBasic(); err != nil {
+		return err
+	}
+
+	// Validate P2P relays.
+	if err := n.P2P.ValidateBasic(); err != nil {
+		return err
+	}
+
 	// Make sure that a node has at least one valid role.
 	switch {
 	case n.Roles == 0:
@@ -480,6 +524,31 @@ type P2PInfo struct {
 
 	// Addresses is the list of addresses at which the node can be reached.
 	Addresses []Address `json:"addresses"`
+
+	// Relays is the list of P2P identifiers of circuit relays through which the node can be
+	// reached when it is not directly reachable (e.g., because it is behind NAT).
+	Relays []signature.PublicKey `json:"relays,omitempty"`
+}
+
+// ValidateBasic performs basic P2P information validity checks.
+func (p *P2PInfo) ValidateBasic() error {
+	if l := len(p.Relays); l > MaxP2PRelays {
+		return fmt.Errorf("too many P2P relays (max: %d got: %d)", MaxP2PRelays, l)
+	}
+	seen := make(map[signature.PublicKey]struct{}, len(p.Relays))
+	for _, relay := range p.Relays {
+		if !relay.IsValid() {
+			return fmt.Errorf("invalid P2P relay ID: %s", relay)
+		}
+		if relay.Equal(p.ID) {
+			return fmt.Errorf("node cannot use itself as a P2P relay")
+		}
+		if _, ok := seen[relay]; ok {
+			return fmt.Errorf("duplicate P2P relay: %s", relay)
+		}
+		seen[relay] = struct{}{}
+	}
+	return nil
 }
 
 // ConsensusInfo contains information for connecting to this node as a
@@ -664,3 +733,14 @@ func MultiSignNode(signers []signature.Signer, context signature.Context, node *
 		MultiSigned: *multiSigned,
 	}, nil
 }
+
+func init() {
+	// Version 2 has an extra supported role (consensus-rpc) and TLS addresses.
+	cbor.RegisterMigration[latestNode](2, 3, func(data []byte) ([]byte, error) {
+		var nv2 nodeV2
+		if err := cbor.Unmarshal(data, &nv2); err != nil {
+			return nil, err
+		}
+		return cbor.Marshal((*latestNode)(nv2.ToV3())), nil
+	})
+}
@@ -480,3 +480,55 @@ func TestNodeSoftwareVersion(t *testing.T) {
 	sw = SoftwareVersion(strings.Repeat("a", 1000))
 	require.Error(sw.ValidateBasic(), "invalid software version")
 }
+
+func TestNodeMetadata(t *testing.T) {
+	require := require.New(t)
+
+	var md Metadata
+	require.NoError(md.ValidateBasic(), "empty metadata is allowed")
+	require.EqualValues(0, md.Size())
+
+	md = Metadata{
+		MetadataKeyContact:       "ops@example.com",
+		MetadataKeyWebsite:       "https://example.com",
+		MetadataKeyHardwareClass: "sgx-xl",
+		MetadataKeyRegion:        "eu-west",
+	}
+	require.NoError(md.ValidateBasic(), "well-known metadata keys are allowed")
+	require.EqualValues(81, md.Size())
+
+	for _, key := range []string{"", "Contact", "contact info", strings.Repeat("a", 65)} {
+		md = Metadata{key: "value"}
+		require.Error(md.ValidateBasic(), "invalid metadata key should be rejected")
+	}
+}
+
+func TestP2PInfoRelays(t *testing.T) {
+	require := require.New(t)
+
+	var id, relay1, relay2 signature.PublicKey
+	require.NoError(id.UnmarshalHex("0000000000000000000000000000000000000000000000000000000000000001"))
+	require.NoError(relay1.UnmarshalHex("0000000000000000000000000000000000000000000000000000000000000002"))
+	require.NoError(relay2.UnmarshalHex("0000000000000000000000000000000000000000000000000000000000000003"))
+
+	p := P2PInfo{ID: id}
+	require.NoError(p.ValidateBasic(), "no relays should be allowed")
+
+	p.Relays = []signature.PublicKey{relay1, relay2}
+	require.NoError(p.ValidateBasic(), "valid relays should be allowed")
+
+	p.Relays = []signature.PublicKey{relay1, relay1}
+	require.Error(p.ValidateBasic(), "duplicate relays should be rejected")
+
+	p.Relays = []signature.PublicKey{id}
+	require.Error(p.ValidateBasic(), "node should not be its own relay")
+
+	var invalid signature.PublicKey
+	require.NoError(invalid.UnmarshalHex("0000000000000000000000000000000000000000000000000000000000000004"))
+	require.NoError(invalid.Blacklist())
+	p.Relays = []signature.PublicKey{invalid}
+	require.Error(p.ValidateBasic(), "invalid relay IDs should be rejected")
+
+	p.Relays = make([]signature.PublicKey, MaxP2PRelays+1)
+	require.Error(p.ValidateBasic(), "too many relays should be rejected")
+}
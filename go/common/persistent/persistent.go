@@ -104,6 +104,31 @@ func (ss *ServiceStore) GetCBOR(key []byte, value interface{}) error {
 	})
 }
 
+func (ss *ServiceStore) getRaw(key []byte) ([]byte, error) {
+	var raw []byte
+	err := ss.store.db.View(func(tx *badger.Txn) error {
+		item, txErr := tx.Get(ss.dbKey(key))
+		switch txErr {
+		case nil:
+		case badger.ErrKeyNotFound:
+			return ErrNotFound
+		default:
+			return txErr
+		}
+		if raw, txErr = item.ValueCopy(nil); txErr != nil {
+			return txErr
+		}
+		if len(raw) == 0 {
+			return ErrNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return raw, nil
+}
+
 // PutCBOR is a helper for storing CBOR-serialized values.
 func (ss *ServiceStore) PutCBOR(key []byte, value interface{}) error {
 	return ss.store.db.Update(func(tx *badger.Txn) error {
@@ -5,6 +5,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 )
 
 func TestPersistent(t *testing.T) {
@@ -31,3 +33,72 @@ func TestPersistent(t *testing.T) {
 	err = svc.GetCBOR(nonexistentKey, &valOut)
 	assert.Equal(t, ErrNotFound, err, "GetCBOR(nonexistent)")
 }
+
+func TestTypedStore(t *testing.T) {
+	dir, err := os.MkdirTemp("", "oasis-core-unittests")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	common, err := NewCommonStore(dir)
+	assert.NoError(t, err, "NewCommonStore")
+	defer common.Close()
+
+	svc := common.GetServiceStore("persistent_test")
+
+	type stateV1 struct {
+		A uint64 `json:"a"`
+	}
+	type stateV2 struct {
+		A uint64 `json:"a"`
+		B string `json:"b"`
+	}
+
+	key := []byte("state")
+	_, err = NewTypedStore[stateV1](svc, 1).Get(key)
+	assert.Equal(t, ErrNotFound, err, "Get(nonexistent)")
+
+	// Values stored without a version are treated as version 0.
+	err = svc.PutCBOR(key, uint64(42))
+	assert.NoError(t, err, "PutCBOR")
+
+	v1Store := NewTypedStore[stateV1](svc, 1)
+	_, err = v1Store.Get(key)
+	assert.ErrorIs(t, err, ErrMissingMigration, "Get should fail without migrations")
+
+	v1Store = NewTypedStore[stateV1](svc, 1).WithMigration(0, func(raw []byte) ([]byte, error) {
+		var a uint64
+		if err := cbor.Unmarshal(raw, &a); err != nil {
+			return nil, err
+		}
+		return cbor.Marshal(stateV1{A: a}), nil
+	})
+	v1, err := v1Store.Get(key)
+	assert.NoError(t, err, "Get")
+	assert.EqualValues(t, 42, v1.A, "legacy value should be migrated")
+
+	err = v1Store.Put(key, v1)
+	assert.NoError(t, err, "Put")
+
+	v2Store := NewTypedStore[stateV2](svc, 2).WithMigration(1, func(raw []byte) ([]byte, error) {
+		var v stateV1
+		if err := cbor.Unmarshal(raw, &v); err != nil {
+			return nil, err
+		}
+		return cbor.Marshal(stateV2{A: v.A, B: "migrated"}), nil
+	})
+	v2, err := v2Store.Get(key)
+	assert.NoError(t, err, "Get")
+	assert.Equal(t, &stateV2{A: 42, B: "migrated"}, v2, "value should be migrated")
+
+	err = v2Store.Put(key, v2)
+	assert.NoError(t, err, "Put")
+
+	// Newer versions should be rejected by older stores.
+	_, err = v1Store.Get(key)
+	assert.ErrorIs(t, err, ErrNewerVersion, "Get should fail for newer versions")
+
+	err = v2Store.Delete(key)
+	assert.NoError(t, err, "Delete")
+	_, err = v2Store.Get(key)
+	assert.Equal(t, ErrNotFound, err, "Get(deleted)")
+}
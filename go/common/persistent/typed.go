@@ -0,0 +1,114 @@
+package persistent
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+)
+
+var (
+	// ErrNewerVersion is returned when a stored value has a newer version than the one supported
+	// by the typed store (e.g., after a downgrade).
+	ErrNewerVersion = errors.New("persistent: stored value has a newer version")
+
+	// ErrMissingMigration is returned when a stored value has an older version for which no
+	// migration is registered.
+	ErrMissingMigration = errors.New("persistent: missing migration")
+)
+
+// Migration upgrades a CBOR-serialized value to the next version.
+type Migration func(raw []byte) ([]byte, error)
+
+// typedValue is the serialization envelope of values stored in a typed store.
+type typedValue struct {
+	cbor.Versioned
+
+	Value cbor.RawMessage `json:"value"`
+}
+
+// TypedStore is a store of versioned values of type T backed by a service store.
+//
+// Values are stored together with their version. Values of older versions are upgraded using the
+// registered migrations when loaded, while values of newer versions (e.g., written by a newer
+// node before a downgrade) are rejected instead of being silently truncated. Values that were
+// stored without a version (e.g., using PutCBOR) are treated as version 0.
+type TypedStore[T any] struct {
+	ss *ServiceStore
+
+	version    uint16
+	migrations map[uint16]Migration
+}
+
+// NewTypedStore creates a new typed store for values of the given (latest) version.
+func NewTypedStore[T any](ss *ServiceStore, version uint16) *TypedStore[T] {
+	return &TypedStore[T]{
+		ss:         ss,
+		version:    version,
+		migrations: make(map[uint16]Migration),
+	}
+}
+
+// WithMigration registers a migration that upgrades values from version fromV to version
+// fromV+1 and returns the store.
+//
+// This method panics in case of invalid or duplicate registrations.
+func (ts *TypedStore[T]) WithMigration(fromV uint16, fn Migration) *TypedStore[T] {
+	if fromV >= ts.version {
+		panic(fmt.Sprintf("persistent: invalid migration from version %d (latest: %d)", fromV, ts.version))
+	}
+	if _, ok := ts.migrations[fromV]; ok {
+		panic(fmt.Sprintf("persistent: migration from version %d already registered", fromV))
+	}
+	ts.migrations[fromV] = fn
+	return ts
+}
+
+// Get retrieves the value stored under the given key, migrating it to the latest version if
+// needed.
+func (ts *TypedStore[T]) Get(key []byte) (*T, error) {
+	raw, err := ts.ss.getRaw(key)
+	if err != nil {
+		return nil, err
+	}
+
+	var tv typedValue
+	if err = cbor.Unmarshal(raw, &tv); err != nil || tv.Value == nil {
+		// Value was stored without a version.
+		tv = typedValue{Value: raw}
+	}
+
+	version, data := tv.V, []byte(tv.Value)
+	if version > ts.version {
+		return nil, fmt.Errorf("%w: %d (supported: %d)", ErrNewerVersion, version, ts.version)
+	}
+	for ; version < ts.version; version++ {
+		fn, ok := ts.migrations[version]
+		if !ok {
+			return nil, fmt.Errorf("%w: from version %d", ErrMissingMigration, version)
+		}
+		if data, err = fn(data); err != nil {
+			return nil, fmt.Errorf("persistent: failed to migrate from version %d: %w", version, err)
+		}
+	}
+
+	var value T
+	if err = cbor.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+	return &value, nil
+}
+
+// Put stores the given value under the given key using the latest version.
+func (ts *TypedStore[T]) Put(key []byte, value *T) error {
+	tv := typedValue{
+		Versioned: cbor.NewVersioned(ts.version),
+		Value:     cbor.Marshal(value),
+	}
+	return ts.ss.PutCBOR(key, &tv)
+}
+
+// Delete removes the value stored under the given key.
+func (ts *TypedStore[T]) Delete(key []byte) error {
+	return ts.ss.Delete(key)
+}
@@ -13,6 +13,7 @@ import (
 	genesis "github.com/oasisprotocol/oasis-core/go/genesis/config"
 	ias "github.com/oasisprotocol/oasis-core/go/ias/config"
 	common "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/config"
+	crashReport "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/crashreport/config"
 	metrics "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/metrics/config"
 	pprof "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/pprof/config"
 	p2p "github.com/oasisprotocol/oasis-core/go/p2p/config"
@@ -84,6 +85,8 @@ type Config struct {
 	Pprof     pprof.Config   `yaml:"pprof,omitempty"`
 	Metrics   metrics.Config `yaml:"metrics,omitempty"`
 
+	CrashReport crashReport.Config `yaml:"crash_report,omitempty"`
+
 	Registration workerRegistration.Config `yaml:"registration,omitempty"`
 	Keymanager   workerKM.Config           `yaml:"keymanager,omitempty"`
 	Storage      workerStorage.Config      `yaml:"storage,omitempty"`
@@ -106,6 +109,17 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("unknown node mode: %s", c.Mode)
 	}
 
+	if c.Common.ReadOnly {
+		switch c.Mode {
+		case ModeClient, ModeStatelessClient, ModeArchive:
+		default:
+			return fmt.Errorf("read-only mode is not supported in %s node mode", c.Mode)
+		}
+		if c.Consensus.Validator {
+			return fmt.Errorf("read-only mode is not supported for consensus validators")
+		}
+	}
+
 	if err = c.Common.Validate(); err != nil {
 		return fmt.Errorf("common: %w", err)
 	}
@@ -142,6 +156,9 @@ func (c *Config) Validate() error {
 	if err = c.Metrics.Validate(); err != nil {
 		return fmt.Errorf("metrics: %w", err)
 	}
+	if err = c.CrashReport.Validate(); err != nil {
+		return fmt.Errorf("crash_report: %w", err)
+	}
 
 	return nil
 }
@@ -162,6 +179,7 @@ func DefaultConfig() Config {
 		IAS:          ias.DefaultConfig(),
 		Pprof:        pprof.DefaultConfig(),
 		Metrics:      metrics.DefaultConfig(),
+		CrashReport:  crashReport.DefaultConfig(),
 	}
 }
 
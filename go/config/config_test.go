@@ -0,0 +1,37 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateReadOnly(t *testing.T) {
+	for _, tc := range []struct {
+		mode      NodeMode
+		validator bool
+		ok        bool
+	}{
+		{ModeClient, false, true},
+		{ModeStatelessClient, false, true},
+		{ModeArchive, false, true},
+		{ModeClient, true, false},
+		{ModeValidator, false, false},
+		{ModeCompute, false, false},
+		{ModeKeyManager, false, false},
+		{ModeSeed, false, false},
+	} {
+		cfg := DefaultConfig()
+		cfg.Mode = tc.mode
+		cfg.Common.ReadOnly = true
+		cfg.Consensus.Validator = tc.validator
+
+		err := cfg.Validate()
+		switch tc.ok {
+		case true:
+			require.NoError(t, err, "read-only mode should be allowed (mode: %s, validator: %t)", tc.mode, tc.validator)
+		default:
+			require.ErrorContains(t, err, "read-only mode is not supported", "read-only mode should be rejected (mode: %s, validator: %t)", tc.mode, tc.validator)
+		}
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/version"
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction/results"
+	consensusGenesis "github.com/oasisprotocol/oasis-core/go/consensus/genesis"
 	genesis "github.com/oasisprotocol/oasis-core/go/genesis/api"
 	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
 	keymanager "github.com/oasisprotocol/oasis-core/go/keymanager/api"
@@ -64,6 +65,10 @@ var (
 	// ErrInvalidArgument is the error returned when the request contains an invalid argument.
 	ErrInvalidArgument = errors.New(ModuleName, 6, "consensus: invalid argument")
 
+	// ErrReadOnly is the error returned when submitting transactions or evidence to a node that is
+	// running in read-only mode.
+	ErrReadOnly = errors.New(ModuleName, 7, "consensus: node is in read-only mode")
+
 	// SystemMethods is a map of all system methods.
 	SystemMethods = map[transaction.MethodName]struct{}{
 		MethodMeta: {},
@@ -121,6 +126,11 @@ type ClientBackend interface {
 	// to be included in a block. Use SubmitTx if you need to wait for execution.
 	SubmitTxNoWait(ctx context.Context, tx *transaction.SignedTransaction) error
 
+	// SubmitTxNoWaitWithStatus submits a signed consensus transaction, but does not wait for the
+	// transaction to be included in a block. In addition to SubmitTxNoWait it returns the result
+	// of local mempool admission, the estimated queue position and the expiry height.
+	SubmitTxNoWaitWithStatus(ctx context.Context, tx *transaction.SignedTransaction) (*SubmitTxStatus, error)
+
 	// SubmitTxWithProof submits a signed consensus transaction, waits for the transaction to be
 	// included in a block and returns a proof of inclusion.
 	SubmitTxWithProof(ctx context.Context, tx *transaction.SignedTransaction) (*transaction.Proof, error)
@@ -148,6 +158,9 @@ type ClientBackend interface {
 	// GetParameters returns the consensus parameters for a specific height.
 	GetParameters(ctx context.Context, height int64) (*Parameters, error)
 
+	// GetGasCosts returns the gas cost tables of all consensus modules for a specific height.
+	GetGasCosts(ctx context.Context, height int64) (*GasCosts, error)
+
 	// SubmitEvidence submits evidence of misbehavior.
 	SubmitEvidence(ctx context.Context, evidence *Evidence) error
 
@@ -227,6 +240,14 @@ type Block struct {
 	Meta cbor.RawMessage `json:"meta"`
 }
 
+// GasCosts are the gas cost tables of all consensus modules.
+type GasCosts struct {
+	// Height contains the block height these gas costs are for.
+	Height int64 `json:"height"`
+	// Modules are the gas cost tables indexed by module name.
+	Modules map[string]transaction.Costs `json:"modules"`
+}
+
 // NextBlockState has the state of the next block being voted on by validators.
 type NextBlockState struct {
 	Height int64 `json:"height"`
@@ -334,6 +355,9 @@ type Status struct { // nolint: maligned
 	LastRetainedHeight int64 `json:"last_retained_height"`
 	// LastRetainedHash is the hash of the oldest retained block.
 	LastRetainedHash hash.Hash `json:"last_retained_hash"`
+	// LastRetainedStateHeight is the height of the oldest retained consensus state. Queries at
+	// earlier heights fail with a HeightPrunedError.
+	LastRetainedStateHeight int64 `json:"last_retained_state_height,omitempty"`
 
 	// ChainContext is the chain domain separation context.
 	ChainContext string `json:"chain_context"`
@@ -358,6 +382,46 @@ type P2PStatus struct {
 
 	// Peers is a list of node's peers.
 	Peers []string `json:"peers"`
+
+	// PeerDetails contains diagnostic information about each of the node's peers.
+	PeerDetails []PeerStatus `json:"peer_details,omitempty"`
+}
+
+// PeerStatus is the diagnostic status of a single consensus peer.
+type PeerStatus struct {
+	// ID is the peer's P2P identifier.
+	ID string `json:"id"`
+
+	// Address is the remote address of the connection.
+	Address string `json:"address"`
+
+	// IsOutbound is true iff the node dialed the peer.
+	IsOutbound bool `json:"is_outbound"`
+
+	// Height is the latest consensus height reported by the peer.
+	Height int64 `json:"height"`
+
+	// Round is the latest consensus round reported by the peer.
+	Round int32 `json:"round"`
+
+	// ConnectedFor is the duration of the connection.
+	ConnectedFor time.Duration `json:"connected_for"`
+
+	// SendRate is the current send rate in bytes per second.
+	SendRate int64 `json:"send_rate"`
+
+	// RecvRate is the current receive rate in bytes per second.
+	RecvRate int64 `json:"recv_rate"`
+
+	// SendIdle is the time since the last data was sent to the peer.
+	SendIdle time.Duration `json:"send_idle"`
+
+	// RecvIdle is the time since the last data was received from the peer.
+	RecvIdle time.Duration `json:"recv_idle"`
+
+	// RTT is the most recently measured round-trip time to the peer. It is zero in case the
+	// round-trip time has not been measured yet or the peer does not support measurements.
+	RTT time.Duration `json:"rtt,omitempty"`
 }
 
 // Backend is an interface that a consensus backend must provide.
@@ -415,6 +479,28 @@ type TransactionAuthHandler interface {
 	GetSignerNonce(ctx context.Context, req *GetSignerNonceRequest) (uint64, error)
 }
 
+// SubmitTxStatus is the status of a transaction submitted without waiting for it to be included
+// in a block.
+type SubmitTxStatus struct {
+	// Height is the latest block height at the time of submission.
+	Height int64 `json:"height"`
+	// Priority is the priority with which the transaction was admitted into the local mempool.
+	Priority int64 `json:"priority"`
+	// GasWanted is the amount of gas requested by the transaction.
+	GasWanted int64 `json:"gas_wanted"`
+
+	// QueuePosition is the estimated number of transactions ordered before the transaction in
+	// the local mempool.
+	QueuePosition uint64 `json:"queue_position"`
+	// QueueSize is the number of transactions in the local mempool.
+	QueueSize uint64 `json:"queue_size"`
+
+	// ExpiryHeight is the height after which the transaction should be considered dropped if it
+	// has not been included in a block by then. Zero means that transactions do not expire from
+	// the local mempool.
+	ExpiryHeight int64 `json:"expiry_height,omitempty"`
+}
+
 // EstimateGasRequest is a EstimateGas request.
 type EstimateGasRequest struct {
 	Signer      signature.PublicKey      `json:"signer"`
@@ -442,3 +528,7 @@ type TransactionsWithProofs struct {
 	Transactions [][]byte `json:"transactions"`
 	Proofs       [][]byte `json:"proofs"`
 }
+
+func init() {
+	transaction.RegisterGasOps(ModuleName, consensusGenesis.GasOpTxByte)
+}
@@ -28,6 +28,8 @@ var (
 	methodSubmitTx = serviceName.NewMethod("SubmitTx", transaction.SignedTransaction{})
 	// methodSubmitTxNoWait is the SubmitTxNoWait method.
 	methodSubmitTxNoWait = serviceName.NewMethod("SubmitTxNoWait", transaction.SignedTransaction{})
+	// methodSubmitTxNoWaitWithStatus is the SubmitTxNoWaitWithStatus method.
+	methodSubmitTxNoWaitWithStatus = serviceName.NewMethod("SubmitTxNoWaitWithStatus", transaction.SignedTransaction{})
 	// methodSubmitTxWithProof is the SubmitTxWithProof method.
 	methodSubmitTxWithProof = serviceName.NewMethod("SubmitTxWithProof", transaction.SignedTransaction{})
 	// methodStateToGenesis is the StateToGenesis method.
@@ -66,6 +68,8 @@ var (
 	methodGetNextBlockState = serviceName.NewMethod("GetNextBlockState", nil)
 	// methodGetParameters is the GetParameters method.
 	methodGetParameters = serviceName.NewMethod("GetParameters", int64(0))
+	// methodGetGasCosts is the GetGasCosts method.
+	methodGetGasCosts = serviceName.NewMethod("GetGasCosts", int64(0))
 	// methodSubmitEvidence is the SubmitEvidence method.
 	methodSubmitEvidence = serviceName.NewMethod("SubmitEvidence", &Evidence{})
 
@@ -85,6 +89,10 @@ var (
 				MethodName: methodSubmitTxNoWait.ShortName(),
 				Handler:    handlerSubmitTxNoWait,
 			},
+			{
+				MethodName: methodSubmitTxNoWaitWithStatus.ShortName(),
+				Handler:    handlerSubmitTxNoWaitWithStatus,
+			},
 			{
 				MethodName: methodSubmitTxWithProof.ShortName(),
 				Handler:    handlerSubmitTxWithProof,
@@ -161,6 +169,10 @@ var (
 				MethodName: methodGetParameters.ShortName(),
 				Handler:    handlerGetParameters,
 			},
+			{
+				MethodName: methodGetGasCosts.ShortName(),
+				Handler:    handlerGetGasCosts,
+			},
 			{
 				MethodName: methodSubmitEvidence.ShortName(),
 				Handler:    handlerSubmitEvidence,
@@ -222,6 +234,29 @@ func handlerSubmitTxNoWait(
 	return interceptor(ctx, rq, info, handler)
 }
 
+func handlerSubmitTxNoWaitWithStatus(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	rq := new(transaction.SignedTransaction)
+	if err := dec(rq); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientBackend).SubmitTxNoWaitWithStatus(ctx, rq)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodSubmitTxNoWaitWithStatus.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientBackend).SubmitTxNoWaitWithStatus(ctx, req.(*transaction.SignedTransaction))
+	}
+	return interceptor(ctx, rq, info, handler)
+}
+
 func handlerSubmitTxWithProof(
 	srv interface{},
 	ctx context.Context,
@@ -635,6 +670,29 @@ func handlerGetParameters(
 	return interceptor(ctx, height, info, handler)
 }
 
+func handlerGetGasCosts(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var height int64
+	if err := dec(&height); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientBackend).GetGasCosts(ctx, height)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetGasCosts.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientBackend).GetGasCosts(ctx, req.(int64))
+	}
+	return interceptor(ctx, height, info, handler)
+}
+
 func handlerSubmitEvidence(
 	srv interface{},
 	ctx context.Context,
@@ -703,6 +761,14 @@ func (c *consensusClient) SubmitTxNoWait(ctx context.Context, tx *transaction.Si
 	return c.conn.Invoke(ctx, methodSubmitTxNoWait.FullName(), tx, nil)
 }
 
+func (c *consensusClient) SubmitTxNoWaitWithStatus(ctx context.Context, tx *transaction.SignedTransaction) (*SubmitTxStatus, error) {
+	var rsp SubmitTxStatus
+	if err := c.conn.Invoke(ctx, methodSubmitTxNoWaitWithStatus.FullName(), tx, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *consensusClient) SubmitTxWithProof(ctx context.Context, tx *transaction.SignedTransaction) (*transaction.Proof, error) {
 	var proof transaction.Proof
 	if err := c.conn.Invoke(ctx, methodSubmitTxWithProof.FullName(), tx, &proof); err != nil {
@@ -866,6 +932,14 @@ func (c *consensusClient) GetParameters(ctx context.Context, height int64) (*Par
 	return &rsp, nil
 }
 
+func (c *consensusClient) GetGasCosts(ctx context.Context, height int64) (*GasCosts, error) {
+	var rsp GasCosts
+	if err := c.conn.Invoke(ctx, methodGetGasCosts.FullName(), height, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *consensusClient) SubmitEvidence(ctx context.Context, evidence *Evidence) error {
 	return c.conn.Invoke(ctx, methodSubmitEvidence.FullName(), evidence, nil)
 }
@@ -0,0 +1,55 @@
+package api
+
+import (
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/common/errors"
+)
+
+// heightPrunedContextFmt is the format of the error context describing a pruned height.
+const heightPrunedContextFmt = "height pruned (earliest available: %d)"
+
+// HeightPrunedError is the error returned when the queried height is no longer available
+// because it has been pruned.
+//
+// It wraps ErrVersionNotFound so existing checks against that error keep working.
+type HeightPrunedError struct {
+	// Earliest is the earliest height that is still available.
+	Earliest int64
+}
+
+// NewHeightPrunedError creates a new error for a query at a height below the given earliest
+// available height.
+func NewHeightPrunedError(earliest int64) error {
+	return &HeightPrunedError{Earliest: earliest}
+}
+
+// Error implements error.
+func (e *HeightPrunedError) Error() string {
+	return fmt.Sprintf("%s: "+heightPrunedContextFmt, ErrVersionNotFound, e.Earliest)
+}
+
+// Unwrap returns the underlying ErrVersionNotFound error.
+func (e *HeightPrunedError) Unwrap() error {
+	return ErrVersionNotFound
+}
+
+// AsHeightPrunedError returns the HeightPrunedError in the given error's chain, if any.
+//
+// Errors received over gRPC only retain their error code and message, so in that case the
+// earliest available height is recovered from the error context.
+func AsHeightPrunedError(err error) (*HeightPrunedError, bool) {
+	var hpe *HeightPrunedError
+	if errors.As(err, &hpe) {
+		return hpe, true
+	}
+	if !errors.Is(err, ErrVersionNotFound) {
+		return nil, false
+	}
+
+	var earliest int64
+	if _, serr := fmt.Sscanf(errors.Context(err), heightPrunedContextFmt, &earliest); serr != nil {
+		return nil, false
+	}
+	return &HeightPrunedError{Earliest: earliest}, true
+}
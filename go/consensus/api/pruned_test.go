@@ -0,0 +1,37 @@
+package api
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/errors"
+)
+
+func TestHeightPrunedError(t *testing.T) {
+	require := require.New(t)
+
+	err := fmt.Errorf("failed to query state: %w", NewHeightPrunedError(42))
+	require.ErrorIs(err, ErrVersionNotFound, "pruned errors should be version not found errors")
+
+	hpe, ok := AsHeightPrunedError(err)
+	require.True(ok, "AsHeightPrunedError")
+	require.EqualValues(42, hpe.Earliest)
+
+	// Simulate an error that has been sent over gRPC.
+	module, code := errors.Code(err)
+	remote := errors.FromCode(module, code, NewHeightPrunedError(42).Error())
+	require.ErrorIs(remote, ErrVersionNotFound)
+	hpe, ok = AsHeightPrunedError(remote)
+	require.True(ok, "AsHeightPrunedError should handle remote errors")
+	require.EqualValues(42, hpe.Earliest)
+
+	// Other errors.
+	_, ok = AsHeightPrunedError(ErrVersionNotFound)
+	require.False(ok, "version not found without a hint is not a pruned error")
+	_, ok = AsHeightPrunedError(ErrNoCommittedBlocks)
+	require.False(ok)
+	_, ok = AsHeightPrunedError(nil)
+	require.False(ok)
+}
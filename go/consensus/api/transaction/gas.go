@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"sync"
 
 	"github.com/oasisprotocol/oasis-core/go/common/errors"
 	"github.com/oasisprotocol/oasis-core/go/common/prettyprint"
@@ -19,6 +20,8 @@ var (
 	// ErrGasPriceTooLow is the error returned when the gas price is too low.
 	ErrGasPriceTooLow = errors.New(moduleName, 3, "transaction: gas price too low")
 
+	registeredGasOps sync.Map
+
 	_ prettyprint.PrettyPrinter = (*Fee)(nil)
 )
 
@@ -76,5 +79,35 @@ func (f Fee) GasPrice() *quantity.Quantity {
 // Costs defines gas costs for different operations.
 type Costs map[Op]Gas
 
+// ValidateOps checks that the gas costs only include operations registered for the given module
+// via RegisterGasOps.
+func (c Costs) ValidateOps(module string) error {
+	v, ok := registeredGasOps.Load(module)
+	if !ok {
+		return fmt.Errorf("transaction: no gas operations registered for module '%s'", module)
+	}
+	ops := v.(map[Op]struct{})
+	for op := range c {
+		if _, ok := ops[op]; !ok {
+			return fmt.Errorf("transaction: unknown gas operation '%s' for module '%s'", op, module)
+		}
+	}
+	return nil
+}
+
 // Op identifies an operation that requires gas to run.
 type Op string
+
+// RegisterGasOps registers the gas operations supported by the given module.
+//
+// This method may only be called during initialization and panics in case the module has already
+// registered its gas operations.
+func RegisterGasOps(module string, ops ...Op) {
+	opSet := make(map[Op]struct{}, len(ops))
+	for _, op := range ops {
+		opSet[op] = struct{}{}
+	}
+	if _, isRegistered := registeredGasOps.LoadOrStore(module, opSet); isRegistered {
+		panic(fmt.Errorf("transaction: gas operations already registered: %s", module))
+	}
+}
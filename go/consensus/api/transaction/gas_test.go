@@ -20,3 +20,19 @@ func TestFeeGasPrice(t *testing.T) {
 	require.NoError(t, referencePrice.FromUint64(1), "import reference price")
 	require.Zero(t, gasPrice.Cmp(&referencePrice), "price matches")
 }
+
+func TestCostsValidateOps(t *testing.T) {
+	require := require.New(t)
+
+	const (
+		opA Op = "a"
+		opB Op = "b"
+	)
+	RegisterGasOps("test/gas", opA, opB)
+	require.Panics(func() { RegisterGasOps("test/gas", opA) }, "duplicate registration should panic")
+
+	require.NoError(Costs{}.ValidateOps("test/gas"))
+	require.NoError(Costs{opA: 10, opB: 0}.ValidateOps("test/gas"))
+	require.Error(Costs{opA: 10, "c": 1}.ValidateOps("test/gas"), "unknown operations should be rejected")
+	require.Error(Costs{opA: 10}.ValidateOps("test/unknown"), "unknown modules should be rejected")
+}
@@ -18,6 +18,7 @@ import (
 	storage "github.com/oasisprotocol/oasis-core/go/storage/api"
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/checkpoint"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
 	upgrade "github.com/oasisprotocol/oasis-core/go/upgrade/api"
 )
 
@@ -291,6 +292,23 @@ func NewImmutableState(ctx context.Context, state ApplicationQueryState, version
 	}
 
 	// Handle a regular (external) query where we need to create a new tree.
+	root, err := StateRootAt(state, version)
+	if err != nil {
+		return nil, err
+	}
+	tree := mkvs.NewWithRoot(nil, state.Storage().NodeDB(), *root, mkvs.WithoutWriteLog())
+
+	return &ImmutableState{tree}, nil
+}
+
+// StateRootAt returns the committed consensus state root at the given version.
+//
+// If the version is not positive or is greater than the last committed block height, the state
+// root at the last committed block height is returned.
+func StateRootAt(state ApplicationQueryState, version int64) (*node.Root, error) {
+	if state == nil {
+		return nil, ErrNoState
+	}
 	if state.BlockHeight() == 0 {
 		return nil, consensus.ErrNoCommittedBlocks
 	}
@@ -298,14 +316,20 @@ func NewImmutableState(ctx context.Context, state ApplicationQueryState, version
 		version = state.BlockHeight()
 	}
 
-	ndb := state.Storage().NodeDB()
-	roots, err := ndb.GetRootsForVersion(uint64(version))
+	roots, err := state.Storage().NodeDB().GetRootsForVersion(uint64(version))
 	if err != nil {
 		return nil, err
 	}
 	switch len(roots) {
 	case 0:
 		// No roots for that state -- it may have been pruned.
+		earliest := int64(state.Storage().NodeDB().GetEarliestVersion())
+		if lastRetained, lerr := state.LastRetainedVersion(); lerr == nil {
+			earliest = max(earliest, lastRetained)
+		}
+		if version < earliest {
+			return nil, consensus.NewHeightPrunedError(earliest)
+		}
 		return nil, consensus.ErrVersionNotFound
 	case 1:
 		// A single root.
@@ -313,7 +337,5 @@ func NewImmutableState(ctx context.Context, state ApplicationQueryState, version
 		// Unexpected number of roots.
 		return nil, fmt.Errorf("state: incorrect number of roots (%d): %+v", version, roots)
 	}
-	tree := mkvs.NewWithRoot(nil, ndb, roots[0], mkvs.WithoutWriteLog())
-
-	return &ImmutableState{tree}, nil
+	return &roots[0], nil
 }
@@ -240,7 +240,7 @@ func (app *governanceApplication) executeProposal(ctx *api.Context, state *gover
 
 		// Locally apply the upgrade proposal.
 		if upgrader := ctx.AppState().Upgrader(); upgrader != nil {
-			if err = upgrader.SubmitDescriptor(&proposal.Content.Upgrade.Descriptor); err != nil {
+			if err = upgrader.SubmitProposalDescriptor(proposal.ID, &proposal.Content.Upgrade.Descriptor); err != nil {
 				ctx.Logger().Error("failed to locally apply the upgrade descriptor",
 					"err", err,
 					"descriptor", proposal.Content.Upgrade.Descriptor,
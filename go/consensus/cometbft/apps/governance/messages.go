@@ -14,24 +14,51 @@ func (app *governanceApplication) completeStateSync(ctx *api.Context) (interface
 	// State sync has just completed, check whether there are any pending upgrades to make
 	// sure we don't miss them after the sync.
 	state := governanceState.NewMutableState(ctx.State())
-	pendingUpgrades, err := state.PendingUpgrades(ctx)
+	proposals, err := state.PendingUpgradeProposals(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("cometbft/governance: couldn't get pending upgrades: %w", err)
 	}
 
+	upgrader := ctx.AppState().Upgrader()
+	if upgrader == nil {
+		// No execute message results at this time.
+		return nil, nil
+	}
+
 	// Apply all pending upgrades locally.
-	if upgrader := ctx.AppState().Upgrader(); upgrader != nil {
-		for _, pu := range pendingUpgrades {
-			switch err = upgrader.SubmitDescriptor(pu); err {
-			case nil, upgrade.ErrAlreadyPending:
-			default:
-				ctx.Logger().Error("failed to locally apply the upgrade descriptor",
-					"err", err,
-					"descriptor", pu,
-				)
-			}
+	pendingProposals := make(map[uint64]bool)
+	for _, proposal := range proposals {
+		pendingProposals[proposal.ID] = true
+
+		switch err = upgrader.SubmitProposalDescriptor(proposal.ID, &proposal.Content.Upgrade.Descriptor); err {
+		case nil, upgrade.ErrAlreadyPending:
+		default:
+			ctx.Logger().Error("failed to locally apply the upgrade descriptor",
+				"err", err,
+				"descriptor", proposal.Content.Upgrade.Descriptor,
+			)
 		}
 	}
+
+	// Unschedule any local upgrades scheduled by proposals that have been cancelled in the
+	// meantime.
+	localUpgrades, err := upgrader.PendingUpgrades()
+	if err != nil {
+		return nil, fmt.Errorf("cometbft/governance: couldn't get local pending upgrades: %w", err)
+	}
+	for _, pu := range localUpgrades {
+		if pu.ProposalID == 0 || pendingProposals[pu.ProposalID] || pu.IsInProgress() {
+			continue
+		}
+		if err = upgrader.CancelUpgrade(pu.Descriptor); err != nil {
+			ctx.Logger().Error("failed to locally cancel the upgrade",
+				"err", err,
+				"descriptor", pu.Descriptor,
+				"proposal_id", pu.ProposalID,
+			)
+		}
+	}
+
 	// No execute message results at this time.
 	return nil, nil
 }
@@ -208,10 +208,25 @@ func (s *ImmutableState) PendingUpgradeProposal(ctx context.Context, id uint64)
 
 // PendingUpgrades looks up all pending upgrades.
 func (s *ImmutableState) PendingUpgrades(ctx context.Context) ([]*upgrade.Descriptor, error) {
+	proposals, err := s.PendingUpgradeProposals(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var pendingUpgrades []*upgrade.Descriptor
+	for _, proposal := range proposals {
+		pendingUpgrades = append(pendingUpgrades, &proposal.Content.Upgrade.Descriptor)
+	}
+
+	return pendingUpgrades, nil
+}
+
+// PendingUpgradeProposals looks up all proposals with pending upgrades.
+func (s *ImmutableState) PendingUpgradeProposals(ctx context.Context) ([]*governance.Proposal, error) {
 	it := s.is.NewIterator(ctx)
 	defer it.Close()
 
-	var pendingUpgrades []*upgrade.Descriptor
+	var proposals []*governance.Proposal
 	for it.Seek(pendingUpgradesKeyFmt.Encode()); it.Valid(); it.Next() {
 		var epoch uint64
 		var proposalID uint64
@@ -226,10 +241,10 @@ func (s *ImmutableState) PendingUpgrades(ctx context.Context) ([]*upgrade.Descri
 		if proposal.Content.Upgrade == nil {
 			return nil, api.UnavailableStateError(fmt.Errorf("cometbft/governance: pending upgrade with missing upgrade descriptor"))
 		}
-		pendingUpgrades = append(pendingUpgrades, &proposal.Content.Upgrade.Descriptor)
+		proposals = append(proposals, proposal)
 	}
 
-	return pendingUpgrades, nil
+	return proposals, nil
 }
 
 // ConsensusParameters returns the governance consensus parameters.
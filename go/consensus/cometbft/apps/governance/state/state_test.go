@@ -279,6 +279,13 @@ func TestPendingUpgrades(t *testing.T) {
 	require.NoError(err, "PendingUpgrades()")
 	require.ElementsMatch(pendingUpgrades, expectedPendingUpgrades, "Pending upgrades should match")
 
+	pendingProposals, err := s.PendingUpgradeProposals(ctx)
+	require.NoError(err, "PendingUpgradeProposals()")
+	require.Len(pendingProposals, len(expectedPendingUpgrades), "Pending upgrade proposals should match")
+	for _, proposal := range pendingProposals {
+		require.NotNil(proposal.Content.Upgrade, "Pending upgrade proposals should be upgrade proposals")
+	}
+
 	// Test querying pending upgrade proposals.
 	for _, proposal := range proposals {
 		var upgradeProposal *governance.UpgradeProposal
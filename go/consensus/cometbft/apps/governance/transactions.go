@@ -10,11 +10,13 @@ import (
 	registryState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/registry/state"
 	schedulerState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/scheduler/state"
 	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/staking/state"
+	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/features"
 	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
 	registryAPI "github.com/oasisprotocol/oasis-core/go/registry/api"
 	schedulerAPI "github.com/oasisprotocol/oasis-core/go/scheduler/api"
 	stakingAPI "github.com/oasisprotocol/oasis-core/go/staking/api"
 	upgradeAPI "github.com/oasisprotocol/oasis-core/go/upgrade/api"
+	"github.com/oasisprotocol/oasis-core/go/upgrade/migrations"
 )
 
 func (app *governanceApplication) submitProposal(
@@ -141,6 +143,20 @@ func (app *governanceApplication) submitProposal(
 		}
 
 	case proposalContent.ChangeParameters != nil:
+		// Reject gas costs for unknown operations with the 25.0 release.
+		var enabled bool
+		if enabled, err = features.IsFeatureVersion(ctx, migrations.Version250); err != nil {
+			return nil, err
+		}
+		if enabled {
+			if err = proposalContent.ChangeParameters.ValidateGasCosts(); err != nil {
+				ctx.Logger().Debug("governance: invalid gas costs in change parameters proposal",
+					"err", err,
+				)
+				return nil, fmt.Errorf("%w: %w", governance.ErrInvalidArgument, err)
+			}
+		}
+
 		// Notify other interested applications to validate the parameter changes.
 		var res interface{}
 		res, err = app.md.Publish(ctx, governanceApi.MessageValidateParameterChanges, proposalContent.ChangeParameters)
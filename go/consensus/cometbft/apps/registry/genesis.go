@@ -49,6 +49,32 @@ func (app *registryApplication) InitChain(ctx *abciAPI.Context, _ types.RequestI
 			return fmt.Errorf("registry: genesis entity registration failure: %w", err)
 		}
 	}
+	for i, v := range st.MultiSignedEntities {
+		if v == nil {
+			return fmt.Errorf("registry: genesis multi-signed entity index %d is nil", i)
+		}
+		ctx.Logger().Debug("InitChain: Registering genesis multi-signed entity",
+			"signers", len(v.Signatures),
+		)
+		if err := app.registerMultiSignedEntity(ctx, state, v); err != nil {
+			ctx.Logger().Error("InitChain: failed to register multi-signed entity",
+				"err", err,
+				"entity", v,
+			)
+			return fmt.Errorf("registry: genesis multi-signed entity registration failure: %w", err)
+		}
+	}
+	for id, nodes := range st.EntityNodes {
+		if _, err := state.Entity(ctx, id); err != nil {
+			return fmt.Errorf("registry: genesis node allowlist for unknown entity %s: %w", id, err)
+		}
+		if err := state.SetEntityNodes(ctx, id, nodes); err != nil {
+			ctx.Logger().Error("InitChain: failed to set entity nodes",
+				"err", err,
+			)
+			return fmt.Errorf("registry: genesis entity nodes set failure: %w", err)
+		}
+	}
 	// Register runtimes. First key manager and then compute runtime(s).
 	for _, k := range []registry.RuntimeKind{registry.KindKeyManager, registry.KindCompute} {
 		for i, rt := range st.Runtimes {
@@ -122,6 +148,33 @@ func (app *registryApplication) InitChain(ctx *abciAPI.Context, _ types.RequestI
 		}
 	}
 
+	for i, tombstone := range st.NodeTombstones {
+		if tombstone == nil || tombstone.Node == nil {
+			return fmt.Errorf("registry: genesis node tombstone index %d is nil", i)
+		}
+		if _, err := state.Node(ctx, tombstone.Node.ID); err == nil {
+			return fmt.Errorf("registry: genesis node tombstone for registered node %s", tombstone.Node.ID)
+		}
+		if err := state.SetNodeTombstone(ctx, tombstone); err != nil {
+			ctx.Logger().Error("InitChain: failed to set node tombstone",
+				"err", err,
+			)
+			return fmt.Errorf("registry: genesis node tombstone set failure: %w", err)
+		}
+	}
+
+	for _, id := range st.ValidatorElectionOptOuts {
+		if _, err := state.Entity(ctx, id); err != nil {
+			return fmt.Errorf("registry: genesis validator election opt-out for unknown entity %s: %w", id, err)
+		}
+		if err := state.SetValidatorElectionOptOut(ctx, id, true); err != nil {
+			ctx.Logger().Error("InitChain: failed to set validator election opt-out",
+				"err", err,
+			)
+			return fmt.Errorf("registry: genesis validator election opt-out set failure: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -131,6 +184,14 @@ func (rq *registryQuerier) Genesis(ctx context.Context) (*registry.Genesis, erro
 	if err != nil {
 		return nil, err
 	}
+	multiSignedEntities, err := rq.state.MultiSignedEntities(ctx)
+	if err != nil {
+		return nil, err
+	}
+	entityNodes, err := rq.state.AllEntityNodes(ctx)
+	if err != nil {
+		return nil, err
+	}
 	runtimes, err := rq.state.Runtimes(ctx)
 	if err != nil {
 		return nil, err
@@ -170,18 +231,33 @@ func (rq *registryQuerier) Genesis(ctx context.Context) (*registry.Genesis, erro
 		nodeStatuses[n.ID] = status
 	}
 
+	validatorElectionOptOuts, err := rq.state.ValidatorElectionOptOuts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	nodeTombstones, err := rq.state.NodeTombstones(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	params, err := rq.state.ConsensusParameters(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	gen := registry.Genesis{
-		Parameters:        *params,
-		Entities:          signedEntities,
-		Runtimes:          runtimes,
-		SuspendedRuntimes: suspendedRuntimes,
-		Nodes:             validatorNodes,
-		NodeStatuses:      nodeStatuses,
+		Parameters:          *params,
+		Entities:            signedEntities,
+		MultiSignedEntities: multiSignedEntities,
+		EntityNodes:         entityNodes,
+		Runtimes:            runtimes,
+		SuspendedRuntimes:   suspendedRuntimes,
+		Nodes:               validatorNodes,
+		NodeStatuses:        nodeStatuses,
+		NodeTombstones:      nodeTombstones,
+
+		ValidatorElectionOptOuts: validatorElectionOptOuts,
 	}
 	return &gen, nil
 }
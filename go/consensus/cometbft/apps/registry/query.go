@@ -7,9 +7,11 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/entity"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
 	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
 	registryState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/registry/state"
+	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/staking/state"
 	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
 )
 
@@ -20,11 +22,14 @@ type Query interface {
 	Node(context.Context, signature.PublicKey) (*node.Node, error)
 	NodeByConsensusAddress(context.Context, []byte) (*node.Node, error)
 	NodeStatus(context.Context, signature.PublicKey) (*registry.NodeStatus, error)
+	NodeTombstone(context.Context, signature.PublicKey) (*registry.NodeTombstone, error)
 	Nodes(context.Context) ([]*node.Node, error)
+	FilteredNodes(context.Context, *registry.GetNodesQuery) (*registry.NodesPage, error)
 	Runtime(ctx context.Context, id common.Namespace, includeSuspended bool) (*registry.Runtime, error)
 	Runtimes(ctx context.Context, includeSuspended bool) ([]*registry.Runtime, error)
 	Genesis(context.Context) (*registry.Genesis, error)
 	ConsensusParameters(context.Context) (*registry.ConsensusParameters, error)
+	ValidateRuntime(context.Context, *registry.Runtime) error
 }
 
 // QueryFactory is the registry query factory.
@@ -81,6 +86,10 @@ func (rq *registryQuerier) NodeStatus(ctx context.Context, id signature.PublicKe
 	return rq.state.NodeStatus(ctx, id)
 }
 
+func (rq *registryQuerier) NodeTombstone(ctx context.Context, id signature.PublicKey) (*registry.NodeTombstone, error) {
+	return rq.state.NodeTombstone(ctx, id)
+}
+
 func (rq *registryQuerier) Nodes(ctx context.Context) ([]*node.Node, error) {
 	epoch, err := rq.queryState.GetEpoch(ctx, rq.height)
 	if err != nil {
@@ -103,6 +112,35 @@ func (rq *registryQuerier) Nodes(ctx context.Context) ([]*node.Node, error) {
 	return filteredNodes, nil
 }
 
+func (rq *registryQuerier) FilteredNodes(ctx context.Context, query *registry.GetNodesQuery) (*registry.NodesPage, error) {
+	epoch, err := rq.queryState.GetEpoch(ctx, rq.height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get epoch: %w", err)
+	}
+
+	// Use the node by entity index in case the filter is for a specific entity.
+	var nodes []*node.Node
+	switch query.Filter.EntityID {
+	case nil:
+		nodes, err = rq.state.Nodes(ctx)
+	default:
+		nodes, err = rq.state.GetEntityNodes(ctx, *query.Filter.EntityID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Filter out expired nodes.
+	var activeNodes []*node.Node
+	for _, n := range nodes {
+		if n.IsExpired(uint64(epoch)) {
+			continue
+		}
+		activeNodes = append(activeNodes, n)
+	}
+	return registry.PaginateNodes(activeNodes, query), nil
+}
+
 func (rq *registryQuerier) Runtime(ctx context.Context, id common.Namespace, includeSuspended bool) (*registry.Runtime, error) {
 	if includeSuspended {
 		return rq.state.AnyRuntime(ctx, id)
@@ -121,6 +159,82 @@ func (rq *registryQuerier) ConsensusParameters(ctx context.Context) (*registry.C
 	return rq.state.ConsensusParameters(ctx)
 }
 
+func (rq *registryQuerier) ValidateRuntime(ctx context.Context, rt *registry.Runtime) error {
+	logger := logging.NewNopLogger()
+
+	params, err := rq.state.ConsensusParameters(ctx)
+	if err != nil {
+		return err
+	}
+	if params.DisableRuntimeRegistration {
+		return registry.ErrForbidden
+	}
+
+	epoch, err := rq.queryState.GetEpoch(ctx, rq.height)
+	if err != nil {
+		return fmt.Errorf("failed to get epoch: %w", err)
+	}
+
+	if err = registry.VerifyRuntime(params, logger, rt, false, false, epoch); err != nil {
+		return err
+	}
+
+	if rt.Kind == registry.KindKeyManager && params.DisableKeyManagerRuntimeRegistration {
+		return registry.ErrForbidden
+	}
+
+	if rt.Kind == registry.KindCompute {
+		if err = registry.VerifyRegisterComputeRuntimeArgs(ctx, logger, rt, rq.state); err != nil {
+			return err
+		}
+	}
+
+	// Invoke the right verification logic.
+	existingRt, err := rq.state.AnyRuntime(ctx, rt.ID)
+	switch err {
+	case nil:
+		// Existing runtime, verify update.
+		err = registry.VerifyRuntimeUpdate(logger, existingRt, rt, epoch, params)
+	case registry.ErrNoSuchRuntime:
+		// New runtime, verify new descriptor.
+		err = registry.VerifyRuntimeNew(logger, rt, epoch, params, false)
+	default:
+		return fmt.Errorf("failed to fetch runtime: %w", err)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Runtimes with consensus-layer governance can only be registered at genesis.
+	rtAddress := rt.StakingAddress()
+	if rtAddress == nil {
+		return registry.ErrForbidden
+	}
+
+	// Make sure that the entity or runtime has enough stake.
+	stakeState, err := stakingState.NewImmutableState(ctx, rq.queryState, rq.height)
+	if err != nil {
+		return err
+	}
+	stakeParams, err := stakeState.ConsensusParameters(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch staking consensus parameters: %w", err)
+	}
+	if stakeParams.DebugBypassStake {
+		return nil
+	}
+
+	thresholds, err := stakeState.Thresholds(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch staking thresholds: %w", err)
+	}
+	acct, err := stakeState.Account(ctx, *rtAddress)
+	if err != nil {
+		return fmt.Errorf("failed to fetch account: %w", err)
+	}
+	return acct.Escrow.AddStakeClaim(thresholds, registry.StakeClaimForRuntime(rt.ID), registry.StakeThresholdsForRuntime(rt))
+}
+
 func (app *registryApplication) QueryFactory() interface{} {
 	return &QueryFactory{app.state}
 }
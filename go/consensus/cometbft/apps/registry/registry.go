@@ -18,9 +18,11 @@ import (
 	roothashApi "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/roothash/api"
 	stakingapp "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/staking"
 	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/staking/state"
+	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/features"
 	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/message"
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+	"github.com/oasisprotocol/oasis-core/go/upgrade/migrations"
 )
 
 var _ api.Application = (*registryApplication)(nil)
@@ -107,9 +109,23 @@ func (app *registryApplication) ExecuteTx(ctx *api.Context, tx *transaction.Tran
 		}
 		return app.registerEntity(ctx, state, &sigEnt)
 
+	case registry.MethodRegisterMultiSignedEntity:
+		var msEnt entity.MultiSignedEntity
+		if err := cbor.Unmarshal(tx.Body, &msEnt); err != nil {
+			return registry.ErrInvalidArgument
+		}
+		return app.registerMultiSignedEntity(ctx, state, &msEnt)
+
 	case registry.MethodDeregisterEntity:
 		return app.deregisterEntity(ctx, state)
 
+	case registry.MethodAddEntityNodes, registry.MethodRemoveEntityNodes:
+		var nodes registry.EntityNodes
+		if err := cbor.Unmarshal(tx.Body, &nodes); err != nil {
+			return registry.ErrInvalidArgument
+		}
+		return app.updateEntityNodes(ctx, state, &nodes, tx.Method == registry.MethodAddEntityNodes)
+
 	case registry.MethodRegisterNode:
 		var sigNode node.MultiSignedNode
 		if err := cbor.Unmarshal(tx.Body, &sigNode); err != nil {
@@ -118,6 +134,13 @@ func (app *registryApplication) ExecuteTx(ctx *api.Context, tx *transaction.Tran
 		ctx.SetPriority(AppPriority + 10000)
 		return app.registerNode(ctx, state, &sigNode)
 
+	case registry.MethodFreezeNode:
+		var freeze registry.FreezeNode
+		if err := cbor.Unmarshal(tx.Body, &freeze); err != nil {
+			return registry.ErrInvalidArgument
+		}
+		return app.freezeNode(ctx, state, &freeze)
+
 	case registry.MethodUnfreezeNode:
 		var unfreeze registry.UnfreezeNode
 		if err := cbor.Unmarshal(tx.Body, &unfreeze); err != nil {
@@ -125,6 +148,13 @@ func (app *registryApplication) ExecuteTx(ctx *api.Context, tx *transaction.Tran
 		}
 		return app.unfreezeNode(ctx, state, &unfreeze)
 
+	case registry.MethodSetValidatorElectionOptOut:
+		var optOut registry.ValidatorElectionOptOut
+		if err := cbor.Unmarshal(tx.Body, &optOut); err != nil {
+			return registry.ErrInvalidArgument
+		}
+		return app.setValidatorElectionOptOut(ctx, state, &optOut)
+
 	case registry.MethodRegisterRuntime:
 		var rt registry.Runtime
 		if err := cbor.Unmarshal(tx.Body, &rt); err != nil {
@@ -135,6 +165,20 @@ func (app *registryApplication) ExecuteTx(ctx *api.Context, tx *transaction.Tran
 		}
 		return nil
 
+	case registry.MethodWithdrawRuntimeDeployment:
+		var wd registry.WithdrawRuntimeDeployment
+		if err := cbor.Unmarshal(tx.Body, &wd); err != nil {
+			return registry.ErrInvalidArgument
+		}
+		return app.withdrawRuntimeDeployment(ctx, state, &wd)
+
+	case registry.MethodUpdateRuntimeEntityWhitelist:
+		var update registry.RuntimeEntityWhitelistUpdate
+		if err := cbor.Unmarshal(tx.Body, &update); err != nil {
+			return registry.ErrInvalidArgument
+		}
+		return app.updateRuntimeEntityWhitelist(ctx, state, &update)
+
 	case registry.MethodProveFreshness:
 		var blob [32]byte
 		if err := cbor.Unmarshal(tx.Body, &blob); err != nil {
@@ -185,6 +229,14 @@ func (app *registryApplication) onRegistryEpochChanged(ctx *api.Context, registr
 		return fmt.Errorf("registry: onRegistryEpochChanged: failed to fetch consensus parameters: %w", err)
 	}
 
+	regParams, err := regState.ConsensusParameters(ctx)
+	if err != nil {
+		ctx.Logger().Error("onRegistryEpochChanged: failed to fetch registry consensus parameters",
+			"err", err,
+		)
+		return fmt.Errorf("registry: onRegistryEpochChanged: failed to fetch registry consensus parameters: %w", err)
+	}
+
 	var stakeAcc *stakingState.StakeAccumulatorCache
 	if !params.DebugBypassStake {
 		stakeAcc, err = stakingState.NewStakeAccumulatorCache(ctx)
@@ -233,6 +285,16 @@ func (app *registryApplication) onRegistryEpochChanged(ctx *api.Context, registr
 				return fmt.Errorf("registry: onRegistryEpochChanged: couldn't remove node: %w", err)
 			}
 
+			// Keep the descriptor of the removed node as a tombstone, if configured.
+			if regParams.NodeTombstoneRetention > 0 {
+				if err = regState.SetNodeTombstone(ctx, &registry.NodeTombstone{
+					Node:      node,
+					RemovedAt: registryEpoch,
+				}); err != nil {
+					return fmt.Errorf("registry: onRegistryEpochChanged: couldn't set node tombstone: %w", err)
+				}
+			}
+
 			// Remove the stake claim for the given node.
 			if !params.DebugBypassStake {
 				acctAddr := staking.NewAddress(node.EntityID)
@@ -249,9 +311,34 @@ func (app *registryApplication) onRegistryEpochChanged(ctx *api.Context, registr
 		}
 	}
 
+	// Prune node tombstones that are past the retention period.
+	var tombstoneCutoff beacon.EpochTime
+	if registryEpoch > regParams.NodeTombstoneRetention {
+		tombstoneCutoff = registryEpoch - regParams.NodeTombstoneRetention
+	}
+	if err = regState.RemoveNodeTombstonesBefore(ctx, tombstoneCutoff); err != nil {
+		return fmt.Errorf("registry: onRegistryEpochChanged: couldn't prune node tombstones: %w", err)
+	}
+
+	// Node expired events are only emitted after the 25.0 upgrade.
+	var emitNodeExpired bool
+	if len(expiredNodes) > 0 {
+		if emitNodeExpired, err = features.IsFeatureVersion(ctx, migrations.Version250); err != nil {
+			return fmt.Errorf("registry: onRegistryEpochChanged: failed to check feature version: %w", err)
+		}
+	}
+
 	// Emit the expired node event for all expired nodes.
 	for _, expiredNode := range expiredNodes {
 		ctx.EmitEvent(api.NewEventBuilder(app.Name()).TypedAttribute(&registry.NodeEvent{Node: expiredNode, IsRegistration: false}))
+		if !emitNodeExpired {
+			continue
+		}
+		ctx.EmitEvent(api.NewEventBuilder(app.Name()).TypedAttribute(&registry.NodeExpiredEvent{
+			NodeID:     expiredNode.ID,
+			EntityID:   expiredNode.EntityID,
+			Expiration: expiredNode.Expiration,
+		}))
 	}
 	// Emit the node list epoch event.
 	ctx.EmitEvent(api.NewEventBuilder(app.Name()).TypedAttribute(&registry.NodeListEpochEvent{}))
@@ -0,0 +1,162 @@
+package registry
+
+import (
+	"testing"
+
+	requirePkg "github.com/stretchr/testify/require"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	eventsAPI "github.com/oasisprotocol/oasis-core/go/consensus/api/events"
+	consensusState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/abci/state"
+	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+	registryState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/registry/state"
+	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/staking/state"
+	consensusGenesis "github.com/oasisprotocol/oasis-core/go/consensus/genesis"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+	"github.com/oasisprotocol/oasis-core/go/upgrade/migrations"
+)
+
+func TestNodeExpiration(t *testing.T) {
+	require := requirePkg.New(t)
+
+	cfg := abciAPI.MockApplicationStateConfig{}
+	appState := abciAPI.NewMockApplicationState(&cfg)
+	ctx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx.Close()
+
+	var md abciAPI.NoopMessageDispatcher
+	app := registryApplication{appState, &md}
+	state := registryState.NewMutableState(ctx.State())
+
+	err := state.SetConsensusParameters(ctx, &registry.ConsensusParameters{
+		NodeTombstoneRetention: 2,
+	})
+	require.NoError(err, "registry.SetConsensusParameters")
+	stakeState := stakingState.NewMutableState(ctx.State())
+	err = stakeState.SetConsensusParameters(ctx, &staking.ConsensusParameters{
+		DebondingInterval: 1,
+		DebugBypassStake:  true,
+	})
+	require.NoError(err, "staking.SetConsensusParameters")
+	consState := consensusState.NewMutableState(ctx.State())
+	err = consState.SetConsensusParameters(ctx, &consensusGenesis.Parameters{})
+	require.NoError(err, "consensus.SetConsensusParameters")
+
+	// Register two nodes that expire at different epochs.
+	entitySigner := memorySigner.NewTestSigner("consensus/cometbft/apps/registry: node expiration entity signer")
+	registerNode := func(name string, expiration uint64) *node.Node {
+		nodeSigner := memorySigner.NewTestSigner("consensus/cometbft/apps/registry: node expiration node signer: " + name)
+		nod := &node.Node{
+			Versioned:  cbor.NewVersioned(node.LatestNodeDescriptorVersion),
+			ID:         nodeSigner.Public(),
+			Consensus:  node.ConsensusInfo{ID: nodeSigner.Public()},
+			EntityID:   entitySigner.Public(),
+			Expiration: expiration,
+		}
+		sigNode, sErr := node.MultiSignNode([]signature.Signer{nodeSigner}, registry.RegisterNodeSignatureContext, nod)
+		require.NoError(sErr, "MultiSignNode")
+		err = state.SetNode(ctx, nil, nod, sigNode)
+		require.NoError(err, "SetNode")
+		err = state.SetNodeStatus(ctx, nod.ID, &registry.NodeStatus{})
+		require.NoError(err, "SetNodeStatus")
+		return nod
+	}
+	n1 := registerNode("n1", 1)
+	n2 := registerNode("n2", 2)
+
+	// Transition to the given epoch and return the emitted node expired events.
+	epochChanged := func(epoch beacon.EpochTime) []*registry.NodeExpiredEvent {
+		epochCtx := appState.NewContext(abciAPI.ContextEndBlock)
+		defer epochCtx.Close()
+
+		err = app.onRegistryEpochChanged(epochCtx, epoch)
+		require.NoError(err, "onRegistryEpochChanged")
+
+		var events []*registry.NodeExpiredEvent
+		for _, ev := range epochCtx.GetEvents() {
+			for _, pair := range ev.GetAttributes() {
+				if !eventsAPI.IsAttributeKind(pair.GetKey(), &registry.NodeExpiredEvent{}) {
+					continue
+				}
+				var e registry.NodeExpiredEvent
+				err = eventsAPI.DecodeValue(pair.GetValue(), &e)
+				require.NoError(err, "DecodeValue")
+				events = append(events, &e)
+			}
+		}
+		return events
+	}
+	requireTombstone := func(n *node.Node, removedAt beacon.EpochTime) {
+		tombstone, tErr := state.NodeTombstone(ctx, n.ID)
+		require.NoError(tErr, "NodeTombstone")
+		require.Equal(removedAt, tombstone.RemovedAt, "tombstone should have the correct removal epoch")
+		require.Equal(n.ID, tombstone.Node.ID, "tombstone should contain the node descriptor")
+	}
+	requireNoTombstone := func(n *node.Node) {
+		_, tErr := state.NodeTombstone(ctx, n.ID)
+		require.Equal(registry.ErrNoSuchNodeTombstone, tErr, "node should not have a tombstone")
+	}
+
+	// Epoch 2: the first node expires, but no event is emitted before the feature version.
+	events := epochChanged(2)
+	require.Empty(events, "node expired events should not be emitted before the feature version")
+	status, err := state.NodeStatus(ctx, n1.ID)
+	require.NoError(err, "NodeStatus")
+	require.True(status.ExpirationProcessed, "expiration should be processed")
+
+	err = consState.SetConsensusParameters(ctx, &consensusGenesis.Parameters{
+		FeatureVersion: &migrations.Version250,
+	})
+	require.NoError(err, "consensus.SetConsensusParameters")
+
+	// Epoch 3: the second node expires and the event is emitted exactly once, while the first
+	// node is removed after the debonding interval and a tombstone is kept.
+	events = epochChanged(3)
+	require.Len(events, 1, "node expired event should be emitted")
+	require.Equal(n2.ID, events[0].NodeID)
+	require.Equal(n2.EntityID, events[0].EntityID)
+	require.EqualValues(2, events[0].Expiration)
+	_, err = state.Node(ctx, n1.ID)
+	require.Equal(registry.ErrNoSuchNode, err, "node should be removed")
+	requireTombstone(n1, 3)
+	_, err = state.Node(ctx, n2.ID)
+	require.NoError(err, "expired node should be kept for the debonding interval")
+	requireNoTombstone(n2)
+
+	// Epoch 4: the second node is removed.
+	events = epochChanged(4)
+	require.Empty(events, "node expired events should only be emitted once")
+	_, err = state.Node(ctx, n2.ID)
+	require.Equal(registry.ErrNoSuchNode, err, "node should be removed")
+	requireTombstone(n1, 3)
+	requireTombstone(n2, 4)
+
+	// Tombstones should be exported in the genesis document.
+	rq := &registryQuerier{appState, state.ImmutableState, 0}
+	gen, err := rq.Genesis(ctx)
+	require.NoError(err, "Genesis")
+	require.Len(gen.NodeTombstones, 2, "tombstones should be exported")
+
+	// Epoch 5: tombstones are kept for the retention period.
+	epochChanged(5)
+	requireTombstone(n1, 3)
+	requireTombstone(n2, 4)
+
+	// Epoch 6: the first tombstone is pruned after the retention period.
+	epochChanged(6)
+	requireNoTombstone(n1)
+	requireTombstone(n2, 4)
+
+	// Epoch 7: the second tombstone is pruned.
+	epochChanged(7)
+	requireNoTombstone(n2)
+
+	gen, err = rq.Genesis(ctx)
+	require.NoError(err, "Genesis")
+	require.Empty(gen.NodeTombstones, "pruned tombstones should not be exported")
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
@@ -76,6 +77,30 @@ var (
 	//
 	// Value is empty.
 	runtimeByEntityKeyFmt = consensus.KeyFormat.New(0x19, keyformat.H(&signature.PublicKey{}), keyformat.H(&common.Namespace{}))
+	// validatorElectionOptOutKeyFmt is the key format used for entities that abstain from
+	// validator elections.
+	//
+	// Value is empty.
+	validatorElectionOptOutKeyFmt = consensus.KeyFormat.New(0x1a, &signature.PublicKey{})
+	// entityNodesKeyFmt is the key format used for entity node allowlists that were changed
+	// incrementally and override the node allowlists in the signed entity descriptors.
+	//
+	// Value is CBOR-serialized list of node identifiers.
+	entityNodesKeyFmt = consensus.KeyFormat.New(0x1b, &signature.PublicKey{})
+	// nodeTombstoneKeyFmt is the key format used for tombstones of expired nodes that have been
+	// removed from the registry.
+	//
+	// Value is CBOR-serialized node tombstone.
+	nodeTombstoneKeyFmt = consensus.KeyFormat.New(0x1c, &signature.PublicKey{})
+	// nodeTombstoneByEpochKeyFmt is the key format used for the node tombstone by removal epoch
+	// index.
+	//
+	// Value is empty.
+	nodeTombstoneByEpochKeyFmt = consensus.KeyFormat.New(0x1d, uint64(0), &signature.PublicKey{})
+	// multiSignedEntityKeyFmt is the key format used for entities controlled by a signer set.
+	//
+	// Value is CBOR-serialized multi-signed entity.
+	multiSignedEntityKeyFmt = consensus.KeyFormat.New(0x1e, keyformat.H(&signature.PublicKey{}))
 )
 
 // ImmutableState is the immutable registry state wrapper.
@@ -94,21 +119,75 @@ func (s *ImmutableState) Entity(ctx context.Context, id signature.PublicKey) (*e
 	if err != nil {
 		return nil, err
 	}
-	if signedEntityRaw == nil {
-		return nil, registry.ErrNoSuchEntity
-	}
 
-	var signedEntity entity.SignedEntity
-	if err = cbor.Unmarshal(signedEntityRaw, &signedEntity); err != nil {
-		return nil, abciAPI.UnavailableStateError(err)
+	var blob []byte
+	switch signedEntityRaw {
+	case nil:
+		// Entity may be controlled by a signer set.
+		var msEnt *entity.MultiSignedEntity
+		if msEnt, err = s.MultiSignedEntity(ctx, id); err != nil {
+			return nil, err
+		}
+		blob = msEnt.Blob
+	default:
+		var signedEntity entity.SignedEntity
+		if err = cbor.Unmarshal(signedEntityRaw, &signedEntity); err != nil {
+			return nil, abciAPI.UnavailableStateError(err)
+		}
+		blob = signedEntity.Blob
 	}
+
 	var entity entity.Entity
-	if err = cbor.Unmarshal(signedEntity.Blob, &entity); err != nil {
+	if err = cbor.Unmarshal(blob, &entity); err != nil {
 		return nil, abciAPI.UnavailableStateError(err)
 	}
+	if err = s.applyEntityNodes(ctx, &entity); err != nil {
+		return nil, err
+	}
 	return &entity, nil
 }
 
+// MultiSignedEntity looks up a registered entity controlled by a signer set by its identifier.
+func (s *ImmutableState) MultiSignedEntity(ctx context.Context, id signature.PublicKey) (*entity.MultiSignedEntity, error) {
+	raw, err := s.is.Get(ctx, multiSignedEntityKeyFmt.Encode(&id))
+	if err != nil {
+		return nil, abciAPI.UnavailableStateError(err)
+	}
+	if raw == nil {
+		return nil, registry.ErrNoSuchEntity
+	}
+
+	var msEnt entity.MultiSignedEntity
+	if err = cbor.Unmarshal(raw, &msEnt); err != nil {
+		return nil, abciAPI.UnavailableStateError(err)
+	}
+	return &msEnt, nil
+}
+
+// MultiSignedEntities returns a list of all registered entities controlled by a signer set.
+func (s *ImmutableState) MultiSignedEntities(ctx context.Context) ([]*entity.MultiSignedEntity, error) {
+	it := s.is.NewIterator(ctx)
+	defer it.Close()
+
+	var entities []*entity.MultiSignedEntity
+	for it.Seek(multiSignedEntityKeyFmt.Encode()); it.Valid(); it.Next() {
+		if !multiSignedEntityKeyFmt.Decode(it.Key()) {
+			break
+		}
+
+		var msEnt entity.MultiSignedEntity
+		if err := cbor.Unmarshal(it.Value(), &msEnt); err != nil {
+			return nil, abciAPI.UnavailableStateError(err)
+		}
+
+		entities = append(entities, &msEnt)
+	}
+	if it.Err() != nil {
+		return nil, abciAPI.UnavailableStateError(it.Err())
+	}
+	return entities, nil
+}
+
 // Entities returns a list of all registered entities.
 func (s *ImmutableState) Entities(ctx context.Context) ([]*entity.Entity, error) {
 	it := s.is.NewIterator(ctx)
@@ -134,9 +213,85 @@ func (s *ImmutableState) Entities(ctx context.Context) ([]*entity.Entity, error)
 	if it.Err() != nil {
 		return nil, abciAPI.UnavailableStateError(it.Err())
 	}
+
+	msEnts, err := s.MultiSignedEntities(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, msEnt := range msEnts {
+		var entity entity.Entity
+		if err = cbor.Unmarshal(msEnt.Blob, &entity); err != nil {
+			return nil, abciAPI.UnavailableStateError(err)
+		}
+
+		entities = append(entities, &entity)
+	}
+
+	for _, entity := range entities {
+		if err := s.applyEntityNodes(ctx, entity); err != nil {
+			return nil, err
+		}
+	}
 	return entities, nil
 }
 
+// EntityNodes returns the incrementally changed node allowlist of the given entity, if any.
+//
+// The returned allowlist overrides the one in the signed entity descriptor.
+func (s *ImmutableState) EntityNodes(ctx context.Context, id signature.PublicKey) ([]signature.PublicKey, bool, error) {
+	raw, err := s.is.Get(ctx, entityNodesKeyFmt.Encode(&id))
+	if err != nil {
+		return nil, false, abciAPI.UnavailableStateError(err)
+	}
+	if raw == nil {
+		return nil, false, nil
+	}
+
+	var nodes []signature.PublicKey
+	if err = cbor.Unmarshal(raw, &nodes); err != nil {
+		return nil, false, abciAPI.UnavailableStateError(err)
+	}
+	return nodes, true, nil
+}
+
+// AllEntityNodes returns all incrementally changed entity node allowlists.
+func (s *ImmutableState) AllEntityNodes(ctx context.Context) (map[signature.PublicKey][]signature.PublicKey, error) {
+	it := s.is.NewIterator(ctx)
+	defer it.Close()
+
+	var allNodes map[signature.PublicKey][]signature.PublicKey
+	for it.Seek(entityNodesKeyFmt.Encode()); it.Valid(); it.Next() {
+		var id signature.PublicKey
+		if !entityNodesKeyFmt.Decode(it.Key(), &id) {
+			break
+		}
+
+		var nodes []signature.PublicKey
+		if err := cbor.Unmarshal(it.Value(), &nodes); err != nil {
+			return nil, abciAPI.UnavailableStateError(err)
+		}
+		if allNodes == nil {
+			allNodes = make(map[signature.PublicKey][]signature.PublicKey)
+		}
+		allNodes[id] = nodes
+	}
+	if it.Err() != nil {
+		return nil, abciAPI.UnavailableStateError(it.Err())
+	}
+	return allNodes, nil
+}
+
+func (s *ImmutableState) applyEntityNodes(ctx context.Context, ent *entity.Entity) error {
+	nodes, ok, err := s.EntityNodes(ctx, ent.ID)
+	if err != nil {
+		return err
+	}
+	if ok {
+		ent.Nodes = nodes
+	}
+	return nil
+}
+
 // SignedEntities returns a list of all registered entities (signed).
 func (s *ImmutableState) SignedEntities(ctx context.Context) ([]*entity.SignedEntity, error) {
 	it := s.is.NewIterator(ctx)
@@ -393,6 +548,46 @@ func (s *ImmutableState) NodeStatus(ctx context.Context, id signature.PublicKey)
 	return &status, nil
 }
 
+// NodeTombstone looks up the tombstone of a removed node by its identifier.
+func (s *ImmutableState) NodeTombstone(ctx context.Context, id signature.PublicKey) (*registry.NodeTombstone, error) {
+	value, err := s.is.Get(ctx, nodeTombstoneKeyFmt.Encode(&id))
+	if err != nil {
+		return nil, abciAPI.UnavailableStateError(err)
+	}
+	if value == nil {
+		return nil, registry.ErrNoSuchNodeTombstone
+	}
+
+	var tombstone registry.NodeTombstone
+	if err := cbor.Unmarshal(value, &tombstone); err != nil {
+		return nil, abciAPI.UnavailableStateError(err)
+	}
+	return &tombstone, nil
+}
+
+// NodeTombstones returns the tombstones of all removed nodes.
+func (s *ImmutableState) NodeTombstones(ctx context.Context) ([]*registry.NodeTombstone, error) {
+	it := s.is.NewIterator(ctx)
+	defer it.Close()
+
+	var tombstones []*registry.NodeTombstone
+	for it.Seek(nodeTombstoneKeyFmt.Encode()); it.Valid(); it.Next() {
+		if !nodeTombstoneKeyFmt.Decode(it.Key()) {
+			break
+		}
+
+		var tombstone registry.NodeTombstone
+		if err := cbor.Unmarshal(it.Value(), &tombstone); err != nil {
+			return nil, abciAPI.UnavailableStateError(err)
+		}
+		tombstones = append(tombstones, &tombstone)
+	}
+	if it.Err() != nil {
+		return nil, abciAPI.UnavailableStateError(it.Err())
+	}
+	return tombstones, nil
+}
+
 // GetEntityNodes returns nodes registered by given entity.
 // Note that this returns both active and expired nodes.
 func (s *ImmutableState) GetEntityNodes(ctx context.Context, id signature.PublicKey) ([]*node.Node, error) {
@@ -465,6 +660,35 @@ func (s *ImmutableState) HasEntityRuntimes(ctx context.Context, id signature.Pub
 	return false, abciAPI.UnavailableStateError(it.Err())
 }
 
+// IsValidatorElectionOptOut returns true iff the given entity abstains from validator elections.
+func (s *ImmutableState) IsValidatorElectionOptOut(ctx context.Context, id signature.PublicKey) (bool, error) {
+	value, err := s.is.Get(ctx, validatorElectionOptOutKeyFmt.Encode(&id))
+	if err != nil {
+		return false, abciAPI.UnavailableStateError(err)
+	}
+	return value != nil, nil
+}
+
+// ValidatorElectionOptOuts returns the list of all entities that abstain from validator elections.
+func (s *ImmutableState) ValidatorElectionOptOuts(ctx context.Context) ([]signature.PublicKey, error) {
+	it := s.is.NewIterator(ctx)
+	defer it.Close()
+
+	var ids []signature.PublicKey
+	for it.Seek(validatorElectionOptOutKeyFmt.Encode()); it.Valid(); it.Next() {
+		var id signature.PublicKey
+		if !validatorElectionOptOutKeyFmt.Decode(it.Key(), &id) {
+			break
+		}
+
+		ids = append(ids, id)
+	}
+	if it.Err() != nil {
+		return nil, abciAPI.UnavailableStateError(it.Err())
+	}
+	return ids, nil
+}
+
 // ConsensusParameters returns the registry consensus parameters.
 func (s *ImmutableState) ConsensusParameters(ctx context.Context) (*registry.ConsensusParameters, error) {
 	raw, err := s.is.Get(ctx, parametersKeyFmt.Encode())
@@ -516,29 +740,82 @@ type MutableState struct {
 }
 
 // SetEntity sets a signed entity descriptor for a registered entity.
+//
+// Any incrementally changed node allowlist of the entity is discarded in favor of the one in
+// the signed entity descriptor.
 func (s *MutableState) SetEntity(ctx context.Context, ent *entity.Entity, sigEnt *entity.SignedEntity) error {
-	err := s.ms.Insert(ctx, signedEntityKeyFmt.Encode(&ent.ID), cbor.Marshal(sigEnt))
+	if err := s.ms.Insert(ctx, signedEntityKeyFmt.Encode(&ent.ID), cbor.Marshal(sigEnt)); err != nil {
+		return abciAPI.UnavailableStateError(err)
+	}
+	if err := s.ms.Remove(ctx, multiSignedEntityKeyFmt.Encode(&ent.ID)); err != nil {
+		return abciAPI.UnavailableStateError(err)
+	}
+	err := s.ms.Remove(ctx, entityNodesKeyFmt.Encode(&ent.ID))
+	return abciAPI.UnavailableStateError(err)
+}
+
+// SetMultiSignedEntity sets a multi-signed entity descriptor for an entity controlled by
+// a signer set.
+func (s *MutableState) SetMultiSignedEntity(ctx context.Context, ent *entity.Entity, msEnt *entity.MultiSignedEntity) error {
+	if err := s.ms.Insert(ctx, multiSignedEntityKeyFmt.Encode(&ent.ID), cbor.Marshal(msEnt)); err != nil {
+		return abciAPI.UnavailableStateError(err)
+	}
+	if err := s.ms.Remove(ctx, signedEntityKeyFmt.Encode(&ent.ID)); err != nil {
+		return abciAPI.UnavailableStateError(err)
+	}
+	err := s.ms.Remove(ctx, entityNodesKeyFmt.Encode(&ent.ID))
+	return abciAPI.UnavailableStateError(err)
+}
+
+// SetEntityNodes sets the incrementally changed node allowlist of a registered entity.
+func (s *MutableState) SetEntityNodes(ctx context.Context, id signature.PublicKey, nodes []signature.PublicKey) error {
+	err := s.ms.Insert(ctx, entityNodesKeyFmt.Encode(&id), cbor.Marshal(nodes))
 	return abciAPI.UnavailableStateError(err)
 }
 
 // RemoveEntity removes a previously registered entity.
 func (s *MutableState) RemoveEntity(ctx context.Context, id signature.PublicKey) (*entity.Entity, error) {
+	var blob []byte
 	data, err := s.ms.RemoveExisting(ctx, signedEntityKeyFmt.Encode(&id))
 	if err != nil {
 		return nil, abciAPI.UnavailableStateError(err)
 	}
-	if data != nil {
-		var removedSignedEntity entity.SignedEntity
-		if err = cbor.Unmarshal(data, &removedSignedEntity); err != nil {
+	switch data {
+	case nil:
+		// Entity may be controlled by a signer set.
+		if data, err = s.ms.RemoveExisting(ctx, multiSignedEntityKeyFmt.Encode(&id)); err != nil {
+			return nil, abciAPI.UnavailableStateError(err)
+		}
+		if data == nil {
+			return nil, registry.ErrNoSuchEntity
+		}
+		var removedMultiSignedEntity entity.MultiSignedEntity
+		if err = cbor.Unmarshal(data, &removedMultiSignedEntity); err != nil {
 			return nil, abciAPI.UnavailableStateError(err)
 		}
-		var removedEntity entity.Entity
-		if err = cbor.Unmarshal(removedSignedEntity.Blob, &removedEntity); err != nil {
+		blob = removedMultiSignedEntity.Blob
+	default:
+		var removedSignedEntity entity.SignedEntity
+		if err = cbor.Unmarshal(data, &removedSignedEntity); err != nil {
 			return nil, abciAPI.UnavailableStateError(err)
 		}
-		return &removedEntity, nil
+		blob = removedSignedEntity.Blob
 	}
-	return nil, registry.ErrNoSuchEntity
+
+	var removedEntity entity.Entity
+	if err = cbor.Unmarshal(blob, &removedEntity); err != nil {
+		return nil, abciAPI.UnavailableStateError(err)
+	}
+	if err = s.applyEntityNodes(ctx, &removedEntity); err != nil {
+		return nil, err
+	}
+	if err = s.ms.Remove(ctx, entityNodesKeyFmt.Encode(&id)); err != nil {
+		return nil, abciAPI.UnavailableStateError(err)
+	}
+	if err = s.SetValidatorElectionOptOut(ctx, id, false); err != nil {
+		return nil, err
+	}
+	return &removedEntity, nil
 }
 
 // SetNode sets a signed node descriptor for a registered node.
@@ -696,6 +973,87 @@ func (s *MutableState) SetNodeStatus(ctx context.Context, id signature.PublicKey
 	return abciAPI.UnavailableStateError(err)
 }
 
+// SetNodeTombstone sets the tombstone of a removed node, replacing any previous tombstone of the
+// same node.
+func (s *MutableState) SetNodeTombstone(ctx context.Context, tombstone *registry.NodeTombstone) error {
+	id := tombstone.Node.ID
+	existing, err := s.NodeTombstone(ctx, id)
+	switch err {
+	case nil:
+		if err = s.ms.Remove(ctx, nodeTombstoneByEpochKeyFmt.Encode(uint64(existing.RemovedAt), &id)); err != nil {
+			return abciAPI.UnavailableStateError(err)
+		}
+	case registry.ErrNoSuchNodeTombstone:
+	default:
+		return err
+	}
+
+	if err = s.ms.Insert(ctx, nodeTombstoneKeyFmt.Encode(&id), cbor.Marshal(tombstone)); err != nil {
+		return abciAPI.UnavailableStateError(err)
+	}
+	err = s.ms.Insert(ctx, nodeTombstoneByEpochKeyFmt.Encode(uint64(tombstone.RemovedAt), &id), []byte(""))
+	return abciAPI.UnavailableStateError(err)
+}
+
+// RemoveNodeTombstone removes the tombstone of the given node, if any.
+func (s *MutableState) RemoveNodeTombstone(ctx context.Context, id signature.PublicKey) error {
+	tombstone, err := s.NodeTombstone(ctx, id)
+	switch err {
+	case nil:
+	case registry.ErrNoSuchNodeTombstone:
+		return nil
+	default:
+		return err
+	}
+
+	if err = s.ms.Remove(ctx, nodeTombstoneByEpochKeyFmt.Encode(uint64(tombstone.RemovedAt), &id)); err != nil {
+		return abciAPI.UnavailableStateError(err)
+	}
+	err = s.ms.Remove(ctx, nodeTombstoneKeyFmt.Encode(&id))
+	return abciAPI.UnavailableStateError(err)
+}
+
+// RemoveNodeTombstonesBefore removes all node tombstones of nodes that were removed before the
+// given epoch.
+func (s *MutableState) RemoveNodeTombstonesBefore(ctx context.Context, epoch beacon.EpochTime) error {
+	it := s.is.NewIterator(ctx)
+	defer it.Close()
+
+	var keys [][]byte
+	for it.Seek(nodeTombstoneByEpochKeyFmt.Encode()); it.Valid(); it.Next() {
+		var (
+			removedAt uint64
+			id        signature.PublicKey
+		)
+		if !nodeTombstoneByEpochKeyFmt.Decode(it.Key(), &removedAt, &id) || removedAt >= uint64(epoch) {
+			break
+		}
+
+		keys = append(keys, nodeTombstoneByEpochKeyFmt.Encode(removedAt, &id), nodeTombstoneKeyFmt.Encode(&id))
+	}
+	if it.Err() != nil {
+		return abciAPI.UnavailableStateError(it.Err())
+	}
+
+	for _, key := range keys {
+		if err := s.ms.Remove(ctx, key); err != nil {
+			return abciAPI.UnavailableStateError(err)
+		}
+	}
+	return nil
+}
+
+// SetValidatorElectionOptOut sets whether the given entity abstains from validator elections.
+func (s *MutableState) SetValidatorElectionOptOut(ctx context.Context, id signature.PublicKey, optOut bool) error {
+	var err error
+	if optOut {
+		err = s.ms.Insert(ctx, validatorElectionOptOutKeyFmt.Encode(&id), []byte(""))
+	} else {
+		err = s.ms.Remove(ctx, validatorElectionOptOutKeyFmt.Encode(&id))
+	}
+	return abciAPI.UnavailableStateError(err)
+}
+
 // SetConsensusParameters sets registry consensus parameters.
 //
 // NOTE: This method must only be called from InitChain/EndBlock contexts.
@@ -158,3 +158,55 @@ func TestNodeUpdate(t *testing.T) {
 	require.Error(err, "TLS mapping should be gone")
 	require.Equal(registry.ErrNoSuchNode, err, "TLS mapping should be gone")
 }
+
+func TestNodeTombstones(t *testing.T) {
+	require := require.New(t)
+
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx.Close()
+
+	s := NewMutableState(ctx.State())
+
+	_, err := s.NodeTombstone(ctx, nodeSigner.Public())
+	require.Equal(registry.ErrNoSuchNodeTombstone, err, "NodeTombstone should fail for unknown nodes")
+
+	n1 := &node.Node{
+		Versioned: cbor.NewVersioned(node.LatestNodeDescriptorVersion),
+		ID:        nodeSigner.Public(),
+		EntityID:  entitySigner.Public(),
+	}
+	n2 := &node.Node{
+		Versioned: cbor.NewVersioned(node.LatestNodeDescriptorVersion),
+		ID:        p2pSigner1.Public(),
+		EntityID:  entitySigner.Public(),
+	}
+
+	err = s.SetNodeTombstone(ctx, &registry.NodeTombstone{Node: n1, RemovedAt: 10})
+	require.NoError(err, "SetNodeTombstone")
+	err = s.SetNodeTombstone(ctx, &registry.NodeTombstone{Node: n2, RemovedAt: 12})
+	require.NoError(err, "SetNodeTombstone")
+
+	tombstone, err := s.NodeTombstone(ctx, n1.ID)
+	require.NoError(err, "NodeTombstone")
+	require.EqualValues(10, tombstone.RemovedAt, "tombstone should have the correct removal epoch")
+	require.Equal(n1.ID, tombstone.Node.ID, "tombstone should contain the node descriptor")
+
+	// Replacing a tombstone should also update the removal epoch index.
+	err = s.SetNodeTombstone(ctx, &registry.NodeTombstone{Node: n1, RemovedAt: 14})
+	require.NoError(err, "SetNodeTombstone")
+
+	err = s.RemoveNodeTombstonesBefore(ctx, 13)
+	require.NoError(err, "RemoveNodeTombstonesBefore")
+
+	_, err = s.NodeTombstone(ctx, n2.ID)
+	require.Equal(registry.ErrNoSuchNodeTombstone, err, "old tombstones should be pruned")
+	tombstone, err = s.NodeTombstone(ctx, n1.ID)
+	require.NoError(err, "NodeTombstone")
+	require.EqualValues(14, tombstone.RemovedAt, "replaced tombstone should not be pruned")
+
+	err = s.RemoveNodeTombstonesBefore(ctx, 15)
+	require.NoError(err, "RemoveNodeTombstonesBefore")
+	_, err = s.NodeTombstone(ctx, n1.ID)
+	require.Equal(registry.ErrNoSuchNodeTombstone, err, "all tombstones should be pruned")
+}
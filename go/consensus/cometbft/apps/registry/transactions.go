@@ -4,7 +4,9 @@ import (
 	"fmt"
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/entity"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
 	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
@@ -12,8 +14,10 @@ import (
 	registryApi "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/registry/api"
 	registryState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/registry/state"
 	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/staking/state"
+	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/features"
 	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+	"github.com/oasisprotocol/oasis-core/go/upgrade/migrations"
 )
 
 func (app *registryApplication) registerEntity(
@@ -57,37 +61,132 @@ func (app *registryApplication) registerEntity(
 		return registry.ErrIncorrectTxSigner
 	}
 
-	stakeState := stakingState.NewMutableState(ctx.State())
-	stakeParams, err := stakeState.ConsensusParameters(ctx)
+	if ent.SignerSet != nil {
+		// Allow entities controlled by a signer set with the 25.0 release.
+		var enabled bool
+		if enabled, err = features.IsFeatureVersion(ctx, migrations.Version250); err != nil {
+			return err
+		}
+		if !enabled {
+			return fmt.Errorf("%w: entity signer sets not enabled", registry.ErrForbidden)
+		}
+	}
+	if len(ent.NodeAuthorizations) > 0 {
+		// Allow role-scoped node authorizations with the 25.0 release.
+		var enabled bool
+		if enabled, err = features.IsFeatureVersion(ctx, migrations.Version250); err != nil {
+			return err
+		}
+		if !enabled {
+			return fmt.Errorf("%w: entity node authorizations not enabled", registry.ErrForbidden)
+		}
+	}
+
+	// Entities controlled by a signer set can only be changed by the signer set.
+	if err = checkNotSignerSetControlled(ctx, state, ent.ID); err != nil {
+		return err
+	}
+
+	if err = addEntityStakeClaim(ctx, ent); err != nil {
+		return err
+	}
+
+	if err = state.SetEntity(ctx, ent, sigEnt); err != nil {
+		return fmt.Errorf("failed to set entity: %w", err)
+	}
+
+	ctx.Logger().Debug("RegisterEntity: registered",
+		"entity", ent,
+	)
+
+	ctx.EmitEvent(api.NewEventBuilder(app.Name()).TypedAttribute(&registry.EntityEvent{Entity: ent, IsRegistration: true}))
+
+	return nil
+}
+
+func (app *registryApplication) registerMultiSignedEntity(
+	ctx *api.Context,
+	state *registryState.MutableState,
+	msEnt *entity.MultiSignedEntity,
+) error {
+	// Allow entities controlled by a signer set with the 25.0 release.
+	enabled, err := features.IsFeatureVersion(ctx, migrations.Version250)
 	if err != nil {
-		ctx.Logger().Error("RegisterEntity: failed to fetch staking consensus parameters",
+		return err
+	}
+	if !enabled {
+		return fmt.Errorf("%w: entity signer sets not enabled", registry.ErrForbidden)
+	}
+
+	ent, err := registry.VerifyRegisterMultiSignedEntityArgs(ctx.Logger(), msEnt, ctx.IsInitChain(), false)
+	if err != nil {
+		return err
+	}
+
+	if ctx.IsCheckOnly() {
+		return nil
+	}
+
+	// Charge gas for this transaction.
+	params, err := state.ConsensusParameters(ctx)
+	if err != nil {
+		ctx.Logger().Error("RegisterMultiSignedEntity: failed to fetch registry consensus parameters",
 			"err", err,
 		)
 		return err
 	}
+	if err = ctx.Gas().UseGas(1, registry.GasOpRegisterEntity, params.GasCosts); err != nil {
+		return err
+	}
+	if err = ctx.Gas().UseGas(len(ent.Nodes), registry.GasOpRegisterNode, params.GasCosts); err != nil {
+		return err
+	}
 
-	if !stakeParams.DebugBypassStake {
-		acctAddr := staking.NewAddress(ent.ID)
-		if err = stakingState.AddStakeClaim(
-			ctx,
-			acctAddr,
-			registry.StakeClaimRegisterEntity,
-			staking.GlobalStakeThresholds(staking.KindEntity),
-		); err != nil {
-			ctx.Logger().Debug("RegisterEntity: Insufficient stake",
-				"err", err,
-				"entity", ent.ID,
-				"account", acctAddr,
-			)
+	// Return early if simulating since this is just estimating gas.
+	if ctx.IsSimulation() {
+		return nil
+	}
+
+	// NOTE: If this is invoked during InitChain then there is no actual transaction
+	//       and thus no transaction signer so we must skip the authorization checks.
+	if !ctx.IsInitChain() {
+		// Make sure the signer of the transaction is one of the signers of the entity.
+		if !msEnt.IsSignedBy(ctx.TxSigner()) {
+			return registry.ErrIncorrectTxSigner
+		}
+
+		// Make sure that the signers are authorized to change the entity.
+		var existing *entity.Entity
+		existing, err = state.Entity(ctx, ent.ID)
+		switch err {
+		case nil:
+		case registry.ErrNoSuchEntity:
+		default:
 			return err
 		}
+		switch {
+		case existing != nil && existing.SignerSet != nil:
+			// Changes must be authorized by the current signer set.
+			if !existing.SignerSet.Verify(msEnt.Signatures) {
+				return fmt.Errorf("%w: insufficient current signer set signatures", registry.ErrInvalidSignature)
+			}
+		default:
+			// Changes must be authorized by the entity key.
+			if !msEnt.IsSignedBy(ent.ID) {
+				return fmt.Errorf("%w: missing entity signature", registry.ErrInvalidSignature)
+			}
+		}
 	}
 
-	if err = state.SetEntity(ctx, ent, sigEnt); err != nil {
+	if err = addEntityStakeClaim(ctx, ent); err != nil {
+		return err
+	}
+
+	if err = state.SetMultiSignedEntity(ctx, ent, msEnt); err != nil {
 		return fmt.Errorf("failed to set entity: %w", err)
 	}
 
-	ctx.Logger().Debug("RegisterEntity: registered",
+	ctx.Logger().Debug("RegisterMultiSignedEntity: registered",
 		"entity", ent,
 	)
 
@@ -96,6 +195,54 @@ func (app *registryApplication) registerEntity(
 	return nil
 }
 
+// addEntityStakeClaim adds the stake claim for registering the given entity.
+func addEntityStakeClaim(ctx *api.Context, ent *entity.Entity) error {
+	stakeState := stakingState.NewMutableState(ctx.State())
+	stakeParams, err := stakeState.ConsensusParameters(ctx)
+	if err != nil {
+		ctx.Logger().Error("RegisterEntity: failed to fetch staking consensus parameters",
+			"err", err,
+		)
+		return err
+	}
+	if stakeParams.DebugBypassStake {
+		return nil
+	}
+
+	acctAddr := staking.NewAddress(ent.ID)
+	if err = stakingState.AddStakeClaim(
+		ctx,
+		acctAddr,
+		registry.StakeClaimRegisterEntity,
+		staking.GlobalStakeThresholds(staking.KindEntity),
+	); err != nil {
+		ctx.Logger().Debug("RegisterEntity: Insufficient stake",
+			"err", err,
+			"entity", ent.ID,
+			"account", acctAddr,
+		)
+		return err
+	}
+	return nil
+}
+
+// checkNotSignerSetControlled returns an error if the given entity is controlled by a signer set
+// and can thus not be changed by the entity key alone.
+func checkNotSignerSetControlled(ctx *api.Context, state *registryState.MutableState, id signature.PublicKey) error {
+	ent, err := state.Entity(ctx, id)
+	switch err {
+	case nil:
+	case registry.ErrNoSuchEntity:
+		return nil
+	default:
+		return err
+	}
+	if ent.SignerSet != nil {
+		return fmt.Errorf("%w: entity is controlled by a signer set", registry.ErrForbidden)
+	}
+	return nil
+}
+
 func (app *registryApplication) deregisterEntity(ctx *api.Context, state *registryState.MutableState) error {
 	if ctx.IsCheckOnly() {
 		return nil
@@ -120,6 +267,11 @@ func (app *registryApplication) deregisterEntity(ctx *api.Context, state *regist
 
 	id := ctx.TxSigner()
 
+	// Entities controlled by a signer set can only be changed by the signer set.
+	if err = checkNotSignerSetControlled(ctx, state, id); err != nil {
+		return err
+	}
+
 	// Prevent entity deregistration if there are any registered nodes.
 	hasNodes, err := state.HasEntityNodes(ctx, id)
 	if err != nil {
@@ -183,6 +335,106 @@ func (app *registryApplication) deregisterEntity(ctx *api.Context, state *regist
 	return nil
 }
 
+func (app *registryApplication) updateEntityNodes(
+	ctx *api.Context,
+	state *registryState.MutableState,
+	nodes *registry.EntityNodes,
+	add bool,
+) error {
+	// Allow incremental entity node allowlist changes with the 25.0 release.
+	enabled, err := features.IsFeatureVersion(ctx, migrations.Version250)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return fmt.Errorf("%w: incremental entity node changes not enabled", registry.ErrForbidden)
+	}
+
+	if err = nodes.ValidateBasic(); err != nil {
+		return err
+	}
+
+	if ctx.IsCheckOnly() {
+		return nil
+	}
+
+	// Charge gas for this transaction.
+	params, err := state.ConsensusParameters(ctx)
+	if err != nil {
+		ctx.Logger().Error("UpdateEntityNodes: failed to fetch registry consensus parameters",
+			"err", err,
+		)
+		return err
+	}
+	if err = ctx.Gas().UseGas(1, registry.GasOpUpdateEntityNodes, params.GasCosts); err != nil {
+		return err
+	}
+	if add {
+		if err = ctx.Gas().UseGas(len(nodes.Nodes), registry.GasOpRegisterNode, params.GasCosts); err != nil {
+			return err
+		}
+	}
+
+	// Return early if simulating since this is just estimating gas.
+	if ctx.IsSimulation() {
+		return nil
+	}
+
+	// Make sure that the request was signed by a registered entity.
+	ent, err := state.Entity(ctx, ctx.TxSigner())
+	if err != nil {
+		return err
+	}
+	// Entities controlled by a signer set can only be changed by the signer set.
+	if ent.SignerSet != nil {
+		return fmt.Errorf("%w: entity is controlled by a signer set", registry.ErrForbidden)
+	}
+
+	// Apply the requested changes, ignoring nodes which are already (not) in the allowlist.
+	var changed bool
+	newNodes := make([]signature.PublicKey, 0, len(ent.Nodes))
+	switch add {
+	case true:
+		newNodes = append(newNodes, ent.Nodes...)
+		for _, id := range nodes.Nodes {
+			if ent.HasNode(id) {
+				continue
+			}
+			newNodes = append(newNodes, id)
+			changed = true
+		}
+	case false:
+		removed := make(map[signature.PublicKey]bool)
+		for _, id := range nodes.Nodes {
+			removed[id] = true
+		}
+		for _, id := range ent.Nodes {
+			if removed[id] {
+				changed = true
+				continue
+			}
+			newNodes = append(newNodes, id)
+		}
+	}
+	if !changed {
+		return nil
+	}
+
+	if err = state.SetEntityNodes(ctx, ent.ID, newNodes); err != nil {
+		return fmt.Errorf("failed to set entity nodes: %w", err)
+	}
+	ent.Nodes = newNodes
+
+	ctx.Logger().Debug("UpdateEntityNodes: updated",
+		"entity_id", ent.ID,
+		"nodes", ent.Nodes,
+	)
+
+	ctx.EmitEvent(api.NewEventBuilder(app.Name()).TypedAttribute(&registry.EntityEvent{Entity: ent, IsRegistration: true}))
+
+	return nil
+}
+
 func (app *registryApplication) registerNode( // nolint: gocyclo
 	ctx *api.Context,
 	state *registryState.MutableState,
@@ -201,6 +453,18 @@ func (app *registryApplication) registerNode( // nolint: gocyclo
 		)
 		return fmt.Errorf("%v: %w", err, registry.ErrInvalidArgument)
 	}
+
+	// Allow nodes reachable via P2P circuit relays with the 25.0 release.
+	if len(untrustedNode.P2P.Relays) > 0 {
+		enabled, err := features.IsFeatureVersion(ctx, migrations.Version250)
+		if err != nil {
+			return err
+		}
+		if !enabled {
+			return fmt.Errorf("%w: P2P relays not enabled", registry.ErrInvalidArgument)
+		}
+	}
+
 	untrustedEntity, err := state.Entity(ctx, untrustedNode.EntityID)
 	if err != nil {
 		ctx.Logger().Error("RegisterNode: failed to query owning entity",
@@ -372,6 +636,13 @@ func (app *registryApplication) registerNode( // nolint: gocyclo
 		return fmt.Errorf("failed to set node: %w", err)
 	}
 
+	// A previously removed node that registers again is no longer removed.
+	if existingNode == nil {
+		if err = state.RemoveNodeTombstone(ctx, newNode.ID); err != nil {
+			return fmt.Errorf("failed to remove node tombstone: %w", err)
+		}
+	}
+
 	// Query the current node status if it exists.
 	var status *registry.NodeStatus
 	if existingNode != nil {
@@ -482,6 +753,102 @@ func (app *registryApplication) registerNode( // nolint: gocyclo
 	return nil
 }
 
+func (app *registryApplication) freezeNode(
+	ctx *api.Context,
+	state *registryState.MutableState,
+	freeze *registry.FreezeNode,
+) error {
+	// Allow entity-initiated node freezing with the 25.0 release.
+	enabled, err := features.IsFeatureVersion(ctx, migrations.Version250)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return fmt.Errorf("%w: node freezing not enabled", registry.ErrForbidden)
+	}
+
+	if err = freeze.ValidateBasic(); err != nil {
+		return err
+	}
+
+	if ctx.IsCheckOnly() {
+		return nil
+	}
+
+	// Charge gas for this transaction.
+	params, err := state.ConsensusParameters(ctx)
+	if err != nil {
+		ctx.Logger().Error("FreezeNode: failed to fetch registry consensus parameters",
+			"err", err,
+		)
+		return err
+	}
+	if err = ctx.Gas().UseGas(1, registry.GasOpFreezeNode, params.GasCosts); err != nil {
+		return err
+	}
+
+	// Return early if simulating since this is just estimating gas.
+	if ctx.IsSimulation() {
+		return nil
+	}
+
+	// Fetch node descriptor.
+	node, err := state.Node(ctx, freeze.NodeID)
+	if err != nil {
+		ctx.Logger().Error("FreezeNode: failed to fetch node",
+			"err", err,
+			"node_id", freeze.NodeID,
+		)
+		return err
+	}
+	// Make sure that the freeze request was signed by the owning entity.
+	if !ctx.TxSigner().Equal(node.EntityID) {
+		return registry.ErrBadEntityForNode
+	}
+
+	// Fetch node status.
+	status, err := state.NodeStatus(ctx, freeze.NodeID)
+	if err != nil {
+		ctx.Logger().Error("FreezeNode: failed to fetch node status",
+			"err", err,
+			"node_id", freeze.NodeID,
+			"entity_id", node.EntityID,
+		)
+		return err
+	}
+	// Do not override an existing freeze as that could shorten it.
+	if status.IsFrozen() {
+		return registry.ErrNodeAlreadyFrozen
+	}
+
+	// Freeze the node for the requested number of epochs, after which the owning entity
+	// can unfreeze it. Make sure to freeze forever if this would otherwise overflow.
+	epoch, err := app.state.GetEpoch(ctx, ctx.BlockHeight()+1)
+	if err != nil {
+		return err
+	}
+	if epoch > registry.FreezeForever-freeze.Duration {
+		status.Freeze(registry.FreezeForever, freeze.Reason)
+	} else {
+		status.Freeze(epoch+freeze.Duration, freeze.Reason)
+	}
+	if err = state.SetNodeStatus(ctx, node.ID, status); err != nil {
+		return fmt.Errorf("failed to set node status: %w", err)
+	}
+
+	ctx.Logger().Debug("FreezeNode: frozen",
+		"node_id", node.ID,
+		"reason", freeze.Reason,
+	)
+
+	ctx.EmitEvent(api.NewEventBuilder(app.Name()).TypedAttribute(&registry.NodeFrozenEvent{
+		NodeID: node.ID,
+		Reason: freeze.Reason,
+	}))
+
+	return nil
+}
+
 func (app *registryApplication) unfreezeNode(
 	ctx *api.Context,
 	state *registryState.MutableState,
@@ -557,6 +924,72 @@ func (app *registryApplication) unfreezeNode(
 	return nil
 }
 
+func (app *registryApplication) setValidatorElectionOptOut(
+	ctx *api.Context,
+	state *registryState.MutableState,
+	optOut *registry.ValidatorElectionOptOut,
+) error {
+	// Allow entities to opt out of validator elections with the 25.0 release.
+	enabled, err := features.IsFeatureVersion(ctx, migrations.Version250)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return fmt.Errorf("%w: validator election opt-out not enabled", registry.ErrForbidden)
+	}
+
+	if ctx.IsCheckOnly() {
+		return nil
+	}
+
+	// Charge gas for this transaction.
+	params, err := state.ConsensusParameters(ctx)
+	if err != nil {
+		ctx.Logger().Error("SetValidatorElectionOptOut: failed to fetch registry consensus parameters",
+			"err", err,
+		)
+		return err
+	}
+	if err = ctx.Gas().UseGas(1, registry.GasOpSetValidatorElectionOptOut, params.GasCosts); err != nil {
+		return err
+	}
+
+	// Return early if simulating since this is just estimating gas.
+	if ctx.IsSimulation() {
+		return nil
+	}
+
+	// Make sure that the request was signed by a registered entity.
+	id := ctx.TxSigner()
+	if _, err = state.Entity(ctx, id); err != nil {
+		return err
+	}
+
+	current, err := state.IsValidatorElectionOptOut(ctx, id)
+	if err != nil {
+		return err
+	}
+	if current == optOut.OptOut {
+		// Nothing to change.
+		return nil
+	}
+	if err = state.SetValidatorElectionOptOut(ctx, id, optOut.OptOut); err != nil {
+		return fmt.Errorf("failed to set validator election opt-out: %w", err)
+	}
+
+	ctx.Logger().Debug("SetValidatorElectionOptOut: updated",
+		"entity_id", id,
+		"opt_out", optOut.OptOut,
+	)
+
+	ctx.EmitEvent(api.NewEventBuilder(app.Name()).TypedAttribute(&registry.ValidatorElectionOptOutEvent{
+		EntityID: id,
+		OptOut:   optOut.OptOut,
+	}))
+
+	return nil
+}
+
 func (app *registryApplication) registerRuntime( // nolint: gocyclo
 	ctx *api.Context,
 	state *registryState.MutableState,
@@ -587,6 +1020,28 @@ func (app *registryApplication) registerRuntime( // nolint: gocyclo
 		return nil, registry.ErrForbidden
 	}
 
+	// Allow entity whitelist expirations with the 25.0 release.
+	if ewl := rt.AdmissionPolicy.EntityWhitelist; ewl != nil && ewl.HasEntityExpirations() {
+		var enabled bool
+		if enabled, err = features.IsFeatureVersion(ctx, migrations.Version250); err != nil {
+			return nil, err
+		}
+		if !enabled {
+			return nil, fmt.Errorf("%w: entity whitelist expirations not enabled", registry.ErrForbidden)
+		}
+	}
+
+	// Allow slashing for repeated discrepancies with the 25.0 release.
+	if rt.Staking.MaxDiscrepancies != 0 {
+		var enabled bool
+		if enabled, err = features.IsFeatureVersion(ctx, migrations.Version250); err != nil {
+			return nil, err
+		}
+		if !enabled {
+			return nil, fmt.Errorf("%w: repeated discrepancy slashing not enabled", registry.ErrForbidden)
+		}
+	}
+
 	if rt.Kind == registry.KindCompute {
 		if err = registry.VerifyRegisterComputeRuntimeArgs(ctx, ctx.Logger(), rt, state); err != nil {
 			return nil, err
@@ -760,6 +1215,257 @@ func (app *registryApplication) registerRuntime( // nolint: gocyclo
 	return rt, nil
 }
 
+func (app *registryApplication) withdrawRuntimeDeployment(
+	ctx *api.Context,
+	state *registryState.MutableState,
+	wd *registry.WithdrawRuntimeDeployment,
+) error {
+	// Allow withdrawing runtime deployments with the 25.0 release.
+	enabled, err := features.IsFeatureVersion(ctx, migrations.Version250)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return fmt.Errorf("%w: runtime deployment withdrawal not enabled", registry.ErrForbidden)
+	}
+
+	params, err := state.ConsensusParameters(ctx)
+	if err != nil {
+		ctx.Logger().Error("WithdrawRuntimeDeployment: failed to fetch registry consensus parameters",
+			"err", err,
+		)
+		return err
+	}
+
+	if params.DisableRuntimeRegistration {
+		return registry.ErrForbidden
+	}
+
+	if ctx.IsCheckOnly() {
+		return nil
+	}
+
+	// Charge gas for this transaction.
+	if err = ctx.Gas().UseGas(1, registry.GasOpWithdrawRuntimeDeployment, params.GasCosts); err != nil {
+		return err
+	}
+
+	// Return early if simulating since this is just estimating gas.
+	if ctx.IsSimulation() {
+		return nil
+	}
+
+	rt, suspended, err := entityGovernedRuntime(ctx, state, wd.RuntimeID)
+	if err != nil {
+		return err
+	}
+
+	epoch, err := app.state.GetEpoch(ctx, ctx.BlockHeight()+1)
+	if err != nil {
+		return err
+	}
+
+	deployment := rt.DeploymentForVersion(wd.Version)
+	if deployment == nil {
+		return registry.ErrNoSuchRuntimeDeployment
+	}
+	if deployment.ValidFrom <= epoch {
+		ctx.Logger().Debug("WithdrawRuntimeDeployment: deployment is already active",
+			"runtime_id", rt.ID,
+			"version", wd.Version,
+			"valid_from", deployment.ValidFrom,
+			"epoch", epoch,
+		)
+		return registry.ErrRuntimeUpdateNotAllowed
+	}
+
+	deployments := make([]*registry.VersionInfo, 0, len(rt.Deployments)-1)
+	for _, d := range rt.Deployments {
+		if d.Version == wd.Version {
+			continue
+		}
+		deployments = append(deployments, d)
+	}
+	rt.Deployments = deployments
+
+	if err = rt.ValidateDeployments(epoch, params); err != nil {
+		ctx.Logger().Debug("WithdrawRuntimeDeployment: invalid deployments after withdrawal",
+			"runtime_id", rt.ID,
+			"err", err,
+		)
+		return registry.ErrRuntimeUpdateNotAllowed
+	}
+
+	// Start a new transaction and rollback in case we fail.
+	ctx = ctx.NewTransaction()
+	defer ctx.Close()
+
+	if err = app.updateRuntimeDescriptor(ctx, state, rt, suspended); err != nil {
+		return err
+	}
+
+	ctx.Logger().Debug("WithdrawRuntimeDeployment: withdrawn",
+		"runtime_id", rt.ID,
+		"version", wd.Version,
+	)
+
+	ctx.EmitEvent(api.NewEventBuilder(app.Name()).TypedAttribute(&registry.RuntimeDeploymentWithdrawnEvent{
+		RuntimeID: rt.ID,
+		Version:   wd.Version,
+	}))
+
+	ctx.Commit()
+
+	return nil
+}
+
+func (app *registryApplication) updateRuntimeEntityWhitelist(
+	ctx *api.Context,
+	state *registryState.MutableState,
+	update *registry.RuntimeEntityWhitelistUpdate,
+) error {
+	// Allow updating runtime entity whitelists with the 25.0 release.
+	enabled, err := features.IsFeatureVersion(ctx, migrations.Version250)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return fmt.Errorf("%w: runtime entity whitelist updates not enabled", registry.ErrForbidden)
+	}
+
+	if err = update.ValidateBasic(); err != nil {
+		return err
+	}
+
+	params, err := state.ConsensusParameters(ctx)
+	if err != nil {
+		ctx.Logger().Error("UpdateRuntimeEntityWhitelist: failed to fetch registry consensus parameters",
+			"err", err,
+		)
+		return err
+	}
+
+	if params.DisableRuntimeRegistration {
+		return registry.ErrForbidden
+	}
+
+	if ctx.IsCheckOnly() {
+		return nil
+	}
+
+	// Charge gas for this transaction.
+	if err = ctx.Gas().UseGas(1, registry.GasOpUpdateRuntimeEntityWhitelist, params.GasCosts); err != nil {
+		return err
+	}
+
+	// Return early if simulating since this is just estimating gas.
+	if ctx.IsSimulation() {
+		return nil
+	}
+
+	rt, suspended, err := entityGovernedRuntime(ctx, state, update.RuntimeID)
+	if err != nil {
+		return err
+	}
+
+	ewl := rt.AdmissionPolicy.EntityWhitelist
+	if ewl == nil {
+		ctx.Logger().Debug("UpdateRuntimeEntityWhitelist: runtime does not use an entity whitelist",
+			"runtime_id", rt.ID,
+		)
+		return fmt.Errorf("%w: runtime does not use an entity whitelist", registry.ErrInvalidArgument)
+	}
+	ewl.Apply(update)
+
+	if err = rt.AdmissionPolicy.ValidateBasic(); err != nil {
+		return err
+	}
+
+	// Start a new transaction and rollback in case we fail.
+	ctx = ctx.NewTransaction()
+	defer ctx.Close()
+
+	if err = app.updateRuntimeDescriptor(ctx, state, rt, suspended); err != nil {
+		return err
+	}
+
+	ctx.Logger().Debug("UpdateRuntimeEntityWhitelist: updated",
+		"runtime_id", rt.ID,
+		"num_set", len(update.Set),
+		"num_removed", len(update.Remove),
+	)
+
+	ctx.Commit()
+
+	return nil
+}
+
+// entityGovernedRuntime returns the (possibly suspended) runtime with the given identifier after
+// making sure that the transaction signer is the entity controlling the runtime.
+//
+// Runtimes using runtime governance can only be changed by updating their descriptor.
+func entityGovernedRuntime(
+	ctx *api.Context,
+	state *registryState.MutableState,
+	id common.Namespace,
+) (*registry.Runtime, bool, error) {
+	var suspended bool
+	rt, err := state.Runtime(ctx, id)
+	switch err {
+	case nil:
+	case registry.ErrNoSuchRuntime:
+		rt, err = state.SuspendedRuntime(ctx, id)
+		if err != nil {
+			return nil, false, err
+		}
+		suspended = true
+	default:
+		return nil, false, fmt.Errorf("failed to fetch runtime: %w", err)
+	}
+
+	expectedAddr := rt.StakingAddress()
+	if expectedAddr == nil || rt.GovernanceModel != registry.GovernanceEntity {
+		ctx.Logger().Debug("only entity governed runtimes may be updated",
+			"runtime_id", rt.ID,
+		)
+		return nil, false, registry.ErrForbidden
+	}
+	if !ctx.CallerAddress().Equal(*expectedAddr) {
+		ctx.Logger().Debug("transaction must be signed by controlling entity",
+			"runtime_id", rt.ID,
+		)
+		return nil, false, registry.ErrIncorrectTxSigner
+	}
+
+	return rt, suspended, nil
+}
+
+// updateRuntimeDescriptor stores an updated descriptor of an existing runtime and notifies other
+// interested applications about the change.
+func (app *registryApplication) updateRuntimeDescriptor(
+	ctx *api.Context,
+	state *registryState.MutableState,
+	rt *registry.Runtime,
+	suspended bool,
+) error {
+	if _, err := app.md.Publish(ctx, registryApi.MessageRuntimeUpdated, rt); err != nil {
+		ctx.Logger().Error("failed to dispatch runtime updated message",
+			"err", err,
+		)
+		return err
+	}
+
+	if err := state.SetRuntime(ctx, rt, suspended); err != nil {
+		return fmt.Errorf("failed to set runtime: %w", err)
+	}
+
+	if !suspended {
+		ctx.EmitEvent(api.NewEventBuilder(app.Name()).TypedAttribute(&registry.RuntimeStartedEvent{Runtime: rt}))
+	}
+
+	return nil
+}
+
 func (app *registryApplication) proveFreshness(
 	ctx *api.Context,
 	state *registryState.MutableState,
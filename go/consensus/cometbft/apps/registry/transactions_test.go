@@ -1,6 +1,7 @@
 package registry
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
@@ -15,12 +16,15 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/node"
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
 	"github.com/oasisprotocol/oasis-core/go/common/version"
+	consensusState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/abci/state"
 	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
 	beaconState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/beacon/state"
 	registryState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/registry/state"
 	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/staking/state"
+	consensusGenesis "github.com/oasisprotocol/oasis-core/go/consensus/genesis"
 	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+	"github.com/oasisprotocol/oasis-core/go/upgrade/migrations"
 )
 
 func TestRegisterNode(t *testing.T) {
@@ -77,6 +81,17 @@ func TestRegisterNode(t *testing.T) {
 	}
 	tcData := make(map[string]*testCaseData)
 
+	// Restrict an already registered entity's node to the given authorization.
+	authorizeNode := func(entitySigner signature.Signer, auth *entity.NodeAuthorization) {
+		ent, err := state.Entity(ctx, entitySigner.Public())
+		require.NoError(err, "Entity")
+		ent.NodeAuthorizations = []*entity.NodeAuthorization{auth}
+		sigEnt, err := entity.SignEntity(entitySigner, registry.RegisterEntitySignatureContext, ent)
+		require.NoError(err, "SignEntity")
+		err = state.SetEntity(ctx, ent, sigEnt)
+		require.NoError(err, "SetEntity")
+	}
+
 	tcs := []struct {
 		name        string
 		prepareFn   func(tcd *testCaseData)
@@ -96,6 +111,19 @@ func TestRegisterNode(t *testing.T) {
 			true,
 			true,
 		},
+		// A validator node that was previously removed from the registry.
+		{
+			"RemovedValidator",
+			func(tcd *testCaseData) {
+				tcd.node.AddRoles(node.RoleValidator)
+				removed := tcd.node
+				err := state.SetNodeTombstone(ctx, &registry.NodeTombstone{Node: &removed, RemovedAt: 1})
+				require.NoError(err, "SetNodeTombstone")
+			},
+			nil,
+			true,
+			true,
+		},
 		// An expired validator node.
 		{
 			"ExpiredValidator",
@@ -560,6 +588,76 @@ func TestRegisterNode(t *testing.T) {
 			true,
 			true,
 		},
+		// Relayed nodes should not be allowed before the feature version is enabled.
+		{
+			"ValidatorRelayedNotEnabled",
+			func(tcd *testCaseData) {
+				err = consensusState.NewMutableState(ctx.State()).SetConsensusParameters(ctx, &consensusGenesis.Parameters{})
+				require.NoError(err, "SetConsensusParameters")
+
+				tcd.node.AddRoles(node.RoleValidator)
+				tcd.node.Expiration = 12
+				tcd.node.P2P.Addresses = nil
+				tcd.node.P2P.Relays = []signature.PublicKey{
+					memorySigner.NewTestSigner("consensus/cometbft/apps/registry: relay").Public(),
+				}
+			},
+			nil,
+			false,
+			false,
+		},
+		// Relayed nodes should not require P2P addresses.
+		{
+			"ValidatorRelayed",
+			func(tcd *testCaseData) {
+				err = consensusState.NewMutableState(ctx.State()).SetConsensusParameters(ctx, &consensusGenesis.Parameters{
+					FeatureVersion: &migrations.Version250,
+				})
+				require.NoError(err, "SetConsensusParameters")
+
+				tcd.node.AddRoles(node.RoleValidator)
+				tcd.node.Expiration = 12
+				tcd.node.P2P.Addresses = nil
+				tcd.node.P2P.Relays = []signature.PublicKey{
+					memorySigner.NewTestSigner("consensus/cometbft/apps/registry: relay").Public(),
+				}
+			},
+			nil,
+			true,
+			true,
+		},
+		// Nodes should only be allowed to register for roles authorized by the entity.
+		{
+			"ValidatorNotAuthorized",
+			func(tcd *testCaseData) {
+				authorizeNode(tcd.entitySigner, &entity.NodeAuthorization{
+					Node:  tcd.nodeSigner.Public(),
+					Roles: node.RoleComputeWorker,
+				})
+
+				tcd.node.AddRoles(node.RoleValidator)
+				tcd.node.Expiration = 12
+			},
+			nil,
+			false,
+			false,
+		},
+		// Nodes with authorized roles should be allowed to register.
+		{
+			"ValidatorAuthorized",
+			func(tcd *testCaseData) {
+				authorizeNode(tcd.entitySigner, &entity.NodeAuthorization{
+					Node:  tcd.nodeSigner.Public(),
+					Roles: node.RoleValidator | node.RoleComputeWorker,
+				})
+
+				tcd.node.AddRoles(node.RoleValidator)
+				tcd.node.Expiration = 12
+			},
+			nil,
+			true,
+			true,
+		},
 	}
 
 	for _, tc := range tcs {
@@ -653,6 +751,10 @@ func TestRegisterNode(t *testing.T) {
 
 				if tc.valid {
 					require.EqualValues(&tcd.node, regNode, "registered node descriptor should be correct")
+
+					// Make sure any tombstone of a previously removed node has been removed.
+					_, err = state.NodeTombstone(ctx, tcd.node.ID)
+					require.Equal(registry.ErrNoSuchNodeTombstone, err, "node tombstone should be removed")
 				}
 			case false:
 				// Make sure the state has not changed.
@@ -893,3 +995,624 @@ func TestProofFreshness(t *testing.T) {
 		require.Equal(registry.ErrInvalidArgument, err)
 	})
 }
+
+func TestFreezeNode(t *testing.T) {
+	require := requirePkg.New(t)
+
+	cfg := abciAPI.MockApplicationStateConfig{
+		CurrentEpoch: 5,
+	}
+	appState := abciAPI.NewMockApplicationState(&cfg)
+	ctx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx.Close()
+
+	var md abciAPI.NoopMessageDispatcher
+	app := registryApplication{appState, &md}
+	state := registryState.NewMutableState(ctx.State())
+
+	err := state.SetConsensusParameters(ctx, &registry.ConsensusParameters{})
+	require.NoError(err, "registry.SetConsensusParameters")
+	consState := consensusState.NewMutableState(ctx.State())
+	err = consState.SetConsensusParameters(ctx, &consensusGenesis.Parameters{})
+	require.NoError(err, "SetConsensusParameters")
+
+	// Register an entity with a single node.
+	entitySigner := memorySigner.NewTestSigner("consensus/cometbft/apps/registry: freeze node entity signer")
+	nodeSigner := memorySigner.NewTestSigner("consensus/cometbft/apps/registry: freeze node node signer")
+	ent := entity.Entity{
+		Versioned: cbor.NewVersioned(entity.LatestDescriptorVersion),
+		ID:        entitySigner.Public(),
+		Nodes:     []signature.PublicKey{nodeSigner.Public()},
+	}
+	sigEnt, err := entity.SignEntity(entitySigner, registry.RegisterEntitySignatureContext, &ent)
+	require.NoError(err, "SignEntity")
+	err = state.SetEntity(ctx, &ent, sigEnt)
+	require.NoError(err, "SetEntity")
+
+	nod := &node.Node{
+		Versioned: cbor.NewVersioned(node.LatestNodeDescriptorVersion),
+		ID:        nodeSigner.Public(),
+		Consensus: node.ConsensusInfo{ID: nodeSigner.Public()},
+		EntityID:  entitySigner.Public(),
+	}
+	sigNode, err := node.MultiSignNode([]signature.Signer{nodeSigner}, registry.RegisterNodeSignatureContext, nod)
+	require.NoError(err, "MultiSignNode")
+	err = state.SetNode(ctx, nil, nod, sigNode)
+	require.NoError(err, "SetNode")
+	err = state.SetNodeStatus(ctx, nod.ID, &registry.NodeStatus{})
+	require.NoError(err, "SetNodeStatus")
+
+	freezeNode := func(signer signature.PublicKey, reason registry.FreezeReason) error {
+		txCtx := appState.NewContext(abciAPI.ContextDeliverTx)
+		defer txCtx.Close()
+		txCtx.SetTxSigner(signer)
+		return app.freezeNode(txCtx, state, &registry.FreezeNode{NodeID: nod.ID, Reason: reason, Duration: 2})
+	}
+	unfreezeNode := func(signer signature.PublicKey) error {
+		txCtx := appState.NewContext(abciAPI.ContextDeliverTx)
+		defer txCtx.Close()
+		txCtx.SetTxSigner(signer)
+		return app.unfreezeNode(txCtx, state, &registry.UnfreezeNode{NodeID: nod.ID})
+	}
+
+	// Freezing should not be allowed before the feature version is enabled.
+	err = freezeNode(entitySigner.Public(), registry.FreezeReasonCompromised)
+	require.ErrorIs(err, registry.ErrForbidden, "freezing before the feature version should fail")
+
+	err = consState.SetConsensusParameters(ctx, &consensusGenesis.Parameters{
+		FeatureVersion: &migrations.Version250,
+	})
+	require.NoError(err, "SetConsensusParameters")
+
+	// Only entity-initiated freeze reasons should be allowed.
+	err = freezeNode(entitySigner.Public(), registry.FreezeReasonSlashed)
+	require.Equal(registry.ErrInvalidArgument, err, "freezing with a reserved reason should fail")
+
+	// Only the owning entity should be able to freeze the node.
+	err = freezeNode(nodeSigner.Public(), registry.FreezeReasonCompromised)
+	require.Equal(registry.ErrBadEntityForNode, err, "freezing by a non-owner should fail")
+
+	err = freezeNode(entitySigner.Public(), registry.FreezeReasonCompromised)
+	require.NoError(err, "freezing by the owning entity should succeed")
+
+	status, err := state.NodeStatus(ctx, nod.ID)
+	require.NoError(err, "NodeStatus")
+	require.True(status.IsFrozen(), "node should be frozen")
+	require.Equal(registry.FreezeReasonCompromised, status.FreezeReason)
+	require.EqualValues(7, status.FreezeEndTime, "node should be frozen for the requested duration")
+
+	// Frozen nodes should not be frozen again.
+	err = freezeNode(entitySigner.Public(), registry.FreezeReasonMaintenance)
+	require.Equal(registry.ErrNodeAlreadyFrozen, err, "freezing a frozen node should fail")
+
+	// The owning entity should not be able to unfreeze the node before the freeze ends.
+	err = unfreezeNode(entitySigner.Public())
+	require.Equal(registry.ErrNodeCannotBeUnfrozen, err, "unfreezing before the freeze ends should fail")
+
+	cfg.CurrentEpoch = 7
+	appState.UpdateMockApplicationStateConfig(&cfg)
+
+	err = unfreezeNode(entitySigner.Public())
+	require.NoError(err, "unfreezing by the owning entity should succeed")
+
+	status, err = state.NodeStatus(ctx, nod.ID)
+	require.NoError(err, "NodeStatus")
+	require.False(status.IsFrozen(), "node should no longer be frozen")
+	require.Equal(registry.FreezeReasonUnspecified, status.FreezeReason)
+}
+
+func TestSetValidatorElectionOptOut(t *testing.T) {
+	require := requirePkg.New(t)
+
+	cfg := abciAPI.MockApplicationStateConfig{
+		CurrentEpoch: 5,
+	}
+	appState := abciAPI.NewMockApplicationState(&cfg)
+	ctx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx.Close()
+
+	var md abciAPI.NoopMessageDispatcher
+	app := registryApplication{appState, &md}
+	state := registryState.NewMutableState(ctx.State())
+
+	err := state.SetConsensusParameters(ctx, &registry.ConsensusParameters{})
+	require.NoError(err, "registry.SetConsensusParameters")
+	consState := consensusState.NewMutableState(ctx.State())
+	err = consState.SetConsensusParameters(ctx, &consensusGenesis.Parameters{})
+	require.NoError(err, "SetConsensusParameters")
+
+	entitySigner := memorySigner.NewTestSigner("consensus/cometbft/apps/registry: opt-out entity signer")
+	otherSigner := memorySigner.NewTestSigner("consensus/cometbft/apps/registry: opt-out other signer")
+	ent := entity.Entity{
+		Versioned: cbor.NewVersioned(entity.LatestDescriptorVersion),
+		ID:        entitySigner.Public(),
+	}
+	sigEnt, err := entity.SignEntity(entitySigner, registry.RegisterEntitySignatureContext, &ent)
+	require.NoError(err, "SignEntity")
+	err = state.SetEntity(ctx, &ent, sigEnt)
+	require.NoError(err, "SetEntity")
+
+	setOptOut := func(signer signature.PublicKey, optOut bool) (int, error) {
+		txCtx := appState.NewContext(abciAPI.ContextDeliverTx)
+		defer txCtx.Close()
+		txCtx.SetTxSigner(signer)
+		err := app.setValidatorElectionOptOut(txCtx, state, &registry.ValidatorElectionOptOut{OptOut: optOut})
+		return len(txCtx.GetEvents()), err
+	}
+
+	// Opting out should not be allowed before the feature version is enabled.
+	_, err = setOptOut(entitySigner.Public(), true)
+	require.ErrorIs(err, registry.ErrForbidden, "opting out before the feature version should fail")
+
+	err = consState.SetConsensusParameters(ctx, &consensusGenesis.Parameters{
+		FeatureVersion: &migrations.Version250,
+	})
+	require.NoError(err, "SetConsensusParameters")
+
+	// Only registered entities should be able to opt out.
+	_, err = setOptOut(otherSigner.Public(), true)
+	require.Equal(registry.ErrNoSuchEntity, err, "opting out by an unknown entity should fail")
+
+	numEvents, err := setOptOut(entitySigner.Public(), true)
+	require.NoError(err, "opting out should succeed")
+	require.Equal(1, numEvents, "opting out should emit an event")
+
+	optOut, err := state.IsValidatorElectionOptOut(ctx, entitySigner.Public())
+	require.NoError(err, "IsValidatorElectionOptOut")
+	require.True(optOut, "entity should be opted out")
+	optOuts, err := state.ValidatorElectionOptOuts(ctx)
+	require.NoError(err, "ValidatorElectionOptOuts")
+	require.Equal([]signature.PublicKey{entitySigner.Public()}, optOuts)
+
+	// Repeating the same request should not emit an event.
+	numEvents, err = setOptOut(entitySigner.Public(), true)
+	require.NoError(err, "opting out again should succeed")
+	require.Equal(0, numEvents, "opting out again should not emit an event")
+
+	numEvents, err = setOptOut(entitySigner.Public(), false)
+	require.NoError(err, "opting back in should succeed")
+	require.Equal(1, numEvents, "opting back in should emit an event")
+
+	optOuts, err = state.ValidatorElectionOptOuts(ctx)
+	require.NoError(err, "ValidatorElectionOptOuts")
+	require.Empty(optOuts, "entity should no longer be opted out")
+
+	// Removing the entity should also clear its opt-out.
+	_, err = setOptOut(entitySigner.Public(), true)
+	require.NoError(err, "opting out should succeed")
+	_, err = state.RemoveEntity(ctx, entitySigner.Public())
+	require.NoError(err, "RemoveEntity")
+	optOut, err = state.IsValidatorElectionOptOut(ctx, entitySigner.Public())
+	require.NoError(err, "IsValidatorElectionOptOut")
+	require.False(optOut, "removed entity should not be opted out")
+}
+
+func TestUpdateEntityNodes(t *testing.T) {
+	require := requirePkg.New(t)
+
+	cfg := abciAPI.MockApplicationStateConfig{
+		CurrentEpoch: 5,
+	}
+	appState := abciAPI.NewMockApplicationState(&cfg)
+	ctx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx.Close()
+
+	var md abciAPI.NoopMessageDispatcher
+	app := registryApplication{appState, &md}
+	state := registryState.NewMutableState(ctx.State())
+
+	err := state.SetConsensusParameters(ctx, &registry.ConsensusParameters{})
+	require.NoError(err, "registry.SetConsensusParameters")
+	consState := consensusState.NewMutableState(ctx.State())
+	err = consState.SetConsensusParameters(ctx, &consensusGenesis.Parameters{})
+	require.NoError(err, "SetConsensusParameters")
+
+	entitySigner := memorySigner.NewTestSigner("consensus/cometbft/apps/registry: entity nodes entity signer")
+	nodeSigners := make([]signature.Signer, 3)
+	for i := range nodeSigners {
+		nodeSigners[i] = memorySigner.NewTestSigner(fmt.Sprintf("consensus/cometbft/apps/registry: entity nodes node signer %d", i))
+	}
+	ent := entity.Entity{
+		Versioned: cbor.NewVersioned(entity.LatestDescriptorVersion),
+		ID:        entitySigner.Public(),
+		Nodes:     []signature.PublicKey{nodeSigners[0].Public()},
+	}
+	sigEnt, err := entity.SignEntity(entitySigner, registry.RegisterEntitySignatureContext, &ent)
+	require.NoError(err, "SignEntity")
+	err = state.SetEntity(ctx, &ent, sigEnt)
+	require.NoError(err, "SetEntity")
+
+	updateNodes := func(signer signature.PublicKey, add bool, nodes ...signature.PublicKey) (int, error) {
+		txCtx := appState.NewContext(abciAPI.ContextDeliverTx)
+		defer txCtx.Close()
+		txCtx.SetTxSigner(signer)
+		err := app.updateEntityNodes(txCtx, state, &registry.EntityNodes{Nodes: nodes}, add)
+		return len(txCtx.GetEvents()), err
+	}
+	entityNodes := func() []signature.PublicKey {
+		e, err := state.Entity(ctx, entitySigner.Public())
+		require.NoError(err, "Entity")
+		return e.Nodes
+	}
+
+	// Changes should not be allowed before the feature version is enabled.
+	_, err = updateNodes(entitySigner.Public(), true, nodeSigners[1].Public())
+	require.ErrorIs(err, registry.ErrForbidden, "adding nodes before the feature version should fail")
+
+	err = consState.SetConsensusParameters(ctx, &consensusGenesis.Parameters{
+		FeatureVersion: &migrations.Version250,
+	})
+	require.NoError(err, "SetConsensusParameters")
+
+	// Invalid requests should be rejected.
+	_, err = updateNodes(entitySigner.Public(), true)
+	require.ErrorIs(err, registry.ErrInvalidArgument, "adding no nodes should fail")
+	_, err = updateNodes(entitySigner.Public(), true, nodeSigners[1].Public(), nodeSigners[1].Public())
+	require.ErrorIs(err, registry.ErrInvalidArgument, "adding duplicate nodes should fail")
+	_, err = updateNodes(nodeSigners[0].Public(), true, nodeSigners[1].Public())
+	require.Equal(registry.ErrNoSuchEntity, err, "adding nodes by an unknown entity should fail")
+
+	numEvents, err := updateNodes(entitySigner.Public(), true, nodeSigners[0].Public(), nodeSigners[1].Public(), nodeSigners[2].Public())
+	require.NoError(err, "adding nodes should succeed")
+	require.Equal(1, numEvents, "adding nodes should emit an event")
+	require.Equal([]signature.PublicKey{nodeSigners[0].Public(), nodeSigners[1].Public(), nodeSigners[2].Public()}, entityNodes())
+
+	numEvents, err = updateNodes(entitySigner.Public(), false, nodeSigners[0].Public())
+	require.NoError(err, "removing nodes should succeed")
+	require.Equal(1, numEvents, "removing nodes should emit an event")
+	require.Equal([]signature.PublicKey{nodeSigners[1].Public(), nodeSigners[2].Public()}, entityNodes())
+
+	// Removing nodes which are not in the allowlist should be a no-op.
+	numEvents, err = updateNodes(entitySigner.Public(), false, nodeSigners[0].Public())
+	require.NoError(err, "removing missing nodes should succeed")
+	require.Equal(0, numEvents, "removing missing nodes should not emit an event")
+
+	allNodes, err := state.AllEntityNodes(ctx)
+	require.NoError(err, "AllEntityNodes")
+	require.Len(allNodes, 1, "there should be a single changed allowlist")
+
+	// Re-registering the entity should reset the allowlist to the one in the descriptor.
+	err = state.SetEntity(ctx, &ent, sigEnt)
+	require.NoError(err, "SetEntity")
+	require.Equal([]signature.PublicKey{nodeSigners[0].Public()}, entityNodes())
+	allNodes, err = state.AllEntityNodes(ctx)
+	require.NoError(err, "AllEntityNodes")
+	require.Empty(allNodes, "there should be no changed allowlists")
+}
+
+func TestRegisterMultiSignedEntity(t *testing.T) {
+	require := requirePkg.New(t)
+
+	cfg := abciAPI.MockApplicationStateConfig{}
+	appState := abciAPI.NewMockApplicationState(&cfg)
+	ctx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx.Close()
+
+	var md abciAPI.NoopMessageDispatcher
+	app := registryApplication{appState, &md}
+	state := registryState.NewMutableState(ctx.State())
+	stakeState := stakingState.NewMutableState(ctx.State())
+
+	err := state.SetConsensusParameters(ctx, &registry.ConsensusParameters{})
+	require.NoError(err, "registry.SetConsensusParameters")
+	err = stakeState.SetConsensusParameters(ctx, &staking.ConsensusParameters{DebugBypassStake: true})
+	require.NoError(err, "staking.SetConsensusParameters")
+	consState := consensusState.NewMutableState(ctx.State())
+	err = consState.SetConsensusParameters(ctx, &consensusGenesis.Parameters{})
+	require.NoError(err, "SetConsensusParameters")
+
+	entitySigner := memorySigner.NewTestSigner("consensus/cometbft/apps/registry: multi-signed entity signer")
+	signers := make([]signature.Signer, 4)
+	for i := range signers {
+		signers[i] = memorySigner.NewTestSigner(fmt.Sprintf("consensus/cometbft/apps/registry: multi-signed entity signer %d", i))
+	}
+	nodeSigner := memorySigner.NewTestSigner("consensus/cometbft/apps/registry: multi-signed entity node signer")
+
+	newEntity := func(signerSet ...signature.Signer) *entity.Entity {
+		ent := &entity.Entity{
+			Versioned: cbor.NewVersioned(entity.LatestDescriptorVersion),
+			ID:        entitySigner.Public(),
+			SignerSet: &entity.SignerSet{Threshold: 2},
+		}
+		for _, signer := range signerSet {
+			ent.SignerSet.Signers = append(ent.SignerSet.Signers, signer.Public())
+		}
+		return ent
+	}
+	register := func(txSigner signature.PublicKey, ent *entity.Entity, signers ...signature.Signer) error {
+		msEnt, err := entity.MultiSignEntity(signers, registry.RegisterEntitySignatureContext, ent)
+		require.NoError(err, "MultiSignEntity")
+
+		txCtx := appState.NewContext(abciAPI.ContextDeliverTx)
+		defer txCtx.Close()
+		txCtx.SetTxSigner(txSigner)
+		return app.registerMultiSignedEntity(txCtx, state, msEnt)
+	}
+
+	ent := newEntity(signers[0], signers[1], signers[2])
+
+	// Registration should not be allowed before the feature version is enabled.
+	err = register(entitySigner.Public(), ent, entitySigner, signers[0], signers[1])
+	require.ErrorIs(err, registry.ErrForbidden, "registration before the feature version should fail")
+
+	err = consState.SetConsensusParameters(ctx, &consensusGenesis.Parameters{
+		FeatureVersion: &migrations.Version250,
+	})
+	require.NoError(err, "SetConsensusParameters")
+
+	// Descriptors must be signed by a threshold of their own signer set.
+	err = register(entitySigner.Public(), ent, entitySigner, signers[0])
+	require.ErrorIs(err, registry.ErrInvalidSignature, "registration without a signer set threshold should fail")
+	// Initial registration must be authorized by the entity key.
+	err = register(signers[0].Public(), ent, signers[0], signers[1])
+	require.ErrorIs(err, registry.ErrInvalidSignature, "registration without the entity key should fail")
+	// Transaction signer must be one of the signers.
+	err = register(signers[2].Public(), ent, entitySigner, signers[0], signers[1])
+	require.Equal(registry.ErrIncorrectTxSigner, err, "registration by a non-signer should fail")
+
+	err = register(entitySigner.Public(), ent, entitySigner, signers[0], signers[1])
+	require.NoError(err, "registration should succeed")
+
+	registered, err := state.Entity(ctx, entitySigner.Public())
+	require.NoError(err, "Entity")
+	require.EqualValues(ent, registered, "registered entity should be correct")
+	msEnts, err := state.MultiSignedEntities(ctx)
+	require.NoError(err, "MultiSignedEntities")
+	require.Len(msEnts, 1, "there should be a single multi-signed entity")
+
+	// Changes by the entity key alone should be rejected.
+	single := newEntity(signers[0], signers[1], signers[2])
+	single.SignerSet = nil
+	sigEnt, err := entity.SignEntity(entitySigner, registry.RegisterEntitySignatureContext, single)
+	require.NoError(err, "SignEntity")
+	txCtx := appState.NewContext(abciAPI.ContextDeliverTx)
+	txCtx.SetTxSigner(entitySigner.Public())
+	err = app.registerEntity(txCtx, state, sigEnt)
+	txCtx.Close()
+	require.ErrorIs(err, registry.ErrForbidden, "single-signed re-registration should fail")
+
+	txCtx = appState.NewContext(abciAPI.ContextDeliverTx)
+	txCtx.SetTxSigner(entitySigner.Public())
+	err = app.updateEntityNodes(txCtx, state, &registry.EntityNodes{Nodes: []signature.PublicKey{nodeSigner.Public()}}, true)
+	txCtx.Close()
+	require.ErrorIs(err, registry.ErrForbidden, "single-signed node allowlist changes should fail")
+
+	txCtx = appState.NewContext(abciAPI.ContextDeliverTx)
+	txCtx.SetTxSigner(entitySigner.Public())
+	err = app.deregisterEntity(txCtx, state)
+	txCtx.Close()
+	require.ErrorIs(err, registry.ErrForbidden, "single-signed deregistration should fail")
+
+	// Changes must be authorized by the current signer set.
+	updated := newEntity(signers[1], signers[3])
+	updated.Nodes = []signature.PublicKey{nodeSigner.Public()}
+	err = register(entitySigner.Public(), updated, entitySigner, signers[1], signers[3])
+	require.ErrorIs(err, registry.ErrInvalidSignature, "changes without the current signer set threshold should fail")
+
+	err = register(signers[1].Public(), updated, signers[0], signers[1], signers[3])
+	require.NoError(err, "changes by the current signer set should succeed")
+
+	registered, err = state.Entity(ctx, entitySigner.Public())
+	require.NoError(err, "Entity")
+	require.EqualValues(updated, registered, "updated entity should be correct")
+}
+
+func TestRegisterEntityNodeAuthorizations(t *testing.T) {
+	require := requirePkg.New(t)
+
+	cfg := abciAPI.MockApplicationStateConfig{}
+	appState := abciAPI.NewMockApplicationState(&cfg)
+	ctx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx.Close()
+
+	var md abciAPI.NoopMessageDispatcher
+	app := registryApplication{appState, &md}
+	state := registryState.NewMutableState(ctx.State())
+	stakeState := stakingState.NewMutableState(ctx.State())
+
+	err := state.SetConsensusParameters(ctx, &registry.ConsensusParameters{})
+	require.NoError(err, "registry.SetConsensusParameters")
+	err = stakeState.SetConsensusParameters(ctx, &staking.ConsensusParameters{DebugBypassStake: true})
+	require.NoError(err, "staking.SetConsensusParameters")
+	consState := consensusState.NewMutableState(ctx.State())
+	err = consState.SetConsensusParameters(ctx, &consensusGenesis.Parameters{})
+	require.NoError(err, "SetConsensusParameters")
+
+	entitySigner := memorySigner.NewTestSigner("consensus/cometbft/apps/registry: node authorizations entity signer")
+	nodeSigner := memorySigner.NewTestSigner("consensus/cometbft/apps/registry: node authorizations node signer")
+
+	ent := &entity.Entity{
+		Versioned: cbor.NewVersioned(entity.LatestDescriptorVersion),
+		ID:        entitySigner.Public(),
+		Nodes:     []signature.PublicKey{nodeSigner.Public()},
+		NodeAuthorizations: []*entity.NodeAuthorization{
+			{Node: nodeSigner.Public(), Roles: node.RoleValidator},
+		},
+	}
+	register := func() error {
+		sigEnt, err := entity.SignEntity(entitySigner, registry.RegisterEntitySignatureContext, ent)
+		require.NoError(err, "SignEntity")
+
+		txCtx := appState.NewContext(abciAPI.ContextDeliverTx)
+		defer txCtx.Close()
+		txCtx.SetTxSigner(entitySigner.Public())
+		return app.registerEntity(txCtx, state, sigEnt)
+	}
+
+	// Registration should not be allowed before the feature version is enabled.
+	err = register()
+	require.ErrorIs(err, registry.ErrForbidden, "registration before the feature version should fail")
+
+	err = consState.SetConsensusParameters(ctx, &consensusGenesis.Parameters{
+		FeatureVersion: &migrations.Version250,
+	})
+	require.NoError(err, "SetConsensusParameters")
+
+	err = register()
+	require.NoError(err, "registration should succeed")
+
+	registered, err := state.Entity(ctx, entitySigner.Public())
+	require.NoError(err, "Entity")
+	require.EqualValues(ent, registered, "registered entity should be correct")
+}
+
+func TestWithdrawRuntimeDeployment(t *testing.T) {
+	require := requirePkg.New(t)
+
+	cfg := abciAPI.MockApplicationStateConfig{
+		CurrentEpoch: 5,
+	}
+	appState := abciAPI.NewMockApplicationState(&cfg)
+	ctx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx.Close()
+
+	var md abciAPI.NoopMessageDispatcher
+	app := registryApplication{appState, &md}
+	state := registryState.NewMutableState(ctx.State())
+
+	err := state.SetConsensusParameters(ctx, &registry.ConsensusParameters{})
+	require.NoError(err, "registry.SetConsensusParameters")
+	consState := consensusState.NewMutableState(ctx.State())
+	err = consState.SetConsensusParameters(ctx, &consensusGenesis.Parameters{})
+	require.NoError(err, "SetConsensusParameters")
+
+	entitySigner := memorySigner.NewTestSigner("consensus/cometbft/apps/registry: withdraw deployment entity signer")
+	otherSigner := memorySigner.NewTestSigner("consensus/cometbft/apps/registry: withdraw deployment other signer")
+	rt := registry.Runtime{
+		Versioned:       cbor.NewVersioned(registry.LatestRuntimeDescriptorVersion),
+		ID:              common.NewTestNamespaceFromSeed([]byte("consensus/cometbft/apps/registry: withdraw deployment runtime"), 0),
+		EntityID:        entitySigner.Public(),
+		Kind:            registry.KindCompute,
+		GovernanceModel: registry.GovernanceEntity,
+		Deployments: []*registry.VersionInfo{
+			{Version: version.Version{Major: 1}, ValidFrom: 1},
+			{Version: version.Version{Major: 2}, ValidFrom: 10},
+		},
+	}
+	err = state.SetRuntime(ctx, &rt, false)
+	require.NoError(err, "SetRuntime")
+
+	withdraw := func(signer signature.PublicKey, v version.Version) (int, error) {
+		txCtx := appState.NewContext(abciAPI.ContextDeliverTx)
+		defer txCtx.Close()
+		txCtx.SetTxSigner(signer)
+		err := app.withdrawRuntimeDeployment(txCtx, state, &registry.WithdrawRuntimeDeployment{
+			RuntimeID: rt.ID,
+			Version:   v,
+		})
+		return len(txCtx.GetEvents()), err
+	}
+
+	// Withdrawing should not be allowed before the feature version is enabled.
+	_, err = withdraw(entitySigner.Public(), version.Version{Major: 2})
+	require.ErrorIs(err, registry.ErrForbidden, "withdrawal before the feature version should fail")
+
+	err = consState.SetConsensusParameters(ctx, &consensusGenesis.Parameters{
+		FeatureVersion: &migrations.Version250,
+	})
+	require.NoError(err, "SetConsensusParameters")
+
+	_, err = withdraw(otherSigner.Public(), version.Version{Major: 2})
+	require.ErrorIs(err, registry.ErrIncorrectTxSigner, "withdrawal by a different entity should fail")
+
+	_, err = withdraw(entitySigner.Public(), version.Version{Major: 3})
+	require.ErrorIs(err, registry.ErrNoSuchRuntimeDeployment, "withdrawal of an unknown deployment should fail")
+
+	_, err = withdraw(entitySigner.Public(), version.Version{Major: 1})
+	require.ErrorIs(err, registry.ErrRuntimeUpdateNotAllowed, "withdrawal of the active deployment should fail")
+
+	numEvents, err := withdraw(entitySigner.Public(), version.Version{Major: 2})
+	require.NoError(err, "withdrawal of a future deployment should succeed")
+	require.Equal(2, numEvents, "withdrawal should emit events")
+
+	updated, err := state.Runtime(ctx, rt.ID)
+	require.NoError(err, "Runtime")
+	require.Len(updated.Deployments, 1, "deployment should be removed")
+	require.Nil(updated.DeploymentForVersion(version.Version{Major: 2}), "deployment should be removed")
+	require.NotNil(updated.DeploymentForVersion(version.Version{Major: 1}), "active deployment should be kept")
+}
+
+func TestUpdateRuntimeEntityWhitelist(t *testing.T) {
+	require := requirePkg.New(t)
+
+	cfg := abciAPI.MockApplicationStateConfig{
+		CurrentEpoch: 5,
+	}
+	appState := abciAPI.NewMockApplicationState(&cfg)
+	ctx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx.Close()
+
+	var md abciAPI.NoopMessageDispatcher
+	app := registryApplication{appState, &md}
+	state := registryState.NewMutableState(ctx.State())
+
+	err := state.SetConsensusParameters(ctx, &registry.ConsensusParameters{})
+	require.NoError(err, "registry.SetConsensusParameters")
+	consState := consensusState.NewMutableState(ctx.State())
+	err = consState.SetConsensusParameters(ctx, &consensusGenesis.Parameters{})
+	require.NoError(err, "SetConsensusParameters")
+
+	entitySigner := memorySigner.NewTestSigner("consensus/cometbft/apps/registry: whitelist update entity signer")
+	otherSigner := memorySigner.NewTestSigner("consensus/cometbft/apps/registry: whitelist update other signer")
+	provider1 := memorySigner.NewTestSigner("consensus/cometbft/apps/registry: whitelist update provider 1").Public()
+	provider2 := memorySigner.NewTestSigner("consensus/cometbft/apps/registry: whitelist update provider 2").Public()
+	rt := registry.Runtime{
+		Versioned:       cbor.NewVersioned(registry.LatestRuntimeDescriptorVersion),
+		ID:              common.NewTestNamespaceFromSeed([]byte("consensus/cometbft/apps/registry: whitelist update runtime"), 0),
+		EntityID:        entitySigner.Public(),
+		Kind:            registry.KindCompute,
+		GovernanceModel: registry.GovernanceEntity,
+		AdmissionPolicy: registry.RuntimeAdmissionPolicy{
+			EntityWhitelist: &registry.EntityWhitelistRuntimeAdmissionPolicy{
+				Entities: map[signature.PublicKey]registry.EntityWhitelistConfig{
+					provider1: {},
+				},
+			},
+		},
+	}
+	err = state.SetRuntime(ctx, &rt, false)
+	require.NoError(err, "SetRuntime")
+
+	update := func(signer signature.PublicKey, update *registry.RuntimeEntityWhitelistUpdate) error {
+		txCtx := appState.NewContext(abciAPI.ContextDeliverTx)
+		defer txCtx.Close()
+		txCtx.SetTxSigner(signer)
+		update.RuntimeID = rt.ID
+		return app.updateRuntimeEntityWhitelist(txCtx, state, update)
+	}
+	rotate := &registry.RuntimeEntityWhitelistUpdate{
+		Set: map[signature.PublicKey]registry.EntityWhitelistConfig{
+			provider1: {Expiration: 10},
+			provider2: {MaxNodes: map[node.RolesMask]uint16{node.RoleComputeWorker: 2}},
+		},
+	}
+
+	// Updates should not be allowed before the feature version is enabled.
+	err = update(entitySigner.Public(), rotate)
+	require.ErrorIs(err, registry.ErrForbidden, "update before the feature version should fail")
+
+	err = consState.SetConsensusParameters(ctx, &consensusGenesis.Parameters{
+		FeatureVersion: &migrations.Version250,
+	})
+	require.NoError(err, "SetConsensusParameters")
+
+	err = update(otherSigner.Public(), rotate)
+	require.ErrorIs(err, registry.ErrIncorrectTxSigner, "update by a different entity should fail")
+
+	err = update(entitySigner.Public(), rotate)
+	require.NoError(err, "update by the controlling entity should succeed")
+
+	updated, err := state.Runtime(ctx, rt.ID)
+	require.NoError(err, "Runtime")
+	require.Equal(rotate.Set, updated.AdmissionPolicy.EntityWhitelist.Entities, "whitelist should be updated")
+
+	err = update(entitySigner.Public(), &registry.RuntimeEntityWhitelistUpdate{
+		Remove: []signature.PublicKey{provider1},
+	})
+	require.NoError(err, "removal by the controlling entity should succeed")
+
+	updated, err = state.Runtime(ctx, rt.ID)
+	require.NoError(err, "Runtime")
+	require.Len(updated.AdmissionPolicy.EntityWhitelist.Entities, 1, "entity should be removed from the whitelist")
+	require.Contains(updated.AdmissionPolicy.EntityWhitelist.Entities, provider2)
+}
@@ -1,6 +1,7 @@
 package roothash
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
@@ -11,11 +12,14 @@ import (
 	registryState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/registry/state"
 	roothashApi "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/roothash/api"
 	roothashState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/roothash/state"
+	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/features"
 	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
 	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/commitment"
+	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+	"github.com/oasisprotocol/oasis-core/go/upgrade/migrations"
 )
 
 func (app *rootHashApplication) tryFinalizeRounds(
@@ -43,6 +47,20 @@ func (app *rootHashApplication) tryFinalizeRound(
 
 	state := roothashState.NewMutableState(ctx.State())
 
+	// Do not finalize rounds of paused runtimes, as committee members cannot make progress and
+	// should not be penalized for failed rounds.
+	params, err := state.ConsensusParameters(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get consensus parameters: %w", err)
+	}
+	if params.IsRuntimePaused(runtimeID) {
+		ctx.Logger().Debug("skipping round finalization of paused runtime",
+			"runtime_id", runtimeID,
+			"timeout", timeout,
+		)
+		return nil
+	}
+
 	// Fetch runtime state.
 	rtState, err := app.getRuntimeState(ctx, state, runtimeID)
 	if err != nil {
@@ -129,7 +147,7 @@ func (app *rootHashApplication) tryFinalizeRoundInsideTx( //nolint: gocyclo
 		fallthrough
 	case commitment.ErrInsufficientVotes:
 		// Emit empty block and fail the round.
-		return app.failRound(ctx, rtState, err)
+		return app.failRound(ctx, rtState, timeout, err)
 	case commitment.ErrDiscrepancyDetected:
 		// This was already handled above, so it should not happen.
 		fallthrough
@@ -174,9 +192,10 @@ func (app *rootHashApplication) tryFinalizeRoundInsideTx( //nolint: gocyclo
 	}
 	if err = verifyRuntimeMessages(ctx, msgs, header.InMessagesHash); err != nil {
 		// TODO: All nodes contributing to this round should be penalized.
-		return app.failRound(ctx, rtState, err)
+		return app.failRound(ctx, rtState, timeout, err)
 	}
-	if err = app.removeRuntimeMessages(ctx, state, rtState.Runtime.ID, msgs, round); err != nil {
+	inMsgEvents, err := app.removeRuntimeMessages(ctx, state, rtState.Runtime.ID, msgs, sc.Commitment.InMessagesResults, round)
+	if err != nil {
 		return err
 	}
 	msgEvents, err := app.processRuntimeMessages(ctx, rtState, sc.Commitment.Messages)
@@ -234,6 +253,9 @@ func (app *rootHashApplication) tryFinalizeRoundInsideTx( //nolint: gocyclo
 			livenessStats.LiveRounds[i]++
 		case false:
 			badComputeEntities = append(badComputeEntities, node.EntityID)
+			if rtState.Runtime.Staking.MaxDiscrepancies > 0 {
+				livenessStats.RecordDiscrepancy(i)
+			}
 		}
 	}
 
@@ -268,12 +290,16 @@ func (app *rootHashApplication) tryFinalizeRoundInsideTx( //nolint: gocyclo
 	// Set last normal round results.
 	results := roothash.RoundResults{
 		Messages:            msgEvents,
+		InMessages:          inMsgEvents,
 		GoodComputeEntities: goodComputeEntities,
 		BadComputeEntities:  badComputeEntities,
 	}
 	if err = state.SetLastRoundResults(ctx, rtState.Runtime.ID, &results); err != nil {
 		return fmt.Errorf("failed to set last round results: %w", err)
 	}
+	if err = setLastRoundMetadata(ctx, rtState, block.Normal, timeout, nil); err != nil {
+		return fmt.Errorf("failed to set last round metadata: %w", err)
+	}
 
 	// Generate the final block.
 	return app.finalizeBlock(ctx, rtState, block.Normal, &sc.Commitment.Header.Header)
@@ -334,6 +360,7 @@ func (app *rootHashApplication) finalizeBlock(ctx *tmapi.Context, rtState *rooth
 func (app *rootHashApplication) failRound(
 	ctx *tmapi.Context,
 	rtState *roothash.RuntimeState,
+	timeout bool,
 	err error,
 ) error {
 	round := rtState.LastBlock.Header.Round + 1
@@ -353,9 +380,95 @@ func (app *rootHashApplication) failRound(
 
 	rtState.LivenessStatistics.MissedProposals[firstSchedulerIdx]++
 
+	if err := setLastRoundMetadata(ctx, rtState, block.RoundFailed, timeout, err); err != nil {
+		return fmt.Errorf("failed to set last round metadata: %w", err)
+	}
+
+	if err := app.emitRoundFailedEvent(ctx, rtState, round, timeout, err); err != nil {
+		return fmt.Errorf("failed to emit round failed event: %w", err)
+	}
+
 	if err := app.finalizeBlock(ctx, rtState, block.RoundFailed, nil); err != nil {
 		return fmt.Errorf("failed to emit empty block: %w", err)
 	}
 
 	return nil
 }
+
+// emitRoundFailedEvent emits an event describing why the given round failed.
+func (app *rootHashApplication) emitRoundFailedEvent(
+	ctx *tmapi.Context,
+	rtState *roothash.RuntimeState,
+	round uint64,
+	timeout bool,
+	roundErr error,
+) error {
+	// Emit round failure details with the 25.0 release.
+	enabled, err := features.IsFeatureVersion(ctx, migrations.Version250)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return nil
+	}
+
+	class, offenders := classifyRoundFailure(rtState, round, roundErr)
+
+	ctx.EmitEvent(
+		tmapi.NewEventBuilder(app.Name()).
+			TypedAttribute(&roothash.RoundFailedEvent{
+				Round:     round,
+				Class:     class,
+				Timeout:   timeout,
+				Offenders: offenders,
+				Error:     roundErr.Error(),
+			}).
+			TypedAttribute(&roothash.RuntimeIDAttribute{ID: rtState.Runtime.ID}),
+	)
+
+	return nil
+}
+
+// classifyRoundFailure determines the class of the round failure caused by the given error and
+// the public keys of the nodes responsible for it.
+func classifyRoundFailure(
+	rtState *roothash.RuntimeState,
+	round uint64,
+	err error,
+) (roothash.RoundFailureClass, []signature.PublicKey) {
+	pool := rtState.CommitmentPool
+	sc := pool.SchedulerCommitments[pool.HighestRank]
+
+	switch {
+	case errors.Is(err, commitment.ErrNoSchedulerCommitment):
+		// No scheduler proposed anything, blame the primary scheduler.
+		var offenders []signature.PublicKey
+		if idx, ok := rtState.Committee.SchedulerIdx(round, 0); ok {
+			offenders = append(offenders, rtState.Committee.Members[idx].PublicKey)
+		}
+		return roothash.RoundFailureTimeout, offenders
+	case errors.Is(err, commitment.ErrInsufficientVotes):
+		// Discrepancy resolution failed, blame backup workers that did not vote.
+		var offenders []signature.PublicKey
+		for _, n := range rtState.Committee.Members {
+			if n.Role != scheduler.RoleBackupWorker {
+				continue
+			}
+			if sc != nil {
+				if vote := sc.Votes[n.PublicKey]; vote != nil {
+					continue
+				}
+			}
+			offenders = append(offenders, n.PublicKey)
+		}
+		return roothash.RoundFailureDiscrepancy, offenders
+	default:
+		// The scheduler's commitment was bad (e.g., it did not receive the majority of votes
+		// during discrepancy resolution or it contained invalid incoming messages).
+		var offenders []signature.PublicKey
+		if sc != nil {
+			offenders = append(offenders, sc.Commitment.Header.SchedulerID)
+		}
+		return roothash.RoundFailureBadCommitment, offenders
+	}
+}
@@ -0,0 +1,73 @@
+package roothash
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/commitment"
+	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
+)
+
+func TestClassifyRoundFailure(t *testing.T) {
+	require := require.New(t)
+
+	pks := make([]signature.PublicKey, 4)
+	for i := range pks {
+		pks[i] = signature.NewPublicKey(fmt.Sprintf("%064x", i+1))
+	}
+
+	committee := scheduler.Committee{
+		Members: []*scheduler.CommitteeNode{
+			{Role: scheduler.RoleWorker, PublicKey: pks[0]},
+			{Role: scheduler.RoleWorker, PublicKey: pks[1]},
+			{Role: scheduler.RoleBackupWorker, PublicKey: pks[2]},
+			{Role: scheduler.RoleBackupWorker, PublicKey: pks[3]},
+		},
+	}
+	rtState := roothash.RuntimeState{
+		Committee:      &committee,
+		CommitmentPool: commitment.NewPool(),
+	}
+	round := uint64(1)
+
+	// No scheduler commitments, the primary scheduler should be blamed.
+	class, offenders := classifyRoundFailure(&rtState, round, commitment.ErrNoSchedulerCommitment)
+	require.Equal(roothash.RoundFailureTimeout, class)
+	idx, ok := committee.SchedulerIdx(round, 0)
+	require.True(ok, "SchedulerIdx")
+	require.Equal([]signature.PublicKey{committee.Members[idx].PublicKey}, offenders)
+
+	// Discrepancy resolution without enough votes, backup workers that did not vote should be
+	// blamed.
+	var vote hash.Hash
+	vote.FromBytes([]byte("vote"))
+	rtState.CommitmentPool = &commitment.Pool{
+		HighestRank: 0,
+		Discrepancy: true,
+		SchedulerCommitments: map[uint64]*commitment.SchedulerCommitment{
+			0: {
+				Commitment: &commitment.ExecutorCommitment{
+					Header: commitment.ExecutorCommitmentHeader{SchedulerID: pks[0]},
+				},
+				Votes: map[signature.PublicKey]*hash.Hash{
+					pks[0]: &vote,
+					pks[2]: &vote,
+					pks[3]: nil,
+				},
+			},
+		},
+	}
+	class, offenders = classifyRoundFailure(&rtState, round, commitment.ErrInsufficientVotes)
+	require.Equal(roothash.RoundFailureDiscrepancy, class)
+	require.Equal([]signature.PublicKey{pks[3]}, offenders)
+
+	// Bad scheduler commitment, the scheduler should be blamed.
+	class, offenders = classifyRoundFailure(&rtState, round, commitment.ErrBadSchedulerCommitment)
+	require.Equal(roothash.RoundFailureBadCommitment, class)
+	require.Equal([]signature.PublicKey{pks[0]}, offenders)
+}
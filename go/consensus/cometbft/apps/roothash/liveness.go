@@ -5,12 +5,15 @@ import (
 	"math"
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	tmapi "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
 	registryState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/registry/state"
+	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/features"
 	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
 	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
 	"github.com/oasisprotocol/oasis-core/go/scheduler/api"
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+	"github.com/oasisprotocol/oasis-core/go/upgrade/migrations"
 )
 
 // processLivenessStatistics checks the liveness statistics for the last epoch and penalizes any
@@ -26,8 +29,7 @@ func processLivenessStatistics(ctx *tmapi.Context, epoch beacon.EpochTime, rtSta
 		return nil
 	}
 
-	minLiveRoundsPercent := uint64(rtState.Runtime.Executor.MinLiveRoundsPercent)
-	minLiveRounds := (rtState.LivenessStatistics.TotalRounds * minLiveRoundsPercent) / 100
+	minLiveRounds := rtState.LivenessStatistics.MinLiveRounds(rtState.Runtime.Executor.MinLiveRoundsPercent)
 	maxFailures := rtState.Runtime.Executor.MaxLivenessFailures
 	if maxFailures == 0 {
 		maxFailures = 255
@@ -86,15 +88,25 @@ func processLivenessStatistics(ctx *tmapi.Context, epoch beacon.EpochTime, rtSta
 			// Check if the node has reached the maximum allowed number of failures.
 			fault := status.Faults[rtState.Runtime.ID]
 			if fault.Failures >= maxFailures {
+				// Record the freeze reason with the 25.0 release.
+				var enabled bool
+				if enabled, err = features.IsFeatureVersion(ctx, migrations.Version250); err != nil {
+					return err
+				}
+				freezeReason := registry.FreezeReasonUnspecified
+				if enabled {
+					freezeReason = registry.FreezeReasonSlashed
+				}
+
 				// Make sure to freeze forever if this would otherwise overflow.
 				if epoch > registry.FreezeForever-slashParams.FreezeInterval {
-					status.FreezeEndTime = registry.FreezeForever
+					status.Freeze(registry.FreezeForever, freezeReason)
 				} else {
-					status.FreezeEndTime = epoch + slashParams.FreezeInterval
+					status.Freeze(epoch+slashParams.FreezeInterval, freezeReason)
 				}
 
 				// Slash if configured.
-				err = onRuntimeLivenessFailure(ctx, n.PublicKey, &slashParams.Amount)
+				err = onRuntimeLivenessFailure(ctx, rtState.Runtime.ID, n.PublicKey, &slashParams.Amount)
 				if err != nil {
 					return fmt.Errorf("failed to slash node %s: %w", n.PublicKey, err)
 				}
@@ -108,3 +120,67 @@ func processLivenessStatistics(ctx *tmapi.Context, epoch beacon.EpochTime, rtSta
 
 	return nil
 }
+
+// processDiscrepancyStatistics checks the number of discrepancies caused by each node in the last
+// epoch and penalizes any nodes that caused more than the runtime allows.
+func processDiscrepancyStatistics(ctx *tmapi.Context, epoch beacon.EpochTime, rtState *roothash.RuntimeState) error {
+	if rtState.Committee == nil || rtState.LivenessStatistics == nil || rtState.Suspended {
+		return nil
+	}
+
+	maxDiscrepancies := rtState.Runtime.Staking.MaxDiscrepancies
+	discrepancies := rtState.LivenessStatistics.DiscrepanciesCaused
+	if maxDiscrepancies == 0 || len(discrepancies) != len(rtState.Committee.Members) {
+		return nil
+	}
+	slashParams := rtState.Runtime.Staking.Slashing[staking.SlashRuntimeRepeatedDiscrepancy]
+
+	// Make sure to freeze forever if this would otherwise overflow.
+	freezeUntil := registry.FreezeForever
+	if epoch <= registry.FreezeForever-slashParams.FreezeInterval {
+		freezeUntil = epoch + slashParams.FreezeInterval
+	}
+
+	regState := registryState.NewMutableState(ctx.State())
+	seen := make(map[signature.PublicKey]struct{})
+	for i, n := range rtState.Committee.Members {
+		// Make sure to not penalize nodes in multiple roles multiple times.
+		if _, ok := seen[n.PublicKey]; ok {
+			continue
+		}
+		seen[n.PublicKey] = struct{}{}
+
+		if discrepancies[i] <= maxDiscrepancies {
+			continue
+		}
+
+		ctx.Logger().Debug("node caused too many discrepancies",
+			"node_id", n.PublicKey,
+			"discrepancies", discrepancies[i],
+			"max_discrepancies", maxDiscrepancies,
+			"freeze_duration", slashParams.FreezeInterval,
+			"slash_amount", slashParams.Amount,
+		)
+
+		if slashParams.FreezeInterval > 0 {
+			status, err := regState.NodeStatus(ctx, n.PublicKey)
+			if err != nil {
+				return fmt.Errorf("failed to retrieve status for node %s: %w", n.PublicKey, err)
+			}
+			// Do not shorten any existing freeze.
+			if status.FreezeEndTime < freezeUntil {
+				status.Freeze(freezeUntil, registry.FreezeReasonSlashed)
+			}
+			if err = regState.SetNodeStatus(ctx, n.PublicKey, status); err != nil {
+				return fmt.Errorf("failed to set node status for node %s: %w", n.PublicKey, err)
+			}
+		}
+
+		// Slash if configured.
+		if err := onRuntimeRepeatedDiscrepancy(ctx, rtState.Runtime.ID, n.PublicKey, &slashParams.Amount); err != nil {
+			return fmt.Errorf("failed to slash node %s: %w", n.PublicKey, err)
+		}
+	}
+
+	return nil
+}
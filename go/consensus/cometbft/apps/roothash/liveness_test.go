@@ -7,16 +7,28 @@ import (
 	"github.com/stretchr/testify/require"
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/common/entity"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	consensusState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/abci/state"
 	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
 	registryState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/registry/state"
 	roothashState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/roothash/state"
 	schedulerState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/scheduler/state"
+	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/staking/state"
+	consensusGenesis "github.com/oasisprotocol/oasis-core/go/consensus/genesis"
+	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
 	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
 	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/commitment"
 	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+	"github.com/oasisprotocol/oasis-core/go/upgrade/migrations"
 )
 
 func TestLivenessProcessing(t *testing.T) {
@@ -213,3 +225,231 @@ func TestLivenessProcessing(t *testing.T) {
 	require.False(status.IsSuspended(runtime.ID, epoch), "node should not be suspended")
 	require.Len(status.Faults, 0, "there should be no faults")
 }
+
+func TestPausedRuntimeLiveness(t *testing.T) {
+	require := require.New(t)
+
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx.Close()
+
+	app := &rootHashApplication{
+		state: appState,
+	}
+
+	// Generate a private key for the single node in this test.
+	sk, err := memorySigner.NewSigner(rand.Reader)
+	require.NoError(err, "NewSigner")
+
+	// Initialize registry state.
+	runtime := registry.Runtime{
+		ID:   common.NewTestNamespaceFromSeed([]byte("cometbft/apps/roothash/liveness_test: paused runtime"), 0),
+		Kind: registry.KindCompute,
+		Executor: registry.ExecutorParameters{
+			RoundTimeout:               5,
+			MinLiveRoundsForEvaluation: 10,
+			MinLiveRoundsPercent:       90,
+			MaxLivenessFailures:        4,
+		},
+	}
+	registryState := registryState.NewMutableState(ctx.State())
+	err = registryState.SetRuntime(ctx, &runtime, false)
+	require.NoError(err, "SetRuntime")
+	err = registryState.SetNodeStatus(ctx, sk.Public(), &registry.NodeStatus{})
+	require.NoError(err, "SetNodeStatus")
+
+	executorCommittee := scheduler.Committee{
+		RuntimeID: runtime.ID,
+		Kind:      scheduler.KindComputeExecutor,
+		Members: []*scheduler.CommitteeNode{
+			{
+				Role:      scheduler.RoleWorker,
+				PublicKey: sk.Public(),
+			},
+		},
+	}
+
+	// Initialize roothash state with a pending round timeout and statistics of a faulty node.
+	const timeoutHeight = 10
+	roothashState := roothashState.NewMutableState(ctx.State())
+	err = roothashState.SetConsensusParameters(ctx, &roothash.ConsensusParameters{})
+	require.NoError(err, "SetConsensusParameters")
+	blk := block.NewGenesisBlock(runtime.ID, 0)
+	err = roothashState.SetRuntimeState(ctx, &roothash.RuntimeState{
+		Runtime:          &runtime,
+		GenesisBlock:     blk,
+		LastBlock:        blk,
+		LastBlockHeight:  1,
+		LastNormalRound:  0,
+		LastNormalHeight: 1,
+		Committee:        &executorCommittee,
+		CommitmentPool:   commitment.NewPool(),
+		NextTimeout:      timeoutHeight,
+		LivenessStatistics: &roothash.LivenessStatistics{
+			TotalRounds:        100,
+			LiveRounds:         []uint64{89}, // At least 90 required.
+			FinalizedProposals: []uint64{80},
+			MissedProposals:    []uint64{20},
+		},
+	})
+	require.NoError(err, "SetRuntimeState")
+	err = roothashState.ScheduleRoundTimeout(ctx, runtime.ID, timeoutHeight)
+	require.NoError(err, "ScheduleRoundTimeout")
+
+	// Pause the runtime.
+	_, err = app.changeParameters(ctx, &governance.ChangeParametersProposal{
+		Module:  roothash.ModuleName,
+		Changes: cbor.Marshal(roothash.ConsensusParameterChanges{PauseRuntimes: []common.Namespace{runtime.ID}}),
+	}, true)
+	require.NoError(err, "pausing a runtime should succeed")
+
+	// The pending round timeout should be disarmed.
+	rtState, err := roothashState.RuntimeState(ctx, runtime.ID)
+	require.NoError(err, "RuntimeState")
+	require.Equal(roothash.TimeoutNever, rtState.NextTimeout, "round timeout should be disarmed")
+	timeouts, err := roothashState.RuntimesWithRoundTimeouts(ctx, timeoutHeight)
+	require.NoError(err, "RuntimesWithRoundTimeouts")
+	require.Empty(timeouts, "round timeout should be cleared")
+
+	// Forced finalization should not fail the round.
+	err = app.tryFinalizeRound(ctx, runtime.ID, true)
+	require.NoError(err, "tryFinalizeRound")
+	rtState, err = roothashState.RuntimeState(ctx, runtime.ID)
+	require.NoError(err, "RuntimeState")
+	require.EqualValues(0, rtState.LastBlock.Header.Round, "no round should be finalized")
+	require.EqualValues([]uint64{20}, rtState.LivenessStatistics.MissedProposals, "no proposals should be missed")
+
+	// Liveness statistics should not be evaluated.
+	epoch := beacon.EpochTime(0)
+	_, err = app.doBeforeSchedule(ctx, epoch)
+	require.NoError(err, "doBeforeSchedule")
+	status, err := registryState.NodeStatus(ctx, sk.Public())
+	require.NoError(err, "NodeStatus")
+	require.False(status.IsSuspended(runtime.ID, epoch), "node should not be suspended")
+	require.Empty(status.Faults, "no faults should be recorded")
+
+	// Once resumed, liveness statistics should be evaluated again.
+	_, err = app.changeParameters(ctx, &governance.ChangeParametersProposal{
+		Module:  roothash.ModuleName,
+		Changes: cbor.Marshal(roothash.ConsensusParameterChanges{ResumeRuntimes: []common.Namespace{runtime.ID}}),
+	}, true)
+	require.NoError(err, "resuming a runtime should succeed")
+	_, err = app.doBeforeSchedule(ctx, epoch)
+	require.NoError(err, "doBeforeSchedule")
+	status, err = registryState.NodeStatus(ctx, sk.Public())
+	require.NoError(err, "NodeStatus")
+	require.True(status.IsSuspended(runtime.ID, epoch), "node should be suspended")
+}
+
+func TestDiscrepancyProcessing(t *testing.T) {
+	require := require.New(t)
+
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx.Close()
+
+	// Initialize consensus state.
+	consState := consensusState.NewMutableState(ctx.State())
+	err := consState.SetConsensusParameters(ctx, &consensusGenesis.Parameters{
+		FeatureVersion: &migrations.Version250,
+	})
+	require.NoError(err, "SetConsensusParameters")
+
+	// Register an entity with a single node.
+	ent, entitySigner, _ := entity.TestEntity()
+	sigEntity, err := entity.SignEntity(entitySigner, registry.RegisterEntitySignatureContext, ent)
+	require.NoError(err, "SignEntity")
+	regState := registryState.NewMutableState(ctx.State())
+	err = regState.SetEntity(ctx, ent, sigEntity)
+	require.NoError(err, "SetEntity")
+
+	nodeSigner := memorySigner.NewTestSigner("discrepancy test signer")
+	nod := &node.Node{
+		Versioned: cbor.NewVersioned(node.LatestNodeDescriptorVersion),
+		ID:        nodeSigner.Public(),
+		EntityID:  ent.ID,
+	}
+	sigNode, err := node.MultiSignNode([]signature.Signer{nodeSigner}, registry.RegisterNodeSignatureContext, nod)
+	require.NoError(err, "MultiSignNode")
+	err = regState.SetNode(ctx, nil, nod, sigNode)
+	require.NoError(err, "SetNode")
+	err = regState.SetNodeStatus(ctx, nod.ID, &registry.NodeStatus{})
+	require.NoError(err, "SetNodeStatus")
+
+	// Give the entity some stake.
+	stakeState := stakingState.NewMutableState(ctx.State())
+	addr := staking.NewAddress(ent.ID)
+	err = stakeState.SetAccount(ctx, addr, &staking.Account{
+		Escrow: staking.EscrowAccount{
+			Active: staking.SharePool{
+				Balance:     *quantity.NewFromUint64(300),
+				TotalShares: *quantity.NewFromUint64(300),
+			},
+		},
+	})
+	require.NoError(err, "SetAccount")
+
+	runtime := registry.Runtime{
+		Staking: registry.RuntimeStakingParameters{
+			MaxDiscrepancies: 2,
+			Slashing: map[staking.SlashReason]staking.Slash{
+				staking.SlashRuntimeRepeatedDiscrepancy: {
+					Amount:         *quantity.NewFromUint64(100),
+					FreezeInterval: 5,
+				},
+			},
+		},
+	}
+	committee := scheduler.Committee{
+		RuntimeID: runtime.ID,
+		Kind:      scheduler.KindComputeExecutor,
+		Members: []*scheduler.CommitteeNode{
+			{Role: scheduler.RoleWorker, PublicKey: nod.ID},
+			{Role: scheduler.RoleBackupWorker, PublicKey: nod.ID},
+		},
+	}
+	rtState := &roothash.RuntimeState{
+		Runtime:            &runtime,
+		Committee:          &committee,
+		LivenessStatistics: roothash.NewLivenessStatistics(len(committee.Members)),
+	}
+	epoch := beacon.EpochTime(10)
+
+	// Nothing should happen when no discrepancies were recorded.
+	err = processDiscrepancyStatistics(ctx, epoch, rtState)
+	require.NoError(err, "processDiscrepancyStatistics")
+
+	// Nothing should happen when the node is within the limit.
+	rtState.LivenessStatistics.RecordDiscrepancy(0)
+	rtState.LivenessStatistics.RecordDiscrepancy(0)
+	rtState.LivenessStatistics.RecordDiscrepancy(1) // Backup role should be ignored.
+	err = processDiscrepancyStatistics(ctx, epoch, rtState)
+	require.NoError(err, "processDiscrepancyStatistics")
+	status, err := regState.NodeStatus(ctx, nod.ID)
+	require.NoError(err, "NodeStatus")
+	require.False(status.IsFrozen(), "node should not be frozen")
+
+	// The node should be frozen and its entity slashed when it exceeds the limit.
+	rtState.LivenessStatistics.RecordDiscrepancy(0)
+	err = processDiscrepancyStatistics(ctx, epoch, rtState)
+	require.NoError(err, "processDiscrepancyStatistics")
+	status, err = regState.NodeStatus(ctx, nod.ID)
+	require.NoError(err, "NodeStatus")
+	require.True(status.IsFrozen(), "node should be frozen")
+	require.EqualValues(epoch+5, status.FreezeEndTime, "freeze end time should be set")
+	require.Equal(registry.FreezeReasonSlashed, status.FreezeReason)
+
+	acct, err := stakeState.Account(ctx, addr)
+	require.NoError(err, "Account")
+	require.EqualValues(*quantity.NewFromUint64(200), acct.Escrow.Active.Balance, "entity stake should be slashed")
+
+	var slashed int
+	for _, ev := range ctx.GetEvents() {
+		for _, attr := range ev.Attributes {
+			if attr.Key == (&roothash.SlashedEvent{}).EventKind() {
+				slashed++
+			}
+		}
+	}
+	require.Equal(1, slashed, "slashed event should be emitted")
+}
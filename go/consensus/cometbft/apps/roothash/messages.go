@@ -12,10 +12,13 @@ import (
 	registryState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/registry/state"
 	roothashApi "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/roothash/api"
 	roothashState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/roothash/state"
+	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/features"
 	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
 	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/commitment"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/message"
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+	"github.com/oasisprotocol/oasis-core/go/upgrade/migrations"
 )
 
 func fetchRuntimeMessages(
@@ -58,38 +61,55 @@ func (app *rootHashApplication) removeRuntimeMessages(
 	state *roothashState.MutableState,
 	runtimeID common.Namespace,
 	msgs []*message.IncomingMessage,
+	results []message.IncomingMessageResult,
 	round uint64,
-) error {
+) ([]*roothash.InMsgProcessedEvent, error) {
 	if len(msgs) == 0 {
-		return nil
+		return nil, nil
+	}
+
+	// Incoming message results are only reported with the 25.0 release.
+	reportResults, err := features.IsFeatureVersion(ctx, migrations.Version250)
+	if err != nil {
+		return nil, err
+	}
+	if !reportResults {
+		results = nil
 	}
 
 	// Remove processed messages from the incoming message queue.
 	meta, err := state.IncomingMessageQueueMeta(ctx, runtimeID)
 	if err != nil {
-		return fmt.Errorf("failed to fetch incoming message queue metadata: %w", err)
+		return nil, fmt.Errorf("failed to fetch incoming message queue metadata: %w", err)
 	}
 
-	for _, msg := range msgs {
+	events := make([]*roothash.InMsgProcessedEvent, 0, len(msgs))
+	for i, msg := range msgs {
 		err = state.RemoveIncomingMessageFromQueue(ctx, runtimeID, msg.ID)
 		if err != nil {
-			return fmt.Errorf("failed to remove processed incoming message from queue: %w", err)
+			return nil, fmt.Errorf("failed to remove processed incoming message from queue: %w", err)
 		}
 
 		if meta.Size == 0 {
 			// This should NEVER happen.
-			return tmapi.UnavailableStateError(fmt.Errorf("inconsistent queue size (state corruption?)"))
+			return nil, tmapi.UnavailableStateError(fmt.Errorf("inconsistent queue size (state corruption?)"))
 		}
 		meta.Size--
 
+		ev := &roothash.InMsgProcessedEvent{
+			ID:     msg.ID,
+			Round:  round,
+			Caller: msg.Caller,
+			Tag:    msg.Tag,
+		}
+		if i < len(results) {
+			ev.Result = &results[i]
+		}
+		events = append(events, ev)
+
 		ctx.EmitEvent(
 			tmapi.NewEventBuilder(app.Name()).
-				TypedAttribute(&roothash.InMsgProcessedEvent{
-					ID:     msg.ID,
-					Round:  round,
-					Caller: msg.Caller,
-					Tag:    msg.Tag,
-				}).
+				TypedAttribute(ev).
 				TypedAttribute(&roothash.RuntimeIDAttribute{ID: runtimeID}),
 		)
 	}
@@ -97,10 +117,13 @@ func (app *rootHashApplication) removeRuntimeMessages(
 	// Update the incoming message queue meta.
 	err = state.SetIncomingMessageQueueMeta(ctx, runtimeID, meta)
 	if err != nil {
-		return fmt.Errorf("failed to set incoming message queue metadata: %w", err)
+		return nil, fmt.Errorf("failed to set incoming message queue metadata: %w", err)
 	}
 
-	return nil
+	if !reportResults {
+		return nil, nil
+	}
+	return events, nil
 }
 
 func (app *rootHashApplication) processRuntimeMessages(
@@ -179,10 +202,23 @@ func (app *rootHashApplication) doBeforeSchedule(ctx *tmapi.Context, msg interfa
 	regState := registryState.NewMutableState(ctx.State())
 	runtimes, _ := regState.Runtimes(ctx)
 
+	params, err := state.ConsensusParameters(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get consensus parameters: %w", err)
+	}
+
 	for _, rt := range runtimes {
 		if !rt.IsCompute() {
 			continue
 		}
+		if params.IsRuntimePaused(rt.ID) {
+			// Committee members cannot make progress while the runtime is paused, so they
+			// should not be penalized for it.
+			ctx.Logger().Debug("skipping liveness statistics of paused runtime",
+				"runtime_id", rt.ID,
+			)
+			continue
+		}
 
 		rtState, err := state.RuntimeState(ctx, rt.ID)
 		if err != nil {
@@ -191,6 +227,9 @@ func (app *rootHashApplication) doBeforeSchedule(ctx *tmapi.Context, msg interfa
 		if err = processLivenessStatistics(ctx, epoch, rtState); err != nil {
 			return nil, fmt.Errorf("failed to process liveness statistics for %s: %w", rt.ID, err)
 		}
+		if err = processDiscrepancyStatistics(ctx, epoch, rtState); err != nil {
+			return nil, fmt.Errorf("failed to process discrepancy statistics for %s: %w", rt.ID, err)
+		}
 	}
 	return nil, nil
 }
@@ -226,6 +265,11 @@ func (app *rootHashApplication) changeParameters(ctx *tmapi.Context, msg interfa
 	if err = changes.SanityCheck(); err != nil {
 		return nil, fmt.Errorf("roothash: failed to validate consensus parameter changes: %w", err)
 	}
+	for _, id := range changes.PauseRuntimes {
+		if _, err = state.RuntimeState(ctx, id); err != nil {
+			return nil, fmt.Errorf("roothash: failed to fetch state of runtime %s: %w", id, err)
+		}
+	}
 	if err = changes.Apply(params); err != nil {
 		return nil, fmt.Errorf("roothash: failed to apply consensus parameter changes: %w", err)
 	}
@@ -245,8 +289,90 @@ func (app *rootHashApplication) changeParameters(ctx *tmapi.Context, msg interfa
 		if err = state.SetConsensusParameters(ctx, params); err != nil {
 			return nil, fmt.Errorf("roothash: failed to update consensus parameters: %w", err)
 		}
+
+		if err = app.emitRuntimePauseEvents(ctx, state, &changes); err != nil {
+			return nil, err
+		}
+
+		if err = discardPausedRuntimeRounds(ctx, state, changes.PauseRuntimes); err != nil {
+			return nil, err
+		}
 	}
 
 	// Non-nil response signals that changes are valid and were successfully applied (if required).
 	return struct{}{}, nil
 }
+
+// emitRuntimePauseEvents emits events for all runtimes paused or resumed by the given changes.
+func (app *rootHashApplication) emitRuntimePauseEvents(
+	ctx *tmapi.Context,
+	state *roothashState.MutableState,
+	changes *roothash.ConsensusParameterChanges,
+) error {
+	emit := func(id common.Namespace, paused bool) error {
+		rtState, err := state.RuntimeState(ctx, id)
+		if err != nil {
+			return fmt.Errorf("roothash: failed to fetch state of runtime %s: %w", id, err)
+		}
+		round := rtState.LastBlock.Header.Round
+
+		ctx.Logger().Info("runtime pause state changed via governance",
+			"runtime_id", id,
+			"paused", paused,
+			"round", round,
+		)
+
+		evb := tmapi.NewEventBuilder(app.Name())
+		if paused {
+			evb.TypedAttribute(&roothash.RuntimePausedEvent{Round: round})
+		} else {
+			evb.TypedAttribute(&roothash.RuntimeResumedEvent{Round: round})
+		}
+		ctx.EmitEvent(evb.TypedAttribute(&roothash.RuntimeIDAttribute{ID: id}))
+		return nil
+	}
+
+	for _, id := range changes.PauseRuntimes {
+		if err := emit(id, true); err != nil {
+			return err
+		}
+	}
+	for _, id := range changes.ResumeRuntimes {
+		if err := emit(id, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// discardPausedRuntimeRounds discards the pending round of each of the given paused runtimes and
+// disarms its round timeout, so that the round does not fail while the runtime is paused.
+func discardPausedRuntimeRounds(
+	ctx *tmapi.Context,
+	state *roothashState.MutableState,
+	ids []common.Namespace,
+) error {
+	for _, id := range ids {
+		rtState, err := state.RuntimeState(ctx, id)
+		if err != nil {
+			return fmt.Errorf("roothash: failed to fetch state of runtime %s: %w", id, err)
+		}
+
+		if rtState.CommitmentPool != nil {
+			rtState.CommitmentPool = commitment.NewPool()
+		}
+
+		prevTimeout := rtState.NextTimeout
+		rtState.NextTimeout = roothash.TimeoutNever
+
+		round := rtState.LastBlock.Header.Round + 1
+		if err = rearmRoundTimeout(ctx, id, round, prevTimeout, rtState.NextTimeout); err != nil {
+			return fmt.Errorf("roothash: failed to disarm round timeout of runtime %s: %w", id, err)
+		}
+
+		if err = state.SetRuntimeState(ctx, rtState); err != nil {
+			return fmt.Errorf("roothash: failed to set state of runtime %s: %w", id, err)
+		}
+	}
+	return nil
+}
@@ -6,13 +6,20 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/version"
+	eventsAPI "github.com/oasisprotocol/oasis-core/go/consensus/api/events"
+	consensusState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/abci/state"
 	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
 	roothashState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/roothash/state"
+	consensusGenesis "github.com/oasisprotocol/oasis-core/go/consensus/genesis"
 	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
 	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
 	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/message"
+	"github.com/oasisprotocol/oasis-core/go/upgrade/migrations"
 )
 
 func TestChangeParameters(t *testing.T) {
@@ -92,6 +99,177 @@ func TestChangeParameters(t *testing.T) {
 	})
 }
 
+func TestChangeParametersPauseRuntime(t *testing.T) {
+	require := require.New(t)
+
+	// Prepare context.
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx.Close()
+
+	// Setup state.
+	state := roothashState.NewMutableState(ctx.State())
+	app := &rootHashApplication{
+		state: appState,
+	}
+	err := state.SetConsensusParameters(ctx, &roothash.ConsensusParameters{})
+	require.NoError(err, "setting consensus parameters should succeed")
+
+	rt, _ := initRuntimeGenesisBlock(require, ctx, 0)
+
+	changeParameters := func(changes roothash.ConsensusParameterChanges) error {
+		proposal := governance.ChangeParametersProposal{
+			Module:  roothash.ModuleName,
+			Changes: cbor.Marshal(changes),
+		}
+		_, err := app.changeParameters(ctx, &proposal, true)
+		return err
+	}
+
+	// Pausing an unknown runtime should fail.
+	var unknownID common.Namespace
+	err = unknownID.UnmarshalHex(fmt.Sprintf("8%0*d", 63, 99))
+	require.NoError(err, "UnmarshalHex")
+	err = changeParameters(roothash.ConsensusParameterChanges{PauseRuntimes: []common.Namespace{unknownID}})
+	require.Error(err, "pausing an unknown runtime should fail")
+
+	// Pausing and resuming the same runtime at once should fail.
+	err = changeParameters(roothash.ConsensusParameterChanges{
+		PauseRuntimes:  []common.Namespace{rt.ID},
+		ResumeRuntimes: []common.Namespace{rt.ID},
+	})
+	require.Error(err, "pausing and resuming the same runtime should fail")
+
+	// Resuming a runtime that is not paused should fail.
+	err = changeParameters(roothash.ConsensusParameterChanges{ResumeRuntimes: []common.Namespace{rt.ID}})
+	require.Error(err, "resuming a runtime that is not paused should fail")
+
+	// Pause the runtime.
+	err = changeParameters(roothash.ConsensusParameterChanges{PauseRuntimes: []common.Namespace{rt.ID}})
+	require.NoError(err, "pausing a runtime should succeed")
+
+	params, err := state.ConsensusParameters(ctx)
+	require.NoError(err, "fetching consensus parameters should succeed")
+	require.True(params.IsRuntimePaused(rt.ID), "runtime should be paused")
+
+	// Pausing a paused runtime should fail.
+	err = changeParameters(roothash.ConsensusParameterChanges{PauseRuntimes: []common.Namespace{rt.ID}})
+	require.Error(err, "pausing a paused runtime should fail")
+
+	// Resume the runtime.
+	err = changeParameters(roothash.ConsensusParameterChanges{ResumeRuntimes: []common.Namespace{rt.ID}})
+	require.NoError(err, "resuming a paused runtime should succeed")
+
+	params, err = state.ConsensusParameters(ctx)
+	require.NoError(err, "fetching consensus parameters should succeed")
+	require.False(params.IsRuntimePaused(rt.ID), "runtime should not be paused")
+
+	// Make sure the pause and resume events were emitted.
+	var paused, resumed int
+	for _, ev := range ctx.GetEvents() {
+		for _, attr := range ev.Attributes {
+			switch attr.Key {
+			case (&roothash.RuntimePausedEvent{}).EventKind():
+				paused++
+			case (&roothash.RuntimeResumedEvent{}).EventKind():
+				resumed++
+			}
+		}
+	}
+	require.Equal(1, paused, "runtime paused event should be emitted")
+	require.Equal(1, resumed, "runtime resumed event should be emitted")
+}
+
+func TestRemoveRuntimeMessages(t *testing.T) {
+	for _, tc := range []struct {
+		name           string
+		featureVersion *version.Version
+	}{
+		{"FeatureEnabled", &migrations.Version250},
+		{"FeatureDisabled", nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require := require.New(t)
+
+			// Prepare context.
+			appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+			ctx := appState.NewContext(abciAPI.ContextEndBlock)
+			defer ctx.Close()
+
+			// Setup state.
+			consState := consensusState.NewMutableState(ctx.State())
+			err := consState.SetConsensusParameters(ctx, &consensusGenesis.Parameters{
+				FeatureVersion: tc.featureVersion,
+			})
+			require.NoError(err, "SetConsensusParameters")
+
+			state := roothashState.NewMutableState(ctx.State())
+			app := &rootHashApplication{
+				state: appState,
+			}
+			err = state.SetConsensusParameters(ctx, &roothash.ConsensusParameters{})
+			require.NoError(err, "setting consensus parameters should succeed")
+
+			rt, _ := initRuntimeGenesisBlock(require, ctx, 0)
+
+			msgs := []*message.IncomingMessage{
+				{ID: 0, Tag: 42},
+				{ID: 1, Tag: 43},
+			}
+			for _, msg := range msgs {
+				err = state.SetIncomingMessageInQueue(ctx, rt.ID, msg)
+				require.NoError(err, "SetIncomingMessageInQueue")
+			}
+			err = state.SetIncomingMessageQueueMeta(ctx, rt.ID, &message.IncomingMessageQueueMeta{
+				Size:               uint32(len(msgs)),
+				NextSequenceNumber: uint64(len(msgs)),
+			})
+			require.NoError(err, "SetIncomingMessageQueueMeta")
+
+			results := []message.IncomingMessageResult{
+				{},
+				{Module: "test", Code: 1},
+			}
+			evs, err := app.removeRuntimeMessages(ctx, state, rt.ID, msgs, results, 1)
+			require.NoError(err, "removeRuntimeMessages")
+
+			meta, err := state.IncomingMessageQueueMeta(ctx, rt.ID)
+			require.NoError(err, "IncomingMessageQueueMeta")
+			require.EqualValues(0, meta.Size, "processed messages should be removed from the queue")
+
+			// Processed message events should always be emitted, but should only include results
+			// when the feature is enabled.
+			var emitted []*roothash.InMsgProcessedEvent
+			for _, ev := range ctx.GetEvents() {
+				for _, pair := range ev.GetAttributes() {
+					if !eventsAPI.IsAttributeKind(pair.GetKey(), &roothash.InMsgProcessedEvent{}) {
+						continue
+					}
+					var e roothash.InMsgProcessedEvent
+					err = eventsAPI.DecodeValue(pair.GetValue(), &e)
+					require.NoError(err, "DecodeValue")
+					emitted = append(emitted, &e)
+				}
+			}
+			require.Len(emitted, len(msgs), "processed message events should be emitted")
+
+			switch tc.featureVersion {
+			case nil:
+				require.Nil(evs, "results should not be reported")
+				for _, e := range emitted {
+					require.Nil(e.Result, "events should not include results")
+				}
+			default:
+				require.Len(evs, len(msgs), "results should be reported")
+				for i, e := range emitted {
+					require.EqualValues(&results[i], e.Result, "events should include results")
+					require.EqualValues(&results[i], evs[i].Result, "round results should include results")
+				}
+			}
+		})
+	}
+}
+
 func initRuntimeGenesisBlock(require *require.Assertions, ctx *abciAPI.Context, id int) (*registry.Runtime, *block.Block) {
 	var runtime registry.Runtime
 	err := runtime.ID.UnmarshalHex(fmt.Sprintf("8%0*d", 63, id))
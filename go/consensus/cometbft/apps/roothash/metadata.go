@@ -0,0 +1,78 @@
+package roothash
+
+import (
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	tmapi "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+	roothashState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/roothash/state"
+	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/features"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+	"github.com/oasisprotocol/oasis-core/go/upgrade/migrations"
+)
+
+// setLastRoundMetadata records the executor commitment details of the current round which is
+// about to be finalized using a block of the given type.
+//
+// This method must be called before the commitment pool is reset.
+func setLastRoundMetadata(
+	ctx *tmapi.Context,
+	rtState *roothash.RuntimeState,
+	hdrType block.HeaderType,
+	timeout bool,
+	roundErr error,
+) error {
+	// Record round metadata with the 25.0 release.
+	enabled, err := features.IsFeatureVersion(ctx, migrations.Version250)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return nil
+	}
+
+	pool := rtState.CommitmentPool
+	metadata := roothash.RoundMetadata{
+		Round:          rtState.LastBlock.Header.Round + 1,
+		HeaderType:     hdrType,
+		StartHeight:    rtState.LastBlockHeight,
+		FinalizeHeight: ctx.BlockHeight() + 1, // Current height is ctx.BlockHeight() + 1
+		Timeout:        timeout,
+		Discrepancy:    pool.Discrepancy,
+		Rank:           pool.HighestRank,
+	}
+	if roundErr != nil {
+		metadata.Error = roundErr.Error()
+	}
+
+	var schedulerVote hash.Hash
+	sc := pool.SchedulerCommitments[pool.HighestRank]
+	if sc != nil {
+		schedulerID := sc.Commitment.Header.SchedulerID
+		metadata.SchedulerID = &schedulerID
+		schedulerVote = sc.Commitment.ToVote()
+	}
+	for _, n := range rtState.Committee.Members {
+		status := roothash.CommitmentMissing
+		if sc != nil {
+			vote, ok := sc.Votes[n.PublicKey]
+			switch {
+			case !ok:
+			case vote == nil:
+				status = roothash.CommitmentFailed
+			case vote.Equal(&schedulerVote):
+				status = roothash.CommitmentAgreed
+			default:
+				status = roothash.CommitmentDisagreed
+			}
+		}
+
+		metadata.Members = append(metadata.Members, &roothash.RoundMemberStatus{
+			PublicKey: n.PublicKey,
+			Role:      n.Role,
+			Status:    status,
+		})
+	}
+
+	state := roothashState.NewMutableState(ctx.State())
+	return state.SetLastRoundMetadata(ctx, rtState.Runtime.ID, &metadata)
+}
@@ -17,10 +17,13 @@ type Query interface {
 	GenesisBlock(context.Context, common.Namespace) (*block.Block, error)
 	RuntimeState(context.Context, common.Namespace) (*roothash.RuntimeState, error)
 	LastRoundResults(context.Context, common.Namespace) (*roothash.RoundResults, error)
+	LastRoundMetadata(context.Context, common.Namespace) (*roothash.RoundMetadata, error)
+	LivenessStatistics(context.Context, common.Namespace) (*roothash.RuntimeLivenessStatistics, error)
 	RoundRoots(context.Context, common.Namespace, uint64) (*roothash.RoundRoots, error)
 	PastRoundRoots(context.Context, common.Namespace) (map[uint64]roothash.RoundRoots, error)
 	IncomingMessageQueueMeta(context.Context, common.Namespace) (*message.IncomingMessageQueueMeta, error)
 	IncomingMessageQueue(ctx context.Context, id common.Namespace, offset uint64, limit uint32) ([]*message.IncomingMessage, error)
+	CheckpointManifests(context.Context, common.Namespace) ([]*roothash.CheckpointManifest, error)
 	Genesis(context.Context) (*roothash.Genesis, error)
 	ConsensusParameters(context.Context) (*roothash.ConsensusParameters, error)
 }
@@ -67,6 +70,18 @@ func (rq *rootHashQuerier) LastRoundResults(ctx context.Context, id common.Names
 	return rq.state.LastRoundResults(ctx, id)
 }
 
+func (rq *rootHashQuerier) LastRoundMetadata(ctx context.Context, id common.Namespace) (*roothash.RoundMetadata, error) {
+	return rq.state.LastRoundMetadata(ctx, id)
+}
+
+func (rq *rootHashQuerier) LivenessStatistics(ctx context.Context, id common.Namespace) (*roothash.RuntimeLivenessStatistics, error) {
+	rtState, err := rq.state.RuntimeState(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return roothash.NewRuntimeLivenessStatistics(rtState), nil
+}
+
 func (rq *rootHashQuerier) RoundRoots(ctx context.Context, id common.Namespace, round uint64) (*roothash.RoundRoots, error) {
 	return rq.state.RoundRoots(ctx, id, round)
 }
@@ -83,6 +98,10 @@ func (rq *rootHashQuerier) IncomingMessageQueue(ctx context.Context, id common.N
 	return rq.state.IncomingMessageQueue(ctx, id, offset, limit)
 }
 
+func (rq *rootHashQuerier) CheckpointManifests(ctx context.Context, id common.Namespace) ([]*roothash.CheckpointManifest, error) {
+	return rq.state.CheckpointManifests(ctx, id)
+}
+
 func (rq *rootHashQuerier) ConsensusParameters(ctx context.Context) (*roothash.ConsensusParameters, error) {
 	return rq.state.ConsensusParameters(ctx)
 }
@@ -301,6 +301,13 @@ func (app *rootHashApplication) ExecuteTx(ctx *tmapi.Context, tx *transaction.Tr
 		}
 
 		return app.submitEvidence(ctx, state, &ev)
+	case roothash.MethodEvidenceBatch:
+		var eb roothash.EvidenceBatch
+		if err := cbor.Unmarshal(tx.Body, &eb); err != nil {
+			return roothash.ErrInvalidArgument
+		}
+
+		return app.submitEvidenceBatch(ctx, state, &eb)
 	case roothash.MethodSubmitMsg:
 		var msg roothash.SubmitMsg
 		if err := cbor.Unmarshal(tx.Body, &msg); err != nil {
@@ -308,6 +315,13 @@ func (app *rootHashApplication) ExecuteTx(ctx *tmapi.Context, tx *transaction.Tr
 		}
 
 		return app.submitMsg(ctx, state, &msg)
+	case roothash.MethodPublishCheckpoint:
+		var pc roothash.PublishCheckpoint
+		if err := cbor.Unmarshal(tx.Body, &pc); err != nil {
+			return roothash.ErrInvalidArgument
+		}
+
+		return app.publishCheckpoint(ctx, state, &pc)
 	default:
 		return roothash.ErrInvalidArgument
 	}
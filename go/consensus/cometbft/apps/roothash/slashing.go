@@ -9,12 +9,54 @@ import (
 	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
 	registryState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/registry/state"
 	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/staking/state"
+	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/features"
 	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
 	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+	"github.com/oasisprotocol/oasis-core/go/upgrade/migrations"
 )
 
-func onRuntimeLivenessFailure(ctx *abciAPI.Context, nodeID signature.PublicKey, penaltyAmount *quantity.Quantity) error {
+// emitSlashedEvent emits an event for a runtime-level slashing of the given account.
+func emitSlashedEvent(
+	ctx *abciAPI.Context,
+	runtimeID common.Namespace,
+	owner staking.Address,
+	reason staking.SlashReason,
+	amount *quantity.Quantity,
+) error {
+	if amount.IsZero() {
+		return nil
+	}
+
+	// Emit slashed events with the 25.0 release.
+	enabled, err := features.IsFeatureVersion(ctx, migrations.Version250)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return nil
+	}
+
+	ctx.EmitEvent(
+		abciAPI.NewEventBuilder(AppName).
+			TypedAttribute(&roothash.SlashedEvent{
+				Owner:  owner,
+				Reason: reason,
+				Amount: *amount,
+			}).
+			TypedAttribute(&roothash.RuntimeIDAttribute{ID: runtimeID}),
+	)
+	return nil
+}
+
+// slashRuntimeNode slashes the entity owning the given runtime node.
+func slashRuntimeNode(
+	ctx *abciAPI.Context,
+	runtimeID common.Namespace,
+	nodeID signature.PublicKey,
+	reason staking.SlashReason,
+	penaltyAmount *quantity.Quantity,
+) error {
 	if penaltyAmount.IsZero() {
 		return nil
 	}
@@ -30,12 +72,30 @@ func onRuntimeLivenessFailure(ctx *abciAPI.Context, nodeID signature.PublicKey,
 
 	// Slash runtime node entity.
 	entityAddr := staking.NewAddress(node.EntityID)
-	_, err = stakeState.SlashEscrow(ctx, entityAddr, penaltyAmount)
+	slashed, err := stakeState.SlashEscrow(ctx, entityAddr, penaltyAmount)
 	if err != nil {
 		return fmt.Errorf("error slashing account %s: %w", entityAddr, err)
 	}
 
-	return nil
+	return emitSlashedEvent(ctx, runtimeID, entityAddr, reason, slashed)
+}
+
+func onRuntimeLivenessFailure(
+	ctx *abciAPI.Context,
+	runtimeID common.Namespace,
+	nodeID signature.PublicKey,
+	penaltyAmount *quantity.Quantity,
+) error {
+	return slashRuntimeNode(ctx, runtimeID, nodeID, staking.SlashRuntimeLiveness, penaltyAmount)
+}
+
+func onRuntimeRepeatedDiscrepancy(
+	ctx *abciAPI.Context,
+	runtimeID common.Namespace,
+	nodeID signature.PublicKey,
+	penaltyAmount *quantity.Quantity,
+) error {
+	return slashRuntimeNode(ctx, runtimeID, nodeID, staking.SlashRuntimeRepeatedDiscrepancy, penaltyAmount)
 }
 
 func onEvidenceRuntimeEquivocation(
@@ -76,6 +136,9 @@ func onEvidenceRuntimeEquivocation(
 		)
 		return nil
 	}
+	if err = emitSlashedEvent(ctx, runtime.ID, entityAddr, staking.SlashRuntimeEquivocation, totalSlashed); err != nil {
+		return fmt.Errorf("cometbft/roothash: failed to emit slashed event: %w", err)
+	}
 
 	// If the caller is a node, distribute slashed funds to the controlling entity instead of the
 	// caller directly.
@@ -121,6 +184,9 @@ func onRuntimeIncorrectResults(
 		if err = totalSlashed.Add(slashed); err != nil {
 			return fmt.Errorf("cometbft/roothash: totalSlashed.Add(slashed): %w", err)
 		}
+		if err = emitSlashedEvent(ctx, runtime.ID, entityAddr, staking.SlashRuntimeIncorrectResults, slashed); err != nil {
+			return fmt.Errorf("cometbft/roothash: failed to emit slashed event: %w", err)
+		}
 		ctx.Logger().Debug("runtime node entity slashed for incorrect results",
 			"slashed", slashed,
 			"total_slashed", totalSlashed,
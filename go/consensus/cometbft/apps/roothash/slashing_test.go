@@ -12,11 +12,15 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/entity"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	consensusState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/abci/state"
 	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
 	registryState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/registry/state"
 	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/staking/state"
+	consensusGenesis "github.com/oasisprotocol/oasis-core/go/consensus/genesis"
 	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+	"github.com/oasisprotocol/oasis-core/go/upgrade/migrations"
 )
 
 func TestOnEvidenceRuntimeEquivocation(t *testing.T) {
@@ -25,6 +29,15 @@ func TestOnEvidenceRuntimeEquivocation(t *testing.T) {
 	amount := quantity.NewFromUint64(100)
 
 	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+
+	// Initialize consensus state.
+	initCtx := appState.NewContext(abciAPI.ContextEndBlock)
+	consState := consensusState.NewMutableState(initCtx.State())
+	require.NoError(consState.SetConsensusParameters(initCtx, &consensusGenesis.Parameters{
+		FeatureVersion: &migrations.Version250,
+	}), "consensus.SetConsensusParameters")
+	initCtx.Close()
+
 	ctx := appState.NewContext(abciAPI.ContextDeliverTx)
 	defer ctx.Close()
 
@@ -156,6 +169,17 @@ func TestOnEvidenceRuntimeEquivocation(t *testing.T) {
 	require.NoError(balance.Sub(amount))               // Slashed amount.
 	require.NoError(balance.Add(expectedCallerReward)) // Earned rewards.
 	require.EqualValues(balance, acct.Escrow.Active.Balance, "entity stake should be slashed")
+
+	// Slashed events should be emitted.
+	var slashed int
+	for _, ev := range ctx.GetEvents() {
+		for _, attr := range ev.Attributes {
+			if attr.Key == (&roothash.SlashedEvent{}).EventKind() {
+				slashed++
+			}
+		}
+	}
+	require.Equal(2, slashed, "slashed events should be emitted")
 }
 
 func TestOnRuntimeIncorrectResults(t *testing.T) {
@@ -167,6 +191,12 @@ func TestOnRuntimeIncorrectResults(t *testing.T) {
 	ctx := appState.NewContext(abciAPI.ContextEndBlock)
 	defer ctx.Close()
 
+	// Initialize consensus state.
+	consState := consensusState.NewMutableState(ctx.State())
+	require.NoError(consState.SetConsensusParameters(ctx, &consensusGenesis.Parameters{
+		FeatureVersion: &migrations.Version250,
+	}), "consensus.SetConsensusParameters")
+
 	regState := registryState.NewMutableState(ctx.State())
 	stakeState := stakingState.NewMutableState(ctx.State())
 
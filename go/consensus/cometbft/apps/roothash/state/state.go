@@ -59,6 +59,15 @@ var (
 	// The maximum number of rounds that this map stores is defined by the
 	// roothash consensus parameters as MaxPastRootsStored.
 	pastRootsKeyFmt = consensus.KeyFormat.New(0x2a, keyformat.H(&common.Namespace{}), uint64(0))
+	// lastRoundMetadataKeyFmt is the key format used for last round metadata.
+	//
+	// Value is CBOR-serialized roothash.RoundMetadata.
+	lastRoundMetadataKeyFmt = consensus.KeyFormat.New(0x2b, keyformat.H(&common.Namespace{}))
+	// checkpointManifestKeyFmt is the key format used for published storage checkpoint manifests.
+	//
+	// Key format is: 0x2c H(<runtime-id>) <round> <checkpoint-metadata-hash>
+	// Value is CBOR-serialized roothash.CheckpointManifest.
+	checkpointManifestKeyFmt = consensus.KeyFormat.New(0x2c, keyformat.H(&common.Namespace{}), uint64(0), &hash.Hash{})
 )
 
 // ImmutableState is the immutable roothash state wrapper.
@@ -154,6 +163,23 @@ func (s *ImmutableState) LastRoundResults(ctx context.Context, id common.Namespa
 	return &results, nil
 }
 
+// LastRoundMetadata returns the last normal or failed round metadata for a specific runtime.
+func (s *ImmutableState) LastRoundMetadata(ctx context.Context, id common.Namespace) (*roothash.RoundMetadata, error) {
+	raw, err := s.is.Get(ctx, lastRoundMetadataKeyFmt.Encode(&id))
+	if err != nil {
+		return nil, api.UnavailableStateError(err)
+	}
+	if raw == nil {
+		return nil, fmt.Errorf("%w: no round metadata", roothash.ErrNotFound)
+	}
+
+	var metadata roothash.RoundMetadata
+	if err = cbor.Unmarshal(raw, &metadata); err != nil {
+		return nil, api.UnavailableStateError(err)
+	}
+	return &metadata, nil
+}
+
 func (s *ImmutableState) getRoot(ctx context.Context, id common.Namespace, kf *keyformat.KeyFormat) (hash.Hash, error) {
 	raw, err := s.is.Get(ctx, kf.Encode(&id))
 	if err != nil {
@@ -367,6 +393,61 @@ func (s *ImmutableState) PastRoundRootsCount(ctx context.Context, runtimeID comm
 	return count
 }
 
+// CheckpointManifest returns the published checkpoint manifest for the given runtime, round and
+// checkpoint metadata hash.
+//
+// If no such manifest has been published, nil is returned.
+func (s *ImmutableState) CheckpointManifest(ctx context.Context, runtimeID common.Namespace, round uint64, h hash.Hash) (*roothash.CheckpointManifest, error) {
+	raw, err := s.is.Get(ctx, checkpointManifestKeyFmt.Encode(&runtimeID, round, &h))
+	if err != nil {
+		return nil, api.UnavailableStateError(err)
+	}
+	if raw == nil {
+		return nil, nil
+	}
+
+	var manifest roothash.CheckpointManifest
+	if err = cbor.Unmarshal(raw, &manifest); err != nil {
+		return nil, api.UnavailableStateError(err)
+	}
+	return &manifest, nil
+}
+
+// CheckpointManifests returns all published checkpoint manifests for the given runtime, ordered
+// by round.
+func (s *ImmutableState) CheckpointManifests(ctx context.Context, runtimeID common.Namespace) ([]*roothash.CheckpointManifest, error) {
+	it := s.is.NewIterator(ctx)
+	defer it.Close()
+
+	// We need to pre-hash the runtime ID, so we can compare it below.
+	hID := keyformat.PreHashed(runtimeID.Hash())
+
+	var manifests []*roothash.CheckpointManifest
+	for it.Seek(checkpointManifestKeyFmt.Encode(&runtimeID)); it.Valid(); it.Next() {
+		var (
+			rtID  keyformat.PreHashed
+			round uint64
+			h     hash.Hash
+		)
+		if !checkpointManifestKeyFmt.Decode(it.Key(), &rtID, &round, &h) {
+			break
+		}
+		if rtID != hID {
+			break
+		}
+
+		var manifest roothash.CheckpointManifest
+		if err := cbor.Unmarshal(it.Value(), &manifest); err != nil {
+			return nil, api.UnavailableStateError(err)
+		}
+		manifests = append(manifests, &manifest)
+	}
+	if it.Err() != nil {
+		return nil, api.UnavailableStateError(it.Err())
+	}
+	return manifests, nil
+}
+
 // MutableState is the mutable roothash state wrapper.
 type MutableState struct {
 	*ImmutableState
@@ -496,6 +577,12 @@ func (s *MutableState) SetLastRoundResults(ctx context.Context, runtimeID common
 	return api.UnavailableStateError(err)
 }
 
+// SetLastRoundMetadata sets a runtime's last normal or failed round metadata.
+func (s *MutableState) SetLastRoundMetadata(ctx context.Context, runtimeID common.Namespace, metadata *roothash.RoundMetadata) error {
+	err := s.ms.Insert(ctx, lastRoundMetadataKeyFmt.Encode(&runtimeID), cbor.Marshal(metadata))
+	return api.UnavailableStateError(err)
+}
+
 // SetConsensusParameters sets roothash consensus parameters.
 //
 // NOTE: This method must only be called from InitChain/EndBlock contexts.
@@ -560,6 +647,70 @@ func (s *MutableState) RemoveExpiredEvidence(ctx context.Context, runtimeID comm
 	return nil
 }
 
+// SetCheckpointManifest sets a published checkpoint manifest.
+func (s *MutableState) SetCheckpointManifest(ctx context.Context, manifest *roothash.CheckpointManifest) error {
+	err := s.ms.Insert(ctx, checkpointManifestKeyFmt.Encode(&manifest.Root.Namespace, manifest.Root.Version, &manifest.Hash), cbor.Marshal(manifest))
+	return api.UnavailableStateError(err)
+}
+
+// PruneCheckpointManifests removes published checkpoint manifests for the given runtime so that
+// manifests for at most the given number of most recent rounds remain.
+func (s *MutableState) PruneCheckpointManifests(ctx context.Context, runtimeID common.Namespace, maxRounds uint64) error {
+	it := s.is.NewIterator(ctx)
+	defer it.Close()
+
+	// We need to pre-hash the runtime ID, so we can compare it below.
+	hID := keyformat.PreHashed(runtimeID.Hash())
+
+	var (
+		keys      [][]byte
+		keyRounds []uint64
+		numRounds uint64
+	)
+	for it.Seek(checkpointManifestKeyFmt.Encode(&runtimeID)); it.Valid(); it.Next() {
+		var (
+			rtID  keyformat.PreHashed
+			round uint64
+			h     hash.Hash
+		)
+		if !checkpointManifestKeyFmt.Decode(it.Key(), &rtID, &round, &h) {
+			break
+		}
+		if rtID != hID {
+			break
+		}
+
+		if len(keyRounds) == 0 || keyRounds[len(keyRounds)-1] != round {
+			numRounds++
+		}
+		keys = append(keys, it.Key())
+		keyRounds = append(keyRounds, round)
+	}
+	if it.Err() != nil {
+		return api.UnavailableStateError(it.Err())
+	}
+	if numRounds <= maxRounds {
+		// Nothing to prune.
+		return nil
+	}
+
+	// Remove manifests for the oldest rounds.
+	numPruned := numRounds - maxRounds
+	for i, key := range keys {
+		if i > 0 && keyRounds[i] != keyRounds[i-1] {
+			numPruned--
+		}
+		if numPruned == 0 {
+			break
+		}
+		if err := s.ms.Remove(ctx, key); err != nil {
+			return api.UnavailableStateError(err)
+		}
+	}
+
+	return nil
+}
+
 // SetIncomingMessageQueueMeta sets the incoming message queue metadata.
 func (s *MutableState) SetIncomingMessageQueueMeta(ctx context.Context, runtimeID common.Namespace, meta *message.IncomingMessageQueueMeta) error {
 	err := s.ms.Insert(ctx, inMsgQueueMetaKeyFmt.Encode(&runtimeID), cbor.Marshal(meta))
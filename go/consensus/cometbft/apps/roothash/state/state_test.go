@@ -305,3 +305,33 @@ func TestPastRuntimeRoots(t *testing.T) {
 	require.EqualValues(0, len(roots))
 	require.EqualValues(len(roots), st.PastRoundRootsCount(ctx, runtime.ID))
 }
+
+func TestLastRoundMetadata(t *testing.T) {
+	require := require.New(t)
+
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextBeginBlock)
+	defer ctx.Close()
+
+	s := NewMutableState(ctx.State())
+
+	rtID := common.NewTestNamespaceFromSeed([]byte("apps/roothash/state_test: runtime1"), 0)
+
+	_, err := s.LastRoundMetadata(ctx, rtID)
+	require.ErrorIs(err, api.ErrNotFound, "LastRoundMetadata should fail without metadata")
+
+	metadata := api.RoundMetadata{
+		Round:          10,
+		HeaderType:     block.RoundFailed,
+		StartHeight:    100,
+		FinalizeHeight: 105,
+		Timeout:        true,
+		Error:          "insufficient votes",
+	}
+	err = s.SetLastRoundMetadata(ctx, rtID, &metadata)
+	require.NoError(err, "SetLastRoundMetadata")
+
+	stored, err := s.LastRoundMetadata(ctx, rtID)
+	require.NoError(err, "LastRoundMetadata")
+	require.EqualValues(&metadata, stored, "stored metadata should match")
+}
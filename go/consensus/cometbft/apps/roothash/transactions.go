@@ -1,19 +1,24 @@
 package roothash
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
 	registryState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/registry/state"
 	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/roothash/api"
 	roothashState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/roothash/state"
 	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/staking/state"
+	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/features"
 	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/commitment"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/message"
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+	storage "github.com/oasisprotocol/oasis-core/go/storage/api"
+	"github.com/oasisprotocol/oasis-core/go/upgrade/migrations"
 )
 
 // getRuntimeState fetches the current runtime state and performs common
@@ -71,11 +76,30 @@ func (app *rootHashApplication) executorCommit(
 		return nil
 	}
 
+	// Reject incoming message results before they are enabled.
+	for _, commit := range cc.Commits {
+		if commit.Header.Header.InMessagesResultsHash == nil && len(commit.InMessagesResults) == 0 {
+			continue
+		}
+
+		var enabled bool
+		if enabled, err = features.IsFeatureVersion(ctx, migrations.Version250); err != nil {
+			return err
+		}
+		if !enabled {
+			return fmt.Errorf("%w: incoming message results not enabled", roothash.ErrInvalidArgument)
+		}
+		break
+	}
+
 	// Fetch the latest runtime state.
 	rtState, err := app.getRuntimeState(ctx, state, cc.ID)
 	if err != nil {
 		return err
 	}
+	if params.IsRuntimePaused(cc.ID) {
+		return roothash.ErrRuntimePaused
+	}
 	prevRank := rtState.CommitmentPool.HighestRank
 
 	// Node lookup needed for RAK-attestation.
@@ -91,9 +115,16 @@ func (app *rootHashApplication) executorCommit(
 		return msgErr
 	}
 
+	// Verify commitment signatures in a single batch, as signature verification dominates
+	// commitment processing for large committees.
+	sigErrs := commitment.VerifyExecutorCommitmentSignatures(rtState.Runtime.ID, cc.Commits)
+
 	// Verify and add commitments to the pool.
-	for _, commit := range cc.Commits {
-		if err = commitment.VerifyExecutorCommitment(ctx, rtState.LastBlock, rtState.Runtime, rtState.Committee.ValidFor, &commit, msgGasAccountant, nl); err != nil { // nolint: gosec
+	for i, commit := range cc.Commits {
+		if err = sigErrs[i]; err == nil {
+			err = commitment.VerifyExecutorCommitmentContent(ctx, rtState.LastBlock, rtState.Runtime, rtState.Committee.ValidFor, &commit, msgGasAccountant, nl) // nolint: gosec
+		}
+		if err != nil {
 			ctx.Logger().Debug("failed to verify executor commitment",
 				"err", err,
 				"runtime_id", cc.ID,
@@ -208,6 +239,91 @@ func (app *rootHashApplication) submitEvidence(
 		return nil
 	}
 
+	return app.processEvidence(ctx, state, params, evidence)
+}
+
+func (app *rootHashApplication) submitEvidenceBatch(
+	ctx *abciAPI.Context,
+	state *roothashState.MutableState,
+	batch *roothash.EvidenceBatch,
+) error {
+	// Submitting evidence batches is only supported with the 25.0 release.
+	enabled, err := features.IsFeatureVersion(ctx, migrations.Version250)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return fmt.Errorf("%w: evidence batches not enabled", roothash.ErrInvalidArgument)
+	}
+
+	if err = batch.ValidateBasic(); err != nil {
+		ctx.Logger().Debug("EvidenceBatch: submitted evidence batch not valid",
+			"err", err,
+		)
+		return fmt.Errorf("%w: %v", roothash.ErrInvalidEvidence, err)
+	}
+
+	if ctx.IsCheckOnly() {
+		return nil
+	}
+
+	// Charge gas for this transaction, once for each piece of evidence.
+	params, err := state.ConsensusParameters(ctx)
+	if err != nil {
+		ctx.Logger().Error("EvidenceBatch: failed to fetch consensus parameters",
+			"err", err,
+		)
+		return err
+	}
+	if err = ctx.Gas().UseGas(len(batch.Evidence), roothash.GasOpEvidence, params.GasCosts); err != nil {
+		return err
+	}
+
+	// Return early for simulation as we only need gas accounting.
+	if ctx.IsSimulation() {
+		return nil
+	}
+
+	// Process the batch atomically, so that a failure does not leave earlier evidence applied.
+	ctx = ctx.NewTransaction()
+	defer ctx.Close()
+
+	state = roothashState.NewMutableState(ctx.State())
+
+	// Multiple honest nodes may detect the same misbehaviour, so evidence that has already been
+	// processed (either in an earlier transaction or earlier in this batch) is skipped instead
+	// of failing the whole batch. Any other error aborts the transaction.
+	var processed int
+	for _, evidence := range batch.Evidence {
+		err = app.processEvidence(ctx, state, params, evidence)
+		switch {
+		case err == nil:
+			processed++
+		case errors.Is(err, roothash.ErrDuplicateEvidence):
+			ctx.Logger().Debug("EvidenceBatch: skipping duplicate evidence",
+				"runtime_id", evidence.ID,
+			)
+		default:
+			return err
+		}
+	}
+	if processed == 0 {
+		return roothash.ErrDuplicateEvidence
+	}
+
+	ctx.Commit()
+
+	return nil
+}
+
+// processEvidence verifies the given basically-valid evidence against the current state and
+// slashes the misbehaving node.
+func (app *rootHashApplication) processEvidence(
+	ctx *abciAPI.Context,
+	state *roothashState.MutableState,
+	params *roothash.ConsensusParameters,
+	evidence *roothash.Evidence,
+) error {
 	rtState, err := app.getRuntimeState(ctx, state, evidence.ID)
 	if err != nil {
 		return err
@@ -349,6 +465,19 @@ func (app *rootHashApplication) submitMsg(
 		return err
 	}
 
+	// Charge the consensus-level incoming message fee on top of the submitted fee. Proceeds are
+	// credited to the runtime account separately, so they are not available to the runtime for
+	// paying for the execution of the message.
+	inMsgFee, err := params.InMessageFee(len(msg.Data))
+	if err != nil {
+		return err
+	}
+	if !inMsgFee.IsZero() {
+		if err = st.Transfer(ctx, ctx.CallerAddress(), rtAddress, inMsgFee); err != nil {
+			return err
+		}
+	}
+
 	// Fetch current incoming queue metadata.
 	meta, err := state.IncomingMessageQueueMeta(ctx, rtState.Runtime.ID)
 	if err != nil {
@@ -380,7 +509,125 @@ func (app *rootHashApplication) submitMsg(
 		return err
 	}
 
+	// Emit queued events with the 25.0 release.
+	enabled, err := features.IsFeatureVersion(ctx, migrations.Version250)
+	if err != nil {
+		return err
+	}
+	if enabled {
+		ctx.EmitEvent(
+			abciAPI.NewEventBuilder(app.Name()).
+				TypedAttribute(&roothash.InMsgQueuedEvent{
+					ID:     inMsg.ID,
+					Caller: inMsg.Caller,
+					Tag:    inMsg.Tag,
+					Fee:    inMsg.Fee,
+					Tokens: inMsg.Tokens,
+				}).
+				TypedAttribute(&roothash.RuntimeIDAttribute{ID: rtState.Runtime.ID}),
+		)
+	}
+
 	ctx.Commit()
 
 	return nil
 }
+
+func (app *rootHashApplication) publishCheckpoint(
+	ctx *abciAPI.Context,
+	state *roothashState.MutableState,
+	pc *roothash.PublishCheckpoint,
+) error {
+	// Publishing checkpoint manifests is only supported with the 25.0 release.
+	enabled, err := features.IsFeatureVersion(ctx, migrations.Version250)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return roothash.ErrInvalidArgument
+	}
+
+	if ctx.IsCheckOnly() {
+		return nil
+	}
+
+	// Charge gas for this transaction.
+	params, err := state.ConsensusParameters(ctx)
+	if err != nil {
+		ctx.Logger().Error("failed to fetch consensus parameters",
+			"err", err,
+		)
+		return err
+	}
+	if err = ctx.Gas().UseGas(1, roothash.GasOpPublishCheckpoint, params.GasCosts); err != nil {
+		return err
+	}
+
+	// Return early for simulation as we only need gas accounting.
+	if ctx.IsSimulation() {
+		return nil
+	}
+
+	if params.MaxCheckpointManifests == 0 {
+		return roothash.ErrCheckpointManifestsDisabled
+	}
+
+	runtimeID := pc.Root.Namespace
+	if _, err = state.RuntimeState(ctx, runtimeID); err != nil {
+		return err
+	}
+
+	// The published root must match one of the stored past runtime roots.
+	roots, err := state.RoundRoots(ctx, runtimeID, pc.Root.Version)
+	if err != nil {
+		return err
+	}
+	if roots == nil {
+		return fmt.Errorf("%w: unknown round %d", roothash.ErrInvalidCheckpointManifest, pc.Root.Version)
+	}
+	var expectedRoot hash.Hash
+	switch pc.Root.Type {
+	case storage.RootTypeState:
+		expectedRoot = roots.StateRoot
+	case storage.RootTypeIO:
+		expectedRoot = roots.IORoot
+	default:
+		return fmt.Errorf("%w: unsupported root type %s", roothash.ErrInvalidCheckpointManifest, pc.Root.Type)
+	}
+	if !pc.Root.Hash.Equal(&expectedRoot) {
+		return fmt.Errorf("%w: root does not match round %d", roothash.ErrInvalidCheckpointManifest, pc.Root.Version)
+	}
+
+	// Only nodes registered for the runtime can publish checkpoint manifests.
+	nodeID := ctx.TxSigner()
+	regState := registryState.NewMutableState(ctx.State())
+	n, err := regState.Node(ctx, nodeID)
+	if err != nil {
+		return err
+	}
+	if !n.HasRuntime(runtimeID) {
+		return roothash.ErrInvalidRuntime
+	}
+
+	manifest, err := state.CheckpointManifest(ctx, runtimeID, pc.Root.Version, pc.Hash)
+	if err != nil {
+		return err
+	}
+	switch manifest {
+	case nil:
+		manifest = &roothash.CheckpointManifest{PublishCheckpoint: *pc}
+	default:
+		if manifest.HasPublisher(nodeID) {
+			// Already published by this node.
+			return nil
+		}
+	}
+	manifest.Publishers = append(manifest.Publishers, nodeID)
+
+	if err = state.SetCheckpointManifest(ctx, manifest); err != nil {
+		return err
+	}
+
+	// Prune stale manifests.
+	return state.PruneCheckpointManifests(ctx, runtimeID, params.MaxCheckpointManifests)
+}
@@ -2,6 +2,7 @@ package roothash
 
 import (
 	"crypto/rand"
+	"fmt"
 	"math"
 	"testing"
 
@@ -14,13 +15,16 @@ import (
 	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	eventsAPI "github.com/oasisprotocol/oasis-core/go/consensus/api/events"
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	consensusState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/abci/state"
 	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
 	registryState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/registry/state"
 	roothashApi "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/roothash/api"
 	roothashState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/roothash/state"
 	schedulerState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/scheduler/state"
 	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/staking/state"
+	consensusGenesis "github.com/oasisprotocol/oasis-core/go/consensus/genesis"
 	genesisTestHelpers "github.com/oasisprotocol/oasis-core/go/genesis/tests"
 	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
 	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
@@ -30,6 +34,8 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/message"
 	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+	storage "github.com/oasisprotocol/oasis-core/go/storage/api"
+	"github.com/oasisprotocol/oasis-core/go/upgrade/migrations"
 )
 
 type testMsgDispatcher struct{}
@@ -232,6 +238,114 @@ func TestMessagesGasEstimation(t *testing.T) {
 	require.EqualValues(15000, ctx.Gas().GasUsed(), "gas amount should be correct")
 }
 
+func TestExecutorCommitInMessagesResults(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	genesisTestHelpers.SetTestChainContext()
+
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx.Close()
+
+	ctx.SetGasAccountant(abciAPI.NewGasAccountant(transaction.Gas(math.MaxUint64)))
+
+	var md testMsgDispatcher
+	app := rootHashApplication{appState, &md, nil}
+
+	sk, err := memorySigner.NewSigner(rand.Reader)
+	require.NoError(err, "NewSigner")
+
+	runtime := registry.Runtime{
+		Executor: registry.ExecutorParameters{
+			MaxMessages: 32,
+		},
+	}
+
+	schedulerState := schedulerState.NewMutableState(ctx.State())
+	executorCommittee := scheduler.Committee{
+		RuntimeID: runtime.ID,
+		Kind:      scheduler.KindComputeExecutor,
+		Members: []*scheduler.CommitteeNode{
+			{
+				Role:      scheduler.RoleWorker,
+				PublicKey: sk.Public(),
+			},
+		},
+	}
+	err = schedulerState.PutCommittee(ctx, &executorCommittee)
+	require.NoError(err, "PutCommittee")
+
+	roothashState := roothashState.NewMutableState(ctx.State())
+	err = roothashState.SetConsensusParameters(ctx, &roothash.ConsensusParameters{
+		MaxRuntimeMessages: 32,
+	})
+	require.NoError(err, "SetConsensusParameters")
+	blk := block.NewGenesisBlock(runtime.ID, 0)
+	err = roothashState.SetRuntimeState(ctx, &roothash.RuntimeState{
+		Runtime:          &runtime,
+		GenesisBlock:     blk,
+		LastBlock:        blk,
+		LastBlockHeight:  1,
+		LastNormalRound:  0,
+		LastNormalHeight: 1,
+		Committee:        &executorCommittee,
+		CommitmentPool:   commitment.NewPool(),
+	})
+	require.NoError(err, "SetRuntimeState")
+
+	// Generate an executor commitment that reports (empty) incoming message results.
+	newBlk := block.NewEmptyBlock(blk, 1, block.Normal)
+
+	var emptyHash hash.Hash
+	emptyHash.Empty()
+	resultsHash := message.InMessagesResultsHash(nil)
+
+	ec := commitment.ExecutorCommitment{
+		NodeID: sk.Public(),
+		Header: commitment.ExecutorCommitmentHeader{
+			SchedulerID: sk.Public(),
+			Header: commitment.ComputeResultsHeader{
+				Round:                 newBlk.Header.Round,
+				PreviousHash:          newBlk.Header.PreviousHash,
+				IORoot:                &newBlk.Header.IORoot,
+				StateRoot:             &newBlk.Header.StateRoot,
+				MessagesHash:          &emptyHash,
+				InMessagesHash:        &emptyHash,
+				InMessagesResultsHash: &resultsHash,
+			},
+		},
+	}
+	err = ec.Sign(sk, runtime.ID)
+	require.NoError(err, "ec.Sign")
+
+	cc := &roothash.ExecutorCommit{
+		ID:      runtime.ID,
+		Commits: []commitment.ExecutorCommitment{ec},
+	}
+
+	// Commitments with incoming message results should be rejected before the upgrade.
+	consState := consensusState.NewMutableState(ctx.State())
+	err = consState.SetConsensusParameters(ctx, &consensusGenesis.Parameters{})
+	require.NoError(err, "consensus.SetConsensusParameters")
+
+	err = app.executorCommit(ctx, roothashState, cc)
+	require.ErrorIs(err, roothash.ErrInvalidArgument, "ExecutorCommit should fail before the upgrade")
+
+	rtState, err := roothashState.RuntimeState(ctx, runtime.ID)
+	require.NoError(err, "RuntimeState")
+	require.Empty(rtState.CommitmentPool.SchedulerCommitments, "commitment should not be added to the pool")
+
+	// Commitments with incoming message results should be accepted after the upgrade.
+	err = consState.SetConsensusParameters(ctx, &consensusGenesis.Parameters{
+		FeatureVersion: &migrations.Version250,
+	})
+	require.NoError(err, "consensus.SetConsensusParameters")
+
+	err = app.executorCommit(ctx, roothashState, cc)
+	require.NoError(err, "ExecutorCommit should succeed after the upgrade")
+}
+
 func TestEvidence(t *testing.T) {
 	require := require.New(t)
 	var err error
@@ -251,11 +365,18 @@ func TestEvidence(t *testing.T) {
 	require.NoError(err, "NewSigner")
 	entitySigner := memorySigner.NewTestSigner("consensus/cometbft/apps/roothash: entity signer")
 
+	// Initialize consensus state.
+	consState := consensusState.NewMutableState(ctx.State())
+	err = consState.SetConsensusParameters(ctx, &consensusGenesis.Parameters{
+		FeatureVersion: &migrations.Version250,
+	})
+	require.NoError(err, "consensus.SetConsensusParameters")
+
 	// Initialize staking state.
 	stakingState := stakingState.NewMutableState(ctx.State())
 	err = stakingState.SetConsensusParameters(ctx, &staking.ConsensusParameters{})
 	require.NoError(err, "staking.SetConsensusParameters")
-	entityEscrow := quantity.NewFromUint64(100)
+	entityEscrow := quantity.NewFromUint64(200)
 	entityAccount := staking.Account{
 		General: staking.GeneralAccount{
 			Balance: quantity.Quantity{},
@@ -617,14 +738,110 @@ func TestEvidence(t *testing.T) {
 		require.ErrorIs(err, ev.err, ev.msg)
 	}
 
+	// Evidence for an earlier round that has not been submitted yet.
+	signedBatch3 := signedBatch1
+	signedBatch3.Header.Round = blk.Header.Round - 1
+	err = signedBatch3.Sign(sk, runtime.ID)
+	require.NoError(err, "ProposalHeader.Sign")
+	signedBatch4 := signedBatch2
+	signedBatch4.Header.Round = blk.Header.Round - 1
+	err = signedBatch4.Sign(sk, runtime.ID)
+	require.NoError(err, "ProposalHeader.Sign")
+
+	submittedEv := roothash.NewEquivocationProposalEvidence(runtime.ID, signedBatch1, signedBatch2)
+	newEv := roothash.NewEquivocationProposalEvidence(runtime.ID, signedBatch3, signedBatch4)
+	expiredEv := roothash.NewEquivocationProposalEvidence(runtime.ID, expiredB1, expiredB2)
+
+	for _, eb := range []struct {
+		eb  *roothash.EvidenceBatch
+		err error
+		msg string
+	}{
+		{
+			&roothash.EvidenceBatch{},
+			roothash.ErrInvalidEvidence,
+			"empty evidence batch",
+		},
+		{
+			&roothash.EvidenceBatch{Evidence: []*roothash.Evidence{newEv, {}}},
+			roothash.ErrInvalidEvidence,
+			"evidence batch with invalid evidence",
+		},
+		{
+			&roothash.EvidenceBatch{Evidence: []*roothash.Evidence{expiredEv, newEv}},
+			roothash.ErrInvalidEvidence,
+			"evidence batch with expired evidence",
+		},
+		{
+			&roothash.EvidenceBatch{Evidence: []*roothash.Evidence{submittedEv, submittedEv}},
+			roothash.ErrDuplicateEvidence,
+			"evidence batch with only duplicate evidence",
+		},
+		{
+			&roothash.EvidenceBatch{Evidence: []*roothash.Evidence{submittedEv, newEv, newEv}},
+			nil,
+			"valid evidence batch with duplicate evidence",
+		},
+	} {
+		err = app.submitEvidenceBatch(ctx, roothashState, eb.eb)
+		require.ErrorIs(err, eb.err, eb.msg)
+	}
+
 	// Check that expected amount was slashed.
-	// Entity should be slashed two times.
+	// Entity should be slashed three times.
+	require.NoError(entityEscrow.Sub(slashAmount))
 	require.NoError(entityEscrow.Sub(slashAmount))
 	require.NoError(entityEscrow.Sub(slashAmount))
 
 	entAcc, err := stakingState.Account(ctx, staking.NewAddress(nod.EntityID))
 	require.NoError(err, "Account()")
 	require.EqualValues(entityEscrow, &entAcc.Escrow.Active.Balance, "entity was slashed expected amount")
+
+	// A batch where a later piece of evidence fails should not apply any of the evidence.
+	signedBatch5 := signedBatch1
+	signedBatch5.Header.Round = blk.Header.Round - 2
+	err = signedBatch5.Sign(sk, runtime.ID)
+	require.NoError(err, "ProposalHeader.Sign")
+	signedBatch6 := signedBatch2
+	signedBatch6.Header.Round = blk.Header.Round - 2
+	err = signedBatch6.Sign(sk, runtime.ID)
+	require.NoError(err, "ProposalHeader.Sign")
+	nonExistingSignerBatch3 := nonExistingSignerBatch1
+	nonExistingSignerBatch3.Header.Round = blk.Header.Round - 2
+	err = nonExistingSignerBatch3.Sign(nonExistingSigner, runtime.ID)
+	require.NoError(err, "ProposalHeader.Sign")
+	nonExistingSignerBatch4 := nonExistingSignerBatch2
+	nonExistingSignerBatch4.Header.Round = blk.Header.Round - 2
+	err = nonExistingSignerBatch4.Sign(nonExistingSigner, runtime.ID)
+	require.NoError(err, "ProposalHeader.Sign")
+
+	laterEv := roothash.NewEquivocationProposalEvidence(runtime.ID, signedBatch5, signedBatch6)
+	failingEv := roothash.NewEquivocationProposalEvidence(runtime.ID, nonExistingSignerBatch3, nonExistingSignerBatch4)
+
+	err = app.submitEvidenceBatch(ctx, roothashState, &roothash.EvidenceBatch{Evidence: []*roothash.Evidence{laterEv, failingEv}})
+	require.Error(err, "evidence batch with failing evidence")
+	require.NotErrorIs(err, roothash.ErrDuplicateEvidence, "evidence batch with failing evidence")
+
+	entAcc, err = stakingState.Account(ctx, staking.NewAddress(nod.EntityID))
+	require.NoError(err, "Account()")
+	require.EqualValues(entityEscrow, &entAcc.Escrow.Active.Balance, "failed batch should not slash")
+
+	// The evidence from the failed batch should not be recorded as submitted.
+	err = app.submitEvidenceBatch(ctx, roothashState, &roothash.EvidenceBatch{Evidence: []*roothash.Evidence{laterEv}})
+	require.NoError(err, "evidence from failed batch should be accepted again")
+
+	require.NoError(entityEscrow.Sub(slashAmount))
+	entAcc, err = stakingState.Account(ctx, staking.NewAddress(nod.EntityID))
+	require.NoError(err, "Account()")
+	require.EqualValues(entityEscrow, &entAcc.Escrow.Active.Balance, "entity was slashed expected amount")
+
+	// Evidence batches should be rejected before the 25.0 upgrade.
+	endCtx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer endCtx.Close()
+	err = consensusState.NewMutableState(endCtx.State()).SetConsensusParameters(endCtx, &consensusGenesis.Parameters{})
+	require.NoError(err, "consensus.SetConsensusParameters")
+	err = app.submitEvidenceBatch(ctx, roothashState, &roothash.EvidenceBatch{Evidence: []*roothash.Evidence{newEv}})
+	require.ErrorIs(err, roothash.ErrInvalidArgument, "evidence batches should not be enabled")
 }
 
 func TestSubmitMsg(t *testing.T) {
@@ -657,6 +874,13 @@ func TestSubmitMsg(t *testing.T) {
 		},
 	}
 
+	// Initialize consensus state.
+	consState := consensusState.NewMutableState(ctx.State())
+	err = consState.SetConsensusParameters(ctx, &consensusGenesis.Parameters{
+		FeatureVersion: &migrations.Version250,
+	})
+	require.NoError(err, "SetConsensusParameters")
+
 	// Initialize staking state.
 	stakingState := stakingState.NewMutableState(ctx.State())
 	err = stakingState.SetConsensusParameters(ctx, &staking.ConsensusParameters{})
@@ -771,6 +995,24 @@ func TestSubmitMsg(t *testing.T) {
 	require.NoError(err, "Account")
 	require.EqualValues(quantity.NewFromUint64(150), &rtAcc.General.Balance, "tokens must have been transferred to runtime")
 
+	// Make sure the queued event has been emitted.
+	var queuedEv *roothash.InMsgQueuedEvent
+	for _, ev := range ctx.GetEvents() {
+		for _, pair := range ev.GetAttributes() {
+			if !eventsAPI.IsAttributeKind(pair.GetKey(), &roothash.InMsgQueuedEvent{}) {
+				continue
+			}
+			queuedEv = new(roothash.InMsgQueuedEvent)
+			err = eventsAPI.DecodeValue(pair.GetValue(), queuedEv)
+			require.NoError(err, "DecodeValue")
+		}
+	}
+	require.NotNil(queuedEv, "InMsgQueued event should be emitted")
+	require.EqualValues(0, queuedEv.ID)
+	require.EqualValues(callerAddress, queuedEv.Caller)
+	require.EqualValues(msg.Fee, queuedEv.Fee)
+	require.EqualValues(msg.Tokens, queuedEv.Tokens)
+
 	// Attempt to queue a message (after queue is full).
 	err = app.submitMsg(ctx, roothashState, &roothash.SubmitMsg{
 		ID:     runtime.ID,
@@ -797,10 +1039,190 @@ func TestSubmitMsg(t *testing.T) {
 	require.EqualValues(msg.Tokens, msgs[0].Tokens)
 	require.EqualValues(msg.Data, msgs[0].Data)
 
+	// Messages before the offset should be skipped.
+	msgs, err = roothashState.IncomingMessageQueue(ctx, runtime.ID, 1, 0)
+	require.NoError(err, "IncomingMessageQueue")
+	require.Empty(msgs, "no messages should be returned past the last queued message")
+
 	// Pop message from queue.
 	err = roothashState.RemoveIncomingMessageFromQueue(ctx, runtime.ID, 0)
 	require.NoError(err, "RemoveIncomingMessageFromQueue")
 	msgs, err = roothashState.IncomingMessageQueue(ctx, runtime.ID, 0, 0)
 	require.NoError(err, "IncomingMessageQueue")
 	require.Empty(msgs, "queue should be empty")
+
+	// Configure consensus-level incoming message fees.
+	meta.Size = 0
+	err = roothashState.SetIncomingMessageQueueMeta(ctx, runtime.ID, meta)
+	require.NoError(err, "SetIncomingMessageQueueMeta")
+	err = roothashState.SetConsensusParameters(ctx, &roothash.ConsensusParameters{
+		MaxRuntimeMessages:     32,
+		InMessageFeePerMessage: *quantity.NewFromUint64(10),
+		InMessageFeePerByte:    *quantity.NewFromUint64(2),
+	})
+	require.NoError(err, "SetConsensusParameters")
+
+	// Attempt to queue a message (fees are charged on top of the submitted fee).
+	msg = roothash.SubmitMsg{
+		ID:   runtime.ID,
+		Fee:  *quantity.NewFromUint64(100),
+		Data: []byte("hello"),
+	}
+	err = app.submitMsg(ctx, roothashState, &msg)
+	require.NoError(err, "SubmitMsg should succeed")
+
+	// Make sure the runtime received the submitted fee and the incoming message fee.
+	rtAcc, err = stakingState.Account(ctx, staking.NewRuntimeAddress(runtime.ID))
+	require.NoError(err, "Account")
+	require.EqualValues(quantity.NewFromUint64(270), &rtAcc.General.Balance, "fees must have been transferred to runtime")
+	callerAcc, err := stakingState.Account(ctx, callerAddress)
+	require.NoError(err, "Account")
+	require.EqualValues(quantity.NewFromUint64(30), &callerAcc.General.Balance, "fees must have been charged to caller")
+
+	msgs, err = roothashState.IncomingMessageQueue(ctx, runtime.ID, 1, 0)
+	require.NoError(err, "IncomingMessageQueue")
+	require.Len(msgs, 1, "one incoming message should be queued")
+	require.EqualValues(msg.Fee, msgs[0].Fee, "queued fee should not include the incoming message fee")
+
+	// Attempt to queue a message without enough balance to pay the incoming message fee.
+	err = stakingState.SetAccount(ctx, callerAddress, &staking.Account{
+		General: staking.GeneralAccount{
+			Balance: *quantity.NewFromUint64(100),
+		},
+	})
+	require.NoError(err, "SetAccount")
+	err = app.submitMsg(ctx, roothashState, &roothash.SubmitMsg{
+		ID:   runtime.ID,
+		Fee:  *quantity.NewFromUint64(100),
+		Data: []byte("hello"),
+	})
+	require.Error(err, "SubmitMsg should fail when there is not enough balance to pay incoming message fees")
+	require.ErrorIs(err, staking.ErrInsufficientBalance)
+}
+
+func TestPublishCheckpoint(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	genesisTestHelpers.SetTestChainContext()
+
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx.Close()
+
+	var md testMsgDispatcher
+	app := rootHashApplication{appState, &md, nil}
+
+	// Initialize consensus state.
+	consState := consensusState.NewMutableState(ctx.State())
+	err = consState.SetConsensusParameters(ctx, &consensusGenesis.Parameters{
+		FeatureVersion: &migrations.Version250,
+	})
+	require.NoError(err, "SetConsensusParameters")
+
+	// Initialize roothash state.
+	roothashState := roothashState.NewMutableState(ctx.State())
+	err = roothashState.SetConsensusParameters(ctx, &roothash.ConsensusParameters{
+		MaxPastRootsStored: 10,
+	})
+	require.NoError(err, "SetConsensusParameters")
+
+	runtime := registry.Runtime{
+		ID: common.NewTestNamespaceFromSeed([]byte("cometbft/apps/roothash/transaction_test: checkpoint runtime"), 0),
+	}
+	var roots []storage.Root
+	blk := block.NewGenesisBlock(runtime.ID, 0)
+	for round := uint64(1); round <= 3; round++ {
+		blk = block.NewEmptyBlock(blk, 0, block.Normal)
+		blk.Header.StateRoot.FromBytes([]byte(fmt.Sprintf("state root %d", round)))
+		roots = append(roots, blk.Header.StorageRootState())
+
+		err = roothashState.SetRuntimeState(ctx, &roothash.RuntimeState{
+			Runtime:   &runtime,
+			LastBlock: blk,
+		})
+		require.NoError(err, "SetRuntimeState")
+	}
+
+	// Initialize registry state.
+	nodeSigner := memorySigner.NewTestSigner("cometbft/apps/roothash/transaction_test: checkpoint node")
+	otherSigner := memorySigner.NewTestSigner("cometbft/apps/roothash/transaction_test: other node")
+	registryState := registryState.NewMutableState(ctx.State())
+	for _, signer := range []signature.Signer{nodeSigner, otherSigner} {
+		nod := &node.Node{
+			Versioned: cbor.NewVersioned(node.LatestNodeDescriptorVersion),
+			ID:        signer.Public(),
+			Consensus: node.ConsensusInfo{ID: signer.Public()},
+		}
+		if signer == nodeSigner {
+			nod.Runtimes = []*node.Runtime{{ID: runtime.ID}}
+		}
+		sigNode, nErr := node.MultiSignNode([]signature.Signer{signer}, registry.RegisterNodeSignatureContext, nod)
+		require.NoError(nErr, "MultiSignNode")
+		err = registryState.SetNode(ctx, nil, nod, sigNode)
+		require.NoError(err, "SetNode")
+	}
+
+	txCtx := appState.NewContext(abciAPI.ContextDeliverTx)
+	defer txCtx.Close()
+	txCtx.SetTxSigner(nodeSigner.Public())
+
+	publish := func(root storage.Root) *roothash.PublishCheckpoint {
+		return &roothash.PublishCheckpoint{
+			Version:   1,
+			Root:      root,
+			NumChunks: 2,
+			Hash:      hash.NewFromBytes([]byte(root.Hash.String())),
+		}
+	}
+
+	// Publishing should fail when disabled.
+	err = app.publishCheckpoint(txCtx, roothashState, publish(roots[0]))
+	require.ErrorIs(err, roothash.ErrCheckpointManifestsDisabled)
+
+	err = roothashState.SetConsensusParameters(ctx, &roothash.ConsensusParameters{
+		MaxPastRootsStored:     10,
+		MaxCheckpointManifests: 2,
+	})
+	require.NoError(err, "SetConsensusParameters")
+
+	// Publishing an unknown root should fail.
+	badRoot := roots[0]
+	badRoot.Hash.FromBytes([]byte("bad root"))
+	err = app.publishCheckpoint(txCtx, roothashState, publish(badRoot))
+	require.ErrorIs(err, roothash.ErrInvalidCheckpointManifest)
+
+	unknownRound := roots[0]
+	unknownRound.Version = 42
+	err = app.publishCheckpoint(txCtx, roothashState, publish(unknownRound))
+	require.ErrorIs(err, roothash.ErrInvalidCheckpointManifest)
+
+	// Publishing by a node not registered for the runtime should fail.
+	txCtx.SetTxSigner(otherSigner.Public())
+	err = app.publishCheckpoint(txCtx, roothashState, publish(roots[0]))
+	require.ErrorIs(err, roothash.ErrInvalidRuntime)
+	txCtx.SetTxSigner(nodeSigner.Public())
+
+	// Publishing a valid manifest should succeed.
+	err = app.publishCheckpoint(txCtx, roothashState, publish(roots[0]))
+	require.NoError(err, "publishCheckpoint")
+	err = app.publishCheckpoint(txCtx, roothashState, publish(roots[0]))
+	require.NoError(err, "publishCheckpoint should be idempotent")
+
+	manifests, err := roothashState.CheckpointManifests(ctx, runtime.ID)
+	require.NoError(err, "CheckpointManifests")
+	require.Len(manifests, 1)
+	require.EqualValues(*publish(roots[0]), manifests[0].PublishCheckpoint)
+	require.Equal([]signature.PublicKey{nodeSigner.Public()}, manifests[0].Publishers)
+
+	// Stale manifests should be pruned.
+	for _, root := range roots[1:] {
+		err = app.publishCheckpoint(txCtx, roothashState, publish(root))
+		require.NoError(err, "publishCheckpoint")
+	}
+	manifests, err = roothashState.CheckpointManifests(ctx, runtime.ID)
+	require.NoError(err, "CheckpointManifests")
+	require.Len(manifests, 2)
+	require.EqualValues(2, manifests[0].Root.Version)
+	require.EqualValues(3, manifests[1].Root.Version)
 }
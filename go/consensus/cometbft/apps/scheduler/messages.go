@@ -6,8 +6,10 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
 	schedulerState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/scheduler/state"
+	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/features"
 	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
 	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
+	"github.com/oasisprotocol/oasis-core/go/upgrade/migrations"
 )
 
 func (app *schedulerApplication) changeParameters(ctx *api.Context, msg interface{}, apply bool) (interface{}, error) {
@@ -35,6 +37,16 @@ func (app *schedulerApplication) changeParameters(ctx *api.Context, msg interfac
 	if err = changes.SanityCheck(); err != nil {
 		return nil, fmt.Errorf("cometbft/scheduler: failed to validate consensus parameter changes: %w", err)
 	}
+	if len(changes.EligibilityLists) > 0 {
+		// Allow eligibility lists with the 25.0 release.
+		var enabled bool
+		if enabled, err = features.IsFeatureVersion(ctx, migrations.Version250); err != nil {
+			return nil, err
+		}
+		if !enabled {
+			return nil, fmt.Errorf("cometbft/scheduler: eligibility lists not enabled")
+		}
+	}
 	if err = changes.Apply(params); err != nil {
 		return nil, fmt.Errorf("cometbft/scheduler: failed to apply consensus parameter changes: %w", err)
 	}
@@ -5,11 +5,16 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	consensusState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/abci/state"
 	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
 	schedulerState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/scheduler/state"
+	consensusGenesis "github.com/oasisprotocol/oasis-core/go/consensus/genesis"
 	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
 	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
+	"github.com/oasisprotocol/oasis-core/go/upgrade/migrations"
 )
 
 func TestChangeParameters(t *testing.T) {
@@ -87,4 +92,35 @@ func TestChangeParameters(t *testing.T) {
 		_, err := app.changeParameters(ctx, &proposal, true)
 		require.EqualError(err, "cometbft/scheduler: failed to validate consensus parameter changes: consensus parameter changes should not be empty")
 	})
+	t.Run("eligibility lists", func(t *testing.T) {
+		require := require.New(t)
+
+		var rtID common.Namespace
+		changes := scheduler.ConsensusParameterChanges{
+			EligibilityLists: map[common.Namespace]*scheduler.EligibilityList{
+				rtID: {Denied: []signature.PublicKey{signature.NewPublicKey("0000000000000000000000000000000000000000000000000000000000000001")}},
+			},
+		}
+		proposal := governance.ChangeParametersProposal{
+			Module:  scheduler.ModuleName,
+			Changes: cbor.Marshal(changes),
+		}
+
+		consState := consensusState.NewMutableState(ctx.State())
+		err := consState.SetConsensusParameters(ctx, &consensusGenesis.Parameters{})
+		require.NoError(err, "SetConsensusParameters")
+		_, err = app.changeParameters(ctx, &proposal, true)
+		require.EqualError(err, "cometbft/scheduler: eligibility lists not enabled")
+
+		err = consState.SetConsensusParameters(ctx, &consensusGenesis.Parameters{
+			FeatureVersion: &migrations.Version250,
+		})
+		require.NoError(err, "SetConsensusParameters")
+		_, err = app.changeParameters(ctx, &proposal, true)
+		require.NoError(err, "changing eligibility lists should succeed")
+
+		state, err := state.ConsensusParameters(ctx)
+		require.NoError(err, "fetching consensus parameters should succeed")
+		require.Equal(changes.EligibilityLists, state.EligibilityLists, "eligibility lists should change")
+	})
 }
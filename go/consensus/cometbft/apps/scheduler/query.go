@@ -3,15 +3,18 @@ package scheduler
 import (
 	"context"
 
+	"github.com/oasisprotocol/oasis-core/go/common"
 	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
 	registryState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/registry/state"
 	schedulerState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/scheduler/state"
 	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/syncer"
 )
 
 // Query is the scheduler query interface.
 type Query interface {
 	Validators(context.Context) ([]*scheduler.Validator, error)
+	Committee(context.Context, scheduler.CommitteeKind, common.Namespace) (*scheduler.Committee, error)
 	AllCommittees(context.Context) ([]*scheduler.Committee, error)
 	KindsCommittees(context.Context, []scheduler.CommitteeKind) ([]*scheduler.Committee, error)
 	Genesis(context.Context) (*scheduler.Genesis, error)
@@ -39,6 +42,32 @@ func (sf *QueryFactory) QueryAt(ctx context.Context, height int64) (Query, error
 	return &schedulerQuerier{state, regState}, nil
 }
 
+// CommitteeProofAt returns a proof of the committee of the given kind for the given runtime
+// against the consensus state root at a specific height.
+func (sf *QueryFactory) CommitteeProofAt(ctx context.Context, height int64, kind scheduler.CommitteeKind, runtimeID common.Namespace) (*scheduler.CommitteeMembershipProof, error) {
+	root, err := abciAPI.StateRootAt(sf.state, height)
+	if err != nil {
+		return nil, err
+	}
+
+	rsp, err := sf.state.Storage().SyncGet(ctx, &syncer.GetRequest{
+		Tree: syncer.TreeID{
+			Root:     *root,
+			Position: root.Hash,
+		},
+		Key:          scheduler.CommitteeStateKey(kind, runtimeID),
+		ProofVersion: syncer.LatestProofVersion,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &scheduler.CommitteeMembershipProof{
+		Height: int64(root.Version),
+		Proof:  rsp.Proof,
+	}, nil
+}
+
 type schedulerQuerier struct {
 	state    *schedulerState.ImmutableState
 	regState *registryState.ImmutableState
@@ -58,6 +87,10 @@ func (sq *schedulerQuerier) Validators(ctx context.Context) ([]*scheduler.Valida
 	return ret, nil
 }
 
+func (sq *schedulerQuerier) Committee(ctx context.Context, kind scheduler.CommitteeKind, runtimeID common.Namespace) (*scheduler.Committee, error) {
+	return sq.state.Committee(ctx, kind, runtimeID)
+}
+
 func (sq *schedulerQuerier) AllCommittees(ctx context.Context) ([]*scheduler.Committee, error) {
 	return sq.state.AllCommittees(ctx)
 }
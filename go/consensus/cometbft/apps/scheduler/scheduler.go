@@ -186,6 +186,17 @@ func (app *schedulerApplication) BeginBlock(ctx *api.Context) error {
 			entitiesEligibleForReward = make(map[staking.Address]bool)
 		}
 
+		// Entities that opted out of validator elections keep their nodes
+		// registered, so they must be excluded from the validator election only.
+		optOutIDs, err := regState.ValidatorElectionOptOuts(ctx)
+		if err != nil {
+			return fmt.Errorf("cometbft/scheduler: couldn't get validator election opt-outs: %w", err)
+		}
+		validatorOptOuts := make(map[staking.Address]bool, len(optOutIDs))
+		for _, id := range optOutIDs {
+			validatorOptOuts[staking.NewAddress(id)] = true
+		}
+
 		// Handle the validator election first, because no consensus is
 		// catastrophic, while failing to elect other committees is not.
 		var validatorEntities map[staking.Address]bool
@@ -196,6 +207,7 @@ func (app *schedulerApplication) BeginBlock(ctx *api.Context) error {
 			beaconParameters,
 			stakeAcc,
 			entitiesEligibleForReward,
+			validatorOptOuts,
 			nodes,
 			params,
 		); err != nil {
@@ -450,13 +462,16 @@ func (app *schedulerApplication) electValidators(
 	beaconParameters *beacon.ConsensusParameters,
 	stakeAcc *stakingState.StakeAccumulatorCache,
 	entitiesEligibleForReward map[staking.Address]bool,
+	optOuts map[staking.Address]bool,
 	nodes []*node.Node,
 	params *scheduler.ConsensusParameters,
 ) (map[staking.Address]bool, error) {
 	// Filter the node list based on eligibility and minimum required
 	// entity stake.
-	var nodeList []*node.Node
-	entities := make(map[staking.Address]bool)
+	var (
+		nodeList, optedOutNodeList []*node.Node
+		entities, optedOutEntities = make(map[staking.Address]bool), make(map[staking.Address]bool)
+	)
 	for _, n := range nodes {
 		if !n.HasRoles(node.RoleValidator) {
 			continue
@@ -467,10 +482,29 @@ func (app *schedulerApplication) electValidators(
 				continue
 			}
 		}
+		if optOuts[entAddr] {
+			optedOutNodeList = append(optedOutNodeList, n)
+			optedOutEntities[entAddr] = true
+			continue
+		}
 		nodeList = append(nodeList, n)
 		entities[entAddr] = true
 	}
 
+	// Ignore opt-outs in case there would otherwise not be enough validators,
+	// as not having consensus is worse than electing entities that abstain.
+	if len(optedOutEntities) > 0 && len(entities) < params.MinValidators {
+		ctx.Logger().Warn("not enough validator entities without opt-outs, ignoring opt-outs",
+			"entities", len(entities),
+			"opted_out_entities", len(optedOutEntities),
+			"min_validators", params.MinValidators,
+		)
+		nodeList = append(nodeList, optedOutNodeList...)
+		for entAddr := range optedOutEntities {
+			entities[entAddr] = true
+		}
+	}
+
 	// Sort all of the entities that are actually running eligible validator
 	// nodes by descending stake.
 	weakEntropy, err := beaconState.Beacon(ctx)
@@ -13,6 +13,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
 	"github.com/oasisprotocol/oasis-core/go/common/version"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/events"
 	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
 	beaconState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/beacon/state"
 	schedulerState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/scheduler/state"
@@ -815,3 +816,125 @@ func TestElectCommittee(t *testing.T) {
 		require.NotNil(c, "Committee should have been elected (%s)", tc.msg)
 	}
 }
+
+func TestElectCommitteeEligibilityList(t *testing.T) {
+	require := require.New(t)
+
+	appState := api.NewMockApplicationState(&api.MockApplicationStateConfig{})
+	ctx := appState.NewContext(api.ContextBeginBlock)
+	defer ctx.Close()
+
+	app := &schedulerApplication{
+		state: appState,
+	}
+
+	beaconState := beaconState.NewMutableState(ctx.State())
+	_ = beaconState.DebugForceSetBeacon(ctx, []byte("mock random beacon mock random beacon mock random beacon!!"))
+	_ = beaconState.SetEpoch(ctx, 1, 69)
+
+	beaconParameters := &beacon.ConsensusParameters{
+		Backend: beacon.BackendInsecure,
+	}
+
+	rtID := common.NewTestNamespaceFromSeed([]byte("runtime 1"), 0)
+
+	nodeID1 := signature.NewPublicKey("0000000000000000000000000000000000000000000000000000000000000001")
+	nodeID2 := signature.NewPublicKey("0000000000000000000000000000000000000000000000000000000000000002")
+	nodeID3 := signature.NewPublicKey("0000000000000000000000000000000000000000000000000000000000000003")
+
+	entityID1 := signature.NewPublicKey("1000000000000000000000000000000000000000000000000000000000000001")
+	entityID2 := signature.NewPublicKey("1000000000000000000000000000000000000000000000000000000000000002")
+
+	var nodes []*nodeWithStatus
+	for _, n := range []struct {
+		id       signature.PublicKey
+		entityID signature.PublicKey
+	}{
+		{nodeID1, entityID1},
+		{nodeID2, entityID2},
+		{nodeID3, entityID2},
+	} {
+		nodes = append(nodes, &nodeWithStatus{
+			node: &node.Node{
+				ID:       n.id,
+				EntityID: n.entityID,
+				Runtimes: []*node.Runtime{{ID: rtID}},
+				Roles:    node.RoleComputeWorker,
+			},
+			status: &registry.NodeStatus{},
+		})
+	}
+
+	rt := registry.Runtime{
+		ID:   rtID,
+		Kind: registry.KindCompute,
+		Executor: registry.ExecutorParameters{
+			GroupSize: 2,
+		},
+		Deployments: []*registry.VersionInfo{
+			{},
+		},
+	}
+
+	elect := func(list *scheduler.EligibilityList) (*scheduler.Committee, []signature.PublicKey) {
+		params := &scheduler.ConsensusParameters{
+			EligibilityLists: map[common.Namespace]*scheduler.EligibilityList{
+				rtID: list,
+			},
+		}
+
+		ctx := ctx.NewTransaction()
+		defer ctx.Close()
+
+		err := app.electCommittee(
+			ctx,
+			params,
+			beaconState,
+			beaconParameters,
+			&registry.ConsensusParameters{},
+			nil,
+			nil,
+			nil,
+			&rt,
+			nodes,
+			scheduler.KindComputeExecutor,
+		)
+		require.NoError(err, "committee election should not fail")
+
+		var excluded []signature.PublicKey
+		for _, ev := range ctx.GetEvents() {
+			for _, attr := range ev.Attributes {
+				if attr.Key != (&scheduler.ExcludedEvent{}).EventKind() {
+					continue
+				}
+				var e scheduler.ExcludedEvent
+				require.NoError(events.DecodeValue(attr.Value, &e), "DecodeValue")
+				require.Equal(rtID, e.RuntimeID)
+				excluded = append(excluded, e.Nodes...)
+			}
+		}
+
+		c, err := schedulerState.NewMutableState(ctx.State()).Committee(ctx, scheduler.KindComputeExecutor, rtID)
+		require.NoError(err, "Committee")
+		return c, excluded
+	}
+
+	// Denying an entity should exclude all of its nodes.
+	c, excluded := elect(&scheduler.EligibilityList{
+		Denied: []signature.PublicKey{entityID2},
+	})
+	require.Nil(c, "committee should not be elected without enough eligible nodes")
+	require.ElementsMatch([]signature.PublicKey{nodeID2, nodeID3}, excluded)
+
+	// Allowed nodes and entities should be elected, unless denied.
+	c, excluded = elect(&scheduler.EligibilityList{
+		Allowed: []signature.PublicKey{nodeID1, entityID2},
+		Denied:  []signature.PublicKey{nodeID3},
+	})
+	require.NotNil(c, "committee should be elected")
+	require.Len(c.Members, 2)
+	for _, m := range c.Members {
+		require.NotEqual(nodeID3, m.PublicKey, "denied node should not be elected")
+	}
+	require.Equal([]signature.PublicKey{nodeID3}, excluded)
+}
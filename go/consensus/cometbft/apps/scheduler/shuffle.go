@@ -233,6 +233,8 @@ func (app *schedulerApplication) electCommittee( //nolint: gocyclo
 	cs := rt.Constraints[kind]
 
 	// Perform pre-election eligiblity filtering.
+	eligibility := schedulerParameters.EligibilityLists[rt.ID]
+	var excluded []signature.PublicKey
 	nodeLists := make(map[scheduler.Role][]*node.Node)
 	for _, n := range nodeList {
 		// Check if an entity has enough stake.
@@ -246,6 +248,11 @@ func (app *schedulerApplication) electCommittee( //nolint: gocyclo
 		if !isSuitableFn(ctx, n, rt, epoch, registryParameters) {
 			continue
 		}
+		// Check the runtime's eligibility list.
+		if eligibility != nil && !eligibility.IsEligible(n.node.ID, n.node.EntityID) {
+			excluded = append(excluded, n.node.ID)
+			continue
+		}
 
 		// If the election uses VRFs, make sure that the node bothered to submit
 		// a VRF proof for this election.
@@ -296,6 +303,18 @@ func (app *schedulerApplication) electCommittee( //nolint: gocyclo
 			entitiesEligibleForReward[entAddr] = true
 		}
 	}
+	if len(excluded) > 0 {
+		ctx.Logger().Debug("eligibility list excluded nodes from election",
+			"kind", kind,
+			"runtime_id", rt.ID,
+			"nodes", excluded,
+		)
+		ctx.EmitEvent(api.NewEventBuilder(AppName).TypedAttribute(&scheduler.ExcludedEvent{
+			RuntimeID: rt.ID,
+			Kind:      kind,
+			Nodes:     excluded,
+		}))
+	}
 
 	// Perform election.
 	var members []*scheduler.CommitteeNode
@@ -18,6 +18,8 @@ var (
 	// committeeKeyFmt is the key format used for committees.
 	//
 	// Value is CBOR-serialized committee.
+	//
+	// NOTE: This must match api.CommitteeStateKey as it is used to verify committee proofs.
 	committeeKeyFmt = consensus.KeyFormat.New(0x60, uint8(0), keyformat.H(&common.Namespace{}))
 	// validatorsCurrentKeyFmt is the key format used for the current set of
 	// validators.
@@ -80,6 +80,36 @@ func (app *stakingApplication) initGovernanceDeposits(ctx *abciAPI.Context, stat
 	return nil
 }
 
+func (app *stakingApplication) initScheduledTransfers(ctx *abciAPI.Context, state *stakingState.MutableState, st *staking.Genesis, totalSupply *quantity.Quantity) error {
+	var (
+		pool   quantity.Quantity
+		nextID uint64
+	)
+	if err := staking.SanityCheckScheduledTransfers(&pool, st.ScheduledTransfers); err != nil {
+		return fmt.Errorf("cometbft/staking: invalid genesis state ScheduledTransfers: %w", err)
+	}
+	for _, xfer := range st.ScheduledTransfers {
+		if err := state.SetScheduledTransfer(ctx, xfer); err != nil {
+			return fmt.Errorf("cometbft/staking: failed to set scheduled transfer %d: %w", xfer.ID, err)
+		}
+		nextID = max(nextID, xfer.ID+1)
+	}
+
+	if err := totalSupply.Add(&pool); err != nil {
+		ctx.Logger().Error("InitChain: failed to add scheduled transfers",
+			"err", err,
+		)
+		return fmt.Errorf("cometbft/staking: failed to add scheduled transfers: %w", err)
+	}
+	if err := state.SetScheduledTransfersBalance(ctx, &pool); err != nil {
+		return fmt.Errorf("cometbft/staking: failed to set scheduled transfers balance: %w", err)
+	}
+	if err := state.SetNextScheduledTransferID(ctx, nextID); err != nil {
+		return fmt.Errorf("cometbft/staking: failed to set next scheduled transfer identifier: %w", err)
+	}
+	return nil
+}
+
 func (app *stakingApplication) initLedger(
 	ctx *abciAPI.Context,
 	state *stakingState.MutableState,
@@ -310,6 +340,10 @@ func (app *stakingApplication) InitChain(ctx *abciAPI.Context, _ types.RequestIn
 		return err
 	}
 
+	if err := app.initScheduledTransfers(ctx, state, st, &totalSupply); err != nil {
+		return err
+	}
+
 	if err := app.initLedger(ctx, state, st, &totalSupply); err != nil {
 		return err
 	}
@@ -382,6 +416,11 @@ func (sq *stakingQuerier) Genesis(ctx context.Context) (*staking.Genesis, error)
 		return nil, err
 	}
 
+	scheduledTransfers, err := sq.state.ScheduledTransfers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	params, err := sq.state.ConsensusParameters(ctx)
 	if err != nil {
 		return nil, err
@@ -396,6 +435,7 @@ func (sq *stakingQuerier) Genesis(ctx context.Context) (*staking.Genesis, error)
 		Ledger:               ledger,
 		Delegations:          delegations,
 		DebondingDelegations: debondingDelegations,
+		ScheduledTransfers:   scheduledTransfers,
 	}
 	return &gen, nil
 }
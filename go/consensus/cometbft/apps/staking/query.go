@@ -2,6 +2,7 @@ package staking
 
 import (
 	"context"
+	"fmt"
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
@@ -27,6 +28,10 @@ type Query interface {
 	DebondingDelegationsFor(context.Context, staking.Address) (map[staking.Address][]*staking.DebondingDelegation, error)
 	DebondingDelegationInfosFor(context.Context, staking.Address) (map[staking.Address][]*staking.DebondingDelegationInfo, error)
 	DebondingDelegationsTo(context.Context, staking.Address) (map[staking.Address][]*staking.DebondingDelegation, error)
+	ScheduledTransfersFrom(context.Context, staking.Address) ([]*staking.ScheduledTransfer, error)
+	ScheduledTransfersTo(context.Context, staking.Address) ([]*staking.ScheduledTransfer, error)
+	SimulateSlash(context.Context, staking.Address, staking.SlashReason) (*staking.SlashSimulation, error)
+	ValidateCommissionSchedule(context.Context, staking.Address, *staking.CommissionSchedule, beacon.EpochTime) (*staking.CommissionSchedule, error)
 	Genesis(context.Context) (*staking.Genesis, error)
 	ConsensusParameters(context.Context) (*staking.ConsensusParameters, error)
 }
@@ -42,11 +47,13 @@ func (sf *QueryFactory) QueryAt(ctx context.Context, height int64) (Query, error
 	if err != nil {
 		return nil, err
 	}
-	return &stakingQuerier{state}, nil
+	return &stakingQuerier{sf.state, state, height}, nil
 }
 
 type stakingQuerier struct {
-	state *stakingState.ImmutableState
+	queryState abciAPI.ApplicationQueryState
+	state      *stakingState.ImmutableState
+	height     int64
 }
 
 func (sq *stakingQuerier) TotalSupply(ctx context.Context) (*quantity.Quantity, error) {
@@ -122,6 +129,16 @@ func (sq *stakingQuerier) Account(ctx context.Context, addr staking.Address) (*s
 				Balance: *gd,
 			},
 		}, nil
+	case addr.Equal(staking.ScheduledTransfersAddress):
+		st, err := sq.state.ScheduledTransfersBalance(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return &staking.Account{
+			General: staking.GeneralAccount{
+				Balance: *st,
+			},
+		}, nil
 
 	default:
 		return sq.state.Account(ctx, addr)
@@ -186,6 +203,58 @@ func (sq *stakingQuerier) DebondingDelegationsTo(ctx context.Context, addr staki
 	return sq.state.DebondingDelegationsTo(ctx, addr)
 }
 
+func (sq *stakingQuerier) ScheduledTransfersFrom(ctx context.Context, addr staking.Address) ([]*staking.ScheduledTransfer, error) {
+	return sq.state.ScheduledTransfersFrom(ctx, addr)
+}
+
+func (sq *stakingQuerier) ScheduledTransfersTo(ctx context.Context, addr staking.Address) ([]*staking.ScheduledTransfer, error) {
+	return sq.state.ScheduledTransfersTo(ctx, addr)
+}
+
+func (sq *stakingQuerier) SimulateSlash(ctx context.Context, addr staking.Address, reason staking.SlashReason) (*staking.SlashSimulation, error) {
+	epoch, err := sq.queryState.GetEpoch(ctx, sq.height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get epoch: %w", err)
+	}
+	params, err := sq.state.ConsensusParameters(ctx)
+	if err != nil {
+		return nil, err
+	}
+	acct, err := sq.state.Account(ctx, addr)
+	if err != nil {
+		return nil, err
+	}
+	delegations, err := sq.state.DelegationsTo(ctx, addr)
+	if err != nil {
+		return nil, err
+	}
+	debDelegations, err := sq.state.DebondingDelegationsTo(ctx, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	return staking.SimulateSlash(params, epoch, addr, reason, acct, delegations, debDelegations)
+}
+
+func (sq *stakingQuerier) ValidateCommissionSchedule(ctx context.Context, addr staking.Address, amendment *staking.CommissionSchedule, epoch beacon.EpochTime) (*staking.CommissionSchedule, error) {
+	if epoch == 0 {
+		var err error
+		if epoch, err = sq.queryState.GetEpoch(ctx, sq.height); err != nil {
+			return nil, fmt.Errorf("failed to get epoch: %w", err)
+		}
+	}
+	params, err := sq.state.ConsensusParameters(ctx)
+	if err != nil {
+		return nil, err
+	}
+	acct, err := sq.state.Account(ctx, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	return staking.ValidateCommissionScheduleAmendment(params, epoch, acct, amendment)
+}
+
 func (sq *stakingQuerier) ConsensusParameters(ctx context.Context) (*staking.ConsensusParameters, error) {
 	return sq.state.ConsensusParameters(ctx)
 }
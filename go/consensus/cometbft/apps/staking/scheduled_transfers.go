@@ -0,0 +1,233 @@
+package staking
+
+import (
+	"fmt"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/errors"
+	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/staking/state"
+	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/features"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+	"github.com/oasisprotocol/oasis-core/go/upgrade/migrations"
+)
+
+func scheduledTransfersEnabled(ctx *api.Context) error {
+	// Allow scheduled transfers with the 25.0 release.
+	enabled, err := features.IsFeatureVersion(ctx, migrations.Version250)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return fmt.Errorf("%w: scheduled transfers not enabled", staking.ErrForbidden)
+	}
+	return nil
+}
+
+func (app *stakingApplication) transferAt(
+	ctx *api.Context,
+	state *stakingState.MutableState,
+	xfer *staking.TransferAt,
+) (*staking.ScheduledTransfer, error) {
+	if err := scheduledTransfersEnabled(ctx); err != nil {
+		return nil, err
+	}
+	if err := xfer.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	if ctx.IsCheckOnly() {
+		return nil, nil
+	}
+
+	// Charge gas for this transaction.
+	params, err := state.ConsensusParameters(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch consensus parameters: %w", err)
+	}
+	if err = ctx.Gas().UseGas(1, staking.GasOpTransferAt, params.GasCosts); err != nil {
+		return nil, err
+	}
+
+	// Return early for simulation as we only need gas accounting.
+	if ctx.IsSimulation() {
+		return nil, nil
+	}
+
+	fromAddr := ctx.CallerAddress()
+	if fromAddr.IsReserved() || !isTransferPermitted(params, fromAddr) {
+		return nil, staking.ErrForbidden
+	}
+	if xfer.To.IsReserved() || fromAddr.Equal(xfer.To) {
+		return nil, fmt.Errorf("%w: invalid destination address", staking.ErrInvalidArgument)
+	}
+
+	epoch, err := app.state.GetEpoch(ctx, ctx.BlockHeight()+1)
+	if err != nil {
+		return nil, err
+	}
+	if xfer.ReleaseEpoch <= epoch {
+		return nil, fmt.Errorf("%w: release epoch must be in the future", staking.ErrInvalidArgument)
+	}
+
+	// Check if sender provided at least a minimum amount.
+	if xfer.Amount.Cmp(&params.MinTransferAmount) < 0 {
+		return nil, staking.ErrUnderMinTransferAmount
+	}
+	if xfer.Amount.IsZero() {
+		return nil, fmt.Errorf("%w: zero amount", staking.ErrInvalidArgument)
+	}
+
+	from, err := state.Account(ctx, fromAddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch account: %w", err)
+	}
+	remaining := from.General.Balance.Clone()
+	if err = remaining.Sub(&xfer.Amount); err != nil {
+		return nil, staking.ErrInsufficientBalance
+	}
+	// Check against minimum balance.
+	if remaining.Cmp(&params.MinTransactBalance) < 0 {
+		return nil, errors.WithContext(staking.ErrBalanceTooLow, "source account")
+	}
+
+	if err = state.TransferToScheduledTransfers(ctx, fromAddr, &xfer.Amount); err != nil {
+		return nil, err
+	}
+
+	id, err := state.NextScheduledTransferID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch next scheduled transfer identifier: %w", err)
+	}
+	if err = state.SetNextScheduledTransferID(ctx, id+1); err != nil {
+		return nil, fmt.Errorf("failed to set next scheduled transfer identifier: %w", err)
+	}
+
+	st := &staking.ScheduledTransfer{
+		ID:             id,
+		From:           fromAddr,
+		To:             xfer.To,
+		Amount:         xfer.Amount,
+		ReleaseEpoch:   xfer.ReleaseEpoch,
+		CancelDeadline: xfer.CancelDeadline,
+	}
+	if err = state.SetScheduledTransfer(ctx, st); err != nil {
+		return nil, fmt.Errorf("failed to set scheduled transfer: %w", err)
+	}
+
+	ctx.Logger().Debug("TransferAt: scheduled transfer",
+		"id", st.ID,
+		"from", st.From,
+		"to", st.To,
+		"amount", st.Amount,
+		"release_epoch", st.ReleaseEpoch,
+	)
+
+	ctx.EmitEvent(api.NewEventBuilder(app.Name()).TypedAttribute(&staking.ScheduleTransferEvent{
+		ID:             st.ID,
+		From:           st.From,
+		To:             st.To,
+		Amount:         st.Amount,
+		ReleaseEpoch:   st.ReleaseEpoch,
+		CancelDeadline: st.CancelDeadline,
+	}))
+
+	return st, nil
+}
+
+func (app *stakingApplication) cancelScheduledTransfer(
+	ctx *api.Context,
+	state *stakingState.MutableState,
+	cancel *staking.CancelScheduledTransfer,
+) error {
+	if err := scheduledTransfersEnabled(ctx); err != nil {
+		return err
+	}
+
+	if ctx.IsCheckOnly() {
+		return nil
+	}
+
+	// Charge gas for this transaction.
+	params, err := state.ConsensusParameters(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch consensus parameters: %w", err)
+	}
+	if err = ctx.Gas().UseGas(1, staking.GasOpCancelScheduledTransfer, params.GasCosts); err != nil {
+		return err
+	}
+
+	// Return early for simulation as we only need gas accounting.
+	if ctx.IsSimulation() {
+		return nil
+	}
+
+	// Only the sender can cancel its own scheduled transfers.
+	st, err := state.ScheduledTransfer(ctx, ctx.CallerAddress(), cancel.ID)
+	if err != nil {
+		return err
+	}
+
+	epoch, err := app.state.GetEpoch(ctx, ctx.BlockHeight()+1)
+	if err != nil {
+		return err
+	}
+	if !st.IsCancellable(epoch) {
+		return fmt.Errorf("%w: scheduled transfer can no longer be cancelled", staking.ErrForbidden)
+	}
+
+	if err = state.RemoveScheduledTransfer(ctx, st); err != nil {
+		return fmt.Errorf("failed to remove scheduled transfer: %w", err)
+	}
+	if err = state.TransferFromScheduledTransfers(ctx, st.From, &st.Amount); err != nil {
+		return err
+	}
+
+	ctx.Logger().Debug("CancelScheduledTransfer: cancelled scheduled transfer",
+		"id", st.ID,
+		"from", st.From,
+		"amount", st.Amount,
+	)
+
+	ctx.EmitEvent(api.NewEventBuilder(app.Name()).TypedAttribute(&staking.CancelScheduledTransferEvent{
+		ID:     st.ID,
+		From:   st.From,
+		To:     st.To,
+		Amount: st.Amount,
+	}))
+
+	return nil
+}
+
+// releaseScheduledTransfers releases all scheduled transfers that are due at the given epoch.
+func (app *stakingApplication) releaseScheduledTransfers(ctx *api.Context, state *stakingState.MutableState, epoch beacon.EpochTime) error {
+	transfers, err := state.DueScheduledTransfers(ctx, epoch)
+	if err != nil {
+		return fmt.Errorf("failed to query due scheduled transfers: %w", err)
+	}
+
+	for _, st := range transfers {
+		if err = state.RemoveScheduledTransfer(ctx, st); err != nil {
+			return fmt.Errorf("failed to remove scheduled transfer: %w", err)
+		}
+		if err = state.TransferFromScheduledTransfers(ctx, st.To, &st.Amount); err != nil {
+			return fmt.Errorf("failed to release scheduled transfer %d: %w", st.ID, err)
+		}
+
+		ctx.Logger().Debug("released scheduled transfer",
+			"id", st.ID,
+			"from", st.From,
+			"to", st.To,
+			"amount", st.Amount,
+		)
+
+		ctx.EmitEvent(api.NewEventBuilder(app.Name()).TypedAttribute(&staking.ReleaseScheduledTransferEvent{
+			ID:     st.ID,
+			From:   st.From,
+			To:     st.To,
+			Amount: st.Amount,
+		}))
+	}
+
+	return nil
+}
@@ -11,8 +11,10 @@ import (
 	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
 	registryState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/registry/state"
 	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/staking/state"
+	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/features"
 	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+	"github.com/oasisprotocol/oasis-core/go/upgrade/migrations"
 )
 
 func onEvidenceByzantineConsensus(
@@ -74,11 +76,22 @@ func onEvidenceByzantineConsensus(
 			return err
 		}
 
+		// Record the freeze reason with the 25.0 release.
+		var enabled bool
+		enabled, err = features.IsFeatureVersion(ctx, migrations.Version250)
+		if err != nil {
+			return err
+		}
+		reason := registry.FreezeReasonUnspecified
+		if enabled {
+			reason = registry.FreezeReasonSlashed
+		}
+
 		// Check for overflow.
 		if math.MaxUint64-penalty.FreezeInterval < epoch {
-			nodeStatus.FreezeEndTime = registry.FreezeForever
+			nodeStatus.Freeze(registry.FreezeForever, reason)
 		} else {
-			nodeStatus.FreezeEndTime = epoch + penalty.FreezeInterval
+			nodeStatus.Freeze(epoch+penalty.FreezeInterval, reason)
 		}
 	}
 
@@ -11,12 +11,15 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/entity"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	consensusState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/abci/state"
 	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
 	registryState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/registry/state"
 	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/staking/state"
 	tmcrypto "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/crypto"
+	consensusGenesis "github.com/oasisprotocol/oasis-core/go/consensus/genesis"
 	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+	"github.com/oasisprotocol/oasis-core/go/upgrade/migrations"
 )
 
 func TestOnEvidenceByzantineConsensus(t *testing.T) {
@@ -35,9 +38,15 @@ func TestOnEvidenceByzantineConsensus(t *testing.T) {
 
 	regState := registryState.NewMutableState(ctx.State())
 	stakeState := stakingState.NewMutableState(ctx.State())
+	consState := consensusState.NewMutableState(ctx.State())
+
+	err := consState.SetConsensusParameters(ctx, &consensusGenesis.Parameters{
+		FeatureVersion: &migrations.Version250,
+	})
+	require.NoError(err, "SetConsensusParameters")
 
 	// Validator address is not known as there are no nodes.
-	err := onEvidenceByzantineConsensus(ctx, staking.SlashConsensusEquivocation, validatorAddress)
+	err = onEvidenceByzantineConsensus(ctx, staking.SlashConsensusEquivocation, validatorAddress)
 	require.NoError(err, "should not fail when validator address is not known")
 
 	// Add entity.
@@ -95,6 +104,7 @@ func TestOnEvidenceByzantineConsensus(t *testing.T) {
 	require.NoError(err, "NodeStatus")
 	require.True(status.IsFrozen(), "node should be frozen after slashing")
 	require.EqualValues(registry.FreezeForever, status.FreezeEndTime, "node should be frozen forever")
+	require.Equal(registry.FreezeReasonSlashed, status.FreezeReason, "node should be frozen due to slashing")
 
 	// Should not fail slashing a frozen node.
 	err = onEvidenceByzantineConsensus(ctx, staking.SlashConsensusEquivocation, validatorAddress)
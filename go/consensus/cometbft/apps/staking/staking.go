@@ -164,6 +164,13 @@ func (app *stakingApplication) ExecuteTx(ctx *api.Context, tx *transaction.Trans
 
 		_, err := app.transfer(ctx, state, &xfer)
 		return err
+	case staking.MethodTransferBatch:
+		var batch staking.TransferBatch
+		if err := cbor.Unmarshal(tx.Body, &batch); err != nil {
+			return staking.ErrInvalidArgument
+		}
+
+		return app.transferBatch(ctx, state, &batch)
 	case staking.MethodBurn:
 		var burn staking.Burn
 		if err := cbor.Unmarshal(tx.Body, &burn); err != nil {
@@ -179,6 +186,13 @@ func (app *stakingApplication) ExecuteTx(ctx *api.Context, tx *transaction.Trans
 
 		_, err := app.addEscrow(ctx, state, &escrow)
 		return err
+	case staking.MethodAddEscrowBatch:
+		var batch staking.EscrowBatch
+		if err := cbor.Unmarshal(tx.Body, &batch); err != nil {
+			return staking.ErrInvalidArgument
+		}
+
+		return app.addEscrowBatch(ctx, state, &batch)
 	case staking.MethodReclaimEscrow:
 		var reclaim staking.ReclaimEscrow
 		if err := cbor.Unmarshal(tx.Body, &reclaim); err != nil {
@@ -209,6 +223,21 @@ func (app *stakingApplication) ExecuteTx(ctx *api.Context, tx *transaction.Trans
 
 		_, err := app.withdraw(ctx, state, &withdraw)
 		return err
+	case staking.MethodTransferAt:
+		var xfer staking.TransferAt
+		if err := cbor.Unmarshal(tx.Body, &xfer); err != nil {
+			return staking.ErrInvalidArgument
+		}
+
+		_, err := app.transferAt(ctx, state, &xfer)
+		return err
+	case staking.MethodCancelScheduledTransfer:
+		var cancel staking.CancelScheduledTransfer
+		if err := cbor.Unmarshal(tx.Body, &cancel); err != nil {
+			return staking.ErrInvalidArgument
+		}
+
+		return app.cancelScheduledTransfer(ctx, state, &cancel)
 	default:
 		return staking.ErrInvalidArgument
 	}
@@ -307,6 +336,11 @@ func (app *stakingApplication) onEpochChange(ctx *api.Context, epoch beacon.Epoc
 		}))
 	}
 
+	// Release scheduled transfers.
+	if err = app.releaseScheduledTransfers(ctx, state, epoch); err != nil {
+		return fmt.Errorf("cometbft/staking: failed to release scheduled transfers: %w", err)
+	}
+
 	// Add signing rewards.
 	if err := app.rewardEpochSigning(ctx, epoch); err != nil {
 		ctx.Logger().Error("failed to add signing rewards",
@@ -0,0 +1,255 @@
+package state
+
+import (
+	"context"
+	"fmt"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+// ScheduledTransfersBalance returns the scheduled transfers pool balance.
+func (s *ImmutableState) ScheduledTransfersBalance(ctx context.Context) (*quantity.Quantity, error) {
+	return s.loadStoredBalance(ctx, scheduledTransfersBalanceKeyFmt)
+}
+
+// ScheduledTransfer returns the pending scheduled transfer with the given sender and identifier.
+func (s *ImmutableState) ScheduledTransfer(ctx context.Context, fromAddr staking.Address, id uint64) (*staking.ScheduledTransfer, error) {
+	value, err := s.is.Get(ctx, scheduledTransferKeyFmt.Encode(&fromAddr, id))
+	if err != nil {
+		return nil, abciAPI.UnavailableStateError(err)
+	}
+	if value == nil {
+		return nil, staking.ErrNoSuchScheduledTransfer
+	}
+
+	var st staking.ScheduledTransfer
+	if err = cbor.Unmarshal(value, &st); err != nil {
+		return nil, abciAPI.UnavailableStateError(err)
+	}
+	return &st, nil
+}
+
+func (s *ImmutableState) scheduledTransfers(
+	ctx context.Context,
+	fromAddr *staking.Address,
+	filter func(*staking.ScheduledTransfer) bool,
+) ([]*staking.ScheduledTransfer, error) {
+	it := s.is.NewIterator(ctx)
+	defer it.Close()
+
+	var prefix []byte
+	switch fromAddr {
+	case nil:
+		prefix = scheduledTransferKeyFmt.Encode()
+	default:
+		prefix = scheduledTransferKeyFmt.Encode(fromAddr)
+	}
+
+	var transfers []*staking.ScheduledTransfer
+	for it.Seek(prefix); it.Valid(); it.Next() {
+		var decFromAddr staking.Address
+		if !scheduledTransferKeyFmt.Decode(it.Key(), &decFromAddr) {
+			break
+		}
+		if fromAddr != nil && !decFromAddr.Equal(*fromAddr) {
+			break
+		}
+
+		var st staking.ScheduledTransfer
+		if err := cbor.Unmarshal(it.Value(), &st); err != nil {
+			return nil, abciAPI.UnavailableStateError(err)
+		}
+		if filter != nil && !filter(&st) {
+			continue
+		}
+		transfers = append(transfers, &st)
+	}
+	if it.Err() != nil {
+		return nil, abciAPI.UnavailableStateError(it.Err())
+	}
+	return transfers, nil
+}
+
+// ScheduledTransfers returns all pending scheduled transfers.
+func (s *ImmutableState) ScheduledTransfers(ctx context.Context) ([]*staking.ScheduledTransfer, error) {
+	return s.scheduledTransfers(ctx, nil, nil)
+}
+
+// ScheduledTransfersFrom returns all pending scheduled transfers sent by the given address.
+func (s *ImmutableState) ScheduledTransfersFrom(ctx context.Context, fromAddr staking.Address) ([]*staking.ScheduledTransfer, error) {
+	return s.scheduledTransfers(ctx, &fromAddr, nil)
+}
+
+// ScheduledTransfersTo returns all pending scheduled transfers to the given address.
+func (s *ImmutableState) ScheduledTransfersTo(ctx context.Context, toAddr staking.Address) ([]*staking.ScheduledTransfer, error) {
+	return s.scheduledTransfers(ctx, nil, func(st *staking.ScheduledTransfer) bool {
+		return st.To.Equal(toAddr)
+	})
+}
+
+// DueScheduledTransfers returns all pending scheduled transfers that should be released
+// at or before the given epoch.
+func (s *ImmutableState) DueScheduledTransfers(ctx context.Context, epoch beacon.EpochTime) ([]*staking.ScheduledTransfer, error) {
+	it := s.is.NewIterator(ctx)
+	defer it.Close()
+
+	var transfers []*staking.ScheduledTransfer
+	for it.Seek(scheduledTransferQueueKeyFmt.Encode()); it.Valid(); it.Next() {
+		var (
+			decEpoch uint64
+			fromAddr staking.Address
+			id       uint64
+		)
+		if !scheduledTransferQueueKeyFmt.Decode(it.Key(), &decEpoch, &fromAddr, &id) || decEpoch > uint64(epoch) {
+			break
+		}
+
+		st, err := s.ScheduledTransfer(ctx, fromAddr, id)
+		if err != nil {
+			return nil, err
+		}
+		transfers = append(transfers, st)
+	}
+	if it.Err() != nil {
+		return nil, abciAPI.UnavailableStateError(it.Err())
+	}
+	return transfers, nil
+}
+
+// NextScheduledTransferID returns the identifier of the next scheduled transfer.
+func (s *ImmutableState) NextScheduledTransferID(ctx context.Context) (uint64, error) {
+	value, err := s.is.Get(ctx, nextScheduledTransferIDKeyFmt.Encode())
+	if err != nil {
+		return 0, abciAPI.UnavailableStateError(err)
+	}
+	if value == nil {
+		return 0, nil
+	}
+
+	var id uint64
+	if err = cbor.Unmarshal(value, &id); err != nil {
+		return 0, abciAPI.UnavailableStateError(err)
+	}
+	return id, nil
+}
+
+// SetNextScheduledTransferID sets the identifier of the next scheduled transfer.
+func (s *MutableState) SetNextScheduledTransferID(ctx context.Context, id uint64) error {
+	err := s.ms.Insert(ctx, nextScheduledTransferIDKeyFmt.Encode(), cbor.Marshal(id))
+	return abciAPI.UnavailableStateError(err)
+}
+
+// SetScheduledTransfersBalance sets the scheduled transfers pool balance.
+func (s *MutableState) SetScheduledTransfersBalance(ctx context.Context, q *quantity.Quantity) error {
+	err := s.ms.Insert(ctx, scheduledTransfersBalanceKeyFmt.Encode(), cbor.Marshal(q))
+	return abciAPI.UnavailableStateError(err)
+}
+
+// SetScheduledTransfer adds a pending scheduled transfer and queues it for release.
+//
+// Note that this does not move any funds.
+func (s *MutableState) SetScheduledTransfer(ctx context.Context, st *staking.ScheduledTransfer) error {
+	if err := s.ms.Insert(
+		ctx,
+		scheduledTransferQueueKeyFmt.Encode(uint64(st.ReleaseEpoch), &st.From, st.ID),
+		[]byte{},
+	); err != nil {
+		return abciAPI.UnavailableStateError(err)
+	}
+	err := s.ms.Insert(ctx, scheduledTransferKeyFmt.Encode(&st.From, st.ID), cbor.Marshal(st))
+	return abciAPI.UnavailableStateError(err)
+}
+
+// RemoveScheduledTransfer removes a pending scheduled transfer from state and from the
+// release queue.
+//
+// Note that this does not move any funds.
+func (s *MutableState) RemoveScheduledTransfer(ctx context.Context, st *staking.ScheduledTransfer) error {
+	if err := s.ms.Remove(ctx, scheduledTransferQueueKeyFmt.Encode(uint64(st.ReleaseEpoch), &st.From, st.ID)); err != nil {
+		return abciAPI.UnavailableStateError(err)
+	}
+	err := s.ms.Remove(ctx, scheduledTransferKeyFmt.Encode(&st.From, st.ID))
+	return abciAPI.UnavailableStateError(err)
+}
+
+// TransferToScheduledTransfers transfers the amount from the given account to the
+// scheduled transfers pool.
+func (s *MutableState) TransferToScheduledTransfers(
+	ctx *abciAPI.Context,
+	fromAddr staking.Address,
+	amount *quantity.Quantity,
+) error {
+	from, err := s.Account(ctx, fromAddr)
+	if err != nil {
+		return fmt.Errorf("cometbft/staking: failed to query account %s: %w", fromAddr, err)
+	}
+
+	pool, err := s.ScheduledTransfersBalance(ctx)
+	if err != nil {
+		return fmt.Errorf("cometbft/staking: failed to query scheduled transfers balance: %w", err)
+	}
+
+	if err = quantity.Move(pool, &from.General.Balance, amount); err != nil {
+		return fmt.Errorf("cometbft/staking: failed to transfer to scheduled transfers, from: %s: %w", fromAddr, err)
+	}
+
+	if err = s.SetAccount(ctx, fromAddr, from); err != nil {
+		return fmt.Errorf("cometbft/staking: failed to set scheduled transfer sender account: %w", err)
+	}
+	if err = s.SetScheduledTransfersBalance(ctx, pool); err != nil {
+		return fmt.Errorf("cometbft/staking: failed to set scheduled transfers balance: %w", err)
+	}
+
+	if !ctx.IsCheckOnly() {
+		ctx.EmitEvent(abciAPI.NewEventBuilder(AppName).TypedAttribute(&staking.TransferEvent{
+			From:   fromAddr,
+			To:     staking.ScheduledTransfersAddress,
+			Amount: *amount,
+		}))
+	}
+
+	return nil
+}
+
+// TransferFromScheduledTransfers transfers the amount from the scheduled transfers pool
+// to the given account.
+func (s *MutableState) TransferFromScheduledTransfers(
+	ctx *abciAPI.Context,
+	toAddr staking.Address,
+	amount *quantity.Quantity,
+) error {
+	to, err := s.Account(ctx, toAddr)
+	if err != nil {
+		return fmt.Errorf("cometbft/staking: failed to query account %s: %w", toAddr, err)
+	}
+
+	pool, err := s.ScheduledTransfersBalance(ctx)
+	if err != nil {
+		return fmt.Errorf("cometbft/staking: failed to query scheduled transfers balance: %w", err)
+	}
+
+	if err = quantity.Move(&to.General.Balance, pool, amount); err != nil {
+		return fmt.Errorf("cometbft/staking: failed to transfer from scheduled transfers, to: %s: %w", toAddr, err)
+	}
+
+	if err = s.SetAccount(ctx, toAddr, to); err != nil {
+		return fmt.Errorf("cometbft/staking: failed to set scheduled transfer recipient account: %w", err)
+	}
+	if err = s.SetScheduledTransfersBalance(ctx, pool); err != nil {
+		return fmt.Errorf("cometbft/staking: failed to set scheduled transfers balance: %w", err)
+	}
+
+	if !ctx.IsCheckOnly() {
+		ctx.EmitEvent(abciAPI.NewEventBuilder(AppName).TypedAttribute(&staking.TransferEvent{
+			From:   staking.ScheduledTransfersAddress,
+			To:     toAddr,
+			Amount: *amount,
+		}))
+	}
+
+	return nil
+}
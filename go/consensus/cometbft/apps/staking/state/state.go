@@ -80,6 +80,27 @@ var (
 	// Value is empty.
 	commissionScheduleAddressesKeyFmt = consensus.KeyFormat.New(0x5B, &staking.Address{})
 
+	// scheduledTransferKeyFmt is the key format used for pending scheduled transfers
+	// (sender address, scheduled transfer identifier).
+	//
+	// Value is CBOR-serialized scheduled transfer.
+	scheduledTransferKeyFmt = consensus.KeyFormat.New(0x5C, &staking.Address{}, uint64(0))
+	// scheduledTransferQueueKeyFmt is the scheduled transfer release queue key format
+	// (release epoch, sender address, scheduled transfer identifier).
+	//
+	// Value is empty.
+	scheduledTransferQueueKeyFmt = consensus.KeyFormat.New(0x5D, uint64(0), &staking.Address{}, uint64(0))
+	// scheduledTransfersBalanceKeyFmt is the key format used for the scheduled transfers
+	// pool balance.
+	//
+	// Value is a CBOR-serialized quantity.
+	scheduledTransfersBalanceKeyFmt = consensus.KeyFormat.New(0x5E)
+	// nextScheduledTransferIDKeyFmt is the key format used for the next scheduled transfer
+	// identifier.
+	//
+	// Value is a CBOR-serialized uint64.
+	nextScheduledTransferIDKeyFmt = consensus.KeyFormat.New(0x5F)
+
 	logger = logging.GetLogger("cometbft/staking")
 )
 
@@ -456,11 +456,37 @@ func TestRewardAndSlash(t *testing.T) {
 	ctx = appState.NewContext(abciAPI.ContextEndBlock)
 	defer ctx.Close()
 
+	// Simulating the slash should match the actual outcome.
+	delegations, err := s.DelegationsTo(ctx, escrowAddr)
+	require.NoError(err, "DelegationsTo")
+	debDelegations, err := s.DebondingDelegationsTo(ctx, escrowAddr)
+	require.NoError(err, "DebondingDelegationsTo")
+	sim, err := staking.SimulateSlash(
+		&staking.ConsensusParameters{
+			Slashing: map[staking.SlashReason]staking.Slash{
+				staking.SlashConsensusEquivocation: {Amount: mustInitQuantity(t, 40)},
+			},
+		},
+		99,
+		escrowAddr,
+		staking.SlashConsensusEquivocation,
+		escrowAccount,
+		delegations,
+		debDelegations,
+	)
+	require.NoError(err, "SimulateSlash")
+
 	// Slash 40 base units
 	slashed, err := s.SlashEscrow(ctx, escrowAddr, mustInitQuantityP(t, 40))
 	require.NoError(err, "slash escrow")
 	require.False(slashed.IsZero(), "slashed nonzero")
 
+	simSlashed := sim.ActiveSlashed.Clone()
+	require.NoError(simSlashed.Add(&sim.DebondingSlashed))
+	require.Equal(slashed, simSlashed, "simulated slash amount should match")
+	require.Equal(mustInitQuantity(t, 10), sim.DebondingSlashed, "simulated debonding slash amount should match")
+	require.Equal(mustInitQuantity(t, 90), sim.Delegators[delegatorAddr].DebondingAfter, "simulated debonding delegation should match")
+
 	// Slashing should emit the correct event.
 	evs = ctx.GetEvents()
 	require.Len(evs, 1, fmt.Sprintf("slashing should emit 1 event; got %d: %+v", len(evs), evs))
@@ -3,12 +3,15 @@ package staking
 import (
 	"fmt"
 
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common/errors"
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
 	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
 	stakingApi "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/staking/api"
 	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/staking/state"
+	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/features"
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+	"github.com/oasisprotocol/oasis-core/go/upgrade/migrations"
 )
 
 func isTransferPermitted(params *staking.ConsensusParameters, fromAddr staking.Address) (permitted bool) {
@@ -23,6 +26,17 @@ func isTransferPermitted(params *staking.ConsensusParameters, fromAddr staking.A
 }
 
 func (app *stakingApplication) transfer(ctx *api.Context, state *stakingState.MutableState, xfer *staking.Transfer) (*staking.TransferResult, error) {
+	return app.doTransfer(ctx, state, xfer, true)
+}
+
+// doTransfer executes the given transfer, optionally sweeping any dust remaining in the source
+// account afterwards.
+func (app *stakingApplication) doTransfer(
+	ctx *api.Context,
+	state *stakingState.MutableState,
+	xfer *staking.Transfer,
+	sweepDust bool,
+) (*staking.TransferResult, error) {
 	if ctx.IsCheckOnly() {
 		return nil, nil
 	}
@@ -67,6 +81,12 @@ func (app *stakingApplication) transfer(ctx *api.Context, state *stakingState.Mu
 		Amount: xfer.Amount,
 	}))
 
+	if sweepDust && !fromAddr.Equal(xfer.To) {
+		if err = app.sweepDust(ctx, state, params, fromAddr); err != nil {
+			return nil, err
+		}
+	}
+
 	return &staking.TransferResult{
 		From:   fromAddr,
 		To:     xfer.To,
@@ -160,6 +180,125 @@ func (app *stakingApplication) transferImpl(
 	return nil
 }
 
+// sweepDust moves the remaining general balance of the given account into the common pool in
+// case it is below the dust threshold.
+//
+// Such a balance is too small to be worth transferring, yet it would keep the account in state
+// (and in event indexes) indefinitely. Since nobody else has a claim on it, the common pool is
+// the only place where it can go without creating new dust elsewhere.
+func (app *stakingApplication) sweepDust(
+	ctx *api.Context,
+	state *stakingState.MutableState,
+	params *staking.ConsensusParameters,
+	addr staking.Address,
+) error {
+	if params.DustThreshold.IsZero() {
+		return nil
+	}
+
+	// Allow dust handling with the 25.0 release.
+	enabled, err := features.IsFeatureVersion(ctx, migrations.Version250)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return nil
+	}
+
+	acct, err := state.Account(ctx, addr)
+	if err != nil {
+		return fmt.Errorf("failed to fetch account: %w", err)
+	}
+	if acct.General.Balance.IsZero() || acct.General.Balance.Cmp(&params.DustThreshold) >= 0 {
+		return nil
+	}
+
+	commonPool, err := state.CommonPool(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to query common pool: %w", err)
+	}
+	dust := acct.General.Balance.Clone()
+	if err = quantity.Move(commonPool, &acct.General.Balance, dust); err != nil {
+		return fmt.Errorf("failed to move dust: %w", err)
+	}
+	if err = state.SetCommonPool(ctx, commonPool); err != nil {
+		return fmt.Errorf("failed to set common pool: %w", err)
+	}
+	if err = state.SetAccount(ctx, addr, acct); err != nil {
+		return fmt.Errorf("failed to set account: %w", err)
+	}
+
+	ctx.Logger().Debug("Transfer: swept dust into the common pool",
+		"account_addr", addr,
+		"amount", dust,
+		"dust_threshold", params.DustThreshold,
+	)
+
+	ctx.EmitEvent(api.NewEventBuilder(app.Name()).TypedAttribute(&staking.TransferEvent{
+		From:   addr,
+		To:     staking.CommonPoolAddress,
+		Amount: *dust,
+	}))
+
+	return nil
+}
+
+func (app *stakingApplication) transferBatch(ctx *api.Context, state *stakingState.MutableState, batch *staking.TransferBatch) error {
+	// Allow batched transfers with the 25.0 release.
+	enabled, err := features.IsFeatureVersion(ctx, migrations.Version250)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return fmt.Errorf("%w: transfer batches not enabled", staking.ErrForbidden)
+	}
+
+	if err = batch.ValidateBasic(); err != nil {
+		return err
+	}
+
+	params, err := state.ConsensusParameters(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch consensus parameters: %w", err)
+	}
+	if params.MaxTransferBatchSize == 0 {
+		return fmt.Errorf("%w: transfer batches disabled", staking.ErrForbidden)
+	}
+	if n := len(batch.Transfers); n > int(params.MaxTransferBatchSize) {
+		return fmt.Errorf("%w: transfer batch too large (%d > %d)", staking.ErrInvalidArgument, n, params.MaxTransferBatchSize)
+	}
+
+	if ctx.IsCheckOnly() {
+		return nil
+	}
+
+	// Start a new transaction and rollback in case any of the transfers fails.
+	ctx = ctx.NewTransaction()
+	defer ctx.Close()
+
+	state = stakingState.NewMutableState(ctx.State())
+
+	// Gas is charged by each individual transfer. Dust is only swept once the whole batch has
+	// been applied, as the balance may temporarily drop below the threshold.
+	fromAddr := ctx.CallerAddress()
+	var sweepDust bool
+	for i := range batch.Transfers {
+		if _, err = app.doTransfer(ctx, state, &batch.Transfers[i], false); err != nil {
+			return err
+		}
+		sweepDust = sweepDust || !fromAddr.Equal(batch.Transfers[i].To)
+	}
+	if sweepDust && !ctx.IsSimulation() {
+		if err = app.sweepDust(ctx, state, params, fromAddr); err != nil {
+			return err
+		}
+	}
+
+	ctx.Commit()
+
+	return nil
+}
+
 func (app *stakingApplication) burn(ctx *api.Context, state *stakingState.MutableState, burn *staking.Burn) error {
 	if ctx.IsCheckOnly() {
 		return nil
@@ -374,6 +513,42 @@ func (app *stakingApplication) addEscrow(ctx *api.Context, state *stakingState.M
 	}, nil
 }
 
+func (app *stakingApplication) addEscrowBatch(ctx *api.Context, state *stakingState.MutableState, batch *staking.EscrowBatch) error {
+	// Allow batched escrows with the 25.0 release.
+	enabled, err := features.IsFeatureVersion(ctx, migrations.Version250)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return fmt.Errorf("%w: escrow batches not enabled", staking.ErrForbidden)
+	}
+
+	if err = batch.ValidateBasic(); err != nil {
+		return err
+	}
+
+	if ctx.IsCheckOnly() {
+		return nil
+	}
+
+	// Start a new transaction and rollback in case any of the escrows fails.
+	ctx = ctx.NewTransaction()
+	defer ctx.Close()
+
+	state = stakingState.NewMutableState(ctx.State())
+
+	// Gas is charged by each individual escrow.
+	for i := range batch.Escrows {
+		if _, err = app.addEscrow(ctx, state, &batch.Escrows[i]); err != nil {
+			return err
+		}
+	}
+
+	ctx.Commit()
+
+	return nil
+}
+
 func (app *stakingApplication) reclaimEscrow(ctx *api.Context, state *stakingState.MutableState, reclaim *staking.ReclaimEscrow) (*staking.ReclaimEscrowResult, error) {
 	// No sense if there is nothing to reclaim.
 	if reclaim.Shares.IsZero() {
@@ -638,6 +813,18 @@ func (app *stakingApplication) allow(
 		return staking.ErrInvalidArgument
 	}
 
+	// Allow allowance limits with the 25.0 release.
+	if allow.HasLimits() {
+		var enabled bool
+		enabled, err = features.IsFeatureVersion(ctx, migrations.Version250)
+		if err != nil {
+			return err
+		}
+		if !enabled {
+			return fmt.Errorf("%w: allowance limits not enabled", staking.ErrForbidden)
+		}
+	}
+
 	acct, err := state.Account(ctx, addr)
 	if err != nil {
 		return fmt.Errorf("failed to fetch account: %w", err)
@@ -679,6 +866,35 @@ func (app *stakingApplication) allow(
 		acct.General.Allowances[allow.Beneficiary] = allowance
 	}
 
+	// Update allowance limits.
+	limits := acct.General.AllowanceLimits[allow.Beneficiary]
+	if allow.Expiration != nil {
+		if *allow.Expiration != 0 {
+			var epoch beacon.EpochTime
+			epoch, err = app.state.GetEpoch(ctx, ctx.BlockHeight()+1)
+			if err != nil {
+				return err
+			}
+			if *allow.Expiration <= epoch {
+				return fmt.Errorf("%w: allowance expiration not in the future", staking.ErrInvalidArgument)
+			}
+		}
+		limits.Expiration = *allow.Expiration
+	}
+	if allow.EpochLimit != nil {
+		limits.EpochLimit = *allow.EpochLimit.Clone()
+	}
+	switch {
+	case allowance.IsZero() || limits.IsEmpty():
+		// Limits are removed together with the allowance or once there is nothing to limit.
+		delete(acct.General.AllowanceLimits, allow.Beneficiary)
+	default:
+		if acct.General.AllowanceLimits == nil {
+			acct.General.AllowanceLimits = make(map[staking.Address]staking.AllowanceLimits)
+		}
+		acct.General.AllowanceLimits[allow.Beneficiary] = limits
+	}
+
 	// If updating allowances would go past the maximum number of allowances, fail.
 	if uint32(len(acct.General.Allowances)) > params.MaxAllowances {
 		return staking.ErrTooManyAllowances
@@ -777,9 +993,22 @@ func (app *stakingApplication) withdraw(
 		if err = allowance.Sub(&withdraw.Amount); err != nil {
 			return nil, staking.ErrForbidden
 		}
+		if limits, ok := from.General.AllowanceLimits[toAddr]; ok {
+			// Enforce the allowance expiration and epoch limit.
+			var epoch beacon.EpochTime
+			epoch, err = app.state.GetEpoch(ctx, ctx.BlockHeight()+1)
+			if err != nil {
+				return nil, err
+			}
+			if err = limits.Withdraw(epoch, &withdraw.Amount); err != nil {
+				return nil, err
+			}
+			from.General.AllowanceLimits[toAddr] = limits
+		}
 		if allowance.IsZero() {
-			// In case the new allowance is equal to zero, remove it.
+			// In case the new allowance is equal to zero, remove it together with its limits.
 			delete(from.General.Allowances, toAddr)
+			delete(from.General.AllowanceLimits, toAddr)
 		} else {
 			// Otherwise update the allowance.
 			from.General.Allowances[toAddr] = allowance
@@ -8,9 +8,13 @@ import (
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	eventsAPI "github.com/oasisprotocol/oasis-core/go/consensus/api/events"
+	consensusState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/abci/state"
 	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
 	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/staking/state"
+	consensusGenesis "github.com/oasisprotocol/oasis-core/go/consensus/genesis"
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+	"github.com/oasisprotocol/oasis-core/go/upgrade/migrations"
 )
 
 func TestIsTransferPermitted(t *testing.T) {
@@ -628,6 +632,147 @@ func TestWithdraw(t *testing.T) {
 	}
 }
 
+func TestAllowanceLimits(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	cfg := &abciAPI.MockApplicationStateConfig{CurrentEpoch: 1}
+	appState := abciAPI.NewMockApplicationState(cfg)
+	ctx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx.Close()
+
+	stakeState := stakingState.NewMutableState(ctx.State())
+	consState := consensusState.NewMutableState(ctx.State())
+	err = consState.SetConsensusParameters(ctx, &consensusGenesis.Parameters{})
+	require.NoError(err, "SetConsensusParameters")
+
+	app := &stakingApplication{
+		state: appState,
+	}
+
+	pk1 := signature.NewPublicKey("aaafffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	addr1 := staking.NewAddress(pk1)
+	pk2 := signature.NewPublicKey("bbbfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	addr2 := staking.NewAddress(pk2)
+
+	require.NoError(stakeState.SetTotalSupply(ctx, quantity.NewFromUint64(100_000)), "SetTotalSupply")
+	err = stakeState.SetAccount(ctx, addr1, &staking.Account{
+		General: staking.GeneralAccount{
+			Balance: *quantity.NewFromUint64(100_000),
+		},
+	})
+	require.NoError(err, "SetAccount")
+	err = stakeState.SetConsensusParameters(ctx, &staking.ConsensusParameters{
+		MaxAllowances: 1,
+	})
+	require.NoError(err, "setting staking consensus parameters should not error")
+
+	allow := func(allow *staking.Allow) error {
+		txCtx := appState.NewContext(abciAPI.ContextDeliverTx)
+		defer txCtx.Close()
+		txCtx.SetTxSigner(pk1)
+		return app.allow(txCtx, stakeState, allow)
+	}
+	withdraw := func(amount uint64) error {
+		txCtx := appState.NewContext(abciAPI.ContextDeliverTx)
+		defer txCtx.Close()
+		txCtx.SetTxSigner(pk2)
+		_, err := app.withdraw(txCtx, stakeState, &staking.Withdraw{From: addr1, Amount: *quantity.NewFromUint64(amount)})
+		return err
+	}
+	requireLimits := func(expected *staking.AllowanceLimits) {
+		acct, aerr := stakeState.Account(ctx, addr1)
+		require.NoError(aerr, "Account")
+		limits, ok := acct.General.AllowanceLimits[addr2]
+		if expected == nil {
+			require.False(ok, "allowance limits should not exist")
+			return
+		}
+		require.True(ok, "allowance limits should exist")
+		require.EqualValues(*expected, limits)
+	}
+
+	expiration := beacon.EpochTime(10)
+	epochLimit := quantity.NewFromUint64(1_000)
+
+	// Allowance limits should not be allowed before the feature version is enabled.
+	err = allow(&staking.Allow{
+		Beneficiary:  addr2,
+		AmountChange: *quantity.NewFromUint64(10_000),
+		Expiration:   &expiration,
+	})
+	require.ErrorIs(err, staking.ErrForbidden, "allowance limits before the feature version should fail")
+
+	err = consState.SetConsensusParameters(ctx, &consensusGenesis.Parameters{
+		FeatureVersion: &migrations.Version250,
+	})
+	require.NoError(err, "SetConsensusParameters")
+
+	pastExpiration := beacon.EpochTime(1)
+	err = allow(&staking.Allow{
+		Beneficiary:  addr2,
+		AmountChange: *quantity.NewFromUint64(10_000),
+		Expiration:   &pastExpiration,
+	})
+	require.ErrorIs(err, staking.ErrInvalidArgument, "allowance expiration in the past should fail")
+
+	err = allow(&staking.Allow{
+		Beneficiary:  addr2,
+		AmountChange: *quantity.NewFromUint64(10_000),
+		Expiration:   &expiration,
+		EpochLimit:   epochLimit,
+	})
+	require.NoError(err, "Allow")
+	requireLimits(&staking.AllowanceLimits{Expiration: expiration, EpochLimit: *epochLimit})
+
+	// Withdrawals should be capped per epoch.
+	require.NoError(withdraw(600), "Withdraw")
+	require.NoError(withdraw(400), "Withdraw")
+	err = withdraw(1)
+	require.ErrorIs(err, staking.ErrForbidden, "withdrawal over the epoch limit should fail")
+	requireLimits(&staking.AllowanceLimits{
+		Expiration: expiration,
+		EpochLimit: *epochLimit,
+		Epoch:      1,
+		Withdrawn:  *quantity.NewFromUint64(1_000),
+	})
+
+	cfg.CurrentEpoch = 2
+	require.NoError(withdraw(1_000), "withdrawal in a new epoch should succeed")
+
+	// Withdrawals should fail once the allowance has expired.
+	cfg.CurrentEpoch = 10
+	err = withdraw(100)
+	require.ErrorIs(err, staking.ErrForbidden, "withdrawal from an expired allowance should fail")
+
+	// Removing all limits should remove the limits entry.
+	var noExpiration beacon.EpochTime
+	err = allow(&staking.Allow{
+		Beneficiary: addr2,
+		Expiration:  &noExpiration,
+		EpochLimit:  quantity.NewQuantity(),
+	})
+	require.NoError(err, "Allow")
+	requireLimits(nil)
+	require.NoError(withdraw(5_000), "withdrawal without limits should succeed")
+
+	// Limits should be removed together with the allowance.
+	expiration = 20
+	err = allow(&staking.Allow{
+		Beneficiary:  addr2,
+		AmountChange: *quantity.NewFromUint64(0),
+		Expiration:   &expiration,
+	})
+	require.NoError(err, "Allow")
+	requireLimits(&staking.AllowanceLimits{Expiration: expiration})
+	require.NoError(withdraw(3_000), "Withdraw")
+
+	acct, err := stakeState.Account(ctx, addr1)
+	require.NoError(err, "Account")
+	require.Empty(acct.General.Allowances, "allowance should be removed")
+	requireLimits(nil)
+}
+
 func TestAddEscrow(t *testing.T) {
 	require := require.New(t)
 	var err error
@@ -763,6 +908,367 @@ func TestAddEscrow(t *testing.T) {
 	}
 }
 
+func TestTransferBatch(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx.Close()
+
+	stakeState := stakingState.NewMutableState(ctx.State())
+	consState := consensusState.NewMutableState(ctx.State())
+	err = consState.SetConsensusParameters(ctx, &consensusGenesis.Parameters{})
+	require.NoError(err, "SetConsensusParameters")
+
+	app := &stakingApplication{
+		state: appState,
+	}
+
+	pk1 := signature.NewPublicKey("aaafffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	addr1 := staking.NewAddress(pk1)
+	pk2 := signature.NewPublicKey("bbbfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	addr2 := staking.NewAddress(pk2)
+	pk3 := signature.NewPublicKey("cccfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	addr3 := staking.NewAddress(pk3)
+
+	err = stakeState.SetAccount(ctx, addr1, &staking.Account{
+		General: staking.GeneralAccount{
+			Balance: *quantity.NewFromUint64(100_000),
+		},
+	})
+	require.NoError(err, "SetAccount1")
+	params := &staking.ConsensusParameters{
+		MinTransferAmount: *quantity.NewFromUint64(1000),
+	}
+	err = stakeState.SetConsensusParameters(ctx, params)
+	require.NoError(err, "setting staking consensus parameters should not error")
+
+	transferBatch := func(transfers ...staking.Transfer) error {
+		txCtx := appState.NewContext(abciAPI.ContextDeliverTx)
+		defer txCtx.Close()
+		txCtx.SetTxSigner(pk1)
+		return app.transferBatch(txCtx, stakeState, &staking.TransferBatch{Transfers: transfers})
+	}
+
+	// Batches should not be allowed before the feature version is enabled.
+	err = transferBatch(staking.Transfer{To: addr2, Amount: *quantity.NewFromUint64(10_000)})
+	require.ErrorIs(err, staking.ErrForbidden, "transfer batch before the feature version should fail")
+
+	err = consState.SetConsensusParameters(ctx, &consensusGenesis.Parameters{
+		FeatureVersion: &migrations.Version250,
+	})
+	require.NoError(err, "SetConsensusParameters")
+
+	// Batches should not be allowed when the maximum batch size is zero.
+	err = transferBatch(staking.Transfer{To: addr2, Amount: *quantity.NewFromUint64(10_000)})
+	require.ErrorIs(err, staking.ErrForbidden, "transfer batch when disabled should fail")
+
+	params.MaxTransferBatchSize = 2
+	err = stakeState.SetConsensusParameters(ctx, params)
+	require.NoError(err, "setting staking consensus parameters should not error")
+
+	err = transferBatch()
+	require.ErrorIs(err, staking.ErrInvalidArgument, "empty transfer batch should fail")
+
+	err = transferBatch(
+		staking.Transfer{To: addr2, Amount: *quantity.NewFromUint64(10_000)},
+		staking.Transfer{To: addr3, Amount: *quantity.NewFromUint64(10_000)},
+		staking.Transfer{To: addr3, Amount: *quantity.NewFromUint64(10_000)},
+	)
+	require.ErrorIs(err, staking.ErrInvalidArgument, "transfer batch over the maximum size should fail")
+
+	// A failing transfer should revert the whole batch.
+	err = transferBatch(
+		staking.Transfer{To: addr2, Amount: *quantity.NewFromUint64(10_000)},
+		staking.Transfer{To: addr3, Amount: *quantity.NewFromUint64(100)},
+	)
+	require.ErrorIs(err, staking.ErrUnderMinTransferAmount, "transfer batch with an invalid transfer should fail")
+
+	acct, err := stakeState.Account(ctx, addr1)
+	require.NoError(err, "Account")
+	require.EqualValues(*quantity.NewFromUint64(100_000), acct.General.Balance, "failed batch should be reverted")
+
+	err = transferBatch(
+		staking.Transfer{To: addr2, Amount: *quantity.NewFromUint64(10_000)},
+		staking.Transfer{To: addr3, Amount: *quantity.NewFromUint64(20_000)},
+	)
+	require.NoError(err, "transfer batch should succeed")
+
+	acct, err = stakeState.Account(ctx, addr1)
+	require.NoError(err, "Account")
+	require.EqualValues(*quantity.NewFromUint64(70_000), acct.General.Balance)
+	for _, tc := range []struct {
+		addr   staking.Address
+		amount uint64
+	}{
+		{addr2, 10_000},
+		{addr3, 20_000},
+	} {
+		acct, err = stakeState.Account(ctx, tc.addr)
+		require.NoError(err, "Account")
+		require.EqualValues(*quantity.NewFromUint64(tc.amount), acct.General.Balance)
+	}
+}
+
+func TestTransferDust(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx.Close()
+
+	stakeState := stakingState.NewMutableState(ctx.State())
+	consState := consensusState.NewMutableState(ctx.State())
+	err = consState.SetConsensusParameters(ctx, &consensusGenesis.Parameters{})
+	require.NoError(err, "SetConsensusParameters")
+
+	app := &stakingApplication{
+		state: appState,
+	}
+
+	pk1 := signature.NewPublicKey("aaafffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	addr1 := staking.NewAddress(pk1)
+	pk2 := signature.NewPublicKey("bbbfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	addr2 := staking.NewAddress(pk2)
+
+	err = stakeState.SetAccount(ctx, addr1, &staking.Account{
+		General: staking.GeneralAccount{
+			Balance: *quantity.NewFromUint64(100_000),
+		},
+	})
+	require.NoError(err, "SetAccount")
+	err = stakeState.SetCommonPool(ctx, quantity.NewFromUint64(0))
+	require.NoError(err, "SetCommonPool")
+	params := &staking.ConsensusParameters{
+		DustThreshold: *quantity.NewFromUint64(1000),
+	}
+	err = stakeState.SetConsensusParameters(ctx, params)
+	require.NoError(err, "setting staking consensus parameters should not error")
+
+	var transferEvents []*staking.TransferEvent
+	transfer := func(amount uint64) error {
+		txCtx := appState.NewContext(abciAPI.ContextDeliverTx)
+		defer txCtx.Close()
+		txCtx.SetTxSigner(pk1)
+		_, err := app.transfer(txCtx, stakeState, &staking.Transfer{To: addr2, Amount: *quantity.NewFromUint64(amount)})
+		transferEvents = decodeEvents[staking.TransferEvent](require, txCtx)
+		return err
+	}
+	transferBatch := func(amounts ...uint64) error {
+		txCtx := appState.NewContext(abciAPI.ContextDeliverTx)
+		defer txCtx.Close()
+		txCtx.SetTxSigner(pk1)
+		var batch staking.TransferBatch
+		for _, amount := range amounts {
+			batch.Transfers = append(batch.Transfers, staking.Transfer{To: addr2, Amount: *quantity.NewFromUint64(amount)})
+		}
+		err := app.transferBatch(txCtx, stakeState, &batch)
+		transferEvents = decodeEvents[staking.TransferEvent](require, txCtx)
+		return err
+	}
+	requireBalances := func(from, to, commonPool uint64) {
+		acct, err := stakeState.Account(ctx, addr1)
+		require.NoError(err, "Account")
+		require.EqualValues(*quantity.NewFromUint64(from), acct.General.Balance, "source balance")
+		acct, err = stakeState.Account(ctx, addr2)
+		require.NoError(err, "Account")
+		require.EqualValues(*quantity.NewFromUint64(to), acct.General.Balance, "destination balance")
+		cp, err := stakeState.CommonPool(ctx)
+		require.NoError(err, "CommonPool")
+		require.EqualValues(*quantity.NewFromUint64(commonPool), *cp, "common pool balance")
+	}
+
+	// Dust should not be swept before the feature version is enabled.
+	err = transfer(49_500)
+	require.NoError(err, "transfer should succeed")
+	requireBalances(50_500, 49_500, 0)
+	require.Len(transferEvents, 1, "only the transfer event should be emitted")
+
+	err = consState.SetConsensusParameters(ctx, &consensusGenesis.Parameters{
+		FeatureVersion: &migrations.Version250,
+	})
+	require.NoError(err, "SetConsensusParameters")
+
+	// Remaining balances at or above the threshold should be kept.
+	err = transfer(49_500)
+	require.NoError(err, "transfer should succeed")
+	requireBalances(1000, 99_000, 0)
+	require.Len(transferEvents, 1, "only the transfer event should be emitted")
+
+	// Remaining balances below the threshold should be swept into the common pool.
+	err = transfer(500)
+	require.NoError(err, "transfer should succeed")
+	requireBalances(0, 99_500, 500)
+	require.Len(transferEvents, 2, "transfer and dust events should be emitted")
+	require.EqualValues(addr2, transferEvents[0].To)
+	require.EqualValues(*quantity.NewFromUint64(500), transferEvents[0].Amount)
+	require.EqualValues(addr1, transferEvents[1].From)
+	require.EqualValues(staking.CommonPoolAddress, transferEvents[1].To)
+	require.EqualValues(*quantity.NewFromUint64(500), transferEvents[1].Amount)
+
+	// Dust should only be swept once the whole batch has been applied.
+	err = stakeState.SetAccount(ctx, addr1, &staking.Account{
+		General: staking.GeneralAccount{
+			Balance: *quantity.NewFromUint64(10_500),
+		},
+	})
+	require.NoError(err, "SetAccount")
+	err = stakeState.SetConsensusParameters(ctx, &staking.ConsensusParameters{
+		DustThreshold:        *quantity.NewFromUint64(1000),
+		MaxTransferBatchSize: 10,
+	})
+	require.NoError(err, "setting staking consensus parameters should not error")
+
+	err = transferBatch(10_000, 500)
+	require.NoError(err, "transfer batch draining the account should succeed")
+	requireBalances(0, 110_000, 500)
+	require.Len(transferEvents, 2, "only the transfer events should be emitted")
+
+	err = stakeState.SetAccount(ctx, addr1, &staking.Account{
+		General: staking.GeneralAccount{
+			Balance: *quantity.NewFromUint64(10_500),
+		},
+	})
+	require.NoError(err, "SetAccount")
+
+	err = transferBatch(9_000, 1_000)
+	require.NoError(err, "transfer batch should succeed")
+	requireBalances(0, 120_000, 1000)
+	require.Len(transferEvents, 3, "transfer and dust events should be emitted")
+	require.EqualValues(staking.CommonPoolAddress, transferEvents[2].To)
+	require.EqualValues(*quantity.NewFromUint64(500), transferEvents[2].Amount)
+}
+
+// decodeEvents decodes all events of the given kind emitted in the given context.
+func decodeEvents[T any, PT interface {
+	*T
+	eventsAPI.TypedAttribute
+}](require *require.Assertions, ctx *abciAPI.Context) []PT {
+	var evs []PT
+	for _, ev := range ctx.GetEvents() {
+		for _, pair := range ev.GetAttributes() {
+			if !eventsAPI.IsAttributeKind(pair.GetKey(), PT(new(T))) {
+				continue
+			}
+			e := PT(new(T))
+			require.NoError(eventsAPI.DecodeValue(pair.GetValue(), e), "DecodeValue")
+			evs = append(evs, e)
+		}
+	}
+	return evs
+}
+
+func TestAddEscrowBatch(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx.Close()
+
+	stakeState := stakingState.NewMutableState(ctx.State())
+	consState := consensusState.NewMutableState(ctx.State())
+	err = consState.SetConsensusParameters(ctx, &consensusGenesis.Parameters{})
+	require.NoError(err, "SetConsensusParameters")
+
+	app := &stakingApplication{
+		state: appState,
+	}
+
+	pk1 := signature.NewPublicKey("aaafffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	addr1 := staking.NewAddress(pk1)
+	pk2 := signature.NewPublicKey("bbbfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	addr2 := staking.NewAddress(pk2)
+	pk3 := signature.NewPublicKey("cccfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	addr3 := staking.NewAddress(pk3)
+
+	err = stakeState.SetAccount(ctx, addr1, &staking.Account{
+		General: staking.GeneralAccount{
+			Balance: *quantity.NewFromUint64(100_000),
+		},
+	})
+	require.NoError(err, "SetAccount1")
+	err = stakeState.SetConsensusParameters(ctx, &staking.ConsensusParameters{
+		MinDelegationAmount: *quantity.NewFromUint64(1000),
+	})
+	require.NoError(err, "setting staking consensus parameters should not error")
+
+	var addEscrowEvents []*staking.AddEscrowEvent
+	addEscrowBatch := func(escrows ...staking.Escrow) error {
+		txCtx := appState.NewContext(abciAPI.ContextDeliverTx)
+		defer txCtx.Close()
+		txCtx.SetTxSigner(pk1)
+		err := app.addEscrowBatch(txCtx, stakeState, &staking.EscrowBatch{Escrows: escrows})
+		addEscrowEvents = decodeEvents[staking.AddEscrowEvent](require, txCtx)
+		return err
+	}
+
+	// Batches should not be allowed before the feature version is enabled.
+	err = addEscrowBatch(staking.Escrow{Account: addr2, Amount: *quantity.NewFromUint64(10_000)})
+	require.ErrorIs(err, staking.ErrForbidden, "escrow batch before the feature version should fail")
+
+	err = consState.SetConsensusParameters(ctx, &consensusGenesis.Parameters{
+		FeatureVersion: &migrations.Version250,
+	})
+	require.NoError(err, "SetConsensusParameters")
+
+	err = addEscrowBatch()
+	require.ErrorIs(err, staking.ErrInvalidArgument, "empty escrow batch should fail")
+
+	err = addEscrowBatch(
+		staking.Escrow{Account: addr2, Amount: *quantity.NewFromUint64(10_000)},
+		staking.Escrow{Account: addr2, Amount: *quantity.NewFromUint64(10_000)},
+	)
+	require.ErrorIs(err, staking.ErrInvalidArgument, "escrow batch with duplicate accounts should fail")
+
+	// A failing escrow should revert the whole batch.
+	err = addEscrowBatch(
+		staking.Escrow{Account: addr2, Amount: *quantity.NewFromUint64(10_000)},
+		staking.Escrow{Account: addr3, Amount: *quantity.NewFromUint64(100)},
+	)
+	require.ErrorIs(err, staking.ErrUnderMinDelegationAmount, "escrow batch with an invalid escrow should fail")
+
+	acct, err := stakeState.Account(ctx, addr1)
+	require.NoError(err, "Account")
+	require.EqualValues(*quantity.NewFromUint64(100_000), acct.General.Balance, "failed batch should be reverted")
+	require.Empty(addEscrowEvents, "failed batch should not emit any events")
+
+	err = addEscrowBatch(
+		staking.Escrow{Account: addr2, Amount: *quantity.NewFromUint64(10_000)},
+		staking.Escrow{Account: addr3, Amount: *quantity.NewFromUint64(20_000)},
+	)
+	require.NoError(err, "escrow batch should succeed")
+	require.Len(addEscrowEvents, 2, "escrow batch should emit an event for each escrow")
+
+	acct, err = stakeState.Account(ctx, addr1)
+	require.NoError(err, "Account")
+	require.EqualValues(*quantity.NewFromUint64(70_000), acct.General.Balance)
+	for i, tc := range []struct {
+		addr   staking.Address
+		amount uint64
+	}{
+		{addr2, 10_000},
+		{addr3, 20_000},
+	} {
+		ev := addEscrowEvents[i]
+		require.Equal(addr1, ev.Owner, "event owner")
+		require.Equal(tc.addr, ev.Escrow, "event escrow")
+		require.EqualValues(*quantity.NewFromUint64(tc.amount), ev.Amount, "event amount")
+		require.EqualValues(*quantity.NewFromUint64(tc.amount), ev.NewShares, "event new shares")
+
+		acct, err = stakeState.Account(ctx, tc.addr)
+		require.NoError(err, "Account")
+		require.EqualValues(*quantity.NewFromUint64(tc.amount), acct.Escrow.Active.Balance)
+
+		var delegation *staking.Delegation
+		delegation, err = stakeState.Delegation(ctx, addr1, tc.addr)
+		require.NoError(err, "Delegation")
+		require.EqualValues(*quantity.NewFromUint64(tc.amount), delegation.Shares)
+	}
+}
+
 func TestAllowEscrowMessages(t *testing.T) {
 	require := require.New(t)
 	var err error
@@ -1114,3 +1620,127 @@ func TestAmendCommissionSchedule(t *testing.T) {
 	}}))
 	require.NoError(app.amendCommissionSchedule(txCtx, stakeState, amendment), "amending commission schedule for address with enough stake should work")
 }
+
+func TestScheduledTransfers(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	cfg := &abciAPI.MockApplicationStateConfig{CurrentEpoch: 1}
+	appState := abciAPI.NewMockApplicationState(cfg)
+	ctx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx.Close()
+
+	stakeState := stakingState.NewMutableState(ctx.State())
+	consState := consensusState.NewMutableState(ctx.State())
+	err = consState.SetConsensusParameters(ctx, &consensusGenesis.Parameters{})
+	require.NoError(err, "SetConsensusParameters")
+
+	app := &stakingApplication{
+		state: appState,
+	}
+
+	pk1 := signature.NewPublicKey("aaafffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	addr1 := staking.NewAddress(pk1)
+	pk2 := signature.NewPublicKey("bbbfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	addr2 := staking.NewAddress(pk2)
+
+	err = stakeState.SetAccount(ctx, addr1, &staking.Account{
+		General: staking.GeneralAccount{
+			Balance: *quantity.NewFromUint64(100_000),
+		},
+	})
+	require.NoError(err, "SetAccount")
+	err = stakeState.SetConsensusParameters(ctx, &staking.ConsensusParameters{
+		MinTransferAmount: *quantity.NewFromUint64(100),
+	})
+	require.NoError(err, "setting staking consensus parameters should not error")
+
+	transferAt := func(signer signature.PublicKey, xfer *staking.TransferAt) (*staking.ScheduledTransfer, error) {
+		txCtx := appState.NewContext(abciAPI.ContextDeliverTx)
+		defer txCtx.Close()
+		txCtx.SetTxSigner(signer)
+		return app.transferAt(txCtx, stakeState, xfer)
+	}
+	cancel := func(signer signature.PublicKey, id uint64) error {
+		txCtx := appState.NewContext(abciAPI.ContextDeliverTx)
+		defer txCtx.Close()
+		txCtx.SetTxSigner(signer)
+		return app.cancelScheduledTransfer(txCtx, stakeState, &staking.CancelScheduledTransfer{ID: id})
+	}
+	requireBalance := func(addr staking.Address, amount uint64) {
+		acct, aerr := stakeState.Account(ctx, addr)
+		require.NoError(aerr, "Account")
+		require.EqualValues(*quantity.NewFromUint64(amount), acct.General.Balance)
+	}
+	requirePool := func(amount uint64) {
+		pool, perr := stakeState.ScheduledTransfersBalance(ctx)
+		require.NoError(perr, "ScheduledTransfersBalance")
+		require.EqualValues(quantity.NewFromUint64(amount), pool)
+	}
+
+	// Scheduled transfers should not be allowed before the feature version is enabled.
+	_, err = transferAt(pk1, &staking.TransferAt{To: addr2, Amount: *quantity.NewFromUint64(10_000), ReleaseEpoch: 10})
+	require.ErrorIs(err, staking.ErrForbidden, "scheduled transfer before the feature version should fail")
+
+	err = consState.SetConsensusParameters(ctx, &consensusGenesis.Parameters{
+		FeatureVersion: &migrations.Version250,
+	})
+	require.NoError(err, "SetConsensusParameters")
+
+	_, err = transferAt(pk1, &staking.TransferAt{To: addr2, Amount: *quantity.NewFromUint64(10_000), ReleaseEpoch: 1})
+	require.ErrorIs(err, staking.ErrInvalidArgument, "scheduled transfer releasing in the past should fail")
+	_, err = transferAt(pk1, &staking.TransferAt{To: addr2, Amount: *quantity.NewFromUint64(10_000), ReleaseEpoch: 10, CancelDeadline: 11})
+	require.ErrorIs(err, staking.ErrInvalidArgument, "scheduled transfer with cancel deadline after release should fail")
+	_, err = transferAt(pk1, &staking.TransferAt{To: addr2, Amount: *quantity.NewFromUint64(10), ReleaseEpoch: 10})
+	require.ErrorIs(err, staking.ErrUnderMinTransferAmount, "scheduled transfer under minimum transfer amount should fail")
+	_, err = transferAt(pk1, &staking.TransferAt{To: addr2, Amount: *quantity.NewFromUint64(200_000), ReleaseEpoch: 10})
+	require.ErrorIs(err, staking.ErrInsufficientBalance, "scheduled transfer over balance should fail")
+
+	st1, err := transferAt(pk1, &staking.TransferAt{To: addr2, Amount: *quantity.NewFromUint64(10_000), ReleaseEpoch: 10, CancelDeadline: 5})
+	require.NoError(err, "TransferAt")
+	require.EqualValues(0, st1.ID)
+	st2, err := transferAt(pk1, &staking.TransferAt{To: addr2, Amount: *quantity.NewFromUint64(20_000), ReleaseEpoch: 20})
+	require.NoError(err, "TransferAt")
+	require.EqualValues(1, st2.ID)
+
+	requireBalance(addr1, 70_000)
+	requirePool(30_000)
+
+	transfers, err := stakeState.ScheduledTransfersFrom(ctx, addr1)
+	require.NoError(err, "ScheduledTransfersFrom")
+	require.EqualValues([]*staking.ScheduledTransfer{st1, st2}, transfers)
+	transfers, err = stakeState.ScheduledTransfersTo(ctx, addr2)
+	require.NoError(err, "ScheduledTransfersTo")
+	require.Len(transfers, 2)
+	transfers, err = stakeState.ScheduledTransfersFrom(ctx, addr2)
+	require.NoError(err, "ScheduledTransfersFrom")
+	require.Empty(transfers)
+
+	// Only the sender can cancel.
+	err = cancel(pk2, st1.ID)
+	require.ErrorIs(err, staking.ErrNoSuchScheduledTransfer, "cancellation by non-sender should fail")
+
+	// Cancellation after the deadline should fail.
+	cfg.CurrentEpoch = 5
+	err = cancel(pk1, st1.ID)
+	require.ErrorIs(err, staking.ErrForbidden, "cancellation after deadline should fail")
+
+	err = cancel(pk1, st2.ID)
+	require.NoError(err, "CancelScheduledTransfer")
+	requireBalance(addr1, 90_000)
+	requirePool(10_000)
+
+	// Nothing should be released before the release epoch.
+	err = app.releaseScheduledTransfers(ctx, stakeState, 9)
+	require.NoError(err, "releaseScheduledTransfers")
+	requirePool(10_000)
+
+	err = app.releaseScheduledTransfers(ctx, stakeState, 10)
+	require.NoError(err, "releaseScheduledTransfers")
+	requireBalance(addr2, 10_000)
+	requirePool(0)
+
+	transfers, err = stakeState.ScheduledTransfers(ctx)
+	require.NoError(err, "ScheduledTransfers")
+	require.Empty(transfers, "released transfers should be removed")
+}
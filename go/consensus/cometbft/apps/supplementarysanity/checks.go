@@ -44,7 +44,11 @@ func checkRegistry(ctx *abciAPI.Context, now beacon.EpochTime) error {
 	if err != nil {
 		return fmt.Errorf("SignedEntities: %w", err)
 	}
-	seenEntities, err := registry.SanityCheckEntities(logger, signedEntities)
+	multiSignedEntities, err := st.MultiSignedEntities(ctx)
+	if err != nil {
+		return fmt.Errorf("MultiSignedEntities: %w", err)
+	}
+	seenEntities, err := registry.SanityCheckEntities(logger, signedEntities, multiSignedEntities)
 	if err != nil {
 		return fmt.Errorf("SanityCheckEntities: %w", err)
 	}
@@ -211,13 +215,32 @@ func checkStaking(ctx *abciAPI.Context, now beacon.EpochTime) error { //nolint:
 		return fmt.Errorf("governance deposits %v is invalid", governanceDeposits)
 	}
 
+	scheduledTransfersBalance, err := st.ScheduledTransfersBalance(ctx)
+	if err != nil {
+		return fmt.Errorf("ScheduledTransfersBalance: %w", err)
+	}
+	scheduledTransfers, err := st.ScheduledTransfers(ctx)
+	if err != nil {
+		return fmt.Errorf("ScheduledTransfers: %w", err)
+	}
+	var scheduledTotal quantity.Quantity
+	if err = staking.SanityCheckScheduledTransfers(&scheduledTotal, scheduledTransfers); err != nil {
+		return err
+	}
+	if scheduledTotal.Cmp(scheduledTransfersBalance) != 0 {
+		return fmt.Errorf("scheduled transfers (%s) do not add up to scheduled transfers balance (%s)",
+			scheduledTotal.String(), scheduledTransfersBalance.String(),
+		)
+	}
+
+	_ = total.Add(scheduledTransfersBalance)
 	_ = total.Add(governanceDeposits)
 	_ = total.Add(commonPool)
 	_ = total.Add(totalFees)
 	if total.Cmp(totalSupply) != 0 {
 		return fmt.Errorf(
-			"balances in accounts plus governance deposits (%s), plus common pool (%s), plus last block fees (%s), does not add up to total supply (%s)",
-			governanceDeposits.String(), commonPool.String(), totalFees.String(), totalSupply.String(),
+			"balances in accounts plus scheduled transfers (%s), plus governance deposits (%s), plus common pool (%s), plus last block fees (%s), does not add up to total supply (%s)",
+			scheduledTransfersBalance.String(), governanceDeposits.String(), commonPool.String(), totalFees.String(), totalSupply.String(),
 		)
 	}
 
@@ -3,6 +3,7 @@ package config
 
 import (
 	"fmt"
+	"net/url"
 	"time"
 )
 
@@ -39,6 +40,9 @@ type Config struct {
 	// Average amount of time to delay shutting down the node on upgrade.
 	UpgradeStopDelay time.Duration `yaml:"upgrade_stop_delay,omitempty"`
 
+	// State export performed when the node halts for an upgrade.
+	HaltExport HaltExportConfig `yaml:"halt_export,omitempty"`
+
 	// ABCI state pruning configuration.
 	Prune PruneConfig `yaml:"prune,omitempty"`
 
@@ -48,6 +52,9 @@ type Config struct {
 	// Consensus state sync configuration.
 	StateSync StateSyncConfig `yaml:"state_sync,omitempty"`
 
+	// Maintain a node-local index of staking events by account address.
+	AccountEventIndex bool `yaml:"account_event_index,omitempty"`
+
 	// Supplementary sanity checks configuration.
 	SupplementarySanity SupplementarySanityConfig `yaml:"supplementary_sanity,omitempty"`
 
@@ -85,6 +92,19 @@ type SubmissionConfig struct {
 	GasPrice uint64 `yaml:"gas_price"`
 	// Max transaction fee when submitting consensus transactions.
 	MaxFee uint64 `yaml:"max_fee"`
+	// Number of blocks after which a transaction that has not been included in a block is dropped
+	// from the local mempool (default: 0, transactions never expire).
+	MempoolTTLBlocks uint64 `yaml:"mempool_ttl_blocks,omitempty"`
+}
+
+// HaltExportConfig is the configuration of the state export performed when the node halts.
+type HaltExportConfig struct {
+	// Directory to which the exported genesis document is written (defaults to the exports
+	// directory within the node's data directory).
+	Path string `yaml:"path,omitempty"`
+	// Verify the exported genesis document by reading it back and comparing its hash, and write
+	// the hash next to the export so that operators can compare it.
+	Verify bool `yaml:"verify,omitempty"`
 }
 
 const (
@@ -112,6 +132,8 @@ type CheckpointerConfig struct {
 	Disabled bool `yaml:"disabled"`
 	// ABCI state checkpointer check interval.
 	CheckInterval time.Duration `yaml:"check_interval"`
+	// Address on which to publish ABCI state checkpoints over HTTP (disabled if empty).
+	HTTPAddress string `yaml:"http_address,omitempty"`
 }
 
 // StateSyncConfig is the consensus state sync configuration structure.
@@ -124,6 +146,8 @@ type StateSyncConfig struct {
 	TrustHeight uint64 `yaml:"trust_height"`
 	// Light client trusted consensus header hash.
 	TrustHash string `yaml:"trust_hash"`
+	// Base URLs of HTTP(S) snapshot sources to bootstrap from before falling back to P2P.
+	HTTPSources []string `yaml:"http_sources,omitempty"`
 }
 
 // SupplementarySanityConfig is the supplementary sanity configuration structure.
@@ -176,6 +200,16 @@ func (c *Config) Validate() error {
 			return fmt.Errorf("state sync enabled, but state_sync.trust_hash is not given")
 		}
 	}
+	for _, src := range c.StateSync.HTTPSources {
+		u, err := url.Parse(src)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("state_sync.http_sources contains a malformed URL: %s", src)
+		}
+	}
+
+	if c.Checkpointer.Disabled && c.Checkpointer.HTTPAddress != "" {
+		return fmt.Errorf("checkpointer.http_address set, but the checkpointer is disabled")
+	}
 
 	if c.SupplementarySanity.Enabled && c.SupplementarySanity.Interval < 1 {
 		return fmt.Errorf("supplementary_sanity.interval must be >= 1")
@@ -202,12 +236,17 @@ func DefaultConfig() Config {
 		SentryUpstreamAddresses: []string{},
 		MinGasPrice:             0,
 		Submission: SubmissionConfig{
-			GasPrice: 0,
-			MaxFee:   10_000_000_000,
+			GasPrice:         0,
+			MaxFee:           10_000_000_000,
+			MempoolTTLBlocks: 0,
 		},
 		HaltEpoch:        0,
 		HaltHeight:       0,
 		UpgradeStopDelay: 60 * time.Second,
+		HaltExport: HaltExportConfig{
+			Path:   "",
+			Verify: false,
+		},
 		Prune: PruneConfig{
 			Strategy:           PruneStrategyNone,
 			NumKept:            3600,
@@ -224,6 +263,7 @@ func DefaultConfig() Config {
 			TrustHeight: 0,
 			TrustHash:   "",
 		},
+		AccountEventIndex: false,
 		SupplementarySanity: SupplementarySanityConfig{
 			Enabled:  false,
 			Interval: 10,
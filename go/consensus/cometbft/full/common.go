@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/sha256"
 	"fmt"
+	"path/filepath"
 	"sync"
 	"sync/atomic"
 
@@ -33,6 +34,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/abci"
 	coreState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/abci/state"
 	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+	secretsState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/keymanager/secrets/state"
 	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/supplementarysanity"
 	tmbeacon "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/beacon"
 	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/common"
@@ -49,6 +51,7 @@ import (
 	genesisAPI "github.com/oasisprotocol/oasis-core/go/genesis/api"
 	governanceAPI "github.com/oasisprotocol/oasis-core/go/governance/api"
 	keymanagerAPI "github.com/oasisprotocol/oasis-core/go/keymanager/api"
+	"github.com/oasisprotocol/oasis-core/go/keymanager/churp"
 	cmbackground "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/background"
 	cmmetrics "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/metrics"
 	p2pAPI "github.com/oasisprotocol/oasis-core/go/p2p/api"
@@ -234,7 +237,7 @@ func (n *commonNode) initialize() error {
 	n.svcMgr.RegisterCleanupOnly(n.registry, "registry backend")
 
 	var scStaking tmstaking.ServiceClient
-	if scStaking, err = tmstaking.New(n.parentNode); err != nil {
+	if scStaking, err = tmstaking.New(n.parentNode, filepath.Join(n.dataDir, common.StateDir)); err != nil {
 		n.Logger.Error("staking: failed to initialize staking backend",
 			"err", err,
 		)
@@ -541,6 +544,15 @@ func (n *commonNode) heightToCometBFTHeight(height int64) (int64, error) {
 	return tmHeight, nil
 }
 
+// ensureBlockRetained returns an error in case the block at the given height has been pruned.
+func (n *commonNode) ensureBlockRetained(tmHeight int64) error {
+	base := store.LoadBlockStoreState(n.blockStoreDB).Base
+	if tmHeight < base {
+		return consensusAPI.NewHeightPrunedError(base)
+	}
+	return nil
+}
+
 // Implements consensusAPI.Backend.
 func (n *commonNode) GetSignerNonce(ctx context.Context, req *consensusAPI.GetSignerNonceRequest) (uint64, error) {
 	return n.mux.TransactionAuthHandler().GetSignerNonce(ctx, req)
@@ -562,6 +574,9 @@ func (n *commonNode) GetCometBFTBlock(ctx context.Context, height int64) (*cmtty
 	default:
 		return nil, err
 	}
+	if err = n.ensureBlockRetained(tmHeight); err != nil {
+		return nil, err
+	}
 	result, err := cmtcore.Block(n.rpcCtx, &tmHeight)
 	if err != nil {
 		return nil, fmt.Errorf("cometbft: block query failed: %w", err)
@@ -579,6 +594,9 @@ func (n *commonNode) GetBlockResults(ctx context.Context, height int64) (*cmtcor
 	if err != nil {
 		return nil, err
 	}
+	if err = n.ensureBlockRetained(tmHeight); err != nil {
+		return nil, err
+	}
 	result, err := cmtcore.BlockResults(n.rpcCtx, &tmHeight)
 	if err != nil {
 		return nil, fmt.Errorf("cometbft: block results query failed: %w", err)
@@ -619,6 +637,9 @@ func (n *commonNode) GetLightBlock(ctx context.Context, height int64) (*consensu
 	if err != nil {
 		return nil, err
 	}
+	if err = n.ensureBlockRetained(tmHeight); err != nil {
+		return nil, err
+	}
 
 	var lb cmttypes.LightBlock
 
@@ -801,6 +822,9 @@ func (n *commonNode) GetParameters(ctx context.Context, height int64) (*consensu
 	if err != nil {
 		return nil, err
 	}
+	if err = n.ensureBlockRetained(tmHeight); err != nil {
+		return nil, err
+	}
 	// Query consensus parameters directly from the state store, as fetching
 	// via n.client.ConsensusParameters also tries fetching latest uncommitted
 	// block which wont work with the archive node setup.
@@ -830,6 +854,90 @@ func (n *commonNode) GetParameters(ctx context.Context, height int64) (*consensu
 	}, nil
 }
 
+// Implements consensusAPI.Backend.
+func (n *commonNode) GetGasCosts(ctx context.Context, height int64) (*consensusAPI.GasCosts, error) {
+	if err := n.ensureStarted(ctx); err != nil {
+		return nil, err
+	}
+
+	// Resolve the height once so that all tables are queried at the same height.
+	tmHeight, err := n.heightToCometBFTHeight(height)
+	if err != nil {
+		return nil, err
+	}
+
+	cs, err := coreState.NewImmutableState(ctx, n.mux.State(), tmHeight)
+	if err != nil {
+		return nil, fmt.Errorf("cometbft: failed to initialize core consensus state: %w", err)
+	}
+	cp, err := cs.ConsensusParameters(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cometbft: failed to fetch core consensus parameters: %w", err)
+	}
+	gasCosts := &consensusAPI.GasCosts{
+		Height: tmHeight,
+		Modules: map[string]transaction.Costs{
+			consensusAPI.ModuleName: cp.GasCosts,
+		},
+	}
+
+	beaconParams, err := n.beacon.ConsensusParameters(ctx, tmHeight)
+	if err != nil {
+		return nil, fmt.Errorf("cometbft: failed to fetch beacon consensus parameters: %w", err)
+	}
+	if beaconParams.VRFParameters != nil {
+		gasCosts.Modules[beaconAPI.ModuleName] = beaconParams.VRFParameters.GasCosts
+	}
+
+	registryParams, err := n.registry.ConsensusParameters(ctx, tmHeight)
+	if err != nil {
+		return nil, fmt.Errorf("cometbft: failed to fetch registry consensus parameters: %w", err)
+	}
+	gasCosts.Modules[registryAPI.ModuleName] = registryParams.GasCosts
+
+	stakingParams, err := n.staking.ConsensusParameters(ctx, tmHeight)
+	if err != nil {
+		return nil, fmt.Errorf("cometbft: failed to fetch staking consensus parameters: %w", err)
+	}
+	gasCosts.Modules[stakingAPI.ModuleName] = stakingParams.GasCosts
+
+	roothashParams, err := n.roothash.ConsensusParameters(ctx, tmHeight)
+	if err != nil {
+		return nil, fmt.Errorf("cometbft: failed to fetch roothash consensus parameters: %w", err)
+	}
+	gasCosts.Modules[roothashAPI.ModuleName] = roothashParams.GasCosts
+
+	governanceParams, err := n.governance.ConsensusParameters(ctx, tmHeight)
+	if err != nil {
+		return nil, fmt.Errorf("cometbft: failed to fetch governance consensus parameters: %w", err)
+	}
+	gasCosts.Modules[governanceAPI.ModuleName] = governanceParams.GasCosts
+
+	vaultParams, err := n.vault.ConsensusParameters(ctx, tmHeight)
+	if err != nil {
+		return nil, fmt.Errorf("cometbft: failed to fetch vault consensus parameters: %w", err)
+	}
+	gasCosts.Modules[vaultAPI.ModuleName] = vaultParams.GasCosts
+
+	kmState, err := secretsState.NewImmutableState(ctx, n.mux.State(), tmHeight)
+	if err != nil {
+		return nil, fmt.Errorf("cometbft: failed to initialize key manager state: %w", err)
+	}
+	secretsParams, err := kmState.ConsensusParameters(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cometbft: failed to fetch key manager consensus parameters: %w", err)
+	}
+	gasCosts.Modules[keymanagerAPI.ModuleName] = secretsParams.GasCosts
+
+	churpParams, err := n.keymanager.Churp().ConsensusParameters(ctx, tmHeight)
+	if err != nil {
+		return nil, fmt.Errorf("cometbft: failed to fetch churp consensus parameters: %w", err)
+	}
+	gasCosts.Modules[churp.ModuleName] = churpParams.GasCosts
+
+	return gasCosts, nil
+}
+
 func (n *commonNode) SupportedFeatures() consensusAPI.FeatureMask {
 	return n.parentNode.SupportedFeatures()
 }
@@ -864,6 +972,11 @@ func (n *commonNode) GetStatus(ctx context.Context) (*consensusAPI.Status, error
 			lastRetainedHeight = n.genesis.Height
 		}
 		status.LastRetainedHeight = lastRetainedHeight
+		lastRetainedStateHeight, err := n.mux.State().LastRetainedVersion()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get last retained state height: %w", err)
+		}
+		status.LastRetainedStateHeight = max(lastRetainedStateHeight, n.genesis.Height)
 		lastRetainedBlock, err := n.GetBlock(ctx, lastRetainedHeight)
 		switch err {
 		case nil:
@@ -940,6 +1053,11 @@ func (n *commonNode) SubmitTxNoWait(context.Context, *transaction.SignedTransact
 	return consensusAPI.ErrUnsupported
 }
 
+// Implements consensusAPI.Backend.
+func (n *commonNode) SubmitTxNoWaitWithStatus(context.Context, *transaction.SignedTransaction) (*consensusAPI.SubmitTxStatus, error) {
+	return nil, consensusAPI.ErrUnsupported
+}
+
 // Implements consensusAPI.Backend.
 func (n *commonNode) SubmitTxWithProof(context.Context, *transaction.SignedTransaction) (*transaction.Proof, error) {
 	return nil, consensusAPI.ErrUnsupported
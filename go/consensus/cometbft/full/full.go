@@ -15,6 +15,7 @@ import (
 	dbm "github.com/cometbft/cometbft-db"
 	cmtabcitypes "github.com/cometbft/cometbft/abci/types"
 	cmtconfig "github.com/cometbft/cometbft/config"
+	cmtconsensus "github.com/cometbft/cometbft/consensus"
 	cmtpubsub "github.com/cometbft/cometbft/libs/pubsub"
 	cmtlight "github.com/cometbft/cometbft/light"
 	cmtmempool "github.com/cometbft/cometbft/mempool"
@@ -46,6 +47,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/crypto"
 	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/db"
 	lightAPI "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/light/api"
+	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/snapshots"
 	"github.com/oasisprotocol/oasis-core/go/consensus/metrics"
 	"github.com/oasisprotocol/oasis-core/go/consensus/pricediscovery"
 	genesisAPI "github.com/oasisprotocol/oasis-core/go/genesis/api"
@@ -90,6 +92,8 @@ type fullService struct { // nolint: maligned
 	blockNotifier *pubsub.Broker
 	failMonitor   *failMonitor
 
+	snapshotServer *snapshots.Server
+
 	submissionMgr consensusAPI.SubmissionManager
 
 	genesisProvider genesisAPI.Provider
@@ -120,6 +124,14 @@ func (t *fullService) Start() error {
 			return fmt.Errorf("cometbft: failed to start service: %w", err)
 		}
 
+		// Optionally publish snapshots over HTTP.
+		if addr := config.GlobalConfig.Consensus.Checkpointer.HTTPAddress; addr != "" {
+			t.snapshotServer = snapshots.NewServer(addr, t.mux.Mux())
+			if err := t.snapshotServer.Start(); err != nil {
+				return fmt.Errorf("cometbft: failed to start snapshot server: %w", err)
+			}
+		}
+
 		// Make sure the quit channel is closed when the node shuts down.
 		go func() {
 			select {
@@ -168,6 +180,9 @@ func (t *fullService) Stop() {
 
 	t.stopOnce.Do(func() {
 		t.failMonitor.markCleanShutdown()
+		if t.snapshotServer != nil {
+			t.snapshotServer.Stop()
+		}
 		if err := t.node.Stop(); err != nil {
 			t.Logger.Error("Error on stopping node", err)
 		}
@@ -196,7 +211,46 @@ func (t *fullService) SubmitTx(ctx context.Context, tx *transaction.SignedTransa
 
 // Implements consensusAPI.Backend.
 func (t *fullService) SubmitTxNoWait(_ context.Context, tx *transaction.SignedTransaction) error {
-	return t.broadcastTxRaw(cbor.Marshal(tx))
+	_, err := t.broadcastTxRaw(cbor.Marshal(tx))
+	return err
+}
+
+// Implements consensusAPI.Backend.
+func (t *fullService) SubmitTxNoWaitWithStatus(_ context.Context, tx *transaction.SignedTransaction) (*consensusAPI.SubmitTxStatus, error) {
+	data := cbor.Marshal(tx)
+	height := t.node.BlockStore().Height()
+	result, err := t.broadcastTxRaw(data)
+	if err != nil {
+		return nil, err
+	}
+
+	status := consensusAPI.SubmitTxStatus{
+		Height:    height,
+		Priority:  result.GetPriority(),
+		GasWanted: result.GetGasWanted(),
+	}
+
+	// Estimate the queue position based on the priority ordering of the local mempool. In case
+	// the transaction has already been removed from the mempool, it is placed at the end.
+	mp := t.node.Mempool()
+	txKey := cmttypes.Tx(data).Key()
+	mempoolTxs := mp.ReapMaxTxs(-1)
+	status.QueuePosition = uint64(len(mempoolTxs))
+	for i, mtx := range mempoolTxs {
+		if mtx.Key() == txKey {
+			status.QueuePosition = uint64(i)
+			break
+		}
+	}
+	status.QueueSize = uint64(mp.Size())
+
+	// The mempool removes transactions once more than the configured number of blocks has been
+	// committed since admission, so the last block that can still include it is one after that.
+	if ttl := config.GlobalConfig.Consensus.Submission.MempoolTTLBlocks; ttl > 0 {
+		status.ExpiryHeight = height + int64(ttl) + 1
+	}
+
+	return &status, nil
 }
 
 // Implements consensusAPI.Backend.
@@ -248,7 +302,7 @@ func (t *fullService) submitTx(ctx context.Context, tx *transaction.SignedTransa
 	defer recheckSub.Close()
 
 	// First try to broadcast.
-	if err := t.broadcastTxRaw(data); err != nil {
+	if _, err := t.broadcastTxRaw(data); err != nil {
 		return nil, err
 	}
 
@@ -269,7 +323,11 @@ func (t *fullService) submitTx(ctx context.Context, tx *transaction.SignedTransa
 	}
 }
 
-func (t *fullService) broadcastTxRaw(data []byte) error {
+func (t *fullService) broadcastTxRaw(data []byte) (*cmtabcitypes.ResponseCheckTx, error) {
+	if config.GlobalConfig.Common.ReadOnly {
+		return nil, consensusAPI.ErrReadOnly
+	}
+
 	// We could use t.client.BroadcastTxSync but that is annoying as it
 	// doesn't give you the right fields when CheckTx fails.
 	mp := t.node.Mempool()
@@ -284,17 +342,18 @@ func (t *fullService) broadcastTxRaw(data []byte) error {
 	case nil:
 	case cmtmempool.ErrTxInCache:
 		// Transaction already in the mempool or was recently there.
-		return consensusAPI.ErrDuplicateTx
+		return nil, consensusAPI.ErrDuplicateTx
 	default:
-		return fmt.Errorf("cometbft: failed to submit to local mempool: %w", err)
+		return nil, fmt.Errorf("cometbft: failed to submit to local mempool: %w", err)
 	}
 
 	rsp := <-ch
-	if result := rsp.GetCheckTx(); !result.IsOK() {
-		return errors.FromCode(result.GetCodespace(), result.GetCode(), result.GetLog())
+	result := rsp.GetCheckTx()
+	if !result.IsOK() {
+		return nil, errors.FromCode(result.GetCodespace(), result.GetCode(), result.GetLog())
 	}
 
-	return nil
+	return result, nil
 }
 
 func (t *fullService) newSubscriberID() string {
@@ -303,6 +362,10 @@ func (t *fullService) newSubscriberID() string {
 
 // Implements consensusAPI.Backend.
 func (t *fullService) SubmitEvidence(ctx context.Context, evidence *consensusAPI.Evidence) error {
+	if config.GlobalConfig.Common.ReadOnly {
+		return consensusAPI.ErrReadOnly
+	}
+
 	var protoEv cmtproto.Evidence
 	if err := protoEv.Unmarshal(evidence.Meta); err != nil {
 		return fmt.Errorf("cometbft: malformed evidence while unmarshalling: %w", err)
@@ -410,18 +473,46 @@ func (t *fullService) GetStatus(ctx context.Context) (*consensusAPI.Status, erro
 		// List of consensus peers.
 		tmpeers := t.node.Switch().Peers().List()
 		peers := make([]string, 0, len(tmpeers))
+		peerDetails := make([]consensusAPI.PeerStatus, 0, len(tmpeers))
 		for _, tmpeer := range tmpeers {
 			p := string(tmpeer.ID()) + "@" + tmpeer.RemoteAddr().String()
 			peers = append(peers, p)
+			peerDetails = append(peerDetails, peerStatus(tmpeer))
 		}
 
 		status.P2P.Peers = peers
+		status.P2P.PeerDetails = peerDetails
 		status.P2P.PeerID = string(t.node.NodeInfo().ID())
 	}
 
 	return status, nil
 }
 
+// peerStatus returns the diagnostic status of the given consensus peer.
+func peerStatus(peer cmtp2p.Peer) consensusAPI.PeerStatus {
+	connStatus := peer.Status()
+	ps := consensusAPI.PeerStatus{
+		ID:           string(peer.ID()),
+		Address:      peer.RemoteAddr().String(),
+		IsOutbound:   peer.IsOutbound(),
+		ConnectedFor: connStatus.Duration,
+		SendRate:     connStatus.SendMonitor.CurRate,
+		RecvRate:     connStatus.RecvMonitor.CurRate,
+		SendIdle:     connStatus.SendMonitor.Idle,
+		RecvIdle:     connStatus.RecvMonitor.Idle,
+		RTT:          peerRTT(peer),
+	}
+
+	// The consensus reactor keeps track of the peer's reported round state.
+	if peerState, ok := peer.Get(cmttypes.PeerStateKey).(*cmtconsensus.PeerState); ok {
+		prs := peerState.GetRoundState()
+		ps.Height = prs.Height
+		ps.Round = prs.Round
+	}
+
+	return ps
+}
+
 // Implements consensusAPI.Backend.
 func (t *fullService) GetNextBlockState(ctx context.Context) (*consensusAPI.NextBlockState, error) {
 	if !t.started() {
@@ -584,6 +675,7 @@ func (t *fullService) lazyInit() error { // nolint: gocyclo
 	cometConfig.Consensus.CreateEmptyBlocksInterval = emptyBlockInterval
 	cometConfig.Consensus.DebugUnsafeReplayRecoverCorruptedWAL = config.GlobalConfig.Consensus.Debug.UnsafeReplayRecoverCorruptedWAL && cmflags.DebugDontBlameOasis()
 	cometConfig.Mempool.Version = cmtconfig.MempoolV1
+	cometConfig.Mempool.TTLNumBlocks = int64(config.GlobalConfig.Consensus.Submission.MempoolTTLBlocks)
 	cometConfig.Instrumentation.Prometheus = true
 	cometConfig.Instrumentation.PrometheusListenAddr = ""
 	cometConfig.TxIndex.Indexer = "null"
@@ -628,9 +720,16 @@ func (t *fullService) lazyInit() error { // nolint: gocyclo
 		)
 	}
 
-	cometbftPV, err := crypto.LoadOrGeneratePrivVal(cometbftDataDir, t.identity.ConsensusSigner)
-	if err != nil {
-		return err
+	var cometbftPV cmttypes.PrivValidator
+	if config.GlobalConfig.Common.ReadOnly {
+		// Never sign with the node's consensus key in read-only mode as the data directory
+		// may be a copy of the one used by an active validator.
+		cometbftPV = cmttypes.NewMockPV()
+	} else {
+		cometbftPV, err = crypto.LoadOrGeneratePrivVal(cometbftDataDir, t.identity.ConsensusSigner)
+		if err != nil {
+			return err
+		}
 	}
 
 	tmGenDoc, err := api.GetCometBFTGenesisDocument(t.genesisProvider)
@@ -728,6 +827,16 @@ func (t *fullService) lazyInit() error { // nolint: gocyclo
 				)
 				return fmt.Errorf("failed to create state sync state provider: %w", err)
 			}
+
+			// Optionally bootstrap from snapshots published over HTTP, falling back to P2P.
+			if len(config.GlobalConfig.Consensus.StateSync.HTTPSources) > 0 {
+				if err = t.bootstrapFromHTTP(cometConfig, dbProvider, stateProvider); err != nil {
+					t.Logger.Error("failed to bootstrap from HTTP snapshot sources",
+						"err", err,
+					)
+					return fmt.Errorf("failed to bootstrap from HTTP snapshot sources: %w", err)
+				}
+			}
 		}
 
 		t.node, err = cmtnode.NewNode(cometConfig,
@@ -739,6 +848,9 @@ func (t *fullService) lazyInit() error { // nolint: gocyclo
 			cmtnode.DefaultMetricsProvider(cometConfig.Instrumentation),
 			tmcommon.NewLogAdapter(!config.GlobalConfig.Consensus.LogDebug),
 			cmtnode.StateProvider(stateProvider),
+			cmtnode.CustomReactors(map[string]cmtp2p.Reactor{
+				latencyReactorName: newLatencyReactor(t.Logger),
+			}),
 		)
 		if err != nil {
 			return fmt.Errorf("cometbft: failed to create node: %w", err)
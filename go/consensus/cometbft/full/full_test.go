@@ -0,0 +1,42 @@
+package full
+
+import (
+	"net"
+	"testing"
+
+	cmtconsensus "github.com/cometbft/cometbft/consensus"
+	"github.com/cometbft/cometbft/p2p/mock"
+	cmttypes "github.com/cometbft/cometbft/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeerStatus(t *testing.T) {
+	require := require.New(t)
+
+	peer := mock.NewPeer(net.IPv4(127, 0, 0, 1))
+	peer.Outbound = true
+
+	// Peers without any reported state should still be mapped.
+	ps := peerStatus(peer)
+	require.Equal(string(peer.ID()), ps.ID)
+	require.Equal(peer.RemoteAddr().String(), ps.Address)
+	require.True(ps.IsOutbound)
+	require.Zero(ps.Height)
+	require.Zero(ps.Round)
+	require.Zero(ps.RTT)
+
+	// Round state reported via the consensus reactor and the measured RTT should be included.
+	peerState := cmtconsensus.NewPeerState(peer)
+	peerState.PRS.Height = 42
+	peerState.PRS.Round = 3
+	peer.Set(cmttypes.PeerStateKey, peerState)
+	pl := &peerLatency{}
+	pl.pong(pl.ping())
+	peer.Set(latencyPeerKey, pl)
+
+	ps = peerStatus(peer)
+	require.EqualValues(42, ps.Height)
+	require.EqualValues(3, ps.Round)
+	require.Equal(pl.RTT(), ps.RTT)
+	require.Positive(ps.RTT)
+}
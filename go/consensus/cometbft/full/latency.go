@@ -0,0 +1,185 @@
+package full
+
+import (
+	"encoding/binary"
+	"sync"
+	"time"
+
+	cmtp2p "github.com/cometbft/cometbft/p2p"
+	cmtconn "github.com/cometbft/cometbft/p2p/conn"
+	gogotypes "github.com/cosmos/gogoproto/types"
+
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+)
+
+const (
+	// latencyReactorName is the name of the peer latency reactor.
+	latencyReactorName = "OASIS_LATENCY"
+	// latencyChannel is the CometBFT P2P channel used for measuring peer round-trip latency.
+	latencyChannel = byte(0x70)
+	// latencyPeerKey is the peer data key under which the peer's latency tracker is stored.
+	latencyPeerKey = "oasis/PeerLatency"
+	// latencyPingInterval is the interval between pings sent to each peer.
+	latencyPingInterval = 30 * time.Second
+
+	latencyMsgPing = byte(0x00)
+	latencyMsgPong = byte(0x01)
+	latencyMsgSize = 9
+)
+
+// peerLatency tracks the round-trip latency to a single peer.
+type peerLatency struct {
+	sync.Mutex
+
+	nonce  uint64
+	sentAt time.Time
+	rtt    time.Duration
+}
+
+// ping records that a new ping is being sent and returns its nonce.
+func (pl *peerLatency) ping() uint64 {
+	pl.Lock()
+	defer pl.Unlock()
+
+	pl.nonce++
+	pl.sentAt = time.Now()
+	return pl.nonce
+}
+
+// pong records the receipt of a pong for the ping with the given nonce.
+func (pl *peerLatency) pong(nonce uint64) bool {
+	pl.Lock()
+	defer pl.Unlock()
+
+	if pl.sentAt.IsZero() || nonce != pl.nonce {
+		return false
+	}
+	pl.rtt = time.Since(pl.sentAt)
+	pl.sentAt = time.Time{}
+	return true
+}
+
+// RTT returns the most recently measured round-trip time to the peer.
+func (pl *peerLatency) RTT() time.Duration {
+	pl.Lock()
+	defer pl.Unlock()
+
+	return pl.rtt
+}
+
+// peerRTT returns the most recently measured round-trip time to the given peer, or zero in case
+// it has not been measured yet.
+func peerRTT(peer cmtp2p.Peer) time.Duration {
+	pl, ok := peer.Get(latencyPeerKey).(*peerLatency)
+	if !ok {
+		return 0
+	}
+	return pl.RTT()
+}
+
+func encodeLatencyMsg(kind byte, nonce uint64) *gogotypes.BytesValue {
+	value := make([]byte, latencyMsgSize)
+	value[0] = kind
+	binary.BigEndian.PutUint64(value[1:], nonce)
+	return &gogotypes.BytesValue{Value: value}
+}
+
+// latencyReactor is a CometBFT reactor that periodically pings peers to measure round-trip
+// latency.
+//
+// Peers that do not support the latency channel are never pinged, so their latency is simply
+// not reported.
+type latencyReactor struct {
+	cmtp2p.BaseReactor
+
+	pingInterval time.Duration
+
+	logger *logging.Logger
+}
+
+// GetChannels implements cmtp2p.Reactor.
+func (r *latencyReactor) GetChannels() []*cmtconn.ChannelDescriptor {
+	return []*cmtconn.ChannelDescriptor{
+		{
+			ID:                  latencyChannel,
+			Priority:            1,
+			SendQueueCapacity:   1,
+			RecvMessageCapacity: 2 * latencyMsgSize,
+			MessageType:         &gogotypes.BytesValue{},
+		},
+	}
+}
+
+// InitPeer implements cmtp2p.Reactor.
+func (r *latencyReactor) InitPeer(peer cmtp2p.Peer) cmtp2p.Peer {
+	peer.Set(latencyPeerKey, &peerLatency{})
+	return peer
+}
+
+// AddPeer implements cmtp2p.Reactor.
+func (r *latencyReactor) AddPeer(peer cmtp2p.Peer) {
+	go r.pingPeer(peer)
+}
+
+func (r *latencyReactor) pingPeer(peer cmtp2p.Peer) {
+	pl, ok := peer.Get(latencyPeerKey).(*peerLatency)
+	if !ok {
+		return
+	}
+
+	ticker := time.NewTicker(r.pingInterval)
+	defer ticker.Stop()
+
+	for {
+		peer.TrySendEnvelope(cmtp2p.Envelope{
+			ChannelID: latencyChannel,
+			Message:   encodeLatencyMsg(latencyMsgPing, pl.ping()),
+		})
+
+		select {
+		case <-ticker.C:
+		case <-peer.Quit():
+			return
+		case <-r.Quit():
+			return
+		}
+	}
+}
+
+// ReceiveEnvelope implements cmtp2p.Reactor.
+func (r *latencyReactor) ReceiveEnvelope(e cmtp2p.Envelope) {
+	msg, ok := e.Message.(*gogotypes.BytesValue)
+	if !ok || len(msg.Value) != latencyMsgSize {
+		r.logger.Debug("ignoring malformed latency message",
+			"peer_id", e.Src.ID(),
+		)
+		return
+	}
+	nonce := binary.BigEndian.Uint64(msg.Value[1:])
+
+	switch msg.Value[0] {
+	case latencyMsgPing:
+		e.Src.TrySendEnvelope(cmtp2p.Envelope{
+			ChannelID: latencyChannel,
+			Message:   encodeLatencyMsg(latencyMsgPong, nonce),
+		})
+	case latencyMsgPong:
+		if pl, ok := e.Src.Get(latencyPeerKey).(*peerLatency); ok {
+			pl.pong(nonce)
+		}
+	default:
+		r.logger.Debug("ignoring unknown latency message",
+			"peer_id", e.Src.ID(),
+			"kind", msg.Value[0],
+		)
+	}
+}
+
+func newLatencyReactor(logger *logging.Logger) *latencyReactor {
+	r := &latencyReactor{
+		pingInterval: latencyPingInterval,
+		logger:       logger,
+	}
+	r.BaseReactor = *cmtp2p.NewBaseReactor("OasisLatency", r)
+	return r
+}
@@ -0,0 +1,78 @@
+package full
+
+import (
+	"testing"
+	"time"
+
+	cmtp2p "github.com/cometbft/cometbft/p2p"
+	"github.com/cometbft/cometbft/p2p/mock"
+	gogotypes "github.com/cosmos/gogoproto/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+)
+
+type recordingPeer struct {
+	*mock.Peer
+
+	sent []cmtp2p.Envelope
+}
+
+func (p *recordingPeer) TrySendEnvelope(e cmtp2p.Envelope) bool {
+	p.sent = append(p.sent, e)
+	return true
+}
+
+func TestLatencyReactor(t *testing.T) {
+	require := require.New(t)
+
+	r := newLatencyReactor(logging.GetLogger("consensus/cometbft/full/latency_test"))
+	peer := &recordingPeer{Peer: mock.NewPeer(nil)}
+	r.InitPeer(peer)
+	require.Zero(peerRTT(peer), "RTT should not be measured initially")
+
+	// Pings should be answered with a pong carrying the same nonce.
+	r.ReceiveEnvelope(cmtp2p.Envelope{
+		Src:       peer,
+		ChannelID: latencyChannel,
+		Message:   encodeLatencyMsg(latencyMsgPing, 42),
+	})
+	require.Len(peer.sent, 1, "pong should be sent")
+	require.Equal(latencyChannel, peer.sent[0].ChannelID)
+	require.Equal(encodeLatencyMsg(latencyMsgPong, 42), peer.sent[0].Message)
+
+	pl := peer.Get(latencyPeerKey).(*peerLatency)
+	nonce := pl.ping()
+	time.Sleep(time.Millisecond)
+
+	// Pongs with a stale nonce and malformed messages should be ignored.
+	r.ReceiveEnvelope(cmtp2p.Envelope{
+		Src:       peer,
+		ChannelID: latencyChannel,
+		Message:   encodeLatencyMsg(latencyMsgPong, nonce-1),
+	})
+	r.ReceiveEnvelope(cmtp2p.Envelope{
+		Src:       peer,
+		ChannelID: latencyChannel,
+		Message:   &gogotypes.BytesValue{Value: []byte{latencyMsgPong}},
+	})
+	require.Zero(peerRTT(peer), "RTT should not be measured from invalid pongs")
+
+	// A matching pong should update the RTT.
+	r.ReceiveEnvelope(cmtp2p.Envelope{
+		Src:       peer,
+		ChannelID: latencyChannel,
+		Message:   encodeLatencyMsg(latencyMsgPong, nonce),
+	})
+	rtt := peerRTT(peer)
+	require.GreaterOrEqual(rtt, time.Millisecond, "RTT should be measured")
+
+	// Duplicate pongs should not update the RTT.
+	time.Sleep(time.Millisecond)
+	r.ReceiveEnvelope(cmtp2p.Envelope{
+		Src:       peer,
+		ChannelID: latencyChannel,
+		Message:   encodeLatencyMsg(latencyMsgPong, nonce),
+	})
+	require.Equal(rtt, peerRTT(peer), "duplicate pong should be ignored")
+}
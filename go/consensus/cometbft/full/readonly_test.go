@@ -0,0 +1,31 @@
+package full
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/config"
+	consensusAPI "github.com/oasisprotocol/oasis-core/go/consensus/api"
+)
+
+func TestReadOnly(t *testing.T) {
+	require := require.New(t)
+
+	config.GlobalConfig.Common.ReadOnly = true
+	defer func() {
+		config.GlobalConfig.Common.ReadOnly = false
+	}()
+
+	// The checks must happen before the node is touched, so an uninitialized service suffices.
+	t.Run("BroadcastTx", func(_ *testing.T) {
+		_, err := (&fullService{}).broadcastTxRaw([]byte("tx"))
+		require.ErrorIs(err, consensusAPI.ErrReadOnly)
+	})
+
+	t.Run("SubmitEvidence", func(_ *testing.T) {
+		err := (&fullService{}).SubmitEvidence(context.Background(), &consensusAPI.Evidence{})
+		require.ErrorIs(err, consensusAPI.ErrReadOnly)
+	})
+}
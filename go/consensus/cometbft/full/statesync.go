@@ -2,20 +2,32 @@ package full
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"path/filepath"
 	"sync"
 
+	cmtconfig "github.com/cometbft/cometbft/config"
+	cmtnode "github.com/cometbft/cometbft/node"
 	cmtstate "github.com/cometbft/cometbft/state"
 	cmtstatesync "github.com/cometbft/cometbft/statesync"
+	cmtstore "github.com/cometbft/cometbft/store"
 	cmttypes "github.com/cometbft/cometbft/types"
 
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/version"
+	"github.com/oasisprotocol/oasis-core/go/config"
+	tmcommon "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/common"
 	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/light"
 	lightAPI "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/light/api"
+	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/snapshots"
 	"github.com/oasisprotocol/oasis-core/go/p2p/rpc"
 )
 
+// httpSnapshotsDir is the directory (relative to the CometBFT state directory) used to store
+// chunks of snapshots fetched over HTTP.
+const httpSnapshotsDir = "http-snapshots"
+
 type stateProvider struct {
 	sync.Mutex
 
@@ -118,3 +130,75 @@ func newStateProvider(ctx context.Context, chainContext string, cfg lightAPI.Cli
 		logger:          logging.GetLogger("consensus/cometbft/stateprovider"),
 	}, nil
 }
+
+// bootstrapFromHTTP restores the consensus state from a snapshot published over HTTP and
+// bootstraps the CometBFT stores so that the node continues with block sync after the snapshot.
+//
+// In case the node already has local state or none of the sources publishes an acceptable
+// snapshot, this is a no-op and regular P2P state sync is used instead.
+func (t *fullService) bootstrapFromHTTP(cometConfig *cmtconfig.Config, dbProvider cmtnode.DBProvider, sp cmtstatesync.StateProvider) error {
+	stateDB, err := dbProvider(&cmtnode.DBContext{ID: "state", Config: cometConfig})
+	if err != nil {
+		return fmt.Errorf("failed to open state database: %w", err)
+	}
+	defer stateDB.Close()
+	blockStoreDB, err := dbProvider(&cmtnode.DBContext{ID: "blockstore", Config: cometConfig})
+	if err != nil {
+		return fmt.Errorf("failed to open block store database: %w", err)
+	}
+	defer blockStoreDB.Close()
+
+	stateStore := cmtstate.NewBootstrapStore(stateDB, cmtstate.StoreOptions{
+		DiscardABCIResponses: cometConfig.Storage.DiscardABCIResponses,
+	})
+	blockStore := cmtstore.NewBlockStore(blockStoreDB)
+
+	state, err := stateStore.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load state: %w", err)
+	}
+	if !state.IsEmpty() || !blockStore.IsEmpty() {
+		t.Logger.Info("local state present, skipping state sync from HTTP snapshot sources")
+		return nil
+	}
+
+	fetcher, err := snapshots.NewFetcher(
+		config.GlobalConfig.Consensus.StateSync.HTTPSources,
+		filepath.Join(t.dataDir, tmcommon.StateDir, httpSnapshotsDir),
+	)
+	if err != nil {
+		return err
+	}
+	snapshot, err := fetcher.Restore(t.ctx, t.mux.Mux(), sp.AppHash)
+	switch {
+	case err == nil:
+	case errors.Is(err, snapshots.ErrNoSnapshots):
+		t.Logger.Warn("no acceptable snapshots published over HTTP, falling back to P2P state sync")
+		return nil
+	default:
+		return err
+	}
+
+	if state, err = sp.State(t.ctx, snapshot.Height); err != nil {
+		return fmt.Errorf("failed to fetch state for height %d: %w", snapshot.Height, err)
+	}
+	commit, err := sp.Commit(t.ctx, snapshot.Height)
+	if err != nil {
+		return fmt.Errorf("failed to fetch commit for height %d: %w", snapshot.Height, err)
+	}
+	if err = stateStore.Bootstrap(state); err != nil {
+		return fmt.Errorf("failed to bootstrap state: %w", err)
+	}
+	if err = blockStore.SaveSeenCommit(state.LastBlockHeight, commit); err != nil {
+		return fmt.Errorf("failed to save commit: %w", err)
+	}
+	if err = stateStore.SetOfflineStateSyncHeight(state.LastBlockHeight); err != nil {
+		return fmt.Errorf("failed to set state sync height: %w", err)
+	}
+
+	t.Logger.Info("bootstrapped state from HTTP snapshot",
+		"height", snapshot.Height,
+	)
+
+	return nil
+}
@@ -35,6 +35,22 @@ func (sc *ServiceClient) GetStatus(ctx context.Context, query *registry.Namespac
 	return q.Secrets().Status(ctx, query.ID)
 }
 
+func (sc *ServiceClient) GetAuthorizedRuntimes(ctx context.Context, query *registry.NamespaceQuery) (*secrets.AuthorizedRuntimes, error) {
+	status, err := sc.GetStatus(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	if status.Policy == nil {
+		return nil, secrets.ErrNoSuchPolicy
+	}
+
+	return &secrets.AuthorizedRuntimes{
+		ID:           status.ID,
+		PolicySerial: status.Policy.Policy.Serial,
+		Runtimes:     status.Policy.Policy.AuthorizedRuntimes(),
+	}, nil
+}
+
 func (sc *ServiceClient) GetStatuses(ctx context.Context, height int64) ([]*secrets.Status, error) {
 	q, err := sc.querier.QueryAt(ctx, height)
 	if err != nil {
@@ -98,6 +98,15 @@ func (sc *serviceClient) GetNodeStatus(ctx context.Context, query *api.IDQuery)
 	return q.NodeStatus(ctx, query.ID)
 }
 
+func (sc *serviceClient) GetNodeTombstone(ctx context.Context, query *api.IDQuery) (*api.NodeTombstone, error) {
+	q, err := sc.querier.QueryAt(ctx, query.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.NodeTombstone(ctx, query.ID)
+}
+
 func (sc *serviceClient) GetNodes(ctx context.Context, height int64) ([]*node.Node, error) {
 	q, err := sc.querier.QueryAt(ctx, height)
 	if err != nil {
@@ -107,6 +116,15 @@ func (sc *serviceClient) GetNodes(ctx context.Context, height int64) ([]*node.No
 	return q.Nodes(ctx)
 }
 
+func (sc *serviceClient) GetFilteredNodes(ctx context.Context, query *api.GetNodesQuery) (*api.NodesPage, error) {
+	q, err := sc.querier.QueryAt(ctx, query.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.FilteredNodes(ctx, query)
+}
+
 func (sc *serviceClient) GetNodeByConsensusAddress(ctx context.Context, query *api.ConsensusAddressQuery) (*node.Node, error) {
 	q, err := sc.querier.QueryAt(ctx, query.Height)
 	if err != nil {
@@ -132,6 +150,58 @@ func (sc *serviceClient) WatchNodeList(context.Context) (<-chan *api.NodeList, p
 	return typedCh, sub, nil
 }
 
+func (sc *serviceClient) WatchFilteredNodes(ctx context.Context, filter *api.NodeFilter) (<-chan *api.NodeEvent, pubsub.ClosableSubscription, error) {
+	ch, sub, err := sc.WatchNodes(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	filteredCh := make(chan *api.NodeEvent)
+	go func() {
+		defer close(filteredCh)
+
+		for ev := range ch {
+			if !filter.Matches(ev.Node) {
+				continue
+			}
+
+			select {
+			case filteredCh <- ev:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return filteredCh, sub, nil
+}
+
+func (sc *serviceClient) WatchFilteredNodeList(ctx context.Context, filter *api.NodeFilter) (<-chan *api.NodeList, pubsub.ClosableSubscription, error) {
+	ch, sub, err := sc.WatchNodeList(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	filteredCh := make(chan *api.NodeList)
+	go func() {
+		defer close(filteredCh)
+
+		for nl := range ch {
+			filtered := &api.NodeList{
+				Nodes: api.FilterNodes(nl.Nodes, filter),
+			}
+
+			select {
+			case filteredCh <- filtered:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return filteredCh, sub, nil
+}
+
 func (sc *serviceClient) GetRuntime(ctx context.Context, query *api.GetRuntimeQuery) (*api.Runtime, error) {
 	q, err := sc.querier.QueryAt(ctx, query.Height)
 	if err != nil {
@@ -141,6 +211,15 @@ func (sc *serviceClient) GetRuntime(ctx context.Context, query *api.GetRuntimeQu
 	return q.Runtime(ctx, query.ID, query.IncludeSuspended)
 }
 
+func (sc *serviceClient) ValidateRuntime(ctx context.Context, rt *api.Runtime) error {
+	q, err := sc.querier.QueryAt(ctx, consensus.HeightLatest)
+	if err != nil {
+		return err
+	}
+
+	return q.ValidateRuntime(ctx, rt)
+}
+
 func (sc *serviceClient) WatchRuntimes(_ context.Context) (<-chan *api.Runtime, pubsub.ClosableSubscription, error) {
 	typedCh := make(chan *api.Runtime)
 	sub := sc.runtimeNotifier.Subscribe()
@@ -328,6 +407,15 @@ func EventsFromCometBFT(
 				}
 
 				events = append(events, &api.Event{Height: height, TxHash: txHash, RuntimeSuspendedEvent: &e})
+			case eventsAPI.IsAttributeKind(key, &api.RuntimeDeploymentWithdrawnEvent{}):
+				// Runtime deployment withdrawn event.
+				var e api.RuntimeDeploymentWithdrawnEvent
+				if err := eventsAPI.DecodeValue(val, &e); err != nil {
+					errs = errors.Join(errs, fmt.Errorf("registry: corrupt RuntimeDeploymentWithdrawn event: %w", err))
+					continue
+				}
+
+				events = append(events, &api.Event{Height: height, TxHash: txHash, RuntimeDeploymentWithdrawnEvent: &e})
 			case eventsAPI.IsAttributeKind(key, &api.EntityEvent{}):
 				// Entity event.
 				var e api.EntityEvent
@@ -346,6 +434,15 @@ func EventsFromCometBFT(
 				}
 
 				events = append(events, &api.Event{Height: height, TxHash: txHash, NodeEvent: &e})
+			case eventsAPI.IsAttributeKind(key, &api.NodeFrozenEvent{}):
+				// Node frozen event.
+				var e api.NodeFrozenEvent
+				if err := eventsAPI.DecodeValue(val, &e); err != nil {
+					errs = errors.Join(errs, fmt.Errorf("registry: corrupt NodeFrozen event: %w", err))
+					continue
+				}
+
+				events = append(events, &api.Event{Height: height, TxHash: txHash, NodeFrozenEvent: &e})
 			case eventsAPI.IsAttributeKind(key, &api.NodeUnfrozenEvent{}):
 				// Node unfrozen event.
 				var e api.NodeUnfrozenEvent
@@ -354,6 +451,22 @@ func EventsFromCometBFT(
 					continue
 				}
 				events = append(events, &api.Event{Height: height, TxHash: txHash, NodeUnfrozenEvent: &e})
+			case eventsAPI.IsAttributeKind(key, &api.NodeExpiredEvent{}):
+				// Node expired event.
+				var e api.NodeExpiredEvent
+				if err := eventsAPI.DecodeValue(val, &e); err != nil {
+					errs = errors.Join(errs, fmt.Errorf("registry: corrupt NodeExpired event: %w", err))
+					continue
+				}
+				events = append(events, &api.Event{Height: height, TxHash: txHash, NodeExpiredEvent: &e})
+			case eventsAPI.IsAttributeKind(key, &api.ValidatorElectionOptOutEvent{}):
+				// Validator election opt-out event.
+				var e api.ValidatorElectionOptOutEvent
+				if err := eventsAPI.DecodeValue(val, &e); err != nil {
+					errs = errors.Join(errs, fmt.Errorf("registry: corrupt ValidatorElectionOptOut event: %w", err))
+					continue
+				}
+				events = append(events, &api.Event{Height: height, TxHash: txHash, ValidatorElectionOptOutEvent: &e})
 			}
 		}
 	}
@@ -29,7 +29,15 @@ import (
 	runtimeRegistry "github.com/oasisprotocol/oasis-core/go/runtime/registry"
 )
 
-const crashPointBlockBeforeIndex = "roothash.before_index"
+const (
+	crashPointBlockBeforeIndex = "roothash.before_index"
+
+	// reindexBatchSize is the number of consensus heights that are fetched in parallel during
+	// reindexing before the fetched blocks are committed and the progress is persisted.
+	reindexBatchSize = 1000
+	// reindexMaxWorkers is the maximum number of workers fetching heights during reindexing.
+	reindexMaxWorkers = 16
+)
 
 // ServiceClient is the roothash service client interface.
 type ServiceClient interface {
@@ -42,6 +50,7 @@ type runtimeBrokers struct {
 
 	blockNotifier *pubsub.Broker
 	eventNotifier *pubsub.Broker
+	inMsgNotifier *pubsub.Broker
 	ecNotifier    *pubsub.Broker
 
 	lastBlockHeight int64
@@ -145,6 +154,26 @@ func (sc *serviceClient) GetLastRoundResults(ctx context.Context, request *api.R
 	return q.LastRoundResults(ctx, request.RuntimeID)
 }
 
+// Implements api.Backend.
+func (sc *serviceClient) GetLastRoundMetadata(ctx context.Context, request *api.RuntimeRequest) (*api.RoundMetadata, error) {
+	q, err := sc.querier.QueryAt(ctx, request.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.LastRoundMetadata(ctx, request.RuntimeID)
+}
+
+// Implements api.Backend.
+func (sc *serviceClient) GetLivenessStatistics(ctx context.Context, request *api.RuntimeRequest) (*api.RuntimeLivenessStatistics, error) {
+	q, err := sc.querier.QueryAt(ctx, request.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.LivenessStatistics(ctx, request.RuntimeID)
+}
+
 func (sc *serviceClient) GetRoundRoots(ctx context.Context, request *api.RoundRootsRequest) (*api.RoundRoots, error) {
 	q, err := sc.querier.QueryAt(ctx, request.Height)
 	if err != nil {
@@ -183,6 +212,16 @@ func (sc *serviceClient) GetIncomingMessageQueue(ctx context.Context, request *a
 	return q.IncomingMessageQueue(ctx, request.RuntimeID, request.Offset, request.Limit)
 }
 
+// Implements api.Backend.
+func (sc *serviceClient) GetCheckpointManifests(ctx context.Context, request *api.RuntimeRequest) ([]*api.CheckpointManifest, error) {
+	q, err := sc.querier.QueryAt(ctx, request.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.CheckpointManifests(ctx, request.RuntimeID)
+}
+
 // Implements api.Backend.
 func (sc *serviceClient) WatchBlocks(_ context.Context, id common.Namespace) (<-chan *api.AnnotatedBlock, pubsub.ClosableSubscription, error) {
 	notifiers := sc.getRuntimeNotifiers(id)
@@ -256,6 +295,22 @@ func (sc *serviceClient) WatchEvents(_ context.Context, id common.Namespace) (<-
 	return ch, sub, nil
 }
 
+// Implements api.Backend.
+func (sc *serviceClient) WatchIncomingMessages(_ context.Context, id common.Namespace) (<-chan *api.InMsgQueuedEvent, pubsub.ClosableSubscription, error) {
+	notifiers := sc.getRuntimeNotifiers(id)
+	sub := notifiers.inMsgNotifier.Subscribe()
+	ch := make(chan *api.InMsgQueuedEvent)
+	sub.Unwrap(ch)
+
+	// Start tracking this runtime if we are not tracking it yet.
+	if err := sc.trackRuntime(sc.ctx, id, nil); err != nil {
+		sub.Close()
+		return nil, nil, err
+	}
+
+	return ch, sub, nil
+}
+
 // Implements api.Backend.
 func (sc *serviceClient) WatchExecutorCommitments(_ context.Context, id common.Namespace) (<-chan *commitment.ExecutorCommitment, pubsub.ClosableSubscription, error) {
 	notifiers := sc.getRuntimeNotifiers(id)
@@ -379,6 +434,7 @@ func (sc *serviceClient) getRuntimeNotifiers(id common.Namespace) *runtimeBroker
 		notifiers = &runtimeBrokers{
 			blockNotifier: pubsub.NewBroker(false),
 			eventNotifier: pubsub.NewBroker(false),
+			inMsgNotifier: pubsub.NewBroker(false),
 			ecNotifier:    pubsub.NewBroker(false),
 		}
 		sc.runtimeNotifiers[id] = notifiers
@@ -387,6 +443,150 @@ func (sc *serviceClient) getRuntimeNotifiers(id common.Namespace) *runtimeBroker
 	return notifiers
 }
 
+// reindexedBlock is a runtime block fetched during reindexing.
+type reindexedBlock struct {
+	annBlk       *api.AnnotatedBlock
+	roundResults *api.RoundResults
+}
+
+// fetchReindexBatch fetches the finalized runtime blocks for all heights in the given range using
+// a bounded number of concurrent workers.
+//
+// The returned slice is indexed by height relative to the start height and contains nil entries
+// for heights without a finalized runtime block.
+func (sc *serviceClient) fetchReindexBatch(runtimeID common.Namespace, startHeight, endHeight int64) ([]*reindexedBlock, error) {
+	ctx, cancel := context.WithCancel(sc.ctx)
+	defer cancel()
+
+	blocks := make([]*reindexedBlock, endHeight-startHeight+1)
+	heightCh := make(chan int64)
+
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		fetchErr error
+	)
+	for i := 0; i < min(reindexMaxWorkers, len(blocks)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for height := range heightCh {
+				rb, err := sc.fetchReindexHeight(ctx, runtimeID, height)
+				if err != nil {
+					errOnce.Do(func() {
+						fetchErr = err
+						cancel()
+					})
+					continue
+				}
+				blocks[height-startHeight] = rb
+			}
+		}()
+	}
+
+FEED:
+	for height := startHeight; height <= endHeight; height++ {
+		select {
+		case heightCh <- height:
+		case <-ctx.Done():
+			break FEED
+		}
+	}
+	close(heightCh)
+	wg.Wait()
+
+	if fetchErr != nil {
+		return nil, fetchErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return blocks, nil
+}
+
+// fetchReindexHeight fetches the finalized runtime block at the given height, if any.
+func (sc *serviceClient) fetchReindexHeight(ctx context.Context, runtimeID common.Namespace, height int64) (*reindexedBlock, error) {
+	results, err := sc.backend.GetBlockResults(ctx, height)
+	if err != nil {
+		// XXX: could soft-fail first few heights in case more heights were
+		// pruned right after the GetLastRetainedVersion query.
+		sc.logger.Error("failed to get cometbft block results",
+			"err", err,
+			"height", height,
+			"runtime_id", runtimeID,
+		)
+		return nil, fmt.Errorf("failed to get cometbft block results: %w", err)
+	}
+
+	// Index block.
+	tmEvents := results.BeginBlockEvents
+	for _, txResults := range results.TxsResults {
+		tmEvents = append(tmEvents, txResults.Events...)
+	}
+	tmEvents = append(tmEvents, results.EndBlockEvents...)
+
+	var rb *reindexedBlock
+	for _, tmEv := range tmEvents {
+		if tmEv.GetType() != app.EventType {
+			continue
+		}
+
+		var evRtID *common.Namespace
+		var ev *api.FinalizedEvent
+		for _, pair := range tmEv.GetAttributes() {
+			key := pair.GetKey()
+			val := pair.GetValue()
+
+			switch {
+			case eventsAPI.IsAttributeKind(key, &api.RuntimeIDAttribute{}):
+				if evRtID != nil {
+					return nil, fmt.Errorf("roothash: duplicate runtime ID attribute")
+				}
+
+				var rtAttribute api.RuntimeIDAttribute
+				if err = eventsAPI.DecodeValue(val, &rtAttribute); err != nil {
+					return nil, fmt.Errorf("roothash: corrupt runtime ID: %w", err)
+				}
+				evRtID = &rtAttribute.ID
+
+			case eventsAPI.IsAttributeKind(key, &api.FinalizedEvent{}):
+				var e api.FinalizedEvent
+				if err = eventsAPI.DecodeValue(val, &e); err != nil {
+					sc.logger.Error("failed to unmarshal finalized event",
+						"err", err,
+						"height", height,
+						"runtime_id", runtimeID,
+					)
+					return nil, fmt.Errorf("failed to unmarshal finalized event: %w", err)
+				}
+				ev = &e
+			default:
+			}
+		}
+
+		// Only process finalized events.
+		if ev == nil {
+			continue
+		}
+		// Only process events for the given runtime.
+		if !evRtID.Equal(&runtimeID) {
+			continue
+		}
+
+		annBlk, roundResults, err := sc.fetchFinalizedBlock(ctx, height, runtimeID, &ev.Round)
+		if err != nil {
+			return nil, fmt.Errorf("failed to process finalized event: %w", err)
+		}
+		rb = &reindexedBlock{
+			annBlk:       annBlk,
+			roundResults: roundResults,
+		}
+	}
+
+	return rb, nil
+}
+
 func (sc *serviceClient) reindexBlocks(currentHeight int64, bh api.BlockHistory) (uint64, error) {
 	lastRound := api.RoundInvalid
 	if currentHeight <= 0 {
@@ -436,75 +636,45 @@ func (sc *serviceClient) reindexBlocks(currentHeight int64, bh api.BlockHistory)
 		logging.LogEvent, api.LogEventHistoryReindexing,
 	)
 
-	for height := lastHeight; height <= currentHeight; height++ {
-		var results *cmtrpctypes.ResultBlockResults
-		results, err = sc.backend.GetBlockResults(sc.ctx, height)
-		if err != nil {
-			// XXX: could soft-fail first few heights in case more heights were
-			// pruned right after the GetLastRetainedVersion query.
-			logger.Error("failed to get cometbft block results",
-				"err", err,
-				"height", height,
-			)
-			return lastRound, fmt.Errorf("failed to get cometbft block results: %w", err)
-		}
+	progress := api.NewHistoryReindexProgress(lastHeight, currentHeight)
+	bh.SetReindexProgress(progress)
+	defer bh.SetReindexProgress(nil)
+
+	for batchStart := lastHeight; batchStart <= currentHeight; batchStart += reindexBatchSize {
+		batchEnd := min(batchStart+reindexBatchSize-1, currentHeight)
 
-		// Index block.
-		tmEvents := results.BeginBlockEvents
-		for _, txResults := range results.TxsResults {
-			tmEvents = append(tmEvents, txResults.Events...)
+		// Fetch all heights in the batch in parallel and then commit them in order.
+		var blocks []*reindexedBlock
+		if blocks, err = sc.fetchReindexBatch(runtimeID, batchStart, batchEnd); err != nil {
+			return 0, err
 		}
-		tmEvents = append(tmEvents, results.EndBlockEvents...)
-		for _, tmEv := range tmEvents {
-			if tmEv.GetType() != app.EventType {
+		for _, rb := range blocks {
+			if rb == nil {
 				continue
 			}
 
-			var evRtID *common.Namespace
-			var ev *api.FinalizedEvent
-			for _, pair := range tmEv.GetAttributes() {
-				key := pair.GetKey()
-				val := pair.GetValue()
-
-				switch {
-				case eventsAPI.IsAttributeKind(key, &api.RuntimeIDAttribute{}):
-					if evRtID != nil {
-						return 0, fmt.Errorf("roothash: duplicate runtime ID attribute")
-					}
-
-					var rtAttribute api.RuntimeIDAttribute
-					if err = eventsAPI.DecodeValue(val, &rtAttribute); err != nil {
-						return 0, fmt.Errorf("roothash: corrupt runtime ID: %w", err)
-					}
-					evRtID = &rtAttribute.ID
-
-				case eventsAPI.IsAttributeKind(key, &api.FinalizedEvent{}):
-					var e api.FinalizedEvent
-					if err = eventsAPI.DecodeValue(val, &e); err != nil {
-						logger.Error("failed to unmarshal finalized event",
-							"err", err,
-							"height", height,
-						)
-						return 0, fmt.Errorf("failed to unmarshal finalized event: %w", err)
-					}
-					ev = &e
-				default:
-				}
+			if err = bh.Commit(rb.annBlk, rb.roundResults, false); err != nil {
+				logger.Error("failed to commit block to history keeper",
+					"err", err,
+					"height", rb.annBlk.Height,
+					"round", rb.annBlk.Block.Header.Round,
+				)
+				return 0, fmt.Errorf("failed to commit block to history keeper: %w", err)
 			}
+			lastRound = rb.annBlk.Block.Header.Round
+		}
 
-			// Only process finalized events.
-			if ev == nil {
-				continue
-			}
-			// Only process events for the given runtime.
-			if !evRtID.Equal(&runtimeID) {
-				continue
-			}
-			if err = sc.processFinalizedEvent(sc.ctx, height, *evRtID, &ev.Round, true); err != nil {
-				return 0, fmt.Errorf("failed to process finalized event: %w", err)
-			}
-			lastRound = ev.Round
+		// Persist progress so that an interrupted reindex resumes after the last batch.
+		if err = bh.ConsensusCheckpoint(batchEnd); err != nil {
+			return 0, fmt.Errorf("failed to checkpoint reindexed height: %w", err)
 		}
+		progress.Advance(batchEnd)
+		bh.SetReindexProgress(progress)
+
+		logger.Debug("reindexed batch",
+			"last_height", batchEnd,
+			"percent", progress.Percent,
+		)
 	}
 
 	if lastRound == api.RoundInvalid {
@@ -573,7 +743,7 @@ func (sc *serviceClient) DeliverCommand(ctx context.Context, height int64, cmd i
 		}
 
 		// Emit latest block.
-		if err := sc.processFinalizedEvent(ctx, rs.LastBlockHeight, tr.runtimeID, nil, false); err != nil {
+		if err := sc.processFinalizedEvent(ctx, rs.LastBlockHeight, tr.runtimeID, nil); err != nil {
 			sc.logger.Warn("failed to emit latest block",
 				"err", err,
 				"runtime_id", tr.runtimeID,
@@ -600,6 +770,9 @@ func (sc *serviceClient) DeliverEvent(ctx context.Context, height int64, tx cmtt
 		if ev.Finalized == nil {
 			notifiers := sc.getRuntimeNotifiers(ev.RuntimeID)
 			notifiers.eventNotifier.Broadcast(ev)
+			if ev.InMsgQueued != nil {
+				notifiers.inMsgNotifier.Broadcast(ev.InMsgQueued)
+			}
 			continue
 		}
 
@@ -607,7 +780,7 @@ func (sc *serviceClient) DeliverEvent(ctx context.Context, height int64, tx cmtt
 		if sc.trackedRuntime[ev.RuntimeID] == nil {
 			continue
 		}
-		if err = sc.processFinalizedEvent(ctx, height, ev.RuntimeID, &ev.Finalized.Round, false); err != nil { //nolint:gosec
+		if err = sc.processFinalizedEvent(ctx, height, ev.RuntimeID, &ev.Finalized.Round); err != nil { //nolint:gosec
 			return fmt.Errorf("roothash: failed to process finalized event: %w", err)
 		}
 	}
@@ -621,50 +794,30 @@ func (sc *serviceClient) DeliverExecutorCommitment(runtimeID common.Namespace, e
 	notifiers.ecNotifier.Broadcast(ec)
 }
 
-func (sc *serviceClient) processFinalizedEvent(
+// fetchFinalizedBlock fetches the runtime block and round results finalized at the given height.
+//
+// If round is non-nil, the fetched block must be for the given round.
+func (sc *serviceClient) fetchFinalizedBlock(
 	ctx context.Context,
 	height int64,
 	runtimeID common.Namespace,
 	round *uint64,
-	isReindex bool,
-) (err error) {
-	tr := sc.trackedRuntime[runtimeID]
-	if tr == nil {
-		sc.logger.Error("runtime not tracked",
-			"runtime_id", runtimeID,
-			"tracked_runtimes", sc.trackedRuntime,
-		)
-		return fmt.Errorf("roothash: runtime not tracked: %s", runtimeID)
-	}
-	defer func() {
-		// If there was an error, flag the tracked runtime for reindex.
-		if err == nil {
-			return
-		}
-
-		tr.reindexDone = false
-	}()
-
-	if height <= tr.height {
-		return nil
-	}
-
-	// Process finalized event.
-	var blk *block.Block
-	if blk, err = sc.getLatestBlockAt(ctx, runtimeID, height); err != nil {
+) (*api.AnnotatedBlock, *api.RoundResults, error) {
+	blk, err := sc.getLatestBlockAt(ctx, runtimeID, height)
+	if err != nil {
 		sc.logger.Error("failed to fetch latest block",
 			"err", err,
 			"height", height,
 			"runtime_id", runtimeID,
 		)
-		return fmt.Errorf("roothash: failed to fetch latest block: %w", err)
+		return nil, nil, fmt.Errorf("roothash: failed to fetch latest block: %w", err)
 	}
 	if round != nil && blk.Header.Round != *round {
 		sc.logger.Error("finalized event/query round mismatch",
 			"block_round", blk.Header.Round,
 			"event_round", *round,
 		)
-		return fmt.Errorf("roothash: finalized event/query round mismatch")
+		return nil, nil, fmt.Errorf("roothash: finalized event/query round mismatch")
 	}
 
 	roundResults, err := sc.GetLastRoundResults(ctx, &api.RuntimeRequest{
@@ -677,13 +830,49 @@ func (sc *serviceClient) processFinalizedEvent(
 			"height", height,
 			"runtime_id", runtimeID,
 		)
-		return fmt.Errorf("roothash: failed to fetch round results: %w", err)
+		return nil, nil, fmt.Errorf("roothash: failed to fetch round results: %w", err)
 	}
 
 	annBlk := &api.AnnotatedBlock{
 		Height: height,
 		Block:  blk,
 	}
+	return annBlk, roundResults, nil
+}
+
+func (sc *serviceClient) processFinalizedEvent(
+	ctx context.Context,
+	height int64,
+	runtimeID common.Namespace,
+	round *uint64,
+) (err error) {
+	tr := sc.trackedRuntime[runtimeID]
+	if tr == nil {
+		sc.logger.Error("runtime not tracked",
+			"runtime_id", runtimeID,
+			"tracked_runtimes", sc.trackedRuntime,
+		)
+		return fmt.Errorf("roothash: runtime not tracked: %s", runtimeID)
+	}
+	defer func() {
+		// If there was an error, flag the tracked runtime for reindex.
+		if err == nil {
+			return
+		}
+
+		tr.reindexDone = false
+	}()
+
+	if height <= tr.height {
+		return nil
+	}
+
+	// Process finalized event.
+	annBlk, roundResults, err := sc.fetchFinalizedBlock(ctx, height, runtimeID, round)
+	if err != nil {
+		return err
+	}
+	blk := annBlk.Block
 
 	// Commit the block to history if needed.
 	if tr.blockHistory != nil {
@@ -691,7 +880,7 @@ func (sc *serviceClient) processFinalizedEvent(
 
 		// Perform reindex if required.
 		lastRound := api.RoundInvalid
-		if !isReindex && !tr.reindexDone {
+		if !tr.reindexDone {
 			// Note that we need to reindex up to the previous height as the current height is
 			// already being processed right now.
 			if lastRound, err = sc.reindexBlocks(height-1, tr.blockHistory); err != nil {
@@ -714,7 +903,7 @@ func (sc *serviceClient) processFinalizedEvent(
 				"round", blk.Header.Round,
 			)
 
-			err = tr.blockHistory.Commit(annBlk, roundResults, !isReindex)
+			err = tr.blockHistory.Commit(annBlk, roundResults, true)
 			if err != nil {
 				sc.logger.Error("failed to commit block to history keeper",
 					"err", err,
@@ -727,11 +916,6 @@ func (sc *serviceClient) processFinalizedEvent(
 		}
 	}
 
-	// Skip emitting events if we are reindexing.
-	if isReindex {
-		return nil
-	}
-
 	notifiers := sc.getRuntimeNotifiers(runtimeID)
 	// Ensure latest block is set.
 	notifiers.Lock()
@@ -805,6 +989,24 @@ EventLoop:
 				}
 
 				ev = &api.Event{ExecutorCommitted: &e}
+			case eventsAPI.IsAttributeKind(key, &api.RoundFailedEvent{}):
+				// A round has failed.
+				var e api.RoundFailedEvent
+				if err := eventsAPI.DecodeValue(val, &e); err != nil {
+					errs = errors.Join(errs, fmt.Errorf("roothash: corrupt RoundFailed event: %w", err))
+					continue EventLoop
+				}
+
+				ev = &api.Event{RoundFailed: &e}
+			case eventsAPI.IsAttributeKind(key, &api.InMsgQueuedEvent{}):
+				// Incoming message queued event.
+				var e api.InMsgQueuedEvent
+				if err := eventsAPI.DecodeValue(val, &e); err != nil {
+					errs = errors.Join(errs, fmt.Errorf("roothash: corrupt InMsgQueued event: %w", err))
+					continue EventLoop
+				}
+
+				ev = &api.Event{InMsgQueued: &e}
 			case eventsAPI.IsAttributeKind(key, &api.InMsgProcessedEvent{}):
 				// Incoming message processed event.
 				var e api.InMsgProcessedEvent
@@ -814,6 +1016,33 @@ EventLoop:
 				}
 
 				ev = &api.Event{InMsgProcessed: &e}
+			case eventsAPI.IsAttributeKind(key, &api.RuntimePausedEvent{}):
+				// Runtime paused event.
+				var e api.RuntimePausedEvent
+				if err := eventsAPI.DecodeValue(val, &e); err != nil {
+					errs = errors.Join(errs, fmt.Errorf("roothash: corrupt RuntimePaused event: %w", err))
+					continue EventLoop
+				}
+
+				ev = &api.Event{RuntimePaused: &e}
+			case eventsAPI.IsAttributeKind(key, &api.RuntimeResumedEvent{}):
+				// Runtime resumed event.
+				var e api.RuntimeResumedEvent
+				if err := eventsAPI.DecodeValue(val, &e); err != nil {
+					errs = errors.Join(errs, fmt.Errorf("roothash: corrupt RuntimeResumed event: %w", err))
+					continue EventLoop
+				}
+
+				ev = &api.Event{RuntimeResumed: &e}
+			case eventsAPI.IsAttributeKind(key, &api.SlashedEvent{}):
+				// Slashed event.
+				var e api.SlashedEvent
+				if err := eventsAPI.DecodeValue(val, &e); err != nil {
+					errs = errors.Join(errs, fmt.Errorf("roothash: corrupt Slashed event: %w", err))
+					continue EventLoop
+				}
+
+				ev = &api.Event{Slashed: &e}
 			case eventsAPI.IsAttributeKind(key, &api.RuntimeIDAttribute{}):
 				if runtimeID != nil {
 					errs = errors.Join(errs, fmt.Errorf("roothash: duplicate runtime ID attribute"))
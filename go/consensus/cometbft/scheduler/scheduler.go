@@ -83,6 +83,26 @@ func (sc *serviceClient) GetCommittees(ctx context.Context, request *api.GetComm
 	return runtimeCommittees, nil
 }
 
+func (sc *serviceClient) GetCommitteeMembershipProof(ctx context.Context, request *api.GetCommitteeMembershipProofRequest) (*api.CommitteeMembershipProof, error) {
+	q, err := sc.querier.QueryAt(ctx, request.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	committee, err := q.Committee(ctx, request.Kind, request.RuntimeID)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case committee == nil:
+		return nil, api.ErrNoSuchCommittee
+	case !committee.IsMember(request.NodeID):
+		return nil, api.ErrNotCommitteeMember
+	}
+
+	return sc.querier.CommitteeProofAt(ctx, request.Height, request.Kind, request.RuntimeID)
+}
+
 func (sc *serviceClient) WatchCommittees(_ context.Context) (<-chan *api.Committee, pubsub.ClosableSubscription, error) {
 	typedCh := make(chan *api.Committee)
 	sub := sc.notifier.Subscribe()
@@ -0,0 +1,421 @@
+package snapshots
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cometbft/cometbft/abci/types"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/checkpoint"
+)
+
+const (
+	// checkpointFormat is the only supported snapshot format.
+	checkpointFormat = 1
+
+	// maxChunkAttempts is the maximum number of attempts to fetch a single chunk.
+	maxChunkAttempts = 5
+
+	// maxListSize is the maximum size of a snapshot list response.
+	maxListSize = 16 * 1024 * 1024
+
+	// maxChunkSize is the maximum size of a single snapshot chunk. The checkpoint metadata
+	// only includes chunk hashes, so this bounds the amount of data downloaded before a chunk
+	// can be verified.
+	maxChunkSize = 128 * 1024 * 1024
+
+	// requestTimeout is the timeout of a single snapshot list or chunk request.
+	requestTimeout = 5 * time.Minute
+)
+
+var (
+	// ErrNoSnapshots is the error returned when none of the sources publishes an acceptable
+	// snapshot. In this case no restore is in progress.
+	ErrNoSnapshots = errors.New("snapshots: no acceptable snapshots available")
+
+	errSnapshotRejected = errors.New("snapshots: snapshot rejected")
+)
+
+// Application is an application into which snapshots can be restored.
+type Application interface {
+	// OfferSnapshot offers a snapshot to the application.
+	OfferSnapshot(types.RequestOfferSnapshot) types.ResponseOfferSnapshot
+
+	// ApplySnapshotChunk applies a snapshot chunk.
+	ApplySnapshotChunk(types.RequestApplySnapshotChunk) types.ResponseApplySnapshotChunk
+}
+
+// AppHashFunc returns the trusted application hash for the given height.
+type AppHashFunc func(ctx context.Context, height uint64) ([]byte, error)
+
+// remoteSnapshot is a snapshot together with the sources publishing it.
+type remoteSnapshot struct {
+	*Snapshot
+
+	metadata *checkpoint.Metadata
+	sources  []string
+}
+
+func (s *remoteSnapshot) chunkURL(source string, idx uint32) string {
+	return fmt.Sprintf("%s%s/%d/%d/%s/%d", source, listPath, s.Height, s.Format, hex.EncodeToString(s.Hash), idx)
+}
+
+// Fetcher fetches consensus state snapshots published over HTTP.
+type Fetcher struct {
+	sources []string
+	dir     string
+	client  *http.Client
+
+	logger *logging.Logger
+}
+
+// Restore restores the most recent snapshot available from any of the sources
+// into the given application.
+//
+// Snapshot metadata and chunks are verified against the snapshot hash before
+// being passed to the application, which in turn verifies the snapshot against
+// the trusted application hash. Downloaded chunks are stored in the fetcher's
+// directory so that an interrupted restore does not need to download them again.
+func (f *Fetcher) Restore(ctx context.Context, app Application, appHash AppHashFunc) (*Snapshot, error) {
+	snapshots, err := f.listSnapshots(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range snapshots {
+		logger := f.logger.With("height", s.Height, "hash", hex.EncodeToString(s.Hash))
+
+		trustedHash, err := appHash(ctx, s.Height)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			logger.Warn("failed to obtain trusted app hash, skipping snapshot",
+				"err", err,
+			)
+			continue
+		}
+
+		rsp := app.OfferSnapshot(types.RequestOfferSnapshot{
+			Snapshot: &types.Snapshot{
+				Height:   s.Height,
+				Format:   s.Format,
+				Chunks:   s.Chunks,
+				Hash:     s.Hash,
+				Metadata: s.Metadata,
+			},
+			AppHash: trustedHash,
+		})
+		switch rsp.Result {
+		case types.ResponseOfferSnapshot_ACCEPT:
+		case types.ResponseOfferSnapshot_REJECT, types.ResponseOfferSnapshot_REJECT_FORMAT, types.ResponseOfferSnapshot_REJECT_SENDER:
+			logger.Warn("snapshot rejected by the application",
+				"result", rsp.Result,
+			)
+			continue
+		default:
+			return nil, fmt.Errorf("snapshots: snapshot offer aborted (result: %s)", rsp.Result)
+		}
+
+		logger.Info("restoring snapshot",
+			"chunks", s.Chunks,
+		)
+
+		err = f.applyChunks(ctx, app, s)
+		switch {
+		case err == nil:
+			_ = os.RemoveAll(f.snapshotDir(s))
+			return s.Snapshot, nil
+		case errors.Is(err, errSnapshotRejected):
+			logger.Warn("snapshot rejected during restore")
+			_ = os.RemoveAll(f.snapshotDir(s))
+			continue
+		default:
+			return nil, err
+		}
+	}
+
+	return nil, ErrNoSnapshots
+}
+
+func (f *Fetcher) applyChunks(ctx context.Context, app Application, s *remoteSnapshot) error {
+	for idx := uint32(0); idx < s.Chunks; idx++ {
+		var applied bool
+		for attempt := 0; attempt < maxChunkAttempts && !applied; attempt++ {
+			source := s.sources[(int(idx)+attempt)%len(s.sources)]
+			chunk, err := f.fetchChunk(ctx, s, source, idx)
+			if err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				f.logger.Warn("failed to fetch chunk",
+					"source", source,
+					"index", idx,
+					"attempt", attempt,
+					"err", err,
+				)
+				continue
+			}
+
+			rsp := app.ApplySnapshotChunk(types.RequestApplySnapshotChunk{
+				Index:  idx,
+				Chunk:  chunk,
+				Sender: source,
+			})
+			switch rsp.Result {
+			case types.ResponseApplySnapshotChunk_ACCEPT:
+				applied = true
+			case types.ResponseApplySnapshotChunk_RETRY:
+				_ = os.Remove(f.chunkFile(s, idx))
+			case types.ResponseApplySnapshotChunk_REJECT_SNAPSHOT:
+				return errSnapshotRejected
+			default:
+				return fmt.Errorf("snapshots: chunk %d restore aborted (result: %s)", idx, rsp.Result)
+			}
+		}
+		if !applied {
+			return fmt.Errorf("snapshots: failed to fetch chunk %d", idx)
+		}
+	}
+	return nil
+}
+
+// listSnapshots fetches snapshot lists from all sources and returns the valid
+// snapshots ordered from the most recent one.
+func (f *Fetcher) listSnapshots(ctx context.Context) ([]*remoteSnapshot, error) {
+	byHash := make(map[hash.Hash]*remoteSnapshot)
+	for _, source := range f.sources {
+		list, err := f.fetchList(ctx, source)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			f.logger.Warn("failed to fetch snapshot list",
+				"source", source,
+				"err", err,
+			)
+			continue
+		}
+
+		for _, s := range list {
+			md, err := decodeMetadata(s)
+			if err != nil {
+				f.logger.Warn("ignoring malformed snapshot",
+					"source", source,
+					"height", s.Height,
+					"err", err,
+				)
+				continue
+			}
+
+			h := md.EncodedHash()
+			rs, ok := byHash[h]
+			if !ok {
+				rs = &remoteSnapshot{Snapshot: s, metadata: md}
+				byHash[h] = rs
+			}
+			rs.sources = append(rs.sources, source)
+		}
+	}
+
+	snapshots := make([]*remoteSnapshot, 0, len(byHash))
+	for _, rs := range byHash {
+		snapshots = append(snapshots, rs)
+	}
+	sort.Slice(snapshots, func(i, j int) bool {
+		if snapshots[i].Height != snapshots[j].Height {
+			return snapshots[i].Height > snapshots[j].Height
+		}
+		// Prefer snapshots available from more sources.
+		return len(snapshots[i].sources) > len(snapshots[j].sources)
+	})
+	return snapshots, nil
+}
+
+func (f *Fetcher) fetchList(ctx context.Context, source string) ([]*Snapshot, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, source+listPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	rsp, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status: %s", rsp.Status)
+	}
+
+	var list []*Snapshot
+	if err = json.NewDecoder(io.LimitReader(rsp.Body, maxListSize)).Decode(&list); err != nil {
+		return nil, fmt.Errorf("malformed snapshot list: %w", err)
+	}
+	return list, nil
+}
+
+// fetchChunk fetches the given chunk, resuming any previously interrupted
+// download, and verifies it against the snapshot metadata.
+func (f *Fetcher) fetchChunk(ctx context.Context, s *remoteSnapshot, source string, idx uint32) ([]byte, error) {
+	expected := s.metadata.Chunks[idx]
+	fn := f.chunkFile(s, idx)
+
+	// Use the chunk if it has already been fully downloaded and verified.
+	if chunk, err := os.ReadFile(fn); err == nil {
+		if h := hash.NewFromBytes(chunk); h.Equal(&expected) {
+			return chunk, nil
+		}
+		_ = os.Remove(fn)
+	}
+
+	if err := common.Mkdir(f.snapshotDir(s)); err != nil {
+		return nil, err
+	}
+	partial := fn + ".part"
+	file, err := os.OpenFile(partial, os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	offset, err := file.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.chunkURL(source, idx), nil)
+	if err != nil {
+		return nil, err
+	}
+	if offset > 0 {
+		req.Header.Set("Range", "bytes="+strconv.FormatInt(offset, 10)+"-")
+	}
+	rsp, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer rsp.Body.Close()
+
+	switch rsp.StatusCode {
+	case http.StatusPartialContent:
+		if !strings.HasPrefix(rsp.Header.Get("Content-Range"), fmt.Sprintf("bytes %d-", offset)) {
+			return nil, fmt.Errorf("unexpected content range: %s", rsp.Header.Get("Content-Range"))
+		}
+	case http.StatusOK:
+		// The server ignored the range request, start from scratch.
+		if err = file.Truncate(0); err != nil {
+			return nil, err
+		}
+		if _, err = file.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+	case http.StatusRequestedRangeNotSatisfiable:
+		// The partial download is corrupted, start from scratch next time.
+		_ = os.Remove(partial)
+		return nil, fmt.Errorf("partial download does not match the remote chunk")
+	default:
+		return nil, fmt.Errorf("unexpected status: %s", rsp.Status)
+	}
+
+	// Read at most one byte over the limit to detect oversized chunks.
+	offset, err = file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, err
+	}
+	n, err := io.Copy(file, io.LimitReader(rsp.Body, maxChunkSize-offset+1))
+	if err != nil {
+		return nil, err
+	}
+	if offset+n > maxChunkSize {
+		_ = os.Remove(partial)
+		return nil, fmt.Errorf("chunk exceeds maximum size of %d bytes", maxChunkSize)
+	}
+	if err = file.Close(); err != nil {
+		return nil, err
+	}
+
+	chunk, err := os.ReadFile(partial)
+	if err != nil {
+		return nil, err
+	}
+	if h := hash.NewFromBytes(chunk); !h.Equal(&expected) {
+		_ = os.Remove(partial)
+		return nil, fmt.Errorf("chunk hash mismatch (expected: %s got: %s)", expected, h)
+	}
+	if err = os.Rename(partial, fn); err != nil {
+		return nil, err
+	}
+	return chunk, nil
+}
+
+func (f *Fetcher) snapshotDir(s *remoteSnapshot) string {
+	return filepath.Join(f.dir, strconv.FormatUint(s.Height, 10), hex.EncodeToString(s.Hash))
+}
+
+func (f *Fetcher) chunkFile(s *remoteSnapshot, idx uint32) string {
+	return filepath.Join(f.snapshotDir(s), strconv.FormatUint(uint64(idx), 10))
+}
+
+// decodeMetadata decodes the checkpoint metadata of the given snapshot and
+// verifies it against the snapshot hash.
+func decodeMetadata(s *Snapshot) (*checkpoint.Metadata, error) {
+	if s.Format != checkpointFormat {
+		return nil, fmt.Errorf("unsupported format: %d", s.Format)
+	}
+
+	var h hash.Hash
+	if err := h.UnmarshalBinary(s.Hash); err != nil {
+		return nil, fmt.Errorf("malformed hash: %w", err)
+	}
+	if mh := hash.NewFromBytes(s.Metadata); !mh.Equal(&h) {
+		return nil, fmt.Errorf("metadata hash mismatch")
+	}
+
+	var md checkpoint.Metadata
+	if err := cbor.Unmarshal(s.Metadata, &md); err != nil {
+		return nil, fmt.Errorf("malformed metadata: %w", err)
+	}
+	if md.Root.Version != s.Height {
+		return nil, fmt.Errorf("metadata height mismatch")
+	}
+	if len(md.Chunks) != int(s.Chunks) {
+		return nil, fmt.Errorf("metadata chunk count mismatch")
+	}
+	return &md, nil
+}
+
+// NewFetcher creates a new snapshot fetcher for the given source base URLs.
+// The given directory is used to store downloaded chunks.
+//
+// Sources may use either HTTP or HTTPS. As all snapshot data is verified against
+// the trusted application hash, HTTPS is only needed for privacy.
+func NewFetcher(sources []string, dir string) (*Fetcher, error) {
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("snapshots: no sources configured")
+	}
+
+	trimmed := make([]string, 0, len(sources))
+	for _, source := range sources {
+		trimmed = append(trimmed, strings.TrimRight(source, "/"))
+	}
+
+	return &Fetcher{
+		sources: trimmed,
+		dir:     dir,
+		client:  &http.Client{Timeout: requestTimeout},
+		logger:  logging.GetLogger("consensus/cometbft/snapshots"),
+	}, nil
+}
@@ -0,0 +1,200 @@
+// Package snapshots implements publishing and fetching of consensus state
+// snapshots over HTTP.
+//
+// Snapshots are published in a static, content-addressed layout so that they
+// can easily be fronted by HTTPS reverse proxies and CDNs:
+//
+//	GET /snapshots                                   list of available snapshots
+//	GET /snapshots/{height}/{format}/{hash}/{chunk}  snapshot chunk
+//
+// Chunk downloads support range requests so that interrupted transfers can be
+// resumed.
+package snapshots
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cometbft/cometbft/abci/types"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+)
+
+const (
+	listPath  = "/snapshots"
+	chunkPath = "/snapshots/{height}/{format}/{hash}/{chunk}"
+)
+
+// Snapshot is a published consensus state snapshot.
+type Snapshot struct {
+	// Height is the height of the snapshot.
+	Height uint64 `json:"height"`
+	// Format is the application-specific snapshot format.
+	Format uint32 `json:"format"`
+	// Chunks is the number of chunks in the snapshot.
+	Chunks uint32 `json:"chunks"`
+	// Hash is the hash of the snapshot metadata.
+	Hash []byte `json:"hash"`
+	// Metadata is the application-specific snapshot metadata.
+	Metadata []byte `json:"metadata"`
+}
+
+// Source is a source of consensus state snapshots.
+type Source interface {
+	// ListSnapshots lists available snapshots.
+	ListSnapshots(types.RequestListSnapshots) types.ResponseListSnapshots
+
+	// LoadSnapshotChunk loads a chunk of a snapshot.
+	LoadSnapshotChunk(types.RequestLoadSnapshotChunk) types.ResponseLoadSnapshotChunk
+}
+
+type handler struct {
+	src Source
+}
+
+func (h *handler) listSnapshots(w http.ResponseWriter, _ *http.Request) {
+	rsp := h.src.ListSnapshots(types.RequestListSnapshots{})
+
+	snapshots := make([]*Snapshot, 0, len(rsp.Snapshots))
+	for _, s := range rsp.Snapshots {
+		snapshots = append(snapshots, &Snapshot{
+			Height:   s.Height,
+			Format:   s.Format,
+			Chunks:   s.Chunks,
+			Hash:     s.Hash,
+			Metadata: s.Metadata,
+		})
+	}
+
+	// The list changes as new snapshots are created, make sure it is always revalidated.
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(snapshots)
+}
+
+func (h *handler) loadChunk(w http.ResponseWriter, r *http.Request) {
+	height, err := strconv.ParseUint(r.PathValue("height"), 10, 64)
+	if err != nil {
+		http.Error(w, "malformed height", http.StatusBadRequest)
+		return
+	}
+	format, err := strconv.ParseUint(r.PathValue("format"), 10, 32)
+	if err != nil {
+		http.Error(w, "malformed format", http.StatusBadRequest)
+		return
+	}
+	snapshotHash, err := hex.DecodeString(r.PathValue("hash"))
+	if err != nil {
+		http.Error(w, "malformed hash", http.StatusBadRequest)
+		return
+	}
+	chunk, err := strconv.ParseUint(r.PathValue("chunk"), 10, 32)
+	if err != nil {
+		http.Error(w, "malformed chunk index", http.StatusBadRequest)
+		return
+	}
+
+	// Make sure that the requested snapshot is still available so that the content behind the
+	// (cacheable) chunk path never changes.
+	var found bool
+	for _, s := range h.src.ListSnapshots(types.RequestListSnapshots{}).Snapshots {
+		if s.Height == height && s.Format == uint32(format) && bytes.Equal(s.Hash, snapshotHash) {
+			found = uint64(s.Chunks) > chunk
+			break
+		}
+	}
+	if !found {
+		http.NotFound(w, r)
+		return
+	}
+
+	rsp := h.src.LoadSnapshotChunk(types.RequestLoadSnapshotChunk{
+		Height: height,
+		Format: uint32(format),
+		Chunk:  uint32(chunk),
+	})
+	if rsp.Chunk == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	chunkHash := hash.NewFromBytes(rsp.Chunk)
+	w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("ETag", fmt.Sprintf(`"%s"`, chunkHash))
+	http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(rsp.Chunk))
+}
+
+// NewHandler creates a new HTTP handler publishing snapshots from the given source.
+func NewHandler(src Source) http.Handler {
+	h := &handler{src: src}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+listPath, h.listSnapshots)
+	mux.HandleFunc("GET "+chunkPath, h.loadChunk)
+	return mux
+}
+
+// Server is an HTTP server publishing consensus state snapshots.
+//
+// The server does not terminate TLS itself. Nodes publishing snapshots over
+// HTTPS are expected to front it with a reverse proxy or CDN, which is also
+// where certificates are managed in practice.
+type Server struct {
+	address string
+	src     Source
+
+	listener net.Listener
+	server   *http.Server
+
+	logger *logging.Logger
+}
+
+// Start starts the server.
+func (s *Server) Start() error {
+	listener, err := net.Listen("tcp", s.address)
+	if err != nil {
+		return fmt.Errorf("snapshots: failed to listen: %w", err)
+	}
+
+	s.logger.Info("publishing snapshots over HTTP",
+		"address", listener.Addr(),
+	)
+
+	s.listener = listener
+	s.server = &http.Server{Handler: NewHandler(s.src), ReadHeaderTimeout: 5 * time.Second}
+
+	go func() {
+		if err := s.server.Serve(s.listener); err != nil && err != http.ErrServerClosed {
+			s.logger.Error("snapshot server terminated uncleanly",
+				"err", err,
+			)
+		}
+	}()
+
+	return nil
+}
+
+// Stop stops the server.
+func (s *Server) Stop() {
+	if s.server != nil {
+		_ = s.server.Close()
+		s.server = nil
+	}
+}
+
+// NewServer creates a new HTTP server publishing snapshots from the given source.
+func NewServer(address string, src Source) *Server {
+	return &Server{
+		address: address,
+		src:     src,
+		logger:  logging.GetLogger("consensus/cometbft/snapshots"),
+	}
+}
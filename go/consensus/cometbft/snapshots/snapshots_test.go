@@ -0,0 +1,206 @@
+package snapshots
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/cometbft/cometbft/abci/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/checkpoint"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
+)
+
+type testApp struct {
+	height   uint64
+	chunks   [][]byte
+	metadata *checkpoint.Metadata
+
+	offered *types.Snapshot
+	applied [][]byte
+}
+
+func newTestApp(height uint64, chunks ...[]byte) *testApp {
+	md := &checkpoint.Metadata{
+		Version: checkpointFormat,
+		Root: node.Root{
+			Version: height,
+			Type:    node.RootTypeState,
+			Hash:    hash.NewFromBytes([]byte(fmt.Sprintf("root %d", height))),
+		},
+	}
+	for _, chunk := range chunks {
+		md.Chunks = append(md.Chunks, hash.NewFromBytes(chunk))
+	}
+	return &testApp{
+		height:   height,
+		chunks:   chunks,
+		metadata: md,
+	}
+}
+
+func (app *testApp) ListSnapshots(types.RequestListSnapshots) types.ResponseListSnapshots {
+	h := app.metadata.EncodedHash()
+	return types.ResponseListSnapshots{
+		Snapshots: []*types.Snapshot{
+			{
+				Height:   app.height,
+				Format:   checkpointFormat,
+				Chunks:   uint32(len(app.chunks)),
+				Hash:     h[:],
+				Metadata: cbor.Marshal(app.metadata),
+			},
+		},
+	}
+}
+
+func (app *testApp) LoadSnapshotChunk(req types.RequestLoadSnapshotChunk) types.ResponseLoadSnapshotChunk {
+	if req.Height != app.height || int(req.Chunk) >= len(app.chunks) {
+		return types.ResponseLoadSnapshotChunk{}
+	}
+	return types.ResponseLoadSnapshotChunk{Chunk: app.chunks[req.Chunk]}
+}
+
+func (app *testApp) OfferSnapshot(req types.RequestOfferSnapshot) types.ResponseOfferSnapshot {
+	var md checkpoint.Metadata
+	if err := cbor.Unmarshal(req.Snapshot.Metadata, &md); err != nil {
+		return types.ResponseOfferSnapshot{Result: types.ResponseOfferSnapshot_REJECT}
+	}
+	if !md.Root.Hash.Equal((*hash.Hash)(req.AppHash)) {
+		return types.ResponseOfferSnapshot{Result: types.ResponseOfferSnapshot_REJECT}
+	}
+	app.offered = req.Snapshot
+	return types.ResponseOfferSnapshot{Result: types.ResponseOfferSnapshot_ACCEPT}
+}
+
+func (app *testApp) ApplySnapshotChunk(req types.RequestApplySnapshotChunk) types.ResponseApplySnapshotChunk {
+	app.applied = append(app.applied, req.Chunk)
+	return types.ResponseApplySnapshotChunk{Result: types.ResponseApplySnapshotChunk_ACCEPT}
+}
+
+func (app *testApp) appHash(_ context.Context, height uint64) ([]byte, error) {
+	if height != app.height {
+		return nil, fmt.Errorf("unknown height")
+	}
+	return app.metadata.Root.Hash[:], nil
+}
+
+func TestRestore(t *testing.T) {
+	require := require.New(t)
+
+	src := newTestApp(42, []byte("first chunk"), []byte("second chunk"))
+	srv := httptest.NewServer(NewHandler(src))
+	defer srv.Close()
+
+	f, err := NewFetcher([]string{srv.URL + "/"}, t.TempDir())
+	require.NoError(err, "NewFetcher")
+
+	dst := newTestApp(42)
+	dst.metadata = src.metadata
+	snapshot, err := f.Restore(context.Background(), dst, dst.appHash)
+	require.NoError(err, "Restore")
+	require.EqualValues(42, snapshot.Height)
+	require.EqualValues(42, dst.offered.Height)
+	require.Equal(src.chunks, dst.applied, "all chunks should be applied in order")
+
+	// Snapshots which do not match the trusted app hash should be rejected.
+	dst = newTestApp(42)
+	dst.metadata.Root.Hash = hash.NewFromBytes([]byte("other root"))
+	_, err = f.Restore(context.Background(), dst, dst.appHash)
+	require.ErrorIs(err, ErrNoSnapshots)
+	require.Empty(dst.applied)
+}
+
+func TestRestoreResume(t *testing.T) {
+	require := require.New(t)
+
+	src := newTestApp(10, []byte("a chunk which is downloaded in two parts"))
+	var ranges []string
+	handler := NewHandler(src)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ranges = append(ranges, r.Header.Get("Range"))
+		handler.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	f, err := NewFetcher([]string{srv.URL}, dir)
+	require.NoError(err, "NewFetcher")
+
+	// Simulate an interrupted download.
+	snapshots, err := f.listSnapshots(context.Background())
+	require.NoError(err, "listSnapshots")
+	require.Len(snapshots, 1)
+	require.NoError(os.MkdirAll(f.snapshotDir(snapshots[0]), 0o700))
+	err = os.WriteFile(f.chunkFile(snapshots[0], 0)+".part", src.chunks[0][:8], 0o600)
+	require.NoError(err, "WriteFile")
+
+	dst := newTestApp(10)
+	dst.metadata = src.metadata
+	_, err = f.Restore(context.Background(), dst, dst.appHash)
+	require.NoError(err, "Restore")
+	require.Equal(src.chunks, dst.applied)
+	require.Contains(ranges, "bytes=8-", "download should be resumed")
+
+	_, err = os.Stat(f.snapshotDir(snapshots[0]))
+	require.True(os.IsNotExist(err), "downloaded chunks should be removed after restore")
+}
+
+func TestRestoreCorruptedChunk(t *testing.T) {
+	require := require.New(t)
+
+	src := newTestApp(7, []byte("good chunk"))
+	bad := newTestApp(7, []byte("good chunk"))
+	bad.chunks = [][]byte{[]byte("evil chunk")}
+
+	badSrv := httptest.NewServer(NewHandler(bad))
+	defer badSrv.Close()
+	goodSrv := httptest.NewServer(NewHandler(src))
+	defer goodSrv.Close()
+
+	f, err := NewFetcher([]string{badSrv.URL, goodSrv.URL}, t.TempDir())
+	require.NoError(err, "NewFetcher")
+
+	dst := newTestApp(7)
+	dst.metadata = src.metadata
+	_, err = f.Restore(context.Background(), dst, dst.appHash)
+	require.NoError(err, "Restore")
+	require.Equal(src.chunks, dst.applied, "only verified chunks should be applied")
+}
+
+func TestHandler(t *testing.T) {
+	require := require.New(t)
+
+	src := newTestApp(5, []byte("chunk"))
+	srv := httptest.NewServer(NewHandler(src))
+	defer srv.Close()
+
+	f, err := NewFetcher([]string{srv.URL}, t.TempDir())
+	require.NoError(err, "NewFetcher")
+	snapshots, err := f.listSnapshots(context.Background())
+	require.NoError(err, "listSnapshots")
+	require.Len(snapshots, 1)
+
+	rsp, err := http.Get(snapshots[0].chunkURL(srv.URL, 0))
+	require.NoError(err)
+	rsp.Body.Close()
+	require.Equal(http.StatusOK, rsp.StatusCode)
+	require.Contains(rsp.Header.Get("Cache-Control"), "immutable")
+
+	// Chunks out of range or of unknown snapshots should not be found.
+	rsp, err = http.Get(snapshots[0].chunkURL(srv.URL, 1))
+	require.NoError(err)
+	rsp.Body.Close()
+	require.Equal(http.StatusNotFound, rsp.StatusCode)
+
+	rsp, err = http.Get(fmt.Sprintf("%s/snapshots/5/1/%x/0", srv.URL, hash.NewFromBytes(nil)))
+	require.NoError(err)
+	rsp.Body.Close()
+	require.Equal(http.StatusNotFound, rsp.StatusCode)
+}
@@ -0,0 +1,208 @@
+package staking
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+
+	"github.com/dgraph-io/badger/v4"
+	"github.com/dgraph-io/badger/v4/options"
+
+	cmnBadger "github.com/oasisprotocol/oasis-core/go/common/badger"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/keyformat"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+const (
+	eventIndexDBName    = "staking-events.badger.db"
+	eventIndexDBVersion = 1
+)
+
+var (
+	// eventIndexKeyFormat is the namespace for the account event index key formats.
+	eventIndexKeyFormat = keyformat.NewNamespace("staking account event index")
+
+	// eventIndexMetadataKeyFmt is the metadata key format.
+	//
+	// Value is CBOR-serialized eventIndexMetadata.
+	eventIndexMetadataKeyFmt = eventIndexKeyFormat.New(0x01)
+	// accountEventKeyFmt is the account event key format.
+	//
+	// Key format is: 0x02 <address> <height (uint64)> <index (uint32)>.
+	//
+	// Value is CBOR-serialized api.Event.
+	accountEventKeyFmt = eventIndexKeyFormat.New(0x02, &api.Address{}, uint64(0), uint32(0))
+)
+
+type eventIndexMetadata struct {
+	// Version is the database schema version.
+	Version uint64 `json:"version"`
+}
+
+// eventIndex is a node-local index of staking events by account address.
+type eventIndex struct {
+	sync.Mutex
+
+	logger *logging.Logger
+
+	db *badger.DB
+	gc *cmnBadger.GCWorker
+
+	lastHeight int64
+	nextIndex  uint32
+}
+
+// index adds the given events to the index.
+//
+// Events must be indexed in the order in which they were emitted. Events at the
+// same height are numbered sequentially, so re-indexing a height overwrites the
+// previously indexed events.
+func (ei *eventIndex) index(height int64, events []*api.Event) error {
+	ei.Lock()
+	defer ei.Unlock()
+
+	if height != ei.lastHeight {
+		ei.lastHeight = height
+		ei.nextIndex = 0
+	}
+
+	return ei.db.Update(func(tx *badger.Txn) error {
+		for _, ev := range events {
+			idx := ei.nextIndex
+			ei.nextIndex++
+
+			raw := cbor.Marshal(ev)
+			for _, addr := range ev.Addresses() {
+				if err := tx.Set(accountEventKeyFmt.Encode(&addr, uint64(height), idx), raw); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// query returns a page of indexed events involving the given account.
+func (ei *eventIndex) query(query *api.AccountEventsQuery) (*api.AccountEvents, error) {
+	if query.StartHeight < 0 {
+		return nil, fmt.Errorf("%w: invalid start height", api.ErrInvalidArgument)
+	}
+	limit := query.Limit
+	if limit == 0 || limit > api.MaxAccountEventsLimit {
+		limit = api.MaxAccountEventsLimit
+	}
+
+	result := api.AccountEvents{
+		Events: []*api.Event{},
+	}
+	err := ei.db.View(func(tx *badger.Txn) error {
+		it := tx.NewIterator(badger.IteratorOptions{Prefix: accountEventKeyFmt.Encode(&query.Owner)})
+		defer it.Close()
+
+		for it.Seek(accountEventKeyFmt.Encode(&query.Owner, uint64(query.StartHeight), query.StartIndex)); it.Valid(); it.Next() {
+			item := it.Item()
+
+			if uint32(len(result.Events)) == limit {
+				var (
+					addr   api.Address
+					height uint64
+					idx    uint32
+				)
+				if !accountEventKeyFmt.Decode(item.Key(), &addr, &height, &idx) {
+					return fmt.Errorf("staking: malformed account event key")
+				}
+				result.Next = &api.AccountEventsQuery{
+					Owner:       query.Owner,
+					StartHeight: int64(height),
+					StartIndex:  idx,
+					Limit:       query.Limit,
+				}
+				return nil
+			}
+
+			var ev api.Event
+			if err := item.Value(func(val []byte) error {
+				return cbor.UnmarshalTrusted(val, &ev)
+			}); err != nil {
+				return err
+			}
+			result.Events = append(result.Events, &ev)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (ei *eventIndex) ensureMetadata() error {
+	return ei.db.Update(func(tx *badger.Txn) error {
+		item, err := tx.Get(eventIndexMetadataKeyFmt.Encode())
+		switch err {
+		case nil:
+		case badger.ErrKeyNotFound:
+			// Create new metadata section.
+			meta := eventIndexMetadata{
+				Version: eventIndexDBVersion,
+			}
+			return tx.Set(eventIndexMetadataKeyFmt.Encode(), cbor.Marshal(meta))
+		default:
+			return err
+		}
+
+		var meta eventIndexMetadata
+		if err = item.Value(func(val []byte) error {
+			return cbor.Unmarshal(val, &meta)
+		}); err != nil {
+			return err
+		}
+
+		// Verify metadata section.
+		if meta.Version != eventIndexDBVersion {
+			return fmt.Errorf("staking: unsupported account event index version (expected: %d got: %d)",
+				eventIndexDBVersion,
+				meta.Version,
+			)
+		}
+		return nil
+	})
+}
+
+func (ei *eventIndex) close() {
+	ei.gc.Stop()
+	ei.db.Close()
+}
+
+func newEventIndex(dataDir string) (*eventIndex, error) {
+	fn := filepath.Join(dataDir, eventIndexDBName)
+	logger := logging.GetLogger("cometbft/staking/index").With("path", fn)
+
+	opts := badger.DefaultOptions(fn)
+	opts = opts.WithLogger(cmnBadger.NewLogAdapter(logger))
+	opts = opts.WithSyncWrites(true)
+	opts = opts.WithCompression(options.None)
+
+	db, err := badger.Open(opts)
+	if err != nil {
+		return nil, fmt.Errorf("staking: failed to open account event index: %w", err)
+	}
+
+	gc := cmnBadger.NewGCWorker(logger, db)
+	gc.Start()
+
+	ei := &eventIndex{
+		logger: logger,
+		db:     db,
+		gc:     gc,
+	}
+
+	if err = ei.ensureMetadata(); err != nil {
+		ei.close()
+		return nil, err
+	}
+
+	return ei, nil
+}
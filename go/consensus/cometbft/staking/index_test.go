@@ -0,0 +1,92 @@
+package staking
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	"github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+func TestEventIndex(t *testing.T) {
+	require := require.New(t)
+
+	dataDir := t.TempDir()
+	ei, err := newEventIndex(dataDir)
+	require.NoError(err, "newEventIndex")
+
+	addr1 := api.NewAddress(signature.NewPublicKey("aaafffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"))
+	addr2 := api.NewAddress(signature.NewPublicKey("bbbfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"))
+	addr3 := api.NewAddress(signature.NewPublicKey("cccfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"))
+
+	transfer := func(height int64, from, to api.Address, amount uint64) *api.Event {
+		return &api.Event{
+			Height: height,
+			Transfer: &api.TransferEvent{
+				From:   from,
+				To:     to,
+				Amount: *quantity.NewFromUint64(amount),
+			},
+		}
+	}
+
+	require.NoError(ei.index(10, []*api.Event{transfer(10, addr1, addr2, 1)}))
+	require.NoError(ei.index(10, []*api.Event{
+		transfer(10, addr2, addr3, 2),
+		{Height: 10, Escrow: &api.EscrowEvent{Add: &api.AddEscrowEvent{Owner: addr1, Escrow: addr3}}},
+	}))
+	require.NoError(ei.index(12, []*api.Event{{Height: 12, Burn: &api.BurnEvent{Owner: addr1}}}))
+	require.NoError(ei.index(15, []*api.Event{transfer(15, addr3, addr1, 3)}))
+
+	// Query all events.
+	evs, err := ei.query(&api.AccountEventsQuery{Owner: addr1})
+	require.NoError(err, "query")
+	require.Len(evs.Events, 4)
+	require.Nil(evs.Next, "there should be no more events")
+	require.NotNil(evs.Events[0].Transfer)
+	require.NotNil(evs.Events[1].Escrow)
+	require.NotNil(evs.Events[2].Burn)
+	require.EqualValues(15, evs.Events[3].Height)
+
+	evs, err = ei.query(&api.AccountEventsQuery{Owner: addr2})
+	require.NoError(err, "query")
+	require.Len(evs.Events, 2)
+
+	// Paginated query.
+	query := &api.AccountEventsQuery{Owner: addr1, StartHeight: 10, Limit: 1}
+	var heights []int64
+	for query != nil {
+		evs, err = ei.query(query)
+		require.NoError(err, "query")
+		require.Len(evs.Events, 1)
+		heights = append(heights, evs.Events[0].Height)
+		query = evs.Next
+	}
+	require.Equal([]int64{10, 10, 12, 15}, heights)
+
+	// Query from a later height.
+	evs, err = ei.query(&api.AccountEventsQuery{Owner: addr1, StartHeight: 11})
+	require.NoError(err, "query")
+	require.Len(evs.Events, 2)
+	require.EqualValues(12, evs.Events[0].Height)
+
+	// Unknown account.
+	evs, err = ei.query(&api.AccountEventsQuery{Owner: api.NewAddress(signature.NewPublicKey("dddfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"))})
+	require.NoError(err, "query")
+	require.Empty(evs.Events)
+
+	_, err = ei.query(&api.AccountEventsQuery{Owner: addr1, StartHeight: -1})
+	require.ErrorIs(err, api.ErrInvalidArgument)
+
+	// Re-opening the index should preserve the events.
+	ei.close()
+	ei, err = newEventIndex(dataDir)
+	require.NoError(err, "newEventIndex")
+	defer ei.close()
+
+	evs, err = ei.query(&api.AccountEventsQuery{Owner: addr3})
+	require.NoError(err, "query")
+	require.Len(evs.Events, 3)
+}
@@ -15,10 +15,12 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	"github.com/oasisprotocol/oasis-core/go/config"
 	eventsAPI "github.com/oasisprotocol/oasis-core/go/consensus/api/events"
 	tmapi "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
 	app "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/staking"
 	"github.com/oasisprotocol/oasis-core/go/staking/api"
+	"github.com/oasisprotocol/oasis-core/go/staking/api/token"
 )
 
 // ServiceClient is the scheduler service client interface.
@@ -36,6 +38,7 @@ type serviceClient struct {
 	querier *app.QueryFactory
 
 	eventNotifier *pubsub.Broker
+	eventIndex    *eventIndex
 }
 
 func (sc *serviceClient) TokenSymbol(ctx context.Context, height int64) (string, error) {
@@ -76,6 +79,22 @@ func (sc *serviceClient) TokenValueExponent(ctx context.Context, height int64) (
 	return genesis.Staking.TokenValueExponent, nil
 }
 
+func (sc *serviceClient) TokenMetadata(ctx context.Context, height int64) (*token.Metadata, error) {
+	symbol, err := sc.TokenSymbol(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	decimals, err := sc.TokenValueExponent(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+
+	return &token.Metadata{
+		Symbol:   symbol,
+		Decimals: decimals,
+	}, nil
+}
+
 func (sc *serviceClient) TotalSupply(ctx context.Context, height int64) (*quantity.Quantity, error) {
 	q, err := sc.querier.QueryAt(ctx, height)
 	if err != nil {
@@ -202,6 +221,42 @@ func (sc *serviceClient) DebondingDelegationsTo(ctx context.Context, query *api.
 	return q.DebondingDelegationsTo(ctx, query.Owner)
 }
 
+func (sc *serviceClient) ScheduledTransfersFrom(ctx context.Context, query *api.OwnerQuery) ([]*api.ScheduledTransfer, error) {
+	q, err := sc.querier.QueryAt(ctx, query.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.ScheduledTransfersFrom(ctx, query.Owner)
+}
+
+func (sc *serviceClient) ScheduledTransfersTo(ctx context.Context, query *api.OwnerQuery) ([]*api.ScheduledTransfer, error) {
+	q, err := sc.querier.QueryAt(ctx, query.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.ScheduledTransfersTo(ctx, query.Owner)
+}
+
+func (sc *serviceClient) SimulateSlash(ctx context.Context, query *api.SlashSimulationQuery) (*api.SlashSimulation, error) {
+	q, err := sc.querier.QueryAt(ctx, query.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.SimulateSlash(ctx, query.Owner, query.Reason)
+}
+
+func (sc *serviceClient) ValidateCommissionSchedule(ctx context.Context, query *api.CommissionScheduleQuery) (*api.CommissionSchedule, error) {
+	q, err := sc.querier.QueryAt(ctx, query.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.ValidateCommissionSchedule(ctx, query.Owner, &query.Amendment, query.Epoch)
+}
+
 func (sc *serviceClient) Allowance(ctx context.Context, query *api.AllowanceQuery) (*quantity.Quantity, error) {
 	acct, err := sc.Account(ctx, &api.OwnerQuery{
 		Height: query.Height,
@@ -291,6 +346,14 @@ func (sc *serviceClient) GetEvents(ctx context.Context, height int64) ([]*api.Ev
 	return events, nil
 }
 
+func (sc *serviceClient) GetAccountEvents(_ context.Context, query *api.AccountEventsQuery) (*api.AccountEvents, error) {
+	if sc.eventIndex == nil {
+		return nil, api.ErrAccountEventIndexDisabled
+	}
+
+	return sc.eventIndex.query(query)
+}
+
 func (sc *serviceClient) WatchEvents(context.Context) (<-chan *api.Event, pubsub.ClosableSubscription, error) {
 	typedCh := make(chan *api.Event)
 	sub := sc.eventNotifier.Subscribe()
@@ -309,6 +372,9 @@ func (sc *serviceClient) ConsensusParameters(ctx context.Context, height int64)
 }
 
 func (sc *serviceClient) Cleanup() {
+	if sc.eventIndex != nil {
+		sc.eventIndex.close()
+	}
 }
 
 // Implements api.ServiceClient.
@@ -323,6 +389,13 @@ func (sc *serviceClient) DeliverEvent(_ context.Context, height int64, tx cmttyp
 		return fmt.Errorf("staking: failed to process cometbft events: %w", err)
 	}
 
+	// Update the account event index.
+	if sc.eventIndex != nil {
+		if err = sc.eventIndex.index(height, events); err != nil {
+			return fmt.Errorf("staking: failed to index events: %w", err)
+		}
+	}
+
 	// Notify subscribers of events.
 	for _, ev := range events {
 		sc.eventNotifier.Broadcast(ev)
@@ -428,6 +501,36 @@ func EventsFromCometBFT(
 
 				evt := &api.Event{Height: height, TxHash: txHash, AllowanceChange: &e}
 				events = append(events, evt)
+			case eventsAPI.IsAttributeKind(key, &api.ScheduleTransferEvent{}):
+				// Schedule transfer event.
+				var e api.ScheduleTransferEvent
+				if err := eventsAPI.DecodeValue(val, &e); err != nil {
+					errs = errors.Join(errs, fmt.Errorf("staking: corrupt ScheduleTransfer event: %w", err))
+					continue
+				}
+
+				evt := &api.Event{Height: height, TxHash: txHash, ScheduledTransfer: &api.ScheduledTransferEvent{Schedule: &e}}
+				events = append(events, evt)
+			case eventsAPI.IsAttributeKind(key, &api.ReleaseScheduledTransferEvent{}):
+				// Release scheduled transfer event.
+				var e api.ReleaseScheduledTransferEvent
+				if err := eventsAPI.DecodeValue(val, &e); err != nil {
+					errs = errors.Join(errs, fmt.Errorf("staking: corrupt ReleaseScheduledTransfer event: %w", err))
+					continue
+				}
+
+				evt := &api.Event{Height: height, TxHash: txHash, ScheduledTransfer: &api.ScheduledTransferEvent{Release: &e}}
+				events = append(events, evt)
+			case eventsAPI.IsAttributeKind(key, &api.CancelScheduledTransferEvent{}):
+				// Cancel scheduled transfer event.
+				var e api.CancelScheduledTransferEvent
+				if err := eventsAPI.DecodeValue(val, &e); err != nil {
+					errs = errors.Join(errs, fmt.Errorf("staking: corrupt CancelScheduledTransfer event: %w", err))
+					continue
+				}
+
+				evt := &api.Event{Height: height, TxHash: txHash, ScheduledTransfer: &api.ScheduledTransferEvent{Cancel: &e}}
+				events = append(events, evt)
 			default:
 				errs = errors.Join(errs, fmt.Errorf("staking: unknown event type: key: %s, val: %s", key, val))
 			}
@@ -438,7 +541,10 @@ func EventsFromCometBFT(
 }
 
 // New constructs a new CometBFT backed staking Backend instance.
-func New(backend tmapi.Backend) (ServiceClient, error) {
+//
+// If the account event index is enabled in the node configuration, it is
+// stored in the given data directory.
+func New(backend tmapi.Backend, dataDir string) (ServiceClient, error) {
 	// Initialize and register the CometBFT service component.
 	a := app.New()
 	if err := backend.RegisterApplication(a); err != nil {
@@ -450,10 +556,20 @@ func New(backend tmapi.Backend) (ServiceClient, error) {
 		return nil, err
 	}
 
-	return &serviceClient{
+	sc := &serviceClient{
 		logger:        logging.GetLogger("cometbft/staking"),
 		backend:       backend,
 		querier:       a.QueryFactory().(*app.QueryFactory),
 		eventNotifier: pubsub.NewBroker(false),
-	}, nil
+	}
+
+	if config.GlobalConfig.Consensus.AccountEventIndex {
+		ei, err := newEventIndex(dataDir)
+		if err != nil {
+			return nil, err
+		}
+		sc.eventIndex = ei
+	}
+
+	return sc, nil
 }
@@ -52,6 +52,7 @@ func ConsensusImplementationTests(t *testing.T, backend consensus.ClientBackend)
 	require.EqualValues(1, status.GenesisHeight, "genesis height must be 1")
 	// We run this test without pruning. All we check is that we retain everything as configured.
 	require.EqualValues(1, status.LastRetainedHeight, "last retained height must be 1")
+	require.EqualValues(1, status.LastRetainedStateHeight, "last retained state height must be 1")
 
 	blk, err = backend.GetBlock(ctx, status.LatestHeight)
 	require.NoError(err, "GetBlock")
@@ -157,6 +158,13 @@ func ConsensusImplementationTests(t *testing.T, backend consensus.ClientBackend)
 	require.NoError(err, "GetParameters(HeightLatest)")
 	require.NotEqual(0, lparams.Parameters.StateCheckpointInterval, "returned parameters should contain parameters")
 
+	gasCosts, err := backend.GetGasCosts(ctx, blk.Height)
+	require.NoError(err, "GetGasCosts")
+	require.Equal(blk.Height, gasCosts.Height, "returned gas costs height should be correct")
+	for module, costs := range gasCosts.Modules {
+		require.NoError(costs.ValidateOps(module), "returned gas costs should only contain known operations")
+	}
+
 	err = backend.SubmitTxNoWait(ctx, &transaction.SignedTransaction{})
 	require.Error(err, "SubmitTxNoWait should fail with invalid transaction")
 
@@ -175,6 +183,20 @@ func ConsensusImplementationTests(t *testing.T, backend consensus.ClientBackend)
 	require.Error(err, "SubmitTxNoWait(duplicate)")
 	require.True(errors.Is(err, consensus.ErrDuplicateTx), "SubmitTxNoWait should return ErrDuplicateTx on duplicate tx")
 
+	_, err = backend.SubmitTxNoWaitWithStatus(ctx, &transaction.SignedTransaction{})
+	require.Error(err, "SubmitTxNoWaitWithStatus should fail with invalid transaction")
+
+	testSigner = memorySigner.NewTestSigner(fmt.Sprintf("consensus tests tx status signer: %T", backend))
+	testSigTx, err = transaction.Sign(testSigner, testTx)
+	require.NoError(err, "transaction.Sign")
+	txStatus, err := backend.SubmitTxNoWaitWithStatus(ctx, testSigTx)
+	require.NoError(err, "SubmitTxNoWaitWithStatus")
+	require.True(txStatus.Height >= blk.Height, "status height should not be behind the latest block")
+	require.True(txStatus.QueuePosition <= txStatus.QueueSize, "queue position should be within the mempool")
+
+	_, err = backend.SubmitTxNoWaitWithStatus(ctx, testSigTx)
+	require.True(errors.Is(err, consensus.ErrDuplicateTx), "SubmitTxNoWaitWithStatus should return ErrDuplicateTx on duplicate tx")
+
 	// We should be able to do remote state queries. Of course the state format is backend-specific
 	// so we simply perform some usual storage operations like fetching random keys and iterating
 	// through everything.
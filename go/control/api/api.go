@@ -62,6 +62,19 @@ type NodeController interface {
 
 	// GetStatus returns the current status overview of the node.
 	GetStatus(ctx context.Context) (*Status, error)
+
+	// SetCommitteeAudit enables or disables auditing of authenticated committee messages
+	// received by the executor worker of the given runtime.
+	SetCommitteeAudit(ctx context.Context, req *SetCommitteeAuditRequest) error
+}
+
+// SetCommitteeAuditRequest is a SetCommitteeAudit request.
+type SetCommitteeAuditRequest struct {
+	// RuntimeID is the identifier of the runtime.
+	RuntimeID common.Namespace `json:"runtime_id"`
+
+	// Enabled specifies whether committee messages should be audited.
+	Enabled bool `json:"enabled"`
 }
 
 // Status is the current status overview.
@@ -72,6 +85,9 @@ type Status struct {
 	// Mode is the node mode.
 	Mode config.NodeMode `json:"mode"`
 
+	// ReadOnly is true iff the node is running in read-only mode.
+	ReadOnly bool `json:"read_only,omitempty"`
+
 	// Debug is the oasis-node debug status.
 	Debug *DebugStatus `json:"debug,omitempty"`
 
@@ -239,9 +255,15 @@ type SeedStatus struct {
 // DebugModuleName is the module name for the debug controller service.
 const DebugModuleName = "control/debug"
 
-// ErrIncompatibleBackend is the error raised when the current beacon
-// backend does not support manually setting the current epoch.
-var ErrIncompatibleBackend = errors.New(DebugModuleName, 1, "debug: incompatible backend")
+var (
+	// ErrIncompatibleBackend is the error raised when the current beacon
+	// backend does not support manually setting the current epoch.
+	ErrIncompatibleBackend = errors.New(DebugModuleName, 1, "debug: incompatible backend")
+
+	// ErrIncompatibleP2P is the error raised when the current P2P service
+	// does not support network partitioning.
+	ErrIncompatibleP2P = errors.New(DebugModuleName, 2, "debug: incompatible P2P service")
+)
 
 // DebugController is a debug-only controller useful during tests.
 type DebugController interface {
@@ -253,4 +275,8 @@ type DebugController interface {
 
 	// WaitNodesRegistered waits for the given number of nodes to register.
 	WaitNodesRegistered(ctx context.Context, count int) error
+
+	// SetIsolatedPeers replaces the set of P2P peers (identified by their P2P public keys)
+	// that the node is isolated from. Passing an empty set heals the partition.
+	SetIsolatedPeers(ctx context.Context, peers []signature.PublicKey) error
 }
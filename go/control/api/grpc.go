@@ -29,6 +29,8 @@ var (
 	methodCancelUpgrade = serviceName.NewMethod("CancelUpgrade", nil)
 	// methodGetStatus is the GetStatus method.
 	methodGetStatus = serviceName.NewMethod("GetStatus", nil)
+	// methodSetCommitteeAudit is the SetCommitteeAudit method.
+	methodSetCommitteeAudit = serviceName.NewMethod("SetCommitteeAudit", SetCommitteeAuditRequest{})
 
 	// serviceDesc is the gRPC service descriptor.
 	serviceDesc = grpc.ServiceDesc{
@@ -67,6 +69,10 @@ var (
 				MethodName: methodGetStatus.ShortName(),
 				Handler:    handlerGetStatus,
 			},
+			{
+				MethodName: methodSetCommitteeAudit.ShortName(),
+				Handler:    handlerSetCommitteeAudit,
+			},
 		},
 		Streams: []grpc.StreamDesc{},
 	}
@@ -236,6 +242,29 @@ func handlerGetStatus(
 	return interceptor(ctx, nil, info, handler)
 }
 
+func handlerSetCommitteeAudit(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var req SetCommitteeAuditRequest
+	if err := dec(&req); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return nil, srv.(NodeController).SetCommitteeAudit(ctx, &req)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodSetCommitteeAudit.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, srv.(NodeController).SetCommitteeAudit(ctx, req.(*SetCommitteeAuditRequest))
+	}
+	return interceptor(ctx, &req, info, handler)
+}
+
 // RegisterService registers a new node controller service with the given gRPC server.
 func RegisterService(server *grpc.Server, service NodeController) {
 	server.RegisterService(&serviceDesc, service)
@@ -289,6 +318,10 @@ func (c *nodeControllerClient) GetStatus(ctx context.Context) (*Status, error) {
 	return &rsp, nil
 }
 
+func (c *nodeControllerClient) SetCommitteeAudit(ctx context.Context, req *SetCommitteeAuditRequest) error {
+	return c.conn.Invoke(ctx, methodSetCommitteeAudit.FullName(), req, nil)
+}
+
 // NewNodeControllerClient creates a new gRPC node controller client service.
 func NewNodeControllerClient(c *grpc.ClientConn) NodeController {
 	return &nodeControllerClient{c}
@@ -6,6 +6,7 @@ import (
 	"google.golang.org/grpc"
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	cmnGrpc "github.com/oasisprotocol/oasis-core/go/common/grpc"
 )
 
@@ -17,6 +18,8 @@ var (
 	methodSetEpoch = debugServiceName.NewMethod("SetEpoch", beacon.EpochTime(0))
 	// methodWaitNodesRegistered is the WaitNodesRegistered method.
 	methodWaitNodesRegistered = debugServiceName.NewMethod("WaitNodesRegistered", int(0))
+	// methodSetIsolatedPeers is the SetIsolatedPeers method.
+	methodSetIsolatedPeers = debugServiceName.NewMethod("SetIsolatedPeers", []signature.PublicKey{})
 
 	// debugServiceDesc is the gRPC service descriptor.
 	debugServiceDesc = grpc.ServiceDesc{
@@ -31,6 +34,10 @@ var (
 				MethodName: methodWaitNodesRegistered.ShortName(),
 				Handler:    handlerWaitNodesRegistered,
 			},
+			{
+				MethodName: methodSetIsolatedPeers.ShortName(),
+				Handler:    handlerSetIsolatedPeers,
+			},
 		},
 		Streams: []grpc.StreamDesc{},
 	}
@@ -82,6 +89,29 @@ func handlerWaitNodesRegistered(
 	return interceptor(ctx, count, info, handler)
 }
 
+func handlerSetIsolatedPeers(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var peers []signature.PublicKey
+	if err := dec(&peers); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return nil, srv.(DebugController).SetIsolatedPeers(ctx, peers)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodSetIsolatedPeers.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, srv.(DebugController).SetIsolatedPeers(ctx, req.([]signature.PublicKey))
+	}
+	return interceptor(ctx, peers, info, handler)
+}
+
 // RegisterDebugService registers a new debug controller service with the given gRPC server.
 func RegisterDebugService(server *grpc.Server, service DebugController) {
 	server.RegisterService(&debugServiceDesc, service)
@@ -99,6 +129,10 @@ func (c *debugControllerClient) WaitNodesRegistered(ctx context.Context, count i
 	return c.conn.Invoke(ctx, methodWaitNodesRegistered.FullName(), count, nil)
 }
 
+func (c *debugControllerClient) SetIsolatedPeers(ctx context.Context, peers []signature.PublicKey) error {
+	return c.conn.Invoke(ctx, methodSetIsolatedPeers.FullName(), peers, nil)
+}
+
 // NewDebugControllerClient creates a new gRPC debug controller client service.
 func NewDebugControllerClient(c *grpc.ClientConn) DebugController {
 	return &debugControllerClient{c}
@@ -332,6 +332,27 @@ func (p *ChangeParametersProposal) ValidateBasic() error {
 	return nil
 }
 
+// ValidateGasCosts checks that the gas costs included in the parameter changes (if any) only
+// refer to gas operations supported by the target module.
+func (p *ChangeParametersProposal) ValidateGasCosts() error {
+	var changes map[string]cbor.RawMessage
+	if err := cbor.Unmarshal(p.Changes, &changes); err != nil {
+		return fmt.Errorf("invalid parameter changes: %w", err)
+	}
+	raw, ok := changes["gas_costs"]
+	if !ok {
+		return nil
+	}
+	var gasCosts transaction.Costs
+	if err := cbor.Unmarshal(raw, &gasCosts); err != nil {
+		return fmt.Errorf("invalid gas costs: %w", err)
+	}
+	if err := gasCosts.ValidateOps(p.Module); err != nil {
+		return fmt.Errorf("invalid gas costs: %w", err)
+	}
+	return nil
+}
+
 // ProposalVote is a vote for a proposal.
 type ProposalVote struct {
 	// ID is the unique identifier of a proposal.
@@ -586,3 +607,11 @@ var DefaultGasCosts = transaction.Costs{
 	GasOpSubmitProposal: 1000,
 	GasOpCastVote:       1000,
 }
+
+func init() {
+	transaction.RegisterGasOps(
+		ModuleName,
+		GasOpSubmitProposal,
+		GasOpCastVote,
+	)
+}
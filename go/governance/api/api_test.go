@@ -11,6 +11,7 @@ import (
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/version"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 	upgrade "github.com/oasisprotocol/oasis-core/go/upgrade/api"
 )
 
@@ -91,6 +92,62 @@ func TestValidateBasic(t *testing.T) {
 	}
 }
 
+func TestChangeParametersValidateGasCosts(t *testing.T) {
+	for _, tc := range []struct {
+		msg       string
+		p         *ChangeParametersProposal
+		shouldErr bool
+	}{
+		{
+			msg: "changes without gas costs should not fail",
+			p: &ChangeParametersProposal{
+				Module:  ModuleName,
+				Changes: cbor.Marshal(map[string]interface{}{"min_proposal_deposit": "1"}),
+			},
+			shouldErr: false,
+		},
+		{
+			msg: "gas costs for known operations should not fail",
+			p: &ChangeParametersProposal{
+				Module:  ModuleName,
+				Changes: cbor.Marshal(&ConsensusParameterChanges{GasCosts: transaction.Costs{GasOpCastVote: 1000}}),
+			},
+			shouldErr: false,
+		},
+		{
+			msg: "gas costs for unknown operations should fail",
+			p: &ChangeParametersProposal{
+				Module:  ModuleName,
+				Changes: cbor.Marshal(&ConsensusParameterChanges{GasCosts: transaction.Costs{"unknown": 1000}}),
+			},
+			shouldErr: true,
+		},
+		{
+			msg: "gas costs for unknown modules should fail",
+			p: &ChangeParametersProposal{
+				Module:  "unknown",
+				Changes: cbor.Marshal(&ConsensusParameterChanges{GasCosts: transaction.Costs{GasOpCastVote: 1000}}),
+			},
+			shouldErr: true,
+		},
+		{
+			msg: "malformed changes should fail",
+			p: &ChangeParametersProposal{
+				Module:  ModuleName,
+				Changes: []byte{0xff},
+			},
+			shouldErr: true,
+		},
+	} {
+		err := tc.p.ValidateGasCosts()
+		if tc.shouldErr {
+			require.Error(t, err, tc.msg)
+			continue
+		}
+		require.NoError(t, err, tc.msg)
+	}
+}
+
 func TestProposalContentEquals(t *testing.T) {
 	for _, tc := range []struct {
 		msg    string
@@ -0,0 +1,146 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+// SignalingStatementSignatureContext is the context used for signing off-chain signaling
+// statements.
+var SignalingStatementSignatureContext = signature.NewContext(
+	"oasis-core/governance: signaling statement",
+	signature.WithChainSeparation(),
+)
+
+// SignalingStatement is an off-chain signaling statement made by an entity.
+//
+// Signaling statements can be used to run off-chain governance polls which are weighted by the
+// on-chain stake of the signing entities at a given consensus height, without going through a
+// full governance proposal cycle.
+type SignalingStatement struct {
+	// Poll is the identifier of the off-chain poll.
+	Poll string `json:"poll"`
+	// Height is the consensus height at which the stake of the signer is taken into account.
+	Height int64 `json:"height"`
+	// Choice is the choice of the signer.
+	Choice string `json:"choice"`
+}
+
+// ValidateBasic performs basic signaling statement validity checks.
+func (s *SignalingStatement) ValidateBasic() error {
+	if s.Poll == "" {
+		return fmt.Errorf("%w: missing poll identifier", ErrInvalidArgument)
+	}
+	if s.Height <= 0 {
+		return fmt.Errorf("%w: invalid stake snapshot height: %d", ErrInvalidArgument, s.Height)
+	}
+	if s.Choice == "" {
+		return fmt.Errorf("%w: missing choice", ErrInvalidArgument)
+	}
+	return nil
+}
+
+// SignedSignalingStatement is a signed off-chain signaling statement.
+type SignedSignalingStatement struct {
+	signature.Signed
+}
+
+// Open first verifies the blob signature and then unmarshals the blob.
+func (s *SignedSignalingStatement) Open(stmt *SignalingStatement) error {
+	return s.Signed.Open(SignalingStatementSignatureContext, stmt)
+}
+
+// SignSignalingStatement serializes the signaling statement and signs the result.
+func SignSignalingStatement(signer signature.Signer, stmt *SignalingStatement) (*SignedSignalingStatement, error) {
+	signed, err := signature.SignSigned(signer, SignalingStatementSignatureContext, stmt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SignedSignalingStatement{
+		Signed: *signed,
+	}, nil
+}
+
+// StakeQuerier is the interface used to look up the stake of signaling statement signers.
+//
+// It is implemented by the staking backend.
+type StakeQuerier interface {
+	// Account returns the account descriptor for the given account at the given height.
+	Account(ctx context.Context, query *staking.OwnerQuery) (*staking.Account, error)
+}
+
+// SignalingTally is the stake-weighted tally of an off-chain signaling poll.
+type SignalingTally struct {
+	// Poll is the identifier of the off-chain poll.
+	Poll string `json:"poll"`
+	// Height is the consensus height of the stake snapshot.
+	Height int64 `json:"height"`
+	// Choices is the total stake backing each of the choices.
+	Choices map[string]quantity.Quantity `json:"choices"`
+	// Signers is the stake of each of the signers, keyed by the signer's entity address.
+	Signers map[staking.Address]quantity.Quantity `json:"signers"`
+	// Total is the total stake of all signers.
+	Total quantity.Quantity `json:"total"`
+}
+
+// TallySignalingStatements verifies the given signed signaling statements for the given poll and
+// computes their tally, weighting each statement by the active escrow balance of the signing
+// entity at the given consensus height.
+//
+// All statements must be validly signed, refer to the given poll and height, and each entity may
+// only submit a single statement.
+func TallySignalingStatements(
+	ctx context.Context,
+	sq StakeQuerier,
+	poll string,
+	height int64,
+	statements []*SignedSignalingStatement,
+) (*SignalingTally, error) {
+	tally := &SignalingTally{
+		Poll:    poll,
+		Height:  height,
+		Choices: make(map[string]quantity.Quantity),
+		Signers: make(map[staking.Address]quantity.Quantity),
+	}
+
+	for i, signed := range statements {
+		var stmt SignalingStatement
+		if err := signed.Open(&stmt); err != nil {
+			return nil, fmt.Errorf("%w: statement %d: bad signature: %w", ErrInvalidArgument, i, err)
+		}
+		if err := stmt.ValidateBasic(); err != nil {
+			return nil, fmt.Errorf("statement %d: %w", i, err)
+		}
+		if stmt.Poll != poll || stmt.Height != height {
+			return nil, fmt.Errorf("%w: statement %d: poll or height mismatch", ErrInvalidArgument, i)
+		}
+
+		signer := staking.NewAddress(signed.Signature.PublicKey)
+		if _, ok := tally.Signers[signer]; ok {
+			return nil, fmt.Errorf("%w: statement %d: duplicate statement by %s", ErrInvalidArgument, i, signer)
+		}
+
+		account, err := sq.Account(ctx, &staking.OwnerQuery{Height: height, Owner: signer})
+		if err != nil {
+			return nil, fmt.Errorf("governance: failed to query account %s: %w", signer, err)
+		}
+		stake := account.Escrow.Active.Balance
+
+		choice := tally.Choices[stmt.Choice]
+		if err = choice.Add(&stake); err != nil {
+			return nil, fmt.Errorf("governance: failed to tally choice: %w", err)
+		}
+		tally.Choices[stmt.Choice] = choice
+		if err = tally.Total.Add(&stake); err != nil {
+			return nil, fmt.Errorf("governance: failed to tally total: %w", err)
+		}
+		tally.Signers[signer] = stake
+	}
+
+	return tally, nil
+}
@@ -0,0 +1,98 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	genesisTestHelpers "github.com/oasisprotocol/oasis-core/go/genesis/tests"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+type testStakeQuerier struct {
+	height int64
+	stake  map[staking.Address]uint64
+}
+
+func (sq *testStakeQuerier) Account(_ context.Context, query *staking.OwnerQuery) (*staking.Account, error) {
+	if query.Height != sq.height {
+		return nil, fmt.Errorf("unexpected height: %d", query.Height)
+	}
+
+	var account staking.Account
+	account.Escrow.Active.Balance = *quantity.NewFromUint64(sq.stake[query.Owner])
+	return &account, nil
+}
+
+func TestTallySignalingStatements(t *testing.T) {
+	require := require.New(t)
+
+	genesisTestHelpers.SetTestChainContext()
+
+	const (
+		poll   = "test poll"
+		height = int64(42)
+	)
+
+	signers := make([]signature.Signer, 3)
+	sq := &testStakeQuerier{
+		height: height,
+		stake:  make(map[staking.Address]uint64),
+	}
+	for i := range signers {
+		signers[i] = memorySigner.NewTestSigner(fmt.Sprintf("governance/api: signaling signer %d", i))
+		sq.stake[staking.NewAddress(signers[i].Public())] = uint64(100 * (i + 1))
+	}
+
+	sign := func(signer signature.Signer, stmt *SignalingStatement) *SignedSignalingStatement {
+		signed, err := SignSignalingStatement(signer, stmt)
+		require.NoError(err, "SignSignalingStatement")
+		return signed
+	}
+
+	statements := []*SignedSignalingStatement{
+		sign(signers[0], &SignalingStatement{Poll: poll, Height: height, Choice: "yes"}),
+		sign(signers[1], &SignalingStatement{Poll: poll, Height: height, Choice: "no"}),
+		sign(signers[2], &SignalingStatement{Poll: poll, Height: height, Choice: "yes"}),
+	}
+
+	tally, err := TallySignalingStatements(context.Background(), sq, poll, height, statements)
+	require.NoError(err, "TallySignalingStatements")
+	require.Len(tally.Signers, 3, "all signers should be counted")
+	require.EqualValues(quantity.NewFromUint64(600), &tally.Total, "total stake should be correct")
+	yes := tally.Choices["yes"]
+	require.EqualValues(quantity.NewFromUint64(400), &yes, "stake backing yes should be correct")
+	no := tally.Choices["no"]
+	require.EqualValues(quantity.NewFromUint64(200), &no, "stake backing no should be correct")
+
+	// Duplicate statements should be rejected.
+	dup := []*SignedSignalingStatement{
+		statements[0],
+		sign(signers[0], &SignalingStatement{Poll: poll, Height: height, Choice: "no"}),
+	}
+	_, err = TallySignalingStatements(context.Background(), sq, poll, height, dup)
+	require.ErrorIs(err, ErrInvalidArgument, "duplicate statements should be rejected")
+
+	// Statements for other polls or heights should be rejected.
+	for _, stmt := range []*SignalingStatement{
+		{Poll: "other poll", Height: height, Choice: "yes"},
+		{Poll: poll, Height: height + 1, Choice: "yes"},
+		{Poll: poll, Height: height, Choice: ""},
+	} {
+		_, err = TallySignalingStatements(context.Background(), sq, poll, height, []*SignedSignalingStatement{
+			sign(signers[0], stmt),
+		})
+		require.ErrorIs(err, ErrInvalidArgument, "invalid statements should be rejected")
+	}
+
+	// Tampered statements should be rejected.
+	tampered := sign(signers[0], &SignalingStatement{Poll: poll, Height: height, Choice: "yes"})
+	tampered.Signature.PublicKey = signers[1].Public()
+	_, err = TallySignalingStatements(context.Background(), sq, poll, height, []*SignedSignalingStatement{tampered})
+	require.ErrorIs(err, ErrInvalidArgument, "statements with invalid signatures should be rejected")
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 	"github.com/oasisprotocol/oasis-core/go/keymanager/churp"
 	"github.com/oasisprotocol/oasis-core/go/keymanager/secrets"
 )
@@ -81,4 +82,11 @@ func init() {
 
 	rek := x25519.PrivateKey(sha512.Sum512_256([]byte("ekiden test key manager REK seed")))
 	InsecureREK = *rek.Public()
+
+	transaction.RegisterGasOps(
+		ModuleName,
+		secrets.GasOpUpdatePolicy,
+		secrets.GasOpPublishMasterSecret,
+		secrets.GasOpPublishEphemeralSecret,
+	)
 }
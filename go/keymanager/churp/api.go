@@ -108,3 +108,13 @@ type StatusQuery struct {
 	RuntimeID common.Namespace `json:"runtime_id"`
 	ChurpID   uint8            `json:"churp_id"`
 }
+
+func init() {
+	transaction.RegisterGasOps(
+		ModuleName,
+		GasOpCreate,
+		GasOpUpdate,
+		GasOpApply,
+		GasOpConfirm,
+	)
+}
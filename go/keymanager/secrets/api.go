@@ -43,6 +43,9 @@ var (
 	// does not exist.
 	ErrNoSuchEphemeralSecret = errors.New(moduleName, 4, "keymanager: no such ephemeral secret")
 
+	// ErrNoSuchPolicy is the error returned when a key manager policy does not exist.
+	ErrNoSuchPolicy = errors.New(moduleName, 5, "keymanager: no such policy")
+
 	// MethodUpdatePolicy is the method name for policy updates.
 	MethodUpdatePolicy = transaction.NewMethodName(moduleName, "UpdatePolicy", SignedPolicySGX{})
 
@@ -150,6 +153,18 @@ type Status struct {
 	RSK *signature.PublicKey `json:"rsk,omitempty"`
 }
 
+// AuthorizedRuntimes is the set of runtimes authorized by the currently active key manager policy.
+type AuthorizedRuntimes struct {
+	// ID is the runtime ID of the key manager.
+	ID common.Namespace `json:"id"`
+
+	// PolicySerial is the serial number of the active policy.
+	PolicySerial uint32 `json:"policy_serial"`
+
+	// Runtimes are the runtimes authorized to query private keys.
+	Runtimes []*AuthorizedRuntime `json:"runtimes"`
+}
+
 // NextGeneration returns the generation of the next master secret.
 func (s *Status) NextGeneration() uint64 {
 	if len(s.Checksum) == 0 {
@@ -189,6 +204,10 @@ type Backend interface {
 	// GetStatus returns a key manager status by key manager ID.
 	GetStatus(context.Context, *registry.NamespaceQuery) (*Status, error)
 
+	// GetAuthorizedRuntimes returns the runtimes and enclave identities authorized by the
+	// currently active policy of the given key manager.
+	GetAuthorizedRuntimes(context.Context, *registry.NamespaceQuery) (*AuthorizedRuntimes, error)
+
 	// GetStatuses returns all currently tracked key manager statuses.
 	GetStatuses(context.Context, int64) ([]*Status, error)
 
@@ -16,6 +16,8 @@ var (
 
 	// methodGetStatus is the GetStatus method.
 	methodGetStatus = serviceName.NewMethod("GetStatus", registry.NamespaceQuery{})
+	// methodGetAuthorizedRuntimes is the GetAuthorizedRuntimes method.
+	methodGetAuthorizedRuntimes = serviceName.NewMethod("GetAuthorizedRuntimes", registry.NamespaceQuery{})
 	// methodGetStatuses is the GetStatuses method.
 	methodGetStatuses = serviceName.NewMethod("GetStatuses", int64(0))
 	// methodGetMasterSecret is the GetMasterSecret method.
@@ -39,6 +41,10 @@ var (
 				MethodName: methodGetStatus.ShortName(),
 				Handler:    handlerGetStatus,
 			},
+			{
+				MethodName: methodGetAuthorizedRuntimes.ShortName(),
+				Handler:    handlerGetAuthorizedRuntimes,
+			},
 			{
 				MethodName: methodGetStatuses.ShortName(),
 				Handler:    handlerGetStatuses,
@@ -95,6 +101,29 @@ func handlerGetStatus(
 	return interceptor(ctx, &query, info, handler)
 }
 
+func handlerGetAuthorizedRuntimes(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var query registry.NamespaceQuery
+	if err := dec(&query); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).GetAuthorizedRuntimes(ctx, &query)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetAuthorizedRuntimes.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).GetAuthorizedRuntimes(ctx, req.(*registry.NamespaceQuery))
+	}
+	return interceptor(ctx, &query, info, handler)
+}
+
 func handlerGetStatuses(
 	srv interface{},
 	ctx context.Context,
@@ -257,6 +286,14 @@ func (c *Client) GetStatus(ctx context.Context, query *registry.NamespaceQuery)
 	return &resp, nil
 }
 
+func (c *Client) GetAuthorizedRuntimes(ctx context.Context, query *registry.NamespaceQuery) (*AuthorizedRuntimes, error) {
+	var resp AuthorizedRuntimes
+	if err := c.conn.Invoke(ctx, methodGetAuthorizedRuntimes.FullName(), query, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 func (c *Client) GetStatuses(ctx context.Context, height int64) ([]*Status, error) {
 	var resp []*Status
 	if err := c.conn.Invoke(ctx, methodGetStatuses.FullName(), height, &resp); err != nil {
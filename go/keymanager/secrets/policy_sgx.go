@@ -1,7 +1,10 @@
 package secrets
 
 import (
+	"bytes"
 	"fmt"
+	"slices"
+	"strings"
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common"
@@ -50,6 +53,63 @@ type EnclavePolicySGX struct {
 	MayReplicate []sgx.EnclaveIdentity `json:"may_replicate"`
 }
 
+// AuthorizedRuntime is a runtime authorized to query private keys by a key manager policy.
+type AuthorizedRuntime struct {
+	// RuntimeID is the identifier of the authorized runtime.
+	RuntimeID common.Namespace `json:"runtime_id"`
+
+	// Enclaves are the runtime enclave identities that may query private keys.
+	Enclaves []sgx.EnclaveIdentity `json:"enclaves"`
+
+	// KeyManagerEnclaves are the key manager enclave identities that grant the access.
+	KeyManagerEnclaves []sgx.EnclaveIdentity `json:"key_manager_enclaves"`
+}
+
+// AuthorizedRuntimes returns the runtimes authorized to query private keys by the policy,
+// sorted by runtime identifier.
+func (p *PolicySGX) AuthorizedRuntimes() []*AuthorizedRuntime {
+	byID := make(map[common.Namespace]*AuthorizedRuntime)
+	for kmEnclave, enclavePolicy := range p.Enclaves {
+		if enclavePolicy == nil {
+			continue
+		}
+		for runtimeID, enclaves := range enclavePolicy.MayQuery {
+			rt, ok := byID[runtimeID]
+			if !ok {
+				rt = &AuthorizedRuntime{RuntimeID: runtimeID}
+				byID[runtimeID] = rt
+			}
+			rt.KeyManagerEnclaves = appendEnclaveIdentity(rt.KeyManagerEnclaves, kmEnclave)
+			for _, enclave := range enclaves {
+				rt.Enclaves = appendEnclaveIdentity(rt.Enclaves, enclave)
+			}
+		}
+	}
+
+	runtimes := make([]*AuthorizedRuntime, 0, len(byID))
+	for _, rt := range byID {
+		slices.SortFunc(rt.Enclaves, compareEnclaveIdentities)
+		slices.SortFunc(rt.KeyManagerEnclaves, compareEnclaveIdentities)
+		runtimes = append(runtimes, rt)
+	}
+	slices.SortFunc(runtimes, func(a, b *AuthorizedRuntime) int {
+		return bytes.Compare(a.RuntimeID[:], b.RuntimeID[:])
+	})
+
+	return runtimes
+}
+
+func appendEnclaveIdentity(ids []sgx.EnclaveIdentity, id sgx.EnclaveIdentity) []sgx.EnclaveIdentity {
+	if slices.Contains(ids, id) {
+		return ids
+	}
+	return append(ids, id)
+}
+
+func compareEnclaveIdentities(a, b sgx.EnclaveIdentity) int {
+	return strings.Compare(a.String(), b.String())
+}
+
 // SignedPolicySGX is a signed SGX key manager access control policy.
 type SignedPolicySGX struct {
 	Policy PolicySGX `json:"policy"`
@@ -0,0 +1,58 @@
+package secrets
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/sgx"
+)
+
+func TestPolicySGXAuthorizedRuntimes(t *testing.T) {
+	require := require.New(t)
+
+	var policy PolicySGX
+	require.Empty(policy.AuthorizedRuntimes(), "empty policy should not authorize any runtimes")
+
+	enclaveID := func(b byte) sgx.EnclaveIdentity {
+		var id sgx.EnclaveIdentity
+		id.MrEnclave[0] = b
+		id.MrSigner[0] = b
+		return id
+	}
+	kmEnclave1, kmEnclave2 := enclaveID(1), enclaveID(2)
+	rtEnclave1, rtEnclave2, rtEnclave3 := enclaveID(3), enclaveID(4), enclaveID(5)
+	runtimeID1 := common.NewTestNamespaceFromSeed([]byte("runtime 1"), 0)
+	runtimeID2 := common.NewTestNamespaceFromSeed([]byte("runtime 2"), 0)
+
+	policy = PolicySGX{
+		Serial: 1,
+		Enclaves: map[sgx.EnclaveIdentity]*EnclavePolicySGX{
+			kmEnclave1: {
+				MayQuery: map[common.Namespace][]sgx.EnclaveIdentity{
+					runtimeID1: {rtEnclave1, rtEnclave2},
+					runtimeID2: {rtEnclave3},
+				},
+			},
+			kmEnclave2: {
+				MayQuery: map[common.Namespace][]sgx.EnclaveIdentity{
+					runtimeID1: {rtEnclave2},
+				},
+			},
+		},
+	}
+
+	runtimes := policy.AuthorizedRuntimes()
+	require.Len(runtimes, 2)
+	byID := make(map[common.Namespace]*AuthorizedRuntime)
+	for _, rt := range runtimes {
+		byID[rt.RuntimeID] = rt
+	}
+
+	require.ElementsMatch([]sgx.EnclaveIdentity{rtEnclave1, rtEnclave2}, byID[runtimeID1].Enclaves)
+	require.ElementsMatch([]sgx.EnclaveIdentity{kmEnclave1, kmEnclave2}, byID[runtimeID1].KeyManagerEnclaves)
+	require.ElementsMatch([]sgx.EnclaveIdentity{rtEnclave3}, byID[runtimeID2].Enclaves)
+	require.ElementsMatch([]sgx.EnclaveIdentity{kmEnclave1}, byID[runtimeID2].KeyManagerEnclaves)
+	require.Equal(runtimes, policy.AuthorizedRuntimes(), "result should be deterministic")
+}
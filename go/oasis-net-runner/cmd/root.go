@@ -200,8 +200,10 @@ func init() {
 	rootCmd.PersistentFlags().AddFlagSet(env.Flags)
 	rootCmd.Flags().AddFlagSet(fixtures.DefaultFixtureFlags)
 	rootCmd.Flags().AddFlagSet(fixtures.FileFixtureFlags)
+	rootCmd.Flags().AddFlagSet(fixtures.GenesisFixtureFlags)
 
 	dumpFixtureCmd.Flags().AddFlagSet(fixtures.DefaultFixtureFlags)
+	dumpFixtureCmd.Flags().AddFlagSet(fixtures.GenesisFixtureFlags)
 	rootCmd.AddCommand(dumpFixtureCmd)
 
 	cobra.OnInitialize(func() {
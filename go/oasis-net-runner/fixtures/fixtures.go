@@ -18,11 +18,15 @@ var (
 	DefaultFixtureFlags = flag.NewFlagSet("", flag.ContinueOnError)
 )
 
-// GetFixture generates fixture object from given file or default fixture, if no fixtures file provided.
+// GetFixture generates fixture object from given file, from given genesis document or default
+// fixture, if neither is provided.
 func GetFixture() (f *oasis.NetworkFixture, err error) {
-	if viper.IsSet(cfgFile) {
+	switch {
+	case viper.IsSet(cfgFile):
 		f, err = newFixtureFromFile(viper.GetString(cfgFile))
-	} else {
+	case viper.GetString(cfgGenesisFile) != "":
+		f, err = newFixtureFromGenesis(viper.GetString(cfgGenesisFile))
+	default:
 		f, err = newDefaultFixture()
 	}
 	if err != nil {
@@ -1,13 +1,21 @@
 package fixtures
 
 import (
+	"encoding/json"
+	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/entity"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 	consensusGenesis "github.com/oasisprotocol/oasis-core/go/consensus/genesis"
+	genesis "github.com/oasisprotocol/oasis-core/go/genesis/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
 )
 
 func TestDefaultFixture(t *testing.T) {
@@ -39,3 +47,55 @@ func TestCustomFixture(t *testing.T) {
 	require.Nil(t, err)
 	require.EqualValues(t, f, fs)
 }
+
+func TestGenesisFixture(t *testing.T) {
+	require := require.New(t)
+
+	doc := genesis.Document{
+		Height: 42,
+		Staking: staking.Genesis{
+			TokenSymbol: "TEST",
+			Ledger:      make(map[staking.Address]*staking.Account),
+		},
+	}
+	doc.Consensus.Backend = "myConsensusBackend"
+	doc.Governance.Parameters.VotingPeriod = 123
+	for i, stake := range []uint64{10, 0, 30, 20} {
+		pk := signature.NewPublicKey(fmt.Sprintf("%064x", i+1))
+		doc.Registry.Entities = append(doc.Registry.Entities, &entity.SignedEntity{
+			Signed: signature.Signed{Signature: signature.Signature{PublicKey: pk}},
+		})
+		var acct staking.Account
+		acct.Escrow.Active.Balance = *quantity.NewFromUint64(stake)
+		doc.Staking.Ledger[staking.NewAddress(pk)] = &acct
+	}
+
+	data, err := json.Marshal(&doc)
+	require.NoError(err)
+	path := filepath.Join(t.TempDir(), "genesis.json")
+	require.NoError(os.WriteFile(path, data, 0o600))
+
+	f, err := newFixtureFromGenesis(path)
+	require.NoError(err)
+	require.EqualValues(42, f.Network.InitialHeight)
+	require.Equal("myConsensusBackend", f.Network.Consensus.Backend)
+	require.EqualValues(123, f.Network.GovernanceParameters.VotingPeriod)
+	require.Equal("TEST", f.Network.StakingGenesis.TokenSymbol)
+	require.True(f.Network.FundEntities)
+
+	// Entities without stake are skipped, the rest is sorted by stake.
+	require.Len(f.Entities, 4)
+	require.True(f.Entities[0].IsDebugTestEntity)
+	for i, stake := range []uint64{30, 20, 10} {
+		require.EqualValues(quantity.NewFromUint64(stake), f.Entities[i+1].Stake)
+		require.Equal(i+1, f.Validators[i].Entity)
+	}
+
+	f, err = fixtureFromGenesis(&doc, 2)
+	require.NoError(err)
+	require.Len(f.Entities, 3)
+	require.Len(f.Validators, 2)
+
+	_, err = fixtureFromGenesis(&doc, 0)
+	require.Error(err)
+}
@@ -0,0 +1,140 @@
+package fixtures
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	genesis "github.com/oasisprotocol/oasis-core/go/genesis/api"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/oasis"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+const (
+	cfgGenesisFile        = "fixture.genesis.file"
+	cfgGenesisNumEntities = "fixture.genesis.num_entities"
+)
+
+// GenesisFixtureFlags are command line flags for the fixture.genesis.* flags.
+var GenesisFixtureFlags = flag.NewFlagSet("", flag.ContinueOnError)
+
+// newFixtureFromGenesis generates a fixture replicating the parameters and key entities of the
+// network described by the genesis document (or exported state) at the given path.
+//
+// Key entities are the entities with the largest active escrow balance. They are replaced with
+// entities using debug keys which are funded with the same stake, each running a validator.
+func newFixtureFromGenesis(path string) (*oasis.NetworkFixture, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("newFixtureFromGenesis: failed to open genesis file: %w", err)
+	}
+	var doc genesis.Document
+	if err = json.Unmarshal(raw, &doc); err != nil {
+		return nil, fmt.Errorf("newFixtureFromGenesis: failed to unmarshal genesis document: %w", err)
+	}
+
+	return fixtureFromGenesis(&doc, viper.GetInt(cfgGenesisNumEntities))
+}
+
+func fixtureFromGenesis(doc *genesis.Document, numEntities int) (*oasis.NetworkFixture, error) {
+	if numEntities < 1 {
+		return nil, fmt.Errorf("fixtureFromGenesis: at least one entity is required")
+	}
+
+	governanceParams := doc.Governance.Parameters
+	roothashParams := doc.RootHash.Parameters
+
+	fixture := &oasis.NetworkFixture{
+		TEE: oasis.TEEFixture{
+			Hardware: node.TEEHardwareInvalid,
+		},
+		Network: oasis.NetworkCfg{
+			NodeBinary:              viper.GetString(cfgNodeBinary),
+			RuntimeSGXLoaderBinary:  viper.GetString(cfgRuntimeLoader),
+			Consensus:               doc.Consensus,
+			Beacon:                  doc.Beacon.Parameters,
+			InitialHeight:           doc.Height,
+			HaltEpoch:               viper.GetUint64(cfgHaltEpoch),
+			DeterministicIdentities: true,
+			FundEntities:            true,
+			IAS: oasis.IASCfg{
+				Mock: true,
+			},
+			StakingGenesis: &staking.Genesis{
+				Parameters:         doc.Staking.Parameters,
+				TokenSymbol:        doc.Staking.TokenSymbol,
+				TokenValueExponent: doc.Staking.TokenValueExponent,
+			},
+			GovernanceParameters: &governanceParams,
+			RoothashParameters:   &roothashParams,
+		},
+		Entities: []oasis.EntityCfg{
+			{IsDebugTestEntity: true},
+		},
+		Seeds:   []oasis.SeedFixture{{}},
+		Clients: []oasis.ClientFixture{{}},
+	}
+	if tf := doc.Registry.Parameters.TEEFeatures; tf != nil {
+		fixture.Network.RuntimeDefaultMaxAttestationAge = tf.SGX.DefaultMaxAttestationAge
+	}
+
+	for i, stake := range keyEntityStakes(doc, numEntities) {
+		fixture.Entities = append(fixture.Entities, oasis.EntityCfg{Stake: stake})
+		fixture.Validators = append(fixture.Validators, oasis.ValidatorFixture{Entity: i + 1})
+	}
+	if len(fixture.Validators) == 0 {
+		// Make sure the network can make progress even if no entity has any stake.
+		fixture.Entities = append(fixture.Entities, oasis.EntityCfg{})
+		fixture.Validators = append(fixture.Validators, oasis.ValidatorFixture{Entity: 1})
+	}
+
+	return fixture, nil
+}
+
+// keyEntityStakes returns the active escrow balances of at most n registered entities with the
+// largest active escrow balance, in descending order.
+func keyEntityStakes(doc *genesis.Document, n int) []*quantity.Quantity {
+	type entityStake struct {
+		addr  staking.Address
+		stake *quantity.Quantity
+	}
+
+	var stakes []entityStake
+	for _, sigEnt := range doc.Registry.Entities {
+		addr := staking.NewAddress(sigEnt.Signature.PublicKey)
+		acct, ok := doc.Staking.Ledger[addr]
+		if !ok || acct.Escrow.Active.Balance.IsZero() {
+			continue
+		}
+		stakes = append(stakes, entityStake{addr, acct.Escrow.Active.Balance.Clone()})
+	}
+	sort.Slice(stakes, func(i, j int) bool {
+		if c := stakes[i].stake.Cmp(stakes[j].stake); c != 0 {
+			return c > 0
+		}
+		// Break ties deterministically.
+		return bytes.Compare(stakes[i].addr[:], stakes[j].addr[:]) < 0
+	})
+	if len(stakes) > n {
+		stakes = stakes[:n]
+	}
+
+	result := make([]*quantity.Quantity, 0, len(stakes))
+	for _, s := range stakes {
+		result = append(result, s.stake)
+	}
+	return result
+}
+
+func init() {
+	GenesisFixtureFlags.String(cfgGenesisFile, "", "path to a genesis document (or exported state) of a network to replicate")
+	GenesisFixtureFlags.Int(cfgGenesisNumEntities, 4, "number of key entities to replicate")
+	_ = viper.BindPFlags(GenesisFixtureFlags)
+}
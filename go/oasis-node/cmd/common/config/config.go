@@ -7,6 +7,8 @@ type Config struct {
 	DataDir string `yaml:"data_dir"`
 	// Path to the node's internal unix socket.
 	InternalSocketPath string `yaml:"internal_socket_path,omitempty"`
+	// Serve queries only, refusing to submit transactions, register or participate in committees.
+	ReadOnly bool `yaml:"read_only,omitempty"`
 	// Logging configuration options.
 	Log LogConfig `yaml:"log,omitempty"`
 	// Debug configuration options (do not use).
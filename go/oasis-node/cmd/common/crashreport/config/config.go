@@ -0,0 +1,43 @@
+// Package config implements global configuration options.
+package config
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// Config is the crash report configuration structure.
+type Config struct {
+	// Enable writing crash reports to the data directory.
+	Enabled bool `yaml:"enabled"`
+	// Optional endpoint to which crash reports are submitted via HTTP POST.
+	Endpoint string `yaml:"endpoint,omitempty"`
+	// Number of the most recent log lines included in crash reports.
+	LogLines int `yaml:"log_lines,omitempty"`
+}
+
+// Validate validates the configuration settings.
+func (c *Config) Validate() error {
+	if c.LogLines < 0 {
+		return fmt.Errorf("log_lines must not be negative")
+	}
+	if c.Endpoint != "" {
+		u, err := url.Parse(c.Endpoint)
+		if err != nil {
+			return fmt.Errorf("malformed endpoint: %w", err)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return fmt.Errorf("endpoint must be an HTTP or HTTPS URL")
+		}
+	}
+	return nil
+}
+
+// DefaultConfig returns the default configuration settings.
+func DefaultConfig() Config {
+	return Config{
+		Enabled:  false,
+		Endpoint: "",
+		LogLines: 100,
+	}
+}
@@ -0,0 +1,361 @@
+// Package crashreport implements structured crash report generation.
+package crashreport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/version"
+	"github.com/oasisprotocol/oasis-core/go/config"
+)
+
+const (
+	// reportDir is the name of the directory (relative to the data directory) where crash
+	// reports are stored.
+	reportDir = "crash-reports"
+	// crashOutputFile is the name of the file in the report directory where the Go runtime
+	// writes the output of fatal errors in any goroutine.
+	crashOutputFile = "crash-output.log"
+
+	// submitTimeout is the timeout for submitting a crash report.
+	submitTimeout = 10 * time.Second
+	// heightTimeout is the timeout for querying the consensus height.
+	heightTimeout = time.Second
+	// maxLogTailSize is the maximum number of bytes read from the end of the log file.
+	maxLogTailSize = 1024 * 1024
+	// maxStackSize is the maximum size of the goroutine dump.
+	maxStackSize = 16 * 1024 * 1024
+	// redactedValue is the value of redacted configuration options.
+	redactedValue = "<redacted>"
+)
+
+// sensitiveKeyRegexp matches configuration option names whose values are redacted.
+var sensitiveKeyRegexp = regexp.MustCompile(`(?i)(secret|password|passphrase|token|spid|api_key|auth|private)`)
+
+// Report is a structured crash report.
+type Report struct {
+	// Time is the time the report was generated at.
+	Time time.Time `json:"time"`
+	// Version is the software version of the node.
+	Version string `json:"version"`
+	// GoVersion is the version of the Go runtime.
+	GoVersion string `json:"go_version"`
+	// Config is the node configuration with sensitive values redacted.
+	Config map[string]interface{} `json:"config,omitempty"`
+	// ConsensusHeight is the latest known consensus height, if available.
+	ConsensusHeight int64 `json:"consensus_height,omitempty"`
+	// Panic is the panic value or the first line of the fatal error output.
+	Panic string `json:"panic"`
+	// Goroutines is the dump of all goroutines.
+	Goroutines string `json:"goroutines"`
+	// LogLines are the most recent log lines.
+	LogLines []string `json:"log_lines,omitempty"`
+}
+
+// HeightSource returns the latest known consensus height.
+type HeightSource func(ctx context.Context) (int64, error)
+
+// Reporter generates crash reports.
+type Reporter struct {
+	sync.Mutex
+
+	dir          string
+	heightSource HeightSource
+
+	crashOutput *os.File
+
+	logger *logging.Logger
+}
+
+// SetHeightSource sets the source of the consensus height included in crash reports.
+func (r *Reporter) SetHeightSource(src HeightSource) {
+	if r == nil {
+		return
+	}
+
+	r.Lock()
+	defer r.Unlock()
+	r.heightSource = src
+}
+
+// Recover generates a crash report in case the calling goroutine is panicking and re-panics.
+//
+// It must be called directly via defer.
+func (r *Reporter) Recover() {
+	if r == nil {
+		return
+	}
+	p := recover()
+	if p == nil {
+		return
+	}
+
+	stack := make([]byte, maxStackSize)
+	stack = stack[:runtime.Stack(stack, true)]
+
+	// Prevent the runtime from writing the same crash again when re-panicking.
+	r.disableCrashOutput()
+
+	report := r.newReport(fmt.Sprintf("panic: %v", p), string(stack))
+	report.ConsensusHeight = r.consensusHeight()
+	r.handleReport(report)
+
+	panic(p)
+}
+
+// Close stops capturing the output of fatal errors.
+func (r *Reporter) Close() {
+	if r == nil {
+		return
+	}
+	r.disableCrashOutput()
+}
+
+func (r *Reporter) disableCrashOutput() {
+	r.Lock()
+	defer r.Unlock()
+
+	if r.crashOutput == nil {
+		return
+	}
+	_ = debug.SetCrashOutput(nil, debug.CrashOptions{})
+	_ = r.crashOutput.Close()
+	_ = os.Remove(r.crashOutput.Name())
+	r.crashOutput = nil
+}
+
+func (r *Reporter) consensusHeight() int64 {
+	r.Lock()
+	src := r.heightSource
+	r.Unlock()
+	if src == nil {
+		return 0
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), heightTimeout)
+	defer cancel()
+	height, err := src(ctx)
+	if err != nil {
+		return 0
+	}
+	return height
+}
+
+func (r *Reporter) newReport(panicMsg, goroutines string) *Report {
+	report := &Report{
+		Time:       time.Now(),
+		Version:    version.SoftwareVersion,
+		GoVersion:  runtime.Version(),
+		Panic:      panicMsg,
+		Goroutines: goroutines,
+	}
+
+	cfg, err := redactedConfig(&config.GlobalConfig)
+	if err != nil {
+		r.logger.Warn("failed to include configuration in crash report",
+			"err", err,
+		)
+	}
+	report.Config = cfg
+
+	if logFile := config.GlobalConfig.Common.Log.File; logFile != "" {
+		if report.LogLines, err = lastLines(logFile, config.GlobalConfig.CrashReport.LogLines); err != nil {
+			r.logger.Warn("failed to include log lines in crash report",
+				"err", err,
+			)
+		}
+	}
+
+	return report
+}
+
+func (r *Reporter) handleReport(report *Report) {
+	raw, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		r.logger.Error("failed to marshal crash report",
+			"err", err,
+		)
+		return
+	}
+
+	fn := filepath.Join(r.dir, fmt.Sprintf("crash-%s.json", report.Time.UTC().Format("20060102T150405.000000000Z")))
+	if err = os.WriteFile(fn, raw, 0o600); err != nil {
+		r.logger.Error("failed to write crash report",
+			"err", err,
+			"path", fn,
+		)
+	} else {
+		r.logger.Error("crash report written",
+			"path", fn,
+		)
+	}
+
+	if endpoint := config.GlobalConfig.CrashReport.Endpoint; endpoint != "" {
+		if err = submitReport(endpoint, raw); err != nil {
+			r.logger.Error("failed to submit crash report",
+				"err", err,
+				"endpoint", endpoint,
+			)
+		}
+	}
+}
+
+// processPreviousCrash generates a crash report from the fatal error output of the previous
+// run, if any.
+func (r *Reporter) processPreviousCrash() error {
+	fn := filepath.Join(r.dir, crashOutputFile)
+	output, err := os.ReadFile(fn)
+	switch {
+	case os.IsNotExist(err):
+		return nil
+	case err != nil:
+		return err
+	case len(bytes.TrimSpace(output)) == 0:
+		return nil
+	}
+
+	panicMsg, _, _ := strings.Cut(strings.TrimSpace(string(output)), "\n")
+	r.logger.Warn("previous run crashed, generating crash report",
+		"panic", panicMsg,
+	)
+	r.handleReport(r.newReport(panicMsg, string(output)))
+
+	return os.Remove(fn)
+}
+
+// New creates a new crash reporter if crash reports are enabled. In this case the output of
+// fatal errors in any goroutine is captured and turned into a crash report on the next start.
+func New(dataDir string) (*Reporter, error) {
+	if !config.GlobalConfig.CrashReport.Enabled {
+		return nil, nil
+	}
+
+	r := &Reporter{
+		dir:    filepath.Join(dataDir, reportDir),
+		logger: logging.GetLogger("crashreport"),
+	}
+	if err := os.MkdirAll(r.dir, 0o700); err != nil {
+		return nil, fmt.Errorf("crashreport: failed to create report directory: %w", err)
+	}
+	if err := r.processPreviousCrash(); err != nil {
+		return nil, fmt.Errorf("crashreport: failed to process previous crash: %w", err)
+	}
+
+	f, err := os.OpenFile(filepath.Join(r.dir, crashOutputFile), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("crashreport: failed to open crash output file: %w", err)
+	}
+	if err = debug.SetCrashOutput(f, debug.CrashOptions{}); err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("crashreport: failed to set crash output: %w", err)
+	}
+	r.crashOutput = f
+
+	return r, nil
+}
+
+func submitReport(endpoint string, raw []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), submitTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(raw))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	rsp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer rsp.Body.Close()
+	_, _ = io.Copy(io.Discard, rsp.Body)
+
+	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code: %d", rsp.StatusCode)
+	}
+	return nil
+}
+
+// redactedConfig returns the given configuration as a generic map with sensitive values
+// redacted.
+func redactedConfig(cfg *config.Config) (map[string]interface{}, error) {
+	raw, err := yaml.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]interface{}
+	if err = yaml.Unmarshal(raw, &m); err != nil {
+		return nil, err
+	}
+	redact(m)
+	return m, nil
+}
+
+func redact(v interface{}) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, val := range v {
+			if sensitiveKeyRegexp.MatchString(k) {
+				v[k] = redactedValue
+				continue
+			}
+			redact(val)
+		}
+	case []interface{}:
+		for _, val := range v {
+			redact(val)
+		}
+	}
+}
+
+// lastLines returns at most n last lines of the given file.
+func lastLines(fn string, n int) ([]string, error) {
+	if n <= 0 {
+		return nil, nil
+	}
+
+	f, err := os.Open(fn)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	offset := max(fi.Size()-maxLogTailSize, 0)
+	if _, err = f.Seek(offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	if offset > 0 && len(lines) > 0 {
+		// The first line is likely incomplete.
+		lines = lines[1:]
+	}
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return lines, nil
+}
@@ -0,0 +1,70 @@
+package crashreport
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedact(t *testing.T) {
+	require := require.New(t)
+
+	cfg := map[string]interface{}{
+		"mode": "client",
+		"ias": map[string]interface{}{
+			"spid":         "0123456789abcdef",
+			"api_key":      "secret",
+			"is_sandbox":   true,
+			"debug_skip":   false,
+			"grpc_address": "127.0.0.1:1234",
+		},
+		"runtime": map[string]interface{}{
+			"paths": []interface{}{"/a", "/b"},
+			"sgx_loaders": []interface{}{
+				map[string]interface{}{"auth_token": "x"},
+			},
+		},
+	}
+	redact(cfg)
+
+	require.Equal("client", cfg["mode"])
+	ias := cfg["ias"].(map[string]interface{})
+	require.Equal(redactedValue, ias["spid"])
+	require.Equal(redactedValue, ias["api_key"])
+	require.Equal(true, ias["is_sandbox"])
+	require.Equal("127.0.0.1:1234", ias["grpc_address"])
+	rt := cfg["runtime"].(map[string]interface{})
+	require.Equal([]interface{}{"/a", "/b"}, rt["paths"])
+	require.Equal(redactedValue, rt["sgx_loaders"].([]interface{})[0].(map[string]interface{})["auth_token"])
+}
+
+func TestLastLines(t *testing.T) {
+	require := require.New(t)
+
+	fn := filepath.Join(t.TempDir(), "node.log")
+	var sb strings.Builder
+	for i := 0; i < 10; i++ {
+		fmt.Fprintf(&sb, "line %d\n", i)
+	}
+	err := os.WriteFile(fn, []byte(sb.String()), 0o600)
+	require.NoError(err, "WriteFile")
+
+	lines, err := lastLines(fn, 3)
+	require.NoError(err, "lastLines")
+	require.Equal([]string{"line 7", "line 8", "line 9"}, lines)
+
+	lines, err = lastLines(fn, 100)
+	require.NoError(err, "lastLines")
+	require.Len(lines, 10)
+
+	lines, err = lastLines(fn, 0)
+	require.NoError(err, "lastLines")
+	require.Empty(lines)
+
+	_, err = lastLines(filepath.Join(t.TempDir(), "missing.log"), 3)
+	require.Error(err, "lastLines should fail on missing file")
+}
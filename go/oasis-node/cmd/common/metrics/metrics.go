@@ -31,6 +31,7 @@ const (
 	MetricsLabelGitBranch       = "git_branch"
 	MetricsLabelInstance        = "instance"
 	MetricsLabelRun             = "run"
+	MetricsLabelRunID           = "run_id"
 	MetricsLabelSoftwareVersion = "software_version"
 	MetricsLabelScenario        = "scenario"
 
@@ -286,6 +287,9 @@ func GetDefaultPushLabels(ti *env.ScenarioInstanceInfo) map[string]string {
 	if version.GitBranch != "" {
 		labels[MetricsLabelGitBranch] = version.GitBranch
 	}
+	if ti.RunID != "" {
+		labels[MetricsLabelRunID] = ti.RunID
+	}
 	// Populate it with test-provided parameters.
 	if ti.ParameterSet != nil {
 		ti.ParameterSet.VisitAll(func(f *flag.Flag) {
@@ -295,19 +299,22 @@ func GetDefaultPushLabels(ti *env.ScenarioInstanceInfo) map[string]string {
 		for k, v := range config.GlobalConfig.Metrics.Labels {
 			labels[k] = v
 		}
+	}
+	for k, v := range ti.MetricsLabels {
+		labels[k] = v
+	}
 
-		// Remove empty label values - workaround for
-		// https://github.com/prometheus/pushgateway/issues/344
-		var emptyKeys []string
-		for k, v := range labels {
-			if v == "" {
-				emptyKeys = append(emptyKeys, k)
-			}
-		}
-		for _, k := range emptyKeys {
-			delete(labels, k)
+	// Remove empty label values - workaround for
+	// https://github.com/prometheus/pushgateway/issues/344
+	var emptyKeys []string
+	for k, v := range labels {
+		if v == "" {
+			emptyKeys = append(emptyKeys, k)
 		}
 	}
+	for _, k := range emptyKeys {
+		delete(labels, k)
+	}
 
 	return labels
 }
@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/env"
 )
 
 func TestEscapeLabelCharacters(t *testing.T) {
@@ -21,3 +23,27 @@ func TestEscapeLabelCharacters(t *testing.T) {
 		require.EqualValues(tc.expected, EscapeLabelCharacters(tc.input))
 	}
 }
+
+func TestGetDefaultPushLabels(t *testing.T) {
+	require := require.New(t)
+
+	labels := GetDefaultPushLabels(&env.ScenarioInstanceInfo{
+		Scenario: "e2e/runtime/runtime",
+		Instance: "oasis-test-runner123",
+		Run:      2,
+		RunID:    "nightly-42",
+		MetricsLabels: map[string]string{
+			"pipeline": "nightly",
+			"empty":    "",
+		},
+	})
+	require.Equal("e2e/runtime/runtime", labels[MetricsLabelScenario])
+	require.Equal("oasis-test-runner123", labels[MetricsLabelInstance])
+	require.Equal("2", labels[MetricsLabelRun])
+	require.Equal("nightly-42", labels[MetricsLabelRunID])
+	require.Equal("nightly", labels["pipeline"])
+	require.NotContains(labels, "empty", "empty labels should be removed")
+
+	labels = GetDefaultPushLabels(&env.ScenarioInstanceInfo{Scenario: "e2e/byzantine"})
+	require.NotContains(labels, MetricsLabelRunID, "run ID label should only be set when configured")
+}
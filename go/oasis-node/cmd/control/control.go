@@ -10,6 +10,7 @@ import (
 	"github.com/spf13/cobra"
 	"google.golang.org/grpc"
 
+	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/persistent"
 	control "github.com/oasisprotocol/oasis-core/go/control/api"
@@ -64,6 +65,14 @@ var (
 		Run:   doCancelUpgrade,
 	}
 
+	controlCommitteeAuditCmd = &cobra.Command{
+		Use:       "committee-audit <runtime-id> <enable|disable>",
+		Short:     "enable or disable auditing of committee messages received by the executor",
+		Args:      cobra.ExactArgs(2),
+		ValidArgs: []string{"enable", "disable"},
+		Run:       doCommitteeAudit,
+	}
+
 	controlStatusCmd = &cobra.Command{
 		Use:   "status",
 		Short: "show node status",
@@ -268,6 +277,44 @@ func doCancelUpgrade(cmd *cobra.Command, args []string) {
 	}
 }
 
+func doCommitteeAudit(cmd *cobra.Command, args []string) {
+	var runtimeID common.Namespace
+	if err := runtimeID.UnmarshalText([]byte(args[0])); err != nil {
+		logger.Error("malformed runtime ID",
+			"err", err,
+			"arg", args[0],
+		)
+		os.Exit(1)
+	}
+
+	var enabled bool
+	switch args[1] {
+	case "enable":
+		enabled = true
+	case "disable":
+		enabled = false
+	default:
+		logger.Error("expected enable or disable",
+			"arg", args[1],
+		)
+		os.Exit(1)
+	}
+
+	conn, client := DoConnect(cmd)
+	defer conn.Close()
+
+	err := client.SetCommitteeAudit(context.Background(), &control.SetCommitteeAuditRequest{
+		RuntimeID: runtimeID,
+		Enabled:   enabled,
+	})
+	if err != nil {
+		logger.Error("failed to toggle committee message auditing",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+}
+
 // DoFetchStatus connects to the node's gRPC server and fetches its status.
 func DoFetchStatus(cmd *cobra.Command) *control.Status {
 	conn, client := DoConnect(cmd)
@@ -309,6 +356,7 @@ func Register(parentCmd *cobra.Command) {
 	controlCmd.AddCommand(controlClearDeregisterCmd)
 	controlCmd.AddCommand(controlUpgradeBinaryCmd)
 	controlCmd.AddCommand(controlCancelUpgradeCmd)
+	controlCmd.AddCommand(controlCommitteeAuditCmd)
 	controlCmd.AddCommand(controlStatusCmd)
 	controlCmd.AddCommand(controlRuntimeStatsCmd)
 	parentCmd.AddCommand(controlCmd)
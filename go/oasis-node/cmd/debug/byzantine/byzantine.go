@@ -18,7 +18,6 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/sgx/pcs"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/grpc"
-	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/commitment"
 	"github.com/oasisprotocol/oasis-core/go/runtime/transaction"
 	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
@@ -43,6 +42,8 @@ const (
 	CfgExecutorProposeBogusTx = "executor.propose_bogus_tx"
 	// CfgVRFBeaconMode configures the byzantine VRF beacon mode.
 	CfgVRFBeaconMode = "vrf_beacon_mode"
+	// CfgKeymanagerMode configures the byzantine key manager mode.
+	CfgKeymanagerMode = "keymanager.mode"
 
 	defaultRuntimeIDHex = "8000000000000000000000000000000000000000000000000000000000000000"
 )
@@ -120,6 +121,11 @@ var (
 		Short: "act as a validator (for VRF beacon testing)",
 		Run:   doVRFBeaconScenario,
 	}
+	keymanagerCmd = &cobra.Command{
+		Use:   "keymanager",
+		Short: "act as a key manager",
+		Run:   doKeymanagerScenario,
+	}
 )
 
 func activateCommonConfig(*cobra.Command, []string) {
@@ -131,7 +137,7 @@ func activateCommonConfig(*cobra.Command, []string) {
 	pcs.SetAllowDebugEnclaves()
 }
 
-func doExecutorScenario(*cobra.Command, []string) { //nolint: gocyclo
+func doExecutorScenario(*cobra.Command, []string) {
 	ctx := context.Background()
 
 	var runtimeID common.Namespace
@@ -139,21 +145,20 @@ func doExecutorScenario(*cobra.Command, []string) { //nolint: gocyclo
 		panic(fmt.Errorf("error initializing node: failed to parse runtime ID: %w", err))
 	}
 
-	// Validate executor mode.
-	var executorMode ExecutorMode
-	if err := executorMode.FromString(viper.GetString(CfgExecutorMode)); err != nil {
-		panic(err)
+	// Load the executor script.
+	script, err := scriptFromConfig()
+	if err != nil {
+		panic(fmt.Errorf("error initializing node: failed to load executor script: %w", err))
 	}
 
-	round := uint64(3)
-	isTxScheduler := viper.GetBool(CfgPrimarySchedulerExpected)
+	first := script.Rounds[0]
 	b, err := initializeAndRegisterByzantineNode(
 		runtimeID,
 		node.RoleComputeWorker,
 		scheduler.RoleWorker,
-		isTxScheduler,
+		first.PrimarySchedulerExpected,
 		false,
-		round,
+		first.Round,
 	)
 	if err != nil {
 		panic(fmt.Sprintf("error initializing node: %+v", err))
@@ -162,40 +167,61 @@ func doExecutorScenario(*cobra.Command, []string) { //nolint: gocyclo
 		_ = b.stop()
 	}()
 
-	if executorMode == ModeExecutorStraggler {
-		logger.Debug("executor straggler: stopping")
-		return
+	for _, rs := range script.Rounds {
+		if err = b.executeRound(ctx, rs); err != nil {
+			panic(fmt.Sprintf("executor round %d failed: %+v", rs.Round, err))
+		}
+	}
+
+	// If this is supposed to be a storage node, keep it running forever.
+	if script.KeepRunning {
+		select {}
+	}
+}
+
+// executeRound acts as an executor in the given round, as described by the round script.
+func (b *byzantine) executeRound(ctx context.Context, rs *RoundScript) error { //nolint: gocyclo
+	logger.Debug("executor: starting round",
+		"round", rs.Round,
+		"mode", rs.Mode,
+	)
+	b.storage.setMisbehavior(rs.CorruptGetDiff, rs.FailReadRequests)
+
+	if rs.Mode == ModeExecutorStraggler {
+		logger.Debug("executor straggler: skipping round", "round", rs.Round)
+		return nil
 	}
 
-	// Get the latest roothash block.
-	var blk *block.Block
-	blk, err = getRoothashLatestBlock(ctx, b.cometbft.service, runtimeID)
+	// Wait for the block preceding the round.
+	blk, err := waitRoothashBlock(ctx, b.cometbft.service, b.runtimeID, rs.Round-1)
 	if err != nil {
-		panic(fmt.Errorf("failed getting latest roothash block: %w", err))
+		return fmt.Errorf("failed waiting for roothash block: %w", err)
 	}
-	if blk.Header.Round != round-1 {
-		panic(fmt.Errorf("latest roothash block has invalid round (expected %d, got %d)", round-1, blk.Header.Round))
+
+	isTxScheduler := schedulerCheckPrimaryScheduler(b.executorCommittee, b.identity.NodeSigner.Public(), rs.Round)
+	if isTxScheduler != rs.PrimarySchedulerExpected {
+		return fmt.Errorf("not in expected executor primary scheduler role")
 	}
 
 	var schedulerID signature.PublicKey
-	cbc := newComputeBatchContext(b.chainContext, runtimeID)
+	cbc := newComputeBatchContext(b.chainContext, b.runtimeID)
 	switch isTxScheduler {
 	case true:
 		// If we are the transaction scheduler, we wait for transactions and schedule them.
 		var cont bool
-		cont, err = b.receiveAndScheduleTransactions(ctx, cbc, blk, executorMode)
+		cont, err = b.receiveAndScheduleTransactions(ctx, cbc, blk, rs)
 		if err != nil {
-			panic(fmt.Sprintf("compute transaction scheduling failed: %+v", err))
+			return fmt.Errorf("compute transaction scheduling failed: %w", err)
 		}
 		if !cont {
-			return
+			return nil
 		}
 
 		schedulerID = b.identity.NodeSigner.Public()
 	case false:
 		// If we are not the scheduler, receive transactions and the proposal.
 		if err = cbc.receiveProposal(b.p2p); err != nil {
-			panic(fmt.Sprintf("compute receive proposal failed: %+v", err))
+			return fmt.Errorf("compute receive proposal failed: %w", err)
 		}
 		logger.Debug("executor: received proposal", "proposal", cbc.proposal)
 
@@ -203,7 +229,7 @@ func doExecutorScenario(*cobra.Command, []string) { //nolint: gocyclo
 	}
 
 	if err = cbc.openTrees(ctx, blk, b.storageClient); err != nil {
-		panic(fmt.Sprintf("compute open trees failed: %+v", err))
+		return fmt.Errorf("compute open trees failed: %w", err)
 	}
 	defer cbc.closeTrees()
 
@@ -212,10 +238,10 @@ func doExecutorScenario(*cobra.Command, []string) { //nolint: gocyclo
 	binary.BigEndian.PutUint64(encodedEpoch[:], uint64(b.executorCommittee.ValidFor))
 
 	if err = cbc.stateTree.Insert(ctx, []byte{0x02}, encodedEpoch[:]); err != nil {
-		panic(fmt.Sprintf("compute state tree set failed: %+v", err))
+		return fmt.Errorf("compute state tree set failed: %w", err)
 	}
 
-	switch executorMode {
+	switch rs.Mode {
 	case ModeExecutorHonest:
 		// Process transaction honestly.
 		switch len(cbc.txs) {
@@ -224,22 +250,22 @@ func doExecutorScenario(*cobra.Command, []string) { //nolint: gocyclo
 		case 1:
 			// A single transaction, simulate the key-value runtime.
 			if err = cbc.stateTree.Insert(ctx, []byte("hello_key"), []byte("hello_value")); err != nil {
-				panic(fmt.Sprintf("compute state tree set failed: %+v", err))
+				return fmt.Errorf("compute state tree set failed: %w", err)
 			}
 			if err = cbc.addResultSuccess(ctx, cbc.txs[0], nil, transaction.Tags{
 				&transaction.Tag{Key: []byte("kv_op"), Value: []byte("insert")},
 				&transaction.Tag{Key: []byte("kv_key"), Value: []byte("hello_key")},
 			}); err != nil {
-				panic(fmt.Sprintf("compute add result success failed: %+v", err))
+				return fmt.Errorf("compute add result success failed: %w", err)
 			}
 		default:
 			// Unsupported condition.
-			panic(fmt.Sprintf("unsupported number of transactions: %d", len(cbc.txs)))
+			return fmt.Errorf("unsupported number of transactions: %d", len(cbc.txs))
 		}
 	case ModeExecutorDishonest:
 		// Alter the state incorrectly.
 		if err = cbc.stateTree.Insert(ctx, []byte("hello_key"), []byte("wrong")); err != nil {
-			panic(fmt.Sprintf("compute state tree set failed: %+v", err))
+			return fmt.Errorf("compute state tree set failed: %w", err)
 		}
 
 		switch len(cbc.txs) {
@@ -251,57 +277,51 @@ func doExecutorScenario(*cobra.Command, []string) { //nolint: gocyclo
 				&transaction.Tag{Key: []byte("kv_op"), Value: []byte("insert")},
 				&transaction.Tag{Key: []byte("kv_key"), Value: []byte("hello_key")},
 			}); err != nil {
-				panic(fmt.Sprintf("compute add result success failed: %+v", err))
+				return fmt.Errorf("compute add result success failed: %w", err)
 			}
 		default:
 			// Unsupported condition.
-			panic(fmt.Sprintf("unsupported number of transactions: %d", len(cbc.txs)))
+			return fmt.Errorf("unsupported number of transactions: %d", len(cbc.txs))
 		}
-	case ModeExecutorFailureIndicating:
+	case ModeExecutorFailureIndicating, ModeExecutorRunaway:
 		// No need to process anything as we'll submit a failure indicating commitment anyway.
 	default:
 		// Other modes should have already quit by here.
-		panic(fmt.Sprintf("unexpected executor mode: %s", executorMode.String()))
+		return fmt.Errorf("unexpected executor mode: %s", rs.Mode)
 	}
 
 	if err = cbc.commitTrees(ctx); err != nil {
-		panic(fmt.Sprintf("compute commit trees failed: %+v", err))
+		return fmt.Errorf("compute commit trees failed: %w", err)
 	}
 	logger.Debug("executor: committed storage trees",
 		"io_write_log", cbc.ioWriteLog,
 		"new_io_root", cbc.newIORoot,
 		"state_write_log", cbc.stateWriteLog,
 		"new_state_root", cbc.newStateRoot,
-		"mode", executorMode,
+		"mode", rs.Mode,
 	)
 
-	switch executorMode {
-	case ModeExecutorFailureIndicating:
-		if err = cbc.createCommitment(b.identity, schedulerID, b.rak, commitment.FailureUnknown); err != nil {
-			panic(fmt.Sprintf("compute create commitment failed: %+v", err))
-		}
-	default:
-		if err = cbc.createCommitment(b.identity, schedulerID, b.rak, commitment.FailureNone); err != nil {
-			panic(fmt.Sprintf("compute create commitment failed: %+v", err))
-		}
-
+	failure := commitment.FailureNone
+	if rs.Mode == ModeExecutorFailureIndicating {
+		failure = commitment.FailureUnknown
+	}
+	if err = cbc.createCommitment(b.identity, schedulerID, b.rak, failure); err != nil {
+		return fmt.Errorf("compute create commitment failed: %w", err)
 	}
 
 	if err = cbc.publishToChain(b.cometbft.service, b.identity); err != nil {
-		panic(fmt.Sprintf("compute publish to chain failed: %+v", err))
+		return fmt.Errorf("compute publish to chain failed: %w", err)
 	}
-	logger.Debug("executor: commitment sent")
+	logger.Debug("executor: commitment sent", "round", rs.Round)
 
-	// If this is supposed to be a storage node, keep it running forever.
-	if viper.GetBool(CfgCorruptGetDiff) {
-		select {}
-	}
+	return nil
 }
 
 // Register registers the byzantine sub-command and all of its children.
 func Register(parentCmd *cobra.Command) {
 	byzantineCmd.AddCommand(executorCmd)
 	byzantineCmd.AddCommand(vrfBeaconCmd)
+	byzantineCmd.AddCommand(keymanagerCmd)
 	parentCmd.AddCommand(byzantineCmd)
 }
 
@@ -313,8 +333,10 @@ func init() {
 	fs.Uint64(CfgActivationEpoch, 0, "epoch at which the Byzantine node should activate")
 	fs.Bool(CfgPrimarySchedulerExpected, false, "is executor node expected to be primary scheduler or not")
 	fs.String(CfgExecutorMode, ModeExecutorHonest.String(), "configures executor mode")
+	fs.String(CfgExecutorScript, "", "path to the executor script (overrides other executor flags)")
 	fs.Bool(CfgExecutorProposeBogusTx, false, "whether the executor should propose bogus transactions")
 	fs.String(CfgVRFBeaconMode, ModeVRFBeaconHonest.String(), "configures VRF beacon mode")
+	fs.String(CfgKeymanagerMode, ModeKeymanagerRefuseReplication.String(), "configures key manager mode")
 	_ = viper.BindPFlags(fs)
 	byzantineCmd.PersistentFlags().AddFlagSet(fs)
 
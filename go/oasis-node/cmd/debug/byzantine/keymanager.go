@@ -0,0 +1,187 @@
+package byzantine
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"golang.org/x/crypto/sha3"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	kmApi "github.com/oasisprotocol/oasis-core/go/keymanager/api"
+	"github.com/oasisprotocol/oasis-core/go/keymanager/secrets"
+	enclaverpc "github.com/oasisprotocol/oasis-core/go/runtime/enclaverpc/api"
+	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
+	kmP2P "github.com/oasisprotocol/oasis-core/go/worker/keymanager/p2p"
+)
+
+// LogEventKeymanagerRegistered is the event emitted when the byzantine key
+// manager (re-)registers with a new initialization response.
+const LogEventKeymanagerRegistered = "byzantine/keymanager/registered"
+
+// KeymanagerMode represents the byzantine key manager mode.
+type KeymanagerMode uint32
+
+// Key manager modes.
+const (
+	ModeKeymanagerRefuseReplication KeymanagerMode = iota
+	ModeKeymanagerWrongChecksum
+	ModeKeymanagerStaleGeneration
+
+	modeKeymanagerRefuseReplicationString = "keymanager_refuse_replication"
+	modeKeymanagerWrongChecksumString     = "keymanager_wrong_checksum"
+	modeKeymanagerStaleGenerationString   = "keymanager_stale_generation"
+)
+
+// String returns a string representation of a key manager mode.
+func (m KeymanagerMode) String() string {
+	switch m {
+	case ModeKeymanagerRefuseReplication:
+		return modeKeymanagerRefuseReplicationString
+	case ModeKeymanagerWrongChecksum:
+		return modeKeymanagerWrongChecksumString
+	case ModeKeymanagerStaleGeneration:
+		return modeKeymanagerStaleGenerationString
+	default:
+		return "[unsupported key manager mode]"
+	}
+}
+
+// FromString deserializes a string into a key manager mode.
+func (m *KeymanagerMode) FromString(s string) error {
+	switch strings.ToLower(s) {
+	case modeKeymanagerRefuseReplicationString:
+		*m = ModeKeymanagerRefuseReplication
+	case modeKeymanagerWrongChecksumString:
+		*m = ModeKeymanagerWrongChecksum
+	case modeKeymanagerStaleGenerationString:
+		*m = ModeKeymanagerStaleGeneration
+	default:
+		return fmt.Errorf("invalid key manager mode kind: '%s'", s)
+	}
+
+	return nil
+}
+
+// keymanagerEnclave is a key manager enclave which refuses to serve any
+// requests, including master and ephemeral secret replication.
+type keymanagerEnclave struct{}
+
+func (e *keymanagerEnclave) CallEnclave(context.Context, []byte, enclaverpc.Kind) ([]byte, error) {
+	logger.Debug("keymanager: refusing enclave call")
+	return nil, errByzantine
+}
+
+func doKeymanagerScenario(*cobra.Command, []string) {
+	var runtimeID common.Namespace
+	if err := runtimeID.UnmarshalHex(viper.GetString(CfgRuntimeID)); err != nil {
+		panic(fmt.Errorf("error initializing node: failed to parse runtime ID: %w", err))
+	}
+
+	var mode KeymanagerMode
+	if err := mode.FromString(viper.GetString(CfgKeymanagerMode)); err != nil {
+		panic(err)
+	}
+
+	b, err := initializeAndRegisterByzantineNode(runtimeID, node.RoleKeyManager, scheduler.RoleInvalid, false, true, 0)
+	if err != nil {
+		panic(fmt.Sprintf("error initializing node: %+v", err))
+	}
+	defer func() {
+		_ = b.stop()
+	}()
+
+	// Advertise the key manager protocol, but never serve anything useful.
+	b.p2p.service.RegisterProtocolServer(kmP2P.NewServer(b.chainContext, runtimeID, &keymanagerEnclave{}))
+
+	// Without an enclave, the initialization response is signed with the insecure
+	// test key unless the node pretends to run in SGX.
+	rak := b.rak
+	if rak == nil {
+		rak = kmApi.TestSigners[0]
+	}
+
+	ch, sub := b.cometbft.service.KeyManager().Secrets().WatchStatuses()
+	defer sub.Close()
+
+	var registered *secrets.InitResponse
+	for status := range ch {
+		if !status.ID.Equal(&runtimeID) || !status.IsInitialized {
+			continue
+		}
+
+		rsp := keymanagerInitResponse(status)
+		switch mode {
+		case ModeKeymanagerRefuseReplication:
+			// Mirror the key manager status so that the node is part of the committee.
+		case ModeKeymanagerWrongChecksum:
+			sum := sha3.Sum256([]byte("byzantine key manager checksum"))
+			rsp.Checksum = sum[:]
+		case ModeKeymanagerStaleGeneration:
+			// Never move past the first generation the node has seen.
+			if registered != nil && len(registered.Checksum) > 0 {
+				continue
+			}
+		}
+		if registered != nil && keymanagerInitResponseEqual(registered, rsp) {
+			continue
+		}
+
+		if err = keymanagerRegister(b, rak, rsp); err != nil {
+			panic(fmt.Sprintf("failed to register key manager: %+v", err))
+		}
+		registered = rsp
+
+		logger.Info("registered key manager",
+			"mode", mode,
+			"generation", status.Generation,
+			"checksum", rsp.Checksum,
+			logging.LogEvent, LogEventKeymanagerRegistered,
+		)
+	}
+}
+
+// keymanagerInitResponse returns an initialization response which conforms
+// to the given key manager status.
+func keymanagerInitResponse(status *secrets.Status) *secrets.InitResponse {
+	var policyChecksum []byte
+	if status.Policy != nil {
+		sum := sha3.Sum256(cbor.Marshal(status.Policy))
+		policyChecksum = sum[:]
+	}
+
+	return &secrets.InitResponse{
+		IsSecure:       status.IsSecure,
+		Checksum:       status.Checksum,
+		PolicyChecksum: policyChecksum,
+		RSK:            status.RSK,
+	}
+}
+
+func keymanagerInitResponseEqual(a, b *secrets.InitResponse) bool {
+	return bytes.Equal(cbor.Marshal(a), cbor.Marshal(b))
+}
+
+func keymanagerRegister(b *byzantine, rak signature.Signer, rsp *secrets.InitResponse) error {
+	signedRsp, err := secrets.SignInitResponse(rak, rsp)
+	if err != nil {
+		return fmt.Errorf("failed to sign init response: %w", err)
+	}
+
+	return registryRegisterNode(
+		b.cometbft.service,
+		b.identity,
+		b.p2p.service.Addresses(),
+		b.runtimeID,
+		b.capabilities,
+		node.RoleKeyManager,
+		cbor.Marshal(signedRsp),
+	)
+}
@@ -58,31 +58,31 @@ func (b *byzantine) stop() error {
 	return nil
 }
 
-func (b *byzantine) receiveAndScheduleTransactions(ctx context.Context, cbc *computeBatchContext, block *block.Block, mode ExecutorMode) (bool, error) {
+func (b *byzantine) receiveAndScheduleTransactions(ctx context.Context, cbc *computeBatchContext, block *block.Block, rs *RoundScript) (bool, error) {
 	// Receive transactions.
 	txs := cbc.receiveTransactions(b.p2p, time.Second)
 	logger.Debug("executor: received transactions", "transactions", txs)
 
 	// Include transactions that nobody else has when configured to do so.
-	if viper.GetBool(CfgExecutorProposeBogusTx) {
+	if rs.ProposeBogusTx {
 		logger.Debug("executor scheduler: including bogus transactions")
 		txs = append(txs, []byte("this is a bogus transition nr. 1"))
 	}
 
 	// Prepare proposal.
 	if err := cbc.prepareProposal(ctx, block, txs, b.identity); err != nil {
-		panic(fmt.Sprintf("executor proposing batch: %+v", err))
+		return false, fmt.Errorf("executor proposing batch: %w", err)
 	}
 
-	if mode == ModeExecutorFailureIndicating {
+	if rs.Mode == ModeExecutorFailureIndicating {
 		// Submit failure indicating commitment and stop.
 		logger.Debug("executor failure indicating: submitting commitment and stopping")
 		schedulerID := b.identity.NodeSigner.Public()
 		if err := cbc.createCommitment(b.identity, schedulerID, nil, commitment.FailureUnknown); err != nil {
-			panic(fmt.Sprintf("compute create failure indicating commitment failed: %+v", err))
+			return false, fmt.Errorf("compute create failure indicating commitment failed: %w", err)
 		}
 		if err := cbc.publishToChain(b.cometbft.service, b.identity); err != nil {
-			panic(fmt.Sprintf("compute publish to chain failed: %+v", err))
+			return false, fmt.Errorf("compute publish to chain failed: %w", err)
 		}
 		return false, nil
 	}
@@ -91,8 +91,19 @@ func (b *byzantine) receiveAndScheduleTransactions(ctx context.Context, cbc *com
 	cbc.publishProposal(ctx, b.p2p, b.electionEpoch)
 	logger.Debug("executor scheduler: dispatched transactions", "transactions", txs)
 
+	// Publish a second, conflicting proposal when configured to do so.
+	if rs.EquivocateProposal {
+		ecbc := newComputeBatchContext(b.chainContext, b.runtimeID)
+		etxs := append(txs[:len(txs):len(txs)], []byte("this is a bogus transition nr. 2"))
+		if err := ecbc.prepareProposal(ctx, block, etxs, b.identity); err != nil {
+			return false, fmt.Errorf("executor proposing conflicting batch: %w", err)
+		}
+		ecbc.publishProposal(ctx, b.p2p, b.electionEpoch)
+		logger.Debug("executor scheduler: dispatched conflicting proposal", "transactions", etxs)
+	}
+
 	// If we're in ModeExecutorRunaway, stop after publishing the batch.
-	return mode != ModeExecutorRunaway, nil
+	return rs.Mode != ModeExecutorRunaway, nil
 }
 
 func initializeAndRegisterByzantineNode(
@@ -179,6 +190,7 @@ func initializeAndRegisterByzantineNode(
 		b.runtimeID,
 		b.capabilities,
 		nodeRoles,
+		nil,
 	); err != nil {
 		return nil, fmt.Errorf("registryRegisterNode: %w", err)
 	}
@@ -227,7 +239,7 @@ func initializeAndRegisterByzantineNode(
 	b.logger.Debug("executor primary scheduler role ok")
 
 	// Create a stateless storage client.
-	b.storageClient = client.NewStatelessStorage(b.p2p.service, b.chainContext, b.runtimeID)
+	b.storageClient = client.NewStatelessStorage(b.p2p.service, b.chainContext, b.runtimeID, 0)
 
 	return b, nil
 }
@@ -14,7 +14,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/worker/registration"
 )
 
-func registryRegisterNode(svc consensus.Backend, id *identity.Identity, p2pAddresses []node.Address, runtimeID common.Namespace, capabilities *node.Capabilities, roles node.RolesMask) error {
+func registryRegisterNode(svc consensus.Backend, id *identity.Identity, p2pAddresses []node.Address, runtimeID common.Namespace, capabilities *node.Capabilities, roles node.RolesMask, extraInfo []byte) error {
 	entityID, registrationSigner, err := registration.GetRegistrationSigner(id)
 	if err != nil {
 		return fmt.Errorf("registration GetRegistrationSigner: %w", err)
@@ -27,7 +27,8 @@ func registryRegisterNode(svc consensus.Backend, id *identity.Identity, p2pAddre
 	if roles&registry.RuntimesRequiredRoles != 0 {
 		runtimes = []*node.Runtime{
 			{
-				ID: runtimeID,
+				ID:        runtimeID,
+				ExtraInfo: extraInfo,
 			},
 		}
 	}
@@ -2,6 +2,7 @@ package byzantine
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/identity"
@@ -22,3 +23,36 @@ func getRoothashLatestBlock(ctx context.Context, sbc consensus.Backend, runtimeI
 		Height:    consensus.HeightLatest,
 	})
 }
+
+// waitRoothashBlock waits for the runtime block of the given round to be finalized.
+func waitRoothashBlock(ctx context.Context, sbc consensus.Backend, runtimeID common.Namespace, round uint64) (*block.Block, error) {
+	ch, sub, err := sbc.RootHash().WatchBlocks(ctx, runtimeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch blocks: %w", err)
+	}
+	defer sub.Close()
+
+	blk, err := getRoothashLatestBlock(ctx, sbc, runtimeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest block: %w", err)
+	}
+
+	for {
+		switch {
+		case blk.Header.Round == round:
+			return blk, nil
+		case blk.Header.Round > round:
+			return nil, fmt.Errorf("round %d already finalized (latest round: %d)", round, blk.Header.Round)
+		}
+
+		select {
+		case annBlk, ok := <-ch:
+			if !ok {
+				return nil, fmt.Errorf("block channel closed")
+			}
+			blk = annBlk.Block
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package byzantine
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/viper"
+)
+
+// CfgExecutorScript configures the path to the byzantine executor script.
+const CfgExecutorScript = "executor.script"
+
+// defaultScriptRound is the round in which the byzantine executor misbehaves when configured
+// via the legacy per-mode flags.
+const defaultScriptRound = 3
+
+// MarshalText encodes an executor mode into text form.
+func (m ExecutorMode) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalText decodes a text slice into an executor mode.
+func (m *ExecutorMode) UnmarshalText(text []byte) error {
+	return m.FromString(string(text))
+}
+
+// RoundScript is the behavior of the byzantine executor in a given round.
+type RoundScript struct {
+	// Round is the runtime round in which the behavior is triggered.
+	Round uint64 `json:"round"`
+
+	// PrimarySchedulerExpected specifies whether the executor is expected to be the primary
+	// scheduler in the given round.
+	PrimarySchedulerExpected bool `json:"primary_scheduler_expected,omitempty"`
+
+	// Mode is the executor mode.
+	Mode ExecutorMode `json:"mode"`
+
+	// ProposeBogusTx specifies whether the executor as the primary scheduler should propose
+	// transactions that nobody else has.
+	ProposeBogusTx bool `json:"propose_bogus_tx,omitempty"`
+
+	// EquivocateProposal specifies whether the executor as the primary scheduler should publish
+	// a second, conflicting proposal.
+	EquivocateProposal bool `json:"equivocate_proposal,omitempty"`
+
+	// CorruptGetDiff specifies whether the storage node should corrupt GetDiff responses,
+	// starting with the given round.
+	CorruptGetDiff bool `json:"corrupt_get_diff,omitempty"`
+
+	// FailReadRequests specifies whether the storage node should fail read requests, starting
+	// with the given round.
+	FailReadRequests bool `json:"fail_read_requests,omitempty"`
+}
+
+// Validate validates the round script.
+func (rs *RoundScript) Validate() error {
+	if (rs.ProposeBogusTx || rs.EquivocateProposal) && !rs.PrimarySchedulerExpected {
+		return fmt.Errorf("round %d: only the primary scheduler can propose", rs.Round)
+	}
+	if rs.EquivocateProposal {
+		switch rs.Mode {
+		case ModeExecutorStraggler, ModeExecutorFailureIndicating:
+			return fmt.Errorf("round %d: executor in mode %s does not publish proposals", rs.Round, rs.Mode)
+		default:
+		}
+	}
+	return nil
+}
+
+// Script is a declarative byzantine executor script, driving the misbehavior of the executor
+// in consecutive rounds.
+//
+// All scripted rounds must be within the epoch in which the executor was elected.
+type Script struct {
+	// Rounds are the per-round behaviors, ordered by round.
+	Rounds []*RoundScript `json:"rounds"`
+
+	// KeepRunning specifies whether the node should keep running (e.g., serving storage
+	// requests) after the last scripted round.
+	KeepRunning bool `json:"keep_running,omitempty"`
+}
+
+// Validate validates the script.
+func (s *Script) Validate() error {
+	if len(s.Rounds) == 0 {
+		return fmt.Errorf("script has no rounds")
+	}
+
+	var lastRound uint64
+	for _, rs := range s.Rounds {
+		if rs.Round <= lastRound {
+			return fmt.Errorf("round %d: rounds must be positive and strictly increasing", rs.Round)
+		}
+		lastRound = rs.Round
+
+		if err := rs.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadScript loads and validates the script from the given JSON file.
+func LoadScript(path string) (*Script, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read script: %w", err)
+	}
+	var s Script
+	if err = json.Unmarshal(raw, &s); err != nil {
+		return nil, fmt.Errorf("failed to parse script: %w", err)
+	}
+	if err = s.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid script: %w", err)
+	}
+	return &s, nil
+}
+
+// scriptFromConfig returns the configured executor script, falling back to a single-round
+// script constructed from the per-mode flags.
+func scriptFromConfig() (*Script, error) {
+	if path := viper.GetString(CfgExecutorScript); path != "" {
+		return LoadScript(path)
+	}
+
+	var mode ExecutorMode
+	if err := mode.FromString(viper.GetString(CfgExecutorMode)); err != nil {
+		return nil, err
+	}
+	s := &Script{
+		Rounds: []*RoundScript{
+			{
+				Round:                    defaultScriptRound,
+				PrimarySchedulerExpected: viper.GetBool(CfgPrimarySchedulerExpected),
+				Mode:                     mode,
+				ProposeBogusTx:           viper.GetBool(CfgExecutorProposeBogusTx),
+				CorruptGetDiff:           viper.GetBool(CfgCorruptGetDiff),
+				FailReadRequests:         viper.GetBool(CfgFailReadRequests),
+			},
+		},
+		KeepRunning: viper.GetBool(CfgCorruptGetDiff),
+	}
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
@@ -0,0 +1,66 @@
+package byzantine
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestScriptValidate(t *testing.T) {
+	require := require.New(t)
+
+	script := &Script{
+		Rounds: []*RoundScript{
+			{Round: 3, Mode: ModeExecutorHonest},
+			{Round: 4, PrimarySchedulerExpected: true, Mode: ModeExecutorHonest, EquivocateProposal: true},
+			{Round: 6, Mode: ModeExecutorDishonest, CorruptGetDiff: true},
+		},
+	}
+	require.NoError(script.Validate(), "Validate")
+
+	require.Error((&Script{}).Validate(), "empty scripts should be rejected")
+
+	script.Rounds[1].Round = 3
+	require.Error(script.Validate(), "non-increasing rounds should be rejected")
+	script.Rounds[1].Round = 4
+
+	script.Rounds[0].Round = 0
+	require.Error(script.Validate(), "round zero should be rejected")
+	script.Rounds[0].Round = 3
+
+	script.Rounds[1].PrimarySchedulerExpected = false
+	require.Error(script.Validate(), "proposing without being the scheduler should be rejected")
+	script.Rounds[1].PrimarySchedulerExpected = true
+
+	script.Rounds[1].Mode = ModeExecutorFailureIndicating
+	require.Error(script.Validate(), "equivocating without publishing proposals should be rejected")
+}
+
+func TestLoadScript(t *testing.T) {
+	require := require.New(t)
+
+	fn := filepath.Join(t.TempDir(), "script.json")
+	err := os.WriteFile(fn, []byte(`{
+		"rounds": [
+			{"round": 3, "mode": "executor_straggler"},
+			{"round": 5, "mode": "executor_dishonest", "fail_read_requests": true}
+		],
+		"keep_running": true
+	}`), 0o600)
+	require.NoError(err, "WriteFile")
+
+	script, err := LoadScript(fn)
+	require.NoError(err, "LoadScript")
+	require.Len(script.Rounds, 2)
+	require.Equal(ModeExecutorStraggler, script.Rounds[0].Mode)
+	require.Equal(ModeExecutorDishonest, script.Rounds[1].Mode)
+	require.True(script.Rounds[1].FailReadRequests)
+	require.True(script.KeepRunning)
+
+	err = os.WriteFile(fn, []byte(`{"rounds": [{"round": 3, "mode": "executor_bogus"}]}`), 0o600)
+	require.NoError(err, "WriteFile")
+	_, err = LoadScript(fn)
+	require.Error(err, "unknown executor modes should be rejected")
+}
@@ -6,6 +6,7 @@ import (
 	"io"
 	"path/filepath"
 	"sync"
+	"sync/atomic"
 
 	flag "github.com/spf13/pflag"
 	"github.com/spf13/viper"
@@ -38,8 +39,8 @@ type storageWorker struct {
 	backend storage.Backend
 	initCh  chan struct{}
 
-	failReadRequests bool
-	corruptGetDiff   bool
+	failReadRequests atomic.Bool
+	corruptGetDiff   atomic.Bool
 }
 
 func newStorageNode(namespace common.Namespace, datadir string) (*storageWorker, error) {
@@ -57,16 +58,23 @@ func newStorageNode(namespace common.Namespace, datadir string) (*storageWorker,
 		return nil, err
 	}
 
-	return &storageWorker{
-		backend:          impl,
-		initCh:           initCh,
-		failReadRequests: viper.GetBool(CfgFailReadRequests),
-		corruptGetDiff:   viper.GetBool(CfgCorruptGetDiff),
-	}, nil
+	w := &storageWorker{
+		backend: impl,
+		initCh:  initCh,
+	}
+	w.setMisbehavior(viper.GetBool(CfgCorruptGetDiff), viper.GetBool(CfgFailReadRequests))
+
+	return w, nil
+}
+
+// setMisbehavior configures how the storage node should misbehave when serving requests.
+func (w *storageWorker) setMisbehavior(corruptGetDiff, failReadRequests bool) {
+	w.corruptGetDiff.Store(corruptGetDiff)
+	w.failReadRequests.Store(failReadRequests)
 }
 
 func (w *storageWorker) SyncGet(ctx context.Context, request *syncer.GetRequest) (*syncer.ProofResponse, error) {
-	if w.failReadRequests {
+	if w.failReadRequests.Load() {
 		return nil, errByzantine
 	}
 
@@ -74,7 +82,7 @@ func (w *storageWorker) SyncGet(ctx context.Context, request *syncer.GetRequest)
 }
 
 func (w *storageWorker) SyncGetPrefixes(ctx context.Context, request *syncer.GetPrefixesRequest) (*syncer.ProofResponse, error) {
-	if w.failReadRequests {
+	if w.failReadRequests.Load() {
 		return nil, errByzantine
 	}
 
@@ -82,7 +90,7 @@ func (w *storageWorker) SyncGetPrefixes(ctx context.Context, request *syncer.Get
 }
 
 func (w *storageWorker) SyncIterate(ctx context.Context, request *syncer.IterateRequest) (*syncer.ProofResponse, error) {
-	if w.failReadRequests {
+	if w.failReadRequests.Load() {
 		return nil, errByzantine
 	}
 
@@ -115,7 +123,7 @@ func (ci *corruptIterator) Value() (storage.LogEntry, error) {
 }
 
 func (w *storageWorker) GetDiff(ctx context.Context, request *storage.GetDiffRequest) (storage.WriteLogIterator, error) {
-	if w.failReadRequests {
+	if w.failReadRequests.Load() {
 		return nil, errByzantine
 	}
 
@@ -125,14 +133,14 @@ func (w *storageWorker) GetDiff(ctx context.Context, request *storage.GetDiffReq
 	}
 
 	modifiedWl := wl
-	if w.corruptGetDiff {
+	if w.corruptGetDiff.Load() {
 		modifiedWl = &corruptIterator{it: wl}
 	}
 	return modifiedWl, nil
 }
 
 func (w *storageWorker) GetCheckpoints(ctx context.Context, request *checkpoint.GetCheckpointsRequest) ([]*checkpoint.Metadata, error) {
-	if w.failReadRequests {
+	if w.failReadRequests.Load() {
 		return nil, errByzantine
 	}
 
@@ -140,7 +148,7 @@ func (w *storageWorker) GetCheckpoints(ctx context.Context, request *checkpoint.
 }
 
 func (w *storageWorker) GetCheckpointChunk(ctx context.Context, chunk *checkpoint.ChunkMetadata, wr io.Writer) error {
-	if w.failReadRequests {
+	if w.failReadRequests.Load() {
 		return fmt.Errorf("failing request")
 	}
 
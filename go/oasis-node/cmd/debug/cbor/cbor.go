@@ -0,0 +1,128 @@
+// Package cbor implements the CBOR inspection debug sub-commands.
+package cbor
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
+)
+
+// cfgFile configures the file containing the CBOR blob.
+const cfgFile = "cbor.file"
+
+var (
+	cborCmd = &cobra.Command{
+		Use:   "cbor",
+		Short: "CBOR inspection utilities",
+	}
+
+	cborToJSONCmd = &cobra.Command{
+		Use:   "to-json [<base64 or hex encoded CBOR>]",
+		Short: "transcode a CBOR blob into JSON",
+		Long: "Transcodes a CBOR blob (e.g., a runtime descriptor or a key manager policy) given " +
+			"either as a base64 or hex encoded argument or as a file into JSON. Byte strings are " +
+			"shown as 0x-prefixed hex strings and tags are annotated.",
+		Args: cobra.MaximumNArgs(1),
+		Run:  doToJSON,
+	}
+
+	cborToJSONFlags = flag.NewFlagSet("", flag.ContinueOnError)
+
+	logger = logging.GetLogger("cmd/debug/cbor")
+)
+
+// DecodeInput decodes a CBOR blob given as raw CBOR, base64 or hex.
+//
+// Textual encodings take precedence in case the input is also well-formed raw CBOR.
+func DecodeInput(data []byte) ([]byte, error) {
+	if trimmed := strings.TrimSpace(string(data)); trimmed != "" {
+		if raw, err := hex.DecodeString(strings.TrimPrefix(trimmed, "0x")); err == nil {
+			return raw, nil
+		}
+		if raw, err := base64.StdEncoding.DecodeString(trimmed); err == nil {
+			return raw, nil
+		}
+	}
+	if _, err := cbor.ToJSON(data); err != nil {
+		return nil, fmt.Errorf("cbor: input is neither hex, base64 nor CBOR encoded")
+	}
+	return data, nil
+}
+
+func doToJSON(_ *cobra.Command, args []string) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	var (
+		data []byte
+		err  error
+	)
+	switch fn := viper.GetString(cfgFile); {
+	case len(args) == 1 && fn != "":
+		logger.Error("CBOR blob must be given either as an argument or as a file")
+		os.Exit(1)
+	case len(args) == 1:
+		data = []byte(args[0])
+	case fn != "":
+		if data, err = os.ReadFile(fn); err != nil {
+			logger.Error("failed to read CBOR file",
+				"err", err,
+			)
+			os.Exit(1)
+		}
+	default:
+		logger.Error("no CBOR blob given")
+		os.Exit(1)
+	}
+
+	raw, err := DecodeInput(data)
+	if err != nil {
+		logger.Error("failed to decode input",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	js, err := cbor.ToJSON(raw)
+	if err != nil {
+		logger.Error("failed to transcode CBOR into JSON",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	var out bytes.Buffer
+	if err = json.Indent(&out, js, "", "  "); err != nil {
+		logger.Error("failed to format JSON",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	fmt.Println(out.String())
+}
+
+// Register registers the cbor sub-command and all of it's children.
+func Register(parentCmd *cobra.Command) {
+	cborToJSONCmd.Flags().AddFlagSet(cborToJSONFlags)
+
+	cborCmd.AddCommand(cborToJSONCmd)
+	parentCmd.AddCommand(cborCmd)
+}
+
+func init() {
+	cborToJSONFlags.String(cfgFile, "", "path to the file containing the CBOR blob")
+	_ = viper.BindPFlags(cborToJSONFlags)
+}
@@ -0,0 +1,30 @@
+package cbor
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+)
+
+func TestDecodeInput(t *testing.T) {
+	require := require.New(t)
+
+	raw := cbor.Marshal(map[string][]byte{"key": {0x01, 0x02}})
+	for _, encoded := range [][]byte{
+		raw,
+		[]byte(hex.EncodeToString(raw)),
+		[]byte("0x" + hex.EncodeToString(raw) + "\n"),
+		[]byte(base64.StdEncoding.EncodeToString(raw)),
+	} {
+		decoded, err := DecodeInput(encoded)
+		require.NoError(err, "DecodeInput")
+		require.Equal(raw, decoded)
+	}
+
+	_, err := DecodeInput([]byte{0x82, 0x01})
+	require.Error(err, "DecodeInput should fail on malformed input")
+}
@@ -6,9 +6,11 @@ import (
 
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/beacon"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/byzantine"
+	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/cbor"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/control"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/dumpdb"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/storage"
+	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/tx"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/txsource"
 )
 
@@ -26,6 +28,8 @@ func Register(parentCmd *cobra.Command) {
 	control.Register(debugCmd)
 	dumpdb.Register(debugCmd)
 	beacon.Register(debugCmd)
+	tx.Register(debugCmd)
+	cbor.Register(debugCmd)
 
 	parentCmd.AddCommand(debugCmd)
 }
@@ -0,0 +1,202 @@
+// Package tx implements the transaction decoder debug sub-commands.
+package tx
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	genesisFile "github.com/oasisprotocol/oasis-core/go/genesis/file"
+	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
+	cmdConsensus "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/consensus"
+	cmdFlags "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+// cfgChainContext configures the chain context used to verify transaction signatures.
+const cfgChainContext = "tx.chain_context"
+
+var (
+	txCmd = &cobra.Command{
+		Use:   "tx",
+		Short: "consensus transaction utilities",
+	}
+
+	txDecodeCmd = &cobra.Command{
+		Use:   "decode [<base64 or hex encoded transaction>]",
+		Short: "decode a signed consensus transaction",
+		Long: "Decodes a signed consensus transaction given either as a base64 or hex encoded " +
+			"argument or as a CBOR file and prints its content together with validity checks. " +
+			"Signatures are verified against the given chain context or, if none is given, " +
+			"against the chain context of the genesis document.",
+		Args: cobra.MaximumNArgs(1),
+		Run:  doDecode,
+	}
+
+	txDecodeFlags = flag.NewFlagSet("", flag.ContinueOnError)
+
+	logger = logging.GetLogger("cmd/debug/tx")
+)
+
+// Check is the result of a transaction validity check.
+type Check struct {
+	// Name is the name of the check.
+	Name string
+	// Err is the error in case the check failed.
+	Err error
+}
+
+// DecodeSignedTransaction decodes a signed transaction given as raw CBOR, base64 or hex.
+func DecodeSignedTransaction(data []byte) (*transaction.SignedTransaction, error) {
+	candidates := [][]byte{data}
+	if trimmed := strings.TrimSpace(string(data)); trimmed != "" {
+		if raw, err := hex.DecodeString(strings.TrimPrefix(trimmed, "0x")); err == nil {
+			candidates = append(candidates, raw)
+		}
+		if raw, err := base64.StdEncoding.DecodeString(trimmed); err == nil {
+			candidates = append(candidates, raw)
+		}
+	}
+
+	for _, raw := range candidates {
+		var sigTx transaction.SignedTransaction
+		if err := cbor.Unmarshal(raw, &sigTx); err == nil && len(sigTx.Blob) > 0 {
+			return &sigTx, nil
+		}
+	}
+	return nil, fmt.Errorf("tx: malformed signed transaction")
+}
+
+// CheckSignedTransaction performs stateless validity checks of the given signed transaction
+// against the currently configured chain context.
+func CheckSignedTransaction(sigTx *transaction.SignedTransaction) []Check {
+	var tx transaction.Transaction
+	sigErr := sigTx.Open(&tx)
+	checks := []Check{
+		{Name: "signature", Err: sigErr},
+	}
+	if sigErr != nil {
+		// Still check the content of transactions with invalid signatures.
+		if err := cbor.Unmarshal(sigTx.Blob, &tx); err != nil {
+			return append(checks, Check{Name: "encoding", Err: err})
+		}
+	}
+	checks = append(checks, Check{Name: "method", Err: tx.SanityCheck()})
+
+	bodyErr := fmt.Errorf("unknown method")
+	if bodyType := tx.Method.BodyType(); bodyType != nil {
+		body := reflect.New(reflect.TypeOf(bodyType)).Interface()
+		bodyErr = cbor.Unmarshal(tx.Body, body)
+	}
+	checks = append(checks, Check{Name: "body", Err: bodyErr})
+
+	var feeErr error
+	if tx.Fee == nil {
+		feeErr = fmt.Errorf("no fee")
+	}
+	checks = append(checks, Check{Name: "fee", Err: feeErr})
+
+	return checks
+}
+
+func loadChainContext() (string, error) {
+	if chainContext := viper.GetString(cfgChainContext); chainContext != "" {
+		return chainContext, nil
+	}
+
+	provider, err := genesisFile.NewFileProvider(cmdFlags.GenesisFile())
+	if err != nil {
+		return "", fmt.Errorf("failed to load genesis file: %w", err)
+	}
+	doc, err := provider.GetGenesisDocument()
+	if err != nil {
+		return "", fmt.Errorf("failed to retrieve genesis document: %w", err)
+	}
+	return doc.ChainContext(), nil
+}
+
+func doDecode(_ *cobra.Command, args []string) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	var (
+		data []byte
+		err  error
+	)
+	switch fn := viper.GetString(cmdConsensus.CfgTxFile); {
+	case len(args) == 1 && fn != "":
+		logger.Error("transaction must be given either as an argument or as a file")
+		os.Exit(1)
+	case len(args) == 1:
+		data = []byte(args[0])
+	case fn != "":
+		if data, err = os.ReadFile(fn); err != nil {
+			logger.Error("failed to read transaction file",
+				"err", err,
+			)
+			os.Exit(1)
+		}
+	default:
+		logger.Error("no transaction given")
+		os.Exit(1)
+	}
+
+	sigTx, err := DecodeSignedTransaction(data)
+	if err != nil {
+		logger.Error("failed to decode transaction",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	chainContext, err := loadChainContext()
+	if err != nil {
+		logger.Error("failed to determine chain context",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	signature.SetChainContext(chainContext)
+
+	ctx := context.Background()
+	fmt.Printf("Chain context: %s\n", chainContext)
+	fmt.Printf("Signer address: %s\n", staking.NewAddress(sigTx.Signature.PublicKey))
+	sigTx.PrettyPrint(ctx, "", os.Stdout)
+
+	fmt.Println("Checks:")
+	for _, check := range CheckSignedTransaction(sigTx) {
+		status := "OK"
+		if check.Err != nil {
+			status = fmt.Sprintf("FAILED (%s)", check.Err)
+		}
+		fmt.Printf("  %s: %s\n", check.Name, status)
+	}
+}
+
+// Register registers the tx sub-command and all of it's children.
+func Register(parentCmd *cobra.Command) {
+	txDecodeCmd.Flags().AddFlagSet(txDecodeFlags)
+	txDecodeCmd.Flags().AddFlagSet(cmdConsensus.TxFileFlags)
+	txDecodeCmd.Flags().AddFlagSet(cmdFlags.GenesisFileFlags)
+
+	txCmd.AddCommand(txDecodeCmd)
+	parentCmd.AddCommand(txCmd)
+}
+
+func init() {
+	txDecodeFlags.String(cfgChainContext, "", "chain context used to verify signatures (default: from genesis file)")
+	_ = viper.BindPFlags(txDecodeFlags)
+}
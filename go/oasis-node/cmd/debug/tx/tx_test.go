@@ -0,0 +1,59 @@
+package tx
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	genesisTestHelpers "github.com/oasisprotocol/oasis-core/go/genesis/tests"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+func TestDecodeSignedTransaction(t *testing.T) {
+	require := require.New(t)
+
+	genesisTestHelpers.SetTestChainContext()
+
+	signer := memorySigner.NewTestSigner("debug tx test signer")
+	tx := transaction.NewTransaction(1, &transaction.Fee{Gas: 1000}, staking.MethodTransfer, &staking.Transfer{})
+	sigTx, err := transaction.Sign(signer, tx)
+	require.NoError(err, "Sign")
+	raw := cbor.Marshal(sigTx)
+
+	for _, encoded := range [][]byte{
+		raw,
+		[]byte(hex.EncodeToString(raw)),
+		[]byte("0x" + hex.EncodeToString(raw)),
+		[]byte(base64.StdEncoding.EncodeToString(raw) + "\n"),
+	} {
+		decoded, err := DecodeSignedTransaction(encoded)
+		require.NoError(err, "DecodeSignedTransaction")
+		require.EqualValues(sigTx, decoded)
+	}
+
+	_, err = DecodeSignedTransaction([]byte("not a transaction"))
+	require.Error(err, "DecodeSignedTransaction should fail on malformed input")
+
+	for _, check := range CheckSignedTransaction(sigTx) {
+		require.NoError(check.Err, "check %s should pass", check.Name)
+	}
+
+	// Transactions with invalid signatures should still be checked.
+	tampered := *sigTx
+	tampered.Blob = cbor.Marshal(transaction.NewTransaction(2, nil, staking.MethodTransfer, &staking.Transfer{}))
+	failed := make(map[string]bool)
+	for _, check := range CheckSignedTransaction(&tampered) {
+		failed[check.Name] = check.Err != nil
+	}
+	require.Equal(map[string]bool{
+		"signature": true,
+		"method":    false,
+		"body":      false,
+		"fee":       true,
+	}, failed)
+}
@@ -404,6 +404,12 @@ func (g *governanceWorkload) doChangeParametersProposal() error { // nolint: goc
 		if randBool() {
 			pc.MaxPastRootsStored = &params.MaxPastRootsStored
 		}
+		if randBool() {
+			pc.InMessageFeePerMessage = &params.InMessageFeePerMessage
+		}
+		if randBool() {
+			pc.InMessageFeePerByte = &params.InMessageFeePerByte
+		}
 		shouldFail = pc.SanityCheck() != nil
 		module = roothash.ModuleName
 		changes = cbor.Marshal(pc)
@@ -706,6 +706,11 @@ func (q *queries) doRoothashQueries(ctx context.Context, _ *rand.Rand, height in
 		return fmt.Errorf("roothash.GetLastRoundResults: %w", err)
 	}
 
+	_, err = q.roothash.GetLivenessStatistics(ctx, &roothash.RuntimeRequest{RuntimeID: q.runtimeID, Height: height})
+	if err != nil {
+		return fmt.Errorf("roothash.GetLivenessStatistics: %w", err)
+	}
+
 	_, err = q.roothash.GetLatestBlock(ctx, &roothash.RuntimeRequest{RuntimeID: q.runtimeID, Height: height})
 	if err != nil {
 		return fmt.Errorf("roothash.GetLatestBlock: %w", err)
@@ -96,6 +96,8 @@ const (
 	CfgRoothashMaxRuntimeMessages        = "roothash.max_runtime_messages"
 	CfgRoothashMaxInRuntimeMessages      = "roothash.max_in_runtime_messages"
 	CfgRoothashMaxPastRootsStored        = "roothash.max_past_roots_stored"
+	CfgRoothashInMessageFeePerMessage    = "roothash.in_message_fee_per_message"
+	CfgRoothashInMessageFeePerByte       = "roothash.in_message_fee_per_byte"
 
 	// Staking config flags.
 	CfgStakingTokenSymbol        = "staking.token_symbol"
@@ -526,6 +528,8 @@ func AppendRootHashState(doc *genesis.Document, exports []string, l *logging.Log
 			MaxRuntimeMessages:        viper.GetUint32(CfgRoothashMaxRuntimeMessages),
 			MaxInRuntimeMessages:      viper.GetUint32(CfgRoothashMaxInRuntimeMessages),
 			MaxPastRootsStored:        viper.GetUint64(CfgRoothashMaxPastRootsStored),
+			InMessageFeePerMessage:    *quantity.NewFromUint64(viper.GetUint64(CfgRoothashInMessageFeePerMessage)),
+			InMessageFeePerByte:       *quantity.NewFromUint64(viper.GetUint64(CfgRoothashInMessageFeePerByte)),
 			GasCosts:                  roothash.DefaultGasCosts, // TODO: Make these configurable.
 		},
 	}
@@ -850,6 +854,8 @@ func init() {
 	initGenesisFlags.Uint32(CfgRoothashMaxRuntimeMessages, 128, "maximum number of runtime messages submitted in a round")
 	initGenesisFlags.Uint32(CfgRoothashMaxInRuntimeMessages, 128, "maximum number of ququed incoming runtime messages")
 	initGenesisFlags.Uint64(CfgRoothashMaxPastRootsStored, 1200, "maximum number of past runtime state and I/O roots stored in consensus state")
+	initGenesisFlags.Uint64(CfgRoothashInMessageFeePerMessage, 0, "fee charged for each incoming runtime message")
+	initGenesisFlags.Uint64(CfgRoothashInMessageFeePerByte, 0, "fee charged for each byte of incoming runtime message data")
 	_ = initGenesisFlags.MarkHidden(cfgRoothashDebugDoNotSuspendRuntimes)
 	_ = initGenesisFlags.MarkHidden(cfgRoothashDebugBypassStake)
 
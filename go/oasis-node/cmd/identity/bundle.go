@@ -0,0 +1,183 @@
+package identity
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/identity"
+	"github.com/oasisprotocol/oasis-core/go/common/pem"
+	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
+	cmdFlags "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
+)
+
+const (
+	// CfgBundleFile is the path to the identity bundle file.
+	CfgBundleFile = "bundle.file"
+	// CfgBundlePassphraseFile is the path to the file containing the identity
+	// bundle passphrase.
+	CfgBundlePassphraseFile = "bundle.passphrase_file"
+	// CfgBundleKeyFile is the path to the file containing the hex-encoded
+	// identity bundle encryption key (e.g., a data key obtained from a KMS).
+	CfgBundleKeyFile = "bundle.key_file"
+
+	bundlePEMType  = "OASIS NODE IDENTITY BUNDLE"
+	bundleFilePerm = 0o600
+)
+
+var (
+	bundleFlags = flag.NewFlagSet("", flag.ContinueOnError)
+
+	identityExportCmd = &cobra.Command{
+		Use:   "export",
+		Short: "export node identity into an encrypted bundle",
+		Run:   doExport,
+	}
+
+	identityImportCmd = &cobra.Command{
+		Use:   "import",
+		Short: "import node identity from an encrypted bundle",
+		Run:   doImport,
+	}
+)
+
+func bundleEncryptionFromFlags() (*identity.BundleEncryption, error) {
+	passphraseFile := viper.GetString(CfgBundlePassphraseFile)
+	keyFile := viper.GetString(CfgBundleKeyFile)
+
+	switch {
+	case passphraseFile != "" && keyFile != "":
+		return nil, fmt.Errorf("only one of --%s and --%s may be set", CfgBundlePassphraseFile, CfgBundleKeyFile)
+	case passphraseFile != "":
+		passphrase, err := os.ReadFile(passphraseFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read passphrase file: %w", err)
+		}
+		passphrase = bytes.TrimRight(passphrase, "\r\n")
+		if len(passphrase) == 0 {
+			return nil, fmt.Errorf("passphrase must not be empty")
+		}
+		return &identity.BundleEncryption{Passphrase: passphrase}, nil
+	case keyFile != "":
+		rawKey, err := os.ReadFile(keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read key file: %w", err)
+		}
+		key, err := hex.DecodeString(string(bytes.TrimSpace(rawKey)))
+		if err != nil {
+			return nil, fmt.Errorf("malformed key file: %w", err)
+		}
+		return &identity.BundleEncryption{Key: key}, nil
+	default:
+		return nil, fmt.Errorf("one of --%s and --%s must be set", CfgBundlePassphraseFile, CfgBundleKeyFile)
+	}
+}
+
+func bundleDataDirAndFile() (string, string) {
+	// Workaround for viper bug: https://github.com/spf13/viper/issues/233
+	_ = viper.BindPFlag(CfgDataDir, identityCmd.PersistentFlags().Lookup(CfgDataDir))
+
+	dataDir := viper.GetString(CfgDataDir)
+	if dataDir == "" {
+		logger.Error("data directory must be set")
+		os.Exit(1)
+	}
+	bundleFile := viper.GetString(CfgBundleFile)
+	if bundleFile == "" {
+		logger.Error("bundle file must be set")
+		os.Exit(1)
+	}
+	return dataDir, bundleFile
+}
+
+func doExport(*cobra.Command, []string) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	dataDir, bundleFile := bundleDataDirAndFile()
+	enc, err := bundleEncryptionFromFlags()
+	if err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	if _, err = os.Stat(bundleFile); err == nil && !cmdFlags.Force() {
+		logger.Error("bundle file already exists, use --force to overwrite",
+			"bundle_file", bundleFile,
+		)
+		os.Exit(1)
+	}
+
+	bundle, err := identity.ExportBundle(dataDir, enc)
+	if err != nil {
+		logger.Error("failed to export node identity",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	data, err := pem.Marshal(bundlePEMType, cbor.Marshal(bundle))
+	if err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+	if err = os.WriteFile(bundleFile, data, bundleFilePerm); err != nil {
+		logger.Error("failed to write bundle file",
+			"err", err,
+			"bundle_file", bundleFile,
+		)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Exported identity of node %s to: %s\n", bundle.NodeID, bundleFile)
+}
+
+func doImport(*cobra.Command, []string) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	dataDir, bundleFile := bundleDataDirAndFile()
+	enc, err := bundleEncryptionFromFlags()
+	if err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	rawBundle, err := os.ReadFile(bundleFile)
+	if err != nil {
+		logger.Error("failed to read bundle file",
+			"err", err,
+			"bundle_file", bundleFile,
+		)
+		os.Exit(1)
+	}
+	data, err := pem.Unmarshal(bundlePEMType, rawBundle)
+	if err != nil {
+		cmdCommon.EarlyLogAndExit(fmt.Errorf("malformed bundle file: %w", err))
+	}
+	var bundle identity.Bundle
+	if err = cbor.Unmarshal(data, &bundle); err != nil {
+		cmdCommon.EarlyLogAndExit(fmt.Errorf("malformed bundle: %w", err))
+	}
+
+	if err = identity.ImportBundle(dataDir, &bundle, enc, cmdFlags.Force()); err != nil {
+		logger.Error("failed to import node identity",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Imported identity of node %s into: %s\n", bundle.NodeID, dataDir)
+}
+
+func init() {
+	bundleFlags.String(CfgBundleFile, "", "path to the identity bundle file")
+	bundleFlags.String(CfgBundlePassphraseFile, "", "path to the file containing the bundle passphrase")
+	bundleFlags.String(CfgBundleKeyFile, "", "path to the file containing the hex-encoded bundle encryption key")
+	_ = viper.BindPFlags(bundleFlags)
+}
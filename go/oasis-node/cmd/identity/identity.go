@@ -167,10 +167,20 @@ func Register(parentCmd *cobra.Command) {
 
 	identityShowAddressCmd.Flags().AddFlagSet(cmdGrpc.ClientFlags)
 
+	for _, v := range []*cobra.Command{
+		identityExportCmd,
+		identityImportCmd,
+	} {
+		v.Flags().AddFlagSet(bundleFlags)
+		v.Flags().AddFlagSet(cmdFlags.ForceFlags)
+	}
+
 	identityCmd.AddCommand(identityInitCmd)
 	identityCmd.AddCommand(identityShowSentryPubkeyCmd)
 	identityCmd.AddCommand(identityShowTLSPubkeyCmd)
 	identityCmd.AddCommand(identityShowAddressCmd)
+	identityCmd.AddCommand(identityExportCmd)
+	identityCmd.AddCommand(identityImportCmd)
 
 	parentCmd.AddCommand(identityCmd)
 }
@@ -13,6 +13,9 @@ import (
 	workerStorage "github.com/oasisprotocol/oasis-core/go/worker/storage"
 )
 
+// CfgReadOnly enables the read-only mode, in which the node only serves queries.
+const CfgReadOnly = "readonly"
+
 // Flags has the configuration flags.
 var Flags = flag.NewFlagSet("", flag.ContinueOnError)
 
@@ -30,6 +33,7 @@ func Register(parentCmd *cobra.Command) {
 }
 
 func init() {
+	Flags.Bool(CfgReadOnly, false, "serve queries only, without submitting transactions, registering or participating in committees")
 	_ = viper.BindPFlags(Flags)
 
 	Flags.AddFlagSet(flags.DebugTestEntityFlags)
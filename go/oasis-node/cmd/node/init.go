@@ -8,6 +8,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/identity"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/service"
+	"github.com/oasisprotocol/oasis-core/go/config"
 	"github.com/oasisprotocol/oasis-core/go/genesis/api"
 	genesisFile "github.com/oasisprotocol/oasis-core/go/genesis/file"
 	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
@@ -94,6 +95,18 @@ func loadOrGenerateIdentity(dataDir string, logger *logging.Logger) (*identity.I
 		return nil, err
 	}
 
+	// Never act on the network with the persisted keys in read-only mode as the data directory
+	// may be a copy of the one used by another node.
+	if config.GlobalConfig.Common.ReadOnly {
+		identity, err = identity.Ephemeral()
+		if err != nil {
+			logger.Error("failed to generate ephemeral identity",
+				"err", err,
+			)
+			return nil, err
+		}
+	}
+
 	logger.Info("loaded/generated node identity",
 		"node_pk", identity.NodeSigner.Public(),
 		"p2p_pk", identity.P2PSigner.Public(),
@@ -3,13 +3,16 @@ package node
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"os"
 	"path/filepath"
 	"sync"
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/crash"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/grpc"
 	"github.com/oasisprotocol/oasis-core/go/common/identity"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
@@ -410,17 +413,71 @@ func (n *Node) dumpGenesis(ctx context.Context, blockHeight int64) error {
 		return fmt.Errorf("dumpGenesis: failed to get genesis: %w", err)
 	}
 
-	exportsDir := filepath.Join(n.dataDir, exportsSubDir)
+	filename, err := writeGenesisExport(doc, haltExportDir(n.dataDir))
+	if err != nil {
+		return fmt.Errorf("dumpGenesis: %w", err)
+	}
+
+	if !config.GlobalConfig.Consensus.HaltExport.Verify {
+		return nil
+	}
+
+	docHash := doc.Hash()
+	if err = verifyGenesisExport(filename, docHash); err != nil {
+		return fmt.Errorf("dumpGenesis: %w", err)
+	}
+
+	n.logger.Info("exported genesis document verified",
+		"filename", filename,
+		"genesis_hash", docHash,
+	)
+
+	return nil
+}
 
+// haltExportDir returns the directory to which the genesis document is exported on halt.
+func haltExportDir(dataDir string) string {
+	if path := config.GlobalConfig.Consensus.HaltExport.Path; path != "" {
+		return path
+	}
+	return filepath.Join(dataDir, exportsSubDir)
+}
+
+// writeGenesisExport writes the genesis document into the given exports directory and returns
+// the name of the written file.
+func writeGenesisExport(doc *genesisAPI.Document, exportsDir string) (string, error) {
 	if err := common.Mkdir(exportsDir); err != nil {
-		return fmt.Errorf("dumpGenesis: failed to create exports dir: %w", err)
+		return "", fmt.Errorf("failed to create exports dir: %w", err)
 	}
 
 	filename := filepath.Join(exportsDir, fmt.Sprintf("genesis-%s-at-%d.json", doc.ChainID, doc.Height))
 	if err := doc.WriteFileJSON(filename); err != nil {
-		return fmt.Errorf("dumpGenesis: failed to write genesis file: %w", err)
+		return "", fmt.Errorf("failed to write genesis file: %w", err)
 	}
+	return filename, nil
+}
 
+// verifyGenesisExport reads the exported genesis document back to make sure that the export is
+// intact and writes its hash next to the export so that operators can compare it before
+// restoring.
+func verifyGenesisExport(filename string, expected hash.Hash) error {
+	raw, err := os.ReadFile(filename)
+	if err != nil {
+		return fmt.Errorf("failed to read back genesis file: %w", err)
+	}
+	var written genesisAPI.Document
+	if err = json.Unmarshal(raw, &written); err != nil {
+		return fmt.Errorf("failed to parse written genesis file: %w", err)
+	}
+	if writtenHash := written.Hash(); !writtenHash.Equal(&expected) {
+		return fmt.Errorf("written genesis file hash mismatch (expected: %s got: %s)",
+			expected, writtenHash,
+		)
+	}
+
+	if err = os.WriteFile(filename+".hash", []byte(expected.Hex()+"\n"), 0o600); err != nil {
+		return fmt.Errorf("failed to write genesis hash file: %w", err)
+	}
 	return nil
 }
 
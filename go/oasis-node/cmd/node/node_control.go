@@ -113,6 +113,18 @@ func (n *Node) CancelUpgrade(_ context.Context, descriptor *upgrade.Descriptor)
 	return n.Upgrader.CancelUpgrade(descriptor)
 }
 
+// SetCommitteeAudit implements control.NodeController.
+func (n *Node) SetCommitteeAudit(_ context.Context, req *control.SetCommitteeAuditRequest) error {
+	if !n.ExecutorWorker.Enabled() {
+		return control.ErrNotImplemented
+	}
+	execNode := n.ExecutorWorker.GetRuntime(req.RuntimeID)
+	if execNode == nil {
+		return fmt.Errorf("runtime %s is not configured", req.RuntimeID)
+	}
+	return execNode.SetCommitteeAudit(req.Enabled)
+}
+
 // GetStatus implements control.NodeController.
 func (n *Node) GetStatus(ctx context.Context) (*control.Status, error) {
 	cs, err := n.getConsensusStatus(ctx)
@@ -165,6 +177,7 @@ func (n *Node) GetStatus(ctx context.Context) (*control.Status, error) {
 	return &control.Status{
 		SoftwareVersion: version.SoftwareVersion,
 		Mode:            config.GlobalConfig.Mode,
+		ReadOnly:        config.GlobalConfig.Common.ReadOnly,
 		Debug:           ds,
 		Identity:        ident,
 		Consensus:       cs,
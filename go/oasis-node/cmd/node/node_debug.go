@@ -2,10 +2,15 @@ package node
 
 import (
 	"context"
+	"fmt"
+
+	"github.com/libp2p/go-libp2p/core"
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/control/api"
 	control "github.com/oasisprotocol/oasis-core/go/control/api"
+	p2pAPI "github.com/oasisprotocol/oasis-core/go/p2p/api"
 )
 
 // Assert that the node implements DebugController interface.
@@ -62,3 +67,23 @@ Loop:
 
 	return nil
 }
+
+// SetIsolatedPeers implements control.DebugController.
+func (n *Node) SetIsolatedPeers(_ context.Context, peers []signature.PublicKey) error {
+	svc, ok := n.P2P.(p2pAPI.DebugService)
+	if !ok {
+		return api.ErrIncompatibleP2P
+	}
+
+	peerIDs := make([]core.PeerID, 0, len(peers))
+	for _, pk := range peers {
+		peerID, err := p2pAPI.PublicKeyToPeerID(pk)
+		if err != nil {
+			return fmt.Errorf("malformed peer public key: %w", err)
+		}
+		peerIDs = append(peerIDs, peerID)
+	}
+	svc.SetIsolatedPeers(peerIDs)
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package node
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/config"
+	genesisAPI "github.com/oasisprotocol/oasis-core/go/genesis/api"
+)
+
+func TestHaltExportDir(t *testing.T) {
+	require := require.New(t)
+
+	dataDir := t.TempDir()
+	require.Equal(filepath.Join(dataDir, exportsSubDir), haltExportDir(dataDir), "default exports dir")
+
+	exportsDir := t.TempDir()
+	config.GlobalConfig.Consensus.HaltExport.Path = exportsDir
+	defer func() {
+		config.GlobalConfig.Consensus.HaltExport.Path = ""
+	}()
+	require.Equal(exportsDir, haltExportDir(dataDir), "configured exports dir should override the default")
+}
+
+func TestGenesisExport(t *testing.T) {
+	require := require.New(t)
+
+	doc := &genesisAPI.Document{
+		Height:  42,
+		Time:    time.Unix(1_700_000_000, 0).UTC(),
+		ChainID: "halt-export-test",
+	}
+	docHash := doc.Hash()
+
+	exportsDir := filepath.Join(t.TempDir(), "exports")
+	filename, err := writeGenesisExport(doc, exportsDir)
+	require.NoError(err, "writeGenesisExport")
+	require.Equal(filepath.Join(exportsDir, "genesis-halt-export-test-at-42.json"), filename)
+	require.FileExists(filename)
+	require.NoFileExists(filename+".hash", "hash file should only be written on verification")
+
+	// Verification against a different hash should fail and not write the hash file.
+	err = verifyGenesisExport(filename, hash.NewFromBytes([]byte("not the genesis document")))
+	require.ErrorContains(err, "hash mismatch")
+	require.NoFileExists(filename + ".hash")
+
+	// Verification against the document hash should succeed and write the hash file.
+	err = verifyGenesisExport(filename, docHash)
+	require.NoError(err, "verifyGenesisExport")
+	raw, err := os.ReadFile(filename + ".hash")
+	require.NoError(err, "read hash file")
+	require.Equal(docHash.Hex()+"\n", string(raw))
+
+	// Verification of a corrupted export should fail.
+	require.NoError(os.WriteFile(filename, []byte("{}"), 0o600))
+	err = verifyGenesisExport(filename, docHash)
+	require.ErrorContains(err, "hash mismatch")
+}
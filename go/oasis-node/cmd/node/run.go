@@ -6,10 +6,13 @@ import (
 	"os"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/service"
 	"github.com/oasisprotocol/oasis-core/go/config"
 	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
+	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/crashreport"
 )
 
 type runnableNode interface {
@@ -21,11 +24,24 @@ type runnableNode interface {
 func Run(_ *cobra.Command, _ []string) {
 	cmdCommon.SetIsNodeCmd(true)
 
-	var (
-		node runnableNode
-		err  error
-	)
+	if viper.GetBool(CfgReadOnly) {
+		config.GlobalConfig.Common.ReadOnly = true
+		if err := config.GlobalConfig.Validate(); err != nil {
+			cmdCommon.EarlyLogAndExit(err)
+		}
+	}
+
+	reporter, err := crashreport.New(config.GlobalConfig.Common.DataDir)
+	if err != nil {
+		logging.GetLogger("node").Error("failed to initialize crash reporter",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	defer reporter.Close()
+	defer reporter.Recover()
 
+	var node runnableNode
 	switch config.GlobalConfig.Mode {
 	case config.ModeSeed:
 		node, err = NewSeedNode()
@@ -42,6 +58,16 @@ func Run(_ *cobra.Command, _ []string) {
 		os.Exit(1)
 	}
 
+	if n, ok := node.(*Node); ok && n.Consensus != nil {
+		reporter.SetHeightSource(func(ctx context.Context) (int64, error) {
+			status, err := n.Consensus.GetStatus(ctx)
+			if err != nil {
+				return 0, err
+			}
+			return status.LatestHeight, nil
+		})
+	}
+
 	defer node.Cleanup()
 	node.Wait()
 }
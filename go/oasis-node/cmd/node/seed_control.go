@@ -81,3 +81,8 @@ func (n *SeedNode) GetStatus(_ context.Context) (*control.Status, error) {
 		Seed:            &seedStatus,
 	}, nil
 }
+
+// SetCommitteeAudit implements control.NodeController.
+func (n *SeedNode) SetCommitteeAudit(context.Context, *control.SetCommitteeAuditRequest) error {
+	return control.ErrNotImplemented
+}
@@ -2,6 +2,7 @@
 package runtime
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -12,6 +13,8 @@ import (
 	"github.com/spf13/viper"
 	"google.golang.org/grpc"
 
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
@@ -29,11 +32,20 @@ const (
 
 	// CfgIncludeSuspended is the flag to include suspended runtimes.
 	CfgIncludeSuspended = "include_suspended"
+
+	// CfgDiffEpoch is the flag to specify the epoch at which the runtime descriptor update
+	// is assumed to take place.
+	CfgDiffEpoch = "diff.epoch"
+
+	// CfgDiffWhatIf is the flag to validate the runtime descriptor update against the current
+	// state of a node.
+	CfgDiffWhatIf = "diff.what_if"
 )
 
 var (
 	runtimeListFlags = flag.NewFlagSet("", flag.ContinueOnError)
 	registerFlags    = flag.NewFlagSet("", flag.ContinueOnError)
+	diffFlags        = flag.NewFlagSet("", flag.ContinueOnError)
 
 	runtimeCmd = &cobra.Command{
 		Use:        "runtime",
@@ -55,6 +67,13 @@ var (
 		Deprecated: "use the `oasis` CLI instead.",
 	}
 
+	diffCmd = &cobra.Command{
+		Use:   "diff <old.json> <new.json>",
+		Short: "compare runtime descriptors and report the authorization required for each change",
+		Args:  cobra.ExactArgs(2),
+		Run:   doDiff,
+	}
+
 	logger = logging.GetLogger("cmd/registry/runtime")
 )
 
@@ -71,6 +90,19 @@ func doConnect(cmd *cobra.Command) (*grpc.ClientConn, registry.Backend) {
 	return conn, client
 }
 
+func loadRuntimeDescriptor(fn string) (*registry.Runtime, error) {
+	fileBytes, err := os.ReadFile(fn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read runtime descriptor: %w", err)
+	}
+
+	var rt registry.Runtime
+	if err = json.Unmarshal(fileBytes, &rt); err != nil {
+		return nil, fmt.Errorf("can't parse runtime descriptor: %w", err)
+	}
+	return &rt, nil
+}
+
 func doGenRegister(*cobra.Command, []string) {
 	if err := cmdCommon.Init(); err != nil {
 		cmdCommon.EarlyLogAndExit(err)
@@ -79,17 +111,9 @@ func doGenRegister(*cobra.Command, []string) {
 	genesis := cmdConsensus.InitGenesis()
 	cmdConsensus.AssertTxFileOK()
 
-	fileBytes, err := os.ReadFile(viper.GetString(CfgRuntimeDescriptor))
+	rt, err := loadRuntimeDescriptor(viper.GetString(CfgRuntimeDescriptor))
 	if err != nil {
-		logger.Error("failed to read runtime descriptor",
-			"err", err,
-		)
-		os.Exit(1)
-	}
-
-	var rt registry.Runtime
-	if err = json.Unmarshal(fileBytes, &rt); err != nil {
-		logger.Error("can't parse runtime descriptor",
+		logger.Error("failed to load runtime descriptor",
 			"err", err,
 		)
 		os.Exit(1)
@@ -109,7 +133,7 @@ func doGenRegister(*cobra.Command, []string) {
 	}
 
 	nonce, fee := cmdConsensus.GetTxNonceAndFee()
-	tx := registry.NewRegisterRuntimeTx(nonce, fee, &rt)
+	tx := registry.NewRegisterRuntimeTx(nonce, fee, rt)
 
 	cmdConsensus.SignAndSaveTx(cmdContext.GetCtxWithGenesisInfo(genesis), tx, nil)
 }
@@ -156,11 +180,118 @@ func doList(cmd *cobra.Command, _ []string) {
 	}
 }
 
+func doDiff(cmd *cobra.Command, args []string) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	currentRt, err := loadRuntimeDescriptor(args[0])
+	if err != nil {
+		logger.Error("failed to load old runtime descriptor",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	newRt, err := loadRuntimeDescriptor(args[1])
+	if err != nil {
+		logger.Error("failed to load new runtime descriptor",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	ctx := context.Background()
+	now := beacon.EpochTime(viper.GetUint64(CfgDiffEpoch))
+
+	var (
+		conn   *grpc.ClientConn
+		client registry.Backend
+	)
+	whatIf := viper.GetBool(CfgDiffWhatIf)
+	if whatIf {
+		conn, client = doConnect(cmd)
+		defer conn.Close()
+
+		if now, err = beacon.NewBeaconClient(conn).GetEpoch(ctx, consensus.HeightLatest); err != nil {
+			logger.Error("failed to query current epoch",
+				"err", err,
+			)
+			os.Exit(1)
+		}
+	}
+
+	changes, err := registry.DiffRuntimes(currentRt, newRt, now)
+	if err != nil {
+		logger.Error("failed to compare runtime descriptors",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	switch cmdFlags.Verbose() {
+	case true:
+		prettyChanges, err := cmdCommon.PrettyJSONMarshal(changes)
+		if err != nil {
+			logger.Error("failed to get pretty JSON of runtime descriptor changes",
+				"err", err,
+			)
+			os.Exit(1)
+		}
+		fmt.Println(string(prettyChanges))
+	default:
+		if len(changes) == 0 {
+			fmt.Println("no changes")
+		}
+		for _, change := range changes {
+			fmt.Println(change)
+		}
+	}
+
+	if !whatIf {
+		return
+	}
+
+	// Validate the update the same way as the consensus layer would.
+	registeredRt, err := client.GetRuntime(ctx, &registry.GetRuntimeQuery{
+		Height:           consensus.HeightLatest,
+		ID:               newRt.ID,
+		IncludeSuspended: true,
+	})
+	if err != nil {
+		logger.Error("failed to query registered runtime",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	if !bytes.Equal(cbor.Marshal(registeredRt), cbor.Marshal(currentRt)) {
+		fmt.Println("what-if: WARNING: old descriptor does not match the registered descriptor")
+	}
+	currentRt = registeredRt
+
+	params, err := client.ConsensusParameters(ctx, consensus.HeightLatest)
+	if err != nil {
+		logger.Error("failed to query registry consensus parameters",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	if err = registry.VerifyRuntime(params, logger, newRt, false, false, now); err == nil {
+		err = registry.VerifyRuntimeUpdate(logger, currentRt, newRt, now, params)
+	}
+	if err != nil {
+		fmt.Printf("what-if: update would be rejected at epoch %d: %s\n", now, err)
+		os.Exit(1)
+	}
+	fmt.Printf("what-if: update would be accepted at epoch %d\n", now)
+}
+
 // Register registers the runtime sub-command and all of it's children.
 func Register(parentCmd *cobra.Command) {
 	for _, v := range []*cobra.Command{
 		registerCmd,
 		listCmd,
+		diffCmd,
 	} {
 		runtimeCmd.AddCommand(v)
 
@@ -177,6 +308,10 @@ func Register(parentCmd *cobra.Command) {
 
 	registerCmd.Flags().AddFlagSet(registerFlags)
 
+	diffCmd.Flags().AddFlagSet(cmdGrpc.ClientFlags)
+	diffCmd.Flags().AddFlagSet(cmdFlags.VerboseFlags)
+	diffCmd.Flags().AddFlagSet(diffFlags)
+
 	parentCmd.AddCommand(runtimeCmd)
 }
 
@@ -192,4 +327,9 @@ func init() {
 	// List Runtimes flags.
 	runtimeListFlags.Bool(CfgIncludeSuspended, false, "Use to include suspended runtimes")
 	_ = viper.BindPFlags(runtimeListFlags)
+
+	// Diff flags.
+	diffFlags.Uint64(CfgDiffEpoch, 0, "Epoch at which the update is assumed to take place")
+	diffFlags.Bool(CfgDiffWhatIf, false, "Validate the update against the state of a node (uses the current epoch)")
+	_ = viper.BindPFlags(diffFlags)
 }
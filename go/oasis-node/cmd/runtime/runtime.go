@@ -0,0 +1,144 @@
+// Package runtime implements the runtime sub-commands.
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
+	cmdGrpc "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/grpc"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	"github.com/oasisprotocol/oasis-core/go/runtime/bundle"
+)
+
+const (
+	// CfgRuntimeID is the flag to specify the runtime identifier.
+	CfgRuntimeID = "id"
+
+	// CfgBundlePath is the flag to specify the path to the runtime bundle.
+	CfgBundlePath = "path"
+
+	// CfgEpoch is the flag to specify the epoch at which the deployment should be verified.
+	CfgEpoch = "epoch"
+)
+
+var (
+	verifyBundleFlags = flag.NewFlagSet("", flag.ContinueOnError)
+
+	runtimeCmd = &cobra.Command{
+		Use:   "runtime",
+		Short: "runtime utilities",
+	}
+
+	verifyBundleCmd = &cobra.Command{
+		Use:   "verify-bundle",
+		Short: "verify a runtime bundle against the registered runtime deployment",
+		Run:   doVerifyBundle,
+	}
+
+	logger = logging.GetLogger("cmd/runtime")
+)
+
+func doVerifyBundle(cmd *cobra.Command, _ []string) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	var runtimeID common.Namespace
+	if err := runtimeID.UnmarshalHex(viper.GetString(CfgRuntimeID)); err != nil {
+		logger.Error("failed to parse runtime ID",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	bnd, err := bundle.Open(viper.GetString(CfgBundlePath))
+	if err != nil {
+		logger.Error("failed to open runtime bundle",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	defer bnd.Close()
+
+	conn, err := cmdGrpc.NewClient(cmd)
+	if err != nil {
+		logger.Error("failed to establish connection with node",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	defer conn.Close()
+
+	ctx := context.Background()
+
+	rt, err := registry.NewRegistryClient(conn).GetRuntime(ctx, &registry.GetRuntimeQuery{
+		Height:           consensus.HeightLatest,
+		ID:               runtimeID,
+		IncludeSuspended: true,
+	})
+	if err != nil {
+		logger.Error("failed to query runtime",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	// Default to the current epoch when no epoch is given.
+	epoch := beacon.EpochTime(viper.GetUint64(CfgEpoch))
+	if !cmd.Flags().Changed(CfgEpoch) {
+		epoch, err = beacon.NewBeaconClient(conn).GetEpoch(ctx, consensus.HeightLatest)
+		if err != nil {
+			logger.Error("failed to query current epoch",
+				"err", err,
+			)
+			os.Exit(1)
+		}
+	}
+
+	deployment := rt.ActiveDeployment(epoch)
+	if deployment == nil {
+		logger.Error("no runtime deployment active at the given epoch",
+			"epoch", epoch,
+		)
+		os.Exit(1)
+	}
+
+	if err = bnd.VerifyDeployment(rt, deployment); err != nil {
+		logger.Error("runtime bundle does not match the registered deployment",
+			"err", err,
+			"epoch", epoch,
+			"version", deployment.Version,
+		)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Runtime bundle matches the deployment of version %s active at epoch %d (valid from epoch %d).\n",
+		deployment.Version, epoch, deployment.ValidFrom,
+	)
+}
+
+// Register registers the runtime sub-command and all of it's children.
+func Register(parentCmd *cobra.Command) {
+	verifyBundleCmd.Flags().AddFlagSet(cmdGrpc.ClientFlags)
+	verifyBundleCmd.Flags().AddFlagSet(verifyBundleFlags)
+
+	runtimeCmd.AddCommand(verifyBundleCmd)
+	parentCmd.AddCommand(runtimeCmd)
+}
+
+func init() {
+	verifyBundleFlags.String(CfgRuntimeID, "", "runtime identifier (hex)")
+	verifyBundleFlags.String(CfgBundlePath, "", "path to the runtime bundle")
+	verifyBundleFlags.Uint64(CfgEpoch, 0, "epoch at which to verify the deployment (default: current epoch)")
+	_ = viper.BindPFlags(verifyBundleFlags)
+}
@@ -10,7 +10,8 @@ import (
 	flag "github.com/spf13/pflag"
 	"github.com/spf13/viper"
 
-	"github.com/oasisprotocol/oasis-core/go/common/prettyprint"
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
 	cmdConsensus "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/consensus"
@@ -18,6 +19,7 @@ import (
 	cmdFlags "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
 	cmdGrpc "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/grpc"
 	"github.com/oasisprotocol/oasis-core/go/staking/api"
+	"github.com/oasisprotocol/oasis-core/go/staking/api/token"
 )
 
 const (
@@ -51,6 +53,12 @@ const (
 	// CfgAllowAmountChange configures the allowance change.
 	CfgAllowAmountChange = "stake.allow.amount_change"
 
+	// CfgAllowExpiration configures the allowance expiration epoch.
+	CfgAllowExpiration = "stake.allow.expiration"
+
+	// CfgAllowEpochLimit configures the allowance per-epoch withdrawal limit.
+	CfgAllowEpochLimit = "stake.allow.epoch_limit"
+
 	// CfgWithdrawSource configures the withdrawal source address.
 	CfgWithdrawSource = "stake.withdraw.source"
 )
@@ -182,10 +190,7 @@ func doAccountInfo(cmd *cobra.Command, _ []string) {
 	incomingDelegations := getDelegationsTo(ctx, addr, height, client)
 	outgoingDebondingDelegationInfos := getDebondingDelegationInfosFor(ctx, addr, height, client)
 	incomingDebondingDelegations := getDebondingDelegationsTo(ctx, addr, height, client)
-	symbol := getTokenSymbol(ctx, client)
-	exp := getTokenValueExponent(ctx, client)
-	ctx = context.WithValue(ctx, prettyprint.ContextKeyTokenSymbol, symbol)
-	ctx = context.WithValue(ctx, prettyprint.ContextKeyTokenValueExponent, exp)
+	ctx = token.NewContext(ctx, getTokenMetadata(ctx, client))
 
 	fmt.Printf("Account State for Height: %d\n", height)
 	fmt.Println("Balance:")
@@ -457,7 +462,7 @@ func doAccountAmendCommissionSchedule(*cobra.Command, []string) {
 	cmdConsensus.SignAndSaveTx(cmdContext.GetCtxWithGenesisInfo(genesis), tx, nil)
 }
 
-func doAccountAllow(*cobra.Command, []string) {
+func doAccountAllow(cmd *cobra.Command, _ []string) {
 	if err := cmdCommon.Init(); err != nil {
 		cmdCommon.EarlyLogAndExit(err)
 	}
@@ -487,6 +492,19 @@ func doAccountAllow(*cobra.Command, []string) {
 		)
 		os.Exit(1)
 	}
+	if cmd.Flags().Changed(CfgAllowExpiration) {
+		expiration := beacon.EpochTime(viper.GetUint64(CfgAllowExpiration))
+		allow.Expiration = &expiration
+	}
+	if cmd.Flags().Changed(CfgAllowEpochLimit) {
+		allow.EpochLimit = quantity.NewQuantity()
+		if err := allow.EpochLimit.UnmarshalText([]byte(viper.GetString(CfgAllowEpochLimit))); err != nil {
+			logger.Error("failed to parse allowance epoch limit",
+				"err", err,
+			)
+			os.Exit(1)
+		}
+	}
 
 	nonce, fee := cmdConsensus.GetTxNonceAndFee()
 	tx := api.NewAllowTx(nonce, fee, &allow)
@@ -604,6 +622,8 @@ func init() {
 
 	accountAllowFlags.String(CfgAllowBeneficiary, "", "allowance beneficiary address")
 	accountAllowFlags.String(CfgAllowAmountChange, "0", "allowance change amount (in base units)")
+	accountAllowFlags.Uint64(CfgAllowExpiration, 0, "allowance expiration epoch (0 removes the expiration)")
+	accountAllowFlags.String(CfgAllowEpochLimit, "0", "allowance per-epoch withdrawal limit (in base units, 0 removes the limit)")
 	_ = viper.BindPFlags(accountAllowFlags)
 	accountAllowFlags.AddFlagSet(cmdConsensus.TxFlags)
 	accountAllowFlags.AddFlagSet(cmdFlags.AssumeYesFlag)
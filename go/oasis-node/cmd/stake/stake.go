@@ -14,7 +14,6 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/errors"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
-	"github.com/oasisprotocol/oasis-core/go/common/prettyprint"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
 	cmdFlags "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
@@ -74,26 +73,15 @@ func doConnect(cmd *cobra.Command) (*grpc.ClientConn, api.Backend) {
 	return conn, client
 }
 
-func getTokenSymbol(ctx context.Context, client api.Backend) string {
-	symbol, err := client.TokenSymbol(ctx, consensus.HeightLatest)
+func getTokenMetadata(ctx context.Context, client api.Backend) *token.Metadata {
+	meta, err := client.TokenMetadata(ctx, consensus.HeightLatest)
 	if err != nil {
-		logger.Error("failed to query token's symbol",
+		logger.Error("failed to query token metadata",
 			"err", err,
 		)
 		os.Exit(1)
 	}
-	return symbol
-}
-
-func getTokenValueExponent(ctx context.Context, client api.Backend) uint8 {
-	exp, err := client.TokenValueExponent(ctx, consensus.HeightLatest)
-	if err != nil {
-		logger.Error("failed to query token's value exponent",
-			"err", err,
-		)
-		os.Exit(1)
-	}
-	return exp
+	return meta
 }
 
 func getAccount(ctx context.Context, addr api.Address, height int64, client api.Backend) *api.Account {
@@ -187,12 +175,10 @@ func doInfo(cmd *cobra.Command, _ []string) {
 	height := consensus.HeightLatest
 
 	ctx := context.Background()
-	symbol := getTokenSymbol(ctx, client)
-	fmt.Printf("Token's ticker symbol: %s\n", symbol)
-	exp := getTokenValueExponent(ctx, client)
-	fmt.Printf("Token's value base-10 exponent: %d\n", exp)
-	ctx = context.WithValue(ctx, prettyprint.ContextKeyTokenSymbol, symbol)
-	ctx = context.WithValue(ctx, prettyprint.ContextKeyTokenValueExponent, exp)
+	meta := getTokenMetadata(ctx, client)
+	fmt.Printf("Token's ticker symbol: %s\n", meta.Symbol)
+	fmt.Printf("Token's value base-10 exponent: %d\n", meta.Decimals)
+	ctx = token.NewContext(ctx, meta)
 
 	totalSupply, err := client.TotalSupply(ctx, height)
 	if err != nil {
@@ -0,0 +1,212 @@
+package cmd
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	outputFormatJUnit = "junit"
+	outputFormatJSON  = "json"
+
+	reportSuiteName            = "oasis-test-runner"
+	reportQuarantinedSuiteName = "oasis-test-runner/quarantined"
+)
+
+// Scenario result statuses.
+const (
+	scenarioStatusPassed  = "passed"
+	scenarioStatusFailed  = "failed"
+	scenarioStatusSkipped = "skipped"
+)
+
+// scenarioResult is the result of a single scenario run.
+type scenarioResult struct {
+	// Name is the name of the scenario.
+	Name string `json:"name"`
+	// RunID is the unique run identifier of the scenario.
+	RunID int `json:"run_id"`
+	// Parameters is the parameter set the scenario was run with.
+	Parameters map[string]string `json:"parameters,omitempty"`
+	// Status is the status of the scenario run.
+	Status string `json:"status"`
+	// Duration is the duration of the scenario run.
+	Duration time.Duration `json:"duration"`
+	// Retries is the number of times the scenario was retried.
+	Retries int `json:"retries"`
+	// Quarantined is true iff the scenario is quarantined, in which case its
+	// failure does not fail the suite.
+	Quarantined bool `json:"quarantined,omitempty"`
+	// Error is the error the scenario failed with, if any.
+	Error string `json:"error,omitempty"`
+	// LogWatcherErrors are the log watcher assertion failures, if any.
+	LogWatcherErrors []string `json:"log_watcher_errors,omitempty"`
+}
+
+// report is the structured report of a test runner invocation.
+type report struct {
+	// Results are the scenario results in the order the scenarios were run.
+	Results []*scenarioResult `json:"results"`
+}
+
+func (r *report) add(res *scenarioResult) {
+	r.Results = append(r.Results, res)
+}
+
+func (r *report) write(format, path string) error {
+	var (
+		w   io.Writer = os.Stdout
+		err error
+	)
+	if path != "" {
+		var f *os.File
+		if f, err = os.Create(path); err != nil {
+			return fmt.Errorf("failed to create report file: %w", err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	switch strings.ToLower(format) {
+	case outputFormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(r)
+	case outputFormatJUnit:
+		if _, err = io.WriteString(w, xml.Header); err != nil {
+			return err
+		}
+		enc := xml.NewEncoder(w)
+		enc.Indent("", "  ")
+		if err = enc.Encode(r.toJUnit()); err == nil {
+			_, err = io.WriteString(w, "\n")
+		}
+	default:
+		return fmt.Errorf("unsupported output format: %s", format)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	return nil
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     float64          `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      float64         `xml:"time,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Body    string `xml:",chardata"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr,omitempty"`
+}
+
+type junitTestCase struct {
+	Name       string          `xml:"name,attr"`
+	ClassName  string          `xml:"classname,attr"`
+	Time       float64         `xml:"time,attr"`
+	Properties []junitProperty `xml:"properties>property,omitempty"`
+	Failure    *junitFailure   `xml:"failure,omitempty"`
+	Skipped    *junitSkipped   `xml:"skipped,omitempty"`
+}
+
+func (r *report) toJUnit() *junitTestSuites {
+	// Quarantined scenarios are reported in a separate suite, with failures
+	// reported as skipped so that they do not fail the whole run.
+	suite := junitTestSuite{
+		Name: reportSuiteName,
+	}
+	quarantinedSuite := junitTestSuite{
+		Name: reportQuarantinedSuiteName,
+	}
+	for _, res := range r.Results {
+		tc := junitTestCase{
+			Name:      fmt.Sprintf("%s/%d", res.Name, res.RunID),
+			ClassName: res.Name,
+			Time:      res.Duration.Seconds(),
+		}
+
+		names := make([]string, 0, len(res.Parameters))
+		for name := range res.Parameters {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			tc.Properties = append(tc.Properties, junitProperty{Name: name, Value: res.Parameters[name]})
+		}
+		if res.Retries > 0 {
+			tc.Properties = append(tc.Properties, junitProperty{Name: "retries", Value: fmt.Sprintf("%d", res.Retries)})
+		}
+
+		s := &suite
+		if res.Quarantined {
+			s = &quarantinedSuite
+		}
+
+		switch {
+		case res.Status == scenarioStatusFailed && res.Quarantined:
+			s.Skipped++
+			tc.Skipped = &junitSkipped{Message: "quarantined: " + res.Error}
+		case res.Status == scenarioStatusFailed:
+			s.Failures++
+			body := res.Error
+			if len(res.LogWatcherErrors) > 0 {
+				body += "\n\nLog watcher assertion failures:\n" + strings.Join(res.LogWatcherErrors, "\n")
+			}
+			tc.Failure = &junitFailure{
+				Message: res.Error,
+				Body:    body,
+			}
+		case res.Status == scenarioStatusSkipped:
+			s.Skipped++
+			tc.Skipped = &junitSkipped{}
+		}
+
+		s.Tests++
+		s.Time += tc.Time
+		s.TestCases = append(s.TestCases, tc)
+	}
+
+	suites := &junitTestSuites{
+		Name:   reportSuiteName,
+		Suites: []junitTestSuite{suite},
+	}
+	if quarantinedSuite.Tests > 0 {
+		suites.Suites = append(suites.Suites, quarantinedSuite)
+	}
+	for _, s := range suites.Suites {
+		suites.Tests += s.Tests
+		suites.Failures += s.Failures
+		suites.Skipped += s.Skipped
+		suites.Time += s.Time
+	}
+	return suites
+}
@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReport(t *testing.T) {
+	require := require.New(t)
+
+	var r report
+	r.add(&scenarioResult{
+		Name:       "e2e/runtime/runtime",
+		Parameters: map[string]string{"runtime.binary": "simple-keyvalue"},
+		Status:     scenarioStatusPassed,
+		Duration:   2 * time.Second,
+	})
+	r.add(&scenarioResult{
+		Name:             "e2e/runtime/byzantine/executor",
+		RunID:            1,
+		Status:           scenarioStatusFailed,
+		Duration:         time.Second,
+		Retries:          2,
+		Error:            "root: failed to run scenario: boom",
+		LogWatcherErrors: []string{"log watcher compute-0/log: round failure"},
+	})
+	r.add(&scenarioResult{
+		Name:   "e2e/runtime/excluded",
+		Status: scenarioStatusSkipped,
+	})
+
+	dir := t.TempDir()
+
+	// JSON.
+	path := filepath.Join(dir, "report.json")
+	require.NoError(r.write(outputFormatJSON, path), "write JSON report")
+	raw, err := os.ReadFile(path)
+	require.NoError(err, "ReadFile")
+	var decoded report
+	require.NoError(json.Unmarshal(raw, &decoded), "Unmarshal JSON report")
+	require.Equal(r, decoded, "JSON report should round-trip")
+
+	// JUnit.
+	path = filepath.Join(dir, "report.xml")
+	require.NoError(r.write(outputFormatJUnit, path), "write JUnit report")
+	raw, err = os.ReadFile(path)
+	require.NoError(err, "ReadFile")
+	var suites junitTestSuites
+	require.NoError(xml.Unmarshal(raw, &suites), "Unmarshal JUnit report")
+	require.Equal(3, suites.Tests)
+	require.Equal(1, suites.Failures)
+	require.Equal(1, suites.Skipped)
+	require.Len(suites.Suites, 1)
+	require.Len(suites.Suites[0].TestCases, 3)
+
+	failed := suites.Suites[0].TestCases[1]
+	require.Equal("e2e/runtime/byzantine/executor/1", failed.Name)
+	require.NotNil(failed.Failure)
+	require.Equal("root: failed to run scenario: boom", failed.Failure.Message)
+	require.Contains(failed.Failure.Body, "round failure")
+	require.Contains(failed.Properties, junitProperty{Name: "retries", Value: "2"})
+
+	require.Error(r.write("yaml", path), "unsupported format should fail")
+}
+
+func TestReportQuarantined(t *testing.T) {
+	require := require.New(t)
+
+	var r report
+	r.add(&scenarioResult{
+		Name:   "e2e/runtime/runtime",
+		Status: scenarioStatusPassed,
+	})
+	r.add(&scenarioResult{
+		Name:        "e2e/runtime/byzantine/executor",
+		Status:      scenarioStatusFailed,
+		Quarantined: true,
+		Error:       "root: failed to run scenario: flaky",
+	})
+
+	suites := r.toJUnit()
+	require.Equal(2, suites.Tests)
+	require.Equal(0, suites.Failures, "quarantined failures should not fail the suite")
+	require.Equal(1, suites.Skipped)
+	require.Len(suites.Suites, 2)
+	require.Equal(reportQuarantinedSuiteName, suites.Suites[1].Name)
+
+	quarantined := suites.Suites[1].TestCases[0]
+	require.Nil(quarantined.Failure)
+	require.NotNil(quarantined.Skipped)
+	require.Contains(quarantined.Skipped.Message, "flaky")
+}
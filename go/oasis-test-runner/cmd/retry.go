@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// scenarioRetries is the retry count of scenarios matching a name regex.
+type scenarioRetries struct {
+	regex   *regexp.Regexp
+	retries int
+}
+
+// retryPolicy determines how many times a failed scenario is retried and
+// whether the scenario is quarantined.
+type retryPolicy struct {
+	defaultRetries  int
+	scenarioRetries []scenarioRetries
+	quarantine      []*regexp.Regexp
+}
+
+// newRetryPolicy creates a new retry policy.
+//
+// The per-scenario retry counts are keyed by scenario name regexes. If more
+// than one regex matches a scenario, the longest (most specific) one is used.
+func newRetryPolicy(defaultRetries int, perScenario map[string]string, quarantineFile string) (*retryPolicy, error) {
+	if defaultRetries < 0 {
+		return nil, fmt.Errorf("invalid number of retries: %d", defaultRetries)
+	}
+	p := &retryPolicy{
+		defaultRetries: defaultRetries,
+	}
+
+	regexes := make([]string, 0, len(perScenario))
+	for regex := range perScenario {
+		regexes = append(regexes, regex)
+	}
+	sort.Slice(regexes, func(i, j int) bool {
+		if len(regexes[i]) != len(regexes[j]) {
+			return len(regexes[i]) > len(regexes[j])
+		}
+		return regexes[i] < regexes[j]
+	})
+	for _, regex := range regexes {
+		retries, err := strconv.Atoi(perScenario[regex])
+		if err != nil || retries < 0 {
+			return nil, fmt.Errorf("invalid number of retries for '%s': %s", regex, perScenario[regex])
+		}
+		re, err := compileScenarioRegex(regex)
+		if err != nil {
+			return nil, err
+		}
+		p.scenarioRetries = append(p.scenarioRetries, scenarioRetries{re, retries})
+	}
+
+	if quarantineFile != "" {
+		var err error
+		if p.quarantine, err = loadQuarantineList(quarantineFile); err != nil {
+			return nil, err
+		}
+	}
+
+	return p, nil
+}
+
+// retries returns the number of times the given scenario may be retried.
+func (p *retryPolicy) retries(name string) int {
+	for _, sr := range p.scenarioRetries {
+		if sr.regex.MatchString(name) {
+			return sr.retries
+		}
+	}
+	return p.defaultRetries
+}
+
+// isQuarantined returns true iff the given scenario is quarantined.
+func (p *retryPolicy) isQuarantined(name string) bool {
+	for _, re := range p.quarantine {
+		if re.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// loadQuarantineList loads the list of quarantined scenario name regexes from
+// the given file. The file contains one regex per line, empty lines and lines
+// starting with '#' are ignored.
+func loadQuarantineList(path string) ([]*regexp.Regexp, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open quarantine list: %w", err)
+	}
+	defer f.Close()
+
+	var list []*regexp.Regexp
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		re, err := compileScenarioRegex(line)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, re)
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read quarantine list: %w", err)
+	}
+
+	return list, nil
+}
+
+// compileScenarioRegex compiles a regex which must match the whole scenario name.
+func compileScenarioRegex(regex string) (*regexp.Regexp, error) {
+	re, err := regexp.Compile(fmt.Sprintf("^%s$", regex))
+	if err != nil {
+		return nil, fmt.Errorf("bad scenario regexp '%s': %w", regex, err)
+	}
+	return re, nil
+}
@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryPolicy(t *testing.T) {
+	require := require.New(t)
+
+	path := filepath.Join(t.TempDir(), "quarantine.txt")
+	err := os.WriteFile(path, []byte("# Flaky byzantine scenarios.\ne2e/runtime/byzantine/.*\n\n  e2e/runtime/sentry  \n"), 0o600)
+	require.NoError(err, "WriteFile")
+
+	p, err := newRetryPolicy(1, map[string]string{
+		"e2e/runtime/byzantine/.*": "3",
+		"e2e/runtime/.*":           "2",
+	}, path)
+	require.NoError(err, "newRetryPolicy")
+
+	require.Equal(3, p.retries("e2e/runtime/byzantine/executor"))
+	require.Equal(2, p.retries("e2e/runtime/runtime"))
+	require.Equal(1, p.retries("e2e/gas-fees/staking"))
+
+	require.True(p.isQuarantined("e2e/runtime/byzantine/executor"))
+	require.True(p.isQuarantined("e2e/runtime/sentry"))
+	require.False(p.isQuarantined("e2e/runtime/sentry-encryption"))
+	require.False(p.isQuarantined("e2e/runtime/runtime"))
+
+	_, err = newRetryPolicy(-1, nil, "")
+	require.Error(err, "negative retries should fail")
+	_, err = newRetryPolicy(0, map[string]string{"e2e/.*": "many"}, "")
+	require.Error(err, "malformed retries should fail")
+	_, err = newRetryPolicy(0, nil, filepath.Join(t.TempDir(), "missing"))
+	require.Error(err, "missing quarantine list should fail")
+}
@@ -41,8 +41,15 @@ const (
 	cfgMetricsAddr      = "metrics.address"
 	cfgMetricsLabels    = "metrics.labels"
 	cfgMetricsInterval  = "metrics.interval"
+	cfgMetricsRunID     = "metrics.run_id"
 	cfgTimeout          = "timeout"
 	cfgScenarioTimeout  = "scenario_timeout"
+	cfgOutputFormat     = "output.format"
+	cfgOutputFile       = "output.file"
+	cfgRetries          = "retries"
+	cfgRetriesScenario  = "retries.scenario"
+	cfgQuarantineFile   = "quarantine.file"
+	cfgCoverageDir      = "coverage.dir"
 )
 
 var (
@@ -271,12 +278,14 @@ func initRootEnv(cmd *cobra.Command) (*env.Env, error) {
 	return env, nil
 }
 
-func runRoot(cmd *cobra.Command, _ []string) error { // nolint: gocyclo
+func runRoot(cmd *cobra.Command, _ []string) (err error) { // nolint: gocyclo
 	cmd.SilenceUsage = true
 
 	// Workaround for viper bug: https://github.com/spf13/viper/issues/233
 	_ = viper.BindPFlag(cfgMetricsAddr, cmd.Flags().Lookup(cfgMetricsAddr))
 	_ = viper.BindPFlag(cfgMetricsLabels, cmd.Flags().Lookup(cfgMetricsLabels))
+	_ = viper.BindPFlag(cfgMetricsRunID, cmd.Flags().Lookup(cfgMetricsRunID))
+	_ = viper.BindPFlag(cfgRetriesScenario, cmd.Flags().Lookup(cfgRetriesScenario))
 
 	if viper.IsSet(cfgMetricsAddr) {
 		oasisTestRunnerOnce.Do(func() {
@@ -284,6 +293,15 @@ func runRoot(cmd *cobra.Command, _ []string) error { // nolint: gocyclo
 		})
 	}
 
+	// Validate the output format early so that a typo doesn't get noticed only
+	// after all scenarios have been run.
+	outputFormat := viper.GetString(cfgOutputFormat)
+	switch outputFormat {
+	case "", outputFormatJUnit, outputFormatJSON:
+	default:
+		return fmt.Errorf("root: unsupported output format: %s", outputFormat)
+	}
+
 	// Initialize the base dir, logging, etc.
 	rootEnv, err := initRootEnv(cmd)
 	if err != nil {
@@ -292,6 +310,31 @@ func runRoot(cmd *cobra.Command, _ []string) error { // nolint: gocyclo
 	defer rootEnv.Cleanup()
 	logger := logging.GetLogger("test-runner")
 
+	// Initialize the seed from which all randomness is derived and log it so that
+	// failing runs can be reproduced.
+	seed, err := env.InitSeed()
+	if err != nil {
+		return fmt.Errorf("root: %w", err)
+	}
+	logger.Info("using random seed",
+		"seed", seed,
+	)
+
+	// Emit the structured report once all scenarios are done, even if any of them failed.
+	var results report
+	if outputFormat != "" {
+		defer func() {
+			if reportErr := results.write(outputFormat, viper.GetString(cfgOutputFile)); reportErr != nil {
+				logger.Error("failed to write report",
+					"err", reportErr,
+				)
+				if err == nil {
+					err = fmt.Errorf("root: %w", reportErr)
+				}
+			}
+		}()
+	}
+
 	// Enumerate requested scenarios.
 	toRun := common.GetDefaultScenarios() // Run all default scenarios if not set.
 	if scNameRegexes := viper.GetStringSlice(common.CfgScenarioRegex); len(scNameRegexes) > 0 {
@@ -357,6 +400,16 @@ func runRoot(cmd *cobra.Command, _ []string) error { // nolint: gocyclo
 		}
 	}
 
+	// Get the retry policy and the quarantine list.
+	policy, err := newRetryPolicy(
+		viper.GetInt(cfgRetries),
+		viper.GetStringMapString(cfgRetriesScenario),
+		viper.GetString(cfgQuarantineFile),
+	)
+	if err != nil {
+		return fmt.Errorf("root: %w", err)
+	}
+
 	// Get parallel job execution parameters.
 	parallelJobCount := viper.GetInt(cfgParallelJobCount)
 	parallelJobIndex := viper.GetInt(cfgParallelJobIndex)
@@ -404,69 +457,76 @@ func runRoot(cmd *cobra.Command, _ []string) error { // nolint: gocyclo
 					continue
 				}
 
+				// Scenarios are considered failed until they pass.
+				res := &scenarioResult{
+					Name:       name,
+					RunID:      runID,
+					Parameters: scenarioParameters(v),
+					Status:     scenarioStatusFailed,
+				}
+				results.add(res)
+
 				if excludeMap[strings.ToLower(v.Name())] {
 					logger.Info("skipping scenario (excluded by environment)",
 						"scenario", name, "run_id", runID,
 					)
+					res.Status = scenarioStatusSkipped
 					index++
 					continue
 				}
 
-				logger.Info("running scenario",
-					"scenario", name, "run_id", runID,
-				)
+				quarantined := policy.isQuarantined(name)
+				res.Quarantined = quarantined
+
+				maxRetries := policy.retries(name)
+				start := time.Now()
+				for attempt := 0; ; attempt++ {
+					// Retries need a pristine scenario instance and datadir.
+					attemptSc, attemptName := v, n
+					if attempt > 0 {
+						attemptSc = v.Clone()
+						attemptName = fmt.Sprintf("%s/retry-%d", n, attempt)
+					}
 
-				childEnv, err := rootEnv.NewChild(n, &env.ScenarioInstanceInfo{
-					Scenario:     v.Name(),
-					Instance:     filepath.Base(rootEnv.Dir()),
-					ParameterSet: v.Parameters(),
-					Run:          run,
-				})
-				if err != nil {
-					logger.Error("failed to setup child environment",
-						"err", err, "scenario", name, "run_id", runID,
+					logger.Info("running scenario",
+						"scenario", name, "run_id", runID, "attempt", attempt,
 					)
-					return fmt.Errorf("root: failed to setup child environment: %w", err)
-				}
 
-				// Dump current parameter set to file.
-				if err = childEnv.WriteScenarioInfo(); err != nil {
-					return err
-				}
-
-				// Init per-run prometheus pusher, if metrics are enabled.
-				if viper.IsSet(cfgMetricsAddr) {
-					pusher = push.New(viper.GetString(cfgMetricsAddr), metrics.MetricsJobTestRunner)
-					labels := metrics.GetDefaultPushLabels(childEnv.ScenarioInfo())
-					for k, v := range labels {
-						pusher = pusher.Grouping(k, v)
+					res.LogWatcherErrors = nil
+					err = runScenarioAttempt(ctx, rootEnv, attemptName, run, attemptSc, res)
+					if err == nil || attempt >= maxRetries {
+						break
 					}
-					pusher = pusher.Gatherer(prometheus.DefaultGatherer)
-				}
 
-				if err = doScenario(ctx, childEnv, v); err != nil {
-					logger.Error("failed to run scenario",
+					logger.Warn("retrying failed scenario",
 						"err", err,
 						"scenario", name,
 						"run_id", runID,
+						"attempt", attempt,
+						"max_retries", maxRetries,
 					)
-					err = fmt.Errorf("root: failed to run scenario: %w", err)
+					res.Retries++
 				}
 
-				if cleanErr := doCleanup(childEnv); cleanErr != nil {
-					logger.Error("failed to clean up child environment",
-						"err", cleanErr,
+				res.Duration = time.Since(start)
+				if err != nil {
+					res.Error = err.Error()
+					if !quarantined {
+						return err
+					}
+
+					// Quarantined scenarios are reported separately and must not fail
+					// the whole suite.
+					logger.Warn("quarantined scenario failed",
+						"err", err,
 						"scenario", name,
 						"run_id", runID,
 					)
-					if err == nil {
-						err = fmt.Errorf("root: failed to clean up child environment: %w", cleanErr)
-					}
-				}
-
-				if err != nil {
-					return err
+					err = nil
+					index++
+					continue
 				}
+				res.Status = scenarioStatusPassed
 
 				logger.Info("passed scenario",
 					"scenario", name, "run_id", runID,
@@ -480,14 +540,95 @@ func runRoot(cmd *cobra.Command, _ []string) error { // nolint: gocyclo
 	return nil
 }
 
-func doScenario(ctx context.Context, childEnv *env.Env, sc scenario.Scenario) (err error) {
+// runScenarioAttempt runs a single attempt of a scenario in a new child environment.
+func runScenarioAttempt(ctx context.Context, rootEnv *env.Env, name string, run int, sc scenario.Scenario, res *scenarioResult) error {
+	childEnv, err := rootEnv.NewChild(name, &env.ScenarioInstanceInfo{
+		Scenario:      sc.Name(),
+		Instance:      filepath.Base(rootEnv.Dir()),
+		ParameterSet:  sc.Parameters(),
+		Run:           run,
+		RunID:         viper.GetString(cfgMetricsRunID),
+		MetricsLabels: viper.GetStringMapString(cfgMetricsLabels),
+	})
+	if err != nil {
+		return fmt.Errorf("root: failed to setup child environment: %w", err)
+	}
+
+	// Dump current parameter set to file.
+	if err = childEnv.WriteScenarioInfo(); err != nil {
+		return err
+	}
+
+	// Init per-run prometheus pusher, if metrics are enabled.
+	if viper.IsSet(cfgMetricsAddr) {
+		pusher = push.New(viper.GetString(cfgMetricsAddr), metrics.MetricsJobTestRunner)
+		labels := metrics.GetDefaultPushLabels(childEnv.ScenarioInfo())
+		for k, v := range labels {
+			pusher = pusher.Grouping(k, v)
+		}
+		pusher = pusher.Gatherer(prometheus.DefaultGatherer)
+	}
+
+	// Coverage data of each scenario run is kept separately.
+	var coverageDir string
+	if dir := viper.GetString(cfgCoverageDir); dir != "" {
+		coverageDir = filepath.Join(dir, name)
+		if err = oasis.PrepareCoverage(coverageDir); err != nil {
+			return fmt.Errorf("root: failed to prepare coverage directory: %w", err)
+		}
+	}
+
+	if err = doScenario(ctx, childEnv, sc, coverageDir, res); err != nil {
+		logger := logging.GetLogger("test-runner")
+		logger.Error("failed to run scenario",
+			"err", err,
+			"scenario", sc.Name(),
+		)
+		err = fmt.Errorf("root: failed to run scenario: %w", err)
+	}
+
+	if cleanErr := doCleanup(childEnv); cleanErr != nil {
+		logger := logging.GetLogger("test-runner")
+		logger.Error("failed to clean up child environment",
+			"err", cleanErr,
+			"scenario", sc.Name(),
+		)
+		if err == nil {
+			err = fmt.Errorf("root: failed to clean up child environment: %w", cleanErr)
+		}
+	}
+
+	// Merge coverage data once all nodes have exited.
+	if coverageDir != "" {
+		if covErr := oasis.MergeCoverage(coverageDir); covErr != nil {
+			logger := logging.GetLogger("test-runner")
+			logger.Error("failed to merge coverage data",
+				"err", covErr,
+				"scenario", sc.Name(),
+			)
+			if err == nil {
+				err = fmt.Errorf("root: failed to merge coverage data: %w", covErr)
+			}
+		}
+	}
+
+	return err
+}
+
+func doScenario(ctx context.Context, childEnv *env.Env, sc scenario.Scenario, coverageDir string, res *scenarioResult) (err error) {
+	var net *oasis.Network
 	defer func() {
 		if r := recover(); r != nil {
 			err = fmt.Errorf("root: panic caught running scenario: %v: %s", r, debug.Stack())
 		}
+		if net != nil {
+			for _, logErr := range net.LogWatcherErrors() {
+				res.LogWatcherErrors = append(res.LogWatcherErrors, logErr.Error())
+			}
+		}
 	}()
 
-	if err = sc.PreInit(); err != nil {
+	if err = sc.PreInit(childEnv); err != nil {
 		err = fmt.Errorf("root: failed to pre-initialize scenario: %w", err)
 		return
 	}
@@ -500,7 +641,6 @@ func doScenario(ctx context.Context, childEnv *env.Env, sc scenario.Scenario) (e
 
 	// Instantiate fixture if it is non-nil. Otherwise assume Init will do
 	// something on its own.
-	var net *oasis.Network
 	if fixture != nil {
 		if net, err = fixture.Create(childEnv); err != nil {
 			err = fmt.Errorf("root: failed to instantiate fixture: %w", err)
@@ -518,6 +658,8 @@ func doScenario(ctx context.Context, childEnv *env.Env, sc scenario.Scenario) (e
 			net.Config().Metrics.Address = viper.GetString(cfgMetricsAddr)
 			net.Config().Metrics.Interval = viper.GetDuration(cfgMetricsInterval)
 		}
+
+		net.Config().CoverageDir = coverageDir
 	}
 
 	if err = sc.Init(childEnv, net); err != nil {
@@ -565,6 +707,14 @@ func doCleanup(childEnv *env.Env) (err error) {
 	return
 }
 
+func scenarioParameters(sc scenario.Scenario) map[string]string {
+	params := make(map[string]string)
+	sc.Parameters().VisitAll(func(f *flag.Flag) {
+		params[f.Name] = f.Value.String()
+	})
+	return params
+}
+
 func runList(*cobra.Command, []string) {
 	scNames := common.GetScenarioNames()
 	switch len(scNames) {
@@ -618,6 +768,7 @@ func init() {
 		map[string]string{},
 		"override Prometheus labels",
 	)
+	persistentFlags.String(cfgMetricsRunID, "", "identifier of this run attached to all pushed metrics (e.g. CI build number)")
 	_ = viper.BindPFlags(persistentFlags)
 	rootCmd.PersistentFlags().AddFlagSet(persistentFlags)
 
@@ -635,6 +786,12 @@ func init() {
 	rootFlags.Int(cfgParallelJobIndex, 0, "(for CI) index of this parallel job")
 	rootFlags.Duration(cfgTimeout, 24*time.Hour, "the maximum allowable total duration for all scenarios")
 	rootFlags.Duration(cfgScenarioTimeout, 20*time.Minute, "the maximum allowable duration for an individual scenario")
+	rootFlags.String(cfgOutputFormat, "", "structured result output format (junit, json)")
+	rootFlags.String(cfgOutputFile, "", "structured result output file (default: stdout)")
+	rootFlags.Int(cfgRetries, 0, "number of times a failed scenario is retried")
+	rootFlags.StringToInt(cfgRetriesScenario, map[string]int{}, "per-scenario retry counts (scenario name regexp=count)")
+	rootFlags.String(cfgQuarantineFile, "", "file with regexp patterns matching names of quarantined scenarios")
+	rootFlags.String(cfgCoverageDir, "", "directory for coverage data of coverage-instrumented node binaries (merged per scenario)")
 	_ = viper.BindPFlags(rootFlags)
 	rootCmd.Flags().AddFlagSet(rootFlags)
 	rootCmd.Flags().AddFlagSet(env.Flags)
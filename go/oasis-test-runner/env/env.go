@@ -5,6 +5,7 @@ import (
 	"container/list"
 	"encoding/json"
 	"errors"
+	"math/rand"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -49,6 +50,13 @@ type ScenarioInstanceInfo struct {
 
 	// Run is the number of the run.
 	Run int `json:"run"`
+
+	// RunID is an optional identifier of the test runner invocation (e.g. a CI build number)
+	// which is used to track metrics of the same scenario across invocations.
+	RunID string `json:"run_id,omitempty"`
+
+	// MetricsLabels are additional labels attached to all pushed metrics.
+	MetricsLabels map[string]string `json:"metrics_labels,omitempty"`
 }
 
 // MarshalJSON outputs ParameterFlagSet as an ordinary JSON map.
@@ -103,6 +111,9 @@ type Env struct {
 	cleanupCmds  []*cmdMonitor
 	cleanupLock  sync.Mutex
 
+	rngs    map[string]*rand.Rand
+	rngLock sync.Mutex
+
 	isInCleanup bool
 }
 
@@ -0,0 +1,123 @@
+package env
+
+import (
+	"crypto"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	mathRand "math/rand"
+	"path"
+	"sync"
+
+	"github.com/spf13/viper"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/drbg"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/mathrand"
+)
+
+const (
+	cfgSeed = "seed"
+
+	// seedSize is the size of randomly generated root seeds.
+	seedSize = 16
+)
+
+var rootSeed []byte
+
+// InitSeed initializes the root seed from which all randomness used by the test runner is
+// derived, generating a random one if none was configured.
+//
+// It returns the hex-encoded root seed which can be passed via the seed flag to reproduce a run.
+func InitSeed() (string, error) {
+	if rawSeed := viper.GetString(cfgSeed); rawSeed != "" {
+		seed, err := hex.DecodeString(rawSeed)
+		if err != nil {
+			return "", fmt.Errorf("env: malformed seed: %w", err)
+		}
+		rootSeed = seed
+	} else {
+		rootSeed = make([]byte, seedSize)
+		if _, err := rand.Read(rootSeed); err != nil {
+			return "", fmt.Errorf("env: failed to generate seed: %w", err)
+		}
+	}
+
+	return hex.EncodeToString(rootSeed), nil
+}
+
+// DeriveSeed deterministically derives a seed for the given domain from the root seed.
+func DeriveSeed(domain string) []byte {
+	h := hash.NewFromBytes(rootSeed, []byte(domain))
+	return h[:]
+}
+
+// Rand returns a deterministic random number generator for the given domain, derived from
+// the root seed and the environment's path.
+//
+// Repeated calls with the same domain return the same generator, so subsequent values continue
+// the same sequence. The returned generator is safe for concurrent use.
+func (env *Env) Rand(domain string) *mathRand.Rand {
+	env.rngLock.Lock()
+	defer env.rngLock.Unlock()
+
+	if rng, ok := env.rngs[domain]; ok {
+		return rng
+	}
+
+	src, err := drbg.New(crypto.SHA512, env.DeriveSeed(domain), nil, []byte("oasis-test-runner"))
+	if err != nil {
+		// This can only happen on invalid hash or seed sizes.
+		panic(fmt.Errorf("env: failed to create random source: %w", err))
+	}
+	rng := mathRand.New(&lockedSource{src: mathrand.New(src)}) // #nosec G404
+
+	if env.rngs == nil {
+		env.rngs = make(map[string]*mathRand.Rand)
+	}
+	env.rngs[domain] = rng
+
+	return rng
+}
+
+// DeriveSeed deterministically derives a seed for the given domain from the root seed and
+// the environment's path.
+func (env *Env) DeriveSeed(domain string) []byte {
+	return DeriveSeed(path.Join(env.path(), domain))
+}
+
+// path returns the path of the environment from the root environment.
+func (env *Env) path() string {
+	if env.parent == nil {
+		return "/"
+	}
+	return path.Join(env.parent.path(), env.name)
+}
+
+// lockedSource is a rand.Source64 which is safe for concurrent use.
+type lockedSource struct {
+	mu  sync.Mutex
+	src mathRand.Source64
+}
+
+func (s *lockedSource) Int63() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.src.Int63()
+}
+
+func (s *lockedSource) Uint64() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.src.Uint64()
+}
+
+func (s *lockedSource) Seed(int64) {
+	panic("env: lockedSource cannot be re-seeded")
+}
+
+func init() {
+	Flags.String(cfgSeed, "", "hex-encoded seed used to derive all test runner randomness (default: random)")
+
+	_ = viper.BindPFlag(cfgSeed, Flags.Lookup(cfgSeed))
+}
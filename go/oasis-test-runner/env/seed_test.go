@@ -0,0 +1,40 @@
+package env
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeed(t *testing.T) {
+	require := require.New(t)
+
+	viper.Set(cfgSeed, "0123456789abcdef")
+	defer viper.Set(cfgSeed, "")
+
+	seed, err := InitSeed()
+	require.NoError(err, "InitSeed")
+	require.Equal("0123456789abcdef", seed)
+
+	newChild := func(name string) *Env {
+		root := New(&Dir{dir: t.TempDir()})
+		child, err := root.NewChild(name, nil)
+		require.NoError(err, "NewChild")
+		return child
+	}
+
+	// The same seed should produce the same values for the same environment and domain.
+	env1 := newChild("scenario")
+	env2 := newChild("scenario")
+	require.Equal(env1.Rand("test").Uint64(), env2.Rand("test").Uint64())
+	require.Equal(env1.Rand("test").Uint64(), env2.Rand("test").Uint64(), "sequence should continue")
+
+	// Different domains and environments should produce different values.
+	require.NotEqual(env1.Rand("test").Uint64(), env1.Rand("other").Uint64())
+	require.NotEqual(newChild("scenario").Rand("test").Uint64(), newChild("other").Rand("test").Uint64())
+
+	viper.Set(cfgSeed, "not hex")
+	_, err = InitSeed()
+	require.Error(err, "malformed seeds should be rejected")
+}
@@ -2,11 +2,16 @@
 package log
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"sync/atomic"
 	"time"
 
 	"github.com/hpcloud/tail"
-	"github.com/hpcloud/tail/watch"
 )
 
 // WatcherHandlerFactory is a factory interface for log file watcher handlers.
@@ -24,12 +29,27 @@ type WatcherHandler interface {
 	Finish() error
 }
 
+const (
+	// drainPollInterval is the interval at which Drain checks the progress of the watcher.
+	drainPollInterval = 10 * time.Millisecond
+
+	// cleanupDrainTimeout is the maximum amount of time Cleanup waits for the remaining log
+	// lines to be processed before stopping the watcher.
+	cleanupDrainTimeout = 5 * time.Second
+)
+
 // Watcher is a log file watcher.
 type Watcher struct {
 	name string
+	file string
 
-	tail  *tail.Tail
-	errCh chan error
+	tail   *tail.Tail
+	errCh  chan error
+	doneCh chan struct{}
+
+	// processed is the file offset up to which lines have been processed, as reported
+	// by the tailer.
+	processed atomic.Int64
 }
 
 // WatcherConfig is a log file watcher configuration.
@@ -45,15 +65,66 @@ func (l *Watcher) Name() string {
 	return l.name
 }
 
+// Drain blocks until all complete log lines written to the log file before
+// the call have been processed by the handlers, the watcher has been stopped
+// or the context is canceled.
+func (l *Watcher) Drain(ctx context.Context) error {
+	target, err := l.completeOffset()
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(drainPollInterval)
+	defer ticker.Stop()
+
+	for l.processed.Load() < target {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-l.doneCh:
+			return nil
+		case <-ticker.C:
+		}
+	}
+	return nil
+}
+
+// completeOffset returns the offset in the log file just past the last
+// complete line. A trailing partial line is not processed until it is
+// terminated, so it must not be waited for.
+func (l *Watcher) completeOffset() (int64, error) {
+	f, err := os.Open(l.file)
+	switch {
+	case err == nil:
+	case errors.Is(err, os.ErrNotExist):
+		// Nothing has been logged yet.
+		return 0, nil
+	default:
+		return 0, fmt.Errorf("log: failed to open file: %w", err)
+	}
+	defer f.Close()
+
+	processed := l.processed.Load()
+	if _, err = f.Seek(processed, io.SeekStart); err != nil {
+		return 0, fmt.Errorf("log: failed to seek file: %w", err)
+	}
+	pending, err := io.ReadAll(f)
+	if err != nil {
+		return 0, fmt.Errorf("log: failed to read file: %w", err)
+	}
+	return processed + int64(bytes.LastIndexByte(pending, '\n')+1), nil
+}
+
 // Cleanup stops watching the log.
 func (l *Watcher) Cleanup() {
 	if l.tail == nil {
 		return
 	}
 
-	// Wait for two polling rounds to complete before stopping so that the watcher had the time to
-	// process any remaining bits.
-	time.Sleep(2 * watch.POLL_DURATION)
+	// Wait for the remaining complete lines to be processed before stopping.
+	ctx, cancel := context.WithTimeout(context.Background(), cleanupDrainTimeout)
+	defer cancel()
+	_ = l.Drain(ctx)
 
 	_ = l.tail.Stop()
 	l.tail = nil
@@ -77,20 +148,46 @@ func NewWatcher(cfg *WatcherConfig) (*Watcher, error) {
 		return nil, fmt.Errorf("log: failed to tail file: %w", err)
 	}
 
-	errCh := make(chan error)
+	w := &Watcher{
+		name:   cfg.Name,
+		file:   cfg.File,
+		tail:   tail,
+		errCh:  make(chan error),
+		doneCh: make(chan struct{}),
+	}
 	go func() {
-		defer close(errCh)
+		defer close(w.errCh)
 
 		var err error
-		for line := range tail.Lines {
-			if l := line.Text; l != "" && err == nil {
-				for _, h := range cfg.Handlers {
-					if err = h.Line(l); err != nil {
-						break
+	Loop:
+		for {
+			// The tailer blocks until the line it has read is received, so its offset before
+			// receiving a line never extends past the end of that line. Once the line has
+			// been processed (or no line arrived in time), everything before the offset has
+			// been processed.
+			offset, tellErr := tail.Tell()
+
+			select {
+			case line, ok := <-tail.Lines:
+				if !ok {
+					break Loop
+				}
+				if l := line.Text; l != "" && err == nil {
+					for _, h := range cfg.Handlers {
+						if err = h.Line(l); err != nil {
+							break
+						}
 					}
 				}
+			case <-time.After(drainPollInterval):
+			}
+
+			if tellErr == nil {
+				w.processed.Store(offset)
 			}
 		}
+		close(w.doneCh)
+
 		if err == nil {
 			for _, h := range cfg.Handlers {
 				if err = h.Finish(); err != nil {
@@ -99,12 +196,8 @@ func NewWatcher(cfg *WatcherConfig) (*Watcher, error) {
 			}
 		}
 
-		errCh <- err
+		w.errCh <- err
 	}()
 
-	return &Watcher{
-		name:  cfg.Name,
-		tail:  tail,
-		errCh: errCh,
-	}, nil
+	return w, nil
 }
@@ -0,0 +1,88 @@
+package log
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type countingHandler struct {
+	lines atomic.Int64
+}
+
+func (h *countingHandler) Line(string) error {
+	h.lines.Add(1)
+	return nil
+}
+
+func (h *countingHandler) Finish() error {
+	return nil
+}
+
+func TestWatcherDrain(t *testing.T) {
+	require := require.New(t)
+
+	fn := filepath.Join(t.TempDir(), "node.log")
+	f, err := os.Create(fn)
+	require.NoError(err, "Create")
+	defer f.Close()
+
+	var h countingHandler
+	w, err := NewWatcher(&WatcherConfig{
+		Name:     "test",
+		File:     fn,
+		Handlers: []WatcherHandler{&h},
+	})
+	require.NoError(err, "NewWatcher")
+	defer w.Cleanup()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err = f.WriteString("first\n\nsecond\n")
+	require.NoError(err, "WriteString")
+	require.NoError(w.Drain(ctx), "Drain")
+	require.EqualValues(2, h.lines.Load(), "all complete non-empty lines should be processed")
+
+	// Partial lines should not be waited for.
+	_, err = f.WriteString("third\nincomplete")
+	require.NoError(err, "WriteString")
+	require.NoError(w.Drain(ctx), "Drain")
+	require.EqualValues(3, h.lines.Load())
+
+	_, err = f.WriteString(" line\n")
+	require.NoError(err, "WriteString")
+	require.NoError(w.Drain(ctx), "Drain")
+	require.EqualValues(4, h.lines.Load())
+}
+
+func TestWatcherCleanup(t *testing.T) {
+	require := require.New(t)
+
+	fn := filepath.Join(t.TempDir(), "node.log")
+	f, err := os.Create(fn)
+	require.NoError(err, "Create")
+	defer f.Close()
+
+	var h countingHandler
+	w, err := NewWatcher(&WatcherConfig{
+		Name:     "test",
+		File:     fn,
+		Handlers: []WatcherHandler{&h},
+	})
+	require.NoError(err, "NewWatcher")
+
+	// Lines written right before the watcher is stopped should still be processed.
+	for i := 0; i < 100; i++ {
+		_, err = f.WriteString("line\n")
+		require.NoError(err, "WriteString")
+	}
+	w.Cleanup()
+	require.NoError(<-w.Errors(), "watcher should not report errors")
+	require.EqualValues(100, h.lines.Load(), "all lines should be processed")
+}
@@ -0,0 +1,259 @@
+package log
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+var (
+	_ WatcherHandler        = (*assertMetricHandler)(nil)
+	_ WatcherHandlerFactory = (*assertMetricFactory)(nil)
+)
+
+// ValueExtractor extracts a numeric value from a log line. It returns false
+// if the line does not contain the value.
+type ValueExtractor func(line string) (float64, bool)
+
+// RegexExtractor returns a value extractor which parses the first capture
+// group of the given regular expression as a number. If the expression has
+// no capture groups, each matching line yields the value one.
+func RegexExtractor(pattern string) ValueExtractor {
+	re := regexp.MustCompile(pattern)
+	return func(line string) (float64, bool) {
+		m := re.FindStringSubmatch(line)
+		switch {
+		case m == nil:
+			return 0, false
+		case len(m) < 2:
+			return 1, true
+		}
+		v, err := strconv.ParseFloat(m[1], 64)
+		if err != nil {
+			return 0, false
+		}
+		return v, true
+	}
+}
+
+// JSONExtractor returns a value extractor which extracts the numeric value
+// under the given key from JSON log output. If event is non-empty, only lines
+// with the given value of the event key are considered.
+func JSONExtractor(eventKey, event, key string) ValueExtractor {
+	return func(line string) (float64, bool) {
+		var kvs map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &kvs); err != nil {
+			return 0, false
+		}
+		if event != "" && kvs[eventKey] != event {
+			return 0, false
+		}
+
+		switch v := kvs[key].(type) {
+		case float64:
+			return v, true
+		case string:
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return 0, false
+			}
+			return f, true
+		default:
+			return 0, false
+		}
+	}
+}
+
+// MetricAggregate aggregates extracted values into a single value.
+type MetricAggregate struct {
+	name string
+	fn   func(sorted []float64) float64
+}
+
+// String returns a string representation of the aggregate.
+func (a MetricAggregate) String() string {
+	return a.name
+}
+
+// AggregateCount returns an aggregate counting the extracted values.
+func AggregateCount() MetricAggregate {
+	return MetricAggregate{"count", func(sorted []float64) float64 {
+		return float64(len(sorted))
+	}}
+}
+
+// AggregateSum returns an aggregate summing the extracted values.
+func AggregateSum() MetricAggregate {
+	return MetricAggregate{"sum", func(sorted []float64) float64 {
+		var sum float64
+		for _, v := range sorted {
+			sum += v
+		}
+		return sum
+	}}
+}
+
+// AggregateMean returns an aggregate computing the mean of the extracted values.
+func AggregateMean() MetricAggregate {
+	return MetricAggregate{"mean", func(sorted []float64) float64 {
+		if len(sorted) == 0 {
+			return math.NaN()
+		}
+		return AggregateSum().fn(sorted) / float64(len(sorted))
+	}}
+}
+
+// AggregateMin returns an aggregate computing the minimum of the extracted values.
+func AggregateMin() MetricAggregate {
+	return MetricAggregate{"min", func(sorted []float64) float64 {
+		if len(sorted) == 0 {
+			return math.NaN()
+		}
+		return sorted[0]
+	}}
+}
+
+// AggregateMax returns an aggregate computing the maximum of the extracted values.
+func AggregateMax() MetricAggregate {
+	return AggregatePercentile(100)
+}
+
+// AggregatePercentile returns an aggregate computing the given percentile of the
+// extracted values using the nearest-rank method.
+func AggregatePercentile(p float64) MetricAggregate {
+	return MetricAggregate{fmt.Sprintf("p%g", p), func(sorted []float64) float64 {
+		if len(sorted) == 0 {
+			return math.NaN()
+		}
+		rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+		return sorted[max(min(rank, len(sorted)), 1)-1]
+	}}
+}
+
+// MetricCondition is a condition on an aggregated value.
+type MetricCondition struct {
+	op        string
+	threshold float64
+}
+
+// String returns a string representation of the condition.
+func (c MetricCondition) String() string {
+	return fmt.Sprintf("%s %g", c.op, c.threshold)
+}
+
+func (c MetricCondition) holds(v float64) bool {
+	switch c.op {
+	case "==":
+		return v == c.threshold
+	case "<":
+		return v < c.threshold
+	case "<=":
+		return v <= c.threshold
+	case ">":
+		return v > c.threshold
+	case ">=":
+		return v >= c.threshold
+	default:
+		return false
+	}
+}
+
+// Equal returns a condition which holds if the aggregated value equals the threshold.
+func Equal(threshold float64) MetricCondition {
+	return MetricCondition{"==", threshold}
+}
+
+// LessThan returns a condition which holds if the aggregated value is below the threshold.
+func LessThan(threshold float64) MetricCondition {
+	return MetricCondition{"<", threshold}
+}
+
+// AtMost returns a condition which holds if the aggregated value does not exceed the threshold.
+func AtMost(threshold float64) MetricCondition {
+	return MetricCondition{"<=", threshold}
+}
+
+// GreaterThan returns a condition which holds if the aggregated value is above the threshold.
+func GreaterThan(threshold float64) MetricCondition {
+	return MetricCondition{">", threshold}
+}
+
+// AtLeast returns a condition which holds if the aggregated value is not below the threshold.
+func AtLeast(threshold float64) MetricCondition {
+	return MetricCondition{">=", threshold}
+}
+
+type assertMetric struct {
+	assertBase
+
+	extract   ValueExtractor
+	aggregate MetricAggregate
+	condition MetricCondition
+}
+
+func (a *assertMetric) String() string {
+	return fmt.Sprintf("assertMetric{message: %s aggregate: %s condition: %s}", a.message, a.aggregate, a.condition)
+}
+
+type assertMetricHandler struct {
+	assertMetric
+
+	values []float64
+}
+
+func (h *assertMetricHandler) Line(line string) error {
+	if v, ok := h.extract(line); ok {
+		h.values = append(h.values, v)
+	}
+	return nil
+}
+
+func (h *assertMetricHandler) Finish() error {
+	sort.Float64s(h.values)
+	v := h.aggregate.fn(h.values)
+	if math.IsNaN(v) || !h.condition.holds(v) {
+		return fmt.Errorf("%w (%s of %d values is %g, expected %s)", h.fail(), h.aggregate, len(h.values), v, h.condition)
+	}
+	return nil
+}
+
+type assertMetricFactory struct {
+	assertMetric
+}
+
+func (fac *assertMetricFactory) New() (WatcherHandler, error) {
+	return &assertMetricHandler{
+		assertMetric: fac.assertMetric,
+	}, nil
+}
+
+// AssertMetric returns a factory of log handlers which extract numeric values
+// from the log output and check that their aggregate satisfies the given
+// condition. Aggregates which are undefined for an empty set of values (e.g.
+// percentiles) fail if no values were extracted.
+func AssertMetric(extract ValueExtractor, aggregate MetricAggregate, condition MetricCondition, message string) WatcherHandlerFactory {
+	return &assertMetricFactory{
+		assertMetric: assertMetric{
+			assertBase: assertBase{message},
+			extract:    extract,
+			aggregate:  aggregate,
+			condition:  condition,
+		},
+	}
+}
+
+// AssertCount returns a factory of log handlers which check that exactly n
+// values were extracted from the log output.
+func AssertCount(extract ValueExtractor, n int, message string) WatcherHandlerFactory {
+	return AssertMetric(extract, AggregateCount(), Equal(float64(n)), message)
+}
+
+// AssertPercentileBelow returns a factory of log handlers which check that the
+// given percentile of the values extracted from the log output is below the
+// threshold.
+func AssertPercentileBelow(extract ValueExtractor, p, threshold float64, message string) WatcherHandlerFactory {
+	return AssertMetric(extract, AggregatePercentile(p), LessThan(threshold), message)
+}
@@ -0,0 +1,50 @@
+package log
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func runHandler(t *testing.T, fac WatcherHandlerFactory, lines []string) error {
+	h, err := fac.New()
+	require.NoError(t, err, "New")
+	for _, line := range lines {
+		require.NoError(t, h.Line(line), "Line")
+	}
+	return h.Finish()
+}
+
+func TestAssertMetric(t *testing.T) {
+	require := require.New(t)
+
+	lines := []string{
+		`{"msg":"round finished","event":"round","duration":0.5,"batch_size":"10"}`,
+		`{"msg":"round finished","event":"round","duration":1.5,"batch_size":"20"}`,
+		`{"msg":"other","event":"other","duration":100}`,
+		`not json: round took 2.5s`,
+		`{"msg":"round finished","event":"round","duration":1}`,
+	}
+	durations := JSONExtractor("event", "round", "duration")
+
+	require.NoError(runHandler(t, AssertCount(durations, 3, "round count"), lines))
+	require.Error(runHandler(t, AssertCount(durations, 4, "round count"), lines))
+	require.NoError(runHandler(t, AssertPercentileBelow(durations, 95, 2, "slow rounds"), lines))
+	require.Error(runHandler(t, AssertPercentileBelow(durations, 95, 1.5, "slow rounds"), lines))
+	require.NoError(runHandler(t, AssertMetric(durations, AggregateMean(), Equal(1), "mean"), lines))
+	require.NoError(runHandler(t, AssertMetric(durations, AggregateMax(), AtMost(1.5), "max"), lines))
+
+	batchSizes := JSONExtractor("event", "", "batch_size")
+	require.NoError(runHandler(t, AssertMetric(batchSizes, AggregateSum(), Equal(30), "batch sizes"), lines))
+
+	regex := RegexExtractor(`round took ([0-9.]+)s`)
+	require.NoError(runHandler(t, AssertMetric(regex, AggregateMin(), GreaterThan(2), "regex"), lines))
+	require.NoError(runHandler(t, AssertCount(RegexExtractor(`round finished`), 3, "regex count"), lines))
+
+	// Aggregates other than count fail without any values.
+	none := RegexExtractor(`never ([0-9]+)`)
+	require.NoError(runHandler(t, AssertCount(none, 0, "none"), lines))
+	err := runHandler(t, AssertMetric(none, AggregateMax(), AtLeast(0), "none"), lines)
+	require.Error(err)
+	require.Contains(err.Error(), "log assertion failed: none")
+}
@@ -177,6 +177,14 @@ func (args *argBuilder) byzantineRuntimeID(runtimeID common.Namespace) *argBuild
 	return args
 }
 
+func (args *argBuilder) byzantineExecutorScript(path string) *argBuilder {
+	args.vec = append(args.vec, Argument{
+		Name:   byzantine.CfgExecutorScript,
+		Values: []string{path},
+	})
+	return args
+}
+
 func (args *argBuilder) merge(string) []string {
 	output := []string{}
 	shipped := map[string][]string{}
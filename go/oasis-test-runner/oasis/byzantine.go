@@ -1,23 +1,28 @@
 package oasis
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strconv"
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
-	"github.com/oasisprotocol/oasis-core/go/common"
-	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/byzantine"
 	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
 	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
 )
 
+const byzantineExecutorScriptFile = "executor-script.json"
+
 // Byzantine is an Oasis byzantine node.
 type Byzantine struct {
 	*Node
 
-	script    string
-	extraArgs []Argument
+	script         string
+	executorScript string
+	extraArgs      []Argument
 
 	runtime         int
 	consensusPort   uint16
@@ -32,6 +37,9 @@ type ByzantineCfg struct {
 	Script    string
 	ExtraArgs []Argument
 
+	// ExecutorScript is the optional per-round misbehavior script of the byzantine executor.
+	ExecutorScript *byzantine.Script
+
 	ForceElectParams *scheduler.ForceElectCommitteeRole
 
 	IdentitySeed string
@@ -50,6 +58,9 @@ func (worker *Byzantine) AddArgs(args *argBuilder) error {
 	if worker.runtime > 0 {
 		args.byzantineRuntimeID(worker.net.runtimes[worker.runtime].ID())
 	}
+	if worker.executorScript != "" {
+		args.byzantineExecutorScript(worker.executorScript)
+	}
 	for _, v := range worker.net.Runtimes() {
 		if v.kind == registry.KindCompute && v.teeHardware == node.TEEHardwareIntelSGX {
 			args.byzantineFakeSGX()
@@ -63,7 +74,7 @@ func (worker *Byzantine) AddArgs(args *argBuilder) error {
 
 func (worker *Byzantine) ModifyConfig() error {
 	worker.Config.Consensus.ListenAddress = allInterfacesAddr + ":" + strconv.Itoa(int(worker.consensusPort))
-	worker.Config.Consensus.ExternalAddress = localhostAddr + ":" + strconv.Itoa(int(worker.consensusPort))
+	worker.Config.Consensus.ExternalAddress = "tcp://" + worker.hostIP() + ":" + strconv.Itoa(int(worker.consensusPort))
 
 	worker.Config.Consensus.Debug.P2PAllowDuplicateIP = true
 	worker.Config.Consensus.Debug.P2PAddrBookLenient = true
@@ -115,34 +126,44 @@ func (net *Network) NewByzantine(cfg *ByzantineCfg) (*Byzantine, error) {
 		return nil, err
 	}
 
+	consensusPort, err := host.getProvisionedPort(nodePortConsensus)
+	if err != nil {
+		return nil, fmt.Errorf("oasis/byzantine: %w", err)
+	}
+	p2pPort, err := host.getProvisionedPort(nodePortP2P)
+	if err != nil {
+		return nil, fmt.Errorf("oasis/byzantine: %w", err)
+	}
+
 	worker := &Byzantine{
 		Node:            host,
 		script:          cfg.Script,
 		extraArgs:       cfg.ExtraArgs,
-		consensusPort:   host.getProvisionedPort(nodePortConsensus),
-		p2pPort:         host.getProvisionedPort(nodePortP2P),
+		consensusPort:   consensusPort,
+		p2pPort:         p2pPort,
 		activationEpoch: cfg.ActivationEpoch,
 		runtime:         cfg.Runtime,
 	}
+	if cfg.ExecutorScript != nil {
+		if err = cfg.ExecutorScript.Validate(); err != nil {
+			return nil, fmt.Errorf("oasis/byzantine: invalid executor script: %w", err)
+		}
+		var raw []byte
+		if raw, err = json.Marshal(cfg.ExecutorScript); err != nil {
+			return nil, fmt.Errorf("oasis/byzantine: failed to marshal executor script: %w", err)
+		}
+		worker.executorScript = filepath.Join(host.dir.String(), byzantineExecutorScriptFile)
+		if err = os.WriteFile(worker.executorScript, raw, 0o600); err != nil {
+			return nil, fmt.Errorf("oasis/byzantine: failed to write executor script: %w", err)
+		}
+	}
 	copy(worker.NodeID[:], host.nodeSigner[:])
 
 	net.byzantine = append(net.byzantine, worker)
 	host.features = append(host.features, worker)
 
 	if cfg.Runtime >= 0 {
-		rt := net.runtimes[cfg.Runtime].ID()
-		pk := host.nodeSigner
-
-		if net.cfg.SchedulerForceElect == nil {
-			net.cfg.SchedulerForceElect = make(map[common.Namespace]map[signature.PublicKey]*scheduler.ForceElectCommitteeRole)
-		}
-		if net.cfg.SchedulerForceElect[rt] == nil {
-			net.cfg.SchedulerForceElect[rt] = make(map[signature.PublicKey]*scheduler.ForceElectCommitteeRole)
-		}
-		if params := cfg.ForceElectParams; params != nil {
-			tmpParams := *params
-			net.cfg.SchedulerForceElect[rt][pk] = &tmpParams
-		}
+		net.addForceElectParams(net.runtimes[cfg.Runtime].ID(), host.nodeSigner, cfg.ForceElectParams)
 	}
 
 	return worker, nil
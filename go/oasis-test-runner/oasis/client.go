@@ -49,7 +49,7 @@ func (client *Client) AddArgs(args *argBuilder) error {
 
 func (client *Client) ModifyConfig() error {
 	client.Config.Consensus.ListenAddress = allInterfacesAddr + ":" + strconv.Itoa(int(client.consensusPort))
-	client.Config.Consensus.ExternalAddress = localhostAddr + ":" + strconv.Itoa(int(client.consensusPort))
+	client.Config.Consensus.ExternalAddress = "tcp://" + client.hostIP() + ":" + strconv.Itoa(int(client.consensusPort))
 
 	if client.supplementarySanityInterval > 0 {
 		client.Config.Consensus.SupplementarySanity.Enabled = true
@@ -86,13 +86,22 @@ func (net *Network) NewClient(cfg *ClientCfg) (*Client, error) {
 		return nil, fmt.Errorf("oasis/client: failed to provision node identity: %w", err)
 	}
 
+	consensusPort, err := host.getProvisionedPort(nodePortConsensus)
+	if err != nil {
+		return nil, fmt.Errorf("oasis/client: %w", err)
+	}
+	p2pPort, err := host.getProvisionedPort(nodePortP2P)
+	if err != nil {
+		return nil, fmt.Errorf("oasis/client: %w", err)
+	}
+
 	client := &Client{
 		Node:               host,
 		runtimes:           cfg.Runtimes,
 		runtimeProvisioner: cfg.RuntimeProvisioner,
 		runtimeConfig:      cfg.RuntimeConfig,
-		consensusPort:      host.getProvisionedPort(nodePortConsensus),
-		p2pPort:            host.getProvisionedPort(nodePortP2P),
+		consensusPort:      consensusPort,
+		p2pPort:            p2pPort,
 	}
 
 	// Remove any exploded bundles on cleanup.
@@ -16,6 +16,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/runtime/bundle"
 	runtimeConfig "github.com/oasisprotocol/oasis-core/go/runtime/config"
 	"github.com/oasisprotocol/oasis-core/go/runtime/registry"
+	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
 	"github.com/oasisprotocol/oasis-core/go/storage/database"
 	workerStorage "github.com/oasisprotocol/oasis-core/go/worker/storage/api"
 )
@@ -61,6 +62,8 @@ type ComputeCfg struct {
 	RuntimeConfig     map[int]map[string]interface{}
 	RuntimeStatePaths map[int]string
 
+	ForceElectParams map[int]*scheduler.ForceElectCommitteeRole
+
 	SentryIndices []int
 
 	StorageBackend          string
@@ -149,7 +152,7 @@ func (worker *Compute) ModifyConfig() error {
 	defer worker.RUnlock()
 
 	worker.Config.Consensus.ListenAddress = allInterfacesAddr + ":" + strconv.Itoa(int(worker.consensusPort))
-	worker.Config.Consensus.ExternalAddress = localhostAddr + ":" + strconv.Itoa(int(worker.consensusPort))
+	worker.Config.Consensus.ExternalAddress = "tcp://" + worker.hostIP() + ":" + strconv.Itoa(int(worker.consensusPort))
 
 	if worker.supplementarySanityInterval > 0 {
 		worker.Config.Consensus.SupplementarySanity.Enabled = true
@@ -230,6 +233,15 @@ func (net *Network) NewCompute(cfg *ComputeCfg) (*Compute, error) {
 		net.logger.Info("state copied", "from", path, "to", stateDir)
 	}
 
+	consensusPort, err := host.getProvisionedPort(nodePortConsensus)
+	if err != nil {
+		return nil, fmt.Errorf("oasis/compute: %w", err)
+	}
+	p2pPort, err := host.getProvisionedPort(nodePortP2P)
+	if err != nil {
+		return nil, fmt.Errorf("oasis/compute: %w", err)
+	}
+
 	worker := &Compute{
 		Node:                    host,
 		storageBackend:          cfg.StorageBackend,
@@ -239,12 +251,20 @@ func (net *Network) NewCompute(cfg *ComputeCfg) (*Compute, error) {
 		checkpointCheckInterval: cfg.CheckpointCheckInterval,
 		sentryPubKey:            sentryPubKey,
 		runtimeProvisioner:      cfg.RuntimeProvisioner,
-		consensusPort:           host.getProvisionedPort(nodePortConsensus),
-		p2pPort:                 host.getProvisionedPort(nodePortP2P),
+		consensusPort:           consensusPort,
+		p2pPort:                 p2pPort,
 		runtimes:                cfg.Runtimes,
 		runtimeConfig:           cfg.RuntimeConfig,
 	}
 
+	// Rig committee elections if configured.
+	for i, params := range cfg.ForceElectParams {
+		if i < 0 || i >= len(net.runtimes) {
+			return nil, fmt.Errorf("oasis/compute: invalid force elect runtime index: %d", i)
+		}
+		net.addForceElectParams(net.runtimes[i].ID(), host.nodeSigner, params)
+	}
+
 	// Remove any exploded bundles on cleanup.
 	net.env.AddOnCleanup(func() {
 		_ = os.RemoveAll(bundle.ExplodedPath(worker.dir.String()))
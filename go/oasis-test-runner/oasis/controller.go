@@ -13,6 +13,7 @@ import (
 	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
 	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
 	runtimeClient "github.com/oasisprotocol/oasis-core/go/runtime/client/api"
+	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
 	storage "github.com/oasisprotocol/oasis-core/go/storage/api"
 	vault "github.com/oasisprotocol/oasis-core/go/vault/api"
@@ -32,6 +33,7 @@ type Controller struct {
 	Registry      registry.Backend
 	Roothash      roothash.Backend
 	RuntimeClient runtimeClient.RuntimeClient
+	Scheduler     scheduler.Backend
 	Storage       storage.Backend
 	Keymanager    *keymanager.KeymanagerClient
 	Vault         vault.Backend
@@ -68,6 +70,7 @@ func NewController(socketPath string) (*Controller, error) {
 		Registry:        registry.NewRegistryClient(conn),
 		Roothash:        roothash.NewRootHashClient(conn),
 		RuntimeClient:   runtimeClient.NewRuntimeClient(conn),
+		Scheduler:       scheduler.NewSchedulerClient(conn),
 		Storage:         storage.NewStorageClient(conn),
 		Keymanager:      keymanager.NewKeymanagerClient(conn),
 		Vault:           vault.NewVaultClient(conn),
@@ -0,0 +1,102 @@
+package oasis
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// coverageCLIDir is the name of the coverage subdirectory used by node CLI invocations.
+	coverageCLIDir = "cli"
+	// coverageMergedDir is the name of the coverage subdirectory holding merged coverage data.
+	coverageMergedDir = "merged"
+	// CoverageProfileFile is the name of the merged coverage profile in text format.
+	CoverageProfileFile = "coverage.txt"
+)
+
+// PrepareCoverage prepares the given directory for collecting coverage data of a scenario run.
+//
+// Node binaries invoked outside of a network node (e.g., CLI helpers) inherit the process
+// environment, so it is updated to make them write their coverage data into a shared
+// subdirectory.
+func PrepareCoverage(dir string) error {
+	cliDir := filepath.Join(dir, coverageCLIDir)
+	if err := os.MkdirAll(cliDir, 0o700); err != nil {
+		return fmt.Errorf("oasis: failed to create coverage directory '%s': %w", cliDir, err)
+	}
+	return os.Setenv("GOCOVERDIR", cliDir)
+}
+
+// coverageEnv returns the environment for a coverage-instrumented node binary that writes its
+// coverage data into the given subdirectory of the network's coverage directory.
+//
+// If coverage collection is not enabled, nil is returned so the parent's environment is used.
+func (net *Network) coverageEnv(name string) ([]string, error) {
+	if net.cfg.CoverageDir == "" {
+		return nil, nil
+	}
+
+	dir := filepath.Join(net.cfg.CoverageDir, name)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("oasis: failed to create coverage directory '%s': %w", dir, err)
+	}
+	return append(os.Environ(), "GOCOVERDIR="+dir), nil
+}
+
+// MergeCoverage merges the coverage data written by coverage-instrumented node binaries into
+// the given coverage directory and converts it into a text profile, stored as
+// CoverageProfileFile in the same directory.
+//
+// Coverage data is only written when the nodes exit, so this should be called after the
+// network has been stopped. Directories without coverage data (e.g., because the node binary
+// was not built with coverage instrumentation) are ignored.
+func MergeCoverage(dir string) error {
+	entries, err := os.ReadDir(dir)
+	switch {
+	case err == nil:
+	case os.IsNotExist(err):
+		return nil
+	default:
+		return fmt.Errorf("oasis: failed to read coverage directory '%s': %w", dir, err)
+	}
+
+	var inputs []string
+	for _, entry := range entries {
+		if !entry.IsDir() || entry.Name() == coverageMergedDir {
+			continue
+		}
+
+		nodeDir := filepath.Join(dir, entry.Name())
+		files, rerr := os.ReadDir(nodeDir)
+		if rerr != nil {
+			return fmt.Errorf("oasis: failed to read coverage directory '%s': %w", nodeDir, rerr)
+		}
+		if len(files) == 0 {
+			continue
+		}
+		inputs = append(inputs, nodeDir)
+	}
+	if len(inputs) == 0 {
+		return nil
+	}
+
+	mergedDir := filepath.Join(dir, coverageMergedDir)
+	if err = os.MkdirAll(mergedDir, 0o700); err != nil {
+		return fmt.Errorf("oasis: failed to create coverage directory '%s': %w", mergedDir, err)
+	}
+	if err = runCovdata("merge", "-i", strings.Join(inputs, ","), "-o", mergedDir); err != nil {
+		return err
+	}
+	return runCovdata("textfmt", "-i", mergedDir, "-o", filepath.Join(dir, CoverageProfileFile))
+}
+
+func runCovdata(args ...string) error {
+	cmd := exec.Command("go", append([]string{"tool", "covdata"}, args...)...)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("oasis: failed to run covdata %s: %w (output: %s)", args[0], err, out)
+	}
+	return nil
+}
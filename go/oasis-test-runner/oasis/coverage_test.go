@@ -0,0 +1,53 @@
+package oasis
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeCoverage(t *testing.T) {
+	require := require.New(t)
+
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go toolchain not available")
+	}
+
+	// Build a small coverage-instrumented binary.
+	srcDir := t.TempDir()
+	err := os.WriteFile(filepath.Join(srcDir, "go.mod"), []byte("module covtest\n\ngo 1.21\n"), 0o600)
+	require.NoError(err, "WriteFile")
+	err = os.WriteFile(filepath.Join(srcDir, "main.go"), []byte("package main\n\nfunc main() {\n\tprintln(\"ok\")\n}\n"), 0o600)
+	require.NoError(err, "WriteFile")
+	binary := filepath.Join(srcDir, "covtest")
+	build := exec.Command("go", "build", "-cover", "-o", binary, ".")
+	build.Dir = srcDir
+	build.Env = append(os.Environ(), "GOFLAGS=", "GOWORK=off")
+	out, err := build.CombinedOutput()
+	require.NoError(err, "go build: %s", out)
+
+	// Missing and empty directories should be ignored.
+	dir := filepath.Join(t.TempDir(), "scenario")
+	require.NoError(MergeCoverage(dir), "MergeCoverage should ignore missing directories")
+	require.NoError(os.MkdirAll(filepath.Join(dir, coverageCLIDir), 0o700))
+	require.NoError(MergeCoverage(dir), "MergeCoverage should ignore empty directories")
+	require.NoFileExists(filepath.Join(dir, CoverageProfileFile))
+
+	// Run the binary as two different nodes.
+	net := &Network{cfg: &NetworkCfg{CoverageDir: dir}}
+	for _, name := range []string{"validator-0", "compute-0"} {
+		cmd := exec.Command(binary)
+		cmd.Env, err = net.coverageEnv(name)
+		require.NoError(err, "coverageEnv")
+		out, err = cmd.CombinedOutput()
+		require.NoError(err, "running instrumented binary: %s", out)
+	}
+
+	err = MergeCoverage(dir)
+	require.NoError(err, "MergeCoverage")
+	require.FileExists(filepath.Join(dir, CoverageProfileFile))
+	require.DirExists(filepath.Join(dir, coverageMergedDir))
+}
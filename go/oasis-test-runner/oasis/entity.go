@@ -8,6 +8,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	fileSigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/file"
 	"github.com/oasisprotocol/oasis-core/go/common/entity"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
 	cmdSigner "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/signer"
@@ -32,6 +33,7 @@ type Entity struct {
 	entitySigner signature.Signer
 
 	isDebugTestEntity bool
+	stake             *quantity.Quantity
 
 	nodes []signature.PublicKey
 }
@@ -40,6 +42,10 @@ type Entity struct {
 type EntityCfg struct {
 	IsDebugTestEntity bool
 	Restore           bool
+
+	// Stake is the amount of self-delegated escrow the entity is funded with in addition to the
+	// general balance in case entities are funded. It is ignored for debug test entities.
+	Stake *quantity.Quantity `json:",omitempty"`
 }
 
 // Inner returns the actual Oasis entity and it's signer.
@@ -198,8 +204,9 @@ func (net *Network) NewEntity(cfg *EntityCfg) (*Entity, error) {
 		}
 
 		ent = &Entity{
-			net: net,
-			dir: entityDir,
+			net:   net,
+			dir:   entityDir,
+			stake: cfg.Stake,
 		}
 		signerFactory, err := fileSigner.NewFactory(entityDir.String(), signature.SignerEntity)
 		if err != nil {
@@ -9,6 +9,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
 	"github.com/oasisprotocol/oasis-core/go/common/sgx"
+	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/byzantine"
 	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/env"
 	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/log"
 	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
@@ -404,6 +405,9 @@ type ComputeWorkerFixture struct {
 
 	// RuntimeConfig contains the per-runtime node-local configuration.
 	RuntimeConfig map[int]map[string]interface{} `json:"runtime_config,omitempty"`
+
+	// ForceElectParams contains the per-runtime rigged committee elections for the node.
+	ForceElectParams map[int]*scheduler.ForceElectCommitteeRole `json:"scheduler_force_params,omitempty"`
 }
 
 // Create instantiates the compute worker described by the fixture.
@@ -438,6 +442,7 @@ func (f *ComputeWorkerFixture) Create(net *Network) (*Compute, error) {
 		Runtimes:               f.Runtimes,
 		RuntimeConfig:          f.RuntimeConfig,
 		RuntimeStatePaths:      f.RuntimeStatePaths,
+		ForceElectParams:       f.ForceElectParams,
 	})
 }
 
@@ -539,6 +544,9 @@ type ByzantineFixture struct { // nolint: maligned
 	Script    string     `json:"script"`
 	ExtraArgs []Argument `json:"extra_args"`
 
+	// ExecutorScript is the optional per-round misbehavior script of the byzantine executor.
+	ExecutorScript *byzantine.Script `json:"executor_script,omitempty"`
+
 	IdentitySeed string `json:"identity_seed"`
 	Entity       int    `json:"entity"`
 
@@ -575,6 +583,7 @@ func (f *ByzantineFixture) Create(net *Network) (*Byzantine, error) {
 		},
 		Script:           f.Script,
 		ExtraArgs:        f.ExtraArgs,
+		ExecutorScript:   f.ExecutorScript,
 		IdentitySeed:     f.IdentitySeed,
 		ActivationEpoch:  f.ActivationEpoch,
 		Runtime:          f.Runtime,
@@ -75,10 +75,15 @@ func (net *Network) newIASProxy() (*iasProxy, error) {
 	}
 	tlsPublicKey := iasCert.PrivateKey.(ed25519.PrivateKey).Public().(ed25519.PublicKey)
 
+	grpcPort, err := host.getProvisionedPort("iasgrpc")
+	if err != nil {
+		return nil, fmt.Errorf("oasis/ias: %w", err)
+	}
+
 	net.iasProxy = &iasProxy{
 		Node:     host,
 		mock:     net.cfg.IAS.Mock,
-		grpcPort: host.getProvisionedPort("iasgrpc"),
+		grpcPort: grpcPort,
 	}
 
 	// Store TLS public key so other nodes can configure authentication.
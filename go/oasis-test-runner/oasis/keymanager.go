@@ -279,7 +279,7 @@ func (km *Keymanager) AddArgs(args *argBuilder) error {
 
 func (km *Keymanager) ModifyConfig() error {
 	km.Config.Consensus.ListenAddress = allInterfacesAddr + ":" + strconv.Itoa(int(km.consensusPort))
-	km.Config.Consensus.ExternalAddress = localhostAddr + ":" + strconv.Itoa(int(km.consensusPort))
+	km.Config.Consensus.ExternalAddress = "tcp://" + km.hostIP() + ":" + strconv.Itoa(int(km.consensusPort))
 
 	if km.supplementarySanityInterval > 0 {
 		km.Config.Consensus.SupplementarySanity.Enabled = true
@@ -304,7 +304,11 @@ func (km *Keymanager) ModifyConfig() error {
 
 	km.Config.Runtime.Runtimes = append(km.Config.Runtime.Runtimes, rtCfg)
 	km.Config.Runtime.Paths = append(km.Config.Runtime.Paths, km.runtime.BundlePaths()...)
-	km.Config.Runtime.Repositories = []string{fmt.Sprintf("http://127.0.0.1:%d", km.net.getProvisionedPort(netPortRepository))}
+	repositoryPort, err := km.net.getProvisionedPort(netPortRepository)
+	if err != nil {
+		return err
+	}
+	km.Config.Runtime.Repositories = []string{fmt.Sprintf("http://%s:%d", km.runnerIP(), repositoryPort)}
 
 	km.Config.Keymanager.RuntimeID = km.runtime.ID().String()
 	km.Config.Keymanager.PrivatePeerPubKeys = km.privatePeerPubKeys
@@ -361,6 +365,15 @@ func (net *Network) NewKeymanager(cfg *KeymanagerCfg) (*Keymanager, error) {
 		cfg.RuntimeProvisioner = runtimeConfig.RuntimeProvisionerSandboxed
 	}
 
+	consensusPort, err := host.getProvisionedPort(nodePortConsensus)
+	if err != nil {
+		return nil, fmt.Errorf("oasis/keymanager: %w", err)
+	}
+	p2pPort, err := host.getProvisionedPort(nodePortP2P)
+	if err != nil {
+		return nil, fmt.Errorf("oasis/keymanager: %w", err)
+	}
+
 	km := &Keymanager{
 		Node:               host,
 		runtime:            cfg.Runtime,
@@ -368,8 +381,8 @@ func (net *Network) NewKeymanager(cfg *KeymanagerCfg) (*Keymanager, error) {
 		runtimeProvisioner: cfg.RuntimeProvisioner,
 		sentryIndices:      cfg.SentryIndices,
 		sentryPubKey:       sentryPubKey,
-		consensusPort:      host.getProvisionedPort(nodePortConsensus),
-		p2pPort:            host.getProvisionedPort(nodePortP2P),
+		consensusPort:      consensusPort,
+		p2pPort:            p2pPort,
 		privatePeerPubKeys: cfg.PrivatePeerPubKeys,
 		churpIDs:           cfg.ChurpIDs,
 	}
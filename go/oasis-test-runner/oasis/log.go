@@ -83,6 +83,12 @@ func LogAssertUpgradeConsensus() log.WatcherHandlerFactory {
 	return LogAssertEvent(upgrade.LogEventConsensusUpgrade, "expected consensus upgrade did not run")
 }
 
+// LogAssertUpgradeCancelled returns a handler which checks whether a pending upgrade was
+// cancelled based on JSON log output.
+func LogAssertUpgradeCancelled() log.WatcherHandlerFactory {
+	return LogAssertEvent(upgrade.LogEventUpgradeCancelled, "expected pending upgrade was not cancelled")
+}
+
 // LogAssertNoUpgradeStartup returns a handler which checks that no startup migration
 // handler was run based on JSON log output.
 func LogAssertNoUpgradeStartup() log.WatcherHandlerFactory {
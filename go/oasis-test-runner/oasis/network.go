@@ -1,6 +1,7 @@
 package oasis
 
 import (
+	"context"
 	"crypto"
 	"crypto/x509"
 	"encoding/json"
@@ -69,15 +70,18 @@ type Network struct { // nolint: maligned
 
 	iasProxy *iasProxy
 
-	cfg      *NetworkCfg
-	nextPort uint16
-	ports    map[string]uint16
+	cfg              *NetworkCfg
+	ports            map[string]uint16
+	portReservations *portReservations
 
-	logWatchers []*log.Watcher
+	logWatchers      []*log.Watcher
+	logWatcherErrors []error
 
 	controller       *Controller
 	clientController *Controller
 
+	p2pIsolated []*Node
+
 	errCh chan error
 }
 
@@ -140,6 +144,10 @@ type NetworkCfg struct { // nolint: maligned
 	// Metrics is the network metrics configuration.
 	Metrics MetricsCfg `json:"metrics,omitempty"`
 
+	// CoverageDir is an optional directory where coverage-instrumented node binaries write
+	// their coverage data. Each node uses its own subdirectory.
+	CoverageDir string `json:"coverage_dir,omitempty"`
+
 	// StakingGenesis is the staking genesis data to be included if
 	// GenesisFile is not set.
 	StakingGenesis *staking.Genesis `json:"staking_genesis,omitempty"`
@@ -170,6 +178,10 @@ type NetworkCfg struct { // nolint: maligned
 	// NodeLogFormat is the log format to use for created nodes.
 	NodeLogFormat string `json:"node_log_format,omitempty"`
 
+	// Topology is an optional placement of nodes on remote hosts. If not specified, all nodes are
+	// spawned locally.
+	Topology *Topology `json:"topology,omitempty"`
+
 	// Nodes lists the names of nodes to be created, enabling an N:M mapping between physical node
 	// processes and the features they host. If a feature is specified as attached to a node that
 	// isn't listed here, a new node will be created automatically, so this list can normally be
@@ -199,6 +211,11 @@ func (net *Network) Config() *NetworkCfg {
 	return net.cfg
 }
 
+// Env returns the environment the network is running in.
+func (net *Network) Env() *env.Env {
+	return net.env
+}
+
 // Entities returns the entities associated with the network.
 func (net *Network) Entities() []*Entity {
 	return net.entities
@@ -305,7 +322,9 @@ func (net *Network) GetNamedNode(defaultName string, cfg *NodeCfg) (*Node, error
 	}
 
 	if cfg != nil {
-		cfg.Into(node)
+		if err := cfg.Into(node); err != nil {
+			return nil, fmt.Errorf("oasis/network: failed to configure node: %w", err)
+		}
 		if newNode {
 			if err := net.AddLogWatcher(node); err != nil {
 				net.logger.Error("failed to add log watcher",
@@ -394,11 +413,28 @@ func (net *Network) CheckLogWatchers() (err error) {
 				"err", logErr,
 			)
 			err = fmt.Errorf("log watcher %s: %w", w.Name(), logErr)
+			net.logWatcherErrors = append(net.logWatcherErrors, err)
 		}
 	}
 	return
 }
 
+// DrainLogWatchers blocks until all log watchers have processed all complete
+// log lines written before the call.
+func (net *Network) DrainLogWatchers(ctx context.Context) error {
+	for _, w := range net.logWatchers {
+		if err := w.Drain(ctx); err != nil {
+			return fmt.Errorf("log watcher %s: %w", w.Name(), err)
+		}
+	}
+	return nil
+}
+
+// LogWatcherErrors returns all errors reported by the log watchers so far.
+func (net *Network) LogWatcherErrors() []error {
+	return net.logWatcherErrors
+}
+
 // Start starts the network.
 func (net *Network) Start() error { // nolint: gocyclo
 	if net.running {
@@ -659,12 +695,18 @@ func (net *Network) startOasisNode(
 	cfg.Common.Log.File = nodeLogPath(node.dir)
 	cfg.Genesis.File = net.GenesisPath()
 
+	// Remote nodes log to standard output which is streamed to the local log file.
+	host := net.cfg.Topology.Host(node.Name)
+	if host != nil {
+		cfg.Common.Log.File = ""
+	}
+
 	baseArgs := []string{
 		"--" + cmdCommon.CfgConfigFile, cfgFile,
 	}
 	if len(subCmd) == 0 {
 		if net.iasProxy != nil {
-			cfg.IAS.ProxyAddresses = []string{fmt.Sprintf("%s@127.0.0.1:%d", net.iasProxy.tlsPublicKey, net.iasProxy.grpcPort)}
+			cfg.IAS.ProxyAddresses = []string{fmt.Sprintf("%s@%s:%d", net.iasProxy.tlsPublicKey, node.runnerIP(), net.iasProxy.grpcPort)}
 			if net.iasProxy.mock {
 				cfg.IAS.DebugSkipVerify = true
 			}
@@ -721,10 +763,9 @@ func (net *Network) startOasisNode(
 	})
 
 	oasisBinary := net.cfg.NodeBinary
-	cmd := exec.Command(oasisBinary, args...)
-	cmd.SysProcAttr = env.CmdAttrs
-	cmd.Stdout = w
-	cmd.Stderr = w
+	if node.binary != "" {
+		oasisBinary = node.binary
+	}
 
 	// Write config to file.
 	cfgString, err := yaml.Marshal(&cfg)
@@ -735,22 +776,60 @@ func (net *Network) startOasisNode(
 		return fmt.Errorf("oasis: failed to write config file '%s': %w", cfgFile, err)
 	}
 
+	var cmd *exec.Cmd
+	switch host {
+	case nil:
+		cmd = exec.Command(oasisBinary, args...)
+		cmd.Stdout = w
+		if cmd.Env, err = net.coverageEnv(node.Name); err != nil {
+			return err
+		}
+	default:
+		if cmd, err = net.remoteNodeCommand(host, node, &cfg, oasisBinary, args); err != nil {
+			return err
+		}
+		var lw *os.File
+		if lw, err = os.OpenFile(node.LogPath(), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600); err != nil {
+			return fmt.Errorf("oasis: failed to open log file: %w", err)
+		}
+		net.env.AddOnCleanup(func() {
+			_ = lw.Close()
+		})
+		cmd.Stdout = lw
+	}
+	cmd.SysProcAttr = env.CmdAttrs
+	cmd.Stderr = w
+
 	net.logger.Info("launching Oasis node",
 		"args", strings.Join(args, " "),
+		"host", host.name(),
 		"log_level", cfg.Common.Log.Level["default"],
 		"log_format", cfg.Common.Log.Format,
 	)
 
+	// Release the node's port reservations right before starting it, so that it can bind them.
+	portsToRelease := make([]uint16, 0, len(node.ports))
+	for _, port := range node.ports {
+		portsToRelease = append(portsToRelease, port)
+	}
+	net.portReservations.release(portsToRelease...)
+
 	if err = cmd.Start(); err != nil {
 		return fmt.Errorf("oasis: failed to start node: %w", err)
 	}
 
 	doneCh := net.env.AddTermOnCleanup(cmd)
 	exitCh := make(chan error, 1)
+	stopMonitorCh := make(chan struct{})
+	if host == nil {
+		// Resources of remote nodes cannot be monitored.
+		go node.monitorResources(cmd.Process.Pid, stopMonitorCh)
+	}
 	go func() {
 		defer close(exitCh)
 
 		cmdErr := <-doneCh
+		close(stopMonitorCh)
 		net.logger.Debug("node terminated",
 			"node", node.Name,
 			"err", cmdErr,
@@ -781,6 +860,27 @@ func (net *Network) startOasisNode(
 	return nil
 }
 
+// remoteNodeCommand prepares the given remote host for running the node and
+// returns the command that runs it.
+func (net *Network) remoteNodeCommand(host *TopologyHost, node *Node, cfg *config.Config, binary string, args []string) (*exec.Cmd, error) {
+	paths := []string{node.DataDir(), net.GenesisPath()}
+	if host.SyncBinaries {
+		paths = append(paths, binary)
+		paths = append(paths, cfg.Runtime.Paths...)
+	}
+	if err := host.sync(paths...); err != nil {
+		return nil, err
+	}
+
+	cmd := host.command(node.SocketPath(), binary, args...)
+
+	// Keep the standard input open as the remote node is terminated once it is closed.
+	if _, err := cmd.StdinPipe(); err != nil {
+		return nil, fmt.Errorf("oasis: failed to create stdin pipe: %w", err)
+	}
+	return cmd, nil
+}
+
 // MakeGenesis generates a new Genesis file.
 func (net *Network) MakeGenesis() error {
 	args := []string{
@@ -887,12 +987,27 @@ func (net *Network) MakeGenesis() error {
 					// Debug test entities already get funded.
 					continue
 				}
-				net.cfg.StakingGenesis.Ledger[staking.NewAddress(ent.Signer().Public())] = &staking.Account{
+				addr := staking.NewAddress(ent.Signer().Public())
+				acct := &staking.Account{
 					General: staking.GeneralAccount{
 						Balance: *toFund,
 					},
 				}
 				_ = net.cfg.StakingGenesis.TotalSupply.Add(toFund)
+
+				if ent.stake != nil && !ent.stake.IsZero() {
+					// Self-delegate the configured stake.
+					acct.Escrow.Active.Balance = *ent.stake.Clone()
+					acct.Escrow.Active.TotalShares = *ent.stake.Clone()
+					if net.cfg.StakingGenesis.Delegations == nil {
+						net.cfg.StakingGenesis.Delegations = make(map[staking.Address]map[staking.Address]*staking.Delegation)
+					}
+					net.cfg.StakingGenesis.Delegations[addr] = map[staking.Address]*staking.Delegation{
+						addr: {Shares: *ent.stake.Clone()},
+					}
+					_ = net.cfg.StakingGenesis.TotalSupply.Add(ent.stake)
+				}
+				net.cfg.StakingGenesis.Ledger[addr] = acct
 			}
 		}
 
@@ -965,6 +1080,21 @@ func (net *Network) GetCLIConfig() cli.Config {
 	return cfg
 }
 
+// addForceElectParams rigs the committee elections of the given runtime so that the given node is
+// elected according to the given parameters.
+func (net *Network) addForceElectParams(rt common.Namespace, nodeID signature.PublicKey, params *scheduler.ForceElectCommitteeRole) {
+	if net.cfg.SchedulerForceElect == nil {
+		net.cfg.SchedulerForceElect = make(map[common.Namespace]map[signature.PublicKey]*scheduler.ForceElectCommitteeRole)
+	}
+	if net.cfg.SchedulerForceElect[rt] == nil {
+		net.cfg.SchedulerForceElect[rt] = make(map[signature.PublicKey]*scheduler.ForceElectCommitteeRole)
+	}
+	if params != nil {
+		tmpParams := *params
+		net.cfg.SchedulerForceElect[rt][nodeID] = &tmpParams
+	}
+}
+
 func (net *Network) provisionNodeIdentity(dataDir *env.Dir, seed string) (signature.PublicKey, signature.PublicKey, *x509.Certificate, error) {
 	if net.cfg.DeterministicIdentities && !net.cfg.RestoreIdentities {
 		if err := net.generateDeterministicNodeIdentity(dataDir, seed); err != nil {
@@ -987,14 +1117,22 @@ func (net *Network) provisionNodeIdentity(dataDir *env.Dir, seed string) (signat
 	return nodeIdentity.NodeSigner.Public(), nodeIdentity.P2PSigner.Public(), sentryCert, nil
 }
 
-func (net *Network) getProvisionedPort(portName string) uint16 {
+// getProvisionedPort provisions a network-level port which is served by the test runner itself
+// (e.g., the runtime bundle repository).
+//
+// Unlike node ports, the reservation is released as soon as the port is provisioned, as the
+// runner may bind the port at any time during the scenario.
+func (net *Network) getProvisionedPort(portName string) (uint16, error) {
 	port, ok := net.ports[portName]
 	if !ok {
-		port = net.nextPort
-		net.nextPort++
+		var err error
+		if port, err = net.portReservations.reserve(); err != nil {
+			return 0, fmt.Errorf("failed to provision network port %s: %w", portName, err)
+		}
+		net.portReservations.release(port)
 		net.ports[portName] = port
 	}
-	return port
+	return port, nil
 }
 
 // New creates a new test Oasis network.
@@ -1044,15 +1182,23 @@ func New(env *env.Env, cfg *NetworkCfg) (*Network, error) {
 		cfgCopy.InitialHeight = defaultInitialHeight
 	}
 
+	if cfgCopy.Topology != nil {
+		if err = cfgCopy.Topology.Validate(); err != nil {
+			return nil, fmt.Errorf("oasis: invalid topology: %w", err)
+		}
+	}
+
 	net := &Network{
-		logger:   logging.GetLogger("oasis/" + env.Name()),
-		env:      env,
-		baseDir:  baseDir,
-		cfg:      &cfgCopy,
-		nextPort: basePort,
-		ports:    make(map[string]uint16),
-		errCh:    make(chan error, maxNodes),
+		logger:  logging.GetLogger("oasis/" + env.Name()),
+		env:     env,
+		baseDir: baseDir,
+		cfg:     &cfgCopy,
+		ports:   make(map[string]uint16),
+		errCh:   make(chan error, maxNodes),
+
+		portReservations: newPortReservations(basePort),
 	}
+	env.AddOnCleanup(net.portReservations.releaseAll)
 
 	// Pre-provision node objects if they were listed in the top-level network fixture.
 	for _, nodeName := range cfg.Nodes {
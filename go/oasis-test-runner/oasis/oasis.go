@@ -59,7 +59,6 @@ const (
 	netPortRepository = "repository"
 
 	allInterfacesAddr = "tcp://0.0.0.0"
-	localhostAddr     = "tcp://127.0.0.1"
 )
 
 // ConsensusStateSyncCfg is a node's consensus state sync configuration.
@@ -96,6 +95,8 @@ type Node struct { // nolint: maligned
 	dir *env.Dir
 	cmd *exec.Cmd
 
+	binary string
+
 	extraArgs      []Argument
 	features       []Feature
 	hasValidators  bool
@@ -126,6 +127,26 @@ type Node struct { // nolint: maligned
 	sentryCert *x509.Certificate
 
 	entity *Entity
+
+	resourcesLock  sync.Mutex
+	resourceLimits *ResourceLimits
+	peakResources  ResourceUsage
+}
+
+// hostIP returns the IP address at which the node can be reached by other nodes.
+func (n *Node) hostIP() string {
+	if host := n.net.cfg.Topology.Host(n.Name); host != nil {
+		return host.Address
+	}
+	return loopbackIP
+}
+
+// runnerIP returns the IP address at which the node can reach services hosted by the test runner.
+func (n *Node) runnerIP() string {
+	if n.net.cfg.Topology.Host(n.Name) != nil {
+		return n.net.cfg.Topology.RunnerAddress
+	}
+	return loopbackIP
 }
 
 // SetArchiveMode sets the archive mode.
@@ -133,14 +154,16 @@ func (n *Node) SetArchiveMode(archive bool) {
 	n.consensus.EnableArchiveMode = archive
 }
 
-func (n *Node) getProvisionedPort(portName string) uint16 {
+func (n *Node) getProvisionedPort(portName string) (uint16, error) {
 	port, ok := n.ports[portName]
 	if !ok {
-		port = n.net.nextPort
-		n.net.nextPort++
+		var err error
+		if port, err = n.net.portReservations.reserve(); err != nil {
+			return 0, fmt.Errorf("failed to provision port %s for node %s: %w", portName, n.Name, err)
+		}
 		n.ports[portName] = port
 	}
-	return port
+	return port, nil
 }
 
 func (n *Node) addHostedRuntime(rt *Runtime, localConfig map[string]interface{}) {
@@ -212,14 +235,14 @@ func (n *Node) AddSeedNodesToConfigExcept(excludeSeedNodeName string) {
 		cometbftSeed := commonNode.ConsensusAddress{
 			ID: seed.p2pSigner,
 			Address: commonNode.Address{
-				IP:   net.ParseIP("127.0.0.1"),
+				IP:   net.ParseIP(seed.hostIP()),
 				Port: int64(seed.consensusPort),
 			},
 		}
 		libp2pSeed := commonNode.ConsensusAddress{
 			ID: seed.p2pSigner,
 			Address: commonNode.Address{
-				IP:   net.ParseIP("127.0.0.1"),
+				IP:   net.ParseIP(seed.hostIP()),
 				Port: int64(seed.libp2pSeedPort),
 			},
 		}
@@ -235,7 +258,7 @@ func (n *Node) AddSeedNodesToConfigExcept(excludeSeedNodeName string) {
 func (n *Node) AddSentriesToConfig(sentries []*Sentry) {
 	var addrs []string
 	for _, sentry := range sentries {
-		addrs = append(addrs, fmt.Sprintf("%s@127.0.0.1:%d", sentry.tlsPublicKey.String(), sentry.controlPort))
+		addrs = append(addrs, fmt.Sprintf("%s@%s:%d", sentry.tlsPublicKey.String(), sentry.hostIP(), sentry.controlPort))
 	}
 	n.Config.Runtime.SentryAddresses = addrs
 }
@@ -303,7 +326,11 @@ func (n *Node) Start() error {
 		n.Config.Runtime.Paths = append(n.Config.Runtime.Paths, hosted.runtime.BundlePaths()...)
 	}
 
-	n.Config.Runtime.Repositories = []string{fmt.Sprintf("http://127.0.0.1:%d", n.net.getProvisionedPort(netPortRepository))}
+	repositoryPort, err := n.net.getProvisionedPort(netPortRepository)
+	if err != nil {
+		return err
+	}
+	n.Config.Runtime.Repositories = []string{fmt.Sprintf("http://%s:%d", n.runnerIP(), repositoryPort)}
 
 	if n.consensus.EnableArchiveMode {
 		n.Config.Mode = config.ModeArchive
@@ -315,7 +342,7 @@ func (n *Node) Start() error {
 		return customStart.CustomStart(args)
 	}
 
-	if err := n.net.startOasisNode(n, nil, args); err != nil {
+	if err = n.net.startOasisNode(n, nil, args); err != nil {
 		return fmt.Errorf("oasis/node: failed to launch node %s: %w", n.Name, err)
 	}
 
@@ -375,6 +402,17 @@ func (n *Node) RestartAfter(ctx context.Context, startDelay time.Duration) error
 	return n.Start()
 }
 
+// SetBinary overrides the path to the Oasis node binary used to start this node. An empty path
+// reverts to the network-wide NetworkCfg.NodeBinary.
+//
+// The new binary is only used the next time the node is started.
+func (n *Node) SetBinary(path string) {
+	n.Lock()
+	defer n.Unlock()
+
+	n.binary = path
+}
+
 // BinaryPath returns the path to the running node's process' image, or an empty string
 // if the node isn't running yet. This can be used as a replacement for NetworkCfg.NodeBinary
 // in cases where the test runner is actually using a wrapper to start the node.
@@ -487,7 +525,7 @@ type NodeCfg struct { // nolint: maligned
 }
 
 // Into sets node parameters of an existing node object from the configuration.
-func (cfg *NodeCfg) Into(node *Node) {
+func (cfg *NodeCfg) Into(node *Node) error {
 	node.noAutoStart = cfg.NoAutoStart
 	node.termEarlyOk = cfg.AllowEarlyTermination
 	node.termErrorOk = cfg.AllowErrorTermination
@@ -504,9 +542,15 @@ func (cfg *NodeCfg) Into(node *Node) {
 	}
 
 	if node.pprofPort == 0 && cfg.EnableProfiling {
-		node.pprofPort = node.getProvisionedPort(nodePortPprof)
+		pprofPort, err := node.getProvisionedPort(nodePortPprof)
+		if err != nil {
+			return err
+		}
+		node.pprofPort = pprofPort
 	}
 	node.extraArgs = cfg.ExtraArgs
+
+	return nil
 }
 
 func nodeLogPath(dir *env.Dir) string {
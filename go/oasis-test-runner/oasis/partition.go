@@ -0,0 +1,64 @@
+package oasis
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+)
+
+func (net *Network) setIsolatedPeers(ctx context.Context, n *Node, peers []signature.PublicKey) error {
+	ctrl, err := NewController(n.SocketPath())
+	if err != nil {
+		return fmt.Errorf("oasis: failed to create controller for node %s: %w", n.Name, err)
+	}
+	defer ctrl.Close()
+
+	if err = ctrl.SetIsolatedPeers(ctx, peers); err != nil {
+		return fmt.Errorf("oasis: failed to set isolated peers of node %s: %w", n.Name, err)
+	}
+	return nil
+}
+
+// IsolateP2P partitions the P2P network by isolating the given nodes from all other nodes
+// in the network. The isolated nodes can still communicate among themselves and consensus
+// connectivity is not affected.
+//
+// The partition is in effect until HealP2P is called.
+func (net *Network) IsolateP2P(ctx context.Context, nodes []*Node) error {
+	if len(net.p2pIsolated) > 0 {
+		return fmt.Errorf("oasis: P2P network already partitioned")
+	}
+
+	var peers []signature.PublicKey
+	for _, n := range net.nodes {
+		if slices.Contains(nodes, n) || !n.p2pSigner.IsValid() {
+			continue
+		}
+		peers = append(peers, n.p2pSigner)
+	}
+
+	for _, n := range nodes {
+		net.logger.Info("isolating node from the P2P network", "node", n.Name)
+
+		net.p2pIsolated = append(net.p2pIsolated, n)
+		if err := net.setIsolatedPeers(ctx, n, peers); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// HealP2P heals the P2P network partition created by IsolateP2P.
+func (net *Network) HealP2P(ctx context.Context) error {
+	for _, n := range net.p2pIsolated {
+		net.logger.Info("healing node P2P connectivity", "node", n.Name)
+
+		if err := net.setIsolatedPeers(ctx, n, nil); err != nil {
+			return err
+		}
+	}
+	net.p2pIsolated = nil
+	return nil
+}
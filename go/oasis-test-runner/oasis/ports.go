@@ -0,0 +1,89 @@
+package oasis
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"strconv"
+	"sync"
+)
+
+// portReservations is a port allocator that reserves ports by binding to them and holding
+// the listeners until the ports are released right before they are used.
+//
+// This prevents ports from being handed out while in use by other processes on the same host
+// (e.g., other test runner instances), which would otherwise cause nodes to fail with
+// "address already in use" errors.
+//
+// Note that only TCP ports are reserved. Users of the same port numbers over UDP are not
+// protected against conflicts.
+type portReservations struct {
+	sync.Mutex
+
+	next      uint16
+	listeners map[uint16]net.Listener
+}
+
+// reserve reserves the next available TCP port.
+//
+// Ports that are already in use are skipped.
+func (r *portReservations) reserve() (uint16, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	for {
+		if r.next == 0 {
+			return 0, fmt.Errorf("oasis: no ports left to reserve")
+		}
+		port := r.next
+		if port == math.MaxUint16 {
+			r.next = 0
+		} else {
+			r.next++
+		}
+
+		l, err := net.Listen("tcp", net.JoinHostPort("", strconv.Itoa(int(port))))
+		if err != nil {
+			// Port is already in use, try the next one.
+			continue
+		}
+		r.listeners[port] = l
+
+		return port, nil
+	}
+}
+
+// release releases the given reserved ports so that they can be bound by their user.
+//
+// Releasing ports that are not reserved is a no-op.
+func (r *portReservations) release(ports ...uint16) {
+	r.Lock()
+	defer r.Unlock()
+
+	for _, port := range ports {
+		l, ok := r.listeners[port]
+		if !ok {
+			continue
+		}
+		_ = l.Close()
+		delete(r.listeners, port)
+	}
+}
+
+// releaseAll releases all reserved ports.
+func (r *portReservations) releaseAll() {
+	r.Lock()
+	defer r.Unlock()
+
+	for port, l := range r.listeners {
+		_ = l.Close()
+		delete(r.listeners, port)
+	}
+}
+
+func newPortReservations(base uint16) *portReservations {
+	return &portReservations{
+		next:      base,
+		listeners: make(map[uint16]net.Listener),
+	}
+}
@@ -0,0 +1,55 @@
+package oasis
+
+import (
+	"net"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPortReservations(t *testing.T) {
+	require := require.New(t)
+
+	// Occupy a port to make sure that it gets skipped.
+	busy, err := net.Listen("tcp", ":0")
+	require.NoError(err, "Listen")
+	defer busy.Close()
+	busyPort := uint16(busy.Addr().(*net.TCPAddr).Port)
+
+	r := newPortReservations(busyPort)
+	defer r.releaseAll()
+
+	port, err := r.reserve()
+	require.NoError(err, "reserve")
+	require.NotEqual(busyPort, port, "busy ports should be skipped")
+	require.Greater(port, busyPort)
+
+	// Reserved ports should not be available to others.
+	_, err = net.Listen("tcp", net.JoinHostPort("", strconv.Itoa(int(port))))
+	require.Error(err, "reserved ports should be held")
+
+	// Released ports should be available.
+	r.release(port)
+	l, err := net.Listen("tcp", net.JoinHostPort("", strconv.Itoa(int(port))))
+	require.NoError(err, "released ports should be available")
+	l.Close()
+
+	// Releasing ports twice should be a no-op.
+	r.release(port)
+
+	// A second allocator should not hand out ports reserved by the first one.
+	other := newPortReservations(busyPort)
+	defer other.releaseAll()
+
+	port, err = r.reserve()
+	require.NoError(err, "reserve")
+	otherPort, err := other.reserve()
+	require.NoError(err, "reserve")
+	require.NotEqual(port, otherPort, "reserved ports should not be shared")
+
+	// Running out of ports should result in an error.
+	exhausted := newPortReservations(0)
+	_, err = exhausted.reserve()
+	require.Error(err, "reserving with no ports left should fail")
+}
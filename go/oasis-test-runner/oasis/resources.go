@@ -0,0 +1,246 @@
+package oasis
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	resourceUsageFile = "resource_usage.jsonl"
+
+	// resourceSamplingInterval is the interval at which node resource usage is sampled.
+	resourceSamplingInterval = 5 * time.Second
+
+	// procClockTicks is the number of clock ticks per second used by /proc/<pid>/stat
+	// (USER_HZ), which is fixed to 100 on all Linux platforms we support.
+	procClockTicks = 100
+)
+
+// ResourceUsage is the resource usage of a node process.
+type ResourceUsage struct {
+	// CPUTime is the total user and system CPU time consumed by the process.
+	CPUTime time.Duration `json:"cpu_time"`
+	// RSS is the resident set size of the process in bytes.
+	RSS uint64 `json:"rss"`
+	// DiskUsage is the size of the node's data directory in bytes.
+	DiskUsage uint64 `json:"disk_usage"`
+	// OpenFiles is the number of open file descriptors of the process.
+	OpenFiles uint64 `json:"open_files"`
+}
+
+func (u *ResourceUsage) updatePeak(other *ResourceUsage) {
+	u.CPUTime = max(u.CPUTime, other.CPUTime)
+	u.RSS = max(u.RSS, other.RSS)
+	u.DiskUsage = max(u.DiskUsage, other.DiskUsage)
+	u.OpenFiles = max(u.OpenFiles, other.OpenFiles)
+}
+
+// ResourceSample is a timestamped resource usage sample.
+type ResourceSample struct {
+	ResourceUsage
+
+	// Timestamp is the time at which the sample was taken.
+	Timestamp time.Time `json:"timestamp"`
+	// PID is the process identifier of the sampled node process.
+	PID int `json:"pid"`
+}
+
+// ResourceLimits are the resource usage ceilings of a node. Zero values mean
+// that the given resource is not limited.
+type ResourceLimits struct {
+	// MaxCPUTime is the maximum CPU time a single node process may consume.
+	MaxCPUTime time.Duration
+	// MaxRSS is the maximum resident set size in bytes.
+	MaxRSS uint64
+	// MaxDiskUsage is the maximum size of the node's data directory in bytes.
+	MaxDiskUsage uint64
+	// MaxOpenFiles is the maximum number of open file descriptors.
+	MaxOpenFiles uint64
+}
+
+// Check checks the given (peak) resource usage against the limits.
+func (l *ResourceLimits) Check(usage *ResourceUsage) error {
+	switch {
+	case l.MaxCPUTime > 0 && usage.CPUTime > l.MaxCPUTime:
+		return fmt.Errorf("CPU time %s exceeds limit %s", usage.CPUTime, l.MaxCPUTime)
+	case l.MaxRSS > 0 && usage.RSS > l.MaxRSS:
+		return fmt.Errorf("RSS %d bytes exceeds limit %d bytes", usage.RSS, l.MaxRSS)
+	case l.MaxDiskUsage > 0 && usage.DiskUsage > l.MaxDiskUsage:
+		return fmt.Errorf("disk usage %d bytes exceeds limit %d bytes", usage.DiskUsage, l.MaxDiskUsage)
+	case l.MaxOpenFiles > 0 && usage.OpenFiles > l.MaxOpenFiles:
+		return fmt.Errorf("open files %d exceeds limit %d", usage.OpenFiles, l.MaxOpenFiles)
+	default:
+		return nil
+	}
+}
+
+// SetResourceLimits sets the resource usage ceilings checked by CheckResourceLimits.
+func (n *Node) SetResourceLimits(limits *ResourceLimits) {
+	n.resourcesLock.Lock()
+	defer n.resourcesLock.Unlock()
+
+	n.resourceLimits = limits
+}
+
+// PeakResourceUsage returns the peak resource usage observed over all runs of the node.
+func (n *Node) PeakResourceUsage() ResourceUsage {
+	n.resourcesLock.Lock()
+	defer n.resourcesLock.Unlock()
+
+	return n.peakResources
+}
+
+// CheckResourceLimits checks the peak resource usage of the node against the configured
+// resource limits, if any.
+func (n *Node) CheckResourceLimits() error {
+	n.resourcesLock.Lock()
+	defer n.resourcesLock.Unlock()
+
+	if n.resourceLimits == nil {
+		return nil
+	}
+	if err := n.resourceLimits.Check(&n.peakResources); err != nil {
+		return fmt.Errorf("node %s: %w", n.Name, err)
+	}
+	return nil
+}
+
+// monitorResources periodically samples the resource usage of the node process with the
+// given PID and records it into the node's directory until the stop channel is closed.
+func (n *Node) monitorResources(pid int, stopCh <-chan struct{}) {
+	logger := n.net.logger.With("node", n.Name, "pid", pid)
+
+	f, err := os.OpenFile(filepath.Join(n.dir.String(), resourceUsageFile), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		logger.Error("failed to open resource usage file",
+			"err", err,
+		)
+		return
+	}
+	defer f.Close()
+	enc := json.NewEncoder(f)
+
+	ticker := time.NewTicker(resourceSamplingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-ticker.C:
+		}
+
+		sample, err := sampleResourceUsage(pid, n.DataDir())
+		if err != nil {
+			// The process may have just exited.
+			logger.Debug("failed to sample resource usage",
+				"err", err,
+			)
+			continue
+		}
+
+		n.resourcesLock.Lock()
+		n.peakResources.updatePeak(&sample.ResourceUsage)
+		n.resourcesLock.Unlock()
+
+		if err = enc.Encode(sample); err != nil {
+			logger.Error("failed to record resource usage",
+				"err", err,
+			)
+		}
+	}
+}
+
+func sampleResourceUsage(pid int, dataDir string) (*ResourceSample, error) {
+	sample := ResourceSample{
+		Timestamp: time.Now(),
+		PID:       pid,
+	}
+	procDir := filepath.Join("/proc", strconv.Itoa(pid))
+
+	// CPU time, see proc(5) for the format. The command name may contain spaces
+	// so start parsing after its closing parenthesis.
+	rawStat, err := os.ReadFile(filepath.Join(procDir, "stat"))
+	if err != nil {
+		return nil, err
+	}
+	stat := string(rawStat)
+	fields := strings.Fields(stat[strings.LastIndexByte(stat, ')')+1:])
+	if len(fields) < 13 {
+		return nil, fmt.Errorf("malformed stat file")
+	}
+	var ticks uint64
+	for _, field := range fields[11:13] { // utime, stime
+		v, err := strconv.ParseUint(field, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("malformed stat file: %w", err)
+		}
+		ticks += v
+	}
+	sample.CPUTime = time.Duration(ticks) * time.Second / procClockTicks
+
+	// Resident set size.
+	statm, err := os.Open(filepath.Join(procDir, "statm"))
+	if err != nil {
+		return nil, err
+	}
+	defer statm.Close()
+	scanner := bufio.NewScanner(statm)
+	scanner.Split(bufio.ScanWords)
+	for i := 0; i < 2 && scanner.Scan(); i++ {
+		if i == 1 {
+			pages, err := strconv.ParseUint(scanner.Text(), 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("malformed statm file: %w", err)
+			}
+			sample.RSS = pages * uint64(os.Getpagesize())
+		}
+	}
+
+	// Open file descriptors.
+	fds, err := os.ReadDir(filepath.Join(procDir, "fd"))
+	if err != nil {
+		return nil, err
+	}
+	sample.OpenFiles = uint64(len(fds))
+
+	// Disk usage of the data directory.
+	_ = filepath.WalkDir(dataDir, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// Files may disappear while walking, ignore.
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		if fi, err := d.Info(); err == nil {
+			sample.DiskUsage += uint64(fi.Size())
+		}
+		return nil
+	})
+
+	return &sample, nil
+}
+
+// CheckResourceLimits checks the peak resource usage of all nodes against their
+// configured resource limits.
+func (net *Network) CheckResourceLimits() (err error) {
+	for _, n := range net.nodes {
+		if limitErr := n.CheckResourceLimits(); limitErr != nil {
+			net.logger.Error("node exceeded resource limits",
+				"node", n.Name,
+				"peak_usage", n.PeakResourceUsage(),
+				"err", limitErr,
+			)
+			err = limitErr
+		}
+	}
+	return
+}
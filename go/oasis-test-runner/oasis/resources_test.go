@@ -0,0 +1,60 @@
+package oasis
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSampleResourceUsage(t *testing.T) {
+	require := require.New(t)
+
+	dataDir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dataDir, "data"), make([]byte, 1024), 0o600)
+	require.NoError(err, "WriteFile")
+
+	sample, err := sampleResourceUsage(os.Getpid(), dataDir)
+	require.NoError(err, "sampleResourceUsage")
+	require.Equal(os.Getpid(), sample.PID)
+	require.NotZero(sample.RSS, "RSS should be reported")
+	require.NotZero(sample.OpenFiles, "open files should be reported")
+	require.EqualValues(1024, sample.DiskUsage, "disk usage should be reported")
+
+	_, err = sampleResourceUsage(-1, dataDir)
+	require.Error(err, "sampleResourceUsage should fail for invalid PID")
+}
+
+func TestResourceLimits(t *testing.T) {
+	require := require.New(t)
+
+	usage := ResourceUsage{
+		CPUTime:   time.Minute,
+		RSS:       1 << 30,
+		DiskUsage: 1 << 20,
+		OpenFiles: 100,
+	}
+
+	var limits ResourceLimits
+	require.NoError(limits.Check(&usage), "no limits")
+
+	limits = ResourceLimits{
+		MaxCPUTime:   time.Hour,
+		MaxRSS:       2 << 30,
+		MaxDiskUsage: 1 << 30,
+		MaxOpenFiles: 1000,
+	}
+	require.NoError(limits.Check(&usage), "usage within limits")
+
+	limits.MaxRSS = 512 << 20
+	require.Error(limits.Check(&usage), "RSS exceeds limit")
+
+	var peak ResourceUsage
+	peak.updatePeak(&usage)
+	peak.updatePeak(&ResourceUsage{RSS: 1, OpenFiles: 200})
+	require.EqualValues(1<<30, peak.RSS)
+	require.EqualValues(200, peak.OpenFiles)
+	require.Equal(time.Minute, peak.CPUTime)
+}
@@ -34,7 +34,7 @@ func (seed *Seed) ModifyConfig() error {
 	seed.Config.Mode = config.ModeSeed
 
 	seed.Config.Consensus.ListenAddress = allInterfacesAddr + ":" + strconv.Itoa(int(seed.consensusPort))
-	seed.Config.Consensus.ExternalAddress = localhostAddr + ":" + strconv.Itoa(int(seed.consensusPort))
+	seed.Config.Consensus.ExternalAddress = "tcp://" + seed.hostIP() + ":" + strconv.Itoa(int(seed.consensusPort))
 
 	if seed.disableAddrBookFromGenesis {
 		seed.Config.Consensus.Debug.DisableAddrBookFromGenesis = true
@@ -68,11 +68,20 @@ func (net *Network) NewSeed(cfg *SeedCfg) (*Seed, error) {
 	}
 	host.p2pSigner = seedIdentity.P2PSigner.Public()
 
+	consensusPort, err := host.getProvisionedPort(nodePortConsensus)
+	if err != nil {
+		return nil, fmt.Errorf("oasis/seed: %w", err)
+	}
+	libp2pSeedPort, err := host.getProvisionedPort(nodePortP2PSeed)
+	if err != nil {
+		return nil, fmt.Errorf("oasis/seed: %w", err)
+	}
+
 	seedNode := &Seed{
 		Node:                       host,
 		disableAddrBookFromGenesis: cfg.DisableAddrBookFromGenesis,
-		consensusPort:              host.getProvisionedPort(nodePortConsensus),
-		libp2pSeedPort:             host.getProvisionedPort(nodePortP2PSeed),
+		consensusPort:              consensusPort,
+		libp2pSeedPort:             libp2pSeedPort,
 	}
 	net.seeds = append(net.seeds, seedNode)
 	host.features = append(host.features, seedNode)
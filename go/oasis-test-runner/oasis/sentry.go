@@ -49,12 +49,12 @@ func (sentry *Sentry) GetTLSPubKey() signature.PublicKey {
 
 // GetSentryAddress returns the sentry grpc endpoint address.
 func (sentry *Sentry) GetSentryAddress() string {
-	return fmt.Sprintf("127.0.0.1:%d", sentry.sentryPort)
+	return fmt.Sprintf("%s:%d", sentry.hostIP(), sentry.sentryPort)
 }
 
 // GetSentryControlAddress returns the sentry control endpoint address.
 func (sentry *Sentry) GetSentryControlAddress() string {
-	return fmt.Sprintf("127.0.0.1:%d", sentry.controlPort)
+	return fmt.Sprintf("%s:%d", sentry.hostIP(), sentry.controlPort)
 }
 
 func (sentry *Sentry) AddArgs(args *argBuilder) error {
@@ -67,7 +67,7 @@ func (sentry *Sentry) AddArgs(args *argBuilder) error {
 
 func (sentry *Sentry) ModifyConfig() error {
 	sentry.Config.Consensus.ListenAddress = allInterfacesAddr + ":" + strconv.Itoa(int(sentry.consensusPort))
-	sentry.Config.Consensus.ExternalAddress = localhostAddr + ":" + strconv.Itoa(int(sentry.consensusPort))
+	sentry.Config.Consensus.ExternalAddress = "tcp://" + sentry.hostIP() + ":" + strconv.Itoa(int(sentry.consensusPort))
 
 	if sentry.supplementarySanityInterval > 0 {
 		sentry.Config.Consensus.SupplementarySanity.Enabled = true
@@ -101,7 +101,7 @@ func (sentry *Sentry) ModifyConfig() error {
 			addr := commonNode.ConsensusAddress{
 				ID: val.p2pSigner,
 				Address: commonNode.Address{
-					IP:   net.ParseIP("127.0.0.1"),
+					IP:   net.ParseIP(val.hostIP()),
 					Port: int64(val.consensusPort),
 				},
 			}
@@ -121,7 +121,7 @@ func (sentry *Sentry) ModifyConfig() error {
 			addr := commonNode.ConsensusAddress{
 				ID: computeWorker.p2pSigner,
 				Address: commonNode.Address{
-					IP:   net.ParseIP("127.0.0.1"),
+					IP:   net.ParseIP(computeWorker.hostIP()),
 					Port: int64(computeWorker.consensusPort),
 				},
 			}
@@ -141,7 +141,7 @@ func (sentry *Sentry) ModifyConfig() error {
 			addr := commonNode.ConsensusAddress{
 				ID: keymanager.p2pSigner,
 				Address: commonNode.Address{
-					IP:   net.ParseIP("127.0.0.1"),
+					IP:   net.ParseIP(keymanager.hostIP()),
 					Port: int64(keymanager.consensusPort),
 				},
 			}
@@ -187,6 +187,19 @@ func (net *Network) NewSentry(cfg *SentryCfg) (*Sentry, error) {
 	sentryP2PPublicKey := sentryIdentity.P2PSigner.Public()
 	sentryTLSPublicKey := sentryIdentity.TLSSigner.Public()
 
+	consensusPort, err := host.getProvisionedPort(nodePortConsensus)
+	if err != nil {
+		return nil, fmt.Errorf("oasis/sentry: %w", err)
+	}
+	controlPort, err := host.getProvisionedPort("sentry-control")
+	if err != nil {
+		return nil, fmt.Errorf("oasis/sentry: %w", err)
+	}
+	sentryPort, err := host.getProvisionedPort("sentry-client")
+	if err != nil {
+		return nil, fmt.Errorf("oasis/sentry: %w", err)
+	}
+
 	sentry := &Sentry{
 		Node:              host,
 		validatorIndices:  cfg.ValidatorIndices,
@@ -195,9 +208,9 @@ func (net *Network) NewSentry(cfg *SentryCfg) (*Sentry, error) {
 		p2pPublicKey:      sentryP2PPublicKey,
 		tlsPublicKey:      sentryTLSPublicKey,
 		tmAddress:         crypto.PublicKeyToCometBFT(&sentryP2PPublicKey).Address().String(),
-		consensusPort:     host.getProvisionedPort(nodePortConsensus),
-		controlPort:       host.getProvisionedPort("sentry-control"),
-		sentryPort:        host.getProvisionedPort("sentry-client"),
+		consensusPort:     consensusPort,
+		controlPort:       controlPort,
+		sentryPort:        sentryPort,
 	}
 
 	net.sentries = append(net.sentries, sentry)
@@ -0,0 +1,200 @@
+package oasis
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+)
+
+const (
+	snapshotsDir         = "snapshots"
+	snapshotManifestFile = "manifest.json"
+)
+
+// snapshotManifest describes the state of the network at the time of the snapshot.
+type snapshotManifest struct {
+	// Running are the names of the nodes that were running when the snapshot was taken.
+	Running []string `json:"running"`
+}
+
+func (net *Network) snapshotPath(name string) (string, error) {
+	if name == "" || name != filepath.Base(name) || name == "." || name == ".." {
+		return "", fmt.Errorf("oasis: malformed snapshot name: '%s'", name)
+	}
+	return filepath.Join(net.baseDir.String(), snapshotsDir, name), nil
+}
+
+// stopForSnapshot gracefully stops all running nodes and returns the names of the stopped nodes.
+func (net *Network) stopForSnapshot() ([]string, error) {
+	for _, n := range net.nodes {
+		if net.cfg.Topology.Host(n.Name) != nil {
+			return nil, fmt.Errorf("oasis: snapshots of remote node %s are not supported", n.Name)
+		}
+	}
+
+	var running []string
+	for _, n := range net.nodes {
+		if n.cmd == nil {
+			continue
+		}
+		net.logger.Info("stopping node for snapshot", "node", n.Name)
+		if err := n.StopGracefully(); err != nil {
+			return nil, fmt.Errorf("oasis: failed to stop node %s: %w", n.Name, err)
+		}
+		running = append(running, n.Name)
+	}
+	return running, nil
+}
+
+func (net *Network) nodeByName(name string) *Node {
+	for _, n := range net.nodes {
+		if n.Name == name {
+			return n
+		}
+	}
+	return nil
+}
+
+// startAfterSnapshot starts the named nodes.
+func (net *Network) startAfterSnapshot(names []string) error {
+	for _, name := range names {
+		n := net.nodeByName(name)
+		if n == nil {
+			return fmt.Errorf("oasis: unknown node in snapshot: %s", name)
+		}
+		net.logger.Info("starting node after snapshot", "node", n.Name)
+		if err := n.Start(); err != nil {
+			return fmt.Errorf("oasis: failed to start node %s: %w", n.Name, err)
+		}
+	}
+	return nil
+}
+
+// Snapshot stops all running nodes, saves their data directories under the given name and starts
+// the nodes again.
+//
+// The network can later be rolled back to this point via RestoreSnapshot, which makes it possible
+// to explore multiple branches of a scenario without repeating the setup. Node logs are not part
+// of the snapshot.
+func (net *Network) Snapshot(name string) error {
+	path, err := net.snapshotPath(name)
+	if err != nil {
+		return err
+	}
+	if _, err = os.Stat(path); err == nil {
+		return fmt.Errorf("oasis: snapshot '%s' already exists", name)
+	}
+
+	running, err := net.stopForSnapshot()
+	if err != nil {
+		return err
+	}
+
+	net.logger.Info("taking network snapshot", "name", name)
+
+	if err = os.MkdirAll(path, 0o700); err != nil {
+		return fmt.Errorf("oasis: failed to create snapshot directory: %w", err)
+	}
+	for _, n := range net.nodes {
+		if err = copyNodeDir(n.DataDir(), filepath.Join(path, n.Name)); err != nil {
+			return fmt.Errorf("oasis: failed to snapshot node %s: %w", n.Name, err)
+		}
+	}
+	raw, err := json.Marshal(&snapshotManifest{Running: running})
+	if err != nil {
+		return fmt.Errorf("oasis: failed to marshal snapshot manifest: %w", err)
+	}
+	if err = os.WriteFile(filepath.Join(path, snapshotManifestFile), raw, 0o600); err != nil {
+		return fmt.Errorf("oasis: failed to write snapshot manifest: %w", err)
+	}
+
+	return net.startAfterSnapshot(running)
+}
+
+// RestoreSnapshot stops all running nodes, rolls back their data directories to the state saved
+// by Snapshot under the given name and starts the nodes that were running at that time.
+func (net *Network) RestoreSnapshot(name string) error {
+	path, err := net.snapshotPath(name)
+	if err != nil {
+		return err
+	}
+	raw, err := os.ReadFile(filepath.Join(path, snapshotManifestFile))
+	if err != nil {
+		return fmt.Errorf("oasis: failed to read snapshot manifest: %w", err)
+	}
+	var manifest snapshotManifest
+	if err = json.Unmarshal(raw, &manifest); err != nil {
+		return fmt.Errorf("oasis: failed to parse snapshot manifest: %w", err)
+	}
+
+	if _, err = net.stopForSnapshot(); err != nil {
+		return err
+	}
+
+	net.logger.Info("restoring network snapshot", "name", name)
+
+	for _, n := range net.nodes {
+		if err = clearNodeDir(n.DataDir()); err != nil {
+			return fmt.Errorf("oasis: failed to clear node %s: %w", n.Name, err)
+		}
+		if err = copyNodeDir(filepath.Join(path, n.Name), n.DataDir()); err != nil {
+			return fmt.Errorf("oasis: failed to restore node %s: %w", n.Name, err)
+		}
+	}
+
+	return net.startAfterSnapshot(manifest.Running)
+}
+
+// isNodeLog returns true iff the path (relative to the node directory) is a node log file.
+func isNodeLog(rel string) bool {
+	return rel == logNodeFile || rel == logConsoleFile
+}
+
+// copyNodeDir copies regular files from the source node directory into the destination
+// directory, skipping logs and sockets.
+func copyNodeDir(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		switch {
+		case d.IsDir():
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			return os.MkdirAll(target, info.Mode().Perm())
+		case !d.Type().IsRegular(), isNodeLog(rel):
+			return nil
+		default:
+			return common.CopyFile(path, target)
+		}
+	})
+}
+
+// clearNodeDir removes everything but the logs from the node directory.
+func clearNodeDir(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if isNodeLog(entry.Name()) {
+			continue
+		}
+		if err = os.RemoveAll(filepath.Join(dir, entry.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package oasis
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotNodeDir(t *testing.T) {
+	require := require.New(t)
+
+	src := t.TempDir()
+	require.NoError(os.MkdirAll(filepath.Join(src, "data", "db"), 0o700))
+	require.NoError(os.WriteFile(filepath.Join(src, "data", "db", "state"), []byte("state"), 0o600))
+	require.NoError(os.WriteFile(filepath.Join(src, logNodeFile), []byte("log"), 0o600))
+	require.NoError(os.WriteFile(filepath.Join(src, logConsoleFile), []byte("log"), 0o600))
+
+	// Sockets should be skipped.
+	l, err := net.Listen("unix", filepath.Join(src, "internal.sock"))
+	require.NoError(err, "Listen")
+	defer l.Close()
+
+	dst := filepath.Join(t.TempDir(), "snapshot")
+	require.NoError(copyNodeDir(src, dst), "copyNodeDir")
+
+	data, err := os.ReadFile(filepath.Join(dst, "data", "db", "state"))
+	require.NoError(err, "ReadFile")
+	require.Equal([]byte("state"), data)
+	for _, name := range []string{logNodeFile, logConsoleFile, "internal.sock"} {
+		_, err = os.Stat(filepath.Join(dst, name))
+		require.True(os.IsNotExist(err), "%s should not be part of the snapshot", name)
+	}
+
+	// Clearing should keep the logs only.
+	require.NoError(os.WriteFile(filepath.Join(src, "data", "db", "newer"), []byte("newer"), 0o600))
+	require.NoError(clearNodeDir(src), "clearNodeDir")
+	entries, err := os.ReadDir(src)
+	require.NoError(err, "ReadDir")
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	require.ElementsMatch([]string{logConsoleFile, logNodeFile}, names)
+
+	require.NoError(copyNodeDir(dst, src), "copyNodeDir")
+	_, err = os.Stat(filepath.Join(src, "data", "db", "newer"))
+	require.True(os.IsNotExist(err), "files created after the snapshot should be removed")
+	data, err = os.ReadFile(filepath.Join(src, "data", "db", "state"))
+	require.NoError(err, "ReadFile")
+	require.Equal([]byte("state"), data)
+}
@@ -0,0 +1,208 @@
+package oasis
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const loopbackIP = "127.0.0.1"
+
+// SSHCfg is the SSH configuration used to reach a remote host.
+type SSHCfg struct {
+	// Destination is the SSH destination in the form [user@]host.
+	Destination string `json:"destination"`
+
+	// Port is the SSH port (if not specified the SSH default is used).
+	Port uint16 `json:"port,omitempty"`
+
+	// IdentityFile is an optional path to the SSH private key.
+	IdentityFile string `json:"identity_file,omitempty"`
+
+	// Options are additional SSH options in the form key=value.
+	Options []string `json:"options,omitempty"`
+}
+
+// TopologyHost is a remote host on which fixture nodes are spawned.
+type TopologyHost struct {
+	// Name is the name of the host.
+	Name string `json:"name"`
+
+	// Address is the IP address at which other nodes (and the test runner)
+	// can reach nodes spawned on this host.
+	Address string `json:"address"`
+
+	// SSH is the SSH configuration used to reach the host.
+	SSH SSHCfg `json:"ssh"`
+
+	// SyncBinaries specifies whether the node binary and runtime bundles
+	// should be copied to the host. If not set, they are expected to be
+	// present on the host under the same paths.
+	SyncBinaries bool `json:"sync_binaries,omitempty"`
+
+	// Nodes are the names of the fixture nodes spawned on this host.
+	Nodes []string `json:"nodes"`
+}
+
+// Topology describes the placement of fixture nodes on remote hosts. Nodes
+// that are not assigned to any host are spawned locally.
+//
+// Remote hosts must be reachable over SSH with non-interactive authentication
+// and have rsync installed. Node data directories are mirrored to the same
+// absolute paths on the remote hosts, node logs are streamed back to the
+// test runner and the node gRPC sockets are forwarded over SSH so that
+// scenarios can interact with remote nodes in the same way as with local
+// ones.
+type Topology struct {
+	// RunnerAddress is the IP address at which remote hosts can reach
+	// services hosted by the test runner (e.g., the runtime repository).
+	RunnerAddress string `json:"runner_address"`
+
+	// Hosts are the remote hosts.
+	Hosts []*TopologyHost `json:"hosts"`
+}
+
+// Validate validates the topology.
+func (t *Topology) Validate() error {
+	if len(t.Hosts) > 0 && net.ParseIP(t.RunnerAddress) == nil {
+		return fmt.Errorf("malformed runner address: '%s'", t.RunnerAddress)
+	}
+
+	hostNames := make(map[string]bool)
+	nodeNames := make(map[string]string)
+	for _, host := range t.Hosts {
+		if host.Name == "" || hostNames[host.Name] {
+			return fmt.Errorf("missing or duplicate host name: '%s'", host.Name)
+		}
+		hostNames[host.Name] = true
+
+		if net.ParseIP(host.Address) == nil {
+			return fmt.Errorf("host %s: malformed address: '%s'", host.Name, host.Address)
+		}
+		if host.SSH.Destination == "" {
+			return fmt.Errorf("host %s: missing SSH destination", host.Name)
+		}
+		for _, name := range host.Nodes {
+			if other, ok := nodeNames[name]; ok {
+				return fmt.Errorf("node %s assigned to multiple hosts (%s, %s)", name, other, host.Name)
+			}
+			nodeNames[name] = host.Name
+		}
+	}
+	return nil
+}
+
+// Host returns the remote host on which the given node is spawned or nil in
+// case the node is spawned locally.
+func (t *Topology) Host(nodeName string) *TopologyHost {
+	if t == nil {
+		return nil
+	}
+	for _, host := range t.Hosts {
+		for _, name := range host.Nodes {
+			if name == nodeName {
+				return host
+			}
+		}
+	}
+	return nil
+}
+
+// LoadTopology loads the topology from the given JSON file.
+func LoadTopology(path string) (*Topology, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("oasis: failed to read topology file: %w", err)
+	}
+	var t Topology
+	if err = json.Unmarshal(raw, &t); err != nil {
+		return nil, fmt.Errorf("oasis: failed to parse topology file: %w", err)
+	}
+	if err = t.Validate(); err != nil {
+		return nil, fmt.Errorf("oasis: invalid topology: %w", err)
+	}
+	return &t, nil
+}
+
+func (h *TopologyHost) name() string {
+	if h == nil {
+		return "local"
+	}
+	return h.Name
+}
+
+func (h *TopologyHost) sshArgs() []string {
+	args := []string{"-o", "BatchMode=yes"}
+	if h.SSH.Port != 0 {
+		args = append(args, "-p", strconv.Itoa(int(h.SSH.Port)))
+	}
+	if h.SSH.IdentityFile != "" {
+		args = append(args, "-i", h.SSH.IdentityFile)
+	}
+	for _, opt := range h.SSH.Options {
+		args = append(args, "-o", opt)
+	}
+	return args
+}
+
+// sync copies the given local paths to the same absolute paths on the host.
+// Files only present on the host (e.g., node state) are kept and local log
+// files are skipped as they are produced by the remote nodes.
+func (h *TopologyHost) sync(paths ...string) error {
+	shell := append([]string{"ssh"}, h.sshArgs()...)
+	args := []string{
+		"-aR",
+		"-e", strings.Join(shell, " "),
+		"--exclude", logNodeFile,
+		"--exclude", logConsoleFile,
+	}
+	for _, path := range paths {
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			return err
+		}
+		args = append(args, abs)
+	}
+	args = append(args, h.SSH.Destination+":/")
+
+	cmd := exec.Command("rsync", args...)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("oasis: failed to sync to host %s: %w (output: %s)", h.Name, err, out)
+	}
+	return nil
+}
+
+// command returns a command that runs the given binary on the host, while
+// forwarding the given unix socket to the same path on the local machine.
+//
+// The remote process is terminated once the SSH connection is closed.
+func (h *TopologyHost) command(socketPath, binary string, args ...string) *exec.Cmd {
+	remoteCmd := make([]string, 0, len(args)+1)
+	for _, arg := range append([]string{binary}, args...) {
+		remoteCmd = append(remoteCmd, shellQuote(arg))
+	}
+	script := fmt.Sprintf(
+		"mkdir -p %s && rm -f %s && exec 3<&0 && { %s & pid=$!; (read _ <&3; kill -TERM $pid) & wait $pid; }",
+		shellQuote(filepath.Dir(socketPath)),
+		shellQuote(socketPath),
+		strings.Join(remoteCmd, " "),
+	)
+
+	sshArgs := append(h.sshArgs(),
+		"-o", "StreamLocalBindUnlink=yes",
+		"-o", "ExitOnForwardFailure=yes",
+		"-L", socketPath+":"+socketPath,
+		h.SSH.Destination,
+		"--", "sh", "-c", shellQuote(script),
+	)
+	return exec.Command("ssh", sshArgs...)
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,92 @@
+package oasis
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTopology(t *testing.T) {
+	require := require.New(t)
+
+	topology := &Topology{
+		RunnerAddress: "10.0.0.1",
+		Hosts: []*TopologyHost{
+			{
+				Name:    "a",
+				Address: "10.0.0.2",
+				SSH:     SSHCfg{Destination: "user@a"},
+				Nodes:   []string{"validator-0", "compute-0"},
+			},
+			{
+				Name:    "b",
+				Address: "10.0.0.3",
+				SSH:     SSHCfg{Destination: "user@b"},
+				Nodes:   []string{"validator-1"},
+			},
+		},
+	}
+	require.NoError(topology.Validate(), "Validate")
+	require.Equal("a", topology.Host("compute-0").name())
+	require.Equal("b", topology.Host("validator-1").name())
+	require.Nil(topology.Host("client-0"), "unassigned nodes should be spawned locally")
+	require.Equal("local", topology.Host("client-0").name())
+
+	var nilTopology *Topology
+	require.Nil(nilTopology.Host("validator-0"))
+
+	topology.Hosts[1].Nodes = append(topology.Hosts[1].Nodes, "validator-0")
+	require.Error(topology.Validate(), "nodes assigned to multiple hosts should be rejected")
+	topology.Hosts[1].Nodes = []string{"validator-1"}
+
+	topology.Hosts[1].Name = "a"
+	require.Error(topology.Validate(), "duplicate host names should be rejected")
+	topology.Hosts[1].Name = "b"
+
+	topology.Hosts[1].Address = "b.example.com"
+	require.Error(topology.Validate(), "malformed host addresses should be rejected")
+	topology.Hosts[1].Address = "10.0.0.3"
+
+	topology.RunnerAddress = ""
+	require.Error(topology.Validate(), "missing runner address should be rejected")
+}
+
+func TestLoadTopology(t *testing.T) {
+	require := require.New(t)
+
+	fn := filepath.Join(t.TempDir(), "topology.json")
+	err := os.WriteFile(fn, []byte(`{
+		"runner_address": "10.0.0.1",
+		"hosts": [{
+			"name": "a",
+			"address": "10.0.0.2",
+			"ssh": {"destination": "user@a", "port": 2222, "options": ["StrictHostKeyChecking=no"]},
+			"nodes": ["validator-0"]
+		}]
+	}`), 0o600)
+	require.NoError(err, "WriteFile")
+
+	topology, err := LoadTopology(fn)
+	require.NoError(err, "LoadTopology")
+	host := topology.Host("validator-0")
+	require.NotNil(host)
+	require.Equal(
+		[]string{"-o", "BatchMode=yes", "-p", "2222", "-o", "StrictHostKeyChecking=no"},
+		host.sshArgs(),
+	)
+
+	err = os.WriteFile(fn, []byte(`{"hosts": [{"name": "a"}]}`), 0o600)
+	require.NoError(err, "WriteFile")
+	_, err = LoadTopology(fn)
+	require.Error(err, "invalid topologies should be rejected")
+}
+
+func TestShellQuote(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal(`'plain'`, shellQuote("plain"))
+	require.Equal(`'it'\''s'`, shellQuote("it's"))
+	require.Equal(`'$HOME && rm'`, shellQuote("$HOME && rm"))
+}
@@ -83,7 +83,7 @@ func (val *Validator) ModifyConfig() error {
 	val.Config.Consensus.Validator = true
 
 	val.Config.Consensus.ListenAddress = allInterfacesAddr + ":" + strconv.Itoa(int(val.consensusPort))
-	val.Config.Consensus.ExternalAddress = localhostAddr + ":" + strconv.Itoa(int(val.consensusPort))
+	val.Config.Consensus.ExternalAddress = "tcp://" + val.hostIP() + ":" + strconv.Itoa(int(val.consensusPort))
 
 	if val.supplementarySanityInterval > 0 {
 		val.Config.Consensus.SupplementarySanity.Enabled = true
@@ -115,34 +115,43 @@ func (net *Network) NewValidator(cfg *ValidatorCfg) (*Validator, error) {
 		return nil, err
 	}
 
+	consensusPort, err := host.getProvisionedPort(nodePortConsensus)
+	if err != nil {
+		return nil, fmt.Errorf("oasis/validator: %w", err)
+	}
+	p2pPort, err := host.getProvisionedPort(nodePortP2P)
+	if err != nil {
+		return nil, fmt.Errorf("oasis/validator: %w", err)
+	}
+
 	val := &Validator{
 		Node:          host,
 		sentries:      cfg.Sentries,
-		consensusPort: host.getProvisionedPort(nodePortConsensus),
-		p2pPort:       host.getProvisionedPort(nodePortP2P),
+		consensusPort: consensusPort,
+		p2pPort:       p2pPort,
 	}
 
 	var consensusAddrs []interface{ String() string }
-	localhost := netPkg.ParseIP("127.0.0.1")
+	hostIP := netPkg.ParseIP(host.hostIP())
 	if len(val.sentries) > 0 {
 		for _, sentry := range val.sentries {
 			var consensusAddr node.ConsensusAddress
 			consensusAddr.ID = sentry.p2pPublicKey
-			if err = consensusAddr.Address.FromIP(localhost, sentry.consensusPort); err != nil {
+			if err = consensusAddr.Address.FromIP(netPkg.ParseIP(sentry.hostIP()), sentry.consensusPort); err != nil {
 				return nil, fmt.Errorf("oasis/validator: failed to parse sentry IP address: %w", err)
 			}
 			consensusAddrs = append(consensusAddrs, &consensusAddr)
 		}
 	} else {
 		var consensusAddr node.Address
-		if err = consensusAddr.FromIP(localhost, val.consensusPort); err != nil {
+		if err = consensusAddr.FromIP(hostIP, val.consensusPort); err != nil {
 			return nil, fmt.Errorf("oasis/validator: failed to parse consensus IP address: %w", err)
 		}
 		consensusAddrs = append(consensusAddrs, &consensusAddr)
 	}
 
 	var p2pAddr node.Address
-	if err = p2pAddr.FromIP(localhost, val.p2pPort); err != nil {
+	if err = p2pAddr.FromIP(hostIP, val.p2pPort); err != nil {
 		return nil, fmt.Errorf("oasis/validator: failed to parse P2P IP address: %w", err)
 	}
 
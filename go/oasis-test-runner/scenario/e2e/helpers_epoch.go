@@ -0,0 +1,112 @@
+package e2e
+
+import (
+	"context"
+	"fmt"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+)
+
+// mockEpochBlocks is the number of blocks after which the current epoch is considered to be over
+// when the mock beacon is used, as epochs only advance when a scenario explicitly triggers an
+// epoch transition.
+const mockEpochBlocks = 10
+
+// EpochCondition is a condition checked by epoch-based assertions at the given epoch.
+type EpochCondition func(ctx context.Context, epoch beacon.EpochTime) (bool, error)
+
+// EventuallyByEpoch checks the given condition after every consensus block and returns as soon
+// as it holds. An error is returned if the condition does not hold by the end of the given epoch.
+//
+// When the mock beacon is used, the epoch is considered to be over after a fixed number of blocks
+// in case the scenario does not trigger the next epoch transition.
+func (sc *Scenario) EventuallyByEpoch(ctx context.Context, epoch beacon.EpochTime, name string, cond EpochCondition) error {
+	sc.Logger.Info("waiting for condition to hold",
+		"condition", name,
+		"by_epoch", epoch,
+	)
+
+	return sc.forEachBlockEpoch(ctx, func(current beacon.EpochTime, over bool) (bool, error) {
+		if current > epoch || (current == epoch && over) {
+			return false, fmt.Errorf("%s: condition did not hold by epoch %d", name, epoch)
+		}
+
+		ok, err := cond(ctx, current)
+		if err != nil {
+			return false, fmt.Errorf("%s: failed to check condition: %w", name, err)
+		}
+		return ok, nil
+	})
+}
+
+// ConsistentlyForEpochs waits for the first epoch and then checks the given condition after every
+// consensus block until the end of the last epoch. An error is returned as soon as the condition
+// does not hold.
+//
+// When the mock beacon is used, the scenario must trigger epoch transitions up to the last epoch,
+// which is then considered to be over after a fixed number of blocks.
+func (sc *Scenario) ConsistentlyForEpochs(ctx context.Context, first, last beacon.EpochTime, name string, cond EpochCondition) error {
+	sc.Logger.Info("checking that condition holds",
+		"condition", name,
+		"first_epoch", first,
+		"last_epoch", last,
+	)
+
+	return sc.forEachBlockEpoch(ctx, func(current beacon.EpochTime, over bool) (bool, error) {
+		switch {
+		case current < first:
+			return false, nil
+		case current > last || (current == last && over):
+			return true, nil
+		}
+
+		ok, err := cond(ctx, current)
+		if err != nil {
+			return false, fmt.Errorf("%s: failed to check condition: %w", name, err)
+		}
+		if !ok {
+			return false, fmt.Errorf("%s: condition does not hold at epoch %d", name, current)
+		}
+		return false, nil
+	})
+}
+
+// forEachBlockEpoch invokes the given function after every consensus block with the epoch at the
+// block height and a flag indicating whether the epoch should be considered over, until the
+// function returns true or an error.
+func (sc *Scenario) forEachBlockEpoch(ctx context.Context, fn func(epoch beacon.EpochTime, over bool) (bool, error)) error {
+	ctrl := sc.Net.ClientController()
+	mock := sc.Net.Config().Beacon.DebugMockBackend
+
+	blockCh, blockSub, err := ctrl.Consensus.WatchBlocks(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to watch blocks: %w", err)
+	}
+	defer blockSub.Close()
+
+	var (
+		lastEpoch   beacon.EpochTime
+		epochBlocks int
+	)
+	for {
+		select {
+		case blk := <-blockCh:
+			epoch, err := ctrl.Beacon.GetEpoch(ctx, blk.Height)
+			if err != nil {
+				return fmt.Errorf("failed to get epoch at height %d: %w", blk.Height, err)
+			}
+			if epoch != lastEpoch {
+				lastEpoch = epoch
+				epochBlocks = 0
+			}
+			epochBlocks++
+
+			done, err := fn(epoch, mock && epochBlocks > mockEpochBlocks)
+			if err != nil || done {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
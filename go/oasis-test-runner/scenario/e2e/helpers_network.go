@@ -280,6 +280,9 @@ func (sc *Scenario) finishWithoutChild() error {
 	case err = <-sc.Net.Errors():
 		return err
 	default:
+		if err = sc.Net.CheckResourceLimits(); err != nil {
+			return err
+		}
 		return sc.Net.CheckLogWatchers()
 	}
 }
@@ -31,7 +31,7 @@ func (sc *identityCLIImpl) Clone() scenario.Scenario {
 	}
 }
 
-func (sc *identityCLIImpl) PreInit() error {
+func (sc *identityCLIImpl) PreInit(*env.Env) error {
 	return nil
 }
 
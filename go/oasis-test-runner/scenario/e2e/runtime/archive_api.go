@@ -343,6 +343,10 @@ func (sc *archiveAPI) Run(ctx context.Context, childEnv *env.Env) error {
 	if err = sc.testArchiveAPI(ctx, valArchive, false, false); err != nil {
 		return fmt.Errorf("validator archive api: %w", err)
 	}
+	sc.Logger.Info("checking validator archive API parity")
+	if err = sc.checkArchiveParity(ctx, sc.Net.ClientController(), valArchive, false); err != nil {
+		return fmt.Errorf("validator archive api parity: %w", err)
+	}
 
 	// Convert a compute worker into an archive node.
 	sc.Logger.Info("converting compute worker 3 into an archive node")
@@ -361,6 +365,10 @@ func (sc *archiveAPI) Run(ctx context.Context, childEnv *env.Env) error {
 	if err = sc.testArchiveAPI(ctx, computeArchive, true, false); err != nil {
 		return fmt.Errorf("compute archive api: %w", err)
 	}
+	sc.Logger.Info("checking compute worker archive API parity")
+	if err = sc.checkArchiveParity(ctx, sc.Net.ClientController(), computeArchive, true); err != nil {
+		return fmt.Errorf("compute archive api parity: %w", err)
+	}
 
 	// Transition to halt epoch.
 	sc.Logger.Info("transitioning to halt epoch",
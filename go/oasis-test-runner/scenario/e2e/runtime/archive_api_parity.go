@@ -0,0 +1,239 @@
+package runtime
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/oasis"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+	"github.com/oasisprotocol/oasis-core/go/runtime/client/api"
+	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
+)
+
+// archiveParityQuery is a query that is issued against both a live node and an archive node
+// in order to check that both return the same result.
+type archiveParityQuery struct {
+	module string
+	method string
+	// at is the consensus height or the runtime round at which the query is issued.
+	at int64
+
+	query func(ctx context.Context, ctrl *oasis.Controller) (any, error)
+}
+
+func (q *archiveParityQuery) String() string {
+	return fmt.Sprintf("%s.%s@%d", q.module, q.method, q.at)
+}
+
+// archiveParityConsensusQueries returns the matrix of consensus layer queries at the given heights.
+func archiveParityConsensusQueries(heights []int64, runtimeIDs []common.Namespace) []*archiveParityQuery {
+	var queries []*archiveParityQuery
+	for _, height := range heights {
+		queries = append(queries,
+			// Consensus.
+			&archiveParityQuery{"consensus", "GetBlock", height, func(ctx context.Context, ctrl *oasis.Controller) (any, error) {
+				return ctrl.Consensus.GetBlock(ctx, height)
+			}},
+			&archiveParityQuery{"consensus", "GetLightBlock", height, func(ctx context.Context, ctrl *oasis.Controller) (any, error) {
+				return ctrl.Consensus.GetLightBlock(ctx, height)
+			}},
+			&archiveParityQuery{"consensus", "GetTransactionsWithResults", height, func(ctx context.Context, ctrl *oasis.Controller) (any, error) {
+				return ctrl.Consensus.GetTransactionsWithResults(ctx, height)
+			}},
+			&archiveParityQuery{"consensus", "GetParameters", height, func(ctx context.Context, ctrl *oasis.Controller) (any, error) {
+				return ctrl.Consensus.GetParameters(ctx, height)
+			}},
+			// Beacon.
+			&archiveParityQuery{"beacon", "GetEpoch", height, func(ctx context.Context, ctrl *oasis.Controller) (any, error) {
+				return ctrl.Beacon.GetEpoch(ctx, height)
+			}},
+			&archiveParityQuery{"beacon", "ConsensusParameters", height, func(ctx context.Context, ctrl *oasis.Controller) (any, error) {
+				return ctrl.Beacon.ConsensusParameters(ctx, height)
+			}},
+			// Registry.
+			&archiveParityQuery{"registry", "GetEntities", height, func(ctx context.Context, ctrl *oasis.Controller) (any, error) {
+				return ctrl.Registry.GetEntities(ctx, height)
+			}},
+			&archiveParityQuery{"registry", "GetNodes", height, func(ctx context.Context, ctrl *oasis.Controller) (any, error) {
+				return ctrl.Registry.GetNodes(ctx, height)
+			}},
+			&archiveParityQuery{"registry", "StateToGenesis", height, func(ctx context.Context, ctrl *oasis.Controller) (any, error) {
+				return ctrl.Registry.StateToGenesis(ctx, height)
+			}},
+			&archiveParityQuery{"registry", "GetEvents", height, func(ctx context.Context, ctrl *oasis.Controller) (any, error) {
+				return ctrl.Registry.GetEvents(ctx, height)
+			}},
+			// Staking.
+			&archiveParityQuery{"staking", "TotalSupply", height, func(ctx context.Context, ctrl *oasis.Controller) (any, error) {
+				return ctrl.Staking.TotalSupply(ctx, height)
+			}},
+			&archiveParityQuery{"staking", "CommonPool", height, func(ctx context.Context, ctrl *oasis.Controller) (any, error) {
+				return ctrl.Staking.CommonPool(ctx, height)
+			}},
+			&archiveParityQuery{"staking", "StateToGenesis", height, func(ctx context.Context, ctrl *oasis.Controller) (any, error) {
+				return ctrl.Staking.StateToGenesis(ctx, height)
+			}},
+			&archiveParityQuery{"staking", "GetEvents", height, func(ctx context.Context, ctrl *oasis.Controller) (any, error) {
+				return ctrl.Staking.GetEvents(ctx, height)
+			}},
+			// Scheduler.
+			&archiveParityQuery{"scheduler", "GetValidators", height, func(ctx context.Context, ctrl *oasis.Controller) (any, error) {
+				return ctrl.Scheduler.GetValidators(ctx, height)
+			}},
+			&archiveParityQuery{"scheduler", "ConsensusParameters", height, func(ctx context.Context, ctrl *oasis.Controller) (any, error) {
+				return ctrl.Scheduler.ConsensusParameters(ctx, height)
+			}},
+			// Governance.
+			&archiveParityQuery{"governance", "Proposals", height, func(ctx context.Context, ctrl *oasis.Controller) (any, error) {
+				return ctrl.Governance.Proposals(ctx, height)
+			}},
+			&archiveParityQuery{"governance", "ConsensusParameters", height, func(ctx context.Context, ctrl *oasis.Controller) (any, error) {
+				return ctrl.Governance.ConsensusParameters(ctx, height)
+			}},
+			// Roothash.
+			&archiveParityQuery{"roothash", "GetEvents", height, func(ctx context.Context, ctrl *oasis.Controller) (any, error) {
+				return ctrl.Roothash.GetEvents(ctx, height)
+			}},
+		)
+
+		for _, runtimeID := range runtimeIDs {
+			queries = append(queries,
+				&archiveParityQuery{"scheduler", "GetCommittees", height, func(ctx context.Context, ctrl *oasis.Controller) (any, error) {
+					return ctrl.Scheduler.GetCommittees(ctx, &scheduler.GetCommitteesRequest{
+						Height:    height,
+						RuntimeID: runtimeID,
+					})
+				}},
+				&archiveParityQuery{"roothash", "GetRuntimeState", height, func(ctx context.Context, ctrl *oasis.Controller) (any, error) {
+					return ctrl.Roothash.GetRuntimeState(ctx, &roothash.RuntimeRequest{
+						RuntimeID: runtimeID,
+						Height:    height,
+					})
+				}},
+			)
+		}
+	}
+	return queries
+}
+
+// archiveParityRuntimeQueries returns the matrix of runtime queries at the given rounds.
+func archiveParityRuntimeQueries(runtimeID common.Namespace, rounds []uint64) []*archiveParityQuery {
+	var queries []*archiveParityQuery
+	for _, round := range rounds {
+		queries = append(queries,
+			&archiveParityQuery{"runtime", "GetBlock", int64(round), func(ctx context.Context, ctrl *oasis.Controller) (any, error) {
+				return ctrl.RuntimeClient.GetBlock(ctx, &api.GetBlockRequest{RuntimeID: runtimeID, Round: round})
+			}},
+			&archiveParityQuery{"runtime", "GetTransactionsWithResults", int64(round), func(ctx context.Context, ctrl *oasis.Controller) (any, error) {
+				return ctrl.RuntimeClient.GetTransactionsWithResults(ctx, &api.GetTransactionsRequest{RuntimeID: runtimeID, Round: round})
+			}},
+			&archiveParityQuery{"runtime", "GetEvents", int64(round), func(ctx context.Context, ctrl *oasis.Controller) (any, error) {
+				return ctrl.RuntimeClient.GetEvents(ctx, &api.GetEventsRequest{RuntimeID: runtimeID, Round: round})
+			}},
+		)
+	}
+	return queries
+}
+
+// archiveParityPoints returns the first, middle and last point of the given range.
+func archiveParityPoints(first, last int64) []int64 {
+	switch {
+	case last <= first:
+		return []int64{last}
+	case last-first == 1:
+		return []int64{first, last}
+	default:
+		return []int64{first, first + (last-first)/2, last}
+	}
+}
+
+// checkArchiveParity issues a matrix of queries at several heights (and rounds, if runtime is
+// set) against both the live and the archive node and compares the results.
+//
+// All mismatches are collected and returned together.
+func (sc *archiveAPI) checkArchiveParity(ctx context.Context, liveCtrl, archiveCtrl *oasis.Controller, runtime bool) error {
+	liveStatus, err := liveCtrl.Consensus.GetStatus(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get live node consensus status: %w", err)
+	}
+	archiveStatus, err := archiveCtrl.Consensus.GetStatus(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get archive node consensus status: %w", err)
+	}
+
+	// Only query heights that are available on both nodes.
+	firstHeight := max(liveStatus.LastRetainedHeight, archiveStatus.LastRetainedHeight)
+	lastHeight := archiveStatus.LatestHeight
+	if lastHeight > liveStatus.LatestHeight {
+		return fmt.Errorf("archive node latest height (%d) is ahead of live node (%d)", lastHeight, liveStatus.LatestHeight)
+	}
+	heights := archiveParityPoints(firstHeight, lastHeight)
+
+	var runtimeIDs []common.Namespace
+	for _, rt := range sc.Net.Runtimes() {
+		runtimeIDs = append(runtimeIDs, rt.ID())
+	}
+	queries := archiveParityConsensusQueries(heights, runtimeIDs)
+
+	if runtime {
+		var firstBlk, lastBlk *block.Block
+		firstBlk, err = archiveCtrl.RuntimeClient.GetLastRetainedBlock(ctx, KeyValueRuntimeID)
+		if err != nil {
+			return fmt.Errorf("failed to get archive node last retained runtime block: %w", err)
+		}
+		lastBlk, err = archiveCtrl.RuntimeClient.GetBlock(ctx, &api.GetBlockRequest{RuntimeID: KeyValueRuntimeID, Round: api.RoundLatest})
+		if err != nil {
+			return fmt.Errorf("failed to get archive node latest runtime block: %w", err)
+		}
+
+		var rounds []uint64
+		for _, round := range archiveParityPoints(int64(firstBlk.Header.Round), int64(lastBlk.Header.Round)) {
+			rounds = append(rounds, uint64(round))
+		}
+		queries = append(queries, archiveParityRuntimeQueries(KeyValueRuntimeID, rounds)...)
+	}
+
+	sc.Logger.Info("checking archive API parity",
+		"heights", heights,
+		"num_queries", len(queries),
+	)
+
+	var errs error
+	for _, q := range queries {
+		if err = compareArchiveParityQuery(ctx, q, liveCtrl, archiveCtrl); err != nil {
+			sc.Logger.Error("archive API parity mismatch",
+				"query", q.String(),
+				"err", err,
+			)
+			errs = errors.Join(errs, err)
+		}
+	}
+	return errs
+}
+
+func compareArchiveParityQuery(ctx context.Context, q *archiveParityQuery, liveCtrl, archiveCtrl *oasis.Controller) error {
+	liveRsp, liveErr := q.query(ctx, liveCtrl)
+	archiveRsp, archiveErr := q.query(ctx, archiveCtrl)
+
+	switch {
+	case liveErr != nil && archiveErr != nil:
+		if liveErr.Error() != archiveErr.Error() {
+			return fmt.Errorf("%s: error mismatch (live: %w, archive: %w)", q, liveErr, archiveErr)
+		}
+		return nil
+	case liveErr != nil:
+		return fmt.Errorf("%s: live node failed: %w", q, liveErr)
+	case archiveErr != nil:
+		return fmt.Errorf("%s: archive node failed: %w", q, archiveErr)
+	}
+
+	if !bytes.Equal(cbor.Marshal(liveRsp), cbor.Marshal(archiveRsp)) {
+		return fmt.Errorf("%s: response mismatch", q)
+	}
+	return nil
+}
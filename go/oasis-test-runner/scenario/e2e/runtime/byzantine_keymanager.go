@@ -0,0 +1,179 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/keymanager/secrets"
+	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/byzantine"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/env"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/oasis"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/scenario"
+)
+
+// byzantineKeymanagerMaxStatuses is the maximum number of key manager status
+// updates to wait for until the committee reaches the expected state.
+const byzantineKeymanagerMaxStatuses = 10
+
+var (
+	// ByzantineKeymanagerRefuseReplication is a scenario in which the Byzantine
+	// node joins the key manager committee, but refuses to serve any requests.
+	// A key manager started later needs to replicate secrets from honest nodes
+	// and compute workers need to fetch keys from honest nodes.
+	ByzantineKeymanagerRefuseReplication scenario.Scenario = newByzantineKeymanagerImpl(
+		"refuse-replication",
+		byzantine.ModeKeymanagerRefuseReplication,
+		0,
+	)
+	// ByzantineKeymanagerWrongChecksum is a scenario in which the Byzantine
+	// node registers with a wrong master secret checksum and should never be
+	// admitted to the key manager committee.
+	ByzantineKeymanagerWrongChecksum scenario.Scenario = newByzantineKeymanagerImpl(
+		"wrong-checksum",
+		byzantine.ModeKeymanagerWrongChecksum,
+		0,
+	)
+	// ByzantineKeymanagerStaleGeneration is a scenario in which the Byzantine
+	// node never registers with master secrets generated after it joined and
+	// should be removed from the key manager committee once secrets rotate.
+	ByzantineKeymanagerStaleGeneration scenario.Scenario = newByzantineKeymanagerImpl(
+		"stale-generation",
+		byzantine.ModeKeymanagerStaleGeneration,
+		1,
+	)
+)
+
+type byzantineKeymanagerImpl struct {
+	Scenario
+
+	mode             byzantine.KeymanagerMode
+	rotationInterval beacon.EpochTime
+}
+
+func newByzantineKeymanagerImpl(name string, mode byzantine.KeymanagerMode, rotationInterval beacon.EpochTime) scenario.Scenario {
+	return &byzantineKeymanagerImpl{
+		Scenario: *NewScenario(
+			"byzantine/keymanager/"+name,
+			NewTestClient().WithScenario(InsertRemoveEncWithSecretsScenario),
+		),
+		mode:             mode,
+		rotationInterval: rotationInterval,
+	}
+}
+
+func (sc *byzantineKeymanagerImpl) Clone() scenario.Scenario {
+	return &byzantineKeymanagerImpl{
+		Scenario:         *sc.Scenario.Clone().(*Scenario),
+		mode:             sc.mode,
+		rotationInterval: sc.rotationInterval,
+	}
+}
+
+func (sc *byzantineKeymanagerImpl) Fixture() (*oasis.NetworkFixture, error) {
+	f, err := sc.Scenario.Fixture()
+	if err != nil {
+		return nil, err
+	}
+
+	// Speed up the test.
+	f.Network.Beacon.VRFParameters = &beacon.VRFParameters{
+		Interval:             10,
+		ProofSubmissionDelay: 2,
+	}
+
+	// Two honest key managers, the last one is started once the Byzantine node
+	// joined the committee and needs to replicate secrets.
+	f.Keymanagers = []oasis.KeymanagerFixture{
+		{Runtime: 0, Entity: 1, Policy: 0},
+		{Runtime: 0, Entity: 1, Policy: 0},
+		{Runtime: 0, Entity: 1, Policy: 0, NodeFixture: oasis.NodeFixture{NoAutoStart: true}},
+	}
+	f.KeymanagerPolicies[0].MasterSecretRotationInterval = sc.rotationInterval
+
+	f.ByzantineNodes = []oasis.ByzantineFixture{
+		{
+			Script: "keymanager",
+			ExtraArgs: []oasis.Argument{
+				{Name: byzantine.CfgRuntimeID, Values: []string{KeyManagerRuntimeID.Hex()}},
+				{Name: byzantine.CfgKeymanagerMode, Values: []string{sc.mode.String()}},
+			},
+			IdentitySeed: oasis.ByzantineDefaultIdentitySeed,
+			Entity:       1,
+			Runtime:      -1,
+		},
+	}
+
+	return f, nil
+}
+
+func (sc *byzantineKeymanagerImpl) Run(ctx context.Context, childEnv *env.Env) error {
+	if err := sc.StartNetworkAndTestClient(ctx, childEnv); err != nil {
+		return err
+	}
+
+	byzID := sc.Net.Byzantine()[0].NodeID
+	honestIDs := []signature.PublicKey{
+		sc.Net.Keymanagers()[0].NodeID,
+		sc.Net.Keymanagers()[1].NodeID,
+	}
+
+	switch sc.mode {
+	case byzantine.ModeKeymanagerRefuseReplication:
+		// The node mirrors the key manager status, so it should be admitted.
+		if _, err := sc.waitByzantineKeymanager(ctx, byzID, true); err != nil {
+			return err
+		}
+
+		// Start the remaining key manager, which can only replicate secrets
+		// from the honest nodes.
+		if err := sc.StartAndWaitKeymanagers(ctx, []int{2}); err != nil {
+			return err
+		}
+		honestIDs = append(honestIDs, sc.Net.Keymanagers()[2].NodeID)
+	case byzantine.ModeKeymanagerWrongChecksum:
+		// Give the node a chance to register and make sure it is never admitted.
+		if _, err := sc.waitKeymanagerStatuses(ctx, 3); err != nil {
+			return err
+		}
+	case byzantine.ModeKeymanagerStaleGeneration:
+		// Rotate secrets a few times so that the registered checksum gets stale.
+		if _, err := sc.WaitMasterSecret(ctx, 3); err != nil {
+			return fmt.Errorf("master secret not generated: %w", err)
+		}
+	}
+
+	status, err := sc.waitByzantineKeymanager(ctx, byzID, sc.mode == byzantine.ModeKeymanagerRefuseReplication)
+	if err != nil {
+		return err
+	}
+	for _, id := range honestIDs {
+		if !slices.Contains(status.Nodes, id) {
+			return fmt.Errorf("honest node %s missing from key manager committee", id)
+		}
+	}
+
+	// Compute workers should keep fetching keys from the honest key managers.
+	return sc.WaitTestClientAndCheckLogs(ctx)
+}
+
+// waitByzantineKeymanager waits until the Byzantine node's key manager committee
+// membership matches the expected one.
+func (sc *byzantineKeymanagerImpl) waitByzantineKeymanager(ctx context.Context, byzID signature.PublicKey, member bool) (*secrets.Status, error) {
+	sc.Logger.Info("waiting for byzantine key manager committee membership",
+		"member", member,
+	)
+
+	for i := 0; i < byzantineKeymanagerMaxStatuses; i++ {
+		status, err := sc.waitKeymanagerStatuses(ctx, 1)
+		if err != nil {
+			return nil, err
+		}
+		if slices.Contains(status.Nodes, byzID) == member {
+			return status, nil
+		}
+	}
+	return nil, fmt.Errorf("byzantine key manager committee membership not reached (member: %t)", member)
+}
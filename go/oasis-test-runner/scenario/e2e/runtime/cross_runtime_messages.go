@@ -0,0 +1,156 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/env"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/oasis"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/scenario"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+)
+
+const (
+	// crossRuntimeMessagesRoundTrips is the number of message round trips between runtimes.
+	crossRuntimeMessagesRoundTrips = 3
+	// crossRuntimeMessagesBatchSize is the number of messages sent in each direction
+	// per round trip.
+	crossRuntimeMessagesBatchSize = 3
+	// crossRuntimeMessagesNonceBase is the first runtime transaction nonce used by
+	// the scenario, chosen so that it doesn't clash with other transactions.
+	crossRuntimeMessagesNonceBase = 1000
+)
+
+// CrossRuntimeMessages is the cross-runtime message passing scenario.
+//
+// Two compute runtimes exchange messages via the consensus layer, where the output of one
+// runtime is submitted as an incoming message to the other one and vice versa. The scenario
+// verifies that incoming messages are processed in order and that replayed messages are
+// rejected.
+var CrossRuntimeMessages scenario.Scenario = &crossRuntimeMessagesImpl{
+	Scenario: *NewScenario("cross-runtime-messages", nil),
+}
+
+type crossRuntimeMessagesImpl struct {
+	Scenario
+
+	otherRuntimeID common.Namespace
+	nonce          uint64
+}
+
+func (sc *crossRuntimeMessagesImpl) Clone() scenario.Scenario {
+	return &crossRuntimeMessagesImpl{
+		Scenario: *sc.Scenario.Clone().(*Scenario),
+	}
+}
+
+func (sc *crossRuntimeMessagesImpl) Fixture() (*oasis.NetworkFixture, error) {
+	f, err := sc.Scenario.Fixture()
+	if err != nil {
+		return nil, err
+	}
+
+	if sc.otherRuntimeID, err = sc.AddComputeRuntime(f); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+func (sc *crossRuntimeMessagesImpl) Run(ctx context.Context, _ *env.Env) error {
+	if err := sc.StartNetworkAndWaitForClientSync(ctx); err != nil {
+		return err
+	}
+
+	sc.nonce = crossRuntimeMessagesNonceBase
+	submitter := sc.NewInMsgSubmitter("cross-runtime")
+
+	// Bounce messages between the runtimes, each runtime acting on the state produced
+	// by the other one.
+	value := "ping"
+	for i := 0; i < crossRuntimeMessagesRoundTrips; i++ {
+		var err error
+		if value, err = sc.forward(ctx, submitter, KeyValueRuntimeID, i, value); err != nil {
+			return err
+		}
+		if value, err = sc.forward(ctx, submitter, sc.otherRuntimeID, i, value); err != nil {
+			return err
+		}
+	}
+
+	// Replayed SubmitMsg transactions should be rejected by the consensus layer.
+	if err := submitter.ReplayLast(ctx); err != nil {
+		return err
+	}
+
+	// Replayed runtime transactions carried by new incoming messages should be processed
+	// by the consensus layer, but rejected by the runtime.
+	key := crossRuntimeMessageKey(crossRuntimeMessagesRoundTrips-1, crossRuntimeMessagesBatchSize-1)
+	if _, err := submitter.Submit(ctx, sc.otherRuntimeID, []*InMsg{
+		{
+			Tag: crossRuntimeMessagesRoundTrips * crossRuntimeMessagesBatchSize,
+			Call: TxnCall{
+				Nonce:  sc.nonce - 1,
+				Method: "insert",
+				Args: InsertCall{
+					Key:   key,
+					Value: "replayed",
+				},
+			},
+		},
+	}); err != nil {
+		return err
+	}
+	replayed, err := sc.submitKeyValueRuntimeGetQuery(ctx, sc.otherRuntimeID, key, roothash.RoundLatest)
+	if err != nil {
+		return err
+	}
+	if replayed != value {
+		return fmt.Errorf("replayed runtime transaction was not rejected (got: %s expected: %s)", replayed, value)
+	}
+
+	return sc.Net.CheckLogWatchers()
+}
+
+// forward submits a batch of incoming messages derived from the given value to the runtime,
+// and returns the value of the last inserted key as read back from the runtime.
+func (sc *crossRuntimeMessagesImpl) forward(ctx context.Context, submitter *InMsgSubmitter, id common.Namespace, roundTrip int, value string) (string, error) {
+	msgs := make([]*InMsg, 0, crossRuntimeMessagesBatchSize)
+	for j := 0; j < crossRuntimeMessagesBatchSize; j++ {
+		msgs = append(msgs, &InMsg{
+			Tag: uint64(roundTrip*crossRuntimeMessagesBatchSize + j),
+			Call: TxnCall{
+				Nonce:  sc.nonce,
+				Method: "insert",
+				Args: InsertCall{
+					Key:   crossRuntimeMessageKey(roundTrip, j),
+					Value: fmt.Sprintf("%s -> %s", value, id),
+				},
+			},
+		})
+		sc.nonce++
+	}
+	if _, err := submitter.Submit(ctx, id, msgs); err != nil {
+		return "", err
+	}
+
+	// Read back the forwarded state, making sure that all messages were executed.
+	var rsp string
+	for j := 0; j < crossRuntimeMessagesBatchSize; j++ {
+		var err error
+		expected := msgs[j].Call.Args.(InsertCall).Value
+		if rsp, err = sc.submitKeyValueRuntimeGetQuery(ctx, id, crossRuntimeMessageKey(roundTrip, j), roothash.RoundLatest); err != nil {
+			return "", err
+		}
+		if rsp != expected {
+			return "", fmt.Errorf("unexpected value in runtime %s (got: %s expected: %s)", id, rsp, expected)
+		}
+	}
+
+	return rsp, nil
+}
+
+func crossRuntimeMessageKey(roundTrip, idx int) string {
+	return fmt.Sprintf("cross_runtime_msg_%d_%d", roundTrip, idx)
+}
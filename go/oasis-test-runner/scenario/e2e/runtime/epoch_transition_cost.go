@@ -0,0 +1,320 @@
+package runtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/env"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/oasis"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/scenario"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
+)
+
+const (
+	// cfgNumValidators is the number of validators.
+	cfgNumValidators = "num_validators"
+	// cfgNumEpochTransitions is the number of measured epoch transitions.
+	cfgNumEpochTransitions = "num_epoch_transitions"
+	// cfgSettleBlocks is the number of blocks following an epoch transition which are
+	// accounted to the transition.
+	cfgSettleBlocks = "settle_blocks"
+
+	epochTransitionCostSummaryFile = "epoch_transition_cost.json"
+)
+
+// EpochTransitionCost is a scenario which measures the wall-clock time and the consensus gas
+// consumed by epoch transitions for the configured number of validators and committee size.
+var EpochTransitionCost = func() scenario.Scenario {
+	sc := &epochTransitionCostImpl{
+		Scenario: *NewScenario("epoch-transition-cost", nil),
+	}
+	sc.Flags.Int(cfgNumValidators, 3, "number of validators")
+	sc.Flags.Int(cfgNumComputeWorkers, 3, "number of compute workers")
+	sc.Flags.Uint16(cfgExecutorGroupSize, 2, "number of executor workers in committee")
+	sc.Flags.Int(cfgNumEpochTransitions, 5, "number of measured epoch transitions")
+	sc.Flags.Int64(cfgSettleBlocks, 3, "number of blocks following a transition accounted to it")
+
+	return sc
+}()
+
+// EpochTransitionSample is a measurement of a single epoch transition.
+type EpochTransitionSample struct {
+	// Epoch is the epoch that was transitioned to.
+	Epoch beacon.EpochTime `json:"epoch"`
+	// Height is the height of the first block of the epoch.
+	Height int64 `json:"height"`
+	// Duration is the wall-clock time from requesting the transition until it was observed.
+	Duration time.Duration `json:"duration"`
+	// BlockInterval is the consensus time between the first block of the epoch and
+	// the block preceding it.
+	BlockInterval time.Duration `json:"block_interval"`
+	// CommitteeSize is the size of the executor committee elected for the epoch.
+	CommitteeSize int `json:"committee_size"`
+	// NumTxs is the number of transactions in the blocks accounted to the transition.
+	NumTxs int `json:"num_txs"`
+	// GasUsed is the gas used by transactions in the blocks accounted to the transition.
+	GasUsed uint64 `json:"gas_used"`
+}
+
+// EpochTransitionCostSummary is a summary of an epoch transition cost run.
+type EpochTransitionCostSummary struct {
+	// NumValidators is the number of validators.
+	NumValidators int `json:"num_validators"`
+	// NumComputeWorkers is the number of compute workers.
+	NumComputeWorkers int `json:"num_compute_workers"`
+	// ExecutorGroupSize is the size of the executor committee.
+	ExecutorGroupSize uint16 `json:"executor_group_size"`
+	// SettleBlocks is the number of blocks following a transition accounted to it.
+	SettleBlocks int64 `json:"settle_blocks"`
+	// Samples are the measurements of individual epoch transitions.
+	Samples []*EpochTransitionSample `json:"samples"`
+	// AvgDuration is the average wall-clock time of an epoch transition.
+	AvgDuration time.Duration `json:"avg_duration"`
+	// MaxDuration is the maximum wall-clock time of an epoch transition.
+	MaxDuration time.Duration `json:"max_duration"`
+	// AvgGasUsed is the average gas used per epoch transition.
+	AvgGasUsed uint64 `json:"avg_gas_used"`
+}
+
+// Write writes the summary to the given directory.
+func (s *EpochTransitionCostSummary) Write(dir string) error {
+	raw, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal epoch transition cost summary: %w", err)
+	}
+	if err = os.WriteFile(filepath.Join(dir, epochTransitionCostSummaryFile), raw, 0o600); err != nil {
+		return fmt.Errorf("failed to write epoch transition cost summary: %w", err)
+	}
+	return nil
+}
+
+func (s *EpochTransitionCostSummary) add(sample *EpochTransitionSample) {
+	s.Samples = append(s.Samples, sample)
+
+	var (
+		totalDuration time.Duration
+		totalGas      uint64
+	)
+	for _, sample := range s.Samples {
+		totalDuration += sample.Duration
+		totalGas += sample.GasUsed
+		if sample.Duration > s.MaxDuration {
+			s.MaxDuration = sample.Duration
+		}
+	}
+	s.AvgDuration = totalDuration / time.Duration(len(s.Samples))
+	s.AvgGasUsed = totalGas / uint64(len(s.Samples))
+}
+
+type epochTransitionCostImpl struct {
+	Scenario
+}
+
+func (sc *epochTransitionCostImpl) Clone() scenario.Scenario {
+	return &epochTransitionCostImpl{
+		Scenario: *sc.Scenario.Clone().(*Scenario),
+	}
+}
+
+func (sc *epochTransitionCostImpl) Fixture() (*oasis.NetworkFixture, error) {
+	f, err := sc.Scenario.Fixture()
+	if err != nil {
+		return nil, err
+	}
+
+	numValidators, _ := sc.Flags.GetInt(cfgNumValidators)
+	numComputeWorkers, _ := sc.Flags.GetInt(cfgNumComputeWorkers)
+	groupSize, _ := sc.Flags.GetUint16(cfgExecutorGroupSize)
+	switch {
+	case numValidators < 1:
+		return nil, fmt.Errorf("at least one validator is required")
+	case groupSize < 1:
+		return nil, fmt.Errorf("executor group size must be at least one")
+	case int(groupSize) > numComputeWorkers:
+		return nil, fmt.Errorf("executor group size (%d) exceeds the number of compute workers (%d)", groupSize, numComputeWorkers)
+	}
+
+	// Scale validators, keeping the configuration of the first one.
+	validators := []oasis.ValidatorFixture{f.Validators[0]}
+	for i := 1; i < numValidators; i++ {
+		validators = append(validators, oasis.ValidatorFixture{Entity: 1})
+	}
+	f.Validators = validators
+
+	// Scale compute workers.
+	computeWorkers := make([]oasis.ComputeWorkerFixture, 0, numComputeWorkers)
+	for i := 0; i < numComputeWorkers; i++ {
+		computeWorkers = append(computeWorkers, oasis.ComputeWorkerFixture{
+			RuntimeProvisioner: f.ComputeWorkers[0].RuntimeProvisioner,
+			Entity:             1,
+			Runtimes:           []int{1},
+		})
+	}
+	f.ComputeWorkers = computeWorkers
+
+	// Use the remaining compute workers as backups.
+	var backupSize uint16
+	if numComputeWorkers > int(groupSize) {
+		backupSize = 1
+	}
+	for i := range f.Runtimes {
+		if f.Runtimes[i].Kind != registry.KindCompute {
+			continue
+		}
+		f.Runtimes[i].Executor.GroupSize = groupSize
+		f.Runtimes[i].Executor.GroupBackupSize = backupSize
+		f.Runtimes[i].Constraints[scheduler.KindComputeExecutor][scheduler.RoleWorker].MinPoolSize.Limit = groupSize
+		f.Runtimes[i].Constraints[scheduler.KindComputeExecutor][scheduler.RoleBackupWorker].MinPoolSize.Limit = backupSize
+	}
+
+	return f, nil
+}
+
+func (sc *epochTransitionCostImpl) Run(ctx context.Context, childEnv *env.Env) error {
+	if err := sc.StartNetworkAndWaitForClientSync(ctx); err != nil {
+		return err
+	}
+
+	numTransitions, _ := sc.Flags.GetInt(cfgNumEpochTransitions)
+	settleBlocks, _ := sc.Flags.GetInt64(cfgSettleBlocks)
+	groupSize, _ := sc.Flags.GetUint16(cfgExecutorGroupSize)
+
+	summary := EpochTransitionCostSummary{
+		NumValidators:     len(sc.Net.Validators()),
+		NumComputeWorkers: len(sc.Net.ComputeWorkers()),
+		ExecutorGroupSize: groupSize,
+		SettleBlocks:      settleBlocks,
+	}
+
+	for i := 0; i < numTransitions; i++ {
+		sample, err := sc.measureEpochTransition(ctx, settleBlocks)
+		if err != nil {
+			return err
+		}
+
+		sc.Logger.Info("epoch transition measured",
+			"epoch", sample.Epoch,
+			"height", sample.Height,
+			"duration", sample.Duration,
+			"block_interval", sample.BlockInterval,
+			"committee_size", sample.CommitteeSize,
+			"num_txs", sample.NumTxs,
+			"gas_used", sample.GasUsed,
+		)
+		summary.add(sample)
+	}
+
+	sc.Logger.Info("epoch transition cost measured",
+		"num_validators", summary.NumValidators,
+		"num_compute_workers", summary.NumComputeWorkers,
+		"executor_group_size", summary.ExecutorGroupSize,
+		"avg_duration", summary.AvgDuration,
+		"max_duration", summary.MaxDuration,
+		"avg_gas_used", summary.AvgGasUsed,
+	)
+	if err := summary.Write(childEnv.Dir()); err != nil {
+		return fmt.Errorf("failed to record epoch transition cost summary: %w", err)
+	}
+
+	return sc.Net.CheckLogWatchers()
+}
+
+func (sc *epochTransitionCostImpl) measureEpochTransition(ctx context.Context, settleBlocks int64) (*EpochTransitionSample, error) {
+	ctrl := sc.Net.Controller()
+
+	epoch, err := ctrl.Beacon.GetEpoch(ctx, consensus.HeightLatest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current epoch: %w", err)
+	}
+	blk, err := ctrl.Consensus.GetBlock(ctx, consensus.HeightLatest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest block: %w", err)
+	}
+	startHeight := blk.Height
+	epoch++
+
+	sc.Logger.Info("triggering epoch transition",
+		"epoch", epoch,
+	)
+
+	start := time.Now()
+	if err = ctrl.SetEpoch(ctx, epoch); err != nil {
+		return nil, fmt.Errorf("failed to set epoch: %w", err)
+	}
+	sample := &EpochTransitionSample{
+		Epoch:    epoch,
+		Duration: time.Since(start),
+	}
+
+	if sample.Height, err = ctrl.Beacon.GetEpochBlock(ctx, epoch); err != nil {
+		return nil, fmt.Errorf("failed to get epoch block: %w", err)
+	}
+
+	rtState, err := ctrl.Roothash.GetRuntimeState(ctx, &roothash.RuntimeRequest{
+		RuntimeID: KeyValueRuntimeID,
+		Height:    sample.Height,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get runtime state: %w", err)
+	}
+	if rtState.Committee == nil || rtState.Committee.ValidFor != epoch {
+		return nil, fmt.Errorf("executor committee not elected for epoch %d", epoch)
+	}
+	sample.CommitteeSize = len(rtState.Committee.Members)
+
+	epochBlk, err := ctrl.Consensus.GetBlock(ctx, sample.Height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get epoch block: %w", err)
+	}
+	prevBlk, err := ctrl.Consensus.GetBlock(ctx, sample.Height-1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block preceding epoch block: %w", err)
+	}
+	sample.BlockInterval = epochBlk.Time.Sub(prevBlk.Time)
+
+	// Account all transactions from the transition request until the network settles,
+	// which includes any re-registrations triggered by the transition.
+	endHeight := sample.Height + settleBlocks
+	if err = sc.waitBlockHeight(ctx, endHeight); err != nil {
+		return nil, err
+	}
+	for height := startHeight + 1; height <= endHeight; height++ {
+		txs, err := ctrl.Consensus.GetTransactionsWithResults(ctx, height)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get transactions at height %d: %w", height, err)
+		}
+		sample.NumTxs += len(txs.Transactions)
+		for _, res := range txs.Results {
+			sample.GasUsed += res.GasUsed
+		}
+	}
+
+	return sample, nil
+}
+
+func (sc *epochTransitionCostImpl) waitBlockHeight(ctx context.Context, height int64) error {
+	blkCh, blkSub, err := sc.Net.Controller().Consensus.WatchBlocks(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to watch blocks: %w", err)
+	}
+	defer blkSub.Close()
+
+	for {
+		select {
+		case blk := <-blkCh:
+			if blk.Height >= height {
+				return nil
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,256 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/env"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/oasis"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/scenario"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+	runtimeClient "github.com/oasisprotocol/oasis-core/go/runtime/client/api"
+)
+
+const (
+	// executorPartitionRounds is the number of runtime rounds observed while partitioned.
+	executorPartitionRounds = 3
+	// executorPartitionTimeout is the maximum time to wait for a runtime block while partitioned.
+	executorPartitionTimeout = 2 * time.Minute
+	// executorPartitionSyncTimeout is the maximum time for compute workers to catch up after
+	// the partition is healed.
+	executorPartitionSyncTimeout = 2 * time.Minute
+)
+
+// ExecutorPartition is the executor committee network partition scenario.
+//
+// Half of the compute workers are isolated from the rest of the P2P network mid-round, which
+// should make the runtime either time out rounds or proceed with the help of backup workers.
+// After the partition is healed, the runtime should resume without a round gap and with all
+// compute workers agreeing on the state.
+var ExecutorPartition scenario.Scenario = &executorPartitionImpl{
+	Scenario: *NewScenario("executor-partition", nil),
+}
+
+type executorPartitionImpl struct {
+	Scenario
+}
+
+func (sc *executorPartitionImpl) Clone() scenario.Scenario {
+	return &executorPartitionImpl{
+		Scenario: *sc.Scenario.Clone().(*Scenario),
+	}
+}
+
+func (sc *executorPartitionImpl) Fixture() (*oasis.NetworkFixture, error) {
+	f, err := sc.Scenario.Fixture()
+	if err != nil {
+		return nil, err
+	}
+
+	// Use four compute workers so that half of them can be isolated, and make all of the
+	// workers not in the primary committee backup workers.
+	f.ComputeWorkers = append(f.ComputeWorkers, f.ComputeWorkers[0])
+	f.Runtimes[1].Executor.GroupBackupSize = 2
+
+	return f, nil
+}
+
+func (sc *executorPartitionImpl) Run(ctx context.Context, _ *env.Env) error {
+	if err := sc.StartNetworkAndWaitForClientSync(ctx); err != nil {
+		return err
+	}
+
+	blkCh, blkSub, err := sc.Net.Controller().Roothash.WatchBlocks(ctx, KeyValueRuntimeID)
+	if err != nil {
+		return err
+	}
+	defer blkSub.Close()
+
+	// Make sure the runtime is making progress before partitioning the network.
+	if _, err = sc.submitKeyValueRuntimeInsertTx(ctx, KeyValueRuntimeID, 0, "hello", "before partition", 0, 0, plaintextTxKind); err != nil {
+		return err
+	}
+	startBlk, err := sc.latestRuntimeBlock(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Isolate half of the compute workers.
+	var isolated []*oasis.Node
+	workers := sc.Net.ComputeWorkers()
+	for _, w := range workers[:len(workers)/2] {
+		isolated = append(isolated, w.Node)
+	}
+	if err = sc.Net.IsolateP2P(ctx, isolated); err != nil {
+		return err
+	}
+
+	// Submit a transaction mid-partition and observe how the runtime copes.
+	if err = sc.Net.ClientController().RuntimeClient.SubmitTxNoWait(ctx, &runtimeClient.SubmitTxRequest{
+		RuntimeID: KeyValueRuntimeID,
+		Data: cbor.Marshal(&TxnCall{
+			Nonce:  1,
+			Method: "insert",
+			Args: struct {
+				Key   string `json:"key"`
+				Value string `json:"value"`
+			}{
+				Key:   "partition",
+				Value: "during partition",
+			},
+		}),
+	}); err != nil {
+		return fmt.Errorf("failed to submit transaction during partition: %w", err)
+	}
+	if err = sc.observePartitionedRounds(blkCh, startBlk.Header.Round); err != nil {
+		return err
+	}
+
+	// Heal the partition and make sure the runtime resumes.
+	if err = sc.Net.HealP2P(ctx); err != nil {
+		return err
+	}
+	if _, err = sc.submitKeyValueRuntimeInsertTx(ctx, KeyValueRuntimeID, 2, "hello", "after partition", 0, 0, plaintextTxKind); err != nil {
+		return fmt.Errorf("runtime did not resume after the partition was healed: %w", err)
+	}
+	endBlk, err := sc.latestRuntimeBlock(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err = sc.checkRuntimeRounds(ctx, startBlk, endBlk.Header.Round); err != nil {
+		return err
+	}
+	if err = sc.waitComputeWorkersSynced(ctx, endBlk); err != nil {
+		return err
+	}
+
+	value, err := sc.submitKeyValueRuntimeGetQuery(ctx, KeyValueRuntimeID, "hello", endBlk.Header.Round)
+	if err != nil {
+		return err
+	}
+	if value != "after partition" {
+		return fmt.Errorf("unexpected value after partition: %s", value)
+	}
+
+	return sc.Net.CheckLogWatchers()
+}
+
+func (sc *executorPartitionImpl) latestRuntimeBlock(ctx context.Context) (*block.Block, error) {
+	return sc.Net.Controller().Roothash.GetLatestBlock(ctx, &roothash.RuntimeRequest{
+		RuntimeID: KeyValueRuntimeID,
+		Height:    consensus.HeightLatest,
+	})
+}
+
+// observePartitionedRounds waits for runtime rounds to progress while the network is partitioned,
+// either via timeouts or via backup workers.
+func (sc *executorPartitionImpl) observePartitionedRounds(ch <-chan *roothash.AnnotatedBlock, startRound uint64) error {
+	var numNormal, numFailed int
+	for numNormal+numFailed < executorPartitionRounds {
+		select {
+		case blk, ok := <-ch:
+			if !ok {
+				return fmt.Errorf("runtime block channel closed")
+			}
+			if blk.Block.Header.Round <= startRound {
+				continue
+			}
+
+			sc.Logger.Info("runtime block while partitioned",
+				"round", blk.Block.Header.Round,
+				"header_type", blk.Block.Header.HeaderType,
+			)
+
+			switch blk.Block.Header.HeaderType {
+			case block.Normal:
+				numNormal++
+			case block.RoundFailed:
+				numFailed++
+			default:
+			}
+		case <-time.After(executorPartitionTimeout):
+			return fmt.Errorf("runtime did not make progress while partitioned")
+		}
+	}
+
+	sc.Logger.Info("runtime made progress while partitioned",
+		"normal_rounds", numNormal,
+		"failed_rounds", numFailed,
+	)
+	return nil
+}
+
+// checkRuntimeRounds checks that there are no gaps in runtime rounds since the given block.
+func (sc *executorPartitionImpl) checkRuntimeRounds(ctx context.Context, startBlk *block.Block, endRound uint64) error {
+	sc.Logger.Info("checking runtime rounds",
+		"start_round", startBlk.Header.Round,
+		"end_round", endRound,
+	)
+
+	prev := startBlk
+	for round := startBlk.Header.Round + 1; round <= endRound; round++ {
+		blk, err := sc.Net.ClientController().RuntimeClient.GetBlock(ctx, &runtimeClient.GetBlockRequest{
+			RuntimeID: KeyValueRuntimeID,
+			Round:     round,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get runtime block for round %d: %w", round, err)
+		}
+		if blk.Header.Round != round {
+			return fmt.Errorf("runtime round gap: expected round %d, got %d", round, blk.Header.Round)
+		}
+		prevHash := prev.Header.EncodedHash()
+		if !blk.Header.PreviousHash.Equal(&prevHash) {
+			return fmt.Errorf("runtime block for round %d does not extend the previous block", round)
+		}
+		prev = blk
+	}
+	return nil
+}
+
+// waitComputeWorkersSynced waits for all compute workers to finalize the given block, which
+// ensures that their state did not diverge.
+func (sc *executorPartitionImpl) waitComputeWorkersSynced(ctx context.Context, blk *block.Block) error {
+	ctx, cancel := context.WithTimeout(ctx, executorPartitionSyncTimeout)
+	defer cancel()
+
+	for _, w := range sc.Net.ComputeWorkers() {
+		sc.Logger.Info("waiting for compute worker to sync",
+			"node", w.Name,
+			"round", blk.Header.Round,
+		)
+
+		ctrl, err := oasis.NewController(w.SocketPath())
+		if err != nil {
+			return err
+		}
+		err = func() error {
+			defer ctrl.Close()
+
+			for {
+				status, err := ctrl.GetStatus(ctx)
+				if err != nil {
+					return err
+				}
+				if rt, ok := status.Runtimes[KeyValueRuntimeID]; ok && rt.Storage != nil && rt.Storage.LastFinalizedRound >= blk.Header.Round {
+					return nil
+				}
+
+				select {
+				case <-ctx.Done():
+					return fmt.Errorf("compute worker %s failed to sync: %w", w.Name, ctx.Err())
+				case <-time.After(time.Second):
+				}
+			}
+		}()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
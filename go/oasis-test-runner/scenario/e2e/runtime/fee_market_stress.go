@@ -0,0 +1,308 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/env"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/oasis"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/scenario"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+const (
+	// cfgFeeStressNumSigners is the number of funded signers flooding the network.
+	cfgFeeStressNumSigners = "fee_stress_num_signers"
+	// cfgFeeStressTxsPerSigner is the number of transfers submitted by each signer.
+	cfgFeeStressTxsPerSigner = "fee_stress_txs_per_signer"
+	// cfgFeeStressMaxPriceMultiplier is the maximum multiple of the minimum gas price used.
+	cfgFeeStressMaxPriceMultiplier = "fee_stress_max_price_multiplier"
+	// cfgFeeStressNumRuntimeTxs is the number of runtime transactions submitted during the flood.
+	cfgFeeStressNumRuntimeTxs = "fee_stress_num_runtime_txs"
+
+	// feeStressSignerBalance is the genesis balance of each flooding signer.
+	feeStressSignerBalance = 100_000_000
+	// feeStressTransferGas is the gas cost of a transfer during the test.
+	feeStressTransferGas = 1000
+)
+
+// FeeMarketStress is the fee market stress scenario.
+//
+// It floods the consensus layer with transfers paying varying gas prices from many funded
+// signers, while making sure that the minimum gas price is enforced and that transactions
+// critical for the operation of the protocol (node registrations and executor commitments)
+// still make it into blocks.
+var FeeMarketStress = func() scenario.Scenario {
+	sc := &feeMarketStressImpl{
+		gasFeesRuntimesImpl: gasFeesRuntimesImpl{
+			Scenario: *NewScenario("gas-fees/stress", nil),
+		},
+	}
+	sc.Flags.Int(cfgFeeStressNumSigners, 8, "number of funded signers flooding the network")
+	sc.Flags.Int(cfgFeeStressTxsPerSigner, 25, "number of transfers submitted by each signer")
+	sc.Flags.Uint64(cfgFeeStressMaxPriceMultiplier, 10, "maximum multiple of the minimum gas price")
+	sc.Flags.Int(cfgFeeStressNumRuntimeTxs, 5, "number of runtime transactions submitted during the flood")
+
+	return sc
+}()
+
+type feeMarketStressImpl struct {
+	gasFeesRuntimesImpl
+}
+
+func (sc *feeMarketStressImpl) Clone() scenario.Scenario {
+	return &feeMarketStressImpl{
+		gasFeesRuntimesImpl: *sc.gasFeesRuntimesImpl.Clone().(*gasFeesRuntimesImpl),
+	}
+}
+
+func (sc *feeMarketStressImpl) signers() []signature.Signer {
+	numSigners, _ := sc.Flags.GetInt(cfgFeeStressNumSigners)
+
+	signers := make([]signature.Signer, 0, numSigners)
+	for i := 0; i < numSigners; i++ {
+		signers = append(signers, memorySigner.NewTestSigner(fmt.Sprintf("oasis fee market stress signer: %d", i)))
+	}
+	return signers
+}
+
+func (sc *feeMarketStressImpl) Fixture() (*oasis.NetworkFixture, error) {
+	f, err := sc.gasFeesRuntimesImpl.Fixture()
+	if err != nil {
+		return nil, err
+	}
+
+	// Enforce the minimum gas price at the consensus level so that underpriced transactions
+	// are rejected by every node, including the one we submit through.
+	f.Network.Consensus.Parameters.MinGasPrice = gasPrice
+	f.Network.StakingGenesis.Parameters.GasCosts = transaction.Costs{
+		staking.GasOpTransfer: feeStressTransferGas,
+	}
+
+	// Fund the flooding signers.
+	for _, signer := range sc.signers() {
+		f.Network.StakingGenesis.Ledger[staking.NewAddress(signer.Public())] = &staking.Account{
+			General: staking.GeneralAccount{
+				Balance: *quantity.NewFromUint64(feeStressSignerBalance),
+			},
+		}
+		_ = f.Network.StakingGenesis.TotalSupply.Add(quantity.NewFromUint64(feeStressSignerBalance))
+	}
+
+	return f, nil
+}
+
+func (sc *feeMarketStressImpl) Run(ctx context.Context, _ *env.Env) error {
+	if err := sc.Net.Start(); err != nil {
+		return err
+	}
+
+	// Wait for all nodes to be synced before we proceed.
+	if err := sc.WaitNodesSynced(ctx); err != nil {
+		return err
+	}
+
+	signers := sc.signers()
+	if len(signers) < 2 {
+		return fmt.Errorf("at least two signers are required")
+	}
+
+	if err := sc.testMinGasPrice(ctx, signers[0]); err != nil {
+		return err
+	}
+
+	// Record the initial state of all signer accounts.
+	initial := make([]*staking.Account, 0, len(signers))
+	for _, signer := range signers {
+		acct, err := sc.account(ctx, signer)
+		if err != nil {
+			return err
+		}
+		initial = append(initial, acct)
+	}
+
+	// Flood the network with transfers. Each signer transfers to the next one so that all
+	// balances only change by the fees paid once the flood is over.
+	sc.Logger.Info("starting transaction flood",
+		"num_signers", len(signers),
+	)
+
+	txsPerSigner, _ := sc.Flags.GetInt(cfgFeeStressTxsPerSigner)
+	fees := make([]quantity.Quantity, len(signers))
+	errCh := make(chan error, len(signers))
+	var wg sync.WaitGroup
+	for i, signer := range signers {
+		wg.Add(1)
+		go func(i int, signer signature.Signer) {
+			defer wg.Done()
+
+			to := staking.NewAddress(signers[(i+1)%len(signers)].Public())
+			paid, err := sc.flood(ctx, signer, initial[i].General.Nonce, to, txsPerSigner)
+			if err != nil {
+				errCh <- fmt.Errorf("signer %d: %w", i, err)
+				return
+			}
+			fees[i] = *paid
+		}(i, signer)
+	}
+
+	// While the flood is in progress, make sure that runtime rounds still get finalized which
+	// requires executor commitments to be included in blocks.
+	numRuntimeTxs, _ := sc.Flags.GetInt(cfgFeeStressNumRuntimeTxs)
+	for i := 0; i < numRuntimeTxs; i++ {
+		key := fmt.Sprintf("fee-stress-%d", i)
+		if _, err := sc.submitKeyValueRuntimeInsertTx(ctx, KeyValueRuntimeID, uint64(i), key, "flooded world", 0, 0, plaintextTxKind); err != nil {
+			return fmt.Errorf("failed to submit runtime transaction during flood: %w", err)
+		}
+	}
+
+	wg.Wait()
+	close(errCh)
+	if err := <-errCh; err != nil {
+		return err
+	}
+
+	sc.Logger.Info("transaction flood finished")
+
+	// Make sure that all transactions were executed and that fees were charged.
+	for i, signer := range signers {
+		acct, err := sc.account(ctx, signer)
+		if err != nil {
+			return err
+		}
+
+		if expected := initial[i].General.Nonce + uint64(txsPerSigner); acct.General.Nonce != expected {
+			return fmt.Errorf("signer %d: unexpected nonce (expected: %d actual: %d)", i, expected, acct.General.Nonce)
+		}
+		expected := initial[i].General.Balance.Clone()
+		if err = expected.Sub(&fees[i]); err != nil {
+			return fmt.Errorf("signer %d: paid more fees than available: %w", i, err)
+		}
+		if acct.General.Balance.Cmp(expected) != 0 {
+			return fmt.Errorf("signer %d: unexpected balance (expected: %s actual: %s)", i, expected, acct.General.Balance)
+		}
+	}
+
+	return sc.checkNodesRegistered(ctx)
+}
+
+// testMinGasPrice makes sure that transactions paying less than the minimum gas price are
+// rejected.
+func (sc *feeMarketStressImpl) testMinGasPrice(ctx context.Context, signer signature.Signer) error {
+	sc.Logger.Info("testing minimum gas price enforcement")
+
+	acct, err := sc.account(ctx, signer)
+	if err != nil {
+		return err
+	}
+
+	fee := transaction.Fee{Gas: feeStressTransferGas}
+	_ = fee.Amount.FromUint64(gasPrice*feeStressTransferGas - 1)
+
+	xfer := staking.Transfer{To: staking.NewAddress(signer.Public())}
+	_ = xfer.Amount.FromUint64(1)
+
+	sigTx, err := transaction.Sign(signer, staking.NewTransferTx(acct.General.Nonce, &fee, &xfer))
+	if err != nil {
+		return fmt.Errorf("failed to sign transfer: %w", err)
+	}
+	err = sc.Net.Controller().Consensus.SubmitTx(ctx, sigTx)
+	if !errors.Is(err, transaction.ErrGasPriceTooLow) {
+		return fmt.Errorf("underpriced transaction should fail with gas price too low (err: %w)", err)
+	}
+	return nil
+}
+
+// flood submits the given number of transfers from the given signer, each paying a random gas
+// price above the minimum, and returns the total amount of fees paid.
+func (sc *feeMarketStressImpl) flood(
+	ctx context.Context,
+	signer signature.Signer,
+	nonce uint64,
+	to staking.Address,
+	numTxs int,
+) (*quantity.Quantity, error) {
+	maxMultiplier, _ := sc.Flags.GetUint64(cfgFeeStressMaxPriceMultiplier)
+	rng := sc.Net.Env().Rand("fee market stress")
+
+	var total quantity.Quantity
+	for i := 0; i < numTxs; i++ {
+		price := gasPrice * (1 + rng.Uint64()%maxMultiplier)
+
+		fee := transaction.Fee{Gas: feeStressTransferGas}
+		_ = fee.Amount.FromUint64(price * feeStressTransferGas)
+
+		xfer := staking.Transfer{To: to}
+		_ = xfer.Amount.FromUint64(1)
+
+		sigTx, err := transaction.Sign(signer, staking.NewTransferTx(nonce, &fee, &xfer))
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign transfer: %w", err)
+		}
+
+		start := time.Now()
+		if err = sc.Net.Controller().Consensus.SubmitTx(ctx, sigTx); err != nil {
+			return nil, fmt.Errorf("failed to submit transfer (nonce: %d gas_price: %d): %w", nonce, price, err)
+		}
+		sc.Logger.Debug("transfer included",
+			"signer", signer.Public(),
+			"nonce", nonce,
+			"gas_price", price,
+			"latency", time.Since(start),
+		)
+
+		_ = total.Add(&fee.Amount)
+		nonce++
+	}
+
+	return &total, nil
+}
+
+// checkNodesRegistered makes sure that node registrations were not crowded out by the flood by
+// waiting for the next epoch and checking that all nodes are still registered.
+func (sc *feeMarketStressImpl) checkNodesRegistered(ctx context.Context) error {
+	ctrl := sc.Net.Controller()
+
+	epoch, err := ctrl.Beacon.GetEpoch(ctx, consensus.HeightLatest)
+	if err != nil {
+		return fmt.Errorf("failed to get current epoch: %w", err)
+	}
+	sc.Logger.Info("waiting for the next epoch",
+		"epoch", epoch+1,
+	)
+	if err = ctrl.Beacon.WaitEpoch(ctx, epoch+1); err != nil {
+		return fmt.Errorf("failed to wait for epoch: %w", err)
+	}
+
+	nodes, err := ctrl.Registry.GetNodes(ctx, consensus.HeightLatest)
+	if err != nil {
+		return fmt.Errorf("failed to get nodes: %w", err)
+	}
+	if n := sc.Net.NumRegisterNodes(); len(nodes) < n {
+		return fmt.Errorf("not all nodes are registered (expected: %d actual: %d)", n, len(nodes))
+	}
+	for _, n := range nodes {
+		if n.IsExpired(uint64(epoch + 1)) {
+			return fmt.Errorf("node %s registration expired during the flood", n.ID)
+		}
+	}
+
+	return nil
+}
+
+func (sc *feeMarketStressImpl) account(ctx context.Context, signer signature.Signer) (*staking.Account, error) {
+	addr := staking.NewAddress(signer.Public())
+	acct, err := sc.Net.Controller().Staking.Account(ctx, &staking.OwnerQuery{Owner: addr, Height: consensus.HeightLatest})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account %s: %w", addr, err)
+	}
+	return acct, nil
+}
@@ -165,6 +165,14 @@ func (sc *governanceConsensusUpgradeImpl) Fixture() (*oasis.NetworkFixture, erro
 			oasis.LogAssertNoUpgradeConsensus(),
 		)
 	}
+	if sc.shouldCancelUpgrade {
+		for i := range f.Validators {
+			f.Validators[i].LogWatcherHandlerFactories = append(
+				f.Validators[i].LogWatcherHandlerFactories,
+				oasis.LogAssertUpgradeCancelled(),
+			)
+		}
+	}
 	return f, nil
 }
 
@@ -295,6 +303,38 @@ func (sc *governanceConsensusUpgradeImpl) cancelUpgrade(ctx context.Context, pro
 		return fmt.Errorf("expected no pending upgrade, got: %v", l)
 	}
 
+	// Ensure nodes have automatically unscheduled the upgrade.
+	return sc.ensureLocalUpgrade(ctx, proposalID, false)
+}
+
+// ensureLocalUpgrade checks whether the upgrade scheduled by the given proposal is (or is not)
+// pending on all validators.
+func (sc *governanceConsensusUpgradeImpl) ensureLocalUpgrade(ctx context.Context, proposalID uint64, pending bool) error {
+	for _, v := range sc.Net.Validators() {
+		ctrl, err := oasis.NewController(v.SocketPath())
+		if err != nil {
+			return fmt.Errorf("failed to create controller for %s: %w", v.Name, err)
+		}
+		status, err := ctrl.GetStatus(ctx)
+		ctrl.Close()
+		if err != nil {
+			return fmt.Errorf("failed to get status for %s: %w", v.Name, err)
+		}
+
+		var found bool
+		for _, pu := range status.PendingUpgrades {
+			if pu.ProposalID == proposalID {
+				found = true
+				break
+			}
+		}
+		switch {
+		case pending && !found:
+			return fmt.Errorf("%s: expected upgrade scheduled by proposal %d to be pending", v.Name, proposalID)
+		case !pending && found:
+			return fmt.Errorf("%s: expected upgrade scheduled by proposal %d to be unscheduled", v.Name, proposalID)
+		}
+	}
 	return nil
 }
 
@@ -378,6 +418,11 @@ func (sc *governanceConsensusUpgradeImpl) Run(ctx context.Context, childEnv *env
 		return fmt.Errorf("expected one pending upgrade, got: %v", l)
 	}
 
+	// Ensure nodes have scheduled the upgrade and track its origin.
+	if err = sc.ensureLocalUpgrade(ctx, proposal.ID, true); err != nil {
+		return err
+	}
+
 	// Cancel upgrade if configured so.
 	if sc.shouldCancelUpgrade {
 		if err = sc.cancelUpgrade(ctx, proposal.ID); err != nil {
@@ -2,7 +2,6 @@ package runtime
 
 import (
 	"context"
-	"crypto/rand"
 	"fmt"
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
@@ -117,9 +116,7 @@ func (sc *Scenario) initialEpochTransitionsWith(ctx context.Context, fixture *oa
 			//
 			// If this causes your test to fail, it is not this code that is
 			// wrong, it is the test that is wrong.
-			var randByte [1]byte
-			_, _ = rand.Read(randByte[:])
-			numSkips := (int)(randByte[0]&3) + 1
+			numSkips := sc.Net.Env().Rand("initial epoch transitions").Intn(4) + 1
 			sc.Logger.Info("advancing the epoch to prevent hardcoding time assumptions in tests",
 				"num_advances", numSkips,
 			)
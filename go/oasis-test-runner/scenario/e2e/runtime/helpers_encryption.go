@@ -0,0 +1,163 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/oasisprotocol/curve25519-voi/primitives/x25519"
+	"github.com/oasisprotocol/deoxysii"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	mrae "github.com/oasisprotocol/oasis-core/go/common/crypto/mrae/deoxysii"
+	"github.com/oasisprotocol/oasis-core/go/keymanager/secrets"
+)
+
+const (
+	// encryptedCallMethod is the name of the test runtime method which dispatches
+	// encrypted calls.
+	encryptedCallMethod = "encrypted"
+
+	// encryptedCallContext is the additional data used when sealing encrypted calls.
+	encryptedCallContext = "simple-keyvalue: encrypted call"
+	// encryptedCallResultContext is the additional data used when sealing encrypted
+	// call results.
+	encryptedCallResultContext = "simple-keyvalue: encrypted call result"
+)
+
+// EncryptedCall is an encrypted call in the test runtime.
+type EncryptedCall struct {
+	KeyPairID secrets.KeyPairID `json:"key_pair_id"`
+	Epoch     beacon.EpochTime  `json:"epoch"`
+	PK        x25519.PublicKey  `json:"pk"`
+	Nonce     []byte            `json:"nonce"`
+	Data      []byte            `json:"data"`
+}
+
+// EncryptedCallResult is an encrypted call result in the test runtime.
+type EncryptedCallResult struct {
+	Nonce []byte `json:"nonce"`
+	Data  []byte `json:"data"`
+}
+
+// TxEncryption is used to encrypt runtime transactions with the runtime's ephemeral key,
+// obtained from the key manager.
+type TxEncryption struct {
+	keyPairID secrets.KeyPairID
+	epoch     beacon.EpochTime
+	runtimePK *x25519.PublicKey
+	rng       io.Reader
+}
+
+// txEnvelope is a sealed transaction call together with the ephemeral key needed to open
+// the result.
+type txEnvelope struct {
+	call *TxnCall
+	pk   *x25519.PublicKey
+	sk   *x25519.PrivateKey
+}
+
+// NewTxEncryption fetches the ephemeral public key of the given runtime for the given key pair
+// ID and epoch from the first key manager, and returns an encryption for runtime transactions.
+func (sc *Scenario) NewTxEncryption(ctx context.Context, id common.Namespace, keyPairID string, epoch beacon.EpochTime) (*TxEncryption, error) {
+	chainContext, err := sc.Net.Controller().Consensus.GetChainContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcClient, err := newKeyManagerRPCClient(chainContext, sc.Net.Env().Rand("key manager rpc clients"))
+	if err != nil {
+		return nil, err
+	}
+	defer rpcClient.host.Close()
+
+	kms := sc.Net.Keymanagers()
+	if len(kms) == 0 {
+		return nil, fmt.Errorf("no key managers available")
+	}
+	peerID, err := rpcClient.addKeyManagerAddrToHost(kms[0])
+	if err != nil {
+		return nil, err
+	}
+
+	// Derive key pair ID the same way as the test runtime.
+	h := hash.NewFromBytes([]byte(keyPairID))
+	kpID := secrets.KeyPairID(h)
+
+	pk, err := rpcClient.fetchRuntimeEphemeralPublicKeyWithRetry(ctx, id, kpID, epoch, peerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch ephemeral public key: %w", err)
+	}
+	if pk == nil {
+		return nil, fmt.Errorf("ephemeral public key for epoch %d not available", epoch)
+	}
+
+	return &TxEncryption{
+		keyPairID: kpID,
+		epoch:     epoch,
+		runtimePK: pk,
+		rng:       sc.Net.Env().Rand("tx encryption"),
+	}, nil
+}
+
+// seal seals the given call using a freshly generated ephemeral key.
+func (e *TxEncryption) seal(call *TxnCall) (*txEnvelope, error) {
+	pk, sk, err := x25519.GenerateKey(e.rng)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate ephemeral key: %w", err)
+	}
+
+	nonce := make([]byte, deoxysii.NonceSize)
+	if _, err = io.ReadFull(e.rng, nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	// The nonce of the outer call is the one checked by the runtime.
+	data := mrae.Box.Seal(nil, nonce, cbor.Marshal(call), []byte(encryptedCallContext), e.runtimePK, sk)
+
+	return &txEnvelope{
+		call: &TxnCall{
+			Nonce:  call.Nonce,
+			Method: encryptedCallMethod,
+			Args: EncryptedCall{
+				KeyPairID: e.keyPairID,
+				Epoch:     e.epoch,
+				PK:        *pk,
+				Nonce:     nonce,
+				Data:      data,
+			},
+		},
+		pk: e.runtimePK,
+		sk: sk,
+	}, nil
+}
+
+// open opens the raw transaction output of an encrypted call, returning the output of
+// the sealed call.
+func (env *txEnvelope) open(rawRsp []byte) ([]byte, error) {
+	var rsp TxnOutput
+	if err := cbor.Unmarshal(rawRsp, &rsp); err != nil {
+		return nil, fmt.Errorf("malformed tx output from runtime: %w", err)
+	}
+	if rsp.Error != nil {
+		return rawRsp, nil
+	}
+
+	var result EncryptedCallResult
+	if err := cbor.Unmarshal(rsp.Success, &result); err != nil {
+		return nil, fmt.Errorf("malformed encrypted call result: %w", err)
+	}
+	if len(result.Nonce) != deoxysii.NonceSize {
+		return nil, fmt.Errorf("malformed encrypted call result nonce")
+	}
+	data, err := mrae.Box.Open(nil, result.Nonce, result.Data, []byte(encryptedCallResultContext), env.pk, env.sk)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt call result: %w", err)
+	}
+	rsp.Success = data
+
+	return cbor.Marshal(rsp), nil
+}
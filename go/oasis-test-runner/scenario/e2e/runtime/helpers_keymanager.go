@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"path/filepath"
+	"slices"
 
 	"github.com/oasisprotocol/curve25519-voi/primitives/x25519"
 
@@ -97,6 +98,161 @@ func (sc *Scenario) WaitMasterSecret(ctx context.Context, generation uint64) (*s
 	}
 }
 
+// waitKeymanagerStatuses waits for the specified number of key manager status updates.
+func (sc *Scenario) waitKeymanagerStatuses(ctx context.Context, n int) (*secrets.Status, error) {
+	sc.Logger.Info("waiting for key manager status", "n", n)
+
+	stCh, stSub, err := sc.Net.Controller().Keymanager.Secrets().WatchStatuses(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer stSub.Close()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case status := <-stCh:
+			if !status.ID.Equal(&KeyManagerRuntimeID) {
+				continue
+			}
+			n--
+			if n <= 0 {
+				return status, nil
+			}
+		}
+	}
+}
+
+// WaitKeymanagerCommittee waits until the key manager committee consists exactly of
+// the specified key manager nodes.
+func (sc *Scenario) WaitKeymanagerCommittee(ctx context.Context, idxs []int) (*secrets.Status, error) {
+	sc.Logger.Info("waiting for key manager committee", "ids", fmt.Sprintf("%+v", idxs))
+
+	stCh, stSub, err := sc.Net.Controller().Keymanager.Secrets().WatchStatuses(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer stSub.Close()
+
+	kms := sc.Net.Keymanagers()
+	isCommittee := func(status *secrets.Status) bool {
+		if len(status.Nodes) != len(idxs) {
+			return false
+		}
+		for _, idx := range idxs {
+			if !slices.Contains(status.Nodes, kms[idx].NodeID) {
+				return false
+			}
+		}
+		return true
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case status := <-stCh:
+			if !status.ID.Equal(&KeyManagerRuntimeID) {
+				continue
+			}
+			if isCommittee(status) {
+				return status, nil
+			}
+		}
+	}
+}
+
+// ChurnKeymanagers starts the key manager nodes that should join the committee, waits until
+// they replicate the master secrets, stops the key manager nodes that should leave
+// the committee, and waits until the committee consists exactly of the remaining nodes.
+//
+// Returns the indices of the new committee members and the key manager status.
+func (sc *Scenario) ChurnKeymanagers(ctx context.Context, committee []int, join []int, leave []int) ([]int, *secrets.Status, error) {
+	sc.Logger.Info("churning the key manager committee",
+		"committee", fmt.Sprintf("%+v", committee),
+		"join", fmt.Sprintf("%+v", join),
+		"leave", fmt.Sprintf("%+v", leave),
+	)
+
+	// Start the joining key managers first so that the master secrets are replicated
+	// while the committee is changing.
+	if err := sc.StartAndWaitKeymanagers(ctx, join); err != nil {
+		return nil, nil, err
+	}
+	if err := sc.StopKeymanagers(leave); err != nil {
+		return nil, nil, err
+	}
+
+	var remaining []int
+	for _, idx := range append(slices.Clone(committee), join...) {
+		if !slices.Contains(leave, idx) && !slices.Contains(remaining, idx) {
+			remaining = append(remaining, idx)
+		}
+	}
+	status, err := sc.WaitKeymanagerCommittee(ctx, remaining)
+	if err != nil {
+		return nil, nil, err
+	}
+	return remaining, status, nil
+}
+
+// AddSpareKeymanagers adds the given number of key manager nodes, which are not started
+// automatically, to the fixture and returns their indices.
+//
+// Spare key managers are configured the same as the first key manager in the fixture and
+// can join the committee mid-run, e.g., via ChurnKeymanagers.
+func (sc *Scenario) AddSpareKeymanagers(f *oasis.NetworkFixture, n int) ([]int, error) {
+	if len(f.Keymanagers) == 0 {
+		return nil, fmt.Errorf("fixture has no key managers")
+	}
+
+	idxs := make([]int, 0, n)
+	for i := 0; i < n; i++ {
+		km := f.Keymanagers[0]
+		km.NoAutoStart = true
+		km.Name = ""
+
+		idxs = append(idxs, len(f.Keymanagers))
+		f.Keymanagers = append(f.Keymanagers, km)
+	}
+	return idxs, nil
+}
+
+// AddKeyManagerRuntimeFixture adds another key manager runtime with the given ID to
+// the fixture and returns its index.
+//
+// The new runtime is configured the same as the first key manager runtime in the fixture
+// and gets its own policy and key manager node, so that both key managers are operated
+// independently.
+func (sc *Scenario) AddKeyManagerRuntimeFixture(f *oasis.NetworkFixture, id common.Namespace) (int, error) {
+	if !id.IsKeyManager() {
+		return 0, fmt.Errorf("runtime %s is not a key manager runtime", id)
+	}
+	if len(f.KeymanagerPolicies) == 0 || len(f.Keymanagers) == 0 {
+		return 0, fmt.Errorf("fixture has no key manager policies or key managers")
+	}
+
+	// Use the runtime of the first key manager as a template.
+	pol := f.KeymanagerPolicies[0]
+	rt := f.Runtimes[pol.Runtime]
+	rt.ID = id
+	rtIdx := len(f.Runtimes)
+	f.Runtimes = append(f.Runtimes, rt)
+
+	pol.Runtime = rtIdx
+	polIdx := len(f.KeymanagerPolicies)
+	f.KeymanagerPolicies = append(f.KeymanagerPolicies, pol)
+
+	km := f.Keymanagers[0]
+	km.Name = ""
+	km.Runtime = rtIdx
+	km.Policy = polIdx
+	f.Keymanagers = append(f.Keymanagers, km)
+
+	return rtIdx, nil
+}
+
 // WaitEphemeralSecrets waits for the specified number of ephemeral secrets to be generated.
 func (sc *Scenario) WaitEphemeralSecrets(ctx context.Context, n int) (*secrets.SignedEncryptedEphemeralSecret, error) {
 	sc.Logger.Info("waiting ephemeral secrets", "n", n)
@@ -171,7 +327,7 @@ func (sc *Scenario) CompareLongtermPublicKeys(ctx context.Context, idxs []int) e
 
 		// Prepare an RPC client which will be used to query key manager nodes
 		// for public ephemeral keys.
-		rpcClient, err := newKeyManagerRPCClient(chainContext)
+		rpcClient, err := newKeyManagerRPCClient(chainContext, sc.Net.Env().Rand("key manager rpc clients"))
 		if err != nil {
 			return err
 		}
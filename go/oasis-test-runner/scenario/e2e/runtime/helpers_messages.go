@@ -0,0 +1,200 @@
+package runtime
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/oasis"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+// inMsgProcessedTimeout is the maximum time to wait for submitted incoming messages
+// to be processed.
+const inMsgProcessedTimeout = time.Minute
+
+// InMsg is an incoming runtime message submitted via the consensus layer.
+type InMsg struct {
+	// Tag is the tag attached to the message.
+	Tag uint64
+	// Call is the runtime transaction carried by the message.
+	Call TxnCall
+}
+
+// InMsgSubmitter submits incoming runtime messages from a single consensus account.
+type InMsgSubmitter struct {
+	sc *Scenario
+
+	signer signature.Signer
+	nonce  uint64
+	last   *transaction.SignedTransaction
+}
+
+// NewInMsgSubmitter creates a new incoming runtime message submitter with a fresh
+// consensus account.
+func (sc *Scenario) NewInMsgSubmitter(name string) *InMsgSubmitter {
+	return &InMsgSubmitter{
+		sc:     sc,
+		signer: memorySigner.NewTestSigner(fmt.Sprintf("oasis in msg submitter: %s %d", name, sc.Net.Env().Rand("in msg signers").Uint64())),
+	}
+}
+
+// Address returns the consensus address of the submitter.
+func (s *InMsgSubmitter) Address() staking.Address {
+	return staking.NewAddress(s.signer.Public())
+}
+
+// Submit submits the given messages to the runtime in order and waits for all of them
+// to be processed.
+//
+// It verifies that the messages were processed in submission order and successfully,
+// in case the runtime reports incoming message results, and returns the corresponding
+// processed events.
+func (s *InMsgSubmitter) Submit(ctx context.Context, id common.Namespace, msgs []*InMsg) ([]*roothash.InMsgProcessedEvent, error) {
+	ctrl := s.sc.Net.ClientController()
+	if ctrl == nil {
+		return nil, fmt.Errorf("client controller not available")
+	}
+
+	// Start watching roothash events before submitting anything.
+	ch, sub, err := ctrl.Roothash.WatchEvents(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch events: %w", err)
+	}
+	defer sub.Close()
+
+	for _, msg := range msgs {
+		s.sc.Logger.Info("submitting incoming runtime message",
+			"runtime_id", id,
+			"tag", msg.Tag,
+			"method", msg.Call.Method,
+			"nonce", msg.Call.Nonce,
+		)
+
+		sigTx, err := roothash.SignSubmitMsgTx(s.signer, s.nonce, &transaction.Fee{Gas: 10_000}, &roothash.SubmitMsg{
+			ID:   id,
+			Tag:  msg.Tag,
+			Data: cbor.Marshal(&msg.Call),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign SubmitMsg transaction: %w", err)
+		}
+		if err = ctrl.Consensus.SubmitTx(ctx, sigTx); err != nil {
+			return nil, fmt.Errorf("failed to submit SubmitMsg transaction: %w", err)
+		}
+		s.nonce++
+		s.last = sigTx
+	}
+
+	// Wait for processed events.
+	ctx, cancel := context.WithTimeout(ctx, inMsgProcessedTimeout)
+	defer cancel()
+
+	s.sc.Logger.Info("waiting for incoming messages to be processed",
+		"runtime_id", id,
+		"count", len(msgs),
+	)
+
+	callerAddr := s.Address()
+	evs := make([]*roothash.InMsgProcessedEvent, 0, len(msgs))
+	for len(evs) < len(msgs) {
+		select {
+		case ev := <-ch:
+			if ev.InMsgProcessed == nil || !ev.InMsgProcessed.Caller.Equal(callerAddr) {
+				continue
+			}
+
+			msg := msgs[len(evs)]
+			if ev.InMsgProcessed.Tag != msg.Tag {
+				return nil, fmt.Errorf("incoming message processed out of order (got tag: %d expected: %d)", ev.InMsgProcessed.Tag, msg.Tag)
+			}
+			if n := len(evs); n > 0 && ev.InMsgProcessed.ID <= evs[n-1].ID {
+				return nil, fmt.Errorf("non-increasing incoming message ID (got: %d previous: %d)", ev.InMsgProcessed.ID, evs[n-1].ID)
+			}
+			if res := ev.InMsgProcessed.Result; res != nil && !res.IsSuccess() {
+				return nil, fmt.Errorf("incoming message failed (tag: %d module: %s code: %d)", msg.Tag, res.Module, res.Code)
+			}
+			evs = append(evs, ev.InMsgProcessed)
+		case <-ctx.Done():
+			return nil, fmt.Errorf("incoming messages not processed (processed: %d expected: %d): %w", len(evs), len(msgs), ctx.Err())
+		}
+	}
+
+	return evs, nil
+}
+
+// ReplayLast resubmits the last submitted SubmitMsg transaction and ensures that it is
+// rejected by the consensus layer.
+func (s *InMsgSubmitter) ReplayLast(ctx context.Context) error {
+	if s.last == nil {
+		return fmt.Errorf("no SubmitMsg transaction submitted yet")
+	}
+
+	s.sc.Logger.Info("replaying last SubmitMsg transaction")
+
+	if err := s.sc.Net.ClientController().Consensus.SubmitTx(ctx, s.last); err == nil {
+		return fmt.Errorf("replayed SubmitMsg transaction should be rejected")
+	}
+	return nil
+}
+
+// AddComputeRuntime adds another compute runtime with the same configuration and binary
+// as the first compute runtime in the fixture, and enables it on all compute workers
+// and clients that run the first one.
+//
+// It returns the ID of the new runtime.
+func (sc *Scenario) AddComputeRuntime(f *oasis.NetworkFixture) (common.Namespace, error) {
+	var (
+		idx   = -1
+		maxID common.Namespace
+	)
+	for i, rt := range f.Runtimes {
+		if rt.Kind != registry.KindCompute {
+			continue
+		}
+		if idx < 0 {
+			idx = i
+		}
+		if bytes.Compare(rt.ID[:], maxID[:]) > 0 {
+			maxID = rt.ID
+		}
+	}
+	if idx < 0 {
+		return common.Namespace{}, fmt.Errorf("no compute runtime in fixture")
+	}
+
+	// Derive a new runtime ID by increasing the LSB of the largest compute runtime ID.
+	newID := maxID
+	newID[len(newID)-1]++
+
+	rt := f.Runtimes[idx]
+	rt.ID = newID
+	rt.Deployments = append([]oasis.DeploymentCfg{}, rt.Deployments...)
+	f.Runtimes = append(f.Runtimes, rt)
+	newIdx := len(f.Runtimes) - 1
+
+	enable := func(runtimes []int) []int {
+		for _, i := range runtimes {
+			if i == idx {
+				return append(runtimes, newIdx)
+			}
+		}
+		return runtimes
+	}
+	for i := range f.ComputeWorkers {
+		f.ComputeWorkers[i].Runtimes = enable(f.ComputeWorkers[i].Runtimes)
+	}
+	for i := range f.Clients {
+		f.Clients[i].Runtimes = enable(f.Clients[i].Runtimes)
+	}
+
+	return newID, nil
+}
@@ -3,7 +3,6 @@ package runtime
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/env"
 	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/oasis"
@@ -47,7 +46,7 @@ func (sc *Scenario) RunTestClientAndCheckLogs(ctx context.Context, childEnv *env
 		return err
 	}
 
-	return sc.WaitTestClientAndCheckLogs()
+	return sc.WaitTestClientAndCheckLogs(ctx)
 }
 
 // WaitNodesSynced waits for all the nodes to sync.
@@ -119,23 +118,26 @@ func (sc *Scenario) WaitTestClient() error {
 
 // WaitTestClientAndCheckLogs waits for the runtime test client to finish its work
 // and then verifies the logs.
-func (sc *Scenario) WaitTestClientAndCheckLogs() error {
+func (sc *Scenario) WaitTestClientAndCheckLogs(ctx context.Context) error {
 	if err := sc.WaitTestClient(); err != nil {
 		return err
 	}
-	return sc.checkTestClientLogs()
+	return sc.checkTestClientLogs(ctx)
 }
 
-func (sc *Scenario) checkTestClientLogs() error {
+func (sc *Scenario) checkTestClientLogs(ctx context.Context) error {
 	sc.Logger.Info("checking test client logs")
 
 	// Wait for logs to be fully processed before checking them. When
 	// the client exits very quickly the log watchers may not have
 	// processed the relevant logs yet.
-	//
-	// TODO: Find a better way to synchronize log watchers.
-	time.Sleep(1 * time.Second)
+	if err := sc.Net.DrainLogWatchers(ctx); err != nil {
+		return err
+	}
 
+	if err := sc.Net.CheckResourceLimits(); err != nil {
+		return err
+	}
 	return sc.Net.CheckLogWatchers()
 }
 
@@ -284,18 +284,14 @@ func (sc *Scenario) EnsureActiveVersionForKeyManagers(ctx context.Context, runti
 	return nil
 }
 
-// EnableRuntimeDeployment registers the specified runtime deployment, updates the key manager
-// policy, and waits until the deployment becomes active.
-func (sc *Scenario) EnableRuntimeDeployment(ctx context.Context, childEnv *env.Env, cli *cli.Helpers, rt *oasis.Runtime, deploymentIndex int, nonce uint64) error {
-	sc.Logger.Info("enabling runtime deployment",
-		"runtime_id", rt.ID(),
-		"deployment", deploymentIndex,
-	)
-
-	// Update the key manager policy.
+// UpdateKeyManagerPolicyForDeployment updates the key manager policy to include the enclave
+// identities of the specified runtime deployment and returns the next nonce.
+//
+// If there are no SGX runtimes, the policy is left unchanged.
+func (sc *Scenario) UpdateKeyManagerPolicyForDeployment(ctx context.Context, childEnv *env.Env, cli *cli.Helpers, rt *oasis.Runtime, deploymentIndex int, nonce uint64) (uint64, error) {
 	status, err := sc.KeyManagerStatus(ctx)
 	if err != nil && err != secrets.ErrNoSuchStatus {
-		return err
+		return nonce, err
 	}
 	var policies map[sgx.EnclaveIdentity]*secrets.EnclavePolicySGX
 	if status != nil && status.Policy != nil {
@@ -307,10 +303,26 @@ func (sc *Scenario) EnableRuntimeDeployment(ctx context.Context, childEnv *env.E
 	default:
 		sc.UpdateEnclavePolicies(rt, deploymentIndex, policies)
 		if err = sc.ApplyKeyManagerPolicy(ctx, childEnv, cli, 0, policies, nonce); err != nil {
-			return fmt.Errorf("updating policies: %w", err)
+			return nonce, fmt.Errorf("updating policies: %w", err)
 		}
 		nonce++
 	}
+	return nonce, nil
+}
+
+// EnableRuntimeDeployment registers the specified runtime deployment, updates the key manager
+// policy, and waits until the deployment becomes active.
+func (sc *Scenario) EnableRuntimeDeployment(ctx context.Context, childEnv *env.Env, cli *cli.Helpers, rt *oasis.Runtime, deploymentIndex int, nonce uint64) error {
+	sc.Logger.Info("enabling runtime deployment",
+		"runtime_id", rt.ID(),
+		"deployment", deploymentIndex,
+	)
+
+	// Update the key manager policy.
+	nonce, err := sc.UpdateKeyManagerPolicyForDeployment(ctx, childEnv, cli, rt, deploymentIndex, nonce)
+	if err != nil {
+		return err
+	}
 
 	// Fetch current epoch.
 	epoch, err := sc.Net.Controller().Beacon.GetEpoch(ctx, consensus.HeightLatest)
@@ -0,0 +1,195 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/oasis"
+	runtimeClient "github.com/oasisprotocol/oasis-core/go/runtime/client/api"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/checkpoint"
+)
+
+// insertManyMaxCount is the maximum number of key/value pairs inserted by a single
+// insert_many call, as enforced by the simple key/value runtime.
+const insertManyMaxCount = 10_000
+
+// InsertManyCall represents a call to insert many generated key/value pairs.
+type InsertManyCall struct {
+	Prefix    string `json:"prefix"`
+	Start     uint64 `json:"start"`
+	Count     uint64 `json:"count"`
+	ValueSize uint64 `json:"value_size"`
+}
+
+// KeyValueDataSet describes a deterministically generated set of key/value pairs
+// stored in the simple key/value runtime.
+type KeyValueDataSet struct {
+	// Prefix is the prefix of all keys in the set.
+	Prefix string
+	// NumKeys is the number of keys in the set.
+	NumKeys uint64
+	// ValueSize is the size of each value in bytes (at most 128).
+	ValueSize uint64
+	// KeysPerTx is the number of keys inserted by a single transaction.
+	KeysPerTx uint64
+}
+
+// Key returns the key with the given index.
+func (ds *KeyValueDataSet) Key(idx uint64) string {
+	return fmt.Sprintf("%s%d", ds.Prefix, idx)
+}
+
+// Value returns the value stored under the key with the given index.
+func (ds *KeyValueDataSet) Value(idx uint64) string {
+	key := ds.Key(idx)
+	return strings.Repeat(key, int(ds.ValueSize)/len(key)+1)[:ds.ValueSize]
+}
+
+func (ds *KeyValueDataSet) validate() error {
+	switch {
+	case ds.NumKeys == 0:
+		return fmt.Errorf("data set must contain at least one key")
+	case ds.ValueSize == 0 || ds.ValueSize > 128:
+		return fmt.Errorf("invalid value size: %d", ds.ValueSize)
+	case ds.KeysPerTx == 0 || ds.KeysPerTx > insertManyMaxCount:
+		return fmt.Errorf("invalid number of keys per transaction: %d", ds.KeysPerTx)
+	}
+	return nil
+}
+
+// GenerateKeyValueData inserts the given data set into the state of the runtime.
+//
+// Returns the round in which the last batch of keys was inserted.
+func (sc *Scenario) GenerateKeyValueData(ctx context.Context, id common.Namespace, ds *KeyValueDataSet, rng rand.Source64) (uint64, error) {
+	if err := ds.validate(); err != nil {
+		return 0, err
+	}
+
+	sc.Logger.Info("generating key/value data",
+		"prefix", ds.Prefix,
+		"num_keys", ds.NumKeys,
+		"value_size", ds.ValueSize,
+		"keys_per_tx", ds.KeysPerTx,
+	)
+
+	start := time.Now()
+	var round uint64
+	for idx := uint64(0); idx < ds.NumKeys; idx += ds.KeysPerTx {
+		args := InsertManyCall{
+			Prefix:    ds.Prefix,
+			Start:     idx,
+			Count:     min(ds.KeysPerTx, ds.NumKeys-idx),
+			ValueSize: ds.ValueSize,
+		}
+		meta, err := sc.submitRuntimeTxMeta(ctx, id, rng.Uint64(), "insert_many", args)
+		if err != nil {
+			return 0, fmt.Errorf("failed to insert keys %d-%d: %w", args.Start, args.Start+args.Count-1, err)
+		}
+		if _, err = unpackRawTxResp(meta.Output); err != nil {
+			return 0, fmt.Errorf("failed to insert keys %d-%d: %w", args.Start, args.Start+args.Count-1, err)
+		}
+		round = meta.Round
+
+		sc.Logger.Debug("inserted key/value data",
+			"start", args.Start,
+			"count", args.Count,
+			"round", round,
+		)
+	}
+
+	sc.Logger.Info("key/value data generated",
+		"num_keys", ds.NumKeys,
+		"round", round,
+		"duration", time.Since(start),
+	)
+
+	return round, nil
+}
+
+// VerifyKeyValueData queries a random sample of keys from the given data set using the runtime
+// client of the given node and verifies that the values match.
+func (sc *Scenario) VerifyKeyValueData(
+	ctx context.Context,
+	ctrl *oasis.Controller,
+	id common.Namespace,
+	round uint64,
+	ds *KeyValueDataSet,
+	numSamples int,
+	rng rand.Source64,
+) error {
+	sc.Logger.Info("verifying key/value data",
+		"prefix", ds.Prefix,
+		"round", round,
+		"num_samples", numSamples,
+	)
+
+	// Always check the boundaries of the data set.
+	samples := []uint64{0, ds.NumKeys - 1}
+	for i := 0; i < numSamples; i++ {
+		samples = append(samples, rng.Uint64()%ds.NumKeys)
+	}
+
+	for _, idx := range samples {
+		resp, err := ctrl.RuntimeClient.Query(ctx, &runtimeClient.QueryRequest{
+			RuntimeID: id,
+			Round:     round,
+			Method:    "get",
+			Args:      cbor.Marshal(GetCall{Key: ds.Key(idx)}),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to query key %s: %w", ds.Key(idx), err)
+		}
+
+		var value string
+		if err = cbor.Unmarshal(resp.Data, &value); err != nil {
+			return fmt.Errorf("failed to unmarshal value of key %s: %w", ds.Key(idx), err)
+		}
+		if expected := ds.Value(idx); value != expected {
+			return fmt.Errorf("unexpected value of key %s (got: '%s', expected: '%s')", ds.Key(idx), value, expected)
+		}
+	}
+
+	return nil
+}
+
+// WaitForCheckpoint waits until the given node has created a runtime storage checkpoint
+// for at least the given round.
+//
+// Returns the round of the most recent checkpoint.
+func (sc *Scenario) WaitForCheckpoint(ctx context.Context, ctrl *oasis.Controller, id common.Namespace, round uint64) (uint64, error) {
+	sc.Logger.Info("waiting for storage checkpoint",
+		"round", round,
+	)
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		cps, err := ctrl.Storage.GetCheckpoints(ctx, &checkpoint.GetCheckpointsRequest{Version: 1, Namespace: id})
+		if err != nil {
+			return 0, fmt.Errorf("failed to get checkpoints: %w", err)
+		}
+
+		var latest uint64
+		for _, cp := range cps {
+			latest = max(latest, cp.Root.Version)
+		}
+		if len(cps) > 0 && latest >= round {
+			sc.Logger.Info("storage checkpoint available",
+				"round", latest,
+			)
+			return latest, nil
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return 0, fmt.Errorf("%w: while waiting for storage checkpoint", ctx.Err())
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package runtime
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/env"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/oasis"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/scenario"
+)
+
+// KeymanagerChurn is the keymanager churn scenario.
+//
+// Key manager nodes join and leave the committee mid-run while master secrets are being
+// rotated, verifying that the secrets are replicated to the joining nodes.
+var KeymanagerChurn scenario.Scenario = newKmChurnImpl()
+
+type kmChurnImpl struct {
+	Scenario
+}
+
+func newKmChurnImpl() scenario.Scenario {
+	return &kmChurnImpl{
+		Scenario: *NewScenario(
+			"keymanager-churn",
+			NewTestClient().WithScenario(InsertRemoveEncWithSecretsScenario),
+		),
+	}
+}
+
+func (sc *kmChurnImpl) Clone() scenario.Scenario {
+	return &kmChurnImpl{
+		Scenario: *sc.Scenario.Clone().(*Scenario),
+	}
+}
+
+func (sc *kmChurnImpl) Fixture() (*oasis.NetworkFixture, error) {
+	f, err := sc.Scenario.Fixture()
+	if err != nil {
+		return nil, err
+	}
+
+	// Speed up the test.
+	f.Network.Beacon.VRFParameters = &beacon.VRFParameters{
+		Interval:             10,
+		ProofSubmissionDelay: 2,
+	}
+
+	// We don't need compute workers.
+	f.ComputeWorkers = []oasis.ComputeWorkerFixture{}
+
+	// Start with two key managers and keep three spare ones for joining mid-run.
+	f.Keymanagers = []oasis.KeymanagerFixture{
+		{Runtime: 0, Entity: 1, Policy: 0},
+		{Runtime: 0, Entity: 1, Policy: 0},
+	}
+	if _, err = sc.AddSpareKeymanagers(f, 3); err != nil {
+		return nil, err
+	}
+
+	// Enable master secret rotation.
+	f.KeymanagerPolicies[0].MasterSecretRotationInterval = 1
+
+	return f, nil
+}
+
+func (sc *kmChurnImpl) Run(ctx context.Context, _ *env.Env) error {
+	// Start the first two key managers.
+	if err := sc.Net.Start(); err != nil {
+		return err
+	}
+
+	// Wait until 2 master secrets are generated.
+	if _, err := sc.WaitMasterSecret(ctx, 1); err != nil {
+		return fmt.Errorf("master secret not generated: %w", err)
+	}
+	status, err := sc.WaitKeymanagerCommittee(ctx, []int{0, 1})
+	if err != nil {
+		return err
+	}
+
+	// Churn the committee a few times, making sure that master secrets are rotated
+	// by every committee.
+	committee := []int{0, 1}
+	for _, step := range []struct {
+		join  []int
+		leave []int
+	}{
+		{join: []int{2}, leave: []int{0}},
+		{join: []int{3, 4}, leave: []int{1}},
+		{join: []int{0}, leave: []int{2, 3}},
+	} {
+		if committee, status, err = sc.ChurnKeymanagers(ctx, committee, step.join, step.leave); err != nil {
+			return fmt.Errorf("key manager committee churn failed: %w", err)
+		}
+		if !status.IsInitialized {
+			return fmt.Errorf("key manager failed to initialize")
+		}
+
+		if status, err = sc.WaitMasterSecret(ctx, status.Generation+2); err != nil {
+			return fmt.Errorf("master secret not generated: %w", err)
+		}
+	}
+
+	// Wait few blocks so that the key managers transition to the new secret and register
+	// with the latest checksum.
+	if _, err = sc.WaitBlocks(ctx, 8); err != nil {
+		return err
+	}
+
+	// Check if checksums of the committee members match.
+	for _, idx := range committee {
+		initRsp, err := sc.KeymanagerInitResponse(ctx, idx)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(initRsp.Checksum, status.Checksum) {
+			return fmt.Errorf("key manager checksum mismatch")
+		}
+	}
+
+	// Make sure the committee members replicated the same secrets.
+	return sc.CompareLongtermPublicKeys(ctx, committee)
+}
@@ -2,8 +2,8 @@ package runtime
 
 import (
 	"context"
-	"crypto/rand"
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
@@ -15,6 +15,7 @@ import (
 	"github.com/oasisprotocol/curve25519-voi/primitives/x25519"
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
@@ -32,8 +33,8 @@ type keyManagerRPCClient struct {
 	client rpc.Client
 }
 
-func newKeyManagerRPCClient(chainContext string) (*keyManagerRPCClient, error) {
-	signer, err := memory.NewFactory().Generate(signature.SignerP2P, rand.Reader)
+func newKeyManagerRPCClient(chainContext string, rng io.Reader) (*keyManagerRPCClient, error) {
+	signer, err := memory.NewFactory().Generate(signature.SignerP2P, rng)
 	if err != nil {
 		return nil, err
 	}
@@ -127,10 +128,14 @@ func (c *keyManagerRPCClient) fetchPublicKey(ctx context.Context, generation uin
 }
 
 func (c *keyManagerRPCClient) fetchEphemeralPublicKey(ctx context.Context, epoch beacon.EpochTime, peerID peer.ID) (*x25519.PublicKey, error) {
+	return c.fetchRuntimeEphemeralPublicKey(ctx, KeyManagerRuntimeID, secrets.KeyPairID{1, 2, 3}, epoch, peerID)
+}
+
+func (c *keyManagerRPCClient) fetchRuntimeEphemeralPublicKey(ctx context.Context, runtimeID common.Namespace, keyPairID secrets.KeyPairID, epoch beacon.EpochTime, peerID peer.ID) (*x25519.PublicKey, error) {
 	args := secrets.EphemeralKeyRequest{
 		Height:    nil,
-		ID:        KeyManagerRuntimeID,
-		KeyPairID: secrets.KeyPairID{1, 2, 3},
+		ID:        runtimeID,
+		KeyPairID: keyPairID,
 		Epoch:     epoch,
 	}
 
@@ -172,6 +177,10 @@ func (c *keyManagerRPCClient) fetchEphemeralPublicKey(ctx context.Context, epoch
 }
 
 func (c *keyManagerRPCClient) fetchEphemeralPublicKeyWithRetry(ctx context.Context, epoch beacon.EpochTime, peerID peer.ID) (*x25519.PublicKey, error) {
+	return c.fetchRuntimeEphemeralPublicKeyWithRetry(ctx, KeyManagerRuntimeID, secrets.KeyPairID{1, 2, 3}, epoch, peerID)
+}
+
+func (c *keyManagerRPCClient) fetchRuntimeEphemeralPublicKeyWithRetry(ctx context.Context, runtimeID common.Namespace, keyPairID secrets.KeyPairID, epoch beacon.EpochTime, peerID peer.ID) (*x25519.PublicKey, error) {
 	var (
 		err error
 		key *x25519.PublicKey
@@ -179,7 +188,7 @@ func (c *keyManagerRPCClient) fetchEphemeralPublicKeyWithRetry(ctx context.Conte
 
 	retry := backoff.WithContext(backoff.WithMaxRetries(backoff.NewConstantBackOff(time.Second), 5), ctx)
 	err = backoff.Retry(func() error {
-		key, err = c.fetchEphemeralPublicKey(ctx, epoch, peerID)
+		key, err = c.fetchRuntimeEphemeralPublicKey(ctx, runtimeID, keyPairID, epoch, peerID)
 		return err
 	}, retry)
 	if err != nil {
@@ -33,6 +33,7 @@ import (
 //   - Start all managers and test that ephemeral secrets can be replicated.
 //   - Run managers for few epochs and test that everything works.
 //   - Publish transactions that use ephemeral keys to encrypt/decrypt messages.
+//   - Publish transactions sealed with the runtime's ephemeral public key.
 var KeymanagerEphemeralSecrets scenario.Scenario = newKmEphemeralSecretsImpl()
 
 type kmEphemeralSecretsImpl struct {
@@ -92,7 +93,7 @@ func (sc *kmEphemeralSecretsImpl) Run(ctx context.Context, _ *env.Env) error { /
 	if err != nil {
 		return err
 	}
-	rpcClient, err := newKeyManagerRPCClient(chainContext)
+	rpcClient, err := newKeyManagerRPCClient(chainContext, sc.Net.Env().Rand("key manager rpc clients"))
 	if err != nil {
 		return err
 	}
@@ -396,6 +397,34 @@ func (sc *kmEphemeralSecretsImpl) Run(ctx context.Context, _ *env.Env) error { /
 		return fmt.Errorf("decryption with wrong key pair id should fail or produce garbage")
 	}
 
+	// Submit calls sealed with the runtime's ephemeral public key for the current epoch.
+	// Successful execution indicates that the runtime can open the calls and seal
+	// the results using the matching ephemeral private key.
+	sc.Logger.Info("submitting encrypted calls")
+	encryption, err := sc.NewTxEncryption(ctx, KeyValueRuntimeID, keyPairID, epoch)
+	if err != nil {
+		return fmt.Errorf("failed to prepare tx encryption: %w", err)
+	}
+	if _, err = sc.submitRuntimeTx(ctx, KeyValueRuntimeID, rng.Uint64(), "insert", InsertCall{
+		Key:   "encrypted_call_key",
+		Value: string(plaintext),
+	}, withTxEncryption(encryption)); err != nil {
+		return fmt.Errorf("failed to submit encrypted insert tx: %w", err)
+	}
+	rawRsp, err := sc.submitRuntimeTx(ctx, KeyValueRuntimeID, rng.Uint64(), "get", GetCall{
+		Key: "encrypted_call_key",
+	}, withTxEncryption(encryption))
+	if err != nil {
+		return fmt.Errorf("failed to submit encrypted get tx: %w", err)
+	}
+	var value string
+	if err = cbor.Unmarshal(rawRsp, &value); err != nil {
+		return fmt.Errorf("failed to unmarshal encrypted get tx response: %w", err)
+	}
+	if value != string(plaintext) {
+		return fmt.Errorf("encrypted get tx response does not match (got: '%s', expected: '%s')", value, plaintext)
+	}
+
 	// Change epoch and test what happens if epoch is invalid,
 	// i.e. too old or somewhere in the future.
 	epoch = epoch + 10
@@ -7,7 +7,6 @@ import (
 	"slices"
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
-	"github.com/oasisprotocol/oasis-core/go/keymanager/secrets"
 	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/env"
 	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/oasis"
 	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/scenario"
@@ -149,28 +148,3 @@ func (sc *kmReplicateImpl) Run(ctx context.Context, _ *env.Env) error {
 	// public keys for all generations.
 	return sc.CompareLongtermPublicKeys(ctx, []int{0, 1, 2, 3})
 }
-
-func (sc *kmReplicateImpl) waitKeymanagerStatuses(ctx context.Context, n int) (*secrets.Status, error) {
-	sc.Logger.Info("waiting for key manager status", "n", n)
-
-	stCh, stSub, err := sc.Net.Controller().Keymanager.Secrets().WatchStatuses(ctx)
-	if err != nil {
-		return nil, err
-	}
-	defer stSub.Close()
-
-	for {
-		select {
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		case status := <-stCh:
-			if !status.ID.Equal(&KeyManagerRuntimeID) {
-				continue
-			}
-			n--
-			if n <= 0 {
-				return status, nil
-			}
-		}
-	}
-}
@@ -0,0 +1,176 @@
+package runtime
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/env"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/oasis"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/scenario"
+)
+
+// KeymanagerRotationChurn is the keymanager master secret rotation under replica churn scenario.
+//
+// Scenario:
+//   - Start two key managers with master secret rotations enabled.
+//   - Repeatedly let key managers join and leave the committee while secrets are rotated.
+//   - After every churn step, insert an encrypted key/value pair using the latest generation.
+//   - Verify that the checksums of all committee members converge.
+//   - Verify that the runtime can still derive state keys for all generations.
+var KeymanagerRotationChurn scenario.Scenario = newKmRotationChurnImpl()
+
+type kmRotationChurnImpl struct {
+	Scenario
+
+	nonce uint64
+}
+
+func newKmRotationChurnImpl() scenario.Scenario {
+	return &kmRotationChurnImpl{
+		Scenario: *NewScenario("keymanager-rotation-churn", nil),
+	}
+}
+
+func (sc *kmRotationChurnImpl) Clone() scenario.Scenario {
+	return &kmRotationChurnImpl{
+		Scenario: *sc.Scenario.Clone().(*Scenario),
+	}
+}
+
+func (sc *kmRotationChurnImpl) Fixture() (*oasis.NetworkFixture, error) {
+	f, err := sc.Scenario.Fixture()
+	if err != nil {
+		return nil, err
+	}
+
+	// Speed up the test.
+	f.Network.Beacon.VRFParameters = &beacon.VRFParameters{
+		Interval:             10,
+		ProofSubmissionDelay: 2,
+	}
+
+	// Start with two key managers and keep three spare ones for joining mid-run.
+	f.Keymanagers = []oasis.KeymanagerFixture{
+		{Runtime: 0, Entity: 1, Policy: 0},
+		{Runtime: 0, Entity: 1, Policy: 0},
+	}
+	if _, err = sc.AddSpareKeymanagers(f, 3); err != nil {
+		return nil, err
+	}
+
+	// Enable master secret rotation.
+	f.KeymanagerPolicies[0].MasterSecretRotationInterval = 1
+
+	return f, nil
+}
+
+func (sc *kmRotationChurnImpl) Run(ctx context.Context, _ *env.Env) error {
+	if err := sc.StartNetworkAndWaitForClientSync(ctx); err != nil {
+		return err
+	}
+
+	status, err := sc.WaitMasterSecret(ctx, 1)
+	if err != nil {
+		return fmt.Errorf("master secret not generated: %w", err)
+	}
+	if _, err = sc.WaitKeymanagerCommittee(ctx, []int{0, 1}); err != nil {
+		return err
+	}
+
+	// Insert a key/value pair encrypted with the initial generation.
+	written := make(map[uint64]string)
+	if err = sc.insertWithGeneration(ctx, status.Generation, written); err != nil {
+		return err
+	}
+
+	// Churn the committee while master secrets are being rotated and store a key/value pair
+	// encrypted with the latest generation after every step.
+	committee := []int{0, 1}
+	for _, step := range []struct {
+		join  []int
+		leave []int
+	}{
+		{join: []int{2}, leave: []int{0}},
+		{join: []int{3, 4}, leave: []int{1}},
+		{join: []int{0}, leave: []int{2, 3}},
+		{join: []int{1, 2}, leave: []int{4}},
+	} {
+		if committee, status, err = sc.ChurnKeymanagers(ctx, committee, step.join, step.leave); err != nil {
+			return fmt.Errorf("key manager committee churn failed: %w", err)
+		}
+		if !status.IsInitialized {
+			return fmt.Errorf("key manager failed to initialize")
+		}
+
+		// Make sure at least one rotation happens with the new committee.
+		if status, err = sc.WaitMasterSecret(ctx, status.Generation+1); err != nil {
+			return fmt.Errorf("master secret not generated: %w", err)
+		}
+		if err = sc.insertWithGeneration(ctx, status.Generation, written); err != nil {
+			return err
+		}
+	}
+
+	// Wait few blocks so that the key managers transition to the new secret and register
+	// with the latest checksum.
+	if _, err = sc.WaitBlocks(ctx, 8); err != nil {
+		return err
+	}
+	if status, err = sc.KeyManagerStatus(ctx); err != nil {
+		return err
+	}
+
+	// Check if checksums of the committee members converged.
+	for _, idx := range committee {
+		initRsp, err := sc.KeymanagerInitResponse(ctx, idx)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(initRsp.Checksum, status.Checksum) {
+			return fmt.Errorf("key manager %d checksum mismatch", idx)
+		}
+	}
+
+	// Make sure the committee members replicated the same secrets.
+	if err = sc.CompareLongtermPublicKeys(ctx, committee); err != nil {
+		return err
+	}
+
+	// Make sure the runtime can still derive state keys for old and new generations.
+	sc.Logger.Info("verifying key/value pairs encrypted with all generations",
+		"generations", len(written),
+	)
+	for generation, key := range written {
+		rsp, err := sc.submitKeyValueRuntimeGetTx(ctx, KeyValueRuntimeID, sc.nonce, key, generation, 0, encryptedWithSecretsTxKind)
+		if err != nil {
+			return fmt.Errorf("failed to get key/value pair (generation %d): %w", generation, err)
+		}
+		sc.nonce++
+		if rsp != sc.valueForGeneration(generation) {
+			return fmt.Errorf("unexpected value for generation %d (got: '%s')", generation, rsp)
+		}
+	}
+
+	return nil
+}
+
+func (sc *kmRotationChurnImpl) valueForGeneration(generation uint64) string {
+	return fmt.Sprintf("value-%d", generation)
+}
+
+func (sc *kmRotationChurnImpl) insertWithGeneration(ctx context.Context, generation uint64, written map[uint64]string) error {
+	if _, ok := written[generation]; ok {
+		return nil
+	}
+
+	key := fmt.Sprintf("key-%d", generation)
+	if _, err := sc.submitKeyValueRuntimeInsertTx(ctx, KeyValueRuntimeID, sc.nonce, key, sc.valueForGeneration(generation), generation, 0, encryptedWithSecretsTxKind); err != nil {
+		return fmt.Errorf("failed to insert key/value pair (generation %d): %w", generation, err)
+	}
+	sc.nonce++
+	written[generation] = key
+
+	return nil
+}
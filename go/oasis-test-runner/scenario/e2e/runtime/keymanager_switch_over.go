@@ -0,0 +1,157 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/env"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/oasis"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/scenario"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+// KeymanagerSwitchOver is the key manager switch-over scenario.
+//
+// In this scenario we test what happens when a compute runtime tries to switch from one
+// key manager runtime to another via a runtime descriptor update. Switching key managers
+// is not allowed, so the update must be rejected and the runtime must keep using keys
+// derived by the original key manager.
+var KeymanagerSwitchOver scenario.Scenario = newKmSwitchOverImpl()
+
+var (
+	// kmSwitchOverRuntimeID is the ID of the key manager runtime the compute runtime
+	// tries to switch to.
+	kmSwitchOverRuntimeID common.Namespace
+
+	_ = kmSwitchOverRuntimeID.UnmarshalHex("c000000000000000fffffffffffffffffffffffffffffffffffffffffffffffe")
+)
+
+type kmSwitchOverImpl struct {
+	Scenario
+}
+
+func newKmSwitchOverImpl() scenario.Scenario {
+	return &kmSwitchOverImpl{
+		Scenario: *NewScenario(
+			"keymanager-switch-over",
+			NewTestClient().WithScenario(InsertEncWithSecretsScenario),
+		),
+	}
+}
+
+func (sc *kmSwitchOverImpl) Fixture() (*oasis.NetworkFixture, error) {
+	f, err := sc.Scenario.Fixture()
+	if err != nil {
+		return nil, err
+	}
+
+	// Add the key manager runtime the compute runtime will try to switch to.
+	if _, err = sc.AddKeyManagerRuntimeFixture(f, kmSwitchOverRuntimeID); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+func (sc *kmSwitchOverImpl) Clone() scenario.Scenario {
+	return &kmSwitchOverImpl{
+		Scenario: *sc.Scenario.Clone().(*Scenario),
+	}
+}
+
+func (sc *kmSwitchOverImpl) Run(ctx context.Context, childEnv *env.Env) error {
+	// Start the network and store some encrypted state.
+	if err := sc.StartNetworkAndTestClient(ctx, childEnv); err != nil {
+		return err
+	}
+	if err := sc.WaitTestClient(); err != nil {
+		return err
+	}
+
+	// Make sure the other key manager is operational before switching to it.
+	if err := sc.waitKeymanagerInitialized(ctx, kmSwitchOverRuntimeID); err != nil {
+		return err
+	}
+
+	// Try to switch the compute runtime to the other key manager.
+	rt, err := sc.Net.Controller().Registry.GetRuntime(ctx, &registry.GetRuntimeQuery{
+		Height: consensus.HeightLatest,
+		ID:     KeyValueRuntimeID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get runtime descriptor: %w", err)
+	}
+	rt.KeyManager = &kmSwitchOverRuntimeID
+
+	sc.Logger.Info("switching key manager",
+		"runtime_id", rt.ID,
+		"key_manager", kmSwitchOverRuntimeID,
+	)
+
+	err = sc.updateRuntime(ctx, rt)
+	if !errors.Is(err, registry.ErrRuntimeUpdateNotAllowed) {
+		return fmt.Errorf("switching key manager should fail with %w (got: %v)", registry.ErrRuntimeUpdateNotAllowed, err)
+	}
+
+	// Make sure the runtime still uses the original key manager.
+	rt, err = sc.Net.Controller().Registry.GetRuntime(ctx, &registry.GetRuntimeQuery{
+		Height: consensus.HeightLatest,
+		ID:     KeyValueRuntimeID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get runtime descriptor: %w", err)
+	}
+	if rt.KeyManager == nil || !rt.KeyManager.Equal(&KeyManagerRuntimeID) {
+		return fmt.Errorf("runtime key manager changed (expected: %s got: %v)", KeyManagerRuntimeID, rt.KeyManager)
+	}
+
+	// Verify that state encrypted before the switch-over attempt can still be decrypted.
+	sc.Logger.Info("starting a second client to check if key derivation is unchanged")
+	sc.Scenario.TestClient = NewTestClient().WithSeed("seed2").WithScenario(RemoveEncWithSecretsScenario)
+	return sc.RunTestClientAndCheckLogs(ctx, childEnv)
+}
+
+func (sc *kmSwitchOverImpl) waitKeymanagerInitialized(ctx context.Context, id common.Namespace) error {
+	sc.Logger.Info("waiting for key manager to initialize", "id", id)
+
+	stCh, stSub, err := sc.Net.Controller().Keymanager.Secrets().WatchStatuses(ctx)
+	if err != nil {
+		return err
+	}
+	defer stSub.Close()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case status := <-stCh:
+			if !status.ID.Equal(&id) || !status.IsInitialized {
+				continue
+			}
+			return nil
+		}
+	}
+}
+
+func (sc *kmSwitchOverImpl) updateRuntime(ctx context.Context, rt *registry.Runtime) error {
+	ent := sc.Net.Entities()[0]
+	nonce, err := sc.Net.Controller().Consensus.GetSignerNonce(ctx, &consensus.GetSignerNonceRequest{
+		AccountAddress: staking.NewAddress(ent.ID()),
+		Height:         consensus.HeightLatest,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get entity nonce: %w", err)
+	}
+
+	tx := registry.NewRegisterRuntimeTx(nonce, &transaction.Fee{Gas: 10000}, rt)
+	sigTx, err := transaction.Sign(ent.Signer(), tx)
+	if err != nil {
+		return fmt.Errorf("failed to sign register runtime transaction: %w", err)
+	}
+	return sc.Net.Controller().Consensus.SubmitTx(ctx, sigTx)
+}
@@ -0,0 +1,307 @@
+package runtime
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+const loadSummaryFile = "load_summary.json"
+
+// LoadProfile is a workload profile of the load generator.
+type LoadProfile struct {
+	// Seed is the seed used to generate keys, values and operations.
+	Seed string
+	// NumOps is the number of measured operations to perform.
+	NumOps uint64
+	// TargetTPS is the rate at which operations are submitted. Zero means
+	// that operations are submitted as fast as the workers allow.
+	TargetTPS float64
+	// Concurrency is the number of operations which can be in flight at
+	// the same time.
+	Concurrency int
+	// ReadRatio is the fraction of read operations in range [0, 1].
+	ReadRatio float64
+	// NumKeys is the size of the key space. All keys are inserted before
+	// measured operations start.
+	NumKeys uint64
+	// ZipfS is the Zipf distribution exponent used to select keys, which
+	// must be greater than one. Zero means that keys are selected uniformly.
+	ZipfS float64
+	// MinValueSize is the minimum size of inserted values in bytes.
+	MinValueSize int
+	// MaxValueSize is the maximum size of inserted values in bytes.
+	MaxValueSize int
+	// Kind is the kind of transactions submitted to the runtime.
+	Kind uint
+}
+
+// Validate validates the load profile.
+func (p *LoadProfile) Validate() error {
+	switch {
+	case p.NumOps == 0:
+		return fmt.Errorf("number of operations must be positive")
+	case p.TargetTPS < 0:
+		return fmt.Errorf("target TPS must not be negative")
+	case p.Concurrency <= 0:
+		return fmt.Errorf("concurrency must be positive")
+	case p.ReadRatio < 0 || p.ReadRatio > 1:
+		return fmt.Errorf("read ratio must be in range [0, 1]")
+	case p.NumKeys == 0:
+		return fmt.Errorf("number of keys must be positive")
+	case p.ZipfS != 0 && p.ZipfS <= 1:
+		return fmt.Errorf("zipf exponent must be greater than one")
+	case p.MinValueSize <= 0 || p.MaxValueSize < p.MinValueSize:
+		return fmt.Errorf("invalid value size range [%d, %d]", p.MinValueSize, p.MaxValueSize)
+	default:
+		return nil
+	}
+}
+
+// LoadSummary is a summary of a load generator run.
+type LoadSummary struct {
+	// Ops is the number of completed measured operations.
+	Ops uint64 `json:"ops"`
+	// Reads is the number of completed read operations.
+	Reads uint64 `json:"reads"`
+	// Writes is the number of completed write operations.
+	Writes uint64 `json:"writes"`
+	// Errors is the number of failed operations.
+	Errors uint64 `json:"errors"`
+	// Duration is the duration of the measured phase.
+	Duration time.Duration `json:"duration"`
+	// TPS is the achieved rate of completed operations.
+	TPS float64 `json:"tps"`
+	// TargetTPS is the configured target rate.
+	TargetTPS float64 `json:"target_tps"`
+	// LatencyP50 is the median operation latency.
+	LatencyP50 time.Duration `json:"latency_p50"`
+	// LatencyP95 is the 95th percentile operation latency.
+	LatencyP95 time.Duration `json:"latency_p95"`
+	// LatencyP99 is the 99th percentile operation latency.
+	LatencyP99 time.Duration `json:"latency_p99"`
+	// LatencyMax is the maximum operation latency.
+	LatencyMax time.Duration `json:"latency_max"`
+}
+
+// Write writes the summary to the given directory.
+func (s *LoadSummary) Write(dir string) error {
+	raw, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal load summary: %w", err)
+	}
+	if err = os.WriteFile(filepath.Join(dir, loadSummaryFile), raw, 0o600); err != nil {
+		return fmt.Errorf("failed to write load summary: %w", err)
+	}
+	return nil
+}
+
+type loadOp struct {
+	read  bool
+	key   string
+	value string
+}
+
+// LoadGenerator generates configurable key/value workloads against the simple
+// key-value runtime and summarizes the results.
+type LoadGenerator struct {
+	profile LoadProfile
+
+	mu        sync.Mutex
+	summary   LoadSummary
+	latencies []time.Duration
+}
+
+// NewLoadGenerator creates a new load generator with the given profile.
+func NewLoadGenerator(profile LoadProfile) (*LoadGenerator, error) {
+	if err := profile.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid load profile: %w", err)
+	}
+	return &LoadGenerator{
+		profile: profile,
+	}, nil
+}
+
+// Summary returns the summary of the measured operations.
+func (g *LoadGenerator) Summary() LoadSummary {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	summary := g.summary
+	summary.TargetTPS = g.profile.TargetTPS
+	if summary.Duration > 0 {
+		summary.TPS = float64(summary.Ops) / summary.Duration.Seconds()
+	}
+
+	latencies := append([]time.Duration{}, g.latencies...)
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	summary.LatencyP50 = latencyPercentile(latencies, 50)
+	summary.LatencyP95 = latencyPercentile(latencies, 95)
+	summary.LatencyP99 = latencyPercentile(latencies, 99)
+	summary.LatencyMax = latencyPercentile(latencies, 100)
+
+	return summary
+}
+
+// Scenario returns a test client scenario which runs the workload.
+func (g *LoadGenerator) Scenario() TestClientScenario {
+	return func(submit func(req interface{}) error) error {
+		src, err := drbgFromSeed(
+			[]byte("oasis-core/oasis-test-runner/e2e/runtime/load-generator"),
+			[]byte(g.profile.Seed),
+		)
+		if err != nil {
+			return err
+		}
+		rng := rand.New(src) // #nosec G404
+		nextKey := g.keySelector(rng)
+
+		// Populate the key space so that every read hits an existing key.
+		populate := make([]loadOp, 0, g.profile.NumKeys)
+		for i := uint64(0); i < g.profile.NumKeys; i++ {
+			populate = append(populate, loadOp{key: loadKey(i), value: g.randomValue(rng)})
+		}
+		if err = g.run(submit, populate, 0, false); err != nil {
+			return fmt.Errorf("failed to populate key space: %w", err)
+		}
+
+		ops := make([]loadOp, 0, g.profile.NumOps)
+		for i := uint64(0); i < g.profile.NumOps; i++ {
+			op := loadOp{
+				read: rng.Float64() < g.profile.ReadRatio,
+				key:  loadKey(nextKey()),
+			}
+			if !op.read {
+				op.value = g.randomValue(rng)
+			}
+			ops = append(ops, op)
+		}
+		return g.run(submit, ops, g.profile.TargetTPS, true)
+	}
+}
+
+func (g *LoadGenerator) run(submit func(req interface{}) error, ops []loadOp, tps float64, measure bool) error {
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	opCh := make(chan loadOp)
+	stopCh := make(chan struct{})
+
+	for i := 0; i < g.profile.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for op := range opCh {
+				start := time.Now()
+				err := submit(g.request(op))
+				if measure {
+					g.record(op, time.Since(start), err)
+				}
+				if err != nil {
+					errOnce.Do(func() {
+						firstErr = err
+						close(stopCh)
+					})
+				}
+			}
+		}()
+	}
+
+	var ticker *time.Ticker
+	if tps > 0 {
+		ticker = time.NewTicker(time.Duration(float64(time.Second) / tps))
+		defer ticker.Stop()
+	}
+
+	start := time.Now()
+Dispatch:
+	for _, op := range ops {
+		if ticker != nil {
+			select {
+			case <-stopCh:
+				break Dispatch
+			case <-ticker.C:
+			}
+		}
+		select {
+		case <-stopCh:
+			break Dispatch
+		case opCh <- op:
+		}
+	}
+	close(opCh)
+	wg.Wait()
+
+	if measure {
+		g.mu.Lock()
+		g.summary.Duration = time.Since(start)
+		g.mu.Unlock()
+	}
+
+	return firstErr
+}
+
+func (g *LoadGenerator) request(op loadOp) interface{} {
+	if op.read {
+		return KeyExistsTx{op.key, 0, 0, g.profile.Kind}
+	}
+	return UpsertKeyValueTx{op.key, op.value, 0, 0, g.profile.Kind}
+}
+
+func (g *LoadGenerator) record(op loadOp, latency time.Duration, err error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if err != nil {
+		g.summary.Errors++
+		return
+	}
+
+	g.summary.Ops++
+	if op.read {
+		g.summary.Reads++
+	} else {
+		g.summary.Writes++
+	}
+	g.latencies = append(g.latencies, latency)
+}
+
+func (g *LoadGenerator) keySelector(rng *rand.Rand) func() uint64 {
+	if g.profile.ZipfS == 0 {
+		return func() uint64 {
+			return uint64(rng.Int63n(int64(g.profile.NumKeys)))
+		}
+	}
+	zipf := rand.NewZipf(rng, g.profile.ZipfS, 1, g.profile.NumKeys-1)
+	return zipf.Uint64
+}
+
+func (g *LoadGenerator) randomValue(rng *rand.Rand) string {
+	const alphabet = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+	size := g.profile.MinValueSize + rng.Intn(g.profile.MaxValueSize-g.profile.MinValueSize+1)
+	value := make([]byte, size)
+	for i := range value {
+		value[i] = alphabet[rng.Intn(len(alphabet))]
+	}
+	return string(value)
+}
+
+func loadKey(idx uint64) string {
+	return fmt.Sprintf("load_key_%d", idx)
+}
+
+func latencyPercentile(sorted []time.Duration, p int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	idx := (len(sorted)*p+99)/100 - 1
+	return sorted[max(idx, 0)]
+}
@@ -0,0 +1,115 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/env"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/scenario"
+)
+
+const (
+	// cfgLoadNumOps is the number of measured load generator operations.
+	cfgLoadNumOps = "load_num_ops"
+	// cfgLoadTargetTPS is the target rate of load generator operations.
+	cfgLoadTargetTPS = "load_target_tps"
+	// cfgLoadConcurrency is the number of concurrent load generator operations.
+	cfgLoadConcurrency = "load_concurrency"
+	// cfgLoadReadRatio is the fraction of load generator read operations.
+	cfgLoadReadRatio = "load_read_ratio"
+	// cfgLoadNumKeys is the size of the load generator key space.
+	cfgLoadNumKeys = "load_num_keys"
+	// cfgLoadZipfS is the Zipf exponent used to select keys.
+	cfgLoadZipfS = "load_zipf_s"
+	// cfgLoadMinValueSize is the minimum size of inserted values.
+	cfgLoadMinValueSize = "load_min_value_size"
+	// cfgLoadMaxValueSize is the maximum size of inserted values.
+	cfgLoadMaxValueSize = "load_max_value_size"
+)
+
+// LoadGeneratorScenario is a scenario which runs a configurable key/value
+// workload against the simple key-value runtime and reports its performance.
+var LoadGeneratorScenario = func() scenario.Scenario {
+	sc := &loadGeneratorImpl{
+		Scenario: *NewScenario("load-generator", nil),
+	}
+	sc.Flags.Uint64(cfgLoadNumOps, 200, "number of measured operations")
+	sc.Flags.Float64(cfgLoadTargetTPS, 20, "target operations per second (0 for unlimited)")
+	sc.Flags.Int(cfgLoadConcurrency, 8, "number of concurrent operations")
+	sc.Flags.Float64(cfgLoadReadRatio, 0.8, "fraction of read operations")
+	sc.Flags.Uint64(cfgLoadNumKeys, 50, "size of the key space")
+	sc.Flags.Float64(cfgLoadZipfS, 1.1, "zipf exponent used to select keys (0 for uniform)")
+	sc.Flags.Int(cfgLoadMinValueSize, 16, "minimum size of inserted values in bytes")
+	sc.Flags.Int(cfgLoadMaxValueSize, 256, "maximum size of inserted values in bytes")
+
+	return sc
+}()
+
+type loadGeneratorImpl struct {
+	Scenario
+}
+
+func (sc *loadGeneratorImpl) Clone() scenario.Scenario {
+	return &loadGeneratorImpl{
+		Scenario: *sc.Scenario.Clone().(*Scenario),
+	}
+}
+
+func (sc *loadGeneratorImpl) profile() LoadProfile {
+	numOps, _ := sc.Flags.GetUint64(cfgLoadNumOps)
+	targetTPS, _ := sc.Flags.GetFloat64(cfgLoadTargetTPS)
+	concurrency, _ := sc.Flags.GetInt(cfgLoadConcurrency)
+	readRatio, _ := sc.Flags.GetFloat64(cfgLoadReadRatio)
+	numKeys, _ := sc.Flags.GetUint64(cfgLoadNumKeys)
+	zipfS, _ := sc.Flags.GetFloat64(cfgLoadZipfS)
+	minValueSize, _ := sc.Flags.GetInt(cfgLoadMinValueSize)
+	maxValueSize, _ := sc.Flags.GetInt(cfgLoadMaxValueSize)
+
+	return LoadProfile{
+		Seed:         "load-generator",
+		NumOps:       numOps,
+		TargetTPS:    targetTPS,
+		Concurrency:  concurrency,
+		ReadRatio:    readRatio,
+		NumKeys:      numKeys,
+		ZipfS:        zipfS,
+		MinValueSize: minValueSize,
+		MaxValueSize: maxValueSize,
+		Kind:         plaintextTxKind,
+	}
+}
+
+func (sc *loadGeneratorImpl) Run(ctx context.Context, childEnv *env.Env) error {
+	gen, err := NewLoadGenerator(sc.profile())
+	if err != nil {
+		return err
+	}
+	sc.TestClient = NewTestClient().WithScenario(gen.Scenario())
+
+	if err = sc.StartNetworkAndTestClient(ctx, childEnv); err != nil {
+		return err
+	}
+	if err = sc.WaitTestClientAndCheckLogs(ctx); err != nil {
+		return err
+	}
+
+	summary := gen.Summary()
+	sc.Logger.Info("load generator finished",
+		"ops", summary.Ops,
+		"reads", summary.Reads,
+		"writes", summary.Writes,
+		"errors", summary.Errors,
+		"duration", summary.Duration,
+		"tps", summary.TPS,
+		"target_tps", summary.TargetTPS,
+		"latency_p50", summary.LatencyP50,
+		"latency_p95", summary.LatencyP95,
+		"latency_p99", summary.LatencyP99,
+		"latency_max", summary.LatencyMax,
+	)
+	if err = summary.Write(childEnv.Dir()); err != nil {
+		return fmt.Errorf("failed to record load summary: %w", err)
+	}
+
+	return nil
+}
@@ -32,15 +32,32 @@ type TxnOutput struct {
 	Error *string
 }
 
+// submitTxOptions are the options used when submitting runtime transactions.
+type submitTxOptions struct {
+	encryption *TxEncryption
+}
+
+// submitTxOption is an option used when submitting runtime transactions.
+type submitTxOption func(*submitTxOptions)
+
+// withTxEncryption seals the transaction call in an encrypted call envelope and opens
+// the result before returning it.
+func withTxEncryption(encryption *TxEncryption) submitTxOption {
+	return func(o *submitTxOptions) {
+		o.encryption = encryption
+	}
+}
+
 func (sc *Scenario) submitRuntimeTx(
 	ctx context.Context,
 	id common.Namespace,
 	nonce uint64,
 	method string,
 	args interface{},
+	opts ...submitTxOption,
 ) (cbor.RawMessage, error) {
 	// Submit a transaction and check the result.
-	metaResp, err := sc.submitRuntimeTxMeta(ctx, id, nonce, method, args)
+	metaResp, err := sc.submitRuntimeTxMeta(ctx, id, nonce, method, args, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -77,6 +94,7 @@ func (sc *Scenario) submitRuntimeTxMeta(
 	nonce uint64,
 	method string,
 	args interface{},
+	opts ...submitTxOption,
 ) (*runtimeClient.SubmitTxMetaResponse, error) {
 	ctrl := sc.Net.ClientController()
 	if ctrl == nil {
@@ -84,13 +102,28 @@ func (sc *Scenario) submitRuntimeTxMeta(
 	}
 	c := ctrl.RuntimeClient
 
+	var o submitTxOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	call := &TxnCall{
+		Nonce:  nonce,
+		Method: method,
+		Args:   args,
+	}
+	var envelope *txEnvelope
+	if o.encryption != nil {
+		var err error
+		if envelope, err = o.encryption.seal(call); err != nil {
+			return nil, err
+		}
+		call = envelope.call
+	}
+
 	resp, err := c.SubmitTxMeta(ctx, &runtimeClient.SubmitTxRequest{
 		RuntimeID: id,
-		Data: cbor.Marshal(&TxnCall{
-			Nonce:  nonce,
-			Method: method,
-			Args:   args,
-		}),
+		Data:      cbor.Marshal(call),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to submit runtime meta tx: %w", err)
@@ -99,6 +132,12 @@ func (sc *Scenario) submitRuntimeTxMeta(
 		return nil, fmt.Errorf("check tx failed: %s", resp.CheckTxError.Message)
 	}
 
+	if envelope != nil && resp.Output != nil {
+		if resp.Output, err = envelope.open(resp.Output); err != nil {
+			return nil, err
+		}
+	}
+
 	return resp, nil
 }
 
@@ -132,7 +171,8 @@ func (sc *Scenario) submitRuntimeInMsg(ctx context.Context, id common.Namespace,
 	}
 
 	// Queue a runtime message and wait for it to be processed.
-	tx := roothash.NewSubmitMsgTx(0, &transaction.Fee{Gas: 10_000}, &roothash.SubmitMsg{
+	signer := memorySigner.NewTestSigner(fmt.Sprintf("oasis in msg test signer: %d", sc.Net.Env().Rand("in msg signers").Uint64()))
+	sigTx, err := roothash.SignSubmitMsgTx(signer, 0, &transaction.Fee{Gas: 10_000}, &roothash.SubmitMsg{
 		ID:  id,
 		Tag: 42,
 		Data: cbor.Marshal(&TxnCall{
@@ -141,8 +181,6 @@ func (sc *Scenario) submitRuntimeInMsg(ctx context.Context, id common.Namespace,
 			Args:   args,
 		}),
 	})
-	signer := memorySigner.NewTestSigner("oasis in msg test signer: " + time.Now().String())
-	sigTx, err := transaction.Sign(signer, tx)
 	if err != nil {
 		return fmt.Errorf("failed to sign SubmitMsg transaction: %w", err)
 	}
@@ -178,6 +216,9 @@ func (sc *Scenario) submitRuntimeInMsg(ctx context.Context, id common.Namespace,
 			if ev.InMsgProcessed.Tag != 42 {
 				return fmt.Errorf("unexpected tag (got: %d expected: %d)", ev.InMsgProcessed.Tag, 42)
 			}
+			if res := ev.InMsgProcessed.Result; res != nil && !res.IsSuccess() {
+				return fmt.Errorf("incoming message failed (module: %s code: %d)", res.Module, res.Code)
+			}
 		case <-ctx.Done():
 			return ctx.Err()
 		}
@@ -269,24 +269,25 @@ func (sc *runtimeDynamicImpl) Run(ctx context.Context, childEnv *env.Env) error
 		if err = sc.epochTransition(ctx); err != nil {
 			return err
 		}
-
-		// Wait a bit between epoch transitions.
-		time.Sleep(1 * time.Second)
 	}
 
 	// Ensure that runtime got suspended.
-	sc.Logger.Info("checking that runtime got suspended")
-	_, err = sc.Net.Controller().Registry.GetRuntime(ctx, &registry.GetRuntimeQuery{
-		Height: consensus.HeightLatest,
-		ID:     compRtDesc.ID,
-	})
-	switch err {
-	case nil:
-		return fmt.Errorf("runtime should be suspended but it is not")
-	case registry.ErrNoSuchRuntime:
-		// Runtime is suspended.
-	default:
-		return fmt.Errorf("unexpected error while fetching runtime: %w", err)
+	if err = sc.EventuallyByEpoch(ctx, sc.epoch, "runtime suspended", func(ctx context.Context, _ beacon.EpochTime) (bool, error) {
+		_, err := sc.Net.Controller().Registry.GetRuntime(ctx, &registry.GetRuntimeQuery{
+			Height: consensus.HeightLatest,
+			ID:     compRtDesc.ID,
+		})
+		switch err {
+		case nil:
+			return false, nil
+		case registry.ErrNoSuchRuntime:
+			// Runtime is suspended.
+			return true, nil
+		default:
+			return false, fmt.Errorf("unexpected error while fetching runtime: %w", err)
+		}
+	}); err != nil {
+		return err
 	}
 	if err = ensureRuntimeEvents(true); err != nil {
 		return err
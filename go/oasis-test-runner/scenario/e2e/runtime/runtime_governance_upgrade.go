@@ -0,0 +1,207 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/version"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/env"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/oasis"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/oasis/cli"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/scenario"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+)
+
+// RuntimeGovernanceUpgrade is a scenario which tests runtime upgrades of
+// runtimes using the runtime governance model.
+//
+// The compute runtime is switched to the runtime governance model and its
+// compute workers are split between multiple entities. The runtime then adds
+// a new deployment to its own descriptor by emitting an update_runtime message,
+// which exercises the registry acceptance paths for runtime-governed runtimes.
+// After the new deployment becomes active on all compute workers, the upgraded
+// runtime performs another descriptor update to verify that it can still
+// govern itself.
+var RuntimeGovernanceUpgrade scenario.Scenario = newRuntimeGovernanceUpgradeImpl()
+
+type runtimeGovernanceUpgradeImpl struct {
+	Scenario
+
+	upgradedRuntimeIndex int
+}
+
+func newRuntimeGovernanceUpgradeImpl() scenario.Scenario {
+	return &runtimeGovernanceUpgradeImpl{
+		Scenario: *NewScenario("runtime-governance-upgrade", nil),
+	}
+}
+
+func (sc *runtimeGovernanceUpgradeImpl) Clone() scenario.Scenario {
+	return &runtimeGovernanceUpgradeImpl{
+		Scenario: *sc.Scenario.Clone().(*Scenario),
+	}
+}
+
+func (sc *runtimeGovernanceUpgradeImpl) Fixture() (*oasis.NetworkFixture, error) {
+	f, err := sc.Scenario.Fixture()
+	if err != nil {
+		return nil, err
+	}
+
+	if sc.upgradedRuntimeIndex, err = sc.UpgradeComputeRuntimeFixture(f, false); err != nil {
+		return nil, err
+	}
+
+	// Switch both the upgraded runtime and its genesis copy to the runtime governance model.
+	f.Runtimes[sc.upgradedRuntimeIndex].GovernanceModel = registry.GovernanceRuntime
+	f.Runtimes[len(f.Runtimes)-1].GovernanceModel = registry.GovernanceRuntime
+
+	// Operate compute workers by multiple entities.
+	f.Entities = append(f.Entities, oasis.EntityCfg{})
+	for i := range f.ComputeWorkers {
+		f.ComputeWorkers[i].Entity = 1 + i%2
+	}
+
+	return f, nil
+}
+
+func (sc *runtimeGovernanceUpgradeImpl) Run(ctx context.Context, childEnv *env.Env) error {
+	cli := cli.New(childEnv, sc.Net, sc.Logger)
+
+	if err := sc.StartNetworkAndWaitForClientSync(ctx); err != nil {
+		return err
+	}
+
+	rt := sc.Net.Runtimes()[sc.upgradedRuntimeIndex]
+
+	blkCh, sub, err := sc.Net.ClientController().RuntimeClient.WatchBlocks(ctx, rt.ID())
+	if err != nil {
+		return err
+	}
+	defer sub.Close()
+
+	// Make sure the old version is active on all compute nodes.
+	if err = sc.EnsureActiveVersionForComputeWorkers(ctx, rt.ID(), version.MustFromString("0.0.0")); err != nil {
+		return err
+	}
+
+	// Update the key manager policy, if needed.
+	if _, err = sc.UpdateKeyManagerPolicyForDeployment(ctx, childEnv, cli, rt, 1, 0); err != nil {
+		return err
+	}
+
+	epoch, err := sc.Net.Controller().Beacon.GetEpoch(ctx, consensus.HeightLatest)
+	if err != nil {
+		return fmt.Errorf("failed to get current epoch: %w", err)
+	}
+	upgradeEpoch := epoch + 4
+
+	// Add the new deployment via the runtime itself.
+	var rtNonce uint64
+	currentRt, err := sc.fetchRuntime(ctx, rt.ID())
+	if err != nil {
+		return err
+	}
+	newDpl := rt.ToRuntimeDescriptor().Deployments[1]
+	newDpl.ValidFrom = upgradeEpoch
+
+	newRt := *currentRt
+	newRt.Deployments = append(slices.Clone(currentRt.Deployments), newDpl)
+
+	sc.Logger.Info("submitting runtime upgrade via runtime governance",
+		"runtime_id", rt.ID(),
+		"version", newDpl.Version,
+		"valid_from", newDpl.ValidFrom,
+	)
+	if err = sc.updateRuntimeViaRuntime(ctx, blkCh, rtNonce, &newRt); err != nil {
+		return err
+	}
+	rtNonce++
+
+	// Verify that the registry accepted the new deployment.
+	fetchedRt, err := sc.fetchRuntime(ctx, rt.ID())
+	if err != nil {
+		return err
+	}
+	if dpl := fetchedRt.DeploymentForVersion(newDpl.Version); dpl == nil || dpl.ValidFrom != upgradeEpoch {
+		return fmt.Errorf("runtime upgrade via runtime governance wasn't accepted")
+	}
+
+	// Wait for activation epoch.
+	sc.Logger.Info("waiting for runtime upgrade epoch",
+		"runtime_id", rt.ID(),
+		"epoch", upgradeEpoch,
+	)
+	if err = sc.Net.Controller().Beacon.WaitEpoch(ctx, upgradeEpoch); err != nil {
+		return fmt.Errorf("failed to wait for epoch: %w", err)
+	}
+
+	// Make sure the new version is active.
+	if err = sc.EnsureActiveVersionForComputeWorkers(ctx, rt.ID(), version.MustFromString("0.1.0")); err != nil {
+		return err
+	}
+
+	// The upgraded runtime should still be able to update its own descriptor.
+	newRt = *fetchedRt
+	newRt.Executor.MaxMessages = 64
+
+	sc.Logger.Info("submitting descriptor update to upgraded runtime",
+		"runtime_id", rt.ID(),
+	)
+	if err = sc.updateRuntimeViaRuntime(ctx, blkCh, rtNonce, &newRt); err != nil {
+		return err
+	}
+
+	fetchedRt, err = sc.fetchRuntime(ctx, rt.ID())
+	if err != nil {
+		return err
+	}
+	if fetchedRt.Executor.MaxMessages != 64 {
+		return fmt.Errorf("descriptor update via upgraded runtime wasn't accepted")
+	}
+
+	sc.Logger.Info("runtime governance upgrade test passed")
+
+	return nil
+}
+
+// updateRuntimeViaRuntime submits an update_runtime transaction to the runtime, which causes the
+// runtime to emit an update_runtime message, and waits for the round after the one in which the
+// message was emitted. If the message is rejected, the runtime panics and no more blocks are
+// produced.
+func (sc *runtimeGovernanceUpgradeImpl) updateRuntimeViaRuntime(
+	ctx context.Context,
+	blkCh <-chan *roothash.AnnotatedBlock,
+	nonce uint64,
+	rt *registry.Runtime,
+) error {
+	meta, err := sc.submitRuntimeTxMeta(ctx, rt.ID, nonce, "update_runtime", struct {
+		UpdateRuntime registry.Runtime `json:"update_runtime"`
+	}{
+		UpdateRuntime: *rt,
+	})
+	if err != nil {
+		return err
+	}
+	if _, err = unpackRawTxResp(meta.Output); err != nil {
+		return err
+	}
+
+	_, err = sc.WaitRuntimeBlock(blkCh, meta.Round+1)
+	return err
+}
+
+func (sc *runtimeGovernanceUpgradeImpl) fetchRuntime(ctx context.Context, id common.Namespace) (*registry.Runtime, error) {
+	rt, err := sc.Net.Controller().Registry.GetRuntime(ctx, &registry.GetRuntimeQuery{
+		Height: consensus.HeightLatest,
+		ID:     id,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch runtime: %w", err)
+	}
+	return rt, nil
+}
@@ -118,7 +118,7 @@ func (sc *Scenario) Clone() scenario.Scenario {
 	}
 }
 
-func (sc *Scenario) PreInit() error {
+func (sc *Scenario) PreInit(*env.Env) error {
 	return nil
 }
 
@@ -291,7 +291,7 @@ func (sc *Scenario) Run(ctx context.Context, childEnv *env.Env) error {
 	if err := sc.StartNetworkAndTestClient(ctx, childEnv); err != nil {
 		return err
 	}
-	return sc.WaitTestClientAndCheckLogs()
+	return sc.WaitTestClientAndCheckLogs(ctx)
 }
 
 // RegisterScenarios registers all end-to-end scenarios.
@@ -305,6 +305,7 @@ func RegisterScenarios() error {
 		Runtime,
 		RuntimeEncryption,
 		RuntimeGovernance,
+		RuntimeGovernanceUpgrade,
 		RuntimeMessage,
 		// Byzantine executor node.
 		ByzantineExecutorHonest,
@@ -321,6 +322,10 @@ func RegisterScenarios() error {
 		ByzantineExecutorFailureIndicating,
 		ByzantineExecutorSchedulerFailureIndicating,
 		ByzantineExecutorCorruptGetDiff,
+		// Byzantine key manager node.
+		ByzantineKeymanagerRefuseReplication,
+		ByzantineKeymanagerWrongChecksum,
+		ByzantineKeymanagerStaleGeneration,
 		// Storage sync test.
 		StorageSync,
 		StorageSyncFromRegistered,
@@ -328,6 +333,10 @@ func RegisterScenarios() error {
 		StorageEarlyStateSync,
 		// Sentry test.
 		Sentry,
+		// Executor committee network partition test.
+		ExecutorPartition,
+		// Scheduler force-elect test.
+		SchedulerForceElect,
 		// Keymanager tests.
 		KeymanagerMasterSecrets,
 		KeymanagerEphemeralSecrets,
@@ -335,8 +344,11 @@ func RegisterScenarios() error {
 		KeymanagerRestart,
 		KeymanagerReplicate,
 		KeymanagerReplicateMany,
+		KeymanagerChurn,
+		KeymanagerRotationChurn,
 		KeymanagerRotationFailure,
 		KeymanagerUpgrade,
+		KeymanagerSwitchOver,
 		KeymanagerChurp,
 		KeymanagerChurpMany,
 		KeymanagerChurpTxs,
@@ -353,6 +365,8 @@ func RegisterScenarios() error {
 		GovernanceConsensusCancelUpgrade,
 		// Multiple runtimes test.
 		MultipleRuntimes,
+		// Cross-runtime message passing test.
+		CrossRuntimeMessages,
 		// Node shutdown test.
 		NodeShutdown,
 		OffsetRestart,
@@ -396,6 +410,22 @@ func RegisterScenarios() error {
 		// it is identical to the txsource-multi-short, only using fewer nodes
 		// due to SGX CI instance resource constrains.
 		TxSourceMultiShortSGX,
+		// Upgrade-path tests. Non-default, because they require an older
+		// oasis-node binary.
+		UpgradePathValidatorsFirst,
+		UpgradePathComputeFirst,
+		// Load generator test. Non-default, because it is meant for
+		// performance measurements.
+		LoadGeneratorScenario,
+		// Fee market stress test. Non-default, because it floods the
+		// network with transactions and takes a long time.
+		FeeMarketStress,
+		// Epoch transition cost test. Non-default, because it is meant for
+		// performance measurements.
+		EpochTransitionCost,
+		// Storage checkpoint restore test. Non-default, because it generates
+		// a large runtime state and takes a long time.
+		StorageCheckpointRestore,
 	} {
 		if err := cmd.RegisterNondefault(s); err != nil {
 			return err
@@ -0,0 +1,201 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/env"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/oasis"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/scenario"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
+)
+
+const (
+	// cfgSchedulerForceElectEpochs is the number of epochs during which the forced committees
+	// are verified.
+	cfgSchedulerForceElectEpochs = "epochs"
+
+	// schedulerForceElectRuntimeIdx is the fixture index of the runtime with forced committees.
+	schedulerForceElectRuntimeIdx = 1
+)
+
+// SchedulerForceElect is the scheduler force-elect scenario.
+//
+// Compute workers are configured with debug force-elect parameters, including a node that is
+// forced into both the worker and the backup worker roles. Across multiple epochs, the elected
+// executor committees must match the forced configuration and rounds must be proposed by the
+// expected schedulers.
+var SchedulerForceElect scenario.Scenario = newSchedulerForceElectImpl()
+
+type schedulerForceElectImpl struct {
+	Scenario
+}
+
+func newSchedulerForceElectImpl() *schedulerForceElectImpl {
+	sc := &schedulerForceElectImpl{
+		Scenario: *NewScenario("scheduler/force-elect", nil),
+	}
+	sc.Flags.Int(cfgSchedulerForceElectEpochs, 3, "number of epochs to verify the forced committees for")
+
+	return sc
+}
+
+func (sc *schedulerForceElectImpl) Clone() scenario.Scenario {
+	return &schedulerForceElectImpl{
+		Scenario: *sc.Scenario.Clone().(*Scenario),
+	}
+}
+
+func (sc *schedulerForceElectImpl) Fixture() (*oasis.NetworkFixture, error) {
+	f, err := sc.Scenario.Fixture()
+	if err != nil {
+		return nil, err
+	}
+
+	// Use mock epochs so that each election can be verified.
+	f.Network.SetMockEpoch()
+
+	// Force the primary workers in reverse order and make the second primary worker also serve
+	// as a backup worker. The remaining compute worker must not be elected.
+	f.Runtimes[schedulerForceElectRuntimeIdx].Executor.GroupSize = 2
+	f.Runtimes[schedulerForceElectRuntimeIdx].Executor.GroupBackupSize = 1
+	f.ComputeWorkers[2].ForceElectParams = map[int]*scheduler.ForceElectCommitteeRole{
+		schedulerForceElectRuntimeIdx: {
+			Kind:  scheduler.KindComputeExecutor,
+			Roles: []scheduler.Role{scheduler.RoleWorker},
+			Index: 0,
+		},
+	}
+	f.ComputeWorkers[0].ForceElectParams = map[int]*scheduler.ForceElectCommitteeRole{
+		schedulerForceElectRuntimeIdx: {
+			Kind:  scheduler.KindComputeExecutor,
+			Roles: []scheduler.Role{scheduler.RoleWorker, scheduler.RoleBackupWorker},
+			Index: 1,
+		},
+	}
+
+	return f, nil
+}
+
+func (sc *schedulerForceElectImpl) Run(ctx context.Context, _ *env.Env) error {
+	if err := sc.Net.Start(); err != nil {
+		return err
+	}
+
+	fixture, err := sc.Fixture()
+	if err != nil {
+		return err
+	}
+
+	epoch, err := sc.initialEpochTransitions(ctx, fixture)
+	if err != nil {
+		return err
+	}
+
+	numEpochs, _ := sc.Flags.GetInt(cfgSchedulerForceElectEpochs)
+	for i := 0; i < numEpochs; i++ {
+		sc.Logger.Info("verifying forced committee",
+			"epoch", epoch-1,
+		)
+
+		committee, err := sc.verifyForcedCommittee(ctx, fixture)
+		if err != nil {
+			return err
+		}
+
+		// Make sure the forced committee is able to finalize rounds.
+		if _, err = sc.submitKeyValueRuntimeInsertTx(ctx, KeyValueRuntimeID, uint64(i), fmt.Sprintf("force-elect-%d", i), "hello", 0, 0, plaintextTxKind); err != nil {
+			return err
+		}
+		if err = sc.verifyRoundScheduler(ctx, committee); err != nil {
+			return err
+		}
+
+		// Transition to the next epoch, triggering new elections.
+		if err = sc.Net.Controller().SetEpoch(ctx, epoch); err != nil {
+			return fmt.Errorf("failed to set epoch %d: %w", epoch, err)
+		}
+		epoch++
+	}
+
+	return sc.Net.CheckLogWatchers()
+}
+
+func (sc *schedulerForceElectImpl) verifyForcedCommittee(ctx context.Context, fixture *oasis.NetworkFixture) (*scheduler.Committee, error) {
+	committees, err := sc.Net.Controller().Scheduler.GetCommittees(ctx, &scheduler.GetCommitteesRequest{
+		Height:    consensus.HeightLatest,
+		RuntimeID: KeyValueRuntimeID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get committees: %w", err)
+	}
+
+	var committee *scheduler.Committee
+	for _, c := range committees {
+		if c.Kind == scheduler.KindComputeExecutor {
+			committee = c
+			break
+		}
+	}
+	if committee == nil {
+		return nil, fmt.Errorf("executor committee not elected")
+	}
+
+	var workers []signature.PublicKey
+	for _, member := range committee.Members {
+		if member.Role == scheduler.RoleWorker {
+			workers = append(workers, member.PublicKey)
+		}
+	}
+
+	for i, cw := range sc.Net.ComputeWorkers() {
+		params := fixture.ComputeWorkers[i].ForceElectParams[schedulerForceElectRuntimeIdx]
+		if params == nil {
+			if committee.IsMember(cw.NodeID) {
+				return nil, fmt.Errorf("compute worker %s elected without being forced", cw.Name)
+			}
+			continue
+		}
+
+		for _, role := range params.Roles {
+			switch role {
+			case scheduler.RoleWorker:
+				if params.Index >= uint64(len(workers)) || !workers[params.Index].Equal(cw.NodeID) {
+					return nil, fmt.Errorf("compute worker %s not elected as worker at index %d", cw.Name, params.Index)
+				}
+			case scheduler.RoleBackupWorker:
+				if !committee.IsBackupWorker(cw.NodeID) {
+					return nil, fmt.Errorf("compute worker %s not elected as backup worker", cw.Name)
+				}
+			}
+		}
+	}
+
+	return committee, nil
+}
+
+func (sc *schedulerForceElectImpl) verifyRoundScheduler(ctx context.Context, committee *scheduler.Committee) error {
+	md, err := sc.Net.Controller().Roothash.GetLastRoundMetadata(ctx, &roothash.RuntimeRequest{
+		RuntimeID: KeyValueRuntimeID,
+		Height:    consensus.HeightLatest,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get last round metadata: %w", err)
+	}
+	if md.SchedulerID == nil {
+		return fmt.Errorf("last round (%d) has no scheduler", md.Round)
+	}
+
+	expected, ok := committee.Scheduler(md.Round, md.Rank)
+	if !ok {
+		return fmt.Errorf("no scheduler of rank %d for round %d", md.Rank, md.Round)
+	}
+	if !expected.PublicKey.Equal(*md.SchedulerID) {
+		return fmt.Errorf("unexpected scheduler for round %d (expected: %s got: %s)", md.Round, expected.PublicKey, md.SchedulerID)
+	}
+
+	return nil
+}
@@ -0,0 +1,191 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/env"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/log"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/oasis"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/scenario"
+	runtimeClient "github.com/oasisprotocol/oasis-core/go/runtime/client/api"
+	"github.com/oasisprotocol/oasis-core/go/runtime/registry"
+)
+
+const (
+	// cfgNumKeys is the number of generated keys.
+	cfgNumKeys = "num_keys"
+	// cfgKeysPerTx is the number of keys inserted by a single transaction.
+	cfgKeysPerTx = "keys_per_tx"
+	// cfgValueSize is the size of generated values.
+	cfgValueSize = "value_size"
+	// cfgRestoreTimeBudget is the time budget for restoring storage from checkpoints.
+	cfgRestoreTimeBudget = "restore_time_budget"
+	// cfgNumVerifiedKeys is the number of randomly sampled keys verified after restore.
+	cfgNumVerifiedKeys = "num_verified_keys"
+)
+
+// StorageCheckpointRestore is a scenario which generates a large runtime state, wipes a storage
+// worker and verifies that it catches up via checkpoint restore within a time budget.
+var StorageCheckpointRestore = func() scenario.Scenario {
+	sc := &storageCheckpointRestoreImpl{
+		Scenario: *NewScenario("storage-checkpoint-restore", nil),
+	}
+	sc.Flags.Uint64(cfgNumKeys, 200_000, "number of generated keys")
+	sc.Flags.Uint64(cfgKeysPerTx, 5_000, "number of keys inserted by a single transaction")
+	sc.Flags.Uint64(cfgValueSize, 64, "size of generated values in bytes")
+	sc.Flags.Duration(cfgRestoreTimeBudget, 5*time.Minute, "time budget for restoring storage from checkpoints")
+	sc.Flags.Int(cfgNumVerifiedKeys, 100, "number of randomly sampled keys verified after restore")
+
+	return sc
+}()
+
+type storageCheckpointRestoreImpl struct {
+	Scenario
+}
+
+func (sc *storageCheckpointRestoreImpl) Clone() scenario.Scenario {
+	return &storageCheckpointRestoreImpl{
+		Scenario: *sc.Scenario.Clone().(*Scenario),
+	}
+}
+
+func (sc *storageCheckpointRestoreImpl) Fixture() (*oasis.NetworkFixture, error) {
+	f, err := sc.Scenario.Fixture()
+	if err != nil {
+		return nil, err
+	}
+
+	// Make the first compute worker check for checkpoints more often.
+	f.ComputeWorkers[0].CheckpointCheckInterval = 1 * time.Second
+	// Configure runtime for storage checkpointing.
+	f.Runtimes[1].Storage.CheckpointInterval = 10
+	f.Runtimes[1].Storage.CheckpointNumKept = 2
+	f.Runtimes[1].Storage.CheckpointChunkSize = 1024 * 1024
+
+	// One more compute worker which will be wiped and restored from checkpoints.
+	f.ComputeWorkers = append(f.ComputeWorkers, oasis.ComputeWorkerFixture{
+		Entity:                     1,
+		Runtimes:                   []int{1},
+		CheckpointSyncEnabled:      true,
+		LogWatcherHandlerFactories: []log.WatcherHandlerFactory{oasis.LogAssertCheckpointSync()},
+	})
+
+	return f, nil
+}
+
+func (sc *storageCheckpointRestoreImpl) Run(ctx context.Context, _ *env.Env) error {
+	if err := sc.StartNetworkAndWaitForClientSync(ctx); err != nil {
+		return err
+	}
+
+	numKeys, _ := sc.Flags.GetUint64(cfgNumKeys)
+	keysPerTx, _ := sc.Flags.GetUint64(cfgKeysPerTx)
+	valueSize, _ := sc.Flags.GetUint64(cfgValueSize)
+	timeBudget, _ := sc.Flags.GetDuration(cfgRestoreTimeBudget)
+	numVerifiedKeys, _ := sc.Flags.GetInt(cfgNumVerifiedKeys)
+
+	drbg, err := drbgFromSeed([]byte("storage-checkpoint-restore"), []byte("plant_your_seeds"))
+	if err != nil {
+		return err
+	}
+
+	ds := &KeyValueDataSet{
+		Prefix:    "restore/",
+		NumKeys:   numKeys,
+		KeysPerTx: keysPerTx,
+		ValueSize: valueSize,
+	}
+	round, err := sc.GenerateKeyValueData(ctx, KeyValueRuntimeID, ds, drbg)
+	if err != nil {
+		return err
+	}
+
+	// Wait for a checkpoint which covers all generated data.
+	ctrl, err := oasis.NewController(sc.Net.ComputeWorkers()[0].SocketPath())
+	if err != nil {
+		return fmt.Errorf("failed to connect with the first compute node: %w", err)
+	}
+	if _, err = sc.WaitForCheckpoint(ctx, ctrl, KeyValueRuntimeID, round); err != nil {
+		return err
+	}
+
+	// Wipe the runtime state of the last compute worker.
+	worker := sc.Net.ComputeWorkers()[len(sc.Net.ComputeWorkers())-1]
+	sc.Logger.Info("stopping and wiping compute worker",
+		"name", worker.Name,
+	)
+	if err = worker.Stop(); err != nil {
+		return fmt.Errorf("failed to stop compute worker: %w", err)
+	}
+	if err = os.RemoveAll(registry.GetRuntimeStateDir(worker.DataDir(), KeyValueRuntimeID)); err != nil {
+		return fmt.Errorf("failed to wipe runtime state: %w", err)
+	}
+
+	// Restart the worker and measure how long it takes to catch up.
+	latest, err := ctrl.RuntimeClient.GetBlock(ctx, &runtimeClient.GetBlockRequest{
+		RuntimeID: KeyValueRuntimeID,
+		Round:     runtimeClient.RoundLatest,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get latest block: %w", err)
+	}
+
+	start := time.Now()
+	restoreCtx, cancel := context.WithTimeout(ctx, timeBudget)
+	defer cancel()
+
+	if err = worker.Start(); err != nil {
+		return fmt.Errorf("failed to start compute worker: %w", err)
+	}
+	if err = worker.WaitReady(restoreCtx); err != nil {
+		return fmt.Errorf("compute worker did not become ready within %s: %w", timeBudget, err)
+	}
+	workerCtrl, err := oasis.NewController(worker.SocketPath())
+	if err != nil {
+		return fmt.Errorf("failed to connect with the restored compute node: %w", err)
+	}
+	if err = sc.waitStorageSynced(restoreCtx, workerCtrl, latest.Header.Round); err != nil {
+		return fmt.Errorf("compute worker did not catch up within %s: %w", timeBudget, err)
+	}
+
+	sc.Logger.Info("storage restored from checkpoints",
+		"num_keys", numKeys,
+		"round", latest.Header.Round,
+		"duration", time.Since(start),
+		"time_budget", timeBudget,
+	)
+
+	if err = sc.VerifyKeyValueData(ctx, workerCtrl, KeyValueRuntimeID, round, ds, numVerifiedKeys, drbg); err != nil {
+		return err
+	}
+
+	return sc.Net.CheckLogWatchers()
+}
+
+func (sc *storageCheckpointRestoreImpl) waitStorageSynced(ctx context.Context, ctrl *oasis.Controller, round uint64) error {
+	sc.Logger.Info("waiting for storage to sync",
+		"round", round,
+	)
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		status, err := ctrl.GetStatus(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get status: %w", err)
+		}
+		if rt, ok := status.Runtimes[KeyValueRuntimeID]; ok && rt.Storage != nil && rt.Storage.LastFinalizedRound >= round {
+			return nil
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"crypto"
 	"fmt"
 	"math/rand"
+	"sync"
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common"
@@ -173,8 +174,10 @@ func (cli *TestClient) workload(ctx context.Context) error {
 
 	cli.sc.Logger.Info("starting k/v runtime test client")
 
+	// Scenarios may submit requests concurrently.
+	rng := &lockedSource{src: cli.rng}
 	if err := cli.scenario(func(req interface{}) error {
-		return cli.submit(ctx, req, cli.rng)
+		return cli.submit(ctx, req, rng)
 	}); err != nil {
 		return err
 	}
@@ -245,6 +248,21 @@ func (cli *TestClient) submit(ctx context.Context, req interface{}, rng rand.Sou
 			return fmt.Errorf("response does not have expected value (got: '%v', expected: '%v')", rsp, req.Response)
 		}
 
+	case UpsertKeyValueTx:
+		_, err := cli.sc.submitKeyValueRuntimeInsertTx(
+			ctx,
+			KeyValueRuntimeID,
+			rng.Uint64(),
+			req.Key,
+			req.Value,
+			req.Generation,
+			req.ChurpID,
+			req.Kind,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to upsert k/v pair: %w", err)
+		}
+
 	case GetKeyValueTx:
 		rsp, err := cli.sc.submitKeyValueRuntimeGetTx(
 			ctx,
@@ -685,3 +703,27 @@ func drbgFromSeed(domainSep, seed []byte) (rand.Source64, error) {
 
 	return mathrand.New(drbg), nil
 }
+
+// lockedSource is a rand.Source64 which is safe for concurrent use.
+type lockedSource struct {
+	mu  sync.Mutex
+	src rand.Source64
+}
+
+func (s *lockedSource) Int63() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.src.Int63()
+}
+
+func (s *lockedSource) Uint64() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.src.Uint64()
+}
+
+func (s *lockedSource) Seed(seed int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.src.Seed(seed)
+}
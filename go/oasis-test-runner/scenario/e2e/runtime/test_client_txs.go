@@ -43,6 +43,16 @@ type InsertKeyValueTx struct {
 	Kind       uint
 }
 
+// UpsertKeyValueTx inserts a key/value pair to the database without verifying the response
+// (previous value), which is useful when the same key may be written concurrently.
+type UpsertKeyValueTx struct {
+	Key        string
+	Value      string
+	Generation uint64
+	ChurpID    uint8
+	Kind       uint
+}
+
 // GetKeyValueTx retrieves the value stored under the given key from the database,
 // and verifies that the response (current value) contains the expected data.
 type GetKeyValueTx struct {
@@ -3,7 +3,6 @@ package runtime
 import (
 	"context"
 	"crypto"
-	cryptoRand "crypto/rand"
 	"encoding/hex"
 	"fmt"
 	"math"
@@ -181,16 +180,11 @@ type txSourceImpl struct { // nolint: maligned
 	seed string
 }
 
-func (sc *txSourceImpl) PreInit() error {
-	// Generate a new random seed and log it so we can reproduce the run.
+func (sc *txSourceImpl) PreInit(childEnv *env.Env) error {
+	// Derive a new seed from the test runner seed and log it so we can reproduce the run.
 	// Use existing seed, if it already exists.
 	if sc.seed == "" {
-		rawSeed := make([]byte, 16)
-		_, err := cryptoRand.Read(rawSeed)
-		if err != nil {
-			return fmt.Errorf("failed to generate random seed: %w", err)
-		}
-		sc.seed = hex.EncodeToString(rawSeed)
+		sc.seed = hex.EncodeToString(childEnv.DeriveSeed(sc.Name())[:16])
 
 		sc.Logger.Info("using random seed",
 			"seed", sc.seed,
@@ -244,12 +238,14 @@ func (sc *txSourceImpl) Fixture() (*oasis.NetworkFixture, error) {
 			},
 			DebondingInterval: 2,
 			GasCosts: transaction.Costs{
-				staking.GasOpTransfer:      10,
-				staking.GasOpBurn:          10,
-				staking.GasOpAddEscrow:     10,
-				staking.GasOpReclaimEscrow: 10,
-				staking.GasOpAllow:         10,
-				staking.GasOpWithdraw:      10,
+				staking.GasOpTransfer:                10,
+				staking.GasOpBurn:                    10,
+				staking.GasOpAddEscrow:               10,
+				staking.GasOpReclaimEscrow:           10,
+				staking.GasOpAllow:                   10,
+				staking.GasOpWithdraw:                10,
+				staking.GasOpTransferAt:              10,
+				staking.GasOpCancelScheduledTransfer: 10,
 			},
 			MaxAllowances:             32,
 			FeeSplitWeightPropose:     *quantity.NewFromUint64(2),
@@ -0,0 +1,335 @@
+package runtime
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/env"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/oasis"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/scenario"
+)
+
+const (
+	// cfgUpgradePathOldBinary is the path to the old oasis-node binary.
+	cfgUpgradePathOldBinary = "upgrade_path.old_binary"
+	// cfgUpgradePathOldVersion is the released oasis-node version to download
+	// in case the old binary is not provided.
+	cfgUpgradePathOldVersion = "upgrade_path.old_version"
+
+	upgradePathReleaseURL = "https://github.com/oasisprotocol/oasis-core/releases/download/v%[1]s/%[2]s"
+	upgradePathArchive    = "oasis_core_%s_linux_amd64.tar.gz"
+	upgradePathChecksums  = "SHA256SUMS-%s.txt"
+
+	// upgradePathBlocksBetweenNodes is the number of blocks to wait for after
+	// each upgraded node before upgrading the next one.
+	upgradePathBlocksBetweenNodes = 3
+)
+
+var (
+	// UpgradePathValidatorsFirst is the upgrade-path scenario where the network
+	// is started using an older oasis-node binary and the validators are
+	// upgraded first.
+	UpgradePathValidatorsFirst scenario.Scenario = newUpgradePathImpl("validators-first", true)
+
+	// UpgradePathComputeFirst is the upgrade-path scenario where the network is
+	// started using an older oasis-node binary and the runtime nodes are
+	// upgraded first.
+	UpgradePathComputeFirst scenario.Scenario = newUpgradePathImpl("compute-first", false)
+)
+
+type upgradePathImpl struct {
+	Scenario
+
+	validatorsFirst bool
+
+	currentBinary string
+}
+
+func newUpgradePathImpl(name string, validatorsFirst bool) scenario.Scenario {
+	sc := &upgradePathImpl{
+		Scenario: *NewScenario(
+			"upgrade-path/"+name,
+			NewTestClient().WithScenario(InsertScenario),
+		),
+		validatorsFirst: validatorsFirst,
+	}
+	sc.Flags.String(cfgUpgradePathOldBinary, "", "path to the old oasis-node binary")
+	sc.Flags.String(cfgUpgradePathOldVersion, "", "released oasis-node version to download if the old binary is not set")
+
+	return sc
+}
+
+func (sc *upgradePathImpl) Clone() scenario.Scenario {
+	return &upgradePathImpl{
+		Scenario:        *sc.Scenario.Clone().(*Scenario),
+		validatorsFirst: sc.validatorsFirst,
+		currentBinary:   sc.currentBinary,
+	}
+}
+
+func (sc *upgradePathImpl) Fixture() (*oasis.NetworkFixture, error) {
+	f, err := sc.Scenario.Fixture()
+	if err != nil {
+		return nil, err
+	}
+
+	// Runtime nodes are drained via a graceful shutdown request before they
+	// are upgraded.
+	for i := range f.ComputeWorkers {
+		f.ComputeWorkers[i].AllowEarlyTermination = true
+	}
+	for i := range f.Keymanagers {
+		f.Keymanagers[i].AllowEarlyTermination = true
+	}
+
+	return f, nil
+}
+
+func (sc *upgradePathImpl) Init(childEnv *env.Env, net *oasis.Network) error {
+	if err := sc.Scenario.Init(childEnv, net); err != nil {
+		return err
+	}
+
+	oldBinary, err := sc.resolveOldBinary(childEnv)
+	if err != nil {
+		return err
+	}
+
+	// Start the whole network using the old binary, individual nodes are
+	// switched to the current binary during the rolling upgrade.
+	sc.currentBinary = net.Config().NodeBinary
+	net.Config().NodeBinary = oldBinary
+
+	sc.Logger.Info("using old node binary",
+		"old_binary", oldBinary,
+		"current_binary", sc.currentBinary,
+	)
+
+	return nil
+}
+
+func (sc *upgradePathImpl) resolveOldBinary(childEnv *env.Env) (string, error) {
+	if oldBinary, _ := sc.Flags.GetString(cfgUpgradePathOldBinary); oldBinary != "" {
+		return oldBinary, nil
+	}
+
+	oldVersion, _ := sc.Flags.GetString(cfgUpgradePathOldVersion)
+	if oldVersion == "" {
+		return "", fmt.Errorf("one of %s or %s must be set", cfgUpgradePathOldBinary, cfgUpgradePathOldVersion)
+	}
+	oldVersion = strings.TrimPrefix(oldVersion, "v")
+
+	dir, err := childEnv.NewSubDir("old-binary")
+	if err != nil {
+		return "", fmt.Errorf("failed to create old binary directory: %w", err)
+	}
+
+	return downloadReleasedNodeBinary(oldVersion, dir.String())
+}
+
+// downloadReleasedNodeBinary downloads the released oasis-node binary of the
+// given version into the given directory and verifies its checksum.
+func downloadReleasedNodeBinary(version, dir string) (string, error) {
+	archive := fmt.Sprintf(upgradePathArchive, version)
+
+	// Fetch the expected archive checksum first.
+	resp, err := http.Get(fmt.Sprintf(upgradePathReleaseURL, version, fmt.Sprintf(upgradePathChecksums, version))) // nolint: gosec
+	if err != nil {
+		return "", fmt.Errorf("failed to download release checksums: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to download release checksums: status code %d", resp.StatusCode)
+	}
+
+	var expected []byte
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 || fields[1] != archive {
+			continue
+		}
+		if expected, err = hex.DecodeString(fields[0]); err != nil {
+			return "", fmt.Errorf("malformed release checksum: %w", err)
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return "", fmt.Errorf("failed to read release checksums: %w", err)
+	}
+	if expected == nil {
+		return "", fmt.Errorf("release checksum for '%s' not found", archive)
+	}
+
+	// Download the archive.
+	archivePath := filepath.Join(dir, archive)
+	if err = downloadFile(fmt.Sprintf(upgradePathReleaseURL, version, archive), archivePath, expected); err != nil {
+		return "", err
+	}
+
+	// Extract the node binary.
+	af, err := os.Open(archivePath)
+	if err != nil {
+		return "", err
+	}
+	defer af.Close()
+	gz, err := gzip.NewReader(af)
+	if err != nil {
+		return "", fmt.Errorf("malformed release archive: %w", err)
+	}
+	tr := tar.NewReader(gz)
+	for {
+		var hdr *tar.Header
+		hdr, err = tr.Next()
+		switch err {
+		case nil:
+		case io.EOF:
+			return "", fmt.Errorf("oasis-node binary not found in release archive")
+		default:
+			return "", fmt.Errorf("malformed release archive: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg || filepath.Base(hdr.Name) != "oasis-node" {
+			continue
+		}
+
+		binaryPath := filepath.Join(dir, "oasis-node")
+		var bf *os.File
+		if bf, err = os.OpenFile(binaryPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o700); err != nil {
+			return "", err
+		}
+		defer bf.Close()
+		if _, err = io.Copy(bf, tr); err != nil { // nolint: gosec
+			return "", fmt.Errorf("failed to extract oasis-node binary: %w", err)
+		}
+		return binaryPath, nil
+	}
+}
+
+func downloadFile(url, path string, expectedSHA256 []byte) error {
+	resp, err := http.Get(url) // nolint: gosec
+	if err != nil {
+		return fmt.Errorf("failed to download '%s': %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to download '%s': status code %d", url, resp.StatusCode)
+	}
+
+	outf, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer outf.Close()
+
+	outh := sha256.New()
+	if _, err = io.Copy(io.MultiWriter(outf, outh), resp.Body); err != nil {
+		return fmt.Errorf("failed to download '%s': %w", url, err)
+	}
+	if h := outh.Sum(nil); !bytes.Equal(h, expectedSHA256) {
+		return fmt.Errorf("invalid checksum for '%s': got: '%x', expected: '%x'", url, h, expectedSHA256)
+	}
+	return nil
+}
+
+type upgradePathNode struct {
+	*oasis.Node
+
+	// drain is true iff the node should be drained before it is stopped so
+	// that it is not part of any committee when stopped.
+	drain bool
+	// waitReady is true iff the node supports waiting for readiness.
+	waitReady bool
+}
+
+// upgradeOrder returns the nodes in the order in which they should be upgraded.
+func (sc *upgradePathImpl) upgradeOrder() []upgradePathNode {
+	var consensusNodes, runtimeNodes []upgradePathNode
+	for _, n := range sc.Net.Seeds() {
+		consensusNodes = append(consensusNodes, upgradePathNode{Node: n.Node})
+	}
+	for _, n := range sc.Net.Validators() {
+		consensusNodes = append(consensusNodes, upgradePathNode{Node: n.Node, waitReady: true})
+	}
+	for _, n := range sc.Net.Keymanagers() {
+		runtimeNodes = append(runtimeNodes, upgradePathNode{Node: n.Node, drain: true, waitReady: true})
+	}
+	for _, n := range sc.Net.ComputeWorkers() {
+		runtimeNodes = append(runtimeNodes, upgradePathNode{Node: n.Node, drain: true, waitReady: true})
+	}
+	for _, n := range sc.Net.Clients() {
+		runtimeNodes = append(runtimeNodes, upgradePathNode{Node: n.Node, waitReady: true})
+	}
+
+	if sc.validatorsFirst {
+		return append(consensusNodes, runtimeNodes...)
+	}
+	return append(runtimeNodes, consensusNodes...)
+}
+
+func (sc *upgradePathImpl) upgradeNode(ctx context.Context, n upgradePathNode) error {
+	sc.Logger.Info("upgrading node", "node", n.Name)
+
+	if n.drain {
+		if err := n.RequestShutdown(ctx, true); err != nil {
+			return fmt.Errorf("failed to request shutdown of node %s: %w", n.Name, err)
+		}
+	}
+	if err := n.StopGracefully(); err != nil {
+		return fmt.Errorf("failed to stop node %s: %w", n.Name, err)
+	}
+
+	n.SetBinary(sc.currentBinary)
+	if err := n.Start(); err != nil {
+		return fmt.Errorf("failed to start upgraded node %s: %w", n.Name, err)
+	}
+	if n.waitReady {
+		if err := n.WaitReady(ctx); err != nil {
+			return fmt.Errorf("failed to wait for upgraded node %s to become ready: %w", n.Name, err)
+		}
+	}
+
+	// Make sure the network keeps making progress before moving on.
+	if _, err := sc.WaitBlocks(ctx, upgradePathBlocksBetweenNodes); err != nil {
+		return fmt.Errorf("network stopped making progress after upgrading node %s: %w", n.Name, err)
+	}
+
+	return nil
+}
+
+func (sc *upgradePathImpl) Run(ctx context.Context, childEnv *env.Env) error {
+	// Start the network using the old binary and run the initial workload.
+	if err := sc.StartNetworkAndTestClient(ctx, childEnv); err != nil {
+		return err
+	}
+	if err := sc.WaitTestClient(); err != nil {
+		return err
+	}
+
+	// Perform a rolling upgrade to the current binary.
+	sc.Logger.Info("starting rolling upgrade")
+	for _, n := range sc.upgradeOrder() {
+		if err := sc.upgradeNode(ctx, n); err != nil {
+			return err
+		}
+	}
+	sc.Logger.Info("rolling upgrade completed")
+
+	if err := sc.WaitNodesSynced(ctx); err != nil {
+		return err
+	}
+
+	// Run the workload again using state written by the old binary. This also
+	// checks the logs for any round failures.
+	sc.Scenario.TestClient = NewTestClient().WithSeed("seed2").WithScenario(RemoveScenario)
+	return sc.RunTestClientAndCheckLogs(ctx, childEnv)
+}
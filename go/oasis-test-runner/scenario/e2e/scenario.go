@@ -18,6 +18,8 @@ import (
 const (
 	// cfgNodeBinary is the path to oasis-node executable.
 	cfgNodeBinary = "node.binary"
+	// cfgTopologyFile is the path to the topology file placing nodes on remote hosts.
+	cfgTopologyFile = "topology.file"
 )
 
 // ParamsDummyScenario is a dummy instance of E2E scenario used to register global E2E flags.
@@ -46,6 +48,7 @@ func NewScenario(name string) *Scenario {
 		Flags:  env.NewParameterFlagSet(fullName, flag.ContinueOnError),
 	}
 	sc.Flags.String(cfgNodeBinary, "oasis-node", "path to the node binary")
+	sc.Flags.String(cfgTopologyFile, "", "path to the topology file placing nodes on remote hosts")
 
 	return sc
 }
@@ -76,7 +79,7 @@ func (sc *Scenario) Parameters() *env.ParameterFlagSet {
 }
 
 // PreInit implements scenario.Scenario.
-func (sc *Scenario) PreInit() error {
+func (sc *Scenario) PreInit(*env.Env) error {
 	return nil
 }
 
@@ -89,9 +92,18 @@ func (sc *Scenario) Run(context.Context, *env.Env) error {
 func (sc *Scenario) Fixture() (*oasis.NetworkFixture, error) {
 	nodeBinary, _ := sc.Flags.GetString(cfgNodeBinary)
 
+	var topology *oasis.Topology
+	if topologyFile, _ := sc.Flags.GetString(cfgTopologyFile); topologyFile != "" {
+		var err error
+		if topology, err = oasis.LoadTopology(topologyFile); err != nil {
+			return nil, err
+		}
+	}
+
 	return &oasis.NetworkFixture{
 		Network: oasis.NetworkCfg{
 			NodeBinary: nodeBinary,
+			Topology:   topology,
 			Consensus: consensusGenesis.Genesis{
 				Parameters: consensusGenesis.Parameters{
 					GasCosts: transaction.Costs{
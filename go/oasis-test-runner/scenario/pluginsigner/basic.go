@@ -2,7 +2,6 @@ package pluginsigner
 
 import (
 	"context"
-	"crypto/rand"
 	"fmt"
 
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
@@ -31,7 +30,7 @@ func (sc *basicImpl) Clone() scenario.Scenario {
 	}
 }
 
-func (sc *basicImpl) Run(_ context.Context, _ *env.Env) error {
+func (sc *basicImpl) Run(_ context.Context, childEnv *env.Env) error {
 	roles := []signature.SignerRole{
 		signature.SignerEntity,
 		signature.SignerNode,
@@ -58,7 +57,7 @@ func (sc *basicImpl) Run(_ context.Context, _ *env.Env) error {
 
 	// Generate keys using the new factory.
 	for _, v := range roles {
-		if _, err = sf.Generate(v, rand.Reader); err != nil {
+		if _, err = sf.Generate(v, childEnv.Rand("signer keys")); err != nil {
 			return fmt.Errorf("Generate(%v) failed: %w", v, err)
 		}
 	}
@@ -60,7 +60,7 @@ func (sc *pluginSignerImpl) Parameters() *env.ParameterFlagSet {
 	return sc.flags
 }
 
-func (sc *pluginSignerImpl) PreInit() error {
+func (sc *pluginSignerImpl) PreInit(*env.Env) error {
 	return nil
 }
 
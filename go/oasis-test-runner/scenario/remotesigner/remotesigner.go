@@ -59,7 +59,7 @@ func (sc *remoteSignerImpl) Parameters() *env.ParameterFlagSet {
 	return sc.flags
 }
 
-func (sc *remoteSignerImpl) PreInit() error {
+func (sc *remoteSignerImpl) PreInit(*env.Env) error {
 	return nil
 }
 
@@ -37,12 +37,18 @@ type Status struct {
 	// Addresses is a list of configured P2P addresses used when registering the node.
 	Addresses []node.Address `json:"addresses"`
 
+	// Relays is a list of circuit relays through which the node can be reached.
+	Relays []signature.PublicKey `json:"relays,omitempty"`
+
 	// NumPeers is the number of connected peers.
 	NumPeers int `json:"num_peers"`
 
 	// NumConnections is the number of peer connections.
 	NumConnections int `json:"num_connections"`
 
+	// NumRelayedConnections is the number of peer connections established via a circuit relay.
+	NumRelayedConnections int `json:"num_relayed_connections"`
+
 	// Protocols is a set of registered protocols together with the number of connected peers.
 	Protocols map[core.ProtocolID]int `json:"protocols"`
 
@@ -60,6 +66,10 @@ type Service interface {
 	// Addresses returns the P2P addresses of the node.
 	Addresses() []node.Address
 
+	// Relays returns the P2P identifiers of the circuit relays through which the node can be
+	// reached when it is behind NAT.
+	Relays() []signature.PublicKey
+
 	// Peers returns a list of connected P2P peers for the given runtime.
 	Peers(runtimeID common.Namespace) []string
 
@@ -90,6 +100,13 @@ type Service interface {
 	GetMinRepublishInterval() time.Duration
 }
 
+// DebugService is a P2P node service interface supporting debug-only network partitioning.
+type DebugService interface {
+	// SetIsolatedPeers replaces the set of peers the local node is isolated from. In contrast to
+	// blocked peers, isolated peers can reconnect once they are removed from the set.
+	SetIsolatedPeers(peerIDs []core.PeerID)
+}
+
 // Handler is a handler for P2P messages.
 type Handler interface {
 	// DecodeMessage decodes the given incoming message.
@@ -20,6 +20,7 @@ type Config struct {
 	PeerManager       PeerManagerConfig       `yaml:"peer_manager,omitempty"`
 	ConnectionManager ConnectionManagerConfig `yaml:"connection_manager,omitempty"`
 	ConnectionGater   ConnectionGaterConfig   `yaml:"connection_gater,omitempty"`
+	NAT               NATConfig               `yaml:"nat,omitempty"`
 }
 
 // DiscoveryConfig is the P2P discovery configuration structure.
@@ -77,6 +78,25 @@ type ConnectionGaterConfig struct {
 	BlockedPeerIPs []string `yaml:"blocked_peers"`
 }
 
+// NATConfig is the P2P NAT traversal configuration structure.
+type NATConfig struct {
+	// Enable port mapping on the local NAT device via UPnP or NAT-PMP.
+	PortMap bool `yaml:"port_map"`
+	// Enable direct connection upgrades of relayed connections via hole punching.
+	HolePunching bool `yaml:"hole_punching"`
+
+	Relay RelayConfig `yaml:"relay,omitempty"`
+}
+
+// RelayConfig is the P2P circuit relay configuration structure.
+type RelayConfig struct {
+	// Enable serving as a circuit relay for peers behind NAT.
+	Service bool `yaml:"service"`
+	// List of relay node addresses in format P2Ppubkey@IP:port used to make this node reachable
+	// when it is behind NAT.
+	StaticRelays []string `yaml:"static_relays,omitempty"`
+}
+
 // Validate validates the configuration settings.
 func (c *Config) Validate() error {
 	if c.ConnectionManager.MaxNumPeers < 0 {
@@ -96,6 +116,10 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("gossipsub.validate_throttle must be >= 0")
 	}
 
+	if c.NAT.Relay.Service && len(c.NAT.Relay.StaticRelays) > 0 {
+		return fmt.Errorf("nat.relay.service and nat.relay.static_relays are mutually exclusive")
+	}
+
 	return nil
 }
 
@@ -130,5 +154,10 @@ func DefaultConfig() Config {
 		ConnectionGater: ConnectionGaterConfig{
 			BlockedPeerIPs: []string{},
 		},
+		NAT: NATConfig{
+			Relay: RelayConfig{
+				StaticRelays: []string{},
+			},
+		},
 	}
 }
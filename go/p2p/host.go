@@ -32,6 +32,7 @@ type HostConfig struct {
 
 	ConnManagerConfig
 	ConnGaterConfig
+	NATConfig
 }
 
 // NewHost constructs a new libp2p host.
@@ -56,14 +57,17 @@ func NewHost(cfg *HostConfig) (host.Host, *conngater.BasicConnectionGater, error
 		return nil, nil, err
 	}
 
-	host, err := libp2p.New(
+	opts := []libp2p.Option{
 		libp2p.UserAgent(cfg.UserAgent),
 		libp2p.ListenAddrs(cfg.ListenAddr),
 		libp2p.Identity(id),
 		libp2p.ResourceManager(rm),
 		libp2p.ConnectionManager(cm),
 		libp2p.ConnectionGater(cg),
-	)
+	}
+	opts = append(opts, cfg.NATConfig.Options()...)
+
+	host, err := libp2p.New(opts...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -100,11 +104,17 @@ func (cfg *HostConfig) Load() error {
 		return fmt.Errorf("failed to load connection gater config: %w", err)
 	}
 
+	var natCfg NATConfig
+	if err = natCfg.Load(); err != nil {
+		return fmt.Errorf("failed to load NAT config: %w", err)
+	}
+
 	cfg.UserAgent = userAgent
 	cfg.Port = port
 	cfg.ListenAddr = listenAddr
 	cfg.ConnManagerConfig = cmCfg
 	cfg.ConnGaterConfig = cgCfg
+	cfg.NATConfig = natCfg
 
 	return nil
 }
@@ -207,6 +217,86 @@ func (cfg *ConnGaterConfig) Load() error {
 	return nil
 }
 
+// NATConfig describes a set of settings for NAT traversal.
+type NATConfig struct {
+	PortMap      bool
+	HolePunching bool
+	RelayService bool
+	StaticRelays []peer.AddrInfo
+	Relays       []signature.PublicKey
+}
+
+// Options returns the libp2p host options for the configured NAT traversal settings.
+func (cfg *NATConfig) Options() []libp2p.Option {
+	var opts []libp2p.Option
+	if cfg.PortMap {
+		opts = append(opts, libp2p.NATPortMap())
+	}
+	if cfg.HolePunching {
+		opts = append(opts, libp2p.EnableHolePunching())
+	}
+	if cfg.RelayService {
+		// Relays are expected to be publicly reachable, so skip reachability detection and also
+		// help other peers determine whether they are behind NAT.
+		opts = append(opts,
+			libp2p.EnableRelayService(),
+			libp2p.EnableNATService(),
+			libp2p.ForceReachabilityPublic(),
+		)
+	}
+	if len(cfg.StaticRelays) > 0 {
+		// Nodes configured with static relays are assumed to be behind NAT, so skip reachability
+		// detection and immediately obtain relay reservations.
+		opts = append(opts,
+			libp2p.EnableAutoRelayWithStaticRelays(cfg.StaticRelays),
+			libp2p.ForceReachabilityPrivate(),
+		)
+	}
+	return opts
+}
+
+// Load loads NAT traversal configuration.
+func (cfg *NATConfig) Load() error {
+	natCfg := config.GlobalConfig.P2P.NAT
+
+	staticRelays, err := api.AddrInfosFromConsensusAddrs(natCfg.Relay.StaticRelays)
+	if err != nil {
+		return fmt.Errorf("failed to convert static relays' addresses: %w", err)
+	}
+	if l := len(staticRelays); l > node.MaxP2PRelays {
+		return fmt.Errorf("too many static relays (max: %d got: %d)", node.MaxP2PRelays, l)
+	}
+
+	// Multiple addresses of the same relay are merged into a single addr info.
+	relaysMap := make(map[signature.PublicKey]struct{})
+	relays := make([]signature.PublicKey, 0, len(staticRelays))
+	for _, sr := range natCfg.Relay.StaticRelays {
+		var addr node.ConsensusAddress
+		if err = addr.UnmarshalText([]byte(sr)); err != nil {
+			return fmt.Errorf("malformed address (expected pubkey@IP:port): %w", err)
+		}
+		if _, ok := relaysMap[addr.ID]; ok {
+			continue
+		}
+		relaysMap[addr.ID] = struct{}{}
+		relays = append(relays, addr.ID)
+	}
+
+	cfg.PortMap = natCfg.PortMap
+	cfg.HolePunching = natCfg.HolePunching
+	cfg.RelayService = natCfg.Relay.Service
+	cfg.StaticRelays = staticRelays
+	cfg.Relays = relays
+
+	return nil
+}
+
+// isRelayedAddr returns true iff the given multiaddress is a circuit relay address.
+func isRelayedAddr(addr multiaddr.Multiaddr) bool {
+	_, err := addr.ValueForProtocol(multiaddr.P_CIRCUIT)
+	return err == nil
+}
+
 // NewResourceManager constructs a new resource manager.
 func NewResourceManager() (network.ResourceManager, error) {
 	// Use the default resource manager for non-seed nodes.
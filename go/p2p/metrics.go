@@ -24,6 +24,14 @@ var (
 		Name: "oasis_p2p_connections",
 		Help: "Number of P2P connections.",
 	})
+	directConnectionsMetric = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "oasis_p2p_direct_connections",
+		Help: "Number of direct P2P connections.",
+	})
+	relayedConnectionsMetric = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "oasis_p2p_relayed_connections",
+		Help: "Number of P2P connections established via a circuit relay.",
+	})
 	topicsMetric = prometheus.NewGauge(prometheus.GaugeOpts{
 		Name: "oasis_p2p_topics",
 		Help: "Number of supported P2P topics.",
@@ -37,6 +45,8 @@ var (
 		peersMetric,
 		blockedPeersMetric,
 		connectionsMetric,
+		directConnectionsMetric,
+		relayedConnectionsMetric,
 		topicsMetric,
 		protocolsMetric,
 	}
@@ -68,7 +78,21 @@ func (p *p2p) metricsWorker() {
 func (p *p2p) updateMetrics() {
 	peersMetric.Set(float64(len(p.host.Network().Peers())))
 	blockedPeersMetric.Set(float64(len(p.gater.ListBlockedPeers())))
-	connectionsMetric.Set(float64(len(p.host.Network().Conns())))
+	numConns := len(p.host.Network().Conns())
+	numRelayedConns := p.numRelayedConns()
+	connectionsMetric.Set(float64(numConns))
+	directConnectionsMetric.Set(float64(numConns - numRelayedConns))
+	relayedConnectionsMetric.Set(float64(numRelayedConns))
 	topicsMetric.Set(float64(len(p.peerMgr.Topics())))
 	protocolsMetric.Set(float64(len(p.peerMgr.Protocols())))
 }
+
+func (p *p2p) numRelayedConns() int {
+	var n int
+	for _, conn := range p.host.Network().Conns() {
+		if isRelayedAddr(conn.RemoteMultiaddr()) {
+			n++
+		}
+	}
+	return n
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/libp2p/go-libp2p/core"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
 	"github.com/oasisprotocol/oasis-core/go/p2p/api"
 	"github.com/oasisprotocol/oasis-core/go/p2p/rpc"
@@ -50,6 +51,11 @@ func (p *nopP2P) Addresses() []node.Address {
 	return nil
 }
 
+// Implements api.Service.
+func (p *nopP2P) Relays() []signature.PublicKey {
+	return nil
+}
+
 // Implements api.Service.
 func (p *nopP2P) Peers(common.Namespace) []string {
 	return nil
@@ -81,7 +81,12 @@ type p2p struct {
 	gater   *conngater.BasicConnectionGater
 	peerMgr *peermgmt.PeerManager
 
+	blockedLock sync.Mutex
+	blocked     map[core.PeerID]struct{}
+	isolated    map[core.PeerID]struct{}
+
 	registerAddresses []multiaddr.Multiaddr
+	relays            []signature.PublicKey
 	topics            map[string]*topicHandler
 
 	logger *logging.Logger
@@ -150,13 +155,15 @@ func (p *p2p) GetStatus() *api.Status {
 	}
 
 	return &api.Status{
-		PubKey:         p.signer.Public(),
-		PeerID:         p.host.ID(),
-		Addresses:      p.Addresses(),
-		NumPeers:       len(p.host.Network().Peers()),
-		NumConnections: len(p.host.Network().Conns()),
-		Protocols:      protocols,
-		Topics:         topics,
+		PubKey:                p.signer.Public(),
+		PeerID:                p.host.ID(),
+		Addresses:             p.Addresses(),
+		Relays:                p.Relays(),
+		NumPeers:              len(p.host.Network().Peers()),
+		NumConnections:        len(p.host.Network().Conns()),
+		NumRelayedConnections: p.numRelayedConns(),
+		Protocols:             protocols,
+		Topics:                topics,
 	}
 }
 
@@ -173,6 +180,11 @@ func (p *p2p) Addresses() []node.Address {
 
 	var addresses []node.Address
 	for _, v := range addrs {
+		// Relayed addresses are advertised via relays instead.
+		if isRelayedAddr(v) {
+			continue
+		}
+
 		netAddr, err := manet.ToNetAddr(v)
 		if err != nil {
 			panic(err)
@@ -194,6 +206,11 @@ func (p *p2p) Addresses() []node.Address {
 	return addresses
 }
 
+// Implements api.Service.
+func (p *p2p) Relays() []signature.PublicKey {
+	return p.relays
+}
+
 // Implements api.Service.
 func (p *p2p) Peers(runtimeID common.Namespace) []string {
 	allPeers := p.pubsub.ListPeers(protocol.NewTopicKindCommitteeID(p.chainContext, runtimeID))
@@ -315,11 +332,46 @@ func (p *p2p) BlockPeer(peerID core.PeerID) {
 		"peer_id", peerID,
 	)
 
+	p.blockedLock.Lock()
+	p.blocked[peerID] = struct{}{}
+	p.blockedLock.Unlock()
+
 	p.pubsub.BlacklistPeer(peerID)
 	_ = p.gater.BlockPeer(peerID)
 	_ = p.host.Network().ClosePeer(peerID)
 }
 
+// Implements api.DebugService.
+func (p *p2p) SetIsolatedPeers(peerIDs []core.PeerID) {
+	p.blockedLock.Lock()
+	defer p.blockedLock.Unlock()
+
+	isolated := make(map[core.PeerID]struct{}, len(peerIDs))
+	for _, peerID := range peerIDs {
+		isolated[peerID] = struct{}{}
+		if _, ok := p.isolated[peerID]; ok {
+			continue
+		}
+		_ = p.gater.BlockPeer(peerID)
+		_ = p.host.Network().ClosePeer(peerID)
+	}
+	for peerID := range p.isolated {
+		if _, ok := isolated[peerID]; ok {
+			continue
+		}
+		// Peers blocked due to misbehaviour must remain blocked.
+		if _, ok := p.blocked[peerID]; ok {
+			continue
+		}
+		_ = p.gater.UnblockPeer(peerID)
+	}
+	p.isolated = isolated
+
+	p.logger.Warn("updated isolated peers",
+		"num_isolated_peers", len(isolated),
+	)
+}
+
 // Implements api.Service.
 func (p *p2p) RegisterProtocol(pid core.ProtocolID, min int, total int) {
 	p.peerMgr.RegisterProtocol(pid, min, total)
@@ -430,7 +482,9 @@ func New(identity *identity.Identity, consensus consensus.Backend, store *persis
 		peerMgr:           mgr,
 		pubsub:            pubsub,
 		registerAddresses: cfg.Addresses,
+		relays:            cfg.Relays,
 		topics:            make(map[string]*topicHandler),
+		blocked:           make(map[core.PeerID]struct{}),
 		logger:            logging.GetLogger("p2p"),
 	}
 
@@ -438,6 +492,12 @@ func New(identity *identity.Identity, consensus consensus.Backend, store *persis
 		"address", fmt.Sprintf("%+v", host.Addrs()),
 	)
 
+	if len(cfg.StaticRelays) > 0 {
+		p.logger.Info("p2p static relays configured",
+			"relays", cfg.Relays,
+		)
+	}
+
 	if len(cfg.BlockedPeers) > 0 {
 		p.logger.Info("p2p blacklist initialized",
 			"num_blocked_peers", len(cfg.BlockedPeers),
@@ -7,6 +7,7 @@ import (
 
 	"github.com/libp2p/go-libp2p/core"
 	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
 	manet "github.com/multiformats/go-multiaddr/net"
 
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
@@ -281,6 +282,18 @@ func p2pInfoToAddrInfo(pi *node.P2PInfo) (*peer.AddrInfo, error) {
 		}
 		ai.Addrs = append(ai.Addrs, addr)
 	}
+	for _, relay := range pi.Relays {
+		// Relays are dialed using their addresses known to the peerstore.
+		relayID, err := api.PublicKeyToPeerID(relay)
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract public key from relay P2P ID: %w", err)
+		}
+		addr, err := multiaddr.NewMultiaddr(fmt.Sprintf("/p2p/%s/p2p-circuit", relayID))
+		if err != nil {
+			return nil, fmt.Errorf("failed to construct relay address: %w", err)
+		}
+		ai.Addrs = append(ai.Addrs, addr)
+	}
 
 	return &ai, nil
 }
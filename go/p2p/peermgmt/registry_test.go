@@ -0,0 +1,43 @@
+package peermgmt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/p2p/api"
+)
+
+func TestP2PInfoToAddrInfo(t *testing.T) {
+	require := require.New(t)
+
+	var addr node.Address
+	err := addr.UnmarshalText([]byte("8.8.8.8:1234"))
+	require.NoError(err, "UnmarshalText")
+
+	nodeSigner := memorySigner.NewTestSigner("p2p/peermgmt: node")
+	relaySigner := memorySigner.NewTestSigner("p2p/peermgmt: relay")
+
+	pi := node.P2PInfo{
+		ID:        nodeSigner.Public(),
+		Addresses: []node.Address{addr},
+	}
+	info, err := p2pInfoToAddrInfo(&pi)
+	require.NoError(err, "p2pInfoToAddrInfo")
+	require.Len(info.Addrs, 1)
+	require.Equal("/ip4/8.8.8.8/tcp/1234", info.Addrs[0].String())
+
+	// Relayed nodes should be reachable via circuit addresses.
+	pi.Addresses = nil
+	pi.Relays = []signature.PublicKey{relaySigner.Public()}
+	info, err = p2pInfoToAddrInfo(&pi)
+	require.NoError(err, "p2pInfoToAddrInfo")
+
+	relayID, err := api.PublicKeyToPeerID(relaySigner.Public())
+	require.NoError(err, "PublicKeyToPeerID")
+	require.Len(info.Addrs, 1)
+	require.Equal("/p2p/"+relayID.String()+"/p2p-circuit", info.Addrs[0].String())
+}
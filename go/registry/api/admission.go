@@ -130,6 +130,9 @@ func (ewl *EntityWhitelistRuntimeAdmissionPolicy) Verify(
 	if !entIsWhitelisted {
 		return ErrForbidden
 	}
+	if wcfg.IsExpired(epoch) {
+		return ErrForbidden
+	}
 	if len(wcfg.MaxNodes) == 0 {
 		return nil // Any amount of nodes allowed.
 	}
@@ -171,6 +174,65 @@ type EntityWhitelistConfig struct {
 	// the number of nodes is restricted to the specified maximum (where zero
 	// means no nodes allowed), any missing roles imply zero nodes.
 	MaxNodes map[node.RolesMask]uint16 `json:"max_nodes,omitempty"`
+
+	// Expiration is the epoch at which the entity is removed from the whitelist. Zero means that
+	// the entity never expires.
+	Expiration beacon.EpochTime `json:"expiration,omitempty"`
+}
+
+// IsExpired returns true iff the whitelist entry is expired at the given epoch.
+func (wc *EntityWhitelistConfig) IsExpired(epoch beacon.EpochTime) bool {
+	return wc.Expiration != 0 && epoch >= wc.Expiration
+}
+
+// HasEntityExpirations returns true iff any of the whitelisted entities has an expiration set.
+func (ewl *EntityWhitelistRuntimeAdmissionPolicy) HasEntityExpirations() bool {
+	for _, wc := range ewl.Entities {
+		if wc.Expiration != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Apply applies the given entity whitelist update to the whitelist.
+func (ewl *EntityWhitelistRuntimeAdmissionPolicy) Apply(update *RuntimeEntityWhitelistUpdate) {
+	if ewl.Entities == nil {
+		ewl.Entities = make(map[signature.PublicKey]EntityWhitelistConfig)
+	}
+	for _, id := range update.Remove {
+		delete(ewl.Entities, id)
+	}
+	for id, wc := range update.Set {
+		ewl.Entities[id] = wc
+	}
+}
+
+// RuntimeEntityWhitelistUpdate is a request to update the entity whitelist admission policy of
+// a runtime without updating the whole runtime descriptor.
+type RuntimeEntityWhitelistUpdate struct {
+	// RuntimeID is the identifier of the runtime.
+	RuntimeID common.Namespace `json:"runtime_id"`
+
+	// Set are the entities that should be added to the whitelist or whose whitelist
+	// configuration should be replaced.
+	Set map[signature.PublicKey]EntityWhitelistConfig `json:"set,omitempty"`
+
+	// Remove are the entities that should be removed from the whitelist.
+	Remove []signature.PublicKey `json:"remove,omitempty"`
+}
+
+// ValidateBasic performs basic entity whitelist update validity checks.
+func (u *RuntimeEntityWhitelistUpdate) ValidateBasic() error {
+	if len(u.Set) == 0 && len(u.Remove) == 0 {
+		return fmt.Errorf("%w: empty entity whitelist update", ErrInvalidArgument)
+	}
+	for _, id := range u.Remove {
+		if _, ok := u.Set[id]; ok {
+			return fmt.Errorf("%w: entity both set and removed in entity whitelist update", ErrInvalidArgument)
+		}
+	}
+	return nil
 }
 
 // PerRoleAdmissionPolicy is a per-role admission policy.
@@ -0,0 +1,63 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+)
+
+func TestEntityWhitelistExpiration(t *testing.T) {
+	require := require.New(t)
+
+	entity := memorySigner.NewTestSigner("registry/api: entity whitelist expiration entity").Public()
+	ewl := EntityWhitelistRuntimeAdmissionPolicy{
+		Entities: map[signature.PublicKey]EntityWhitelistConfig{
+			entity: {Expiration: 10},
+		},
+	}
+	require.True(ewl.HasEntityExpirations())
+
+	n := &node.Node{EntityID: entity, Roles: node.RoleComputeWorker}
+	rt := &Runtime{}
+	require.NoError(ewl.Verify(context.Background(), nil, n, rt, 9), "entity should be admitted before expiration")
+	require.ErrorIs(ewl.Verify(context.Background(), nil, n, rt, 10), ErrForbidden, "entity should not be admitted at expiration")
+	require.ErrorIs(ewl.Verify(context.Background(), nil, n, rt, 11), ErrForbidden, "entity should not be admitted after expiration")
+
+	ewl.Entities[entity] = EntityWhitelistConfig{}
+	require.False(ewl.HasEntityExpirations())
+	require.NoError(ewl.Verify(context.Background(), nil, n, rt, 100), "entity without expiration should always be admitted")
+}
+
+func TestRuntimeEntityWhitelistUpdate(t *testing.T) {
+	require := require.New(t)
+
+	entity1 := memorySigner.NewTestSigner("registry/api: entity whitelist update entity 1").Public()
+	entity2 := memorySigner.NewTestSigner("registry/api: entity whitelist update entity 2").Public()
+
+	var update RuntimeEntityWhitelistUpdate
+	require.ErrorIs(update.ValidateBasic(), ErrInvalidArgument, "empty update should be invalid")
+
+	update = RuntimeEntityWhitelistUpdate{
+		Set:    map[signature.PublicKey]EntityWhitelistConfig{entity1: {}},
+		Remove: []signature.PublicKey{entity1},
+	}
+	require.ErrorIs(update.ValidateBasic(), ErrInvalidArgument, "update setting and removing the same entity should be invalid")
+
+	ewl := EntityWhitelistRuntimeAdmissionPolicy{
+		Entities: map[signature.PublicKey]EntityWhitelistConfig{
+			entity1: {},
+		},
+	}
+	update = RuntimeEntityWhitelistUpdate{
+		Set:    map[signature.PublicKey]EntityWhitelistConfig{entity2: {Expiration: 5}},
+		Remove: []signature.PublicKey{entity1},
+	}
+	require.NoError(update.ValidateBasic())
+	ewl.Apply(&update)
+	require.Equal(map[signature.PublicKey]EntityWhitelistConfig{entity2: {Expiration: 5}}, ewl.Entities)
+}
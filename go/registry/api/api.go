@@ -6,6 +6,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"regexp"
 	"sort"
 	"time"
 
@@ -120,27 +121,63 @@ var (
 	// has runtimes.
 	ErrEntityHasRuntimes = errors.New(ModuleName, 19, "registry: entity still has runtimes")
 
+	// ErrNodeAlreadyFrozen is the error returned when trying to freeze a node that is
+	// already frozen.
+	ErrNodeAlreadyFrozen = errors.New(ModuleName, 20, "registry: node already frozen")
+
+	// ErrNoSuchNodeTombstone is the error returned when a node tombstone does not exist.
+	ErrNoSuchNodeTombstone = errors.New(ModuleName, 21, "registry: no such node tombstone")
+
+	// ErrNoSuchRuntimeDeployment is the error returned when a runtime deployment does not exist.
+	ErrNoSuchRuntimeDeployment = errors.New(ModuleName, 22, "registry: no such runtime deployment")
+
 	// MethodRegisterEntity is the method name for entity registrations.
 	MethodRegisterEntity = transaction.NewMethodName(ModuleName, "RegisterEntity", entity.SignedEntity{})
+	// MethodRegisterMultiSignedEntity is the method name for registrations of entities
+	// controlled by a signer set.
+	MethodRegisterMultiSignedEntity = transaction.NewMethodName(ModuleName, "RegisterMultiSignedEntity", entity.MultiSignedEntity{})
 	// MethodDeregisterEntity is the method name for entity deregistrations.
 	MethodDeregisterEntity = transaction.NewMethodName(ModuleName, "DeregisterEntity", DeregisterEntity{})
+	// MethodAddEntityNodes is the method name for adding nodes to an entity's node allowlist.
+	MethodAddEntityNodes = transaction.NewMethodName(ModuleName, "AddEntityNodes", EntityNodes{})
+	// MethodRemoveEntityNodes is the method name for removing nodes from an entity's node
+	// allowlist.
+	MethodRemoveEntityNodes = transaction.NewMethodName(ModuleName, "RemoveEntityNodes", EntityNodes{})
 	// MethodRegisterNode is the method name for node registrations.
 	MethodRegisterNode = transaction.NewMethodName(ModuleName, "RegisterNode", node.MultiSignedNode{})
+	// MethodFreezeNode is the method name for freezing nodes.
+	MethodFreezeNode = transaction.NewMethodName(ModuleName, "FreezeNode", FreezeNode{})
 	// MethodUnfreezeNode is the method name for unfreezing nodes.
 	MethodUnfreezeNode = transaction.NewMethodName(ModuleName, "UnfreezeNode", UnfreezeNode{})
 	// MethodRegisterRuntime is the method name for registering runtimes.
 	MethodRegisterRuntime = transaction.NewMethodName(ModuleName, "RegisterRuntime", Runtime{})
+	// MethodWithdrawRuntimeDeployment is the method name for withdrawing scheduled runtime
+	// deployments.
+	MethodWithdrawRuntimeDeployment = transaction.NewMethodName(ModuleName, "WithdrawRuntimeDeployment", WithdrawRuntimeDeployment{})
+	// MethodUpdateRuntimeEntityWhitelist is the method name for updating the entity whitelist
+	// admission policy of a runtime.
+	MethodUpdateRuntimeEntityWhitelist = transaction.NewMethodName(ModuleName, "UpdateRuntimeEntityWhitelist", RuntimeEntityWhitelistUpdate{})
 	// MethodProveFreshness is the method name for freshness proofs.
 	MethodProveFreshness = transaction.NewMethodName(ModuleName, "ProveFreshness", [32]byte{})
+	// MethodSetValidatorElectionOptOut is the method name for changing the validator election
+	// opt-out status of an entity.
+	MethodSetValidatorElectionOptOut = transaction.NewMethodName(ModuleName, "SetValidatorElectionOptOut", ValidatorElectionOptOut{})
 
 	// Methods is the list of all methods supported by the registry backend.
 	Methods = []transaction.MethodName{
 		MethodRegisterEntity,
+		MethodRegisterMultiSignedEntity,
 		MethodDeregisterEntity,
+		MethodAddEntityNodes,
+		MethodRemoveEntityNodes,
 		MethodRegisterNode,
+		MethodFreezeNode,
 		MethodUnfreezeNode,
 		MethodRegisterRuntime,
+		MethodWithdrawRuntimeDeployment,
+		MethodUpdateRuntimeEntityWhitelist,
 		MethodProveFreshness,
+		MethodSetValidatorElectionOptOut,
 	}
 
 	// RuntimesRequiredRoles are the Node roles that require runtimes.
@@ -182,9 +219,18 @@ type Backend interface {
 	// GetNodeStatus returns a node's status.
 	GetNodeStatus(context.Context, *IDQuery) (*NodeStatus, error)
 
+	// GetNodeTombstone returns the tombstone of an expired node that has been removed from the
+	// registry.
+	GetNodeTombstone(context.Context, *IDQuery) (*NodeTombstone, error)
+
 	// GetNodes gets a list of all registered nodes.
 	GetNodes(context.Context, int64) ([]*node.Node, error)
 
+	// GetFilteredNodes gets a page of registered nodes matching the given filter.
+	//
+	// Nodes are returned sorted by node ID in lexicographically ascending order.
+	GetFilteredNodes(context.Context, *GetNodesQuery) (*NodesPage, error)
+
 	// GetNodeByConsensusAddress looks up a node by its consensus address at the
 	// specified block height. The nature and format of the consensus address depends
 	// on the specific consensus backend implementation used.
@@ -194,6 +240,10 @@ type Backend interface {
 	// NodeEvent on node registration changes.
 	WatchNodes(context.Context) (<-chan *NodeEvent, pubsub.ClosableSubscription, error)
 
+	// WatchFilteredNodes returns a channel that produces a stream of
+	// NodeEvent on registration changes of nodes matching the given filter.
+	WatchFilteredNodes(context.Context, *NodeFilter) (<-chan *NodeEvent, pubsub.ClosableSubscription, error)
+
 	// WatchNodeList returns a channel that produces a stream of NodeList.
 	// Upon subscription, the node list for the current epoch will be sent
 	// immediately.
@@ -202,6 +252,11 @@ type Backend interface {
 	// order.
 	WatchNodeList(context.Context) (<-chan *NodeList, pubsub.ClosableSubscription, error)
 
+	// WatchFilteredNodeList returns a channel that produces a stream of NodeList
+	// containing only the nodes matching the given filter. Upon subscription,
+	// the filtered node list for the current epoch will be sent immediately.
+	WatchFilteredNodeList(context.Context, *NodeFilter) (<-chan *NodeList, pubsub.ClosableSubscription, error)
+
 	// GetRuntime gets a runtime by ID.
 	GetRuntime(context.Context, *GetRuntimeQuery) (*Runtime, error)
 
@@ -209,6 +264,12 @@ type Backend interface {
 	// block height.
 	GetRuntimes(context.Context, *GetRuntimesQuery) ([]*Runtime, error)
 
+	// ValidateRuntime performs the full runtime registration validation of the given runtime
+	// descriptor against the latest consensus state without submitting a transaction.
+	//
+	// Transaction signer checks are not performed as there is no transaction.
+	ValidateRuntime(context.Context, *Runtime) error
+
 	// WatchRuntimes returns a stream of Runtime.  Upon subscription,
 	// all runtimes will be sent immediately.
 	WatchRuntimes(context.Context) (<-chan *Runtime, pubsub.ClosableSubscription, error)
@@ -270,16 +331,64 @@ func NewRegisterEntityTx(nonce uint64, fee *transaction.Fee, sigEnt *entity.Sign
 	return transaction.NewTransaction(nonce, fee, MethodRegisterEntity, sigEnt)
 }
 
+// NewRegisterMultiSignedEntityTx creates a new register multi-signed entity transaction.
+func NewRegisterMultiSignedEntityTx(nonce uint64, fee *transaction.Fee, msEnt *entity.MultiSignedEntity) *transaction.Transaction {
+	return transaction.NewTransaction(nonce, fee, MethodRegisterMultiSignedEntity, msEnt)
+}
+
 // NewDeregisterEntityTx creates a new deregister entity transaction.
 func NewDeregisterEntityTx(nonce uint64, fee *transaction.Fee) *transaction.Transaction {
 	return transaction.NewTransaction(nonce, fee, MethodDeregisterEntity, nil)
 }
 
+// EntityNodes is a request by an entity to incrementally change its node allowlist.
+type EntityNodes struct {
+	// Nodes is the list of node identity keys to add to or remove from the allowlist.
+	Nodes []signature.PublicKey `json:"nodes"`
+}
+
+// ValidateBasic performs basic entity nodes request validity checks.
+func (en *EntityNodes) ValidateBasic() error {
+	if len(en.Nodes) == 0 {
+		return fmt.Errorf("%w: empty node list", ErrInvalidArgument)
+	}
+	return validateEntityNodeList(en.Nodes)
+}
+
+func validateEntityNodeList(nodes []signature.PublicKey) error {
+	seen := make(map[signature.PublicKey]bool)
+	for _, id := range nodes {
+		if !id.IsValid() {
+			return fmt.Errorf("%w: malformed node id", ErrInvalidArgument)
+		}
+		if seen[id] {
+			return fmt.Errorf("%w: duplicate nodes", ErrInvalidArgument)
+		}
+		seen[id] = true
+	}
+	return nil
+}
+
+// NewAddEntityNodesTx creates a new add entity nodes transaction.
+func NewAddEntityNodesTx(nonce uint64, fee *transaction.Fee, nodes *EntityNodes) *transaction.Transaction {
+	return transaction.NewTransaction(nonce, fee, MethodAddEntityNodes, nodes)
+}
+
+// NewRemoveEntityNodesTx creates a new remove entity nodes transaction.
+func NewRemoveEntityNodesTx(nonce uint64, fee *transaction.Fee, nodes *EntityNodes) *transaction.Transaction {
+	return transaction.NewTransaction(nonce, fee, MethodRemoveEntityNodes, nodes)
+}
+
 // NewRegisterNodeTx creates a new register node transaction.
 func NewRegisterNodeTx(nonce uint64, fee *transaction.Fee, sigNode *node.MultiSignedNode) *transaction.Transaction {
 	return transaction.NewTransaction(nonce, fee, MethodRegisterNode, sigNode)
 }
 
+// NewFreezeNodeTx creates a new freeze node transaction.
+func NewFreezeNodeTx(nonce uint64, fee *transaction.Fee, freeze *FreezeNode) *transaction.Transaction {
+	return transaction.NewTransaction(nonce, fee, MethodFreezeNode, freeze)
+}
+
 // NewUnfreezeNodeTx creates a new unfreeze node transaction.
 func NewUnfreezeNodeTx(nonce uint64, fee *transaction.Fee, unfreeze *UnfreezeNode) *transaction.Transaction {
 	return transaction.NewTransaction(nonce, fee, MethodUnfreezeNode, unfreeze)
@@ -290,11 +399,42 @@ func NewRegisterRuntimeTx(nonce uint64, fee *transaction.Fee, rt *Runtime) *tran
 	return transaction.NewTransaction(nonce, fee, MethodRegisterRuntime, rt)
 }
 
+// WithdrawRuntimeDeployment is a request to withdraw a scheduled runtime deployment before it
+// becomes active.
+type WithdrawRuntimeDeployment struct {
+	// RuntimeID is the identifier of the runtime.
+	RuntimeID common.Namespace `json:"runtime_id"`
+	// Version is the version of the deployment to withdraw.
+	Version version.Version `json:"version"`
+}
+
+// NewWithdrawRuntimeDeploymentTx creates a new withdraw runtime deployment transaction.
+func NewWithdrawRuntimeDeploymentTx(nonce uint64, fee *transaction.Fee, wd *WithdrawRuntimeDeployment) *transaction.Transaction {
+	return transaction.NewTransaction(nonce, fee, MethodWithdrawRuntimeDeployment, wd)
+}
+
+// NewUpdateRuntimeEntityWhitelistTx creates a new update runtime entity whitelist transaction.
+func NewUpdateRuntimeEntityWhitelistTx(nonce uint64, fee *transaction.Fee, update *RuntimeEntityWhitelistUpdate) *transaction.Transaction {
+	return transaction.NewTransaction(nonce, fee, MethodUpdateRuntimeEntityWhitelist, update)
+}
+
 // NewProveFreshnessTx creates a new prove freshness transaction.
 func NewProveFreshnessTx(nonce uint64, fee *transaction.Fee, blob [32]byte) *transaction.Transaction {
 	return transaction.NewTransaction(nonce, fee, MethodProveFreshness, blob)
 }
 
+// ValidatorElectionOptOut is a request by an entity to change whether it abstains from validator
+// elections.
+type ValidatorElectionOptOut struct {
+	// OptOut is true iff the entity's nodes should not be elected into the validator set.
+	OptOut bool `json:"opt_out"`
+}
+
+// NewSetValidatorElectionOptOutTx creates a new set validator election opt-out transaction.
+func NewSetValidatorElectionOptOutTx(nonce uint64, fee *transaction.Fee, optOut *ValidatorElectionOptOut) *transaction.Transaction {
+	return transaction.NewTransaction(nonce, fee, MethodSetValidatorElectionOptOut, optOut)
+}
+
 // EntityEvent is the event that is returned via WatchEntities to signify
 // entity registration changes and updates.
 type EntityEvent struct {
@@ -341,6 +481,17 @@ func (e *RuntimeSuspendedEvent) EventKind() string {
 	return "runtime_suspended"
 }
 
+// NodeFrozenEvent signifies when node becomes frozen by its owning entity.
+type NodeFrozenEvent struct {
+	NodeID signature.PublicKey `json:"node_id"`
+	Reason FreezeReason        `json:"reason"`
+}
+
+// EventKind returns a string representation of this event's kind.
+func (e *NodeFrozenEvent) EventKind() string {
+	return "node_frozen"
+}
+
 // NodeUnfrozenEvent signifies when node becomes unfrozen.
 type NodeUnfrozenEvent struct {
 	NodeID signature.PublicKey `json:"node_id"`
@@ -351,6 +502,44 @@ func (e *NodeUnfrozenEvent) EventKind() string {
 	return "node_unfrozen"
 }
 
+// NodeExpiredEvent signifies that a node registration has expired.
+//
+// Emitted once, at the first epoch transition at which the node is expired.
+type NodeExpiredEvent struct {
+	NodeID     signature.PublicKey `json:"node_id"`
+	EntityID   signature.PublicKey `json:"entity_id"`
+	Expiration uint64              `json:"expiration"`
+}
+
+// EventKind returns a string representation of this event's kind.
+func (e *NodeExpiredEvent) EventKind() string {
+	return "node_expired"
+}
+
+// RuntimeDeploymentWithdrawnEvent signifies that a scheduled runtime deployment was withdrawn
+// before it became active.
+type RuntimeDeploymentWithdrawnEvent struct {
+	RuntimeID common.Namespace `json:"runtime_id"`
+	Version   version.Version  `json:"version"`
+}
+
+// EventKind returns a string representation of this event's kind.
+func (e *RuntimeDeploymentWithdrawnEvent) EventKind() string {
+	return "runtime_deployment_withdrawn"
+}
+
+// ValidatorElectionOptOutEvent signifies a change of the validator election opt-out status of
+// an entity.
+type ValidatorElectionOptOutEvent struct {
+	EntityID signature.PublicKey `json:"entity_id"`
+	OptOut   bool                `json:"opt_out"`
+}
+
+// EventKind returns a string representation of this event's kind.
+func (e *ValidatorElectionOptOutEvent) EventKind() string {
+	return "validator_election_opt_out"
+}
+
 var _ events.CustomTypedAttribute = (*NodeListEpochEvent)(nil)
 
 // NodeListEpochEvent is the per epoch node list event.
@@ -377,11 +566,26 @@ type Event struct {
 	Height int64     `json:"height,omitempty"`
 	TxHash hash.Hash `json:"tx_hash,omitempty"`
 
-	RuntimeStartedEvent   *RuntimeStartedEvent   `json:"runtime_started,omitempty"`
-	RuntimeSuspendedEvent *RuntimeSuspendedEvent `json:"runtime_suspended,omitempty"`
-	EntityEvent           *EntityEvent           `json:"entity,omitempty"`
-	NodeEvent             *NodeEvent             `json:"node,omitempty"`
-	NodeUnfrozenEvent     *NodeUnfrozenEvent     `json:"node_unfrozen,omitempty"`
+	RuntimeStartedEvent             *RuntimeStartedEvent             `json:"runtime_started,omitempty"`
+	RuntimeSuspendedEvent           *RuntimeSuspendedEvent           `json:"runtime_suspended,omitempty"`
+	RuntimeDeploymentWithdrawnEvent *RuntimeDeploymentWithdrawnEvent `json:"runtime_deployment_withdrawn,omitempty"`
+	EntityEvent                     *EntityEvent                     `json:"entity,omitempty"`
+	NodeEvent                       *NodeEvent                       `json:"node,omitempty"`
+	NodeFrozenEvent                 *NodeFrozenEvent                 `json:"node_frozen,omitempty"`
+	NodeUnfrozenEvent               *NodeUnfrozenEvent               `json:"node_unfrozen,omitempty"`
+	NodeExpiredEvent                *NodeExpiredEvent                `json:"node_expired,omitempty"`
+	ValidatorElectionOptOutEvent    *ValidatorElectionOptOutEvent    `json:"validator_election_opt_out,omitempty"`
+}
+
+// NodeTombstone is the descriptor of an expired node that has been removed from the registry.
+//
+// Tombstones are kept for a limited number of epochs as configured by the node tombstone
+// retention consensus parameter.
+type NodeTombstone struct {
+	// Node is the last descriptor of the removed node.
+	Node *node.Node `json:"node"`
+	// RemovedAt is the epoch at which the node was removed from the registry.
+	RemovedAt beacon.EpochTime `json:"removed_at"`
 }
 
 // NodeList is a per-epoch immutable node list.
@@ -389,6 +593,93 @@ type NodeList struct {
 	Nodes []*node.Node `json:"nodes"`
 }
 
+// NodeFilter is a filter for registered nodes.
+//
+// A node matches the filter if it matches all of the specified criteria.
+type NodeFilter struct {
+	// EntityID is the identifier of the entity that must own the node.
+	EntityID *signature.PublicKey `json:"entity_id,omitempty"`
+	// Roles are the roles that the node must have.
+	Roles node.RolesMask `json:"roles,omitempty"`
+	// RuntimeID is the identifier of the runtime the node must be registered for.
+	RuntimeID *common.Namespace `json:"runtime_id,omitempty"`
+}
+
+// Matches returns true iff the given node matches the filter.
+func (f *NodeFilter) Matches(n *node.Node) bool {
+	if f == nil {
+		return true
+	}
+	if f.EntityID != nil && !f.EntityID.Equal(n.EntityID) {
+		return false
+	}
+	if f.Roles != 0 && !n.HasRoles(f.Roles) {
+		return false
+	}
+	if f.RuntimeID != nil && !n.HasRuntime(*f.RuntimeID) {
+		return false
+	}
+	return true
+}
+
+// FilterNodes returns the nodes matching the given filter.
+func FilterNodes(nodes []*node.Node, filter *NodeFilter) []*node.Node {
+	var filtered []*node.Node
+	for _, n := range nodes {
+		if filter.Matches(n) {
+			filtered = append(filtered, n)
+		}
+	}
+	return filtered
+}
+
+// GetNodesQuery is a filtered and paginated registry node query.
+type GetNodesQuery struct {
+	Height int64 `json:"height"`
+
+	// Filter is the filter that the returned nodes must match.
+	Filter NodeFilter `json:"filter"`
+	// After is the node identifier after which the returned nodes start. It should be set to
+	// the Next field of the previous page in order to retrieve the following page.
+	After *signature.PublicKey `json:"after,omitempty"`
+	// Limit is the maximum number of nodes to return. Zero means no limit.
+	Limit uint32 `json:"limit,omitempty"`
+}
+
+// NodesPage is a page of registered nodes.
+type NodesPage struct {
+	// Nodes are the nodes in the page, sorted by node ID in lexicographically ascending order.
+	Nodes []*node.Node `json:"nodes"`
+	// Next is the cursor of the following page, if there are more nodes to retrieve.
+	Next *signature.PublicKey `json:"next,omitempty"`
+}
+
+// PaginateNodes filters the given nodes and returns the page of nodes requested by the query.
+//
+// The passed node list is sorted in place.
+func PaginateNodes(nodes []*node.Node, query *GetNodesQuery) *NodesPage {
+	SortNodeList(nodes)
+
+	page := NodesPage{
+		Nodes: []*node.Node{},
+	}
+	for _, n := range nodes {
+		if query.After != nil && bytes.Compare(n.ID[:], query.After[:]) <= 0 {
+			continue
+		}
+		if !query.Filter.Matches(n) {
+			continue
+		}
+		if query.Limit > 0 && len(page.Nodes) >= int(query.Limit) {
+			next := page.Nodes[len(page.Nodes)-1].ID
+			page.Next = &next
+			break
+		}
+		page.Nodes = append(page.Nodes, n)
+	}
+	return &page
+}
+
 // NodeLookup interface implements various ways for the verification
 // functions to look-up nodes in the registry's state.
 type NodeLookup interface {
@@ -454,12 +745,64 @@ func VerifyRegisterEntityArgs(logger *logging.Logger, sigEnt *entity.SignedEntit
 		)
 		return nil, ErrInvalidArgument
 	}
+	if err := verifyEntityDescriptor(logger, &ent, isGenesis, isSanityCheck); err != nil {
+		return nil, err
+	}
+
+	return &ent, nil
+}
+
+// VerifyRegisterMultiSignedEntityArgs verifies arguments for RegisterMultiSignedEntity.
+//
+// Besides the signatures being valid, the descriptor must define a signer set and must be signed
+// by a threshold of its members. Whether the signers are authorized to change the currently
+// registered descriptor (if any) must be checked separately.
+func VerifyRegisterMultiSignedEntityArgs(logger *logging.Logger, msEnt *entity.MultiSignedEntity, isGenesis, isSanityCheck bool) (*entity.Entity, error) {
+	var ent entity.Entity
+	if msEnt == nil {
+		return nil, ErrInvalidArgument
+	}
+
+	var ctx signature.Context
+	switch isGenesis {
+	case true:
+		ctx = RegisterGenesisEntitySignatureContext
+	case false:
+		ctx = RegisterEntitySignatureContext
+	}
+
+	if err := msEnt.Open(ctx, &ent); err != nil {
+		logger.Error("RegisterMultiSignedEntity: invalid signature",
+			"signed_entity", msEnt,
+		)
+		return nil, ErrInvalidSignature
+	}
+	if err := verifyEntityDescriptor(logger, &ent, isGenesis, isSanityCheck); err != nil {
+		return nil, err
+	}
+	if ent.SignerSet == nil {
+		logger.Error("RegisterMultiSignedEntity: entity descriptor has no signer set",
+			"entity", ent,
+		)
+		return nil, fmt.Errorf("%w: missing signer set", ErrInvalidArgument)
+	}
+	if !ent.SignerSet.Verify(msEnt.Signatures) {
+		logger.Error("RegisterMultiSignedEntity: insufficient signatures",
+			"entity", ent,
+		)
+		return nil, fmt.Errorf("%w: insufficient signer set signatures", ErrInvalidSignature)
+	}
+
+	return &ent, nil
+}
+
+func verifyEntityDescriptor(logger *logging.Logger, ent *entity.Entity, isGenesis, isSanityCheck bool) error {
 	if err := ent.ValidateBasic(!isGenesis && !isSanityCheck); err != nil {
 		logger.Error("RegisterEntity: invalid entity descriptor",
 			"entity", ent,
 			"err", err,
 		)
-		return nil, ErrInvalidArgument
+		return ErrInvalidArgument
 	}
 
 	// Ensure the node list has no duplicates.
@@ -469,19 +812,19 @@ func VerifyRegisterEntityArgs(logger *logging.Logger, sigEnt *entity.SignedEntit
 			logger.Error("RegisterEntity: malformed node id",
 				"entity", ent,
 			)
-			return nil, fmt.Errorf("%w: malformed node id", ErrInvalidArgument)
+			return fmt.Errorf("%w: malformed node id", ErrInvalidArgument)
 		}
 
 		if nodesMap[v] {
 			logger.Error("RegisterEntity: duplicate entries in node list",
 				"entity", ent,
 			)
-			return nil, fmt.Errorf("%w: duplicate nodes", ErrInvalidArgument)
+			return fmt.Errorf("%w: duplicate nodes", ErrInvalidArgument)
 		}
 		nodesMap[v] = true
 	}
 
-	return &ent, nil
+	return nil
 }
 
 // VerifyRegisterNodeArgs verifies arguments for RegisterNode.
@@ -554,6 +897,23 @@ func VerifyRegisterNodeArgs( // nolint: gocyclo
 		)
 		return nil, nil, fmt.Errorf("%w: node public key not found in entity's node list", ErrInvalidArgument)
 	}
+	if entity.HasNode(n.ID) && (!isSanityCheck || isGenesis) {
+		runtimes := make([]common.Namespace, 0, len(n.Runtimes))
+		for _, rt := range n.Runtimes {
+			if rt == nil {
+				// Malformed runtimes are rejected below.
+				continue
+			}
+			runtimes = append(runtimes, rt.ID)
+		}
+		if !entity.AuthorizesNode(n.ID, n.Roles, runtimes) {
+			logger.Debug("RegisterNode: node not authorized by entity for its roles or runtimes",
+				"signed_node", sigNode,
+				"node", n,
+			)
+			return nil, nil, fmt.Errorf("%w: node not authorized by entity for its roles or runtimes", ErrForbidden)
+		}
+	}
 
 	// Expired registrations are allowed here because this routine is abused
 	// by the invariant checker, and expired registrations are persisted in
@@ -570,6 +930,15 @@ func VerifyRegisterNodeArgs( // nolint: gocyclo
 		return nil, nil, fmt.Errorf("%w: expiration period greater than allowed", ErrInvalidArgument)
 	}
 
+	// Validate node metadata.
+	if err := VerifyNodeMetadata(params, n.Metadata); err != nil {
+		logger.Error("RegisterNode: invalid node metadata",
+			"node", n,
+			"err", err,
+		)
+		return nil, nil, err
+	}
+
 	// TODO: Key manager nodes maybe should be restricted to only being a
 	// key manager at the expense of breaking some of our test configs.
 
@@ -726,6 +1095,10 @@ func VerifyRegisterNodeArgs( // nolint: gocyclo
 		// All new (re)registrations will require a p2p address for all nodes,
 		// and will reject descriptors otherwise.
 	}
+	// Nodes which are only reachable via circuit relays do not need to advertise addresses.
+	if len(n.P2P.Relays) > 0 {
+		p2pAddressRequired = false
+	}
 
 	if err := verifyAddresses(params, p2pAddressRequired, n.P2P.Addresses); err != nil {
 		addrs, _ := json.Marshal(n.P2P.Addresses)
@@ -868,6 +1241,34 @@ func VerifyAddress(addr node.Address, allowUnroutable bool) error {
 	return nil
 }
 
+// VerifyNodeMetadata verifies the given node metadata against the size limit and the optional
+// metadata schema in the consensus parameters.
+func VerifyNodeMetadata(params *ConsensusParameters, md node.Metadata) error {
+	if len(md) == 0 {
+		return nil
+	}
+	if size := md.Size(); size > params.MaxNodeMetadataSize {
+		return fmt.Errorf("%w: node metadata too big (max size: %d, size: %d)", ErrInvalidArgument, params.MaxNodeMetadataSize, size)
+	}
+	if params.NodeMetadataSchema == nil {
+		return nil
+	}
+	for k, v := range md {
+		pattern, ok := params.NodeMetadataSchema[k]
+		if !ok {
+			return fmt.Errorf("%w: node metadata key '%s' not allowed", ErrInvalidArgument, k)
+		}
+		re, err := regexp.Compile("^(?:" + pattern + ")$")
+		if err != nil {
+			return fmt.Errorf("%w: malformed node metadata schema for key '%s': %w", ErrInvalidArgument, k, err)
+		}
+		if !re.MatchString(v) {
+			return fmt.Errorf("%w: node metadata value for key '%s' does not match schema", ErrInvalidArgument, k)
+		}
+	}
+	return nil
+}
+
 func verifyAddresses(params *ConsensusParameters, addressRequired bool, addresses interface{}) error {
 	switch addrs := addresses.(type) {
 	case []node.ConsensusAddress:
@@ -1364,6 +1765,11 @@ type Genesis struct {
 
 	// Entities is the initial list of entities.
 	Entities []*entity.SignedEntity `json:"entities,omitempty"`
+	// MultiSignedEntities is the initial list of entities controlled by a signer set.
+	MultiSignedEntities []*entity.MultiSignedEntity `json:"multi_signed_entities,omitempty"`
+	// EntityNodes are the node allowlists of entities which were changed incrementally and
+	// override the node allowlists in the signed entity descriptors.
+	EntityNodes map[signature.PublicKey][]signature.PublicKey `json:"entity_nodes,omitempty"`
 
 	// Runtimes is the initial list of runtimes.
 	Runtimes []*Runtime `json:"runtimes,omitempty"`
@@ -1375,6 +1781,12 @@ type Genesis struct {
 
 	// NodeStatuses is a set of node statuses.
 	NodeStatuses map[signature.PublicKey]*NodeStatus `json:"node_statuses,omitempty"`
+
+	// NodeTombstones are the tombstones of expired nodes that have been removed from the registry.
+	NodeTombstones []*NodeTombstone `json:"node_tombstones,omitempty"`
+
+	// ValidatorElectionOptOuts is the list of entities that abstain from validator elections.
+	ValidatorElectionOptOuts []signature.PublicKey `json:"validator_election_opt_outs,omitempty"`
 }
 
 // ConsensusParameters are the registry consensus parameters.
@@ -1419,6 +1831,19 @@ type ConsensusParameters struct {
 
 	// MaxRuntimeDeployments is the maximum number of runtime deployments.
 	MaxRuntimeDeployments uint8 `json:"max_runtime_deployments,omitempty"`
+
+	// MaxNodeMetadataSize is the maximum size of node metadata (sum of the lengths of all keys
+	// and values). Zero means that node metadata is not allowed.
+	MaxNodeMetadataSize uint64 `json:"max_node_metadata_size,omitempty"`
+
+	// NodeMetadataSchema is the optional node metadata schema. When set, it maps each allowed
+	// metadata key to a regular expression that the corresponding value must fully match.
+	NodeMetadataSchema map[string]string `json:"node_metadata_schema,omitempty"`
+
+	// NodeTombstoneRetention is the number of epochs for which the descriptors of expired nodes
+	// are kept as tombstones after the nodes are removed from the registry. Zero means that no
+	// tombstones are kept.
+	NodeTombstoneRetention beacon.EpochTime `json:"node_tombstone_retention,omitempty"`
 }
 
 // ConsensusParameterChanges are allowed registry consensus parameter changes.
@@ -1443,6 +1868,15 @@ type ConsensusParameterChanges struct {
 
 	// MaxRuntimeDeployments is the new maximum number of runtime deployments.
 	MaxRuntimeDeployments *uint8 `json:"max_runtime_deployments,omitempty"`
+
+	// MaxNodeMetadataSize is the new maximum size of node metadata.
+	MaxNodeMetadataSize *uint64 `json:"max_node_metadata_size,omitempty"`
+
+	// NodeMetadataSchema is the new node metadata schema.
+	NodeMetadataSchema *map[string]string `json:"node_metadata_schema,omitempty"`
+
+	// NodeTombstoneRetention is the new node tombstone retention.
+	NodeTombstoneRetention *beacon.EpochTime `json:"node_tombstone_retention,omitempty"`
 }
 
 // Apply applies changes to the given consensus parameters.
@@ -1468,6 +1902,15 @@ func (c *ConsensusParameterChanges) Apply(params *ConsensusParameters) error {
 	if c.MaxRuntimeDeployments != nil {
 		params.MaxRuntimeDeployments = *c.MaxRuntimeDeployments
 	}
+	if c.MaxNodeMetadataSize != nil {
+		params.MaxNodeMetadataSize = *c.MaxNodeMetadataSize
+	}
+	if c.NodeMetadataSchema != nil {
+		params.NodeMetadataSchema = *c.NodeMetadataSchema
+	}
+	if c.NodeTombstoneRetention != nil {
+		params.NodeTombstoneRetention = *c.NodeTombstoneRetention
+	}
 	return nil
 }
 
@@ -1476,30 +1919,49 @@ const (
 	GasOpRegisterEntity transaction.Op = "register_entity"
 	// GasOpDeregisterEntity is the gas operation identifier for entity deregistration.
 	GasOpDeregisterEntity transaction.Op = "deregister_entity"
+	// GasOpUpdateEntityNodes is the gas operation identifier for incremental entity node
+	// allowlist changes.
+	GasOpUpdateEntityNodes transaction.Op = "update_entity_nodes"
 	// GasOpRegisterNode is the gas operation identifier for entity registration.
 	GasOpRegisterNode transaction.Op = "register_node"
+	// GasOpFreezeNode is the gas operation identifier for freezing nodes.
+	GasOpFreezeNode transaction.Op = "freeze_node"
 	// GasOpUnfreezeNode is the gas operation identifier for unfreezing nodes.
 	GasOpUnfreezeNode transaction.Op = "unfreeze_node"
 	// GasOpRegisterRuntime is the gas operation identifier for runtime registration.
 	GasOpRegisterRuntime transaction.Op = "register_runtime"
+	// GasOpWithdrawRuntimeDeployment is the gas operation identifier for withdrawing scheduled
+	// runtime deployments.
+	GasOpWithdrawRuntimeDeployment transaction.Op = "withdraw_runtime_deployment"
+	// GasOpUpdateRuntimeEntityWhitelist is the gas operation identifier for updating the entity
+	// whitelist admission policy of a runtime.
+	GasOpUpdateRuntimeEntityWhitelist transaction.Op = "update_runtime_entity_whitelist"
 	// GasOpRuntimeEpochMaintenance is the gas operation identifier for per-epoch
 	// runtime maintenance costs.
 	GasOpRuntimeEpochMaintenance transaction.Op = "runtime_epoch_maintenance"
 	// GasOpProveFreshness is the gas operation identifier for freshness proofs.
 	GasOpProveFreshness transaction.Op = "prove_freshness"
+	// GasOpSetValidatorElectionOptOut is the gas operation identifier for changing the validator
+	// election opt-out status of an entity.
+	GasOpSetValidatorElectionOptOut transaction.Op = "set_validator_election_opt_out"
 )
 
 // XXX: Define reasonable default gas costs.
 
 // DefaultGasCosts are the "default" gas costs for operations.
 var DefaultGasCosts = transaction.Costs{
-	GasOpRegisterEntity:          1000,
-	GasOpDeregisterEntity:        1000,
-	GasOpRegisterNode:            1000,
-	GasOpUnfreezeNode:            1000,
-	GasOpRegisterRuntime:         1000,
-	GasOpRuntimeEpochMaintenance: 1000,
-	GasOpProveFreshness:          1000,
+	GasOpRegisterEntity:               1000,
+	GasOpDeregisterEntity:             1000,
+	GasOpUpdateEntityNodes:            1000,
+	GasOpRegisterNode:                 1000,
+	GasOpFreezeNode:                   1000,
+	GasOpUnfreezeNode:                 1000,
+	GasOpRegisterRuntime:              1000,
+	GasOpWithdrawRuntimeDeployment:    1000,
+	GasOpUpdateRuntimeEntityWhitelist: 1000,
+	GasOpRuntimeEpochMaintenance:      1000,
+	GasOpProveFreshness:               1000,
+	GasOpSetValidatorElectionOptOut:   1000,
 }
 
 const (
@@ -1587,3 +2049,21 @@ func StakeThresholdsForRuntime(rt *Runtime) (thresholds []staking.StakeThreshold
 	}
 	return
 }
+
+func init() {
+	transaction.RegisterGasOps(
+		ModuleName,
+		GasOpRegisterEntity,
+		GasOpDeregisterEntity,
+		GasOpUpdateEntityNodes,
+		GasOpRegisterNode,
+		GasOpFreezeNode,
+		GasOpUnfreezeNode,
+		GasOpRegisterRuntime,
+		GasOpWithdrawRuntimeDeployment,
+		GasOpUpdateRuntimeEntityWhitelist,
+		GasOpRuntimeEpochMaintenance,
+		GasOpProveFreshness,
+		GasOpSetValidatorElectionOptOut,
+	)
+}
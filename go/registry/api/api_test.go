@@ -1,6 +1,7 @@
 package api
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -221,6 +222,41 @@ func TestVerifyRegisterNodeArgs(t *testing.T) {
 	}
 }
 
+func TestVerifyNodeMetadata(t *testing.T) {
+	require := require.New(t)
+
+	md := node.Metadata{
+		node.MetadataKeyContact: "ops@example.com",
+		node.MetadataKeyRegion:  "eu-west",
+	}
+
+	params := &ConsensusParameters{}
+	require.NoError(VerifyNodeMetadata(params, nil), "empty metadata should always be allowed")
+	err := VerifyNodeMetadata(params, md)
+	require.ErrorIs(err, ErrInvalidArgument, "metadata should be rejected when not allowed")
+
+	params.MaxNodeMetadataSize = 16
+	err = VerifyNodeMetadata(params, md)
+	require.ErrorIs(err, ErrInvalidArgument, "metadata exceeding the size limit should be rejected")
+
+	params.MaxNodeMetadataSize = 128
+	require.NoError(VerifyNodeMetadata(params, md), "metadata within the size limit should be allowed")
+
+	params.NodeMetadataSchema = map[string]string{
+		node.MetadataKeyContact: `[^@]+@[^@]+`,
+		node.MetadataKeyRegion:  `[a-z]+-[a-z]+`,
+	}
+	require.NoError(VerifyNodeMetadata(params, md), "metadata matching the schema should be allowed")
+
+	params.NodeMetadataSchema[node.MetadataKeyRegion] = `[a-z]+`
+	err = VerifyNodeMetadata(params, md)
+	require.ErrorIs(err, ErrInvalidArgument, "metadata not matching the schema should be rejected")
+
+	delete(params.NodeMetadataSchema, node.MetadataKeyRegion)
+	err = VerifyNodeMetadata(params, md)
+	require.ErrorIs(err, ErrInvalidArgument, "metadata keys not in the schema should be rejected")
+}
+
 func TestVerifyNodeUpdate(t *testing.T) {
 	logger := logging.GetLogger("registry/api/tests")
 
@@ -352,3 +388,73 @@ func TestVerifyNodeUpdate(t *testing.T) {
 		require.Equal(t, tc.err, err, tc.msg)
 	}
 }
+
+func TestPaginateNodes(t *testing.T) {
+	require := require.New(t)
+
+	var rtID common.Namespace
+	require.NoError(rtID.UnmarshalHex("8000000000000000000000000000000000000000000000000000000000000000"), "UnmarshalHex")
+
+	entityA := memorySigner.NewTestSigner("registry/api: paginate nodes entity A").Public()
+	entityB := memorySigner.NewTestSigner("registry/api: paginate nodes entity B").Public()
+
+	var nodes []*node.Node
+	for i := 0; i < 6; i++ {
+		n := &node.Node{
+			ID:       memorySigner.NewTestSigner(fmt.Sprintf("registry/api: paginate nodes node %d", i)).Public(),
+			EntityID: entityA,
+			Roles:    node.RoleValidator,
+		}
+		if i%2 == 1 {
+			n.EntityID = entityB
+			n.Roles = node.RoleComputeWorker
+			n.Runtimes = []*node.Runtime{{ID: rtID}}
+		}
+		nodes = append(nodes, n)
+	}
+
+	// Without a filter and limit all nodes should be returned in a single sorted page.
+	page := PaginateNodes(append([]*node.Node{}, nodes...), &GetNodesQuery{})
+	require.Len(page.Nodes, len(nodes), "all nodes should be returned")
+	require.Nil(page.Next, "there should be no next page")
+	for i := 1; i < len(page.Nodes); i++ {
+		require.Equal(-1, bytes.Compare(page.Nodes[i-1].ID[:], page.Nodes[i].ID[:]), "nodes should be sorted")
+	}
+
+	// Filters should be applied.
+	for _, filter := range []NodeFilter{
+		{EntityID: &entityB},
+		{Roles: node.RoleComputeWorker},
+		{RuntimeID: &rtID},
+		{EntityID: &entityB, Roles: node.RoleComputeWorker, RuntimeID: &rtID},
+	} {
+		page = PaginateNodes(append([]*node.Node{}, nodes...), &GetNodesQuery{Filter: filter})
+		require.Len(page.Nodes, 3, "filtered nodes should be returned")
+		for _, n := range page.Nodes {
+			require.True(n.EntityID.Equal(entityB), "only matching nodes should be returned")
+		}
+	}
+	page = PaginateNodes(append([]*node.Node{}, nodes...), &GetNodesQuery{Filter: NodeFilter{EntityID: &entityA, RuntimeID: &rtID}})
+	require.Empty(page.Nodes, "no nodes should match")
+
+	// Pages should cover all matching nodes exactly once.
+	var (
+		paged []*node.Node
+		after *signature.PublicKey
+	)
+	for {
+		page = PaginateNodes(append([]*node.Node{}, nodes...), &GetNodesQuery{
+			Filter: NodeFilter{Roles: node.RoleValidator},
+			After:  after,
+			Limit:  2,
+		})
+		require.LessOrEqual(len(page.Nodes), 2, "page should respect the limit")
+		paged = append(paged, page.Nodes...)
+		if page.Next == nil {
+			break
+		}
+		after = page.Next
+	}
+	require.Len(paged, 3, "paging should return all matching nodes")
+	require.Equal(FilterNodes(page.Nodes, &NodeFilter{Roles: node.RoleValidator}), page.Nodes)
+}
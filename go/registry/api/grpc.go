@@ -25,12 +25,18 @@ var (
 	methodGetNodeByConsensusAddress = serviceName.NewMethod("GetNodeByConsensusAddress", ConsensusAddressQuery{})
 	// methodGetNodeStatus is the GetNodeStatus method.
 	methodGetNodeStatus = serviceName.NewMethod("GetNodeStatus", IDQuery{})
+	// methodGetNodeTombstone is the GetNodeTombstone method.
+	methodGetNodeTombstone = serviceName.NewMethod("GetNodeTombstone", IDQuery{})
 	// methodGetNodes is the GetNodes method.
 	methodGetNodes = serviceName.NewMethod("GetNodes", int64(0))
+	// methodGetFilteredNodes is the GetFilteredNodes method.
+	methodGetFilteredNodes = serviceName.NewMethod("GetFilteredNodes", GetNodesQuery{})
 	// methodGetRuntime is the GetRuntime method.
 	methodGetRuntime = serviceName.NewMethod("GetRuntime", GetRuntimeQuery{})
 	// methodGetRuntimes is the GetRuntimes method.
 	methodGetRuntimes = serviceName.NewMethod("GetRuntimes", GetRuntimesQuery{})
+	// methodValidateRuntime is the ValidateRuntime method.
+	methodValidateRuntime = serviceName.NewMethod("ValidateRuntime", Runtime{})
 	// methodStateToGenesis is the StateToGenesis method.
 	methodStateToGenesis = serviceName.NewMethod("StateToGenesis", int64(0))
 	// methodGetEvents is the GetEvents method.
@@ -48,6 +54,10 @@ var (
 	methodWatchRuntimes = serviceName.NewMethod("WatchRuntimes", nil)
 	// methodWatchEvents is the WatchEvents method.
 	methodWatchEvents = serviceName.NewMethod("WatchEvents", nil)
+	// methodWatchFilteredNodes is the WatchFilteredNodes method.
+	methodWatchFilteredNodes = serviceName.NewMethod("WatchFilteredNodes", NodeFilter{})
+	// methodWatchFilteredNodeList is the WatchFilteredNodeList method.
+	methodWatchFilteredNodeList = serviceName.NewMethod("WatchFilteredNodeList", NodeFilter{})
 
 	// serviceDesc is the gRPC service descriptor.
 	serviceDesc = grpc.ServiceDesc{
@@ -74,10 +84,18 @@ var (
 				MethodName: methodGetNodeStatus.ShortName(),
 				Handler:    handlerGetNodeStatus,
 			},
+			{
+				MethodName: methodGetNodeTombstone.ShortName(),
+				Handler:    handlerGetNodeTombstone,
+			},
 			{
 				MethodName: methodGetNodes.ShortName(),
 				Handler:    handlerGetNodes,
 			},
+			{
+				MethodName: methodGetFilteredNodes.ShortName(),
+				Handler:    handlerGetFilteredNodes,
+			},
 			{
 				MethodName: methodGetRuntime.ShortName(),
 				Handler:    handlerGetRuntime,
@@ -86,6 +104,10 @@ var (
 				MethodName: methodGetRuntimes.ShortName(),
 				Handler:    handlerGetRuntimes,
 			},
+			{
+				MethodName: methodValidateRuntime.ShortName(),
+				Handler:    handlerValidateRuntime,
+			},
 			{
 				MethodName: methodStateToGenesis.ShortName(),
 				Handler:    handlerStateToGenesis,
@@ -125,6 +147,16 @@ var (
 				Handler:       handlerWatchEvents,
 				ServerStreams: true,
 			},
+			{
+				StreamName:    methodWatchFilteredNodes.ShortName(),
+				Handler:       handlerWatchFilteredNodes,
+				ServerStreams: true,
+			},
+			{
+				StreamName:    methodWatchFilteredNodeList.ShortName(),
+				Handler:       handlerWatchFilteredNodeList,
+				ServerStreams: true,
+			},
 		},
 	}
 )
@@ -221,6 +253,29 @@ func handlerGetNodeByConsensusAddress(
 	return interceptor(ctx, &query, info, handler)
 }
 
+func handlerGetFilteredNodes(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var query GetNodesQuery
+	if err := dec(&query); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).GetFilteredNodes(ctx, &query)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetFilteredNodes.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).GetFilteredNodes(ctx, req.(*GetNodesQuery))
+	}
+	return interceptor(ctx, &query, info, handler)
+}
+
 func handlerGetNodeStatus(
 	srv interface{},
 	ctx context.Context,
@@ -244,6 +299,29 @@ func handlerGetNodeStatus(
 	return interceptor(ctx, &query, info, handler)
 }
 
+func handlerGetNodeTombstone(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var query IDQuery
+	if err := dec(&query); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).GetNodeTombstone(ctx, &query)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetNodeTombstone.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).GetNodeTombstone(ctx, req.(*IDQuery))
+	}
+	return interceptor(ctx, &query, info, handler)
+}
+
 func handlerGetNodes(
 	srv interface{},
 	ctx context.Context,
@@ -313,6 +391,29 @@ func handlerGetRuntimes(
 	return interceptor(ctx, &query, info, handler)
 }
 
+func handlerValidateRuntime(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var rt Runtime
+	if err := dec(&rt); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return nil, srv.(Backend).ValidateRuntime(ctx, &rt)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodValidateRuntime.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, srv.(Backend).ValidateRuntime(ctx, req.(*Runtime))
+	}
+	return interceptor(ctx, &rt, info, handler)
+}
+
 func handlerStateToGenesis(
 	srv interface{},
 	ctx context.Context,
@@ -466,6 +567,64 @@ func handlerWatchNodeList(srv interface{}, stream grpc.ServerStream) error {
 	}
 }
 
+func handlerWatchFilteredNodes(srv interface{}, stream grpc.ServerStream) error {
+	var filter NodeFilter
+	if err := stream.RecvMsg(&filter); err != nil {
+		return err
+	}
+
+	ctx := stream.Context()
+	ch, sub, err := srv.(Backend).WatchFilteredNodes(ctx, &filter)
+	if err != nil {
+		return err
+	}
+	defer sub.Close()
+
+	for {
+		select {
+		case ev, ok := <-ch:
+			if !ok {
+				return nil
+			}
+
+			if err := stream.SendMsg(ev); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func handlerWatchFilteredNodeList(srv interface{}, stream grpc.ServerStream) error {
+	var filter NodeFilter
+	if err := stream.RecvMsg(&filter); err != nil {
+		return err
+	}
+
+	ctx := stream.Context()
+	ch, sub, err := srv.(Backend).WatchFilteredNodeList(ctx, &filter)
+	if err != nil {
+		return err
+	}
+	defer sub.Close()
+
+	for {
+		select {
+		case ev, ok := <-ch:
+			if !ok {
+				return nil
+			}
+
+			if err := stream.SendMsg(ev); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
 func handlerWatchRuntimes(srv interface{}, stream grpc.ServerStream) error {
 	if err := stream.RecvMsg(nil); err != nil {
 		return err
@@ -605,6 +764,14 @@ func (c *registryClient) GetNodeStatus(ctx context.Context, query *IDQuery) (*No
 	return &rsp, nil
 }
 
+func (c *registryClient) GetNodeTombstone(ctx context.Context, query *IDQuery) (*NodeTombstone, error) {
+	var rsp NodeTombstone
+	if err := c.conn.Invoke(ctx, methodGetNodeTombstone.FullName(), query, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *registryClient) GetNodes(ctx context.Context, height int64) ([]*node.Node, error) {
 	var rsp []*node.Node
 	if err := c.conn.Invoke(ctx, methodGetNodes.FullName(), height, &rsp); err != nil {
@@ -613,6 +780,14 @@ func (c *registryClient) GetNodes(ctx context.Context, height int64) ([]*node.No
 	return rsp, nil
 }
 
+func (c *registryClient) GetFilteredNodes(ctx context.Context, query *GetNodesQuery) (*NodesPage, error) {
+	var rsp NodesPage
+	if err := c.conn.Invoke(ctx, methodGetFilteredNodes.FullName(), query, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *registryClient) WatchNodes(ctx context.Context) (<-chan *NodeEvent, pubsub.ClosableSubscription, error) {
 	ctx, sub := pubsub.NewContextSubscription(ctx)
 
@@ -683,6 +858,76 @@ func (c *registryClient) WatchNodeList(ctx context.Context) (<-chan *NodeList, p
 	return ch, sub, nil
 }
 
+func (c *registryClient) WatchFilteredNodes(ctx context.Context, filter *NodeFilter) (<-chan *NodeEvent, pubsub.ClosableSubscription, error) {
+	ctx, sub := pubsub.NewContextSubscription(ctx)
+
+	stream, err := c.conn.NewStream(ctx, &serviceDesc.Streams[5], methodWatchFilteredNodes.FullName())
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = stream.SendMsg(filter); err != nil {
+		return nil, nil, err
+	}
+	if err = stream.CloseSend(); err != nil {
+		return nil, nil, err
+	}
+
+	ch := make(chan *NodeEvent)
+	go func() {
+		defer close(ch)
+
+		for {
+			var ev NodeEvent
+			if serr := stream.RecvMsg(&ev); serr != nil {
+				return
+			}
+
+			select {
+			case ch <- &ev:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch, sub, nil
+}
+
+func (c *registryClient) WatchFilteredNodeList(ctx context.Context, filter *NodeFilter) (<-chan *NodeList, pubsub.ClosableSubscription, error) {
+	ctx, sub := pubsub.NewContextSubscription(ctx)
+
+	stream, err := c.conn.NewStream(ctx, &serviceDesc.Streams[6], methodWatchFilteredNodeList.FullName())
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = stream.SendMsg(filter); err != nil {
+		return nil, nil, err
+	}
+	if err = stream.CloseSend(); err != nil {
+		return nil, nil, err
+	}
+
+	ch := make(chan *NodeList)
+	go func() {
+		defer close(ch)
+
+		for {
+			var ev NodeList
+			if serr := stream.RecvMsg(&ev); serr != nil {
+				return
+			}
+
+			select {
+			case ch <- &ev:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch, sub, nil
+}
+
 func (c *registryClient) GetRuntime(ctx context.Context, query *GetRuntimeQuery) (*Runtime, error) {
 	var rsp Runtime
 	if err := c.conn.Invoke(ctx, methodGetRuntime.FullName(), query, &rsp); err != nil {
@@ -699,6 +944,10 @@ func (c *registryClient) GetRuntimes(ctx context.Context, query *GetRuntimesQuer
 	return rsp, nil
 }
 
+func (c *registryClient) ValidateRuntime(ctx context.Context, rt *Runtime) error {
+	return c.conn.Invoke(ctx, methodValidateRuntime.FullName(), rt, nil)
+}
+
 func (c *registryClient) WatchRuntimes(ctx context.Context) (<-chan *Runtime, pubsub.ClosableSubscription, error) {
 	ctx, sub := pubsub.NewContextSubscription(ctx)
 
@@ -253,6 +253,10 @@ type RuntimeStakingParameters struct {
 	// MinInMessageFee specifies the minimum fee that the incoming message must include for the
 	// message to be queued.
 	MinInMessageFee quantity.Quantity `json:"min_in_message_fee,omitempty"`
+
+	// MaxDiscrepancies is the maximum number of executor discrepancies a node may cause in an
+	// epoch before it is slashed for repeated discrepancies. Zero disables the check.
+	MaxDiscrepancies uint64 `json:"max_discrepancies,omitempty"`
 }
 
 // ValidateBasic performs basic descriptor validity checks.
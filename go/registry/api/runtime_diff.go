@@ -0,0 +1,218 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/version"
+)
+
+// RuntimeChangeAuthorization is the authorization required to apply a runtime descriptor change.
+type RuntimeChangeAuthorization uint8
+
+const (
+	// RuntimeChangeOwner means that the change requires a signature of the owning entity.
+	RuntimeChangeOwner RuntimeChangeAuthorization = 1
+	// RuntimeChangeRuntime means that the change must be submitted by the runtime itself.
+	RuntimeChangeRuntime RuntimeChangeAuthorization = 2
+	// RuntimeChangeGovernance means that the change requires a consensus governance proposal.
+	RuntimeChangeGovernance RuntimeChangeAuthorization = 3
+	// RuntimeChangeImmutable means that the change is not allowed.
+	RuntimeChangeImmutable RuntimeChangeAuthorization = 4
+)
+
+// String returns a string representation of the runtime change authorization.
+func (a RuntimeChangeAuthorization) String() string {
+	switch a {
+	case RuntimeChangeOwner:
+		return "owner signature"
+	case RuntimeChangeRuntime:
+		return "runtime governance"
+	case RuntimeChangeGovernance:
+		return "consensus governance"
+	case RuntimeChangeImmutable:
+		return "immutable"
+	default:
+		return "[unknown]"
+	}
+}
+
+// MarshalText encodes a RuntimeChangeAuthorization into text form.
+func (a RuntimeChangeAuthorization) MarshalText() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// RuntimeDescriptorChange is a change of a single runtime descriptor field.
+type RuntimeDescriptorChange struct {
+	// Field is the name of the changed field.
+	//
+	// Changes of deployments are reported per version (e.g., `deployments[1.2.3]`).
+	Field string `json:"field"`
+
+	// Authorization is the authorization required to apply the change.
+	Authorization RuntimeChangeAuthorization `json:"authorization"`
+
+	// Reason is the reason why the change is not allowed, if any.
+	Reason string `json:"reason,omitempty"`
+}
+
+// String returns a string representation of the runtime descriptor change.
+func (c *RuntimeDescriptorChange) String() string {
+	if c.Reason == "" {
+		return fmt.Sprintf("%s: %s", c.Field, c.Authorization)
+	}
+	return fmt.Sprintf("%s: %s (%s)", c.Field, c.Authorization, c.Reason)
+}
+
+// DiffRuntimes compares the current and the new runtime descriptor and returns the list of
+// changed fields, together with the authorization required to apply each change via an update
+// of the runtime descriptor at the given epoch.
+//
+// The classification follows the rules enforced by VerifyRuntimeUpdate.
+func DiffRuntimes(currentRt, newRt *Runtime, now beacon.EpochTime) ([]*RuntimeDescriptorChange, error) {
+	currentFields, err := runtimeFields(currentRt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode current descriptor: %w", err)
+	}
+	newFields, err := runtimeFields(newRt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode new descriptor: %w", err)
+	}
+
+	// Changes that are allowed need to be authorized by whoever governs the runtime.
+	var controller RuntimeChangeAuthorization
+	switch currentRt.GovernanceModel {
+	case GovernanceEntity:
+		controller = RuntimeChangeOwner
+	case GovernanceRuntime:
+		controller = RuntimeChangeRuntime
+	default:
+		controller = RuntimeChangeGovernance
+	}
+
+	names := make(map[string]struct{})
+	for name := range currentFields {
+		names[name] = struct{}{}
+	}
+	for name := range newFields {
+		names[name] = struct{}{}
+	}
+	sortedNames := make([]string, 0, len(names))
+	for name := range names {
+		sortedNames = append(sortedNames, name)
+	}
+	sort.Strings(sortedNames)
+
+	var changes []*RuntimeDescriptorChange
+	for _, name := range sortedNames {
+		if bytes.Equal(currentFields[name], newFields[name]) {
+			continue
+		}
+
+		change := &RuntimeDescriptorChange{
+			Field:         name,
+			Authorization: controller,
+		}
+		switch name {
+		case "id", "kind", "genesis":
+			change.Authorization = RuntimeChangeImmutable
+			change.Reason = "field can never be changed"
+		case "key_manager":
+			if currentRt.KeyManager != nil {
+				change.Authorization = RuntimeChangeImmutable
+				change.Reason = "key manager can not be changed once set"
+			}
+		case "governance_model":
+			if currentRt.GovernanceModel != GovernanceEntity || newRt.GovernanceModel != GovernanceRuntime {
+				change.Authorization = RuntimeChangeImmutable
+				change.Reason = "only entity to runtime governance transition is allowed"
+			}
+		case "deployments":
+			changes = append(changes, diffDeployments(currentRt, newRt, now, controller)...)
+			continue
+		}
+		changes = append(changes, change)
+	}
+
+	if newRt.GovernanceModel == GovernanceRuntime && newRt.Kind != KindCompute {
+		changes = append(changes, &RuntimeDescriptorChange{
+			Field:         "governance_model",
+			Authorization: RuntimeChangeImmutable,
+			Reason:        "runtime governance can only be used with compute runtimes",
+		})
+	}
+
+	return changes, nil
+}
+
+func diffDeployments(currentRt, newRt *Runtime, now beacon.EpochTime, controller RuntimeChangeAuthorization) []*RuntimeDescriptorChange {
+	currentDeployments := make(map[version.Version]*VersionInfo)
+	for _, deployment := range currentRt.Deployments {
+		currentDeployments[deployment.Version] = deployment
+	}
+	newDeployments := make(map[version.Version]*VersionInfo)
+	for _, deployment := range newRt.Deployments {
+		newDeployments[deployment.Version] = deployment
+	}
+
+	versions := make(map[version.Version]struct{})
+	for v := range currentDeployments {
+		versions[v] = struct{}{}
+	}
+	for v := range newDeployments {
+		versions[v] = struct{}{}
+	}
+	sortedVersions := make([]version.Version, 0, len(versions))
+	for v := range versions {
+		sortedVersions = append(sortedVersions, v)
+	}
+	sort.Slice(sortedVersions, func(i, j int) bool {
+		return sortedVersions[i].ToU64() < sortedVersions[j].ToU64()
+	})
+
+	activeDeployment := currentRt.ActiveDeployment(now)
+
+	var changes []*RuntimeDescriptorChange
+	for _, v := range sortedVersions {
+		currentInfo, newInfo := currentDeployments[v], newDeployments[v]
+		if currentInfo != nil && newInfo != nil && currentInfo.Equal(newInfo) {
+			continue
+		}
+
+		change := &RuntimeDescriptorChange{
+			Field:         fmt.Sprintf("deployments[%s]", v),
+			Authorization: controller,
+		}
+		switch {
+		case newInfo == nil:
+			if activeDeployment != nil && activeDeployment.Version == v {
+				change.Authorization = RuntimeChangeImmutable
+				change.Reason = "active deployment can not be removed"
+			}
+		case currentInfo != nil && currentInfo.ValidFrom <= now:
+			change.Authorization = RuntimeChangeImmutable
+			change.Reason = "active or past deployment can not be changed"
+		case newInfo.ValidFrom <= now:
+			change.Authorization = RuntimeChangeImmutable
+			change.Reason = "deployment must be valid from a future epoch"
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+// runtimeFields returns the JSON encoding of each top-level runtime descriptor field.
+func runtimeFields(rt *Runtime) (map[string]json.RawMessage, error) {
+	raw, err := json.Marshal(rt)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err = json.Unmarshal(raw, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
@@ -0,0 +1,100 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/version"
+)
+
+func TestDiffRuntimes(t *testing.T) {
+	require := require.New(t)
+
+	var kmID common.Namespace
+	require.NoError(kmID.UnmarshalHex("c000000000000000ffffffffffffffffffffffffffffffffffffffffffffffff"))
+
+	newRuntime := func() *Runtime {
+		return &Runtime{
+			Kind:            KindCompute,
+			GovernanceModel: GovernanceEntity,
+			Executor:        ExecutorParameters{GroupSize: 1},
+			Deployments: []*VersionInfo{
+				{Version: version.Version{Major: 1}, ValidFrom: 0},
+				{Version: version.Version{Major: 2}, ValidFrom: 20},
+			},
+		}
+	}
+	const now = 10
+
+	currentRt := newRuntime()
+	changes, err := DiffRuntimes(currentRt, newRuntime(), now)
+	require.NoError(err, "DiffRuntimes")
+	require.Empty(changes, "identical descriptors should have no changes")
+
+	// Mutable fields under entity governance.
+	newRt := newRuntime()
+	newRt.Executor.GroupSize = 3
+	newRt.KeyManager = &kmID
+	newRt.GovernanceModel = GovernanceRuntime
+	changes, err = DiffRuntimes(currentRt, newRt, now)
+	require.NoError(err, "DiffRuntimes")
+	require.Len(changes, 3)
+	require.Equal("executor", changes[0].Field)
+	require.Equal("governance_model", changes[1].Field)
+	require.Equal("key_manager", changes[2].Field)
+	for _, c := range changes {
+		require.Equal(RuntimeChangeOwner, c.Authorization, c.Field)
+	}
+
+	// Under runtime governance, changes must come from the runtime and key manager is fixed.
+	rtGovRt := newRuntime()
+	rtGovRt.GovernanceModel = GovernanceRuntime
+	rtGovRt.KeyManager = &kmID
+	newRt = newRuntime()
+	newRt.GovernanceModel = GovernanceEntity
+	newRt.Executor.GroupSize = 3
+	changes, err = DiffRuntimes(rtGovRt, newRt, now)
+	require.NoError(err, "DiffRuntimes")
+	require.Len(changes, 3)
+	require.Equal(RuntimeChangeRuntime, changes[0].Authorization, "executor")
+	require.Equal(RuntimeChangeImmutable, changes[1].Authorization, "governance_model")
+	require.Equal(RuntimeChangeImmutable, changes[2].Authorization, "key_manager")
+
+	// Immutable fields.
+	newRt = newRuntime()
+	newRt.Kind = KindKeyManager
+	newRt.GovernanceModel = GovernanceEntity
+	changes, err = DiffRuntimes(currentRt, newRt, now)
+	require.NoError(err, "DiffRuntimes")
+	require.Len(changes, 1)
+	require.Equal("kind", changes[0].Field)
+	require.Equal(RuntimeChangeImmutable, changes[0].Authorization)
+
+	// Deployments.
+	newRt = newRuntime()
+	// Changing the active deployment is not allowed.
+	newRt.Deployments[0].ValidFrom = 5
+	// Changing a future deployment is allowed.
+	newRt.Deployments[1].ValidFrom = 30
+	// Immediate deployments are not allowed.
+	newRt.Deployments = append(newRt.Deployments, &VersionInfo{Version: version.Version{Major: 3}, ValidFrom: 10})
+	changes, err = DiffRuntimes(currentRt, newRt, now)
+	require.NoError(err, "DiffRuntimes")
+	require.Len(changes, 3)
+	require.Equal("deployments[1.0.0]", changes[0].Field)
+	require.Equal(RuntimeChangeImmutable, changes[0].Authorization)
+	require.Equal("deployments[2.0.0]", changes[1].Field)
+	require.Equal(RuntimeChangeOwner, changes[1].Authorization)
+	require.Equal("deployments[3.0.0]", changes[2].Field)
+	require.Equal(RuntimeChangeImmutable, changes[2].Authorization)
+
+	newRt = newRuntime()
+	newRt.Deployments = newRt.Deployments[:1]
+	changes, err = DiffRuntimes(currentRt, newRt, now)
+	require.NoError(err, "DiffRuntimes")
+	require.Len(changes, 1)
+	require.Equal("deployments[2.0.0]", changes[0].Field)
+	require.Equal(RuntimeChangeOwner, changes[0].Authorization, "future deployment can be withdrawn")
+}
@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"time"
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
@@ -25,6 +26,9 @@ func (p *ConsensusParameters) SanityCheck() error {
 			return fmt.Errorf("maximum node expiration not specified")
 		}
 	}
+	if err := sanityCheckNodeMetadataSchema(p.NodeMetadataSchema); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -35,9 +39,29 @@ func (c *ConsensusParameterChanges) SanityCheck() error {
 		c.GasCosts == nil &&
 		c.MaxNodeExpiration == nil &&
 		c.EnableRuntimeGovernanceModels == nil &&
-		c.TEEFeatures == nil {
+		c.TEEFeatures == nil &&
+		c.MaxNodeMetadataSize == nil &&
+		c.NodeMetadataSchema == nil &&
+		c.NodeTombstoneRetention == nil {
 		return fmt.Errorf("consensus parameter changes should not be empty")
 	}
+	if c.NodeMetadataSchema != nil {
+		if err := sanityCheckNodeMetadataSchema(*c.NodeMetadataSchema); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func sanityCheckNodeMetadataSchema(schema map[string]string) error {
+	for k, pattern := range schema {
+		if err := (node.Metadata{k: ""}).ValidateBasic(); err != nil {
+			return fmt.Errorf("invalid node metadata schema: %w", err)
+		}
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("invalid node metadata schema for key '%s': %w", k, err)
+		}
+	}
 	return nil
 }
 
@@ -56,11 +80,37 @@ func (g *Genesis) SanityCheck(
 	}
 
 	// Check entities.
-	seenEntities, err := SanityCheckEntities(logger, g.Entities)
+	seenEntities, err := SanityCheckEntities(logger, g.Entities, g.MultiSignedEntities)
 	if err != nil {
 		return err
 	}
 
+	// Apply incrementally changed entity node allowlists.
+	for id, nodes := range g.EntityNodes {
+		ent, ok := seenEntities[id]
+		if !ok {
+			return fmt.Errorf("registry: sanity check failed: node allowlist for unknown entity: '%s'", id)
+		}
+		if err = validateEntityNodeList(nodes); err != nil {
+			return fmt.Errorf("registry: sanity check failed: invalid node allowlist for entity '%s': %w", id, err)
+		}
+		updated := *ent
+		updated.Nodes = nodes
+		seenEntities[id] = &updated
+	}
+
+	// Check validator election opt-outs.
+	seenOptOuts := make(map[signature.PublicKey]bool)
+	for _, id := range g.ValidatorElectionOptOuts {
+		if _, ok := seenEntities[id]; !ok {
+			return fmt.Errorf("registry: sanity check failed: validator election opt-out for unknown entity: '%s'", id)
+		}
+		if seenOptOuts[id] {
+			return fmt.Errorf("registry: sanity check failed: duplicate validator election opt-out: '%s'", id)
+		}
+		seenOptOuts[id] = true
+	}
+
 	// Check runtimes.
 	runtimesLookup, err := SanityCheckRuntimes(logger, &g.Parameters, g.Runtimes, g.SuspendedRuntimes, true, baseEpoch)
 	if err != nil {
@@ -96,13 +146,31 @@ func (g *Genesis) SanityCheck(
 		}
 	}
 
-	// Add stake claims.
+	// Check node tombstones.
 	nodes, err := nodeLookup.Nodes(context.Background())
 	if err != nil {
 		return fmt.Errorf("registry: sanity check failed: could not obtain node list from nodeLookup: %w", err)
 	}
+	registeredNodes := make(map[signature.PublicKey]bool)
+	for _, n := range nodes {
+		registeredNodes[n.ID] = true
+	}
+	seenTombstones := make(map[signature.PublicKey]bool)
+	for i, tombstone := range g.NodeTombstones {
+		if tombstone == nil || tombstone.Node == nil {
+			return fmt.Errorf("registry: sanity check failed: node tombstone index %d is nil", i)
+		}
+		id := tombstone.Node.ID
+		if registeredNodes[id] {
+			return fmt.Errorf("registry: sanity check failed: node tombstone for registered node: '%s'", id)
+		}
+		if seenTombstones[id] {
+			return fmt.Errorf("registry: sanity check failed: duplicate node tombstone: '%s'", id)
+		}
+		seenTombstones[id] = true
+	}
 
-	// Skip suspended runtimes for computing stake claims.
+	// Add stake claims, skipping suspended runtimes.
 	runtimes, err := runtimesLookup.Runtimes(context.Background())
 	if err != nil {
 		return fmt.Errorf("registry: sanity check failed: could not obtain runtimes from runtimesLookup: %w", err)
@@ -113,7 +181,11 @@ func (g *Genesis) SanityCheck(
 
 // SanityCheckEntities examines the entities table.
 // Returns lookup of entity ID to the entity record for use in other checks.
-func SanityCheckEntities(logger *logging.Logger, entities []*entity.SignedEntity) (map[signature.PublicKey]*entity.Entity, error) {
+func SanityCheckEntities(
+	logger *logging.Logger,
+	entities []*entity.SignedEntity,
+	multiSignedEntities []*entity.MultiSignedEntity,
+) (map[signature.PublicKey]*entity.Entity, error) {
 	seenEntities := make(map[signature.PublicKey]*entity.Entity)
 	for _, signedEnt := range entities {
 		entity, err := VerifyRegisterEntityArgs(logger, signedEnt, true, true)
@@ -122,6 +194,16 @@ func SanityCheckEntities(logger *logging.Logger, entities []*entity.SignedEntity
 		}
 		seenEntities[entity.ID] = entity
 	}
+	for _, msEnt := range multiSignedEntities {
+		entity, err := VerifyRegisterMultiSignedEntityArgs(logger, msEnt, true, true)
+		if err != nil {
+			return nil, fmt.Errorf("multi-signed entity sanity check failed: %w", err)
+		}
+		if _, ok := seenEntities[entity.ID]; ok {
+			return nil, fmt.Errorf("multi-signed entity sanity check failed: duplicate entity %s", entity.ID)
+		}
+		seenEntities[entity.ID] = entity
+	}
 
 	return seenEntities, nil
 }
@@ -1,6 +1,8 @@
 package api
 
 import (
+	"fmt"
+
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
@@ -10,6 +12,48 @@ import (
 // all (practical) time.
 const FreezeForever beacon.EpochTime = 0xffffffffffffffff
 
+// FreezeReason is the machine-readable reason for a node being frozen.
+type FreezeReason uint8
+
+const (
+	// FreezeReasonUnspecified is used when no freeze reason has been recorded.
+	FreezeReasonUnspecified FreezeReason = 0
+	// FreezeReasonSlashed is used when a node has been frozen due to being slashed.
+	FreezeReasonSlashed FreezeReason = 1
+	// FreezeReasonCompromised is used when the owning entity reports the node as compromised.
+	FreezeReasonCompromised FreezeReason = 2
+	// FreezeReasonMaintenance is used when the owning entity takes the node out of
+	// scheduling for maintenance.
+	FreezeReasonMaintenance FreezeReason = 3
+)
+
+// String returns a string representation of the freeze reason.
+func (r FreezeReason) String() string {
+	switch r {
+	case FreezeReasonUnspecified:
+		return "unspecified"
+	case FreezeReasonSlashed:
+		return "slashed"
+	case FreezeReasonCompromised:
+		return "compromised"
+	case FreezeReasonMaintenance:
+		return "maintenance"
+	default:
+		return fmt.Sprintf("[unknown freeze reason: %d]", uint8(r))
+	}
+}
+
+// IsEntityInitiated returns true if the freeze reason can be used by entities to freeze
+// their own nodes.
+func (r FreezeReason) IsEntityInitiated() bool {
+	switch r {
+	case FreezeReasonCompromised, FreezeReasonMaintenance:
+		return true
+	default:
+		return false
+	}
+}
+
 // NodeStatus is live status of a node.
 type NodeStatus struct {
 	// ExpirationProcessed is a flag specifying whether the node expiration
@@ -23,6 +67,8 @@ type NodeStatus struct {
 	// After the specified epoch passes, this flag needs to be explicitly
 	// cleared (set to zero) in order for the node to become unfrozen.
 	FreezeEndTime beacon.EpochTime `json:"freeze_end_time"`
+	// FreezeReason is the reason why the node is frozen.
+	FreezeReason FreezeReason `json:"freeze_reason,omitempty"`
 	// ElectionEligibleAfter specifies the epoch after which a node is
 	// eligible to be included in non-validator committee elections.
 	//
@@ -39,9 +85,16 @@ func (ns NodeStatus) IsFrozen() bool {
 	return ns.FreezeEndTime > 0
 }
 
+// Freeze makes the node frozen until the given epoch for the given reason.
+func (ns *NodeStatus) Freeze(until beacon.EpochTime, reason FreezeReason) {
+	ns.FreezeEndTime = until
+	ns.FreezeReason = reason
+}
+
 // Unfreeze makes the node unfrozen.
 func (ns *NodeStatus) Unfreeze() {
 	ns.FreezeEndTime = 0
+	ns.FreezeReason = FreezeReasonUnspecified
 }
 
 // RecordFailure records a liveness failure in the epoch preceding the specified epoch.
@@ -138,3 +191,23 @@ func (f *Fault) IsSuspended(epoch beacon.EpochTime) bool {
 type UnfreezeNode struct {
 	NodeID signature.PublicKey `json:"node_id"`
 }
+
+// FreezeNode is a request by the owning entity to freeze a node.
+type FreezeNode struct {
+	NodeID signature.PublicKey `json:"node_id"`
+	Reason FreezeReason        `json:"reason"`
+	// Duration is the number of epochs for which the node is frozen before it can be
+	// unfrozen.
+	Duration beacon.EpochTime `json:"duration"`
+}
+
+// ValidateBasic performs basic freeze node request validity checks.
+func (fn *FreezeNode) ValidateBasic() error {
+	if !fn.Reason.IsEntityInitiated() {
+		return ErrInvalidArgument
+	}
+	if fn.Duration == 0 {
+		return ErrInvalidArgument
+	}
+	return nil
+}
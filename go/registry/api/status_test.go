@@ -66,3 +66,41 @@ func TestStatusFaults(t *testing.T) {
 	require.False(ns.IsSuspended(testRuntimeID, 26), "should not be suspended in epoch 26")
 	require.Len(ns.Faults, 0, "faults set should be cleared")
 }
+
+func TestStatusFreeze(t *testing.T) {
+	require := require.New(t)
+
+	var testRuntimeID common.Namespace
+
+	var ns NodeStatus
+	ns.Freeze(10, FreezeReasonCompromised)
+	require.True(ns.IsFrozen(), "node should be frozen")
+	require.True(ns.IsSuspended(testRuntimeID, 1), "frozen node should be suspended")
+	require.Equal(FreezeReasonCompromised, ns.FreezeReason)
+
+	ns.Unfreeze()
+	require.False(ns.IsFrozen(), "node should no longer be frozen")
+	require.Equal(FreezeReasonUnspecified, ns.FreezeReason, "freeze reason should be cleared")
+
+	for _, tc := range []struct {
+		reason FreezeReason
+		valid  bool
+	}{
+		{FreezeReasonUnspecified, false},
+		{FreezeReasonSlashed, false},
+		{FreezeReasonCompromised, true},
+		{FreezeReasonMaintenance, true},
+		{FreezeReason(42), false},
+	} {
+		fn := FreezeNode{Reason: tc.reason, Duration: 1}
+		switch tc.valid {
+		case true:
+			require.NoError(fn.ValidateBasic(), "freeze reason %s should be allowed", tc.reason)
+		case false:
+			require.Error(fn.ValidateBasic(), "freeze reason %s should not be allowed", tc.reason)
+		}
+	}
+
+	fn := FreezeNode{Reason: FreezeReasonCompromised}
+	require.Error(fn.ValidateBasic(), "zero freeze duration should not be allowed")
+}
@@ -69,8 +69,22 @@ func main() {
 			tx := registry.NewDeregisterEntityTx(nonce, fee)
 			vectors = append(vectors, testvectors.MakeTestVector("DeregisterEntity", tx, true))
 
+			// Generate freeze node transactions.
+			nodeSigner := memorySigner.NewTestSigner("oasis-core registry test vectors: FreezeNode signer")
+			for _, reason := range []registry.FreezeReason{
+				registry.FreezeReasonCompromised,
+				registry.FreezeReasonMaintenance,
+			} {
+				tx = registry.NewFreezeNodeTx(nonce, fee, &registry.FreezeNode{
+					NodeID:   nodeSigner.Public(),
+					Reason:   reason,
+					Duration: 10,
+				})
+				vectors = append(vectors, testvectors.MakeTestVector("FreezeNode", tx, true))
+			}
+
 			// Generate unfreeze node transactions.
-			nodeSigner := memorySigner.NewTestSigner("oasis-core registry test vectors: UnfreezeNode signer")
+			nodeSigner = memorySigner.NewTestSigner("oasis-core registry test vectors: UnfreezeNode signer")
 			tx = registry.NewUnfreezeNodeTx(nonce, fee, &registry.UnfreezeNode{
 				NodeID: nodeSigner.Public(),
 			})
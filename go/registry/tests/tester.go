@@ -418,6 +418,28 @@ func testRegistryEntityNodes( // nolint: gocyclo
 			}
 		}
 		require.EqualValues(expectedNodeList, registeredNodes, "node list")
+
+		// Filtered queries should only return the matching nodes, page by page.
+		filter := api.NodeFilter{EntityID: &entities[1].Entity.ID}
+		var (
+			filteredNodes []*node.Node
+			after         *signature.PublicKey
+		)
+		for {
+			page, nerr := backend.GetFilteredNodes(ctx, &api.GetNodesQuery{
+				Height: consensusAPI.HeightLatest,
+				Filter: filter,
+				After:  after,
+				Limit:  1,
+			})
+			require.NoError(nerr, "GetFilteredNodes")
+			filteredNodes = append(filteredNodes, page.Nodes...)
+			if page.Next == nil {
+				break
+			}
+			after = page.Next
+		}
+		require.EqualValues(api.FilterNodes(expectedNodeList, &filter), filteredNodes, "filtered node list")
 	})
 
 	t.Run("NodeUnfreeze", func(t *testing.T) {
@@ -942,11 +964,16 @@ func testRegistryRuntime(t *testing.T, backend api.Backend, consensus consensusA
 			tc.prepareFn(rt.Runtime)
 		}
 
+		// Dry-run validation should agree with the registration outcome.
+		err = backend.ValidateRuntime(context.Background(), rt.Runtime)
+
 		switch tc.valid {
 		case true:
+			require.NoError(err, "ValidateRuntime (%s)", tc.name)
 			rtMap[rt.Runtime.ID] = rt.Runtime
 			rt.MustRegister(t, backend, consensus)
 		case false:
+			require.Error(err, "ValidateRuntime (%s)", tc.name)
 			rt.MustNotRegister(t, consensus)
 		}
 
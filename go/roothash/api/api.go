@@ -6,10 +6,12 @@ import (
 	"encoding/base64"
 	"fmt"
 	"math"
+	"slices"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/errors"
 	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
@@ -90,20 +92,40 @@ var (
 	// value larger than the MaxInRuntimeMessages specified in consensus parameters.
 	ErrMaxInMessagesTooBig = errors.New(ModuleName, 13, "roothash: max incoming runtime messages is too big")
 
+	// ErrCheckpointManifestsDisabled is the error returned when a checkpoint manifest is published
+	// while publishing checkpoint manifests is disabled.
+	ErrCheckpointManifestsDisabled = errors.New(ModuleName, 14, "roothash: checkpoint manifests are disabled")
+
+	// ErrInvalidCheckpointManifest is the error returned when a published checkpoint manifest does
+	// not refer to a known runtime root.
+	ErrInvalidCheckpointManifest = errors.New(ModuleName, 15, "roothash: invalid checkpoint manifest")
+
+	// ErrRuntimePaused is the error returned when the runtime has been paused via governance.
+	ErrRuntimePaused = errors.New(ModuleName, 16, "roothash: runtime is paused")
+
 	// MethodExecutorCommit is the method name for executor commit submission.
 	MethodExecutorCommit = transaction.NewMethodName(ModuleName, "ExecutorCommit", ExecutorCommit{})
 
 	// MethodEvidence is the method name for submitting evidence of node misbehavior.
 	MethodEvidence = transaction.NewMethodName(ModuleName, "Evidence", Evidence{})
 
+	// MethodEvidenceBatch is the method name for submitting multiple pieces of evidence of node
+	// misbehavior in a single transaction.
+	MethodEvidenceBatch = transaction.NewMethodName(ModuleName, "EvidenceBatch", EvidenceBatch{})
+
 	// MethodSubmitMsg is the method name for queuing incoming runtime messages.
 	MethodSubmitMsg = transaction.NewMethodName(ModuleName, "SubmitMsg", SubmitMsg{})
 
+	// MethodPublishCheckpoint is the method name for publishing storage checkpoint manifests.
+	MethodPublishCheckpoint = transaction.NewMethodName(ModuleName, "PublishCheckpoint", PublishCheckpoint{})
+
 	// Methods is a list of all methods supported by the roothash backend.
 	Methods = []transaction.MethodName{
 		MethodExecutorCommit,
 		MethodEvidence,
+		MethodEvidenceBatch,
 		MethodSubmitMsg,
+		MethodPublishCheckpoint,
 	}
 )
 
@@ -130,12 +152,27 @@ type Backend interface {
 	// GetLastRoundResults returns the given runtime's last normal round results.
 	GetLastRoundResults(ctx context.Context, request *RuntimeRequest) (*RoundResults, error)
 
+	// GetLastRoundMetadata returns the executor commitment details of the given runtime's last
+	// normal or failed round.
+	GetLastRoundMetadata(ctx context.Context, request *RuntimeRequest) (*RoundMetadata, error)
+
+	// GetLivenessStatistics returns the per-node liveness statistics of the given runtime's
+	// executor committee for the current epoch.
+	GetLivenessStatistics(ctx context.Context, request *RuntimeRequest) (*RuntimeLivenessStatistics, error)
+
 	// GetIncomingMessageQueueMeta returns the given runtime's incoming message queue metadata.
 	GetIncomingMessageQueueMeta(ctx context.Context, request *RuntimeRequest) (*message.IncomingMessageQueueMeta, error)
 
 	// GetIncomingMessageQueue returns the given runtime's queued incoming messages.
+	//
+	// Messages are returned in the order in which they were queued, starting with the message
+	// identifier given by the request offset.
 	GetIncomingMessageQueue(ctx context.Context, request *InMessageQueueRequest) ([]*message.IncomingMessage, error)
 
+	// GetCheckpointManifests returns the storage checkpoint manifests published for the given
+	// runtime, ordered by round.
+	GetCheckpointManifests(ctx context.Context, request *RuntimeRequest) ([]*CheckpointManifest, error)
+
 	// WatchBlocks returns a channel that produces a stream of
 	// annotated blocks.
 	//
@@ -147,6 +184,13 @@ type Backend interface {
 	// WatchEvents returns a stream of protocol events.
 	WatchEvents(ctx context.Context, runtimeID common.Namespace) (<-chan *Event, pubsub.ClosableSubscription, error)
 
+	// WatchIncomingMessages returns a channel that produces a stream of incoming messages
+	// as they are queued for the given runtime.
+	//
+	// The message data is not included. Use GetIncomingMessageQueue to retrieve messages that
+	// are still pending together with their data.
+	WatchIncomingMessages(ctx context.Context, runtimeID common.Namespace) (<-chan *InMsgQueuedEvent, pubsub.ClosableSubscription, error)
+
 	// WatchExecutorCommitments returns a channel that produces a stream of executor commitments
 	// observed in the consensus layer P2P network.
 	//
@@ -188,8 +232,10 @@ type InMessageQueueRequest struct {
 	RuntimeID common.Namespace `json:"runtime_id"`
 	Height    int64            `json:"height"`
 
+	// Offset is the identifier of the first incoming message to return.
 	Offset uint64 `json:"offset,omitempty"`
-	Limit  uint32 `json:"limit,omitempty"`
+	// Limit is the maximum number of incoming messages to return. Zero means no limit.
+	Limit uint32 `json:"limit,omitempty"`
 }
 
 // ExecutorCommit is the argument set for the ExecutorCommit method.
@@ -206,6 +252,17 @@ func NewExecutorCommitTx(nonce uint64, fee *transaction.Fee, runtimeID common.Na
 	})
 }
 
+// SignExecutorCommitTx creates and signs a new executor commit transaction.
+func SignExecutorCommitTx(
+	signer signature.Signer,
+	nonce uint64,
+	fee *transaction.Fee,
+	runtimeID common.Namespace,
+	commits []commitment.ExecutorCommitment,
+) (*transaction.SignedTransaction, error) {
+	return transaction.Sign(signer, NewExecutorCommitTx(nonce, fee, runtimeID, commits))
+}
+
 // SubmitMsg is the argument set for the SubmitMsg method.
 type SubmitMsg struct {
 	// ID is the destination runtime ID.
@@ -228,6 +285,16 @@ func NewSubmitMsgTx(nonce uint64, fee *transaction.Fee, msg *SubmitMsg) *transac
 	return transaction.NewTransaction(nonce, fee, MethodSubmitMsg, msg)
 }
 
+// SignSubmitMsgTx creates and signs a new incoming runtime message submission transaction.
+func SignSubmitMsgTx(
+	signer signature.Signer,
+	nonce uint64,
+	fee *transaction.Fee,
+	msg *SubmitMsg,
+) (*transaction.SignedTransaction, error) {
+	return transaction.Sign(signer, NewSubmitMsgTx(nonce, fee, msg))
+}
+
 // EvidenceKind is the evidence kind.
 type EvidenceKind uint8
 
@@ -383,6 +450,109 @@ func NewEvidenceTx(nonce uint64, fee *transaction.Fee, evidence *Evidence) *tran
 	return transaction.NewTransaction(nonce, fee, MethodEvidence, evidence)
 }
 
+// SignEvidenceTx creates and signs a new evidence transaction.
+//
+// The evidence is checked for basic validity before signing so that evidence which would be
+// rejected by the consensus layer is never submitted.
+func SignEvidenceTx(
+	signer signature.Signer,
+	nonce uint64,
+	fee *transaction.Fee,
+	evidence *Evidence,
+) (*transaction.SignedTransaction, error) {
+	if err := evidence.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("roothash: invalid evidence: %w", err)
+	}
+	return transaction.Sign(signer, NewEvidenceTx(nonce, fee, evidence))
+}
+
+// MaxEvidenceBatchSize is the maximum number of pieces of evidence in an evidence batch.
+const MaxEvidenceBatchSize = 128
+
+// EvidenceBatch is a batch of evidence of node misbehaviour.
+type EvidenceBatch struct {
+	Evidence []*Evidence `json:"evidence"`
+}
+
+// ValidateBasic performs basic evidence batch validity checks.
+func (eb *EvidenceBatch) ValidateBasic() error {
+	switch n := len(eb.Evidence); {
+	case n == 0:
+		return fmt.Errorf("evidence batch is empty")
+	case n > MaxEvidenceBatchSize:
+		return fmt.Errorf("evidence batch too big (%d > %d)", n, MaxEvidenceBatchSize)
+	}
+
+	for i, ev := range eb.Evidence {
+		if ev == nil {
+			return fmt.Errorf("evidence %d is missing", i)
+		}
+		if err := ev.ValidateBasic(); err != nil {
+			return fmt.Errorf("evidence %d not valid: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// NewEvidenceBatch creates a new evidence batch, omitting exact duplicates.
+func NewEvidenceBatch(evidence ...*Evidence) *EvidenceBatch {
+	var eb EvidenceBatch
+	seen := make(map[hash.Hash]struct{})
+	for _, ev := range evidence {
+		h := hash.NewFrom(ev)
+		if _, ok := seen[h]; ok {
+			continue
+		}
+		seen[h] = struct{}{}
+		eb.Evidence = append(eb.Evidence, ev)
+	}
+	return &eb
+}
+
+// NewEvidenceBatchTx creates a new evidence batch transaction.
+func NewEvidenceBatchTx(nonce uint64, fee *transaction.Fee, batch *EvidenceBatch) *transaction.Transaction {
+	return transaction.NewTransaction(nonce, fee, MethodEvidenceBatch, batch)
+}
+
+// SignEvidenceBatchTx creates and signs a new evidence batch transaction.
+//
+// Exact duplicates are omitted and the remaining evidence is checked for basic validity before
+// signing so that a batch which would be rejected by the consensus layer is never submitted.
+func SignEvidenceBatchTx(
+	signer signature.Signer,
+	nonce uint64,
+	fee *transaction.Fee,
+	evidence []*Evidence,
+) (*transaction.SignedTransaction, error) {
+	batch := NewEvidenceBatch(evidence...)
+	if err := batch.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("roothash: invalid evidence batch: %w", err)
+	}
+	return transaction.Sign(signer, NewEvidenceBatchTx(nonce, fee, batch))
+}
+
+// NewEquivocationExecutorEvidence creates new evidence of executor commitment equivocation.
+func NewEquivocationExecutorEvidence(runtimeID common.Namespace, commitA, commitB commitment.ExecutorCommitment) *Evidence {
+	return &Evidence{
+		ID: runtimeID,
+		EquivocationExecutor: &EquivocationExecutorEvidence{
+			CommitA: commitA,
+			CommitB: commitB,
+		},
+	}
+}
+
+// NewEquivocationProposalEvidence creates new evidence of proposal equivocation.
+func NewEquivocationProposalEvidence(runtimeID common.Namespace, proposalA, proposalB commitment.Proposal) *Evidence {
+	return &Evidence{
+		ID: runtimeID,
+		EquivocationProposal: &EquivocationProposalEvidence{
+			ProposalA: proposalA,
+			ProposalB: proposalB,
+		},
+	}
+}
+
 // RuntimeState is the per-runtime state.
 type RuntimeState struct {
 	// Runtime is the latest per-epoch runtime descriptor.
@@ -490,6 +660,108 @@ func (e *FinalizedEvent) EventKind() string {
 	return "finalized"
 }
 
+// RoundFailureClass is the class of a runtime round failure.
+type RoundFailureClass uint8
+
+const (
+	// RoundFailureTimeout indicates that the round timed out without any scheduler submitting
+	// a commitment.
+	RoundFailureTimeout RoundFailureClass = 1
+	// RoundFailureDiscrepancy indicates that discrepancy resolution failed to reach a majority.
+	RoundFailureDiscrepancy RoundFailureClass = 2
+	// RoundFailureBadCommitment indicates that the scheduler's commitment was invalid.
+	RoundFailureBadCommitment RoundFailureClass = 3
+)
+
+// String returns a string representation of the round failure class.
+func (c RoundFailureClass) String() string {
+	switch c {
+	case RoundFailureTimeout:
+		return "timeout"
+	case RoundFailureDiscrepancy:
+		return "discrepancy"
+	case RoundFailureBadCommitment:
+		return "bad commitment"
+	default:
+		return "[unknown round failure class]"
+	}
+}
+
+// RoundFailedEvent is an event of a runtime round failing.
+type RoundFailedEvent struct {
+	// Round is the round that failed.
+	Round uint64 `json:"round"`
+	// Class is the class of the round failure.
+	Class RoundFailureClass `json:"class"`
+	// Timeout signals whether the round failed after the round timeout expired.
+	Timeout bool `json:"timeout,omitempty"`
+	// Offenders are the public keys of the nodes that caused the round to fail.
+	Offenders []signature.PublicKey `json:"offenders,omitempty"`
+	// Error is the reason for the round failure.
+	Error string `json:"error,omitempty"`
+}
+
+// EventKind returns a string representation of this event's kind.
+func (e *RoundFailedEvent) EventKind() string {
+	return "round_failed"
+}
+
+// RuntimePausedEvent is an event of a runtime being paused via governance.
+type RuntimePausedEvent struct {
+	// Round is the runtime round at the time the runtime was paused.
+	Round uint64 `json:"round"`
+}
+
+// EventKind returns a string representation of this event's kind.
+func (e *RuntimePausedEvent) EventKind() string {
+	return "runtime_paused"
+}
+
+// RuntimeResumedEvent is an event of a paused runtime being resumed via governance.
+type RuntimeResumedEvent struct {
+	// Round is the runtime round at the time the runtime was resumed.
+	Round uint64 `json:"round"`
+}
+
+// EventKind returns a string representation of this event's kind.
+func (e *RuntimeResumedEvent) EventKind() string {
+	return "runtime_resumed"
+}
+
+// SlashedEvent is an event of a runtime node's entity being slashed for a runtime-level offense.
+type SlashedEvent struct {
+	// Owner is the address of the slashed escrow account.
+	Owner staking.Address `json:"owner"`
+	// Reason is the reason for slashing.
+	Reason staking.SlashReason `json:"reason"`
+	// Amount is the amount slashed from the escrow account.
+	Amount quantity.Quantity `json:"amount"`
+}
+
+// EventKind returns a string representation of this event's kind.
+func (e *SlashedEvent) EventKind() string {
+	return "slashed"
+}
+
+// InMsgQueuedEvent is an event of a new incoming message being queued.
+type InMsgQueuedEvent struct {
+	// ID is the unique incoming message identifier.
+	ID uint64 `json:"id"`
+	// Caller is the incoming message submitter address.
+	Caller staking.Address `json:"caller"`
+	// Tag is an optional tag provided by the caller.
+	Tag uint64 `json:"tag,omitempty"`
+	// Fee is the fee sent into the runtime as part of the message being sent.
+	Fee quantity.Quantity `json:"fee,omitempty"`
+	// Tokens are any tokens sent into the runtime as part of the message being sent.
+	Tokens quantity.Quantity `json:"tokens,omitempty"`
+}
+
+// EventKind returns a string representation of this event's kind.
+func (e *InMsgQueuedEvent) EventKind() string {
+	return "in_msg_queued"
+}
+
 // InMsgProcessedEvent is an event of a specific incoming message being processed.
 //
 // In order to see details one needs to query the runtime at the specified round.
@@ -502,6 +774,9 @@ type InMsgProcessedEvent struct {
 	Caller staking.Address `json:"caller"`
 	// Tag is an optional tag provided by the caller.
 	Tag uint64 `json:"tag,omitempty"`
+	// Result is the result of processing the incoming message. It is absent in case the runtime
+	// does not report incoming message results.
+	Result *message.IncomingMessageResult `json:"result,omitempty"`
 }
 
 // EventKind returns a string representation of this event's kind.
@@ -534,7 +809,12 @@ type Event struct {
 	ExecutorCommitted            *ExecutorCommittedEvent            `json:"executor_committed,omitempty"`
 	ExecutionDiscrepancyDetected *ExecutionDiscrepancyDetectedEvent `json:"execution_discrepancy,omitempty"`
 	Finalized                    *FinalizedEvent                    `json:"finalized,omitempty"`
+	RoundFailed                  *RoundFailedEvent                  `json:"round_failed,omitempty"`
+	InMsgQueued                  *InMsgQueuedEvent                  `json:"in_msg_queued,omitempty"`
 	InMsgProcessed               *InMsgProcessedEvent               `json:"in_msg_processed,omitempty"`
+	RuntimePaused                *RuntimePausedEvent                `json:"runtime_paused,omitempty"`
+	RuntimeResumed               *RuntimeResumedEvent               `json:"runtime_resumed,omitempty"`
+	Slashed                      *SlashedEvent                      `json:"slashed,omitempty"`
 }
 
 // MetricsMonitorable is the interface exposed by backends capable of
@@ -590,6 +870,45 @@ type ConsensusParameters struct {
 	// MaxPastRootsStored is the maximum number of past runtime state and I/O
 	// roots that are stored in the consensus state.
 	MaxPastRootsStored uint64 `json:"max_past_roots_stored,omitempty"`
+
+	// MaxCheckpointManifests is the maximum number of rounds for which storage checkpoint
+	// manifests are stored per runtime. Zero disables publishing checkpoint manifests.
+	MaxCheckpointManifests uint64 `json:"max_checkpoint_manifests,omitempty"`
+
+	// InMessageFeePerMessage is the fee charged for each incoming runtime message submitted via
+	// SubmitMsg, in addition to gas. The fee is credited to the runtime account.
+	InMessageFeePerMessage quantity.Quantity `json:"in_message_fee_per_message,omitempty"`
+
+	// InMessageFeePerByte is the fee charged for each byte of incoming runtime message data
+	// submitted via SubmitMsg, in addition to gas. The fee is credited to the runtime account.
+	InMessageFeePerByte quantity.Quantity `json:"in_message_fee_per_byte,omitempty"`
+
+	// PausedRuntimes is the list of runtimes that have been administratively paused. Executor
+	// commitments for paused runtimes are rejected until the runtimes are resumed.
+	PausedRuntimes []common.Namespace `json:"paused_runtimes,omitempty"`
+}
+
+// IsRuntimePaused returns true iff the given runtime has been administratively paused.
+func (p *ConsensusParameters) IsRuntimePaused(id common.Namespace) bool {
+	for _, paused := range p.PausedRuntimes {
+		if paused.Equal(&id) {
+			return true
+		}
+	}
+	return false
+}
+
+// InMessageFee computes the fee that needs to be paid for an incoming runtime message carrying
+// the given amount of data, in addition to gas and any runtime-specific minimum fee.
+func (p *ConsensusParameters) InMessageFee(dataLen int) (*quantity.Quantity, error) {
+	fee := p.InMessageFeePerByte.Clone()
+	if err := fee.Mul(quantity.NewFromUint64(uint64(dataLen))); err != nil {
+		return nil, err
+	}
+	if err := fee.Add(&p.InMessageFeePerMessage); err != nil {
+		return nil, err
+	}
+	return fee, nil
 }
 
 // ConsensusParameterChanges are allowed roothash consensus parameter changes.
@@ -609,6 +928,22 @@ type ConsensusParameterChanges struct {
 	// MaxPastRootsStored is the new maximum number of past runtime state and I/O
 	// roots that are stored in the consensus state.
 	MaxPastRootsStored *uint64 `json:"max_past_roots_stored,omitempty"`
+
+	// MaxCheckpointManifests is the new maximum number of rounds for which storage checkpoint
+	// manifests are stored per runtime.
+	MaxCheckpointManifests *uint64 `json:"max_checkpoint_manifests,omitempty"`
+
+	// InMessageFeePerMessage is the new fee charged for each incoming runtime message.
+	InMessageFeePerMessage *quantity.Quantity `json:"in_message_fee_per_message,omitempty"`
+
+	// InMessageFeePerByte is the new fee charged for each byte of incoming runtime message data.
+	InMessageFeePerByte *quantity.Quantity `json:"in_message_fee_per_byte,omitempty"`
+
+	// PauseRuntimes is the list of runtimes that should be paused.
+	PauseRuntimes []common.Namespace `json:"pause_runtimes,omitempty"`
+
+	// ResumeRuntimes is the list of paused runtimes that should be resumed.
+	ResumeRuntimes []common.Namespace `json:"resume_runtimes,omitempty"`
 }
 
 // Apply applies changes to the given consensus parameters.
@@ -628,6 +963,29 @@ func (c *ConsensusParameterChanges) Apply(params *ConsensusParameters) error {
 	if c.MaxPastRootsStored != nil {
 		params.MaxPastRootsStored = *c.MaxPastRootsStored
 	}
+	if c.MaxCheckpointManifests != nil {
+		params.MaxCheckpointManifests = *c.MaxCheckpointManifests
+	}
+	if c.InMessageFeePerMessage != nil {
+		params.InMessageFeePerMessage = *c.InMessageFeePerMessage
+	}
+	if c.InMessageFeePerByte != nil {
+		params.InMessageFeePerByte = *c.InMessageFeePerByte
+	}
+	for _, id := range c.PauseRuntimes {
+		if params.IsRuntimePaused(id) {
+			return fmt.Errorf("runtime %s is already paused", id)
+		}
+		params.PausedRuntimes = append(params.PausedRuntimes, id)
+	}
+	for _, id := range c.ResumeRuntimes {
+		if !params.IsRuntimePaused(id) {
+			return fmt.Errorf("runtime %s is not paused", id)
+		}
+		params.PausedRuntimes = slices.DeleteFunc(params.PausedRuntimes, func(paused common.Namespace) bool {
+			return paused.Equal(&id)
+		})
+	}
 	return nil
 }
 
@@ -643,16 +1001,21 @@ const (
 
 	// GasOpSubmitMsg is the gas operation identifier for message submission transaction cost.
 	GasOpSubmitMsg transaction.Op = "submit_msg"
+
+	// GasOpPublishCheckpoint is the gas operation identifier for checkpoint manifest publication
+	// transaction cost.
+	GasOpPublishCheckpoint transaction.Op = "publish_checkpoint"
 )
 
 // XXX: Define reasonable default gas costs.
 
 // DefaultGasCosts are the "default" gas costs for operations.
 var DefaultGasCosts = transaction.Costs{
-	GasOpComputeCommit:   1000,
-	GasOpProposerTimeout: 1000,
-	GasOpEvidence:        1000,
-	GasOpSubmitMsg:       1000,
+	GasOpComputeCommit:     1000,
+	GasOpProposerTimeout:   1000,
+	GasOpEvidence:          1000,
+	GasOpSubmitMsg:         1000,
+	GasOpPublishCheckpoint: 1000,
 }
 
 // VerifyRuntimeParameters verifies whether the runtime parameters are valid in the context of the
@@ -677,3 +1040,14 @@ type RoundRoots struct {
 	StateRoot hash.Hash
 	IORoot    hash.Hash
 }
+
+func init() {
+	transaction.RegisterGasOps(
+		ModuleName,
+		GasOpComputeCommit,
+		GasOpProposerTimeout,
+		GasOpEvidence,
+		GasOpSubmitMsg,
+		GasOpPublishCheckpoint,
+	)
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/events"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 	genesisTestHelpers "github.com/oasisprotocol/oasis-core/go/genesis/tests"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/commitment"
@@ -654,6 +655,81 @@ func TestEquivocationExecutorEvidenceValidateBasic(t *testing.T) {
 	}
 }
 
+func TestSignTransactions(t *testing.T) {
+	require := require.New(t)
+
+	genesisTestHelpers.SetTestChainContext()
+
+	sk, err := memorySigner.NewSigner(rand.Reader)
+	require.NoError(err, "NewSigner")
+	runtimeID := common.NewTestNamespaceFromSeed([]byte("roothash/api_test/sign: runtime"), 0)
+	fee := &transaction.Fee{Gas: 1000}
+
+	sigTx, err := SignSubmitMsgTx(sk, 1, fee, &SubmitMsg{ID: runtimeID, Data: []byte("data")})
+	require.NoError(err, "SignSubmitMsgTx")
+	var tx transaction.Transaction
+	require.NoError(sigTx.Open(&tx), "Open")
+	require.Equal(MethodSubmitMsg, tx.Method)
+	require.EqualValues(1, tx.Nonce)
+	var msg SubmitMsg
+	require.NoError(cbor.Unmarshal(tx.Body, &msg), "Unmarshal")
+	require.Equal(runtimeID, msg.ID)
+
+	sigTx, err = SignExecutorCommitTx(sk, 2, fee, runtimeID, nil)
+	require.NoError(err, "SignExecutorCommitTx")
+	require.NoError(sigTx.Open(&tx), "Open")
+	require.Equal(MethodExecutorCommit, tx.Method)
+
+	// Evidence should be validated before signing.
+	blk := block.NewGenesisBlock(runtimeID, 0)
+	proposalA := commitment.Proposal{
+		NodeID: sk.Public(),
+		Header: commitment.ProposalHeader{
+			Round:        blk.Header.Round + 1,
+			PreviousHash: blk.Header.EncodedHash(),
+			BatchHash:    blk.Header.IORoot,
+		},
+	}
+	require.NoError(proposalA.Sign(sk, runtimeID), "Proposal.Sign")
+	proposalB := proposalA
+	proposalB.Header.BatchHash = hash.NewFromBytes([]byte("other batch"))
+	require.NoError(proposalB.Sign(sk, runtimeID), "Proposal.Sign")
+
+	_, err = SignEvidenceTx(sk, 3, fee, NewEquivocationProposalEvidence(runtimeID, proposalA, proposalA))
+	require.Error(err, "SignEvidenceTx should fail for invalid evidence")
+
+	ev := NewEquivocationProposalEvidence(runtimeID, proposalA, proposalB)
+	sigTx, err = SignEvidenceTx(sk, 3, fee, ev)
+	require.NoError(err, "SignEvidenceTx")
+	require.NoError(sigTx.Open(&tx), "Open")
+	require.Equal(MethodEvidence, tx.Method)
+	var decEv Evidence
+	require.NoError(cbor.Unmarshal(tx.Body, &decEv), "Unmarshal")
+	require.EqualValues(*ev, decEv)
+
+	// Evidence batches should be deduplicated and validated before signing.
+	_, err = SignEvidenceBatchTx(sk, 4, fee, nil)
+	require.Error(err, "SignEvidenceBatchTx should fail for an empty batch")
+	_, err = SignEvidenceBatchTx(sk, 4, fee, []*Evidence{ev, NewEquivocationProposalEvidence(runtimeID, proposalA, proposalA)})
+	require.Error(err, "SignEvidenceBatchTx should fail for invalid evidence")
+
+	sigTx, err = SignEvidenceBatchTx(sk, 4, fee, []*Evidence{ev, ev})
+	require.NoError(err, "SignEvidenceBatchTx")
+	require.NoError(sigTx.Open(&tx), "Open")
+	require.Equal(MethodEvidenceBatch, tx.Method)
+	var decBatch EvidenceBatch
+	require.NoError(cbor.Unmarshal(tx.Body, &decBatch), "Unmarshal")
+	require.Len(decBatch.Evidence, 1, "duplicate evidence should be omitted")
+	require.EqualValues(*ev, *decBatch.Evidence[0])
+
+	tooBig := make([]*Evidence, 0, MaxEvidenceBatchSize+1)
+	for i := 0; i <= MaxEvidenceBatchSize; i++ {
+		tooBig = append(tooBig, NewEquivocationProposalEvidence(runtimeID, proposalA, proposalB))
+		tooBig[i].EquivocationProposal.ProposalA.Header.Round = uint64(i)
+	}
+	require.Error(NewEvidenceBatch(tooBig...).ValidateBasic(), "evidence batch should not be too big")
+}
+
 func TestRuntimeIDAttribute(t *testing.T) {
 	var runtimeID common.Namespace
 	require.NoError(t, runtimeID.UnmarshalHex("8000000000000000000000000000000000000000000000000000000000000000"), "runtime id")
@@ -0,0 +1,57 @@
+package api
+
+import (
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	storage "github.com/oasisprotocol/oasis-core/go/storage/api"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/checkpoint"
+)
+
+// PublishCheckpoint is the argument set for the PublishCheckpoint method.
+type PublishCheckpoint struct {
+	// Version is the checkpoint format version.
+	Version uint16 `json:"version"`
+	// Root is the checkpointed storage root.
+	Root storage.Root `json:"root"`
+	// NumChunks is the number of chunks in the checkpoint.
+	NumChunks uint64 `json:"num_chunks"`
+	// Hash is the hash of the checkpoint metadata.
+	Hash hash.Hash `json:"hash"`
+}
+
+// NewPublishCheckpointFromMetadata creates a new checkpoint manifest publication from the given
+// checkpoint metadata.
+func NewPublishCheckpointFromMetadata(meta *checkpoint.Metadata) *PublishCheckpoint {
+	return &PublishCheckpoint{
+		Version:   meta.Version,
+		Root:      meta.Root,
+		NumChunks: uint64(len(meta.Chunks)),
+		Hash:      meta.EncodedHash(),
+	}
+}
+
+// NewPublishCheckpointTx creates a new checkpoint manifest publication transaction.
+func NewPublishCheckpointTx(nonce uint64, fee *transaction.Fee, pc *PublishCheckpoint) *transaction.Transaction {
+	return transaction.NewTransaction(nonce, fee, MethodPublishCheckpoint, pc)
+}
+
+// CheckpointManifest is a storage checkpoint manifest published in the consensus layer so that
+// syncing nodes can discover available checkpoints.
+type CheckpointManifest struct {
+	PublishCheckpoint
+
+	// Publishers are the public keys of the nodes that published the manifest and should be able
+	// to serve the checkpoint.
+	Publishers []signature.PublicKey `json:"publishers"`
+}
+
+// HasPublisher returns true iff the given node published the manifest.
+func (m *CheckpointManifest) HasPublisher(id signature.PublicKey) bool {
+	for _, pk := range m.Publishers {
+		if pk.Equal(id) {
+			return true
+		}
+	}
+	return false
+}
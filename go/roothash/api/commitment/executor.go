@@ -8,11 +8,14 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	p2pError "github.com/oasisprotocol/oasis-core/go/p2p/error"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/message"
 )
 
 var (
+	errSignatureVerificationFailed = fmt.Errorf("roothash/commitment: signature verification failed")
+
 	// ExecutorSignatureContext is the signature context used to sign executor
 	// worker commitments.
 	ExecutorSignatureContext = signature.NewContext(
@@ -54,6 +57,9 @@ type ComputeResultsHeader struct {
 	InMessagesHash *hash.Hash `json:"in_msgs_hash,omitempty"`
 	// InMessagesCount is the number of processed incoming messages.
 	InMessagesCount uint32 `json:"in_msgs_count,omitempty"`
+	// InMessagesResultsHash is the hash of the results of processed incoming messages. It is
+	// absent in case the runtime does not report incoming message results.
+	InMessagesResultsHash *hash.Hash `json:"in_msgs_results_hash,omitempty"`
 }
 
 // IsParentOf returns true iff the header is the parent of a child header.
@@ -109,6 +115,7 @@ func (eh *ExecutorCommitmentHeader) SetFailure(failure ExecutorCommitmentFailure
 	eh.Header.MessagesHash = nil
 	eh.Header.InMessagesHash = nil
 	eh.Header.InMessagesCount = 0
+	eh.Header.InMessagesResultsHash = nil
 	eh.RAKSignature = nil
 	eh.Failure = failure
 }
@@ -162,6 +169,13 @@ type ExecutorCommitment struct {
 	// This field is only present in case this commitment belongs to the proposer. In case of
 	// the commitment being submitted as equivocation evidence, this field should be omitted.
 	Messages []message.Message `json:"messages,omitempty"`
+
+	// InMessagesResults are the results of processing incoming messages by the runtime.
+	//
+	// This field is only present in case this commitment belongs to the proposer and the runtime
+	// reports incoming message results. In case of the commitment being submitted as
+	// equivocation evidence, this field should be omitted.
+	InMessagesResults []message.IncomingMessageResult `json:"in_msgs_results,omitempty"`
 }
 
 // Sign signs the executor commitment header and sets the signature on the commitment.
@@ -186,11 +200,53 @@ func (c *ExecutorCommitment) Verify(runtimeID common.Namespace) error {
 	}
 
 	if !c.NodeID.Verify(sigCtx, cbor.Marshal(c.Header), c.Signature[:]) {
-		return fmt.Errorf("roothash/commitment: signature verification failed")
+		return errSignatureVerificationFailed
 	}
 	return nil
 }
 
+// VerifyExecutorCommitmentSignatures verifies the header signatures of the given executor
+// commitments using batch verification, which is considerably faster than verifying each
+// signature separately.
+//
+// The index of each returned error is that of the corresponding commitment. As with
+// VerifyExecutorCommitment, all returned errors are permanent.
+func VerifyExecutorCommitmentSignatures(runtimeID common.Namespace, commits []ExecutorCommitment) []error {
+	errs := make([]error, len(commits))
+
+	// Batch verification of a single signature is slower than verifying it directly.
+	if len(commits) == 1 {
+		if err := commits[0].Verify(runtimeID); err != nil {
+			errs[0] = p2pError.Permanent(err)
+		}
+		return errs
+	}
+
+	sigCtx, err := ExecutorSignatureContext.WithSuffix(runtimeID.String())
+	if err != nil {
+		err = p2pError.Permanent(fmt.Errorf("roothash/commitment: signature context error: %w", err))
+		for i := range errs {
+			errs[i] = err
+		}
+		return errs
+	}
+
+	verifier := signature.NewBatchVerifierWithCapacity(len(commits))
+	for i := range commits {
+		c := &commits[i]
+		verifier.Add(c.NodeID, sigCtx, cbor.Marshal(c.Header), c.Signature[:])
+	}
+
+	_, sigErrs := verifier.Verify()
+	for i, sigErr := range sigErrs {
+		if sigErr != nil {
+			errs[i] = p2pError.Permanent(errSignatureVerificationFailed)
+		}
+	}
+
+	return errs
+}
+
 // ValidateBasic performs basic executor commitment validity checks.
 func (c *ExecutorCommitment) ValidateBasic() error {
 	header := &c.Header.Header
@@ -227,15 +283,15 @@ func (c *ExecutorCommitment) ValidateBasic() error {
 		if header.MessagesHash != nil {
 			return fmt.Errorf("failure indicating commitment includes MessagesHash")
 		}
-		if header.InMessagesHash != nil || header.InMessagesCount != 0 {
-			return fmt.Errorf("failure indicating commitment includes InMessagesHash/Count")
+		if header.InMessagesHash != nil || header.InMessagesCount != 0 || header.InMessagesResultsHash != nil {
+			return fmt.Errorf("failure indicating commitment includes InMessagesHash/Count/ResultsHash")
 		}
 		// In case of failure indicating commitment make sure RAK signature is empty.
 		if c.Header.RAKSignature != nil {
 			return fmt.Errorf("failure indicating body includes RAK signature")
 		}
 		// In case of failure indicating commitment make sure messages are empty.
-		if len(c.Messages) > 0 {
+		if len(c.Messages) > 0 || len(c.InMessagesResults) > 0 {
 			return fmt.Errorf("failure indicating body includes messages")
 		}
 	default:
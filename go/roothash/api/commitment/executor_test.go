@@ -1,12 +1,19 @@
 package commitment
 
 import (
+	"crypto/rand"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	genesisTestHelpers "github.com/oasisprotocol/oasis-core/go/genesis/tests"
+	p2pError "github.com/oasisprotocol/oasis-core/go/p2p/error"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/message"
 )
 
@@ -177,3 +184,96 @@ func TestValidateBasic(t *testing.T) {
 		}
 	}
 }
+
+func TestVerifyExecutorCommitmentSignatures(t *testing.T) {
+	require := require.New(t)
+
+	// Set chain domain separation context, required for signing commitments.
+	genesisTestHelpers.SetTestChainContext()
+
+	var runtimeID common.Namespace
+	commits := generateSignedCommitments(t, runtimeID, 4)
+
+	errs := VerifyExecutorCommitmentSignatures(runtimeID, commits)
+	require.Len(errs, len(commits))
+	for i, err := range errs {
+		require.NoError(err, "commitment %d should be valid", i)
+		require.NoError(commits[i].Verify(runtimeID), "commitment %d should be valid", i)
+	}
+
+	// Corrupt some of the commitments.
+	commits[1].Header.Header.Round++
+	commits[3].NodeID = commits[2].NodeID
+
+	errs = VerifyExecutorCommitmentSignatures(runtimeID, commits)
+	require.Len(errs, len(commits))
+	for i, err := range errs {
+		switch i {
+		case 1, 3:
+			require.Error(err, "commitment %d should be invalid", i)
+			require.True(p2pError.IsPermanent(err), "signature verification errors should be permanent")
+			require.Error(commits[i].Verify(runtimeID), "commitment %d should be invalid", i)
+		default:
+			require.NoError(err, "commitment %d should be valid", i)
+		}
+	}
+
+	// Commitments signed for a different runtime should be invalid.
+	otherRuntimeID := common.NewTestNamespaceFromSeed([]byte("other runtime"), 0)
+	for i, err := range VerifyExecutorCommitmentSignatures(otherRuntimeID, commits[:1]) {
+		require.Error(err, "commitment %d should be invalid", i)
+		require.True(p2pError.IsPermanent(err), "signature verification errors should be permanent")
+	}
+
+	// Empty batches should be valid.
+	require.Empty(VerifyExecutorCommitmentSignatures(runtimeID, nil))
+}
+
+func BenchmarkVerifyExecutorCommitmentSignatures(b *testing.B) {
+	genesisTestHelpers.SetTestChainContext()
+
+	var runtimeID common.Namespace
+	for _, n := range []int{1, 10, 100} {
+		commits := generateSignedCommitments(b, runtimeID, n)
+
+		b.Run(fmt.Sprintf("Single/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				for j := range commits {
+					if err := commits[j].Verify(runtimeID); err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+		})
+
+		b.Run(fmt.Sprintf("Batch/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				for _, err := range VerifyExecutorCommitmentSignatures(runtimeID, commits) {
+					if err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+		})
+	}
+}
+
+func generateSignedCommitments(tb testing.TB, runtimeID common.Namespace, n int) []ExecutorCommitment {
+	lastBlock := block.NewGenesisBlock(runtimeID, 0)
+
+	var schedulerID signature.PublicKey
+	commits := make([]ExecutorCommitment, 0, n)
+	for i := 0; i < n; i++ {
+		signer, err := memorySigner.NewSigner(rand.Reader)
+		require.NoError(tb, err, "NewSigner")
+		if i == 0 {
+			schedulerID = signer.Public()
+		}
+
+		ec := generateCommitment(signer.Public(), schedulerID, lastBlock, nil, nil)
+		require.NoError(tb, ec.Sign(signer, runtimeID), "Sign")
+		commits = append(commits, *ec)
+	}
+
+	return commits
+}
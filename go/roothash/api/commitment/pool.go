@@ -2,6 +2,7 @@ package commitment
 
 import (
 	"context"
+	"fmt"
 	"math"
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
@@ -81,7 +82,7 @@ func NewPool() *Pool {
 }
 
 // VerifyExecutorCommitment verifies the given executor commitment.
-func VerifyExecutorCommitment( // nolint: gocyclo
+func VerifyExecutorCommitment(
 	ctx context.Context,
 	blk *block.Block,
 	rt *registry.Runtime,
@@ -95,6 +96,20 @@ func VerifyExecutorCommitment( // nolint: gocyclo
 		return p2pError.Permanent(err)
 	}
 
+	return VerifyExecutorCommitmentContent(ctx, blk, rt, epoch, commit, msgValidator, nl)
+}
+
+// VerifyExecutorCommitmentContent verifies the given executor commitment, assuming that its
+// signature has already been verified (e.g., via VerifyExecutorCommitmentSignatures).
+func VerifyExecutorCommitmentContent( // nolint: gocyclo
+	ctx context.Context,
+	blk *block.Block,
+	rt *registry.Runtime,
+	epoch beacon.EpochTime,
+	commit *ExecutorCommitment,
+	msgValidator MessageValidator,
+	nl NodeLookup,
+) error {
 	// Validate executor commitment.
 	if err := commit.ValidateBasic(); err != nil {
 		logger.Debug("executor commitment validate basic error",
@@ -205,12 +220,22 @@ func VerifyExecutorCommitment( // nolint: gocyclo
 					return err
 				}
 			}
+
+			// Check incoming message results, if reported by the runtime.
+			if err := verifyInMessagesResults(commit); err != nil {
+				logger.Debug("executor commitment from scheduler has invalid incoming message results",
+					"err", err,
+					"node_id", commit.NodeID,
+				)
+				return ErrInvalidMessages
+			}
 		case false:
 			// Other workers cannot include any messages.
-			if len(commit.Messages) > 0 {
+			if len(commit.Messages) > 0 || len(commit.InMessagesResults) > 0 {
 				logger.Debug("executor commitment from non-scheduler contains messages",
 					"node_id", commit.NodeID,
 					"num_messages", len(commit.Messages),
+					"num_in_msgs_results", len(commit.InMessagesResults),
 				)
 				return ErrInvalidMessages
 			}
@@ -220,6 +245,28 @@ func VerifyExecutorCommitment( // nolint: gocyclo
 	return nil
 }
 
+// verifyInMessagesResults verifies that the incoming message results included in the scheduler's
+// commitment correspond to the results hash in the commitment header.
+func verifyInMessagesResults(commit *ExecutorCommitment) error {
+	header := &commit.Header.Header
+	if header.InMessagesResultsHash == nil {
+		if len(commit.InMessagesResults) > 0 {
+			return fmt.Errorf("results included without results hash")
+		}
+		return nil
+	}
+	if uint32(len(commit.InMessagesResults)) != header.InMessagesCount {
+		return fmt.Errorf("number of results does not match number of processed messages (expected: %d got: %d)",
+			header.InMessagesCount,
+			len(commit.InMessagesResults),
+		)
+	}
+	if h := message.InMessagesResultsHash(commit.InMessagesResults); !h.Equal(header.InMessagesResultsHash) {
+		return fmt.Errorf("results hash mismatch (expected: %s got: %s)", header.InMessagesResultsHash, h)
+	}
+	return nil
+}
+
 // AddVerifiedExecutorCommitment adds a verified executor commitment to the pool.
 func (p *Pool) AddVerifiedExecutorCommitment(c *scheduler.Committee, ec *ExecutorCommitment) error {
 	// Enforce specific roles based on current discrepancy state.
@@ -931,6 +931,53 @@ func TestVerify(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("Incoming message results", func(t *testing.T) {
+		results := []message.IncomingMessageResult{
+			{},
+			{Module: "test", Code: 1},
+		}
+		resultsHash := message.InMessagesResultsHash(results)
+
+		generate := func(signer signature.Signer) *ExecutorCommitment {
+			ec := generateCommitment(signer.Public(), worker.Public(), lastBlock, nil, nil)
+			ec.Header.Header.InMessagesCount = uint32(len(results))
+			ec.Header.Header.InMessagesResultsHash = &resultsHash
+			ec.InMessagesResults = results
+			return ec
+		}
+		verify := func(signer signature.Signer, ec *ExecutorCommitment) error {
+			err := ec.Sign(signer, id)
+			require.NoError(t, err)
+			return VerifyExecutorCommitment(ctx, lastBlock, rtTEE, committee.ValidFor, ec, nil, nil)
+		}
+
+		// Schedulers are allowed to include results.
+		err = verify(worker, generate(worker))
+		require.NoError(t, err)
+
+		// Non-schedulers are not.
+		err = verify(backup, generate(backup))
+		require.ErrorIs(t, err, ErrInvalidMessages)
+
+		// There should be a result for each processed message.
+		ec := generate(worker)
+		ec.Header.Header.InMessagesCount++
+		err = verify(worker, ec)
+		require.ErrorIs(t, err, ErrInvalidMessages)
+
+		// And the hash should match.
+		ec = generate(worker)
+		ec.InMessagesResults = []message.IncomingMessageResult{{}, {}}
+		err = verify(worker, ec)
+		require.ErrorIs(t, err, ErrInvalidMessages)
+
+		// Results cannot be included without the results hash.
+		ec = generate(worker)
+		ec.Header.Header.InMessagesResultsHash = nil
+		err = verify(worker, ec)
+		require.ErrorIs(t, err, ErrInvalidMessages)
+	})
+
 	t.Run("TEE", func(t *testing.T) {
 		// Prepare a TEE runtime.
 		rtTEE = &registry.Runtime{
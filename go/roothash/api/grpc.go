@@ -25,6 +25,10 @@ var (
 	methodGetRuntimeState = serviceName.NewMethod("GetRuntimeState", RuntimeRequest{})
 	// methodGetLastRoundResults is the GetLastRoundResults method.
 	methodGetLastRoundResults = serviceName.NewMethod("GetLastRoundResults", RuntimeRequest{})
+	// methodGetLastRoundMetadata is the GetLastRoundMetadata method.
+	methodGetLastRoundMetadata = serviceName.NewMethod("GetLastRoundMetadata", RuntimeRequest{})
+	// methodGetLivenessStatistics is the GetLivenessStatistics method.
+	methodGetLivenessStatistics = serviceName.NewMethod("GetLivenessStatistics", RuntimeRequest{})
 	// methodGetRoundRoots is the GetRoundRoots method.
 	methodGetRoundRoots = serviceName.NewMethod("GetRoundRoots", RoundRootsRequest{})
 	// methodGetPastRoundRoots is the GetPastRoundRoots method.
@@ -33,6 +37,8 @@ var (
 	methodGetIncomingMessageQueueMeta = serviceName.NewMethod("GetIncomingMessageQueueMeta", RuntimeRequest{})
 	// methodGetIncomingMessageQueue is the GetIncomingMessageQueue method.
 	methodGetIncomingMessageQueue = serviceName.NewMethod("GetIncomingMessageQueue", InMessageQueueRequest{})
+	// methodGetCheckpointManifests is the GetCheckpointManifests method.
+	methodGetCheckpointManifests = serviceName.NewMethod("GetCheckpointManifests", RuntimeRequest{})
 	// methodStateToGenesis is the StateToGenesis method.
 	methodStateToGenesis = serviceName.NewMethod("StateToGenesis", int64(0))
 	// methodConsensusParameters is the ConsensusParameters method.
@@ -44,6 +50,8 @@ var (
 	methodWatchBlocks = serviceName.NewMethod("WatchBlocks", common.Namespace{})
 	// methodWatchEvents is the WatchEvents method.
 	methodWatchEvents = serviceName.NewMethod("WatchEvents", common.Namespace{})
+	// methodWatchIncomingMessages is the WatchIncomingMessages method.
+	methodWatchIncomingMessages = serviceName.NewMethod("WatchIncomingMessages", common.Namespace{})
 	// methodWatchExecutorCommitments is the WatchExecutorCommitments method.
 	methodWatchExecutorCommitments = serviceName.NewMethod("WatchExecutorCommitments", nil)
 
@@ -68,6 +76,14 @@ var (
 				MethodName: methodGetLastRoundResults.ShortName(),
 				Handler:    handlerGetLastRoundResults,
 			},
+			{
+				MethodName: methodGetLastRoundMetadata.ShortName(),
+				Handler:    handlerGetLastRoundMetadata,
+			},
+			{
+				MethodName: methodGetLivenessStatistics.ShortName(),
+				Handler:    handlerGetLivenessStatistics,
+			},
 			{
 				MethodName: methodGetRoundRoots.ShortName(),
 				Handler:    handlerGetRoundRoots,
@@ -84,6 +100,10 @@ var (
 				MethodName: methodGetIncomingMessageQueue.ShortName(),
 				Handler:    handlerGetIncomingMessageQueue,
 			},
+			{
+				MethodName: methodGetCheckpointManifests.ShortName(),
+				Handler:    handlerGetCheckpointManifests,
+			},
 			{
 				MethodName: methodStateToGenesis.ShortName(),
 				Handler:    handlerStateToGenesis,
@@ -113,6 +133,11 @@ var (
 				Handler:       handlerWatchExecutorCommitments,
 				ServerStreams: true,
 			},
+			{
+				StreamName:    methodWatchIncomingMessages.ShortName(),
+				Handler:       handlerWatchIncomingMessages,
+				ServerStreams: true,
+			},
 		},
 	}
 )
@@ -209,6 +234,52 @@ func handlerGetLastRoundResults(
 	return interceptor(ctx, &rq, info, handler)
 }
 
+func handlerGetLastRoundMetadata(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var rq RuntimeRequest
+	if err := dec(&rq); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).GetLastRoundMetadata(ctx, &rq)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetLastRoundMetadata.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).GetLastRoundMetadata(ctx, req.(*RuntimeRequest))
+	}
+	return interceptor(ctx, &rq, info, handler)
+}
+
+func handlerGetLivenessStatistics(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var rq RuntimeRequest
+	if err := dec(&rq); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).GetLivenessStatistics(ctx, &rq)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetLivenessStatistics.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).GetLivenessStatistics(ctx, req.(*RuntimeRequest))
+	}
+	return interceptor(ctx, &rq, info, handler)
+}
+
 func handlerGetRoundRoots(
 	srv interface{},
 	ctx context.Context,
@@ -255,6 +326,29 @@ func handlerGetPastRoundRoots(
 	return interceptor(ctx, &rq, info, handler)
 }
 
+func handlerGetCheckpointManifests(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var rq RuntimeRequest
+	if err := dec(&rq); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).GetCheckpointManifests(ctx, &rq)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetCheckpointManifests.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).GetCheckpointManifests(ctx, req.(*RuntimeRequest))
+	}
+	return interceptor(ctx, &rq, info, handler)
+}
+
 func handlerGetIncomingMessageQueueMeta(
 	srv interface{},
 	ctx context.Context,
@@ -457,6 +551,35 @@ func handlerWatchExecutorCommitments(srv interface{}, stream grpc.ServerStream)
 	}
 }
 
+func handlerWatchIncomingMessages(srv interface{}, stream grpc.ServerStream) error {
+	var runtimeID common.Namespace
+	if err := stream.RecvMsg(&runtimeID); err != nil {
+		return err
+	}
+
+	ctx := stream.Context()
+	ch, sub, err := srv.(Backend).WatchIncomingMessages(ctx, runtimeID)
+	if err != nil {
+		return err
+	}
+	defer sub.Close()
+
+	for {
+		select {
+		case ev, ok := <-ch:
+			if !ok {
+				return nil
+			}
+
+			if err := stream.SendMsg(ev); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
 // RegisterService registers a new roothash service with the given gRPC server.
 func RegisterService(server *grpc.Server, service Backend) {
 	server.RegisterService(&serviceDesc, service)
@@ -498,6 +621,22 @@ func (c *roothashClient) GetLastRoundResults(ctx context.Context, request *Runti
 	return &rsp, nil
 }
 
+func (c *roothashClient) GetLastRoundMetadata(ctx context.Context, request *RuntimeRequest) (*RoundMetadata, error) {
+	var rsp RoundMetadata
+	if err := c.conn.Invoke(ctx, methodGetLastRoundMetadata.FullName(), request, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
+func (c *roothashClient) GetLivenessStatistics(ctx context.Context, request *RuntimeRequest) (*RuntimeLivenessStatistics, error) {
+	var rsp RuntimeLivenessStatistics
+	if err := c.conn.Invoke(ctx, methodGetLivenessStatistics.FullName(), request, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *roothashClient) GetRoundRoots(ctx context.Context, request *RoundRootsRequest) (*RoundRoots, error) {
 	var rsp RoundRoots
 	if err := c.conn.Invoke(ctx, methodGetRoundRoots.FullName(), request, &rsp); err != nil {
@@ -514,6 +653,14 @@ func (c *roothashClient) GetPastRoundRoots(ctx context.Context, request *Runtime
 	return rsp, nil
 }
 
+func (c *roothashClient) GetCheckpointManifests(ctx context.Context, request *RuntimeRequest) ([]*CheckpointManifest, error) {
+	var rsp []*CheckpointManifest
+	if err := c.conn.Invoke(ctx, methodGetCheckpointManifests.FullName(), request, &rsp); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
 func (c *roothashClient) GetIncomingMessageQueueMeta(ctx context.Context, request *RuntimeRequest) (*message.IncomingMessageQueueMeta, error) {
 	var rsp message.IncomingMessageQueueMeta
 	if err := c.conn.Invoke(ctx, methodGetIncomingMessageQueueMeta.FullName(), request, &rsp); err != nil {
@@ -666,6 +813,41 @@ func (c *roothashClient) WatchExecutorCommitments(ctx context.Context, runtimeID
 	return ch, sub, nil
 }
 
+func (c *roothashClient) WatchIncomingMessages(ctx context.Context, runtimeID common.Namespace) (<-chan *InMsgQueuedEvent, pubsub.ClosableSubscription, error) {
+	ctx, sub := pubsub.NewContextSubscription(ctx)
+
+	stream, err := c.conn.NewStream(ctx, &serviceDesc.Streams[3], methodWatchIncomingMessages.FullName())
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = stream.SendMsg(runtimeID); err != nil {
+		return nil, nil, err
+	}
+	if err = stream.CloseSend(); err != nil {
+		return nil, nil, err
+	}
+
+	ch := make(chan *InMsgQueuedEvent)
+	go func() {
+		defer close(ch)
+
+		for {
+			var ev InMsgQueuedEvent
+			if serr := stream.RecvMsg(&ev); serr != nil {
+				return
+			}
+
+			select {
+			case ch <- &ev:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch, sub, nil
+}
+
 // NewRootHashClient creates a new gRPC roothash client service.
 func NewRootHashClient(c *grpc.ClientConn) Backend {
 	return &roothashClient{
@@ -39,6 +39,14 @@ type BlockHistory interface {
 	// LastStorageSyncedRound returns the last runtime round which was synced to storage.
 	LastStorageSyncedRound() (uint64, error)
 
+	// SetReindexProgress records the progress of an ongoing history reindex. Passing nil clears
+	// the progress once reindexing has completed.
+	SetReindexProgress(progress *HistoryReindexProgress)
+
+	// ReindexProgress returns the progress of an ongoing history reindex or nil in case no
+	// reindex is in progress.
+	ReindexProgress() *HistoryReindexProgress
+
 	// WatchBlocks returns a channel watching block rounds as they are committed.
 	// If node has local storage this includes waiting for the round to be synced into storage.
 	WatchBlocks() (<-chan *AnnotatedBlock, pubsub.ClosableSubscription, error)
@@ -75,3 +83,42 @@ type BlockHistory interface {
 	// Passing the special value `RoundLatest` will return results for the latest round.
 	GetRoundResults(ctx context.Context, round uint64) (*RoundResults, error)
 }
+
+// HistoryReindexProgress is the progress of a block history reindex.
+type HistoryReindexProgress struct {
+	// StartHeight is the first consensus height being reindexed.
+	StartHeight int64 `json:"start_height"`
+	// EndHeight is the last consensus height being reindexed.
+	EndHeight int64 `json:"end_height"`
+	// LastHeight is the last consensus height that has been reindexed.
+	LastHeight int64 `json:"last_height"`
+	// Percent is the percentage of consensus heights that have been reindexed.
+	Percent float64 `json:"percent"`
+}
+
+// NewHistoryReindexProgress creates a new reindex progress for the given range of heights.
+func NewHistoryReindexProgress(startHeight, endHeight int64) *HistoryReindexProgress {
+	p := &HistoryReindexProgress{
+		StartHeight: startHeight,
+		EndHeight:   endHeight,
+	}
+	p.Advance(startHeight - 1)
+	return p
+}
+
+// Advance records that all consensus heights up to and including the given height have been
+// reindexed.
+func (p *HistoryReindexProgress) Advance(height int64) {
+	p.LastHeight = height
+
+	total := p.EndHeight - p.StartHeight + 1
+	done := height - p.StartHeight + 1
+	switch {
+	case total <= 0 || done >= total:
+		p.Percent = 100
+	case done <= 0:
+		p.Percent = 0
+	default:
+		p.Percent = 100 * float64(done) / float64(total)
+	}
+}
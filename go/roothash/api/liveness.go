@@ -1,5 +1,10 @@
 package api
 
+import (
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
+)
+
 // LivenessStatistics has the per-epoch liveness statistics for nodes.
 type LivenessStatistics struct {
 	// TotalRounds is the total number of rounds in the last epoch, excluding any rounds generated
@@ -23,6 +28,22 @@ type LivenessStatistics struct {
 	// The list is ordered according to the committee arrangement (i.e., the counter at index i
 	// holds the value for the node at index i in the committee).
 	MissedProposals []uint64 `json:"missed_proposals"`
+
+	// DiscrepanciesCaused is a list that records the number of rounds in which a node submitted
+	// a commitment that disagreed with the result of discrepancy resolution.
+	//
+	// The list is ordered according to the committee arrangement (i.e., the counter at index i
+	// holds the value for the node at index i in the committee). It is only populated for runtimes
+	// that slash nodes for repeated discrepancies.
+	DiscrepanciesCaused []uint64 `json:"discrepancies_caused,omitempty"`
+}
+
+// RecordDiscrepancy records a discrepancy caused by the node at the given committee index.
+func (s *LivenessStatistics) RecordDiscrepancy(idx int) {
+	if s.DiscrepanciesCaused == nil {
+		s.DiscrepanciesCaused = make([]uint64, len(s.LiveRounds))
+	}
+	s.DiscrepanciesCaused[idx]++
 }
 
 // NewLivenessStatistics creates a new instance of per-epoch liveness statistics.
@@ -34,3 +55,75 @@ func NewLivenessStatistics(numNodes int) *LivenessStatistics {
 		MissedProposals:    make([]uint64, numNodes),
 	}
 }
+
+// MinLiveRounds returns the minimum number of live rounds a node must have in order to be
+// considered live given the minimum percentage of live rounds.
+func (s *LivenessStatistics) MinLiveRounds(minLiveRoundsPercent uint8) uint64 {
+	return (s.TotalRounds * uint64(minLiveRoundsPercent)) / 100
+}
+
+// NodeLivenessStatistics are the liveness statistics of a single executor committee member for
+// the current epoch.
+type NodeLivenessStatistics struct {
+	// NodeID is the identifier of the node.
+	NodeID signature.PublicKey `json:"node_id"`
+
+	// Role is the role of the node in the executor committee.
+	Role scheduler.Role `json:"role"`
+
+	// ElectedRounds is the number of rounds in which the node was elected.
+	ElectedRounds uint64 `json:"elected_rounds"`
+
+	// LiveRounds is the number of rounds in which the node participated.
+	LiveRounds uint64 `json:"live_rounds"`
+
+	// FinalizedProposals is the number of finalized rounds when the node acted as a proposer
+	// with the highest rank.
+	FinalizedProposals uint64 `json:"finalized_proposals"`
+
+	// MissedProposals is the number of failed rounds when the node acted as a proposer with
+	// the highest rank.
+	MissedProposals uint64 `json:"missed_proposals"`
+}
+
+// RuntimeLivenessStatistics are the per-node liveness statistics of a runtime's executor
+// committee for the current epoch.
+type RuntimeLivenessStatistics struct {
+	// TotalRounds is the total number of rounds in the current epoch, excluding any rounds
+	// generated by the roothash service itself.
+	TotalRounds uint64 `json:"total_rounds"`
+
+	// MinLiveRounds is the minimum number of live rounds a worker needs at the end of the epoch
+	// in order not to be deemed faulty, based on the rounds so far.
+	MinLiveRounds uint64 `json:"min_live_rounds"`
+
+	// Nodes are the liveness statistics of the executor committee members, in committee order.
+	Nodes []*NodeLivenessStatistics `json:"nodes,omitempty"`
+}
+
+// NewRuntimeLivenessStatistics creates per-node liveness statistics from the given runtime state.
+func NewRuntimeLivenessStatistics(rtState *RuntimeState) *RuntimeLivenessStatistics {
+	var stats RuntimeLivenessStatistics
+	if rtState.Committee == nil {
+		return &stats
+	}
+
+	ls := rtState.LivenessStatistics
+	if ls == nil {
+		ls = NewLivenessStatistics(len(rtState.Committee.Members))
+	}
+	stats.TotalRounds = ls.TotalRounds
+	stats.MinLiveRounds = ls.MinLiveRounds(rtState.Runtime.Executor.MinLiveRoundsPercent)
+
+	for i, n := range rtState.Committee.Members {
+		stats.Nodes = append(stats.Nodes, &NodeLivenessStatistics{
+			NodeID:             n.PublicKey,
+			Role:               n.Role,
+			ElectedRounds:      ls.TotalRounds,
+			LiveRounds:         ls.LiveRounds[i],
+			FinalizedProposals: ls.FinalizedProposals[i],
+			MissedProposals:    ls.MissedProposals[i],
+		})
+	}
+	return &stats
+}
@@ -0,0 +1,70 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
+)
+
+func TestNewRuntimeLivenessStatistics(t *testing.T) {
+	require := require.New(t)
+
+	worker := signature.NewPublicKey("0000000000000000000000000000000000000000000000000000000000000000")
+	backup := signature.NewPublicKey("0000000000000000000000000000000000000000000000000000000000000001")
+
+	rtState := &RuntimeState{
+		Runtime: &registry.Runtime{
+			Executor: registry.ExecutorParameters{
+				MinLiveRoundsPercent: 90,
+			},
+		},
+	}
+
+	// No committee.
+	stats := NewRuntimeLivenessStatistics(rtState)
+	require.EqualValues(0, stats.TotalRounds)
+	require.Empty(stats.Nodes)
+
+	// Committee without statistics.
+	rtState.Committee = &scheduler.Committee{
+		Members: []*scheduler.CommitteeNode{
+			{Role: scheduler.RoleWorker, PublicKey: worker},
+			{Role: scheduler.RoleBackupWorker, PublicKey: backup},
+		},
+	}
+	stats = NewRuntimeLivenessStatistics(rtState)
+	require.EqualValues(0, stats.TotalRounds)
+	require.Len(stats.Nodes, 2)
+	require.EqualValues(0, stats.Nodes[0].LiveRounds)
+
+	// Committee with statistics.
+	rtState.LivenessStatistics = &LivenessStatistics{
+		TotalRounds:        20,
+		LiveRounds:         []uint64{18, 3},
+		FinalizedProposals: []uint64{15, 0},
+		MissedProposals:    []uint64{1, 0},
+	}
+	stats = NewRuntimeLivenessStatistics(rtState)
+	require.EqualValues(20, stats.TotalRounds)
+	require.EqualValues(18, stats.MinLiveRounds)
+	require.Equal([]*NodeLivenessStatistics{
+		{
+			NodeID:             worker,
+			Role:               scheduler.RoleWorker,
+			ElectedRounds:      20,
+			LiveRounds:         18,
+			FinalizedProposals: 15,
+			MissedProposals:    1,
+		},
+		{
+			NodeID:        backup,
+			Role:          scheduler.RoleBackupWorker,
+			ElectedRounds: 20,
+			LiveRounds:    3,
+		},
+	}, stats.Nodes)
+}
@@ -2,6 +2,7 @@ package message
 
 import (
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/errors"
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
 )
@@ -40,6 +41,30 @@ func InMessagesHash(msgs []*IncomingMessage) (h hash.Hash) {
 	return hash.NewFrom(msgs)
 }
 
+// IncomingMessageResult is the result of processing an incoming message by the runtime.
+type IncomingMessageResult struct {
+	// Module is the module name of the error returned by the runtime, if any.
+	Module string `json:"module,omitempty"`
+
+	// Code is the error code returned by the runtime, if any.
+	Code uint32 `json:"code,omitempty"`
+}
+
+// IsSuccess returns true iff the incoming message was successfully processed.
+func (r *IncomingMessageResult) IsSuccess() bool {
+	return r.Code == errors.CodeNoError
+}
+
+// InMessagesResultsHash returns a hash of provided incoming runtime message results.
+func InMessagesResultsHash(results []IncomingMessageResult) (h hash.Hash) {
+	if len(results) == 0 {
+		// Special case if there are no results.
+		h.Empty()
+		return
+	}
+	return hash.NewFrom(results)
+}
+
 // IncomingMessageQueueMeta is the incoming message queue metadata.
 type IncomingMessageQueueMeta struct {
 	// Size contains the current size of the queue.
@@ -1,6 +1,10 @@
 package api
 
-import "github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+import (
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
+)
 
 // RoundResults contains information about how a particular round was executed by the consensus
 // layer.
@@ -8,6 +12,9 @@ type RoundResults struct {
 	// Messages are the results of executing emitted runtime messages.
 	Messages []*MessageEvent `json:"messages,omitempty"`
 
+	// InMessages are the results of processing incoming runtime messages.
+	InMessages []*InMsgProcessedEvent `json:"in_messages,omitempty"`
+
 	// GoodComputeEntities are the public keys of compute nodes' controlling entities that
 	// positively contributed to the round by replicating the computation correctly.
 	GoodComputeEntities []signature.PublicKey `json:"good_compute_entities,omitempty"`
@@ -15,3 +22,86 @@ type RoundResults struct {
 	// negatively contributed to the round by causing discrepancies.
 	BadComputeEntities []signature.PublicKey `json:"bad_compute_entities,omitempty"`
 }
+
+// CommitmentStatus is the status of an executor committee member's commitment in a round.
+type CommitmentStatus uint8
+
+const (
+	// CommitmentMissing indicates that the member did not submit a commitment.
+	CommitmentMissing CommitmentStatus = 0
+	// CommitmentAgreed indicates that the member submitted a commitment which agreed with the
+	// scheduler's proposal.
+	CommitmentAgreed CommitmentStatus = 1
+	// CommitmentDisagreed indicates that the member submitted a commitment which disagreed with
+	// the scheduler's proposal.
+	CommitmentDisagreed CommitmentStatus = 2
+	// CommitmentFailed indicates that the member submitted a failure indication.
+	CommitmentFailed CommitmentStatus = 3
+)
+
+// String returns a string representation of the commitment status.
+func (s CommitmentStatus) String() string {
+	switch s {
+	case CommitmentMissing:
+		return "missing"
+	case CommitmentAgreed:
+		return "agreed"
+	case CommitmentDisagreed:
+		return "disagreed"
+	case CommitmentFailed:
+		return "failed"
+	default:
+		return "[unknown commitment status]"
+	}
+}
+
+// RoundMemberStatus is the commitment status of an executor committee member in a round.
+type RoundMemberStatus struct {
+	// PublicKey is the public key of the committee member.
+	PublicKey signature.PublicKey `json:"public_key"`
+	// Role is the role of the committee member.
+	Role scheduler.Role `json:"role"`
+	// Status is the status of the member's commitment.
+	Status CommitmentStatus `json:"status"`
+}
+
+// RoundMetadata contains information about how the executor committee took part in a particular
+// round.
+type RoundMetadata struct {
+	// Round is the runtime round.
+	Round uint64 `json:"round"`
+	// HeaderType is the type of the block that finalized the round.
+	HeaderType block.HeaderType `json:"header_type"`
+
+	// StartHeight is the consensus height at which the previous round was finalized.
+	StartHeight int64 `json:"start_height"`
+	// FinalizeHeight is the consensus height at which the round was finalized.
+	FinalizeHeight int64 `json:"finalize_height"`
+	// Timeout is true iff the round was finalized after the round timeout expired.
+	Timeout bool `json:"timeout,omitempty"`
+
+	// Discrepancy is true iff a discrepancy was detected during the round.
+	Discrepancy bool `json:"discrepancy,omitempty"`
+	// Rank is the rank of the scheduler whose proposal the commitments were compared against.
+	Rank uint64 `json:"rank"`
+	// SchedulerID is the public key of the scheduler whose proposal the commitments were
+	// compared against. It is nil in case no scheduler submitted a proposal.
+	SchedulerID *signature.PublicKey `json:"scheduler_id,omitempty"`
+	// Members are the commitment statuses of all executor committee members.
+	Members []*RoundMemberStatus `json:"members,omitempty"`
+
+	// Error is the reason for the round failure in case the round failed.
+	Error string `json:"error,omitempty"`
+}
+
+// Stragglers returns the public keys of primary executor workers that did not submit a
+// commitment in the round.
+func (m *RoundMetadata) Stragglers() []signature.PublicKey {
+	var stragglers []signature.PublicKey
+	for _, member := range m.Members {
+		if member.Role == scheduler.RoleWorker && member.Status == CommitmentMissing {
+			stragglers = append(stragglers, member.PublicKey)
+		}
+	}
+	return stragglers
+}
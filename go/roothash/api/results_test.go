@@ -8,6 +8,7 @@ import (
 
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
 )
 
 func TestRoundResultsSerialization(t *testing.T) {
@@ -48,3 +49,22 @@ func TestRoundResultsSerialization(t *testing.T) {
 		require.EqualValues(tc.rr, dec, "RoundResults serialization should round-trip")
 	}
 }
+
+func TestRoundMetadataStragglers(t *testing.T) {
+	require := require.New(t)
+
+	pk1 := signature.NewPublicKey("0000000000000000000000000000000000000000000000000000000000000001")
+	pk2 := signature.NewPublicKey("0000000000000000000000000000000000000000000000000000000000000002")
+	pk3 := signature.NewPublicKey("0000000000000000000000000000000000000000000000000000000000000003")
+
+	md := RoundMetadata{
+		Members: []*RoundMemberStatus{
+			{PublicKey: pk1, Role: scheduler.RoleWorker, Status: CommitmentAgreed},
+			{PublicKey: pk2, Role: scheduler.RoleWorker, Status: CommitmentMissing},
+			{PublicKey: pk3, Role: scheduler.RoleWorker, Status: CommitmentFailed},
+			{PublicKey: pk3, Role: scheduler.RoleBackupWorker, Status: CommitmentMissing},
+		},
+	}
+	require.Equal([]signature.PublicKey{pk2}, md.Stragglers(), "only primary workers without commitments should be stragglers")
+	require.Empty((&RoundMetadata{}).Stragglers())
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
@@ -51,8 +52,21 @@ func (c *ConsensusParameterChanges) SanityCheck() error {
 		c.MaxRuntimeMessages == nil &&
 		c.MaxInRuntimeMessages == nil &&
 		c.MaxEvidenceAge == nil &&
-		c.MaxPastRootsStored == nil {
+		c.MaxPastRootsStored == nil &&
+		c.MaxCheckpointManifests == nil &&
+		c.InMessageFeePerMessage == nil &&
+		c.InMessageFeePerByte == nil &&
+		len(c.PauseRuntimes) == 0 &&
+		len(c.ResumeRuntimes) == 0 {
 		return fmt.Errorf("consensus parameter changes should not be empty")
 	}
+
+	seen := make(map[common.Namespace]struct{})
+	for _, id := range append(slices.Clone(c.PauseRuntimes), c.ResumeRuntimes...) {
+		if _, ok := seen[id]; ok {
+			return fmt.Errorf("runtime %s is paused or resumed more than once", id)
+		}
+		seen[id] = struct{}{}
+	}
 	return nil
 }
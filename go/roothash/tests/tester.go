@@ -448,6 +448,21 @@ func (s *runtimeState) livenessStatistics(t *testing.T, ctx context.Context, bac
 	require.Len(state.LivenessStatistics.FinalizedProposals, numNodes)
 	require.Len(state.LivenessStatistics.MissedProposals, numNodes)
 
+	// Make sure the per-node statistics match the runtime state.
+	stats, err := backend.GetLivenessStatistics(ctx, &api.RuntimeRequest{
+		RuntimeID: s.rt.Runtime.ID,
+		Height:    height,
+	})
+	require.NoError(err, "GetLivenessStatistics")
+	require.Equal(state.LivenessStatistics.TotalRounds, stats.TotalRounds)
+	require.Len(stats.Nodes, numNodes)
+	for i, n := range stats.Nodes {
+		require.Equal(state.Committee.Members[i].PublicKey, n.NodeID)
+		require.Equal(state.LivenessStatistics.LiveRounds[i], n.LiveRounds)
+		require.Equal(state.LivenessStatistics.FinalizedProposals[i], n.FinalizedProposals)
+		require.Equal(state.LivenessStatistics.MissedProposals[i], n.MissedProposals)
+	}
+
 	return state.LivenessStatistics
 }
 
@@ -961,13 +976,7 @@ func testSubmitEquivocationEvidence(t *testing.T, backend api.Backend, consensus
 	require.NoError(err, "AddEscrow")
 
 	// Submit evidence of executor equivocation.
-	tx = api.NewEvidenceTx(0, nil, &api.Evidence{
-		ID: s.rt.Runtime.ID,
-		EquivocationProposal: &api.EquivocationProposalEvidence{
-			ProposalA: signedBatch1,
-			ProposalB: signedBatch2,
-		},
-	})
+	tx = api.NewEvidenceTx(0, nil, api.NewEquivocationProposalEvidence(s.rt.Runtime.ID, signedBatch1, signedBatch2))
 	submitter := s.executorCommittee.workers[1]
 	err = consensusAPI.SignAndSubmitTx(ctx, consensus, submitter.Signer, tx)
 	require.NoError(err, "SignAndSubmitTx(EvidenceTx)")
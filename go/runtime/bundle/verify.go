@@ -0,0 +1,52 @@
+package bundle
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	registryAPI "github.com/oasisprotocol/oasis-core/go/registry/api"
+	"github.com/oasisprotocol/oasis-core/go/runtime/bundle/component"
+)
+
+// VerifyDeployment verifies that the bundle matches the given on-chain deployment of the given
+// runtime.
+//
+// The runtime identifier, version and (if present in the deployment) the manifest checksum must
+// match. For runtimes requiring a TEE, at least one of the enclave identities of the RONL component
+// must be allowed by the deployment's TEE constraints.
+func (bnd *Bundle) VerifyDeployment(rt *registryAPI.Runtime, deployment *registryAPI.VersionInfo) error {
+	if !bnd.Manifest.ID.Equal(&rt.ID) {
+		return fmt.Errorf("runtime/bundle: runtime ID mismatch (got: %s expected: %s)", bnd.Manifest.ID, rt.ID)
+	}
+	if v := bnd.Manifest.GetVersion(); v.ToU64() != deployment.Version.ToU64() {
+		return fmt.Errorf("runtime/bundle: version mismatch (got: %s expected: %s)", v, deployment.Version)
+	}
+	if len(deployment.BundleChecksum) > 0 && !bytes.Equal(bnd.manifestHash[:], deployment.BundleChecksum) {
+		return fmt.Errorf("runtime/bundle: manifest checksum mismatch (got: %s expected: %X)", bnd.manifestHash, deployment.BundleChecksum)
+	}
+
+	switch rt.TEEHardware {
+	case node.TEEHardwareInvalid:
+		return nil
+	case node.TEEHardwareIntelSGX:
+		var cs node.SGXConstraints
+		if err := cbor.Unmarshal(deployment.TEE, &cs); err != nil {
+			return fmt.Errorf("runtime/bundle: invalid SGX TEE constraints: %w", err)
+		}
+
+		ids, err := bnd.EnclaveIdentities(component.ID_RONL)
+		if err != nil {
+			return err
+		}
+		for _, id := range ids {
+			if cs.ContainsEnclave(id) {
+				return nil
+			}
+		}
+		return fmt.Errorf("runtime/bundle: none of the enclave identities are allowed by the deployment (got: %v)", ids)
+	default:
+		return fmt.Errorf("runtime/bundle: unsupported TEE hardware: %s", rt.TEEHardware)
+	}
+}
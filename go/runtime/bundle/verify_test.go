@@ -0,0 +1,106 @@
+package bundle
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/sgx"
+	"github.com/oasisprotocol/oasis-core/go/common/version"
+	registryAPI "github.com/oasisprotocol/oasis-core/go/registry/api"
+	"github.com/oasisprotocol/oasis-core/go/runtime/bundle/component"
+)
+
+func TestVerifyDeployment(t *testing.T) {
+	require := require.New(t)
+
+	enclaveID := sgx.EnclaveIdentity{
+		MrSigner:  sgx.MrSigner{0x01},
+		MrEnclave: sgx.MrEnclave{0x02},
+	}
+
+	var rt registryAPI.Runtime
+	err := rt.ID.UnmarshalHex("c000000000000000ffffffffffffffffffffffffffffffffffffffffffffffff")
+	require.NoError(err, "UnmarshalHex")
+
+	// Create and open a synthetic bundle.
+	bnd := &Bundle{
+		Manifest: &Manifest{
+			Name: "test-runtime",
+			ID:   rt.ID,
+			Components: []*Component{
+				{
+					Kind:    component.RONL,
+					Version: version.Version{Major: 1, Minor: 2},
+					ELF: &ELFMetadata{
+						Executable: "runtime.bin",
+					},
+					SGX: &SGXMetadata{
+						Executable: "runtime.sgx",
+					},
+					Identities: []Identity{
+						{
+							Enclave: enclaveID,
+						},
+					},
+				},
+			},
+		},
+	}
+	err = bnd.Add("runtime.bin", NewBytesData(randBuffer(1024)))
+	require.NoError(err, "bundle.Add(elf)")
+	err = bnd.Add("runtime.sgx", NewBytesData(randBuffer(1024)))
+	require.NoError(err, "bundle.Add(sgx)")
+
+	bundleFn := filepath.Join(t.TempDir(), "bundle.orc")
+	err = bnd.Write(bundleFn)
+	require.NoError(err, "bundle.Write")
+
+	bnd, err = Open(bundleFn)
+	require.NoError(err, "Open")
+
+	manifestHash := bnd.Manifest.Hash()
+	deployment := &registryAPI.VersionInfo{
+		Version:        version.Version{Major: 1, Minor: 2},
+		BundleChecksum: manifestHash[:],
+	}
+
+	// Non-TEE runtime.
+	err = bnd.VerifyDeployment(&rt, deployment)
+	require.NoError(err, "VerifyDeployment")
+
+	// Version mismatch.
+	err = bnd.VerifyDeployment(&rt, &registryAPI.VersionInfo{Version: version.Version{Major: 1, Minor: 3}})
+	require.ErrorContains(err, "version mismatch")
+
+	// Checksum mismatch.
+	err = bnd.VerifyDeployment(&rt, &registryAPI.VersionInfo{
+		Version:        deployment.Version,
+		BundleChecksum: make([]byte, 32),
+	})
+	require.ErrorContains(err, "manifest checksum mismatch")
+
+	// Runtime ID mismatch.
+	otherRt := rt
+	otherRt.ID[31] = 0x00
+	err = bnd.VerifyDeployment(&otherRt, deployment)
+	require.ErrorContains(err, "runtime ID mismatch")
+
+	// SGX runtime.
+	rt.TEEHardware = node.TEEHardwareIntelSGX
+	deployment.TEE = cbor.Marshal(node.SGXConstraints{
+		Enclaves: []sgx.EnclaveIdentity{enclaveID},
+	})
+	err = bnd.VerifyDeployment(&rt, deployment)
+	require.NoError(err, "VerifyDeployment")
+
+	// SGX runtime with a different enclave identity.
+	deployment.TEE = cbor.Marshal(node.SGXConstraints{
+		Enclaves: []sgx.EnclaveIdentity{{MrSigner: sgx.MrSigner{0x01}, MrEnclave: sgx.MrEnclave{0x03}}},
+	})
+	err = bnd.VerifyDeployment(&rt, deployment)
+	require.ErrorContains(err, "none of the enclave identities are allowed")
+}
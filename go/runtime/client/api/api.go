@@ -4,13 +4,19 @@ import (
 	"context"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/errors"
 	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	"github.com/oasisprotocol/oasis-core/go/common/version"
 	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/commitment"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/message"
 	"github.com/oasisprotocol/oasis-core/go/runtime/bundle/component"
 	"github.com/oasisprotocol/oasis-core/go/runtime/host/protocol"
+	storage "github.com/oasisprotocol/oasis-core/go/storage/api"
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/syncer"
 )
 
@@ -20,6 +26,10 @@ const (
 
 	// RoundLatest is a special round number always referring to the latest round.
 	RoundLatest = roothash.RoundLatest
+
+	// QueryMethodRuntimeParameters is the name of the standardized runtime query method which
+	// runtimes can implement to report their operational parameters.
+	QueryMethodRuntimeParameters = "core.RuntimeParameters"
 )
 
 var (
@@ -36,6 +46,12 @@ var (
 	ErrCheckTxFailed = errors.New(ModuleName, 5, "client: transaction check failed")
 	// ErrNoHostedRuntime is returned when the hosted runtime is not available locally.
 	ErrNoHostedRuntime = errors.New(ModuleName, 6, "client: no hosted runtime is available")
+	// ErrReplayDisabled is returned when batch replay is requested but is not enabled.
+	ErrReplayDisabled = errors.New(ModuleName, 7, "client: batch replay is disabled")
+	// ErrTxIndexDisabled is an error when the transaction index is disabled.
+	ErrTxIndexDisabled = errors.New(ModuleName, 8, "client: transaction index is disabled")
+	// ErrReadOnly is an error denoting that the node is in read-only mode.
+	ErrReadOnly = errors.New(ModuleName, 9, "client: node is in read-only mode")
 )
 
 // RuntimeClient is the runtime client interface.
@@ -81,9 +97,27 @@ type RuntimeClient interface {
 	// GetEvents returns all events emitted in a given block.
 	GetEvents(ctx context.Context, request *GetEventsRequest) ([]*Event, error)
 
+	// GetRuntimeParameters returns the operational parameters of the given runtime.
+	GetRuntimeParameters(ctx context.Context, runtimeID common.Namespace) (*RuntimeParameters, error)
+
 	// Query makes a runtime-specific query.
 	Query(ctx context.Context, request *QueryRequest) (*QueryResponse, error)
 
+	// ReplayBatch re-executes the batch of a past round using the local runtime and returns the
+	// results for comparison with the committed results.
+	//
+	// This is a debugging method which is only available when explicitly enabled in the node
+	// configuration.
+	ReplayBatch(ctx context.Context, request *ReplayBatchRequest) (*ReplayBatchResponse, error)
+
+	// GetRoundByTxHash returns the round in which the transaction with the given hash was
+	// executed.
+	//
+	// Lookups are served from a node-local transaction index which is only available when
+	// explicitly enabled in the node configuration. Only transactions in rounds that have been
+	// synced while the index was enabled and that have not yet been pruned can be found.
+	GetRoundByTxHash(ctx context.Context, request *GetRoundByTxHashRequest) (*GetRoundByTxHashResponse, error)
+
 	// WatchBlocks subscribes to blocks for a specific runtimes.
 	WatchBlocks(ctx context.Context, runtimeID common.Namespace) (<-chan *roothash.AnnotatedBlock, pubsub.ClosableSubscription, error)
 
@@ -174,9 +208,128 @@ type QueryRequest struct {
 	Round  uint64 `json:"round"`
 	Method string `json:"method"`
 	Args   []byte `json:"args"`
+
+	// ConsensusHeight is an optional consensus height at which the query should be performed.
+	//
+	// If set, the query is performed at the latest runtime round that has been finalized at or
+	// before the given consensus height and the Round field is ignored.
+	ConsensusHeight int64 `json:"consensus_height,omitempty"`
 }
 
 // QueryResponse is a response to the runtime query.
 type QueryResponse struct {
 	Data []byte `json:"data"`
+
+	// Round is the runtime round at which the query was performed.
+	Round uint64 `json:"round,omitempty"`
+	// ConsensusHeight is the consensus height at which the queried runtime round was finalized.
+	ConsensusHeight int64 `json:"consensus_height,omitempty"`
+}
+
+// GetRoundByTxHashRequest is a GetRoundByTxHash request.
+type GetRoundByTxHashRequest struct {
+	RuntimeID common.Namespace `json:"runtime_id"`
+	TxHash    hash.Hash        `json:"tx_hash"`
+}
+
+// GetRoundByTxHashResponse is a GetRoundByTxHash response.
+type GetRoundByTxHashResponse struct {
+	// Round is the roothash round in which the transaction was executed.
+	Round uint64 `json:"round"`
+	// BatchOrder is the order of the transaction in the execution batch.
+	BatchOrder uint32 `json:"batch_order"`
+}
+
+// ReplayBatchRequest is a ReplayBatch request.
+type ReplayBatchRequest struct {
+	RuntimeID common.Namespace `json:"runtime_id"`
+	Round     uint64           `json:"round"`
+}
+
+// ReplayBatchResponse is the result of replaying the batch of a past round.
+type ReplayBatchResponse struct {
+	// Committed is the header of the committed block.
+	Committed block.Header `json:"committed"`
+	// Computed is the header of the compute results produced by the replay.
+	Computed commitment.ComputeResultsHeader `json:"computed"`
+
+	// IOWriteLog is the write log generating the I/O tree produced by the replay.
+	IOWriteLog storage.WriteLog `json:"io_write_log"`
+	// StateWriteLog is the state write log produced by the replay.
+	StateWriteLog storage.WriteLog `json:"state_write_log"`
+	// Messages are the runtime messages emitted by the replay.
+	Messages []message.Message `json:"messages,omitempty"`
+
+	// Transactions are the replayed transactions in batch order.
+	Transactions []*ReplayedTransaction `json:"transactions,omitempty"`
+}
+
+// Mismatches returns the names of the header fields where the replayed results differ from the
+// committed results. An empty result means that the replay was consistent.
+func (r *ReplayBatchResponse) Mismatches() []string {
+	var fields []string
+	check := func(name string, committed hash.Hash, computed *hash.Hash) {
+		if computed == nil || !committed.Equal(computed) {
+			fields = append(fields, name)
+		}
+	}
+	if r.Computed.Round != r.Committed.Round {
+		fields = append(fields, "round")
+	}
+	if !r.Computed.PreviousHash.Equal(&r.Committed.PreviousHash) {
+		fields = append(fields, "previous_hash")
+	}
+	check("io_root", r.Committed.IORoot, r.Computed.IORoot)
+	check("state_root", r.Committed.StateRoot, r.Computed.StateRoot)
+	check("messages_hash", r.Committed.MessagesHash, r.Computed.MessagesHash)
+	check("in_msgs_hash", r.Committed.InMessagesHash, r.Computed.InMessagesHash)
+	return fields
+}
+
+// ReplayedTransaction is a transaction with its committed and replayed result.
+type ReplayedTransaction struct {
+	Tx              []byte `json:"tx"`
+	CommittedResult []byte `json:"committed_result"`
+	Result          []byte `json:"result"`
+}
+
+// ResultMatches returns true iff the replayed result is equal to the committed result. Results are
+// compared in canonical form so encoding differences do not cause spurious mismatches.
+func (t *ReplayedTransaction) ResultMatches() bool {
+	return cbor.CanonicalEqual(t.CommittedResult, t.Result)
+}
+
+// RuntimeParameters are the operational parameters of a runtime.
+type RuntimeParameters struct {
+	// RuntimeID is the runtime identifier.
+	RuntimeID common.Namespace `json:"runtime_id"`
+	// Round is the runtime round at which the parameters were queried.
+	Round uint64 `json:"round"`
+
+	// RuntimeVersion is the version of the active runtime.
+	RuntimeVersion version.Version `json:"runtime_version"`
+	// Features are the features supported by the active runtime.
+	Features protocol.Features `json:"features,omitempty"`
+
+	// MaxBatchSize is the maximum number of transactions in a batch.
+	MaxBatchSize uint64 `json:"max_batch_size"`
+	// MaxBatchSizeBytes is the maximum size of a batch in bytes.
+	MaxBatchSizeBytes uint64 `json:"max_batch_size_bytes"`
+	// MaxMessages is the maximum number of messages that can be emitted in a round.
+	MaxMessages uint32 `json:"max_messages"`
+
+	// Reported are the parameters reported by the runtime itself. They are nil in case the
+	// runtime does not support the QueryMethodRuntimeParameters query method.
+	Reported *ReportedRuntimeParameters `json:"reported,omitempty"`
+}
+
+// ReportedRuntimeParameters are the operational parameters reported by the runtime in response
+// to the QueryMethodRuntimeParameters query method.
+type ReportedRuntimeParameters struct {
+	// MinGasPrice is the minimum gas price accepted by the runtime, keyed by denomination.
+	MinGasPrice map[string]quantity.Quantity `json:"min_gas_price,omitempty"`
+	// Methods are the names of the methods supported by the runtime.
+	Methods []string `json:"methods,omitempty"`
+	// Features are the names of the runtime-specific features supported by the runtime.
+	Features []string `json:"features,omitempty"`
 }
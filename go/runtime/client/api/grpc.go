@@ -42,8 +42,14 @@ var (
 	methodGetUnconfirmedTransactions = serviceName.NewMethod("GetUnconfirmedTransactions", common.Namespace{})
 	// methodGetEvents is the GetEvents method.
 	methodGetEvents = serviceName.NewMethod("GetEvents", GetEventsRequest{})
+	// methodGetRuntimeParameters is the GetRuntimeParameters method.
+	methodGetRuntimeParameters = serviceName.NewMethod("GetRuntimeParameters", common.Namespace{})
 	// methodQuery is the Query method.
 	methodQuery = serviceName.NewMethod("Query", QueryRequest{})
+	// methodReplayBatch is the ReplayBatch method.
+	methodReplayBatch = serviceName.NewMethod("ReplayBatch", ReplayBatchRequest{})
+	// methodGetRoundByTxHash is the GetRoundByTxHash method.
+	methodGetRoundByTxHash = serviceName.NewMethod("GetRoundByTxHash", GetRoundByTxHashRequest{})
 	// methodStateSyncGet is the StateSyncGet method.
 	methodStateSyncGet = serviceName.NewMethod("StateSyncGet", syncer.GetRequest{})
 	// methodStateSyncGetPrefixes is the StateSyncGetPrefixes method.
@@ -103,10 +109,22 @@ var (
 				MethodName: methodGetEvents.ShortName(),
 				Handler:    handlerGetEvents,
 			},
+			{
+				MethodName: methodGetRuntimeParameters.ShortName(),
+				Handler:    handlerGetRuntimeParameters,
+			},
 			{
 				MethodName: methodQuery.ShortName(),
 				Handler:    handlerQuery,
 			},
+			{
+				MethodName: methodReplayBatch.ShortName(),
+				Handler:    handlerReplayBatch,
+			},
+			{
+				MethodName: methodGetRoundByTxHash.ShortName(),
+				Handler:    handlerGetRoundByTxHash,
+			},
 			{
 				MethodName: methodStateSyncGet.ShortName(),
 				Handler:    handlerStateSyncGet,
@@ -416,6 +434,29 @@ func handlerGetEvents(
 	return interceptor(ctx, &rq, info, handler)
 }
 
+func handlerGetRuntimeParameters(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var runtimeID common.Namespace
+	if err := dec(&runtimeID); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeClient).GetRuntimeParameters(ctx, runtimeID)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetRuntimeParameters.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeClient).GetRuntimeParameters(ctx, req.(common.Namespace))
+	}
+	return interceptor(ctx, runtimeID, info, handler)
+}
+
 func handlerQuery( // nolint: revive
 	srv interface{},
 	ctx context.Context,
@@ -441,6 +482,54 @@ func handlerQuery( // nolint: revive
 	return interceptor(ctx, &rq, info, handler)
 }
 
+func handlerReplayBatch(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var rq ReplayBatchRequest
+	if err := dec(&rq); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeClient).ReplayBatch(ctx, &rq)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodReplayBatch.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeClient).ReplayBatch(ctx, req.(*ReplayBatchRequest))
+	}
+	return interceptor(ctx, &rq, info, handler)
+}
+
+func handlerGetRoundByTxHash(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var rq GetRoundByTxHashRequest
+	if err := dec(&rq); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		rsp, err := srv.(RuntimeClient).GetRoundByTxHash(ctx, &rq)
+		return rsp, errorWrapNotFound(err)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetRoundByTxHash.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		rsp, err := srv.(RuntimeClient).GetRoundByTxHash(ctx, req.(*GetRoundByTxHashRequest))
+		return rsp, errorWrapNotFound(err)
+	}
+	return interceptor(ctx, &rq, info, handler)
+}
+
 func handlerStateSyncGet(
 	srv interface{},
 	ctx context.Context,
@@ -628,6 +717,14 @@ func (c *runtimeClient) GetEvents(ctx context.Context, request *GetEventsRequest
 	return rsp, nil
 }
 
+func (c *runtimeClient) GetRuntimeParameters(ctx context.Context, runtimeID common.Namespace) (*RuntimeParameters, error) {
+	var rsp RuntimeParameters
+	if err := c.conn.Invoke(ctx, methodGetRuntimeParameters.FullName(), runtimeID, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *runtimeClient) Query(ctx context.Context, request *QueryRequest) (*QueryResponse, error) {
 	var rsp QueryResponse
 	if err := c.conn.Invoke(ctx, methodQuery.FullName(), request, &rsp); err != nil {
@@ -636,6 +733,22 @@ func (c *runtimeClient) Query(ctx context.Context, request *QueryRequest) (*Quer
 	return &rsp, nil
 }
 
+func (c *runtimeClient) ReplayBatch(ctx context.Context, request *ReplayBatchRequest) (*ReplayBatchResponse, error) {
+	var rsp ReplayBatchResponse
+	if err := c.conn.Invoke(ctx, methodReplayBatch.FullName(), request, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
+func (c *runtimeClient) GetRoundByTxHash(ctx context.Context, request *GetRoundByTxHashRequest) (*GetRoundByTxHashResponse, error) {
+	var rsp GetRoundByTxHashResponse
+	if err := c.conn.Invoke(ctx, methodGetRoundByTxHash.FullName(), request, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 type stateReadSync struct {
 	c *runtimeClient
 }
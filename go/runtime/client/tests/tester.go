@@ -173,6 +173,14 @@ func testQuery(
 	require.NoError(t, err, "GetGenesisBlock2")
 	require.EqualValues(t, genBlk, genBlk2, "GetGenesisBlock should match previous GetGenesisBlock")
 
+	// Runtime parameters (see the mock runtime for reported parameters).
+	params, err := c.GetRuntimeParameters(ctx, runtimeID)
+	require.NoError(t, err, "GetRuntimeParameters")
+	require.EqualValues(t, runtimeID, params.RuntimeID)
+	require.NotZero(t, params.MaxBatchSize, "GetRuntimeParameters should return max batch size")
+	require.NotNil(t, params.Reported, "GetRuntimeParameters should return reported parameters")
+	require.EqualValues(t, mock.ReportedParameters, *params.Reported)
+
 	// Query runtime.
 	// Since we are using the mock runtime host the response should be a CBOR-serialized method name
 	// with the added " world" string.
@@ -223,6 +231,18 @@ func testQuery(
 	require.NoError(t, err, "cbor.Unmarshal(<QueryResponse.Data>)")
 	require.True(t, strings.HasPrefix(decResp4, "hello world"), "Query response at latest round should be correct")
 
+	// Make sure that queries pinned to a consensus height work.
+	rsp, err = c.Query(ctx, &api.QueryRequest{
+		RuntimeID:       runtimeID,
+		Method:          "hello",
+		ConsensusHeight: rsp.ConsensusHeight,
+	})
+	require.NoError(t, err, "Query")
+	var decResp5 string
+	err = cbor.Unmarshal(rsp.Data, &decResp5)
+	require.NoError(t, err, "cbor.Unmarshal(<QueryResponse.Data>)")
+	require.EqualValues(t, decResp4, decResp5, "Query response at the consensus height of the latest round should be equal")
+
 	// Execute CheckTx using the mock runtime host.
 	err = c.CheckTx(ctx, &api.CheckTxRequest{
 		RuntimeID: runtimeID,
@@ -122,6 +122,29 @@ type Config struct {
 	//
 	// If not specified, a default value is used.
 	MaxBundleSize string `yaml:"max_bundle_size,omitempty"`
+
+	// DebugReplay enables the runtime client ReplayBatch method which re-executes the batches of
+	// past rounds using the locally hosted runtime. It is meant for investigating discrepancies
+	// and should not be left enabled on production nodes.
+	DebugReplay bool `yaml:"debug_replay,omitempty"`
+
+	// IndexTransactions enables indexing of executed runtime transactions by their hashes on
+	// client nodes so that the runtime client GetRoundByTxHash method can be used.
+	IndexTransactions bool `yaml:"index_transactions,omitempty"`
+
+	// CommitteeAudit is the committee message audit configuration.
+	CommitteeAudit CommitteeAuditConfig `yaml:"committee_audit,omitempty"`
+}
+
+// CommitteeAuditConfig is the committee message audit configuration.
+type CommitteeAuditConfig struct {
+	// Enabled specifies whether authenticated committee messages received by executor nodes are
+	// audited from startup. Auditing can also be toggled at runtime via the node controller.
+	Enabled bool `yaml:"enabled,omitempty"`
+
+	// Persist specifies whether audited messages are also appended to an audit log in the
+	// runtime's data directory in addition to being logged.
+	Persist bool `yaml:"persist,omitempty"`
 }
 
 // GetComponent returns the configuration for the given component
@@ -273,6 +296,7 @@ func DefaultConfig() Config {
 			MaxPoolSize:          50_000,
 			MaxLastSeenCacheSize: 100_000,
 			MaxCheckTxBatchSize:  128,
+			MaxCheckTxCacheSize:  10_000,
 			RecheckInterval:      5,
 			RepublishInterval:    60 * time.Second,
 		},
@@ -9,6 +9,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common"
 	cmnBadger "github.com/oasisprotocol/oasis-core/go/common/badger"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/keyformat"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
@@ -32,8 +33,24 @@ var (
 	//
 	// Value is CBOR-serialized roothash.RoundResults.
 	roundResultsKeyFmt = keyFormat.New(0x03, uint64(0))
+	// txKeyFmt is the transaction index key format.
+	//
+	// Value is CBOR-serialized TxLocation.
+	txKeyFmt = keyFormat.New(0x04, &hash.Hash{})
+	// roundTxsKeyFmt is the per-round indexed transactions key format.
+	//
+	// Value is CBOR-serialized list of transaction hashes.
+	roundTxsKeyFmt = keyFormat.New(0x05, uint64(0))
 )
 
+// TxLocation is the location of a runtime transaction.
+type TxLocation struct {
+	// Round is the runtime round in which the transaction was executed.
+	Round uint64 `json:"round"`
+	// BatchOrder is the order of the transaction in the execution batch.
+	BatchOrder uint32 `json:"batch_order"`
+}
+
 type dbMetadata struct {
 	// RuntimeID is the runtime ID this database is for.
 	RuntimeID common.Namespace `json:"runtime_id"`
@@ -279,6 +296,73 @@ func (d *DB) getRoundResults(round uint64) (*roothash.RoundResults, error) {
 	return roundResults, nil
 }
 
+func (d *DB) indexTransactions(round uint64, txs map[hash.Hash]uint32) error {
+	return d.db.Update(func(tx *badger.Txn) error {
+		txHashes := make([]hash.Hash, 0, len(txs))
+		for txHash, batchOrder := range txs {
+			loc := TxLocation{
+				Round:      round,
+				BatchOrder: batchOrder,
+			}
+			if err := tx.Set(txKeyFmt.Encode(&txHash), cbor.Marshal(loc)); err != nil {
+				return err
+			}
+			txHashes = append(txHashes, txHash)
+		}
+
+		return tx.Set(roundTxsKeyFmt.Encode(round), cbor.Marshal(txHashes))
+	})
+}
+
+func (d *DB) getTransactionLocation(txHash hash.Hash) (*TxLocation, error) {
+	var loc TxLocation
+	txErr := d.db.View(func(tx *badger.Txn) error {
+		item, err := tx.Get(txKeyFmt.Encode(&txHash))
+		switch err {
+		case nil:
+		case badger.ErrKeyNotFound:
+			return roothash.ErrNotFound
+		default:
+			return err
+		}
+
+		return item.Value(func(val []byte) error {
+			return cbor.UnmarshalTrusted(val, &loc)
+		})
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+	return &loc, nil
+}
+
+// queryDeleteIndexedTransactions removes all transactions indexed for the given round.
+func (d *DB) queryDeleteIndexedTransactions(tx *badger.Txn, round uint64) error {
+	key := roundTxsKeyFmt.Encode(round)
+	item, err := tx.Get(key)
+	switch err {
+	case nil:
+	case badger.ErrKeyNotFound:
+		return nil
+	default:
+		return err
+	}
+
+	var txHashes []hash.Hash
+	if err = item.Value(func(val []byte) error {
+		return cbor.UnmarshalTrusted(val, &txHashes)
+	}); err != nil {
+		return err
+	}
+
+	for _, txHash := range txHashes {
+		if err = tx.Delete(txKeyFmt.Encode(&txHash)); err != nil {
+			return err
+		}
+	}
+	return tx.Delete(key)
+}
+
 func (d *DB) close() {
 	d.gc.Stop()
 	d.db.Close()
@@ -12,6 +12,7 @@ import (
 	"github.com/eapache/channels"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
 	"github.com/oasisprotocol/oasis-core/go/config"
@@ -35,6 +36,15 @@ type Factory func(runtimeID common.Namespace, dataDir string) (History, error)
 type History interface {
 	roothash.BlockHistory
 
+	// IndexTransactions records the given transactions, mapped from their hashes to their batch
+	// order, as executed in the given round.
+	//
+	// The index entries are pruned together with the round.
+	IndexTransactions(round uint64, txs map[hash.Hash]uint32) error
+
+	// GetTransactionLocation returns the location of an indexed transaction.
+	GetTransactionLocation(ctx context.Context, txHash hash.Hash) (*TxLocation, error)
+
 	// Pruner returns the history pruner.
 	Pruner() Pruner
 
@@ -66,6 +76,13 @@ func (h *nopHistory) LastStorageSyncedRound() (uint64, error) {
 	return 0, errNopHistory
 }
 
+func (h *nopHistory) SetReindexProgress(*roothash.HistoryReindexProgress) {
+}
+
+func (h *nopHistory) ReindexProgress() *roothash.HistoryReindexProgress {
+	return nil
+}
+
 func (h *nopHistory) WatchBlocks() (<-chan *roothash.AnnotatedBlock, pubsub.ClosableSubscription, error) {
 	return nil, nil, errNopHistory
 }
@@ -98,6 +115,14 @@ func (h *nopHistory) GetRoundResults(context.Context, uint64) (*roothash.RoundRe
 	return nil, errNopHistory
 }
 
+func (h *nopHistory) IndexTransactions(uint64, map[hash.Hash]uint32) error {
+	return errNopHistory
+}
+
+func (h *nopHistory) GetTransactionLocation(context.Context, hash.Hash) (*TxLocation, error) {
+	return nil, errNopHistory
+}
+
 func (h *nopHistory) Pruner() Pruner {
 	pruner, _ := NewNonePrunerFactory()(h.runtimeID, nil)
 	return pruner
@@ -128,6 +153,10 @@ type runtimeHistory struct {
 
 	hasLocalStorage bool
 
+	// Progress of an ongoing reindex (if any) as reported by the roothash backend.
+	reindexLock     sync.RWMutex
+	reindexProgress *roothash.HistoryReindexProgress
+
 	pruner  Pruner
 	pruneCh *channels.RingChannel
 	stopCh  chan struct{}
@@ -199,6 +228,29 @@ func (h *runtimeHistory) LastStorageSyncedRound() (uint64, error) {
 	return h.lastStorageSyncedRound, nil
 }
 
+func (h *runtimeHistory) SetReindexProgress(progress *roothash.HistoryReindexProgress) {
+	h.reindexLock.Lock()
+	defer h.reindexLock.Unlock()
+
+	if progress == nil {
+		h.reindexProgress = nil
+		return
+	}
+	p := *progress
+	h.reindexProgress = &p
+}
+
+func (h *runtimeHistory) ReindexProgress() *roothash.HistoryReindexProgress {
+	h.reindexLock.RLock()
+	defer h.reindexLock.RUnlock()
+
+	if h.reindexProgress == nil {
+		return nil
+	}
+	p := *h.reindexProgress
+	return &p
+}
+
 func (h *runtimeHistory) WatchBlocks() (<-chan *roothash.AnnotatedBlock, pubsub.ClosableSubscription, error) {
 	typedCh := make(chan *roothash.AnnotatedBlock)
 	sub := h.blocksNotifier.Subscribe()
@@ -320,6 +372,14 @@ func (h *runtimeHistory) GetRoundResults(ctx context.Context, round uint64) (*ro
 	return h.db.getRoundResults(resolvedRound)
 }
 
+func (h *runtimeHistory) IndexTransactions(round uint64, txs map[hash.Hash]uint32) error {
+	return h.db.indexTransactions(round, txs)
+}
+
+func (h *runtimeHistory) GetTransactionLocation(_ context.Context, txHash hash.Hash) (*TxLocation, error) {
+	return h.db.getTransactionLocation(txHash)
+}
+
 func (h *runtimeHistory) Pruner() Pruner {
 	return h.pruner
 }
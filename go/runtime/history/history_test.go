@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
 )
@@ -199,6 +200,42 @@ func testWatchBlocks(t *testing.T, history History, expectedRound uint64) {
 	}
 }
 
+func TestReindexProgress(t *testing.T) {
+	require := require.New(t)
+
+	dataDir, err := os.MkdirTemp("", "oasis-runtime-history-test_")
+	require.NoError(err, "TempDir")
+	defer os.RemoveAll(dataDir)
+
+	runtimeID := common.NewTestNamespaceFromSeed([]byte("history test ns 1"), 0)
+	history, err := New(runtimeID, dataDir, NewNonePrunerFactory(), true)
+	require.NoError(err, "New")
+	defer history.Close()
+
+	require.Nil(history.ReindexProgress(), "there should be no reindex progress initially")
+
+	progress := roothash.NewHistoryReindexProgress(11, 20)
+	require.EqualValues(10, progress.LastHeight)
+	require.EqualValues(0, progress.Percent)
+	history.SetReindexProgress(progress)
+
+	progress.Advance(15)
+	require.EqualValues(50, progress.Percent)
+	require.EqualValues(0, history.ReindexProgress().Percent, "reindex progress should be copied")
+
+	history.SetReindexProgress(progress)
+	p := history.ReindexProgress()
+	require.EqualValues(15, p.LastHeight)
+	require.EqualValues(50, p.Percent)
+
+	progress.Advance(20)
+	require.EqualValues(100, progress.Percent)
+	require.EqualValues(100, roothash.NewHistoryReindexProgress(21, 20).Percent, "empty range should be complete")
+
+	history.SetReindexProgress(nil)
+	require.Nil(history.ReindexProgress(), "reindex progress should be cleared")
+}
+
 func TestWatchBlocks(t *testing.T) {
 	require := require.New(t)
 	ctx := context.Background()
@@ -343,6 +380,11 @@ func TestHistoryPrune(t *testing.T) {
 
 		err = history.StorageSyncCheckpoint(blk.Block.Header.Round)
 		require.NoError(err, "StorageSyncCheckpoint")
+
+		err = history.IndexTransactions(blk.Block.Header.Round, map[hash.Hash]uint32{
+			hash.NewFromBytes([]byte(fmt.Sprintf("tx %d", i))): uint32(i % 3),
+		})
+		require.NoError(err, "IndexTransactions")
 	}
 
 	// No more blocks after this point.
@@ -390,6 +432,19 @@ func TestHistoryPrune(t *testing.T) {
 		}
 	}
 
+	// Ensure only transactions in the last 10 blocks are indexed.
+	for i := 0; i <= 50; i++ {
+		loc, err := history.GetTransactionLocation(ctx, hash.NewFromBytes([]byte(fmt.Sprintf("tx %d", i))))
+		if i <= 40 {
+			require.Error(err, "GetTransactionLocation should fail for pruned round %d", i)
+			require.Equal(roothash.ErrNotFound, err)
+			continue
+		}
+		require.NoError(err, "GetTransactionLocation(%d)", i)
+		require.EqualValues(i, loc.Round)
+		require.EqualValues(i%3, loc.BatchOrder)
+	}
+
 	// Ensure the prune handler was called.
 	require.Len(ph.prunedRounds, 41)
 	for i := 0; i <= 40; i++ {
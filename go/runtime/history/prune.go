@@ -128,6 +128,14 @@ func (p *keepLastPruner) Prune(latestRound uint64) error {
 				return err
 			}
 
+			if err := p.db.queryDeleteIndexedTransactions(tx, round); err != nil {
+				if err == badger.ErrTxnTooBig {
+					// We can't prune any more rounds in this transaction.
+					break
+				}
+				return err
+			}
+
 			if err := tx.Delete(item.KeyCopy(nil)); err != nil {
 				return err
 			}
@@ -12,8 +12,10 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/errors"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
 	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
 	"github.com/oasisprotocol/oasis-core/go/common/version"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/commitment"
+	"github.com/oasisprotocol/oasis-core/go/runtime/client/api"
 	"github.com/oasisprotocol/oasis-core/go/runtime/host"
 	"github.com/oasisprotocol/oasis-core/go/runtime/host/protocol"
 	"github.com/oasisprotocol/oasis-core/go/runtime/transaction"
@@ -22,6 +24,14 @@ import (
 
 type provisioner struct{}
 
+// ReportedParameters are the parameters reported by the mock runtime.
+var ReportedParameters = api.ReportedRuntimeParameters{
+	MinGasPrice: map[string]quantity.Quantity{
+		"": *quantity.NewFromUint64(1),
+	},
+	Methods: []string{"hello"},
+}
+
 // CheckTxFailInput is the input that will cause a CheckTx failure in the mock runtime.
 var CheckTxFailInput = []byte("checktx-mock-fail")
 
@@ -176,6 +186,10 @@ func (r *runtime) Call(ctx context.Context, body *protocol.Body) (*protocol.Body
 		rq := body.RuntimeQueryRequest
 
 		switch rq.Method {
+		case api.QueryMethodRuntimeParameters:
+			return &protocol.Body{RuntimeQueryResponse: &protocol.RuntimeQueryResponse{
+				Data: cbor.Marshal(&ReportedParameters),
+			}}, nil
 		default:
 			return &protocol.Body{RuntimeQueryResponse: &protocol.RuntimeQueryResponse{
 				Data: cbor.Marshal(rq.Method + " world at:" + fmt.Sprintf("%d", rq.ConsensusBlock.Height)),
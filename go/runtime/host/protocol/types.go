@@ -1,6 +1,7 @@
 package protocol
 
 import (
+	"encoding/binary"
 	"fmt"
 	"reflect"
 
@@ -125,6 +126,10 @@ type Body struct {
 	HostFetchTxBatchResponse         *HostFetchTxBatchResponse         `json:",omitempty"`
 	HostFetchGenesisHeightRequest    *HostFetchGenesisHeightRequest    `json:",omitempty"`
 	HostFetchGenesisHeightResponse   *HostFetchGenesisHeightResponse   `json:",omitempty"`
+	HostFetchConsensusTimeRequest    *HostFetchConsensusTimeRequest    `json:",omitempty"`
+	HostFetchConsensusTimeResponse   *HostFetchConsensusTimeResponse   `json:",omitempty"`
+	HostFetchRoundEntropyRequest     *HostFetchRoundEntropyRequest     `json:",omitempty"`
+	HostFetchRoundEntropyResponse    *HostFetchRoundEntropyResponse    `json:",omitempty"`
 	HostFetchBlockMetadataTxRequest  *HostFetchBlockMetadataTxRequest  `json:",omitempty"`
 	HostFetchBlockMetadataTxResponse *HostFetchBlockMetadataTxResponse `json:",omitempty"`
 	HostProveFreshnessRequest        *HostProveFreshnessRequest        `json:",omitempty"`
@@ -362,6 +367,9 @@ type ComputedBatch struct {
 	RakSig signature.RawSignature `json:"rak_sig"`
 	// Messages are the emitted runtime messages.
 	Messages []message.Message `json:"messages"`
+	// InMessagesResults are the results of processing incoming messages, if reported by the
+	// runtime.
+	InMessagesResults []message.IncomingMessageResult `json:"in_msgs_results,omitempty"`
 }
 
 // String returns a string representation of a computed batch.
@@ -600,6 +608,51 @@ type HostFetchGenesisHeightResponse struct {
 	Height uint64 `json:"height"`
 }
 
+// HostFetchConsensusTimeRequest is a request to host to fetch the time of the consensus block at
+// the given height.
+//
+// The returned time is the second-granular time of the consensus block and as such only depends
+// on the height, so all runtime instances asking for the same height observe the same value.
+type HostFetchConsensusTimeRequest struct {
+	Height uint64 `json:"height"`
+}
+
+// HostFetchConsensusTimeResponse is a response from host fetching the consensus time.
+type HostFetchConsensusTimeResponse struct {
+	// Time is the UNIX timestamp (in seconds) of the consensus block.
+	Time uint64 `json:"time"`
+}
+
+// HostFetchRoundEntropyRequest is a request to host to fetch the entropy for the given runtime
+// round, anchored in the consensus beacon at the given height.
+//
+// The returned entropy only depends on the runtime identifier, the round and the beacon at the
+// given height, so all executors of the same round observe the same value. The entropy is public
+// and must not be used for secrets.
+type HostFetchRoundEntropyRequest struct {
+	Height uint64 `json:"height"`
+	Round  uint64 `json:"round"`
+}
+
+// HostFetchRoundEntropyResponse is a response from host fetching the round entropy.
+type HostFetchRoundEntropyResponse struct {
+	// Beacon is the consensus beacon at the requested height which can be used to verify the
+	// entropy against the consensus state.
+	Beacon []byte `json:"beacon"`
+	// Entropy is the derived round entropy.
+	Entropy hash.Hash `json:"entropy"`
+}
+
+// RoundEntropyContext is the domain separation context used when deriving round entropy.
+var RoundEntropyContext = []byte("oasis-core/runtime: round entropy")
+
+// DeriveRoundEntropy derives the entropy for the given runtime round from the consensus beacon.
+func DeriveRoundEntropy(runtimeID common.Namespace, round uint64, beacon []byte) hash.Hash {
+	var rawRound [8]byte
+	binary.BigEndian.PutUint64(rawRound[:], round)
+	return hash.NewFromBytes(RoundEntropyContext, runtimeID[:], rawRound[:], beacon)
+}
+
 // HostFetchTxBatchRequest is a request to host to fetch a further batch of transactions.
 type HostFetchTxBatchRequest struct {
 	// Offset specifies the transaction hash that should serve as an offset when returning
@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
 )
 
 func TestBody_Type(t *testing.T) {
@@ -30,3 +32,18 @@ func TestBody_Type(t *testing.T) {
 	// All members are nil, expect empty string.
 	require.Equal(t, b.Type(), "")
 }
+
+func TestDeriveRoundEntropy(t *testing.T) {
+	require := require.New(t)
+
+	var runtimeID, otherRuntimeID common.Namespace
+	require.NoError(runtimeID.UnmarshalHex("8000000000000000000000000000000000000000000000000000000000000000"))
+	require.NoError(otherRuntimeID.UnmarshalHex("8000000000000000000000000000000000000000000000000000000000000001"))
+	beacon := []byte("beacon")
+
+	entropy := DeriveRoundEntropy(runtimeID, 42, beacon)
+	require.Equal(entropy, DeriveRoundEntropy(runtimeID, 42, beacon), "derivation should be deterministic")
+	require.NotEqual(entropy, DeriveRoundEntropy(runtimeID, 43, beacon), "entropy should depend on round")
+	require.NotEqual(entropy, DeriveRoundEntropy(otherRuntimeID, 42, beacon), "entropy should depend on runtime")
+	require.NotEqual(entropy, DeriveRoundEntropy(runtimeID, 42, []byte("other")), "entropy should depend on beacon")
+}
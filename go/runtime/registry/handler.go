@@ -111,6 +111,12 @@ func (h *runtimeHostHandler) Handle(ctx context.Context, rq *protocol.Body) (*pr
 	case rq.HostFetchGenesisHeightRequest != nil:
 		// Consensus genesis height.
 		rsp.HostFetchGenesisHeightResponse, err = h.handleHostFetchGenesisHeight(ctx)
+	case rq.HostFetchConsensusTimeRequest != nil:
+		// Consensus time.
+		rsp.HostFetchConsensusTimeResponse, err = h.handleHostFetchConsensusTime(ctx, rq.HostFetchConsensusTimeRequest)
+	case rq.HostFetchRoundEntropyRequest != nil:
+		// Round entropy.
+		rsp.HostFetchRoundEntropyResponse, err = h.handleHostFetchRoundEntropy(ctx, rq.HostFetchRoundEntropyRequest)
 	case rq.HostFetchTxBatchRequest != nil:
 		// Transaction pool.
 		rsp.HostFetchTxBatchResponse, err = h.handleHostFetchTxBatch(rq.HostFetchTxBatchRequest)
@@ -343,6 +349,37 @@ func (h *runtimeHostHandler) handleHostFetchGenesisHeight(
 	return &protocol.HostFetchGenesisHeightResponse{Height: uint64(doc.Height)}, nil
 }
 
+func (h *runtimeHostHandler) handleHostFetchConsensusTime(
+	ctx context.Context,
+	rq *protocol.HostFetchConsensusTimeRequest,
+) (*protocol.HostFetchConsensusTimeResponse, error) {
+	if rq.Height == 0 {
+		return nil, fmt.Errorf("invalid consensus height")
+	}
+	blk, err := h.consensus.GetBlock(ctx, int64(rq.Height))
+	if err != nil {
+		return nil, err
+	}
+	return &protocol.HostFetchConsensusTimeResponse{Time: uint64(blk.Time.Unix())}, nil
+}
+
+func (h *runtimeHostHandler) handleHostFetchRoundEntropy(
+	ctx context.Context,
+	rq *protocol.HostFetchRoundEntropyRequest,
+) (*protocol.HostFetchRoundEntropyResponse, error) {
+	if rq.Height == 0 {
+		return nil, fmt.Errorf("invalid consensus height")
+	}
+	beacon, err := h.consensus.Beacon().GetBeacon(ctx, int64(rq.Height))
+	if err != nil {
+		return nil, err
+	}
+	return &protocol.HostFetchRoundEntropyResponse{
+		Beacon:  beacon,
+		Entropy: protocol.DeriveRoundEntropy(h.runtime.ID(), rq.Round, beacon),
+	}, nil
+}
+
 func (h *runtimeHostHandler) handleHostFetchTxBatch(
 	rq *protocol.HostFetchTxBatchRequest,
 ) (*protocol.HostFetchTxBatchResponse, error) {
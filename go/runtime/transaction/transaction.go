@@ -541,6 +541,12 @@ func (t *Tree) GetTagMultiple(ctx context.Context, tags [][]byte) (Tags, error)
 	return foundTags, nil
 }
 
+// ApplyWriteLog applies the operations from a write log to the underlying
+// Merkle tree.
+func (t *Tree) ApplyWriteLog(ctx context.Context, wl writelog.WriteLog) error {
+	return t.tree.ApplyWriteLog(ctx, writelog.NewStaticIterator(wl))
+}
+
 // Commit commits the updates to the underlying Merkle tree and returns the
 // write log and root hash.
 func (t *Tree) Commit(ctx context.Context) (writelog.WriteLog, hash.Hash, error) {
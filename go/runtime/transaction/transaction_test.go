@@ -279,6 +279,34 @@ func TestTransactionInvalidBatchOrder(t *testing.T) {
 	require.Error(t, err, "GetInputBatch should fail with inconsistent order")
 }
 
+func TestTransactionApplyWriteLog(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	var emptyRoot node.Root
+	emptyRoot.Type = node.RootTypeIO
+	emptyRoot.Empty()
+
+	tree := NewTree(nil, emptyRoot)
+	defer tree.Close()
+	testTxns := populateTransactions(t, tree)
+	wl, root, err := tree.Commit(ctx)
+	require.NoError(err, "Commit")
+
+	// Applying the write log to an empty tree should result in the same tree.
+	applied := NewTree(nil, emptyRoot)
+	defer applied.Close()
+	err = applied.ApplyWriteLog(ctx, wl)
+	require.NoError(err, "ApplyWriteLog")
+	_, appliedRoot, err := applied.Commit(ctx)
+	require.NoError(err, "Commit")
+	require.EqualValues(root, appliedRoot, "roots should be equal")
+
+	txns, err := applied.GetTransactions(ctx)
+	require.NoError(err, "GetTransactions")
+	require.Len(txns, len(testTxns), "all transactions should be present")
+}
+
 func TestIOWriteLogValidation(t *testing.T) {
 	var (
 		err  error
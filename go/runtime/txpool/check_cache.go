@@ -0,0 +1,59 @@
+package txpool
+
+import (
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/runtime/host/protocol"
+)
+
+// checkTxCache is a cache of transaction check results for a single runtime round.
+//
+// Since transactions are checked against the state of the latest runtime block, the result of
+// checking the same transaction is the same for as long as the round does not change. The cache
+// is used to avoid repeatedly invoking the runtime for transactions that are re-gossiped by peers
+// within the same round (e.g., transactions that failed the check and were thus removed from the
+// seen cache).
+//
+// The cache is not safe for concurrent use.
+type checkTxCache struct {
+	maxSize int
+
+	round   uint64
+	results map[hash.Hash]protocol.CheckTxResult
+}
+
+// get returns the cached check result for the given transaction hash in the given round.
+func (c *checkTxCache) get(round uint64, txHash hash.Hash) (protocol.CheckTxResult, bool) {
+	if round != c.round {
+		return protocol.CheckTxResult{}, false
+	}
+	result, ok := c.results[txHash]
+	return result, ok
+}
+
+// put caches the check result for the given transaction hash in the given round.
+//
+// Caching a result for a different round than the current one discards all cached results.
+func (c *checkTxCache) put(round uint64, txHash hash.Hash, result protocol.CheckTxResult) {
+	if c.maxSize == 0 {
+		return
+	}
+	if round != c.round || c.results == nil {
+		c.round = round
+		c.results = make(map[hash.Hash]protocol.CheckTxResult)
+	}
+	if len(c.results) >= c.maxSize {
+		return
+	}
+	c.results[txHash] = result
+}
+
+// size returns the number of cached check results.
+func (c *checkTxCache) size() int {
+	return len(c.results)
+}
+
+func newCheckTxCache(maxSize int) *checkTxCache {
+	return &checkTxCache{
+		maxSize: maxSize,
+	}
+}
@@ -0,0 +1,52 @@
+package txpool
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/runtime/host/protocol"
+)
+
+func TestCheckTxCache(t *testing.T) {
+	require := require.New(t)
+
+	cache := newCheckTxCache(2)
+
+	tx1 := hash.NewFromBytes([]byte("tx1"))
+	tx2 := hash.NewFromBytes([]byte("tx2"))
+	tx3 := hash.NewFromBytes([]byte("tx3"))
+	failed := protocol.CheckTxResult{Error: protocol.Error{Module: "test", Code: 1}}
+	ok := protocol.CheckTxResult{Meta: &protocol.CheckTxMetadata{Priority: 1}}
+
+	_, found := cache.get(1, tx1)
+	require.False(found, "empty cache should not return results")
+
+	cache.put(1, tx1, failed)
+	cache.put(1, tx2, ok)
+	cache.put(1, tx3, ok)
+	require.Equal(2, cache.size(), "cache size should be bounded")
+
+	res, found := cache.get(1, tx1)
+	require.True(found, "cached result should be returned")
+	require.Equal(failed, res)
+	res, found = cache.get(1, tx2)
+	require.True(found, "cached result should be returned")
+	require.Equal(ok, res)
+	_, found = cache.get(1, tx3)
+	require.False(found, "results over capacity should not be cached")
+
+	_, found = cache.get(2, tx1)
+	require.False(found, "results from other rounds should not be returned")
+
+	cache.put(2, tx3, ok)
+	require.Equal(1, cache.size(), "results from previous rounds should be discarded")
+	_, found = cache.get(1, tx1)
+	require.False(found, "results from previous rounds should be discarded")
+
+	disabled := newCheckTxCache(0)
+	disabled.put(1, tx1, ok)
+	_, found = disabled.get(1, tx1)
+	require.False(found, "disabled cache should not cache results")
+}
@@ -11,6 +11,8 @@ type Config struct {
 	MaxLastSeenCacheSize uint64 `yaml:"schedule_tx_cache_size"`
 	// Maximum check tx batch size.
 	MaxCheckTxBatchSize uint64 `yaml:"check_tx_max_batch_size"`
+	// Maximum size of the per-round check tx result cache (zero disables the cache).
+	MaxCheckTxCacheSize uint64 `yaml:"check_tx_cache_size"`
 	// Transaction recheck interval (in rounds).
 	RecheckInterval uint64 `yaml:"recheck_interval"`
 	// Republish interval.
@@ -49,6 +49,27 @@ var (
 		},
 		[]string{"runtime"},
 	)
+	checkTxCacheHits = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "oasis_txpool_check_tx_cache_hits",
+			Help: "Number of transaction checks served from the check tx result cache.",
+		},
+		[]string{"runtime"},
+	)
+	checkTxCacheMisses = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "oasis_txpool_check_tx_cache_misses",
+			Help: "Number of transaction checks not served from the check tx result cache.",
+		},
+		[]string{"runtime"},
+	)
+	checkTxCacheSize = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "oasis_txpool_check_tx_cache_size",
+			Help: "Size of the check tx result cache (number of entries).",
+		},
+		[]string{"runtime"},
+	)
 	txpoolCollectors = []prometheus.Collector{
 		pendingCheckSize,
 		mainQueueSize,
@@ -56,6 +77,9 @@ var (
 		rimQueueSize,
 		rejectedTransactions,
 		acceptedTransactions,
+		checkTxCacheHits,
+		checkTxCacheMisses,
+		checkTxCacheSize,
 	}
 
 	metricsOnce sync.Once
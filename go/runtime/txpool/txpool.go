@@ -164,6 +164,7 @@ type txPool struct {
 
 	checkTxCh       *channels.RingChannel
 	checkTxQueue    *checkTxQueue
+	checkTxCache    *checkTxCache
 	checkTxNotifier *pubsub.Broker
 	recheckTxCh     *channels.RingChannel
 
@@ -482,40 +483,64 @@ func (t *txPool) checkTxBatch(ctx context.Context) error {
 		return nil
 	}
 
-	results, err := func() ([]protocol.CheckTxResult, error) {
-		checkCtx, cancelCheckCtx := context.WithTimeout(ctx, checkTxTimeout)
-		defer cancelCheckCtx()
-
-		// Check batch.
-		rawTxBatch := make([][]byte, 0, len(batch))
-		for _, pct := range batch {
-			rawTxBatch = append(rawTxBatch, pct.Raw())
-		}
-		return t.runtime.CheckTx(checkCtx, bi.RuntimeBlock, bi.ConsensusBlock, bi.Epoch, bi.ActiveDescriptor.Executor.MaxMessages, rawTxBatch)
-	}()
-	switch {
-	case err == nil:
-	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
-		// Context was canceled while the runtime was processing a request.
-		t.logger.Error("transaction batch check aborted by context, aborting runtime")
-
-		// Abort the runtime, so we can start processing the next batch.
-		abortCtx, cancel := context.WithTimeout(ctx, abortTimeout)
-		defer cancel()
-
-		if err = t.runtime.Abort(abortCtx, false); err != nil {
-			t.logger.Error("failed to abort the runtime",
-				"err", err,
-			)
+	// Use cached results for new transactions that have already been checked in this round.
+	round := bi.RuntimeBlock.Header.Round
+	results := make([]protocol.CheckTxResult, len(batch))
+	uncached := make([]int, 0, len(batch))
+	for i, pct := range batch {
+		if !pct.flags.isRecheck() {
+			if res, ok := t.checkTxCache.get(round, pct.Hash()); ok {
+				checkTxCacheHits.With(t.getMetricLabels()).Inc()
+				results[i] = res
+				continue
+			}
+			checkTxCacheMisses.With(t.getMetricLabels()).Inc()
 		}
+		uncached = append(uncached, i)
+	}
 
-		fallthrough
-	default:
-		// Return transaction batch back to the check queue.
-		t.checkTxQueue.retryBatch(batch)
+	if len(uncached) > 0 {
+		checkResults, err := func() ([]protocol.CheckTxResult, error) {
+			checkCtx, cancelCheckCtx := context.WithTimeout(ctx, checkTxTimeout)
+			defer cancelCheckCtx()
 
-		return err
+			// Check batch.
+			rawTxBatch := make([][]byte, 0, len(uncached))
+			for _, i := range uncached {
+				rawTxBatch = append(rawTxBatch, batch[i].Raw())
+			}
+			return t.runtime.CheckTx(checkCtx, bi.RuntimeBlock, bi.ConsensusBlock, bi.Epoch, bi.ActiveDescriptor.Executor.MaxMessages, rawTxBatch)
+		}()
+		switch {
+		case err == nil:
+		case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+			// Context was canceled while the runtime was processing a request.
+			t.logger.Error("transaction batch check aborted by context, aborting runtime")
+
+			// Abort the runtime, so we can start processing the next batch.
+			abortCtx, cancel := context.WithTimeout(ctx, abortTimeout)
+			defer cancel()
+
+			if err = t.runtime.Abort(abortCtx, false); err != nil {
+				t.logger.Error("failed to abort the runtime",
+					"err", err,
+				)
+			}
+
+			fallthrough
+		default:
+			// Return transaction batch back to the check queue.
+			t.checkTxQueue.retryBatch(batch)
+
+			return err
+		}
+
+		for j, i := range uncached {
+			results[i] = checkResults[j]
+			t.checkTxCache.put(round, batch[i].Hash(), checkResults[j])
+		}
 	}
+	checkTxCacheSize.With(t.getMetricLabels()).Set(float64(t.checkTxCache.size()))
 
 	pendingCheckSize.With(t.getMetricLabels()).Set(float64(t.PendingCheckSize()))
 
@@ -906,6 +931,7 @@ func New(
 		txPublisher:          txPublisher,
 		seenCache:            seenCache,
 		checkTxQueue:         newCheckTxQueue(maxCheckTxQueueSize, int(cfg.MaxCheckTxBatchSize)),
+		checkTxCache:         newCheckTxCache(int(cfg.MaxCheckTxCacheSize)),
 		checkTxCh:            channels.NewRingChannel(1),
 		checkTxNotifier:      pubsub.NewBroker(false),
 		recheckTxCh:          channels.NewRingChannel(1),
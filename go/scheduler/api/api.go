@@ -2,21 +2,48 @@
 package api
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"strings"
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/errors"
+	"github.com/oasisprotocol/oasis-core/go/common/keyformat"
 	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/syncer"
 )
 
 // ModuleName is a unique module name for the scheduler module.
 const ModuleName = "scheduler"
 
+var (
+	// ErrNoSuchCommittee is the error returned when a committee does not exist.
+	ErrNoSuchCommittee = errors.New(ModuleName, 1, "scheduler: no such committee")
+
+	// ErrNotCommitteeMember is the error returned when a node is not a member of a committee.
+	ErrNotCommitteeMember = errors.New(ModuleName, 2, "scheduler: node is not a committee member")
+
+	// ErrInvalidProof is the error returned when a committee membership proof is invalid.
+	ErrInvalidProof = errors.New(ModuleName, 3, "scheduler: invalid committee membership proof")
+
+	// committeeStateKeyFmt is the key format used for committees in consensus state.
+	//
+	// It must be kept in sync with the key format used by the scheduler application.
+	committeeStateKeyFmt = keyformat.New(0x60, uint8(0), keyformat.H(&common.Namespace{}))
+)
+
+// CommitteeStateKey returns the consensus state key under which the committee of the given kind
+// for the given runtime is stored.
+func CommitteeStateKey(kind CommitteeKind, runtimeID common.Namespace) []byte {
+	return committeeStateKeyFmt.Encode(uint8(kind), &runtimeID)
+}
+
 // Role is the role a given node plays in a committee.
 type Role uint8
 
@@ -356,6 +383,10 @@ type Backend interface {
 	// Iff the callback is nil, `beacon.GetBlockBeacon` will be used.
 	GetCommittees(ctx context.Context, request *GetCommitteesRequest) ([]*Committee, error)
 
+	// GetCommitteeMembershipProof returns a proof that the given node is a member of the
+	// given committee at the specified block height.
+	GetCommitteeMembershipProof(ctx context.Context, request *GetCommitteeMembershipProofRequest) (*CommitteeMembershipProof, error)
+
 	// WatchCommittees returns a channel that produces a stream of
 	// Committee.
 	//
@@ -379,6 +410,74 @@ type GetCommitteesRequest struct {
 	RuntimeID common.Namespace `json:"runtime_id"`
 }
 
+// GetCommitteeMembershipProofRequest is a GetCommitteeMembershipProof request.
+type GetCommitteeMembershipProofRequest struct {
+	Height    int64               `json:"height"`
+	RuntimeID common.Namespace    `json:"runtime_id"`
+	Kind      CommitteeKind       `json:"kind"`
+	NodeID    signature.PublicKey `json:"node_id"`
+}
+
+// CommitteeMembershipProof is a proof that a node is a member of a committee which can be
+// verified against a trusted consensus state root, e.g., by light clients.
+type CommitteeMembershipProof struct {
+	// Height is the consensus block height of the state the proof is for.
+	//
+	// The proof should be verified against the state root committed in the block at the next
+	// height.
+	Height int64 `json:"height"`
+
+	// Proof is the Merkle proof of the committee under the consensus state root.
+	Proof syncer.Proof `json:"proof"`
+}
+
+// Verify verifies the committee membership proof against the given trusted consensus state
+// root and returns the proven committee.
+//
+// The committee must be of the given kind, belong to the given runtime, be valid for the given
+// epoch and include the given node as a member.
+func (p *CommitteeMembershipProof) Verify(
+	ctx context.Context,
+	stateRoot hash.Hash,
+	kind CommitteeKind,
+	runtimeID common.Namespace,
+	epoch beacon.EpochTime,
+	nodeID signature.PublicKey,
+) (*Committee, error) {
+	var pv syncer.ProofVerifier
+	wl, err := pv.VerifyProofToWriteLog(ctx, stateRoot, &p.Proof)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidProof, err)
+	}
+
+	key := CommitteeStateKey(kind, runtimeID)
+	var raw []byte
+	for _, entry := range wl {
+		if bytes.Equal(entry.Key, key) {
+			raw = entry.Value
+			break
+		}
+	}
+	if raw == nil {
+		return nil, ErrNoSuchCommittee
+	}
+
+	var committee Committee
+	if err = cbor.Unmarshal(raw, &committee); err != nil {
+		return nil, fmt.Errorf("%w: malformed committee: %w", ErrInvalidProof, err)
+	}
+	switch {
+	case committee.Kind != kind, !committee.RuntimeID.Equal(&runtimeID):
+		return nil, fmt.Errorf("%w: committee mismatch", ErrInvalidProof)
+	case committee.ValidFor != epoch:
+		return nil, fmt.Errorf("%w: committee valid for epoch %d, not %d", ErrNoSuchCommittee, committee.ValidFor, epoch)
+	case !committee.IsMember(nodeID):
+		return nil, ErrNotCommitteeMember
+	}
+
+	return &committee, nil
+}
+
 // Genesis is the committee scheduler genesis state.
 type Genesis struct {
 	// Parameters are the scheduler consensus parameters.
@@ -418,6 +517,11 @@ type ConsensusParameters struct {
 
 	// VotingPowerDistribution is the voting power distribution.
 	VotingPowerDistribution VotingPowerDistribution `json:"voting_power_distribution,omitempty"`
+
+	// EligibilityLists are the per-runtime lists of nodes and entities consulted during
+	// committee elections, which allow permissioned runtimes to restrict their committees
+	// without restricting node registration.
+	EligibilityLists map[common.Namespace]*EligibilityList `json:"eligibility_lists,omitempty"`
 }
 
 // ConsensusParameterChanges are allowed scheduler consensus parameter changes.
@@ -430,6 +534,10 @@ type ConsensusParameterChanges struct {
 
 	// VotingPowerDistribution is the new voting power distribution.
 	VotingPowerDistribution *VotingPowerDistribution `json:"voting_power_distribution,omitempty"`
+
+	// EligibilityLists are the new eligibility lists of the given runtimes. An empty list removes
+	// the runtime's eligibility list.
+	EligibilityLists map[common.Namespace]*EligibilityList `json:"eligibility_lists,omitempty"`
 }
 
 // Apply applies changes to the given consensus parameters.
@@ -443,6 +551,16 @@ func (c *ConsensusParameterChanges) Apply(params *ConsensusParameters) error {
 	if c.VotingPowerDistribution != nil {
 		params.VotingPowerDistribution = *c.VotingPowerDistribution
 	}
+	for id, list := range c.EligibilityLists {
+		if list == nil || list.IsEmpty() {
+			delete(params.EligibilityLists, id)
+			continue
+		}
+		if params.EligibilityLists == nil {
+			params.EligibilityLists = make(map[common.Namespace]*EligibilityList)
+		}
+		params.EligibilityLists[id] = list
+	}
 	return nil
 }
 
@@ -1,11 +1,19 @@
 package api
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/syncer"
 )
 
 func TestSanityCheck(t *testing.T) {
@@ -35,3 +43,75 @@ func TestVotingPowerDistribution(t *testing.T) {
 	require.True(t, powerS > 0, "sqrt should be greater than 0")
 	require.True(t, powerL > powerS, "linear should be greater than sqrt")
 }
+
+func TestCommitteeMembershipProof(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	var runtimeID, otherRuntimeID common.Namespace
+	require.NoError(runtimeID.UnmarshalHex("8000000000000000000000000000000000000000000000000000000000000000"), "UnmarshalHex")
+	require.NoError(otherRuntimeID.UnmarshalHex("8000000000000000000000000000000000000000000000000000000000000001"), "UnmarshalHex")
+	member := signature.NewPublicKey("0000000000000000000000000000000000000000000000000000000000000001")
+	nonMember := signature.NewPublicKey("0000000000000000000000000000000000000000000000000000000000000002")
+
+	committee := &Committee{
+		Kind: KindComputeExecutor,
+		Members: []*CommitteeNode{
+			{Role: RoleWorker, PublicKey: member},
+		},
+		RuntimeID: runtimeID,
+		ValidFor:  42,
+	}
+
+	// Build a consensus state tree containing the committee.
+	tree := mkvs.New(nil, nil, node.RootTypeState)
+	defer tree.Close()
+	err := tree.Insert(ctx, CommitteeStateKey(committee.Kind, runtimeID), cbor.Marshal(committee))
+	require.NoError(err, "Insert")
+	err = tree.Insert(ctx, []byte("other key"), []byte("other value"))
+	require.NoError(err, "Insert")
+	_, rootHash, err := tree.Commit(ctx, common.Namespace{}, 10)
+	require.NoError(err, "Commit")
+
+	root := node.Root{Version: 10, Type: node.RootTypeState, Hash: rootHash}
+	rsp, err := tree.SyncGet(ctx, &syncer.GetRequest{
+		Tree: syncer.TreeID{
+			Root:     root,
+			Position: rootHash,
+		},
+		Key:          CommitteeStateKey(committee.Kind, runtimeID),
+		ProofVersion: syncer.LatestProofVersion,
+	})
+	require.NoError(err, "SyncGet")
+	proof := CommitteeMembershipProof{Height: 10, Proof: rsp.Proof}
+
+	// Valid proof.
+	c, err := proof.Verify(ctx, rootHash, KindComputeExecutor, runtimeID, 42, member)
+	require.NoError(err, "Verify")
+	require.EqualValues(committee, c)
+
+	// Not a member.
+	_, err = proof.Verify(ctx, rootHash, KindComputeExecutor, runtimeID, 42, nonMember)
+	require.ErrorIs(err, ErrNotCommitteeMember)
+
+	// Wrong epoch.
+	_, err = proof.Verify(ctx, rootHash, KindComputeExecutor, runtimeID, 43, member)
+	require.ErrorIs(err, ErrNoSuchCommittee)
+
+	// Committee not included in the proof.
+	_, err = proof.Verify(ctx, rootHash, KindComputeExecutor, otherRuntimeID, 42, member)
+	require.ErrorIs(err, ErrNoSuchCommittee)
+
+	// Wrong state root.
+	var otherRoot hash.Hash
+	otherRoot.FromBytes([]byte("other root"))
+	_, err = proof.Verify(ctx, otherRoot, KindComputeExecutor, runtimeID, 42, member)
+	require.ErrorIs(err, ErrInvalidProof)
+
+	// Tampered proof.
+	tampered := CommitteeMembershipProof{Height: 10, Proof: rsp.Proof}
+	tampered.Proof.Entries = append([][]byte{}, rsp.Proof.Entries...)
+	tampered.Proof.Entries[len(tampered.Proof.Entries)-1] = []byte("garbage")
+	_, err = tampered.Verify(ctx, rootHash, KindComputeExecutor, runtimeID, 42, member)
+	require.ErrorIs(err, ErrInvalidProof)
+}
@@ -0,0 +1,79 @@
+package api
+
+import (
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+)
+
+// EligibilityList is a per-runtime list of nodes and entities that restricts which nodes are
+// eligible for the runtime's committee elections.
+//
+// Each entry is either a node or an entity identifier, where an entity entry applies to all
+// nodes of the entity.
+type EligibilityList struct {
+	// Allowed are the nodes and entities that are eligible for elections. If empty, all nodes are
+	// eligible unless denied.
+	Allowed []signature.PublicKey `json:"allowed,omitempty"`
+
+	// Denied are the nodes and entities that are never eligible for elections.
+	Denied []signature.PublicKey `json:"denied,omitempty"`
+}
+
+// IsEmpty returns true iff the list neither allows nor denies any nodes.
+func (l *EligibilityList) IsEmpty() bool {
+	return len(l.Allowed) == 0 && len(l.Denied) == 0
+}
+
+// IsEligible returns true iff the node with the given identifier, owned by the given entity, is
+// eligible for elections.
+func (l *EligibilityList) IsEligible(nodeID, entityID signature.PublicKey) bool {
+	for _, id := range l.Denied {
+		if id.Equal(nodeID) || id.Equal(entityID) {
+			return false
+		}
+	}
+	if len(l.Allowed) == 0 {
+		return true
+	}
+	for _, id := range l.Allowed {
+		if id.Equal(nodeID) || id.Equal(entityID) {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateBasic performs basic eligibility list validity checks.
+func (l *EligibilityList) ValidateBasic() error {
+	seen := make(map[signature.PublicKey]struct{}, len(l.Allowed)+len(l.Denied))
+	for _, ids := range [][]signature.PublicKey{l.Allowed, l.Denied} {
+		for _, id := range ids {
+			if !id.IsValid() {
+				return fmt.Errorf("invalid identifier %s", id)
+			}
+			if _, ok := seen[id]; ok {
+				return fmt.Errorf("duplicate identifier %s", id)
+			}
+			seen[id] = struct{}{}
+		}
+	}
+	return nil
+}
+
+// ExcludedEvent is the event emitted when a runtime's eligibility list excludes otherwise
+// suitable nodes from a committee election.
+type ExcludedEvent struct {
+	// RuntimeID is the identifier of the runtime.
+	RuntimeID common.Namespace `json:"runtime_id"`
+	// Kind is the kind of the elected committee.
+	Kind CommitteeKind `json:"kind"`
+	// Nodes are the identifiers of the excluded nodes.
+	Nodes []signature.PublicKey `json:"nodes"`
+}
+
+// EventKind returns a string representation of this event's kind.
+func (ev *ExcludedEvent) EventKind() string {
+	return "excluded"
+}
@@ -0,0 +1,59 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+)
+
+func TestEligibilityList(t *testing.T) {
+	require := require.New(t)
+
+	nodeID1 := signature.NewPublicKey("0000000000000000000000000000000000000000000000000000000000000001")
+	nodeID2 := signature.NewPublicKey("0000000000000000000000000000000000000000000000000000000000000002")
+	entityID1 := signature.NewPublicKey("1000000000000000000000000000000000000000000000000000000000000001")
+	entityID2 := signature.NewPublicKey("1000000000000000000000000000000000000000000000000000000000000002")
+
+	list := EligibilityList{}
+	require.True(list.IsEmpty())
+	require.True(list.IsEligible(nodeID1, entityID1), "empty list should allow all nodes")
+
+	// Deny list.
+	list.Denied = []signature.PublicKey{entityID2}
+	require.True(list.IsEligible(nodeID1, entityID1))
+	require.False(list.IsEligible(nodeID2, entityID2), "nodes of denied entities should not be eligible")
+
+	// Allow list.
+	list.Allowed = []signature.PublicKey{nodeID1}
+	require.True(list.IsEligible(nodeID1, entityID1))
+	require.False(list.IsEligible(nodeID2, entityID1), "nodes not allowed should not be eligible")
+	list.Allowed = []signature.PublicKey{entityID1}
+	require.True(list.IsEligible(nodeID2, entityID1), "nodes of allowed entities should be eligible")
+	list.Denied = []signature.PublicKey{nodeID2}
+	require.False(list.IsEligible(nodeID2, entityID1), "denied nodes should not be eligible")
+
+	// Validation.
+	require.NoError(list.ValidateBasic())
+	list.Denied = []signature.PublicKey{entityID1}
+	require.Error(list.ValidateBasic(), "identifiers should not be both allowed and denied")
+
+	// Parameter changes.
+	var rtID common.Namespace
+	params := ConsensusParameters{}
+	changes := ConsensusParameterChanges{
+		EligibilityLists: map[common.Namespace]*EligibilityList{
+			rtID: {Allowed: []signature.PublicKey{nodeID1}},
+		},
+	}
+	require.NoError(changes.SanityCheck())
+	require.NoError(changes.Apply(&params))
+	require.NoError(params.SanityCheck())
+	require.Len(params.EligibilityLists, 1)
+
+	changes.EligibilityLists[rtID] = &EligibilityList{}
+	require.NoError(changes.Apply(&params))
+	require.Empty(params.EligibilityLists, "empty list should remove the runtime's list")
+}
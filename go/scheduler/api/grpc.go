@@ -17,6 +17,8 @@ var (
 	methodGetValidators = serviceName.NewMethod("GetValidators", int64(0))
 	// methodGetCommittees is the GetCommittees method.
 	methodGetCommittees = serviceName.NewMethod("GetCommittees", GetCommitteesRequest{})
+	// methodGetCommitteeMembershipProof is the GetCommitteeMembershipProof method.
+	methodGetCommitteeMembershipProof = serviceName.NewMethod("GetCommitteeMembershipProof", GetCommitteeMembershipProofRequest{})
 	// methodStateToGenesis is the StateToGenesis method.
 	methodStateToGenesis = serviceName.NewMethod("StateToGenesis", int64(0))
 	// methodConsensusParameters is the ConsensusParameters method.
@@ -38,6 +40,10 @@ var (
 				MethodName: methodGetCommittees.ShortName(),
 				Handler:    handlerGetCommittees,
 			},
+			{
+				MethodName: methodGetCommitteeMembershipProof.ShortName(),
+				Handler:    handlerGetCommitteeMembershipProof,
+			},
 			{
 				MethodName: methodStateToGenesis.ShortName(),
 				Handler:    handlerStateToGenesis,
@@ -103,6 +109,29 @@ func handlerGetCommittees(
 	return interceptor(ctx, &req, info, handler)
 }
 
+func handlerGetCommitteeMembershipProof(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var req GetCommitteeMembershipProofRequest
+	if err := dec(&req); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).GetCommitteeMembershipProof(ctx, &req)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetCommitteeMembershipProof.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).GetCommitteeMembershipProof(ctx, req.(*GetCommitteeMembershipProofRequest))
+	}
+	return interceptor(ctx, &req, info, handler)
+}
+
 func handlerStateToGenesis(
 	srv interface{},
 	ctx context.Context,
@@ -202,6 +231,14 @@ func (c *schedulerClient) GetCommittees(ctx context.Context, request *GetCommitt
 	return rsp, nil
 }
 
+func (c *schedulerClient) GetCommitteeMembershipProof(ctx context.Context, request *GetCommitteeMembershipProofRequest) (*CommitteeMembershipProof, error) {
+	var rsp CommitteeMembershipProof
+	if err := c.conn.Invoke(ctx, methodGetCommitteeMembershipProof.FullName(), request, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *schedulerClient) StateToGenesis(ctx context.Context, height int64) (*Genesis, error) {
 	var rsp Genesis
 	if err := c.conn.Invoke(ctx, methodStateToGenesis.FullName(), height, &rsp); err != nil {
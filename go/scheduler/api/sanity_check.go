@@ -36,6 +36,14 @@ func (p *ConsensusParameters) SanityCheck() error {
 	if unsafeFlags && !flags.DebugDontBlameOasis() {
 		return fmt.Errorf("one or more unsafe debug flags set")
 	}
+	for id, list := range p.EligibilityLists {
+		if list == nil || list.IsEmpty() {
+			return fmt.Errorf("empty eligibility list for runtime %s", id)
+		}
+		if err := list.ValidateBasic(); err != nil {
+			return fmt.Errorf("invalid eligibility list for runtime %s: %w", id, err)
+		}
+	}
 	return nil
 }
 
@@ -43,7 +51,8 @@ func (p *ConsensusParameters) SanityCheck() error {
 func (c *ConsensusParameterChanges) SanityCheck() error {
 	if c.MinValidators == nil &&
 		c.MaxValidators == nil &&
-		c.VotingPowerDistribution == nil {
+		c.VotingPowerDistribution == nil &&
+		len(c.EligibilityLists) == 0 {
 		return fmt.Errorf("consensus parameter changes should not be empty")
 	}
 	return nil
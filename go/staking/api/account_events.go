@@ -0,0 +1,61 @@
+package api
+
+// MaxAccountEventsLimit is the maximum number of events returned by a single
+// account events query.
+const MaxAccountEventsLimit = 1000
+
+// AccountEventsQuery is an account events query.
+type AccountEventsQuery struct {
+	// Owner is the address of the account.
+	Owner Address `json:"owner"`
+	// StartHeight is the (inclusive) height at which to start listing events.
+	StartHeight int64 `json:"start_height"`
+	// StartIndex is the (inclusive) index of the first event to return at the
+	// start height, used to resume listing in the middle of a block.
+	StartIndex uint32 `json:"start_index,omitempty"`
+	// Limit is the maximum number of events to return. If zero or larger than
+	// MaxAccountEventsLimit, MaxAccountEventsLimit is used.
+	Limit uint32 `json:"limit,omitempty"`
+}
+
+// AccountEvents is a page of events involving an account.
+type AccountEvents struct {
+	// Events are the events involving the account, ordered by block height.
+	Events []*Event `json:"events"`
+	// Next is the query which returns the next page of events. It is nil when
+	// there are no more indexed events.
+	Next *AccountEventsQuery `json:"next,omitempty"`
+}
+
+// Addresses returns the addresses of all accounts involved in the event.
+func (e *Event) Addresses() []Address {
+	switch {
+	case e.Transfer != nil:
+		return []Address{e.Transfer.From, e.Transfer.To}
+	case e.Burn != nil:
+		return []Address{e.Burn.Owner}
+	case e.Escrow != nil:
+		switch {
+		case e.Escrow.Add != nil:
+			return []Address{e.Escrow.Add.Owner, e.Escrow.Add.Escrow}
+		case e.Escrow.Take != nil:
+			return []Address{e.Escrow.Take.Owner}
+		case e.Escrow.DebondingStart != nil:
+			return []Address{e.Escrow.DebondingStart.Owner, e.Escrow.DebondingStart.Escrow}
+		case e.Escrow.Reclaim != nil:
+			return []Address{e.Escrow.Reclaim.Owner, e.Escrow.Reclaim.Escrow}
+		}
+	case e.AllowanceChange != nil:
+		return []Address{e.AllowanceChange.Owner, e.AllowanceChange.Beneficiary}
+	case e.ScheduledTransfer != nil:
+		switch {
+		case e.ScheduledTransfer.Schedule != nil:
+			return []Address{e.ScheduledTransfer.Schedule.From, e.ScheduledTransfer.Schedule.To}
+		case e.ScheduledTransfer.Release != nil:
+			return []Address{e.ScheduledTransfer.Release.From, e.ScheduledTransfer.Release.To}
+		case e.ScheduledTransfer.Cancel != nil:
+			return []Address{e.ScheduledTransfer.Cancel.From, e.ScheduledTransfer.Cancel.To}
+		}
+	}
+	return nil
+}
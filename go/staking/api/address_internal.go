@@ -28,6 +28,15 @@ var (
 		signature.NewPublicKey("1abe11eddeaccfffffffffffffffffffffffffffffffffffffffffffffffffff"),
 	)
 
+	// ScheduledTransfersAddress is the scheduled transfers pool address.
+	// It holds the amounts of all pending scheduled transfers.
+	// This address is reserved to prevent it from being accidentally used in the actual ledger.
+	//
+	// oasis1qrnkj33zsgwpfhurzj0629n686t6tr8swv0hpfw7
+	ScheduledTransfersAddress = NewReservedAddress(
+		signature.NewPublicKey("1abe11ed5c7dffffffffffffffffffffffffffffffffffffffffffffffffffff"),
+	)
+
 	// BurnAddress is the burn address.  Transfers sent to this address
 	// are treated identically to token burn by the transfer originator.
 	//
@@ -0,0 +1,73 @@
+package api
+
+import (
+	"fmt"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+)
+
+// AllowanceLimits are optional limits bounding the exposure of a beneficiary allowance.
+type AllowanceLimits struct {
+	// Expiration is the epoch starting at which the allowance can no longer be withdrawn from.
+	// Zero means that the allowance does not expire.
+	Expiration beacon.EpochTime `json:"expiration,omitempty"`
+	// EpochLimit is the maximum amount that can be withdrawn from the allowance in a single
+	// epoch. Zero means that there is no limit.
+	EpochLimit quantity.Quantity `json:"epoch_limit,omitempty"`
+
+	// Epoch is the epoch of the last withdrawal that counted towards the epoch limit.
+	Epoch beacon.EpochTime `json:"epoch,omitempty"`
+	// Withdrawn is the amount withdrawn during Epoch.
+	Withdrawn quantity.Quantity `json:"withdrawn,omitempty"`
+}
+
+// IsEmpty returns true iff the allowance neither expires nor has an epoch limit.
+func (l *AllowanceLimits) IsEmpty() bool {
+	return l.Expiration == 0 && l.EpochLimit.IsZero()
+}
+
+// IsExpired returns true iff the allowance has expired at the given epoch.
+func (l *AllowanceLimits) IsExpired(epoch beacon.EpochTime) bool {
+	return l.Expiration != 0 && epoch >= l.Expiration
+}
+
+// Withdraw accounts for a withdrawal of the given amount at the given epoch, failing in case the
+// allowance has expired or the withdrawal would exceed the epoch limit.
+func (l *AllowanceLimits) Withdraw(epoch beacon.EpochTime, amount *quantity.Quantity) error {
+	if l.IsExpired(epoch) {
+		return fmt.Errorf("%w: allowance expired at epoch %d", ErrForbidden, l.Expiration)
+	}
+	if l.EpochLimit.IsZero() {
+		return nil
+	}
+
+	withdrawn := l.Withdrawn.Clone()
+	if l.Epoch != epoch {
+		withdrawn = quantity.NewQuantity()
+	}
+	if err := withdrawn.Add(amount); err != nil {
+		return fmt.Errorf("failed to add withdrawn amount: %w", err)
+	}
+	if withdrawn.Cmp(&l.EpochLimit) > 0 {
+		return fmt.Errorf("%w: allowance epoch limit exceeded", ErrForbidden)
+	}
+
+	l.Epoch = epoch
+	l.Withdrawn = *withdrawn
+	return nil
+}
+
+// SanityCheck performs a sanity check on the allowance limits.
+func (l *AllowanceLimits) SanityCheck() error {
+	if !l.EpochLimit.IsValid() {
+		return fmt.Errorf("invalid epoch limit")
+	}
+	if !l.Withdrawn.IsValid() {
+		return fmt.Errorf("invalid withdrawn amount")
+	}
+	if l.IsEmpty() {
+		return fmt.Errorf("empty limits")
+	}
+	return nil
+}
@@ -70,12 +70,23 @@ var (
 	// total supply value.
 	ErrAllowanceGreaterThanSupply = errors.New(ModuleName, 11, "staking: allowance greater than total supply")
 
+	// ErrNoSuchScheduledTransfer is the error returned when a scheduled transfer does not exist.
+	ErrNoSuchScheduledTransfer = errors.New(ModuleName, 12, "staking: no such scheduled transfer")
+
+	// ErrAccountEventIndexDisabled is the error returned when the account event index is
+	// queried, but is not enabled on the node.
+	ErrAccountEventIndexDisabled = errors.New(ModuleName, 13, "staking: account event index is disabled")
+
 	// MethodTransfer is the method name for transfers.
 	MethodTransfer = transaction.NewMethodName(ModuleName, "Transfer", Transfer{})
+	// MethodTransferBatch is the method name for batched transfers.
+	MethodTransferBatch = transaction.NewMethodName(ModuleName, "TransferBatch", TransferBatch{})
 	// MethodBurn is the method name for burns.
 	MethodBurn = transaction.NewMethodName(ModuleName, "Burn", Burn{})
 	// MethodAddEscrow is the method name for escrows.
 	MethodAddEscrow = transaction.NewMethodName(ModuleName, "AddEscrow", Escrow{})
+	// MethodAddEscrowBatch is the method name for batched escrows.
+	MethodAddEscrowBatch = transaction.NewMethodName(ModuleName, "AddEscrowBatch", EscrowBatch{})
 	// MethodReclaimEscrow is the method name for escrow reclamations.
 	MethodReclaimEscrow = transaction.NewMethodName(ModuleName, "ReclaimEscrow", ReclaimEscrow{})
 	// MethodAmendCommissionSchedule is the method name for amending commission schedules.
@@ -84,21 +95,31 @@ var (
 	MethodAllow = transaction.NewMethodName(ModuleName, "Allow", Allow{})
 	// MethodWithdraw is the method name for
 	MethodWithdraw = transaction.NewMethodName(ModuleName, "Withdraw", Withdraw{})
+	// MethodTransferAt is the method name for scheduled transfers.
+	MethodTransferAt = transaction.NewMethodName(ModuleName, "TransferAt", TransferAt{})
+	// MethodCancelScheduledTransfer is the method name for cancelling scheduled transfers.
+	MethodCancelScheduledTransfer = transaction.NewMethodName(ModuleName, "CancelScheduledTransfer", CancelScheduledTransfer{})
 
 	// Methods is the list of all methods supported by the staking backend.
 	Methods = []transaction.MethodName{
 		MethodTransfer,
+		MethodTransferBatch,
 		MethodBurn,
 		MethodAddEscrow,
+		MethodAddEscrowBatch,
 		MethodReclaimEscrow,
 		MethodAmendCommissionSchedule,
 		MethodAllow,
 		MethodWithdraw,
+		MethodTransferAt,
+		MethodCancelScheduledTransfer,
 	}
 
 	_ prettyprint.PrettyPrinter = (*Transfer)(nil)
+	_ prettyprint.PrettyPrinter = (*TransferBatch)(nil)
 	_ prettyprint.PrettyPrinter = (*Burn)(nil)
 	_ prettyprint.PrettyPrinter = (*Escrow)(nil)
+	_ prettyprint.PrettyPrinter = (*EscrowBatch)(nil)
 	_ prettyprint.PrettyPrinter = (*ReclaimEscrow)(nil)
 	_ prettyprint.PrettyPrinter = (*AmendCommissionSchedule)(nil)
 	_ prettyprint.PrettyPrinter = (*Allow)(nil)
@@ -120,6 +141,9 @@ type Backend interface {
 	// 1 token = 10**TokenValueExponent base units.
 	TokenValueExponent(ctx context.Context, height int64) (uint8, error)
 
+	// TokenMetadata returns the token metadata needed to display amounts in tokens.
+	TokenMetadata(ctx context.Context, height int64) (*token.Metadata, error)
+
 	// TotalSupply returns the total number of base units.
 	TotalSupply(ctx context.Context, height int64) (*quantity.Quantity, error)
 
@@ -173,6 +197,24 @@ type Backend interface {
 	// Allowance looks up the allowance for the given owner/beneficiary combination.
 	Allowance(ctx context.Context, query *AllowanceQuery) (*quantity.Quantity, error)
 
+	// ScheduledTransfersFrom returns the list of pending (outgoing) scheduled
+	// transfers sent by the given owner.
+	ScheduledTransfersFrom(ctx context.Context, query *OwnerQuery) ([]*ScheduledTransfer, error)
+
+	// ScheduledTransfersTo returns the list of pending (incoming) scheduled
+	// transfers to the given account.
+	ScheduledTransfersTo(ctx context.Context, query *OwnerQuery) ([]*ScheduledTransfer, error)
+
+	// SimulateSlash computes the outcome of a hypothetical slashing of the given escrow
+	// account for the given reason under the current consensus parameters, without
+	// modifying any state.
+	SimulateSlash(ctx context.Context, query *SlashSimulationQuery) (*SlashSimulation, error)
+
+	// ValidateCommissionSchedule checks whether the given commission schedule amendment would
+	// be accepted for the given account under the current consensus parameters and returns
+	// the resulting commission schedule, without modifying any state.
+	ValidateCommissionSchedule(ctx context.Context, query *CommissionScheduleQuery) (*CommissionSchedule, error)
+
 	// StateToGenesis returns the genesis state at specified block height.
 	StateToGenesis(ctx context.Context, height int64) (*Genesis, error)
 
@@ -182,6 +224,13 @@ type Backend interface {
 	// GetEvents returns the events at specified block height.
 	GetEvents(ctx context.Context, height int64) ([]*Event, error)
 
+	// GetAccountEvents returns a page of events involving the given account,
+	// ordered by block height.
+	//
+	// The events are served from a node-local index which must be enabled in
+	// the node configuration, and only covers blocks processed by the node.
+	GetAccountEvents(ctx context.Context, query *AccountEventsQuery) (*AccountEvents, error)
+
 	// WatchEvents returns a channel that produces a stream of Events.
 	WatchEvents(ctx context.Context) (<-chan *Event, pubsub.ClosableSubscription, error)
 
@@ -275,6 +324,8 @@ type Event struct {
 	Burn            *BurnEvent            `json:"burn,omitempty"`
 	Escrow          *EscrowEvent          `json:"escrow,omitempty"`
 	AllowanceChange *AllowanceChangeEvent `json:"allowance_change,omitempty"`
+
+	ScheduledTransfer *ScheduledTransferEvent `json:"scheduled_transfer,omitempty"`
 }
 
 // AddEscrowEvent is the event emitted when stake is transferred into an escrow
@@ -447,6 +498,52 @@ func NewTransferTx(nonce uint64, fee *transaction.Fee, xfer *Transfer) *transact
 	return transaction.NewTransaction(nonce, fee, MethodTransfer, xfer)
 }
 
+// TransferBatch is a batch of stake transfers from the same account which are
+// either all applied or none.
+//
+// The maximum number of transfers in a batch is bounded by the
+// MaxTransferBatchSize consensus parameter.
+type TransferBatch struct {
+	Transfers []Transfer `json:"transfers"`
+}
+
+// ValidateBasic performs basic transfer batch validity checks.
+func (tb *TransferBatch) ValidateBasic() error {
+	if len(tb.Transfers) == 0 {
+		return fmt.Errorf("%w: empty transfer batch", ErrInvalidArgument)
+	}
+	return nil
+}
+
+// PrettyPrint writes a pretty-printed representation of TransferBatch to the
+// given writer.
+func (tb TransferBatch) PrettyPrint(ctx context.Context, prefix string, w io.Writer) {
+	var total quantity.Quantity
+	for _, t := range tb.Transfers {
+		_ = total.Add(&t.Amount)
+	}
+	fmt.Fprintf(w, "%sTotal amount: ", prefix)
+	token.PrettyPrintAmount(ctx, total, w)
+	fmt.Fprintln(w)
+
+	fmt.Fprintf(w, "%sTransfers:\n", prefix)
+	for i, t := range tb.Transfers {
+		fmt.Fprintf(w, "%s  %d:\n", prefix, i+1)
+		t.PrettyPrint(ctx, prefix+"    ", w)
+	}
+}
+
+// PrettyType returns a representation of TransferBatch that can be used for
+// pretty printing.
+func (tb TransferBatch) PrettyType() (interface{}, error) {
+	return tb, nil
+}
+
+// NewTransferBatchTx creates a new transfer batch transaction.
+func NewTransferBatchTx(nonce uint64, fee *transaction.Fee, batch *TransferBatch) *transaction.Transaction {
+	return transaction.NewTransaction(nonce, fee, MethodTransferBatch, batch)
+}
+
 // Burn is a stake burn (destruction).
 type Burn struct {
 	Amount quantity.Quantity `json:"amount"`
@@ -498,6 +595,62 @@ func NewAddEscrowTx(nonce uint64, fee *transaction.Fee, escrow *Escrow) *transac
 	return transaction.NewTransaction(nonce, fee, MethodAddEscrow, escrow)
 }
 
+// MaxEscrowBatchSize is the maximum number of escrows in a single escrow batch.
+const MaxEscrowBatchSize = 64
+
+// EscrowBatch is a batch of stake escrows which are either all applied or none.
+type EscrowBatch struct {
+	Escrows []Escrow `json:"escrows"`
+}
+
+// ValidateBasic performs basic escrow batch validity checks.
+func (eb *EscrowBatch) ValidateBasic() error {
+	switch n := len(eb.Escrows); {
+	case n == 0:
+		return fmt.Errorf("%w: empty escrow batch", ErrInvalidArgument)
+	case n > MaxEscrowBatchSize:
+		return fmt.Errorf("%w: escrow batch too large (%d > %d)", ErrInvalidArgument, n, MaxEscrowBatchSize)
+	}
+
+	accounts := make(map[Address]struct{}, len(eb.Escrows))
+	for _, e := range eb.Escrows {
+		if _, ok := accounts[e.Account]; ok {
+			return fmt.Errorf("%w: duplicate escrow account %s", ErrInvalidArgument, e.Account)
+		}
+		accounts[e.Account] = struct{}{}
+	}
+	return nil
+}
+
+// PrettyPrint writes a pretty-printed representation of EscrowBatch to the given
+// writer.
+func (eb EscrowBatch) PrettyPrint(ctx context.Context, prefix string, w io.Writer) {
+	var total quantity.Quantity
+	for _, e := range eb.Escrows {
+		_ = total.Add(&e.Amount)
+	}
+	fmt.Fprintf(w, "%sTotal amount: ", prefix)
+	token.PrettyPrintAmount(ctx, total, w)
+	fmt.Fprintln(w)
+
+	fmt.Fprintf(w, "%sEscrows:\n", prefix)
+	for i, e := range eb.Escrows {
+		fmt.Fprintf(w, "%s  %d:\n", prefix, i+1)
+		e.PrettyPrint(ctx, prefix+"    ", w)
+	}
+}
+
+// PrettyType returns a representation of EscrowBatch that can be used for pretty
+// printing.
+func (eb EscrowBatch) PrettyType() (interface{}, error) {
+	return eb, nil
+}
+
+// NewAddEscrowBatchTx creates a new add escrow batch transaction.
+func NewAddEscrowBatchTx(nonce uint64, fee *transaction.Fee, batch *EscrowBatch) *transaction.Transaction {
+	return transaction.NewTransaction(nonce, fee, MethodAddEscrowBatch, batch)
+}
+
 // ReclaimEscrow is a reclamation of stake from an escrow.
 type ReclaimEscrow struct {
 	Account Address           `json:"account"`
@@ -551,6 +704,18 @@ type Allow struct {
 	Beneficiary  Address           `json:"beneficiary"`
 	Negative     bool              `json:"negative,omitempty"`
 	AmountChange quantity.Quantity `json:"amount_change"`
+
+	// Expiration is the new epoch starting at which the allowance can no longer be withdrawn
+	// from. If not set, the expiration is not changed. Zero removes the expiration.
+	Expiration *beacon.EpochTime `json:"expiration,omitempty"`
+	// EpochLimit is the new maximum amount that can be withdrawn from the allowance in a single
+	// epoch. If not set, the limit is not changed. Zero removes the limit.
+	EpochLimit *quantity.Quantity `json:"epoch_limit,omitempty"`
+}
+
+// HasLimits returns true iff the allow changes any allowance limits.
+func (aw *Allow) HasLimits() bool {
+	return aw.Expiration != nil || aw.EpochLimit != nil
 }
 
 // PrettyPrint writes a pretty-printed representation of Allow to the given writer.
@@ -561,10 +726,19 @@ func (aw Allow) PrettyPrint(ctx context.Context, prefix string, w io.Writer) {
 	if aw.Negative {
 		sign = "-"
 	}
-	ctx = context.WithValue(ctx, prettyprint.ContextKeyTokenValueSign, sign)
+	signCtx := context.WithValue(ctx, prettyprint.ContextKeyTokenValueSign, sign)
 	fmt.Fprintf(w, "%sAmount change: ", prefix)
-	token.PrettyPrintAmount(ctx, aw.AmountChange, w)
+	token.PrettyPrintAmount(signCtx, aw.AmountChange, w)
 	fmt.Fprintln(w)
+
+	if aw.Expiration != nil {
+		fmt.Fprintf(w, "%sExpiration:    %d\n", prefix, *aw.Expiration)
+	}
+	if aw.EpochLimit != nil {
+		fmt.Fprintf(w, "%sEpoch limit:   ", prefix)
+		token.PrettyPrintAmount(ctx, *aw.EpochLimit, w)
+		fmt.Fprintln(w)
+	}
 }
 
 // PrettyType returns a representation of Allow that can be used for pretty printing.
@@ -1008,6 +1182,9 @@ type GeneralAccount struct {
 
 	// Allowances is the set of per-beneficiary allowances.
 	Allowances map[Address]quantity.Quantity `json:"allowances,omitempty"`
+	// AllowanceLimits is the set of per-beneficiary allowance limits. Allowances without an
+	// entry are not limited.
+	AllowanceLimits map[Address]AllowanceLimits `json:"allowance_limits,omitempty"`
 	// Hooks is the set of hooks that should be invoked when specific actions happen to override
 	// common behavior.
 	Hooks map[HookKind]HookDestination `json:"hooks,omitempty"`
@@ -1030,6 +1207,16 @@ func (ga GeneralAccount) PrettyPrint(ctx context.Context, prefix string, w io.Wr
 			fmt.Fprintf(w, "%s%s%s: ", prefix, prefix, beneficiary)
 			token.PrettyPrintAmount(ctx, allowance, w)
 			fmt.Fprintln(w)
+			if limits, ok := ga.AllowanceLimits[beneficiary]; ok {
+				if limits.Expiration != 0 {
+					fmt.Fprintf(w, "%s%s%s  Expiration:  %d\n", prefix, prefix, prefix, limits.Expiration)
+				}
+				if !limits.EpochLimit.IsZero() {
+					fmt.Fprintf(w, "%s%s%s  Epoch limit: ", prefix, prefix, prefix)
+					token.PrettyPrintAmount(ctx, limits.EpochLimit, w)
+					fmt.Fprintln(w)
+				}
+			}
 		}
 	}
 
@@ -1226,6 +1413,18 @@ type Genesis struct {
 	// DebondingDelegations is a nested map of staking delegations of the form:
 	// DEBONDING-DELEGATEE-ACCOUNT-ADDRESS: DEBONDING-DELEGATOR-ACCOUNT-ADDRESS: list of DEBONDING-DELEGATIONs.
 	DebondingDelegations map[Address]map[Address][]*DebondingDelegation `json:"debonding_delegations,omitempty"`
+
+	// ScheduledTransfers is the list of pending scheduled transfers. The amounts
+	// are held in the scheduled transfers pool.
+	ScheduledTransfers []*ScheduledTransfer `json:"scheduled_transfers,omitempty"`
+}
+
+// TokenMetadata returns the token metadata configured in the genesis state.
+func (g *Genesis) TokenMetadata() *token.Metadata {
+	return &token.Metadata{
+		Symbol:   g.TokenSymbol,
+		Decimals: g.TokenValueExponent,
+	}
 }
 
 // ConsensusParameters are the staking consensus parameters.
@@ -1260,6 +1459,14 @@ type ConsensusParameters struct { // nolint: maligned
 	// MaxAllowances is the maximum number of allowances an account can have. Zero means disabled.
 	MaxAllowances uint32 `json:"max_allowances,omitempty"`
 
+	// MaxTransferBatchSize is the maximum number of transfers in a single transfer batch.
+	// Zero means disabled.
+	MaxTransferBatchSize uint16 `json:"max_transfer_batch_size,omitempty"`
+
+	// DustThreshold is the general balance below which the remaining balance of a transfer's
+	// source account is swept into the common pool. Zero means disabled.
+	DustThreshold quantity.Quantity `json:"dust_threshold,omitempty"`
+
 	// FeeSplitWeightPropose is the proportion of block fee portions that go to the proposer.
 	FeeSplitWeightPropose quantity.Quantity `json:"fee_split_weight_propose"`
 	// FeeSplitWeightVote is the proportion of block fee portions that go to the validator that votes.
@@ -1310,6 +1517,12 @@ type ConsensusParameterChanges struct {
 	// MaxAllowances is the new maximum number of allowances.
 	MaxAllowances *uint32 `json:"max_allowances,omitempty"`
 
+	// MaxTransferBatchSize is the new maximum number of transfers in a transfer batch.
+	MaxTransferBatchSize *uint16 `json:"max_transfer_batch_size,omitempty"`
+
+	// DustThreshold is the new dust threshold.
+	DustThreshold *quantity.Quantity `json:"dust_threshold,omitempty"`
+
 	// FeeSplitWeightPropose is the new propose fee split weight.
 	FeeSplitWeightPropose *quantity.Quantity `json:"fee_split_weight_propose"`
 	// FeeSplitWeightVote is the new vote fee split weight.
@@ -1358,6 +1571,12 @@ func (c *ConsensusParameterChanges) Apply(params *ConsensusParameters) error {
 	if c.MaxAllowances != nil {
 		params.MaxAllowances = *c.MaxAllowances
 	}
+	if c.MaxTransferBatchSize != nil {
+		params.MaxTransferBatchSize = *c.MaxTransferBatchSize
+	}
+	if c.DustThreshold != nil {
+		params.DustThreshold = *c.DustThreshold
+	}
 	if c.FeeSplitWeightPropose != nil {
 		params.FeeSplitWeightPropose = *c.FeeSplitWeightPropose
 	}
@@ -1391,6 +1610,10 @@ const (
 	GasOpAllow transaction.Op = "allow"
 	// GasOpWithdraw is the gas operation identifier for withdraw.
 	GasOpWithdraw transaction.Op = "withdraw"
+	// GasOpTransferAt is the gas operation identifier for scheduled transfer.
+	GasOpTransferAt transaction.Op = "transfer_at"
+	// GasOpCancelScheduledTransfer is the gas operation identifier for cancel scheduled transfer.
+	GasOpCancelScheduledTransfer transaction.Op = "cancel_scheduled_transfer"
 )
 
 // TransferResult is the result of staking transfer.
@@ -1425,3 +1648,16 @@ type ReclaimEscrowResult struct {
 	RemainingShares quantity.Quantity `json:"remaining_shares"`
 	DebondEndTime   beacon.EpochTime  `json:"debond_end_time"`
 }
+
+func init() {
+	transaction.RegisterGasOps(
+		ModuleName,
+		GasOpTransfer,
+		GasOpBurn,
+		GasOpAddEscrow,
+		GasOpReclaimEscrow,
+		GasOpAmendCommissionSchedule,
+		GasOpAllow,
+		GasOpWithdraw,
+	)
+}
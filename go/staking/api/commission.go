@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"math/big"
+	"slices"
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common/prettyprint"
@@ -393,6 +394,146 @@ func (cs *CommissionSchedule) AmendAndPruneAndValidate(amendment *CommissionSche
 	return nil
 }
 
+// ValidateAmendment checks whether the given amendment would be accepted for this schedule at
+// the given epoch and returns the resulting schedule. This schedule is not modified.
+func (cs *CommissionSchedule) ValidateAmendment(amendment *CommissionSchedule, rules *CommissionScheduleRules, now beacon.EpochTime) (*CommissionSchedule, error) {
+	result := cs.clone()
+	if err := result.AmendAndPruneAndValidate(amendment, rules, now); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// MinimalRateAmendment returns the smallest amendment that changes the commission rate to the
+// given rate as soon as possible at or after the given epoch.
+//
+// The rate change is aligned with the commission rate change interval. In case the rate is not
+// within the rate bounds, the amendment also widens the rate bound in effect at the time of the
+// change just enough to include the rate, postponing the change until the rate bound lead has
+// passed if needed. An error is returned in case the resulting amendment would be rejected.
+func (cs *CommissionSchedule) MinimalRateAmendment(rules *CommissionScheduleRules, now beacon.EpochTime, rate *quantity.Quantity, epoch beacon.EpochTime) (*CommissionSchedule, error) {
+	if epoch == beacon.EpochInvalid {
+		return nil, fmt.Errorf("invalid epoch")
+	}
+	interval := max(rules.RateChangeInterval, 1)
+	alignUp := func(e beacon.EpochTime) beacon.EpochTime {
+		return (e + interval - 1) / interval * interval
+	}
+
+	current := cs.clone()
+	current.Prune(now)
+
+	start := alignUp(max(epoch, now+1))
+	amendment := &CommissionSchedule{
+		Rates: []CommissionRateStep{{Start: start, Rate: *rate.Clone()}},
+	}
+	if !current.boundsInclude(rate, start) {
+		if len(current.Bounds) != 0 {
+			// Bounds of an existing schedule can only be changed RateBoundLead in advance.
+			start = max(start, alignUp(now+1+rules.RateBoundLead))
+			amendment.Rates[0].Start = start
+		}
+
+		bound := CommissionRateBoundStep{
+			Start:   start,
+			RateMin: *rate.Clone(),
+			RateMax: *rate.Clone(),
+		}
+		if step := current.boundAt(start); step != nil {
+			if step.RateMin.Cmp(rate) < 0 {
+				bound.RateMin = *step.RateMin.Clone()
+			}
+			if step.RateMax.Cmp(rate) > 0 {
+				bound.RateMax = *step.RateMax.Clone()
+			}
+		}
+		amendment.Bounds = []CommissionRateBoundStep{bound}
+	}
+
+	if _, err := cs.ValidateAmendment(amendment, rules, now); err != nil {
+		return nil, err
+	}
+	return amendment, nil
+}
+
+// boundAt returns the rate bound step in effect at the given epoch or nil if no step is.
+func (cs *CommissionSchedule) boundAt(epoch beacon.EpochTime) *CommissionRateBoundStep {
+	var bound *CommissionRateBoundStep
+	for i := range cs.Bounds {
+		if cs.Bounds[i].Start > epoch {
+			break
+		}
+		bound = &cs.Bounds[i]
+	}
+	return bound
+}
+
+// boundsInclude checks whether all rate bounds in effect at or after the given epoch include
+// the given rate.
+func (cs *CommissionSchedule) boundsInclude(rate *quantity.Quantity, epoch beacon.EpochTime) bool {
+	if cs.boundAt(epoch) == nil {
+		return false
+	}
+	for i := range cs.Bounds {
+		step := &cs.Bounds[i]
+		if i+1 < len(cs.Bounds) && cs.Bounds[i+1].Start <= epoch {
+			// Step is no longer in effect at the given epoch.
+			continue
+		}
+		if rate.Cmp(&step.RateMin) < 0 || rate.Cmp(&step.RateMax) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (cs *CommissionSchedule) clone() *CommissionSchedule {
+	return &CommissionSchedule{
+		Rates:  slices.Clone(cs.Rates),
+		Bounds: slices.Clone(cs.Bounds),
+	}
+}
+
+// CommissionScheduleQuery is a commission schedule amendment validation query.
+type CommissionScheduleQuery struct {
+	Height    int64              `json:"height"`
+	Owner     Address            `json:"owner"`
+	Amendment CommissionSchedule `json:"amendment"`
+
+	// Epoch is the epoch at which the amendment is validated. If not set, the epoch at the
+	// given height is used.
+	Epoch beacon.EpochTime `json:"epoch,omitempty"`
+}
+
+// ValidateCommissionScheduleAmendment checks whether the given commission schedule amendment
+// would be accepted for the given account at the given epoch under the given consensus
+// parameters and returns the resulting commission schedule.
+//
+// The checks mirror the ones performed by the consensus layer when processing commission
+// schedule amendment transactions. The account is not modified.
+func ValidateCommissionScheduleAmendment(
+	params *ConsensusParameters,
+	epoch beacon.EpochTime,
+	acct *Account,
+	amendment *CommissionSchedule,
+) (*CommissionSchedule, error) {
+	entityThreshold := params.Thresholds[KindEntity]
+	validatorThreshold := params.Thresholds[KindNodeValidator]
+	requiredStake := entityThreshold.Clone()
+	if err := requiredStake.Add(&validatorThreshold); err != nil {
+		return nil, err
+	}
+	if acct.Escrow.Active.Balance.Cmp(requiredStake) < 0 {
+		return nil, ErrInsufficientStake
+	}
+
+	schedule, err := acct.Escrow.CommissionSchedule.ValidateAmendment(amendment, &params.CommissionScheduleRules, epoch)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidArgument, err)
+	}
+	return schedule, nil
+}
+
 // CurrentRate returns the rate at the latest rate step that has started or nil if no step has started.
 func (cs *CommissionSchedule) CurrentRate(now beacon.EpochTime) *quantity.Quantity {
 	var latestStartedStep *CommissionRateStep
@@ -875,6 +875,87 @@ func TestCommissionSchedule(t *testing.T) {
 	require.Equal(t, beacon.EpochTime(10), cs.Bounds[0].Start, "prune 10 bounds start")
 }
 
+func TestCommissionScheduleAmendmentHelpers(t *testing.T) {
+	require := require.New(t)
+
+	rules := CommissionScheduleRules{
+		RateChangeInterval: 10,
+		RateBoundLead:      30,
+		MaxRateSteps:       4,
+		MaxBoundSteps:      4,
+	}
+
+	// Initial schedule requires both a rate and a bound.
+	var empty CommissionSchedule
+	amendment, err := empty.MinimalRateAmendment(&rules, 5, mustInitQuantityP(t, 10_000), 0)
+	require.NoError(err, "MinimalRateAmendment initial")
+	require.Equal([]CommissionRateStep{{Start: 10, Rate: mustInitQuantity(t, 10_000)}}, amendment.Rates)
+	require.Equal([]CommissionRateBoundStep{{Start: 10, RateMin: mustInitQuantity(t, 10_000), RateMax: mustInitQuantity(t, 10_000)}}, amendment.Bounds)
+	schedule, err := empty.ValidateAmendment(amendment, &rules, 5)
+	require.NoError(err, "ValidateAmendment initial")
+	require.True(empty.IsEmpty(), "ValidateAmendment should not modify the schedule")
+	require.Len(schedule.Rates, 1)
+
+	cs := CommissionSchedule{
+		Rates: []CommissionRateStep{
+			{Start: 0, Rate: mustInitQuantity(t, 10_000)},
+			{Start: 50, Rate: mustInitQuantity(t, 15_000)},
+		},
+		Bounds: []CommissionRateBoundStep{
+			{Start: 0, RateMin: mustInitQuantity(t, 5_000), RateMax: mustInitQuantity(t, 20_000)},
+		},
+	}
+	original := cs.clone()
+
+	// Rate within bounds, target epoch gets aligned.
+	amendment, err = cs.MinimalRateAmendment(&rules, 5, mustInitQuantityP(t, 20_000), 12)
+	require.NoError(err, "MinimalRateAmendment within bounds")
+	require.Equal([]CommissionRateStep{{Start: 20, Rate: mustInitQuantity(t, 20_000)}}, amendment.Rates)
+	require.Empty(amendment.Bounds, "no bound change should be needed")
+	schedule, err = cs.ValidateAmendment(amendment, &rules, 5)
+	require.NoError(err, "ValidateAmendment")
+	require.Len(schedule.Rates, 2, "later rate steps should be replaced")
+	require.Equal(original, &cs, "ValidateAmendment should not modify the schedule")
+
+	// Rate above the bound requires widening the bound with the rate bound lead.
+	amendment, err = cs.MinimalRateAmendment(&rules, 5, mustInitQuantityP(t, 30_000), 12)
+	require.NoError(err, "MinimalRateAmendment outside bounds")
+	require.Equal([]CommissionRateStep{{Start: 40, Rate: mustInitQuantity(t, 30_000)}}, amendment.Rates)
+	require.Equal([]CommissionRateBoundStep{{Start: 40, RateMin: mustInitQuantity(t, 5_000), RateMax: mustInitQuantity(t, 30_000)}}, amendment.Bounds)
+	_, err = cs.ValidateAmendment(amendment, &rules, 5)
+	require.NoError(err, "ValidateAmendment")
+
+	// Changing the rate right away outside of the bounds is rejected.
+	_, err = cs.ValidateAmendment(&CommissionSchedule{
+		Rates: []CommissionRateStep{{Start: 20, Rate: mustInitQuantity(t, 30_000)}},
+	}, &rules, 5)
+	requireErrorShowDiagnostic(t, err, "rate outside of bounds")
+
+	// Rates over unity can never be accepted.
+	_, err = cs.MinimalRateAmendment(&rules, 5, mustInitQuantityP(t, 200_000), 12)
+	requireErrorShowDiagnostic(t, err, "rate over unity")
+
+	// Account-level validation also checks the stake requirement.
+	params := ConsensusParameters{
+		Thresholds: map[ThresholdKind]quantity.Quantity{
+			KindEntity:        mustInitQuantity(t, 100),
+			KindNodeValidator: mustInitQuantity(t, 100),
+		},
+		CommissionScheduleRules: rules,
+	}
+	var acct Account
+	acct.Escrow.CommissionSchedule = cs
+	_, err = ValidateCommissionScheduleAmendment(&params, 5, &acct, amendment)
+	require.ErrorIs(err, ErrInsufficientStake)
+	acct.Escrow.Active.Balance = mustInitQuantity(t, 200)
+	_, err = ValidateCommissionScheduleAmendment(&params, 5, &acct, amendment)
+	require.NoError(err, "ValidateCommissionScheduleAmendment")
+	_, err = ValidateCommissionScheduleAmendment(&params, 5, &acct, &CommissionSchedule{
+		Rates: []CommissionRateStep{{Start: 20, Rate: mustInitQuantity(t, 30_000)}},
+	})
+	require.ErrorIs(err, ErrInvalidArgument)
+}
+
 func TestPrettyPrintCommissionRateStep(t *testing.T) {
 	require := require.New(t)
 
@@ -8,6 +8,7 @@ import (
 	cmnGrpc "github.com/oasisprotocol/oasis-core/go/common/grpc"
 	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	"github.com/oasisprotocol/oasis-core/go/staking/api/token"
 )
 
 var (
@@ -18,6 +19,8 @@ var (
 	methodTokenSymbol = serviceName.NewMethod("TokenSymbol", int64(0))
 	// methodTokenValueExponent is the TokenValueExponent method.
 	methodTokenValueExponent = serviceName.NewMethod("TokenValueExponent", int64(0))
+	// methodTokenMetadata is the TokenMetadata method.
+	methodTokenMetadata = serviceName.NewMethod("TokenMetadata", int64(0))
 	// methodTotalSupply is the TotalSupply method.
 	methodTotalSupply = serviceName.NewMethod("TotalSupply", int64(0))
 	// methodCommonPool is the CommonPool method.
@@ -48,12 +51,22 @@ var (
 	methodDebondingDelegationsTo = serviceName.NewMethod("DebondingDelegationsTo", OwnerQuery{})
 	// methodAllowance is the Allowance method.
 	methodAllowance = serviceName.NewMethod("Allowance", AllowanceQuery{})
+	// methodScheduledTransfersFrom is the ScheduledTransfersFrom method.
+	methodScheduledTransfersFrom = serviceName.NewMethod("ScheduledTransfersFrom", OwnerQuery{})
+	// methodScheduledTransfersTo is the ScheduledTransfersTo method.
+	methodScheduledTransfersTo = serviceName.NewMethod("ScheduledTransfersTo", OwnerQuery{})
+	// methodSimulateSlash is the SimulateSlash method.
+	methodSimulateSlash = serviceName.NewMethod("SimulateSlash", SlashSimulationQuery{})
+	// methodValidateCommissionSchedule is the ValidateCommissionSchedule method.
+	methodValidateCommissionSchedule = serviceName.NewMethod("ValidateCommissionSchedule", CommissionScheduleQuery{})
 	// methodStateToGenesis is the StateToGenesis method.
 	methodStateToGenesis = serviceName.NewMethod("StateToGenesis", int64(0))
 	// methodConsensusParameters is the ConsensusParameters method.
 	methodConsensusParameters = serviceName.NewMethod("ConsensusParameters", int64(0))
 	// methodGetEvents is the GetEvents method.
 	methodGetEvents = serviceName.NewMethod("GetEvents", int64(0))
+	// methodGetAccountEvents is the GetAccountEvents method.
+	methodGetAccountEvents = serviceName.NewMethod("GetAccountEvents", AccountEventsQuery{})
 
 	// methodWatchEvents is the WatchEvents method.
 	methodWatchEvents = serviceName.NewMethod("WatchEvents", nil)
@@ -71,6 +84,10 @@ var (
 				MethodName: methodTokenValueExponent.ShortName(),
 				Handler:    handlerTokenValueExponent,
 			},
+			{
+				MethodName: methodTokenMetadata.ShortName(),
+				Handler:    handlerTokenMetadata,
+			},
 			{
 				MethodName: methodTotalSupply.ShortName(),
 				Handler:    handlerTotalSupply,
@@ -131,6 +148,22 @@ var (
 				MethodName: methodAllowance.ShortName(),
 				Handler:    handlerAllowance,
 			},
+			{
+				MethodName: methodScheduledTransfersFrom.ShortName(),
+				Handler:    handlerScheduledTransfersFrom,
+			},
+			{
+				MethodName: methodScheduledTransfersTo.ShortName(),
+				Handler:    handlerScheduledTransfersTo,
+			},
+			{
+				MethodName: methodSimulateSlash.ShortName(),
+				Handler:    handlerSimulateSlash,
+			},
+			{
+				MethodName: methodValidateCommissionSchedule.ShortName(),
+				Handler:    handlerValidateCommissionSchedule,
+			},
 			{
 				MethodName: methodStateToGenesis.ShortName(),
 				Handler:    handlerStateToGenesis,
@@ -143,6 +176,10 @@ var (
 				MethodName: methodGetEvents.ShortName(),
 				Handler:    handlerGetEvents,
 			},
+			{
+				MethodName: methodGetAccountEvents.ShortName(),
+				Handler:    handlerGetAccountEvents,
+			},
 		},
 		Streams: []grpc.StreamDesc{
 			{
@@ -200,6 +237,29 @@ func handlerTokenValueExponent(
 	return interceptor(ctx, height, info, handler)
 }
 
+func handlerTokenMetadata(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var height int64
+	if err := dec(&height); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).TokenMetadata(ctx, height)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodTokenMetadata.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).TokenMetadata(ctx, req.(int64))
+	}
+	return interceptor(ctx, height, info, handler)
+}
+
 func handlerTotalSupply(
 	srv interface{},
 	ctx context.Context,
@@ -545,6 +605,98 @@ func handlerAllowance(
 	return interceptor(ctx, &query, info, handler)
 }
 
+func handlerScheduledTransfersFrom(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var query OwnerQuery
+	if err := dec(&query); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).ScheduledTransfersFrom(ctx, &query)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodScheduledTransfersFrom.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).ScheduledTransfersFrom(ctx, req.(*OwnerQuery))
+	}
+	return interceptor(ctx, &query, info, handler)
+}
+
+func handlerScheduledTransfersTo(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var query OwnerQuery
+	if err := dec(&query); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).ScheduledTransfersTo(ctx, &query)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodScheduledTransfersTo.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).ScheduledTransfersTo(ctx, req.(*OwnerQuery))
+	}
+	return interceptor(ctx, &query, info, handler)
+}
+
+func handlerSimulateSlash(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var query SlashSimulationQuery
+	if err := dec(&query); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).SimulateSlash(ctx, &query)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodSimulateSlash.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).SimulateSlash(ctx, req.(*SlashSimulationQuery))
+	}
+	return interceptor(ctx, &query, info, handler)
+}
+
+func handlerValidateCommissionSchedule(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var query CommissionScheduleQuery
+	if err := dec(&query); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).ValidateCommissionSchedule(ctx, &query)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodValidateCommissionSchedule.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).ValidateCommissionSchedule(ctx, req.(*CommissionScheduleQuery))
+	}
+	return interceptor(ctx, &query, info, handler)
+}
+
 func handlerStateToGenesis(
 	srv interface{},
 	ctx context.Context,
@@ -614,6 +766,29 @@ func handlerGetEvents(
 	return interceptor(ctx, height, info, handler)
 }
 
+func handlerGetAccountEvents(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var query AccountEventsQuery
+	if err := dec(&query); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).GetAccountEvents(ctx, &query)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetAccountEvents.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).GetAccountEvents(ctx, req.(*AccountEventsQuery))
+	}
+	return interceptor(ctx, &query, info, handler)
+}
+
 func handlerWatchEvents(srv interface{}, stream grpc.ServerStream) error {
 	if err := stream.RecvMsg(nil); err != nil {
 		return err
@@ -667,6 +842,14 @@ func (c *stakingClient) TokenValueExponent(ctx context.Context, height int64) (u
 	return rsp, nil
 }
 
+func (c *stakingClient) TokenMetadata(ctx context.Context, height int64) (*token.Metadata, error) {
+	var rsp token.Metadata
+	if err := c.conn.Invoke(ctx, methodTokenMetadata.FullName(), height, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *stakingClient) TotalSupply(ctx context.Context, height int64) (*quantity.Quantity, error) {
 	var rsp quantity.Quantity
 	if err := c.conn.Invoke(ctx, methodTotalSupply.FullName(), height, &rsp); err != nil {
@@ -787,6 +970,38 @@ func (c *stakingClient) Allowance(ctx context.Context, query *AllowanceQuery) (*
 	return &rsp, nil
 }
 
+func (c *stakingClient) ScheduledTransfersFrom(ctx context.Context, query *OwnerQuery) ([]*ScheduledTransfer, error) {
+	var rsp []*ScheduledTransfer
+	if err := c.conn.Invoke(ctx, methodScheduledTransfersFrom.FullName(), query, &rsp); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
+func (c *stakingClient) ScheduledTransfersTo(ctx context.Context, query *OwnerQuery) ([]*ScheduledTransfer, error) {
+	var rsp []*ScheduledTransfer
+	if err := c.conn.Invoke(ctx, methodScheduledTransfersTo.FullName(), query, &rsp); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
+func (c *stakingClient) SimulateSlash(ctx context.Context, query *SlashSimulationQuery) (*SlashSimulation, error) {
+	var rsp SlashSimulation
+	if err := c.conn.Invoke(ctx, methodSimulateSlash.FullName(), query, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
+func (c *stakingClient) ValidateCommissionSchedule(ctx context.Context, query *CommissionScheduleQuery) (*CommissionSchedule, error) {
+	var rsp CommissionSchedule
+	if err := c.conn.Invoke(ctx, methodValidateCommissionSchedule.FullName(), query, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *stakingClient) StateToGenesis(ctx context.Context, height int64) (*Genesis, error) {
 	var rsp Genesis
 	if err := c.conn.Invoke(ctx, methodStateToGenesis.FullName(), height, &rsp); err != nil {
@@ -811,6 +1026,14 @@ func (c *stakingClient) GetEvents(ctx context.Context, height int64) ([]*Event,
 	return rsp, nil
 }
 
+func (c *stakingClient) GetAccountEvents(ctx context.Context, query *AccountEventsQuery) (*AccountEvents, error) {
+	var rsp AccountEvents
+	if err := c.conn.Invoke(ctx, methodGetAccountEvents.FullName(), query, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *stakingClient) WatchEvents(ctx context.Context) (<-chan *Event, pubsub.ClosableSubscription, error) {
 	ctx, sub := pubsub.NewContextSubscription(ctx)
 
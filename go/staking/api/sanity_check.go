@@ -44,6 +44,11 @@ func (p *ConsensusParameters) SanityCheck() error {
 		return fmt.Errorf("fee split proportions are all zero")
 	}
 
+	// Dust threshold.
+	if !p.DustThreshold.IsValid() {
+		return fmt.Errorf("dust threshold has invalid value")
+	}
+
 	// MinCommissionRate bound.
 	if p.CommissionScheduleRules.MinCommissionRate.Cmp(CommissionRateDenominator) > 0 {
 		return fmt.Errorf("minimum commission %v/%v over unity", p.CommissionScheduleRules, CommissionRateDenominator)
@@ -80,6 +85,8 @@ func (c *ConsensusParameterChanges) SanityCheck() error {
 		c.DisableDelegation == nil &&
 		c.AllowEscrowMessages == nil &&
 		c.MaxAllowances == nil &&
+		c.MaxTransferBatchSize == nil &&
+		c.DustThreshold == nil &&
 		c.FeeSplitWeightPropose == nil &&
 		c.FeeSplitWeightVote == nil &&
 		c.FeeSplitWeightNextPropose == nil &&
@@ -143,6 +150,14 @@ func SanityCheckAccount(
 			return fmt.Errorf("staking: sanity check failed: account %s allowance is greater than total supply for beneficiary %s", addr, beneficiary)
 		}
 	}
+	for beneficiary, limits := range acct.General.AllowanceLimits {
+		if _, ok := acct.General.Allowances[beneficiary]; !ok {
+			return fmt.Errorf("staking: sanity check failed: account %s has allowance limits without an allowance for beneficiary %s", addr, beneficiary)
+		}
+		if err := limits.SanityCheck(); err != nil {
+			return fmt.Errorf("staking: sanity check failed: account %s allowance limits are invalid for beneficiary %s: %w", addr, beneficiary, err)
+		}
+	}
 
 	return nil
 }
@@ -337,12 +352,15 @@ func (g *Genesis) SanityCheck(now beacon.EpochTime) error { // nolint: gocyclo
 			return fmt.Errorf("staking: non-empty stake accumulator in genesis")
 		}
 	}
+	if err := SanityCheckScheduledTransfers(&total, g.ScheduledTransfers); err != nil {
+		return err
+	}
 	_ = total.Add(&g.GovernanceDeposits)
 	_ = total.Add(&g.CommonPool)
 	_ = total.Add(&g.LastBlockFees)
 	if total.Cmp(&g.TotalSupply) != 0 {
 		return fmt.Errorf(
-			"staking: sanity check failed: balances in accounts, plus governance deposits, plus common pool, plus last block fees (%s), does not add up to total supply (%s)",
+			"staking: sanity check failed: balances in accounts, plus scheduled transfers, plus governance deposits, plus common pool, plus last block fees (%s), does not add up to total supply (%s)",
 			total.String(), g.TotalSupply.String(),
 		)
 	}
@@ -0,0 +1,199 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/prettyprint"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	"github.com/oasisprotocol/oasis-core/go/staking/api/token"
+)
+
+var (
+	_ prettyprint.PrettyPrinter = (*TransferAt)(nil)
+	_ prettyprint.PrettyPrinter = (*CancelScheduledTransfer)(nil)
+)
+
+// TransferAt is a stake transfer which is released to the destination at a
+// future epoch.
+//
+// Until released, the transferred amount is held in the scheduled transfers
+// pool and can be cancelled by the sender.
+type TransferAt struct {
+	To     Address           `json:"to"`
+	Amount quantity.Quantity `json:"amount"`
+
+	// ReleaseEpoch is the epoch at which the amount is released to the destination.
+	ReleaseEpoch beacon.EpochTime `json:"release_epoch"`
+	// CancelDeadline is the epoch starting at which the transfer can no longer be
+	// cancelled by the sender. If not set, the transfer can be cancelled until
+	// it is released.
+	CancelDeadline beacon.EpochTime `json:"cancel_deadline,omitempty"`
+}
+
+// ValidateBasic performs basic scheduled transfer validity checks.
+func (t *TransferAt) ValidateBasic() error {
+	if !t.To.IsValid() {
+		return fmt.Errorf("%w: invalid destination address", ErrInvalidArgument)
+	}
+	if t.ReleaseEpoch == beacon.EpochInvalid {
+		return fmt.Errorf("%w: invalid release epoch", ErrInvalidArgument)
+	}
+	if t.CancelDeadline > t.ReleaseEpoch {
+		return fmt.Errorf("%w: cancel deadline after release epoch", ErrInvalidArgument)
+	}
+	return nil
+}
+
+// PrettyPrint writes a pretty-printed representation of TransferAt to the given
+// writer.
+func (t TransferAt) PrettyPrint(ctx context.Context, prefix string, w io.Writer) {
+	fmt.Fprintf(w, "%sTo:              %s\n", prefix, t.To)
+
+	fmt.Fprintf(w, "%sAmount:          ", prefix)
+	token.PrettyPrintAmount(ctx, t.Amount, w)
+	fmt.Fprintln(w)
+
+	fmt.Fprintf(w, "%sRelease epoch:   %d\n", prefix, t.ReleaseEpoch)
+	if t.CancelDeadline != 0 {
+		fmt.Fprintf(w, "%sCancel deadline: %d\n", prefix, t.CancelDeadline)
+	}
+}
+
+// PrettyType returns a representation of TransferAt that can be used for pretty
+// printing.
+func (t TransferAt) PrettyType() (interface{}, error) {
+	return t, nil
+}
+
+// NewTransferAtTx creates a new scheduled transfer transaction.
+func NewTransferAtTx(nonce uint64, fee *transaction.Fee, xfer *TransferAt) *transaction.Transaction {
+	return transaction.NewTransaction(nonce, fee, MethodTransferAt, xfer)
+}
+
+// CancelScheduledTransfer is a cancellation of a pending scheduled transfer.
+type CancelScheduledTransfer struct {
+	ID uint64 `json:"id"`
+}
+
+// PrettyPrint writes a pretty-printed representation of CancelScheduledTransfer
+// to the given writer.
+func (c CancelScheduledTransfer) PrettyPrint(_ context.Context, prefix string, w io.Writer) {
+	fmt.Fprintf(w, "%sID: %d\n", prefix, c.ID)
+}
+
+// PrettyType returns a representation of CancelScheduledTransfer that can be
+// used for pretty printing.
+func (c CancelScheduledTransfer) PrettyType() (interface{}, error) {
+	return c, nil
+}
+
+// NewCancelScheduledTransferTx creates a new cancel scheduled transfer transaction.
+func NewCancelScheduledTransferTx(nonce uint64, fee *transaction.Fee, cancel *CancelScheduledTransfer) *transaction.Transaction {
+	return transaction.NewTransaction(nonce, fee, MethodCancelScheduledTransfer, cancel)
+}
+
+// ScheduledTransfer is a pending scheduled transfer.
+type ScheduledTransfer struct {
+	// ID is the unique scheduled transfer identifier.
+	ID uint64 `json:"id"`
+	// From is the address of the sender.
+	From Address `json:"from"`
+	// To is the address of the destination.
+	To Address `json:"to"`
+	// Amount is the amount held until the transfer is released.
+	Amount quantity.Quantity `json:"amount"`
+	// ReleaseEpoch is the epoch at which the amount is released to the destination.
+	ReleaseEpoch beacon.EpochTime `json:"release_epoch"`
+	// CancelDeadline is the epoch starting at which the transfer can no longer be
+	// cancelled by the sender.
+	CancelDeadline beacon.EpochTime `json:"cancel_deadline,omitempty"`
+}
+
+// IsCancellable returns true iff the transfer can still be cancelled at the given epoch.
+func (st *ScheduledTransfer) IsCancellable(epoch beacon.EpochTime) bool {
+	if epoch >= st.ReleaseEpoch {
+		return false
+	}
+	return st.CancelDeadline == 0 || epoch < st.CancelDeadline
+}
+
+// SanityCheckScheduledTransfers performs a sanity check on the given scheduled
+// transfers and adds their amounts to the given total.
+func SanityCheckScheduledTransfers(total *quantity.Quantity, transfers []*ScheduledTransfer) error {
+	ids := make(map[uint64]struct{}, len(transfers))
+	for _, st := range transfers {
+		if _, ok := ids[st.ID]; ok {
+			return fmt.Errorf("staking: sanity check failed: duplicate scheduled transfer %d", st.ID)
+		}
+		ids[st.ID] = struct{}{}
+
+		if !st.From.IsValid() || st.From.IsReserved() {
+			return fmt.Errorf("staking: sanity check failed: scheduled transfer %d: invalid sender", st.ID)
+		}
+		if !st.To.IsValid() || st.To.IsReserved() {
+			return fmt.Errorf("staking: sanity check failed: scheduled transfer %d: invalid destination", st.ID)
+		}
+		if !st.Amount.IsValid() || st.Amount.IsZero() {
+			return fmt.Errorf("staking: sanity check failed: scheduled transfer %d: invalid amount", st.ID)
+		}
+		if st.CancelDeadline > st.ReleaseEpoch {
+			return fmt.Errorf("staking: sanity check failed: scheduled transfer %d: cancel deadline after release epoch", st.ID)
+		}
+		_ = total.Add(&st.Amount)
+	}
+	return nil
+}
+
+// ScheduledTransferEvent is a scheduled transfer event.
+type ScheduledTransferEvent struct {
+	Schedule *ScheduleTransferEvent         `json:"schedule,omitempty"`
+	Release  *ReleaseScheduledTransferEvent `json:"release,omitempty"`
+	Cancel   *CancelScheduledTransferEvent  `json:"cancel,omitempty"`
+}
+
+// ScheduleTransferEvent is the event emitted when a transfer is scheduled.
+type ScheduleTransferEvent struct {
+	ID             uint64            `json:"id"`
+	From           Address           `json:"from"`
+	To             Address           `json:"to"`
+	Amount         quantity.Quantity `json:"amount"`
+	ReleaseEpoch   beacon.EpochTime  `json:"release_epoch"`
+	CancelDeadline beacon.EpochTime  `json:"cancel_deadline,omitempty"`
+}
+
+// EventKind returns a string representation of this event's kind.
+func (e *ScheduleTransferEvent) EventKind() string {
+	return "schedule_transfer"
+}
+
+// ReleaseScheduledTransferEvent is the event emitted when a scheduled transfer
+// is released to its destination.
+type ReleaseScheduledTransferEvent struct {
+	ID     uint64            `json:"id"`
+	From   Address           `json:"from"`
+	To     Address           `json:"to"`
+	Amount quantity.Quantity `json:"amount"`
+}
+
+// EventKind returns a string representation of this event's kind.
+func (e *ReleaseScheduledTransferEvent) EventKind() string {
+	return "release_scheduled_transfer"
+}
+
+// CancelScheduledTransferEvent is the event emitted when a scheduled transfer
+// is cancelled and the amount is returned to the sender.
+type CancelScheduledTransferEvent struct {
+	ID     uint64            `json:"id"`
+	From   Address           `json:"from"`
+	To     Address           `json:"to"`
+	Amount quantity.Quantity `json:"amount"`
+}
+
+// EventKind returns a string representation of this event's kind.
+func (e *CancelScheduledTransferEvent) EventKind() string {
+	return "cancel_scheduled_transfer"
+}
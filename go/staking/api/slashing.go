@@ -2,6 +2,7 @@ package api
 
 import (
 	"fmt"
+	"math"
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
@@ -30,6 +31,9 @@ const (
 	SlashRuntimeEquivocation SlashReason = 0x81
 	// SlashRuntimeLiveness is slashing due to not doing the required work.
 	SlashRuntimeLiveness SlashReason = 0x82
+	// SlashRuntimeRepeatedDiscrepancy is slashing due to causing more executor
+	// discrepancies in an epoch than allowed by the runtime.
+	SlashRuntimeRepeatedDiscrepancy SlashReason = 0x83
 
 	// SlashConsensusEquivocationName is the string representation of SlashConsensusEquivocation.
 	SlashConsensusEquivocationName = "consensus-equivocation"
@@ -41,6 +45,8 @@ const (
 	SlashRuntimeEquivocationName = "runtime-equivocation"
 	// SlashRuntimeLivenessName is the string representation of SlashRuntimeLiveness.
 	SlashRuntimeLivenessName = "runtime-liveness"
+	// SlashRuntimeRepeatedDiscrepancyName is the string representation of SlashRuntimeRepeatedDiscrepancy.
+	SlashRuntimeRepeatedDiscrepancyName = "runtime-repeated-discrepancy"
 )
 
 // String returns a string representation of a SlashReason.
@@ -61,6 +67,8 @@ func (s SlashReason) checkedString() (string, error) {
 		return SlashRuntimeEquivocationName, nil
 	case SlashRuntimeLiveness:
 		return SlashRuntimeLivenessName, nil
+	case SlashRuntimeRepeatedDiscrepancy:
+		return SlashRuntimeRepeatedDiscrepancyName, nil
 	default:
 		return "[unknown slash reason]", fmt.Errorf("unknown slash reason: %d", s)
 	}
@@ -89,6 +97,8 @@ func (s *SlashReason) UnmarshalText(text []byte) error {
 		*s = SlashRuntimeEquivocation
 	case SlashRuntimeLivenessName:
 		*s = SlashRuntimeLiveness
+	case SlashRuntimeRepeatedDiscrepancyName:
+		*s = SlashRuntimeRepeatedDiscrepancy
 	default:
 		return fmt.Errorf("invalid slash reason: %s", string(text))
 	}
@@ -100,3 +110,165 @@ type Slash struct {
 	Amount         quantity.Quantity `json:"amount"`
 	FreezeInterval beacon.EpochTime  `json:"freeze_interval"`
 }
+
+// SlashSimulationQuery is a slashing simulation query.
+type SlashSimulationQuery struct {
+	Height int64       `json:"height"`
+	Owner  Address     `json:"owner"`
+	Reason SlashReason `json:"reason"`
+}
+
+// SlashSimulation is the outcome of a hypothetical slashing of an escrow account under the
+// current consensus parameters.
+type SlashSimulation struct {
+	// Owner is the address of the slashed escrow account.
+	Owner Address `json:"owner"`
+	// Reason is the simulated slashing reason.
+	Reason SlashReason `json:"reason"`
+	// Penalty is the penalty configured for the given reason.
+	Penalty Slash `json:"penalty"`
+
+	// FreezeEndTime is the epoch until which the offending nodes would be frozen. Zero means
+	// that the nodes would not be frozen.
+	FreezeEndTime beacon.EpochTime `json:"freeze_end_time,omitempty"`
+
+	// ActiveSlashed is the amount slashed from the active escrow balance.
+	ActiveSlashed quantity.Quantity `json:"active_slashed"`
+	// DebondingSlashed is the amount slashed from the debonding escrow balance.
+	DebondingSlashed quantity.Quantity `json:"debonding_slashed"`
+
+	// Delegators contains the effect of the slashing on each affected delegator.
+	Delegators map[Address]*SlashedDelegator `json:"delegators,omitempty"`
+}
+
+// SlashedDelegator is the effect of slashing on a single delegator.
+type SlashedDelegator struct {
+	// ActiveBefore is the active delegation amount before slashing.
+	ActiveBefore quantity.Quantity `json:"active_before"`
+	// ActiveAfter is the active delegation amount after slashing.
+	ActiveAfter quantity.Quantity `json:"active_after"`
+	// DebondingBefore is the total debonding delegation amount before slashing.
+	DebondingBefore quantity.Quantity `json:"debonding_before"`
+	// DebondingAfter is the total debonding delegation amount after slashing.
+	DebondingAfter quantity.Quantity `json:"debonding_after"`
+}
+
+// SimulateSlash computes the outcome of slashing the given escrow account for the given reason
+// at the given epoch, without modifying any of the passed state.
+//
+// The computation mirrors the one performed by the consensus layer when slashing escrow
+// accounts. Runtime-specific penalties are not taken into account.
+func SimulateSlash(
+	params *ConsensusParameters,
+	epoch beacon.EpochTime,
+	owner Address,
+	reason SlashReason,
+	acct *Account,
+	delegations map[Address]*Delegation,
+	debondingDelegations map[Address][]*DebondingDelegation,
+) (*SlashSimulation, error) {
+	if _, err := reason.checkedString(); err != nil {
+		return nil, fmt.Errorf("%w: unknown slash reason", ErrInvalidArgument)
+	}
+
+	sim := &SlashSimulation{
+		Owner:   owner,
+		Reason:  reason,
+		Penalty: params.Slashing[reason],
+	}
+	if interval := sim.Penalty.FreezeInterval; interval > 0 {
+		// Check for overflow, in which case the nodes are frozen forever.
+		if math.MaxUint64-interval < epoch {
+			sim.FreezeEndTime = beacon.EpochInvalid
+		} else {
+			sim.FreezeEndTime = epoch + interval
+		}
+	}
+
+	total := acct.Escrow.Active.Balance.Clone()
+	if err := total.Add(&acct.Escrow.Debonding.Balance); err != nil {
+		return nil, fmt.Errorf("staking: account total balance: %w", err)
+	}
+	active, err := simulateSlashPool(&sim.ActiveSlashed, &acct.Escrow.Active, &sim.Penalty.Amount, total)
+	if err != nil {
+		return nil, fmt.Errorf("staking: failed slashing active escrow: %w", err)
+	}
+	debonding, err := simulateSlashPool(&sim.DebondingSlashed, &acct.Escrow.Debonding, &sim.Penalty.Amount, total)
+	if err != nil {
+		return nil, fmt.Errorf("staking: failed slashing debonding escrow: %w", err)
+	}
+	if sim.ActiveSlashed.IsZero() && sim.DebondingSlashed.IsZero() {
+		return sim, nil
+	}
+
+	sim.Delegators = make(map[Address]*SlashedDelegator)
+	delegator := func(addr Address) *SlashedDelegator {
+		d, ok := sim.Delegators[addr]
+		if !ok {
+			d = &SlashedDelegator{}
+			sim.Delegators[addr] = d
+		}
+		return d
+	}
+	for addr, del := range delegations {
+		before, err := acct.Escrow.Active.StakeForShares(&del.Shares)
+		if err != nil {
+			return nil, fmt.Errorf("staking: failed computing delegation amount: %w", err)
+		}
+		after, err := active.StakeForShares(&del.Shares)
+		if err != nil {
+			return nil, fmt.Errorf("staking: failed computing delegation amount: %w", err)
+		}
+
+		d := delegator(addr)
+		d.ActiveBefore = *before
+		d.ActiveAfter = *after
+	}
+	for addr, debDels := range debondingDelegations {
+		d := delegator(addr)
+		for _, debDel := range debDels {
+			before, err := acct.Escrow.Debonding.StakeForShares(&debDel.Shares)
+			if err != nil {
+				return nil, fmt.Errorf("staking: failed computing debonding delegation amount: %w", err)
+			}
+			after, err := debonding.StakeForShares(&debDel.Shares)
+			if err != nil {
+				return nil, fmt.Errorf("staking: failed computing debonding delegation amount: %w", err)
+			}
+			if err = d.DebondingBefore.Add(before); err != nil {
+				return nil, fmt.Errorf("staking: failed totalling debonding delegations: %w", err)
+			}
+			if err = d.DebondingAfter.Add(after); err != nil {
+				return nil, fmt.Errorf("staking: failed totalling debonding delegations: %w", err)
+			}
+		}
+	}
+
+	return sim, nil
+}
+
+// simulateSlashPool computes the amount slashed from the given share pool and returns the
+// share pool after slashing.
+func simulateSlashPool(dst *quantity.Quantity, p *SharePool, amount, total *quantity.Quantity) (*SharePool, error) {
+	pool := &SharePool{
+		Balance:     *p.Balance.Clone(),
+		TotalShares: *p.TotalShares.Clone(),
+	}
+	if total.IsZero() {
+		// Nothing to slash.
+		return pool, nil
+	}
+	// slashAmount = amount * p.Balance / total
+	slashAmount := p.Balance.Clone()
+	if err := slashAmount.Mul(amount); err != nil {
+		return nil, err
+	}
+	if err := slashAmount.Quo(total); err != nil {
+		return nil, err
+	}
+
+	if _, err := quantity.MoveUpTo(dst, &pool.Balance, slashAmount); err != nil {
+		return nil, err
+	}
+	return pool, nil
+}
@@ -4,6 +4,10 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
 )
 
 func TestSlashReason(t *testing.T) {
@@ -35,3 +39,83 @@ func TestSlashReason(t *testing.T) {
 	err = sr.UnmarshalText([]byte("invalid slash reason"))
 	require.Error(err, "UnmarshalText on invalid slash reason should error")
 }
+
+func TestSimulateSlash(t *testing.T) {
+	require := require.New(t)
+
+	owner := NewAddress(signature.NewPublicKey("aaafffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"))
+	delegatorA := NewAddress(signature.NewPublicKey("bbbfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"))
+	delegatorB := NewAddress(signature.NewPublicKey("cccfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"))
+
+	acct := &Account{
+		Escrow: EscrowAccount{
+			Active: SharePool{
+				Balance:     *quantity.NewFromUint64(300),
+				TotalShares: *quantity.NewFromUint64(300),
+			},
+			Debonding: SharePool{
+				Balance:     *quantity.NewFromUint64(100),
+				TotalShares: *quantity.NewFromUint64(100),
+			},
+		},
+	}
+	delegations := map[Address]*Delegation{
+		delegatorA: {Shares: *quantity.NewFromUint64(200)},
+		delegatorB: {Shares: *quantity.NewFromUint64(100)},
+	}
+	debDelegations := map[Address][]*DebondingDelegation{
+		delegatorB: {
+			{Shares: *quantity.NewFromUint64(60), DebondEndTime: 10},
+			{Shares: *quantity.NewFromUint64(40), DebondEndTime: 11},
+		},
+	}
+	params := &ConsensusParameters{
+		Slashing: map[SlashReason]Slash{
+			SlashConsensusEquivocation: {
+				Amount:         *quantity.NewFromUint64(100),
+				FreezeInterval: 10,
+			},
+			SlashConsensusLightClientAttack: {
+				FreezeInterval: beacon.EpochInvalid,
+			},
+		},
+	}
+
+	_, err := SimulateSlash(params, 5, owner, SlashReason(0xff), acct, delegations, debDelegations)
+	require.ErrorIs(err, ErrInvalidArgument, "unknown slash reason should fail")
+
+	// No penalty configured.
+	sim, err := SimulateSlash(params, 5, owner, SlashRuntimeLiveness, acct, delegations, debDelegations)
+	require.NoError(err, "SimulateSlash")
+	require.True(sim.ActiveSlashed.IsZero())
+	require.True(sim.DebondingSlashed.IsZero())
+	require.EqualValues(0, sim.FreezeEndTime)
+	require.Empty(sim.Delegators)
+
+	// Freeze interval overflow.
+	sim, err = SimulateSlash(params, 5, owner, SlashConsensusLightClientAttack, acct, delegations, debDelegations)
+	require.NoError(err, "SimulateSlash")
+	require.EqualValues(beacon.EpochInvalid, sim.FreezeEndTime)
+
+	sim, err = SimulateSlash(params, 5, owner, SlashConsensusEquivocation, acct, delegations, debDelegations)
+	require.NoError(err, "SimulateSlash")
+	require.Equal(owner, sim.Owner)
+	require.EqualValues(15, sim.FreezeEndTime)
+	require.EqualValues(*quantity.NewFromUint64(75), sim.ActiveSlashed)
+	require.EqualValues(*quantity.NewFromUint64(25), sim.DebondingSlashed)
+	require.Len(sim.Delegators, 2)
+	require.EqualValues(&SlashedDelegator{
+		ActiveBefore: *quantity.NewFromUint64(200),
+		ActiveAfter:  *quantity.NewFromUint64(150),
+	}, sim.Delegators[delegatorA])
+	require.EqualValues(&SlashedDelegator{
+		ActiveBefore:    *quantity.NewFromUint64(100),
+		ActiveAfter:     *quantity.NewFromUint64(75),
+		DebondingBefore: *quantity.NewFromUint64(100),
+		DebondingAfter:  *quantity.NewFromUint64(75),
+	}, sim.Delegators[delegatorB])
+
+	// The account should not be modified.
+	require.EqualValues(*quantity.NewFromUint64(300), acct.Escrow.Active.Balance)
+	require.EqualValues(*quantity.NewFromUint64(100), acct.Escrow.Debonding.Balance)
+}
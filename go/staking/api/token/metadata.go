@@ -0,0 +1,33 @@
+package token
+
+import (
+	"context"
+	"strings"
+
+	"github.com/oasisprotocol/oasis-core/go/common/prettyprint"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+)
+
+// Metadata is the token metadata needed to display amounts in tokens instead of base units.
+type Metadata struct {
+	// Symbol is the token's ticker symbol.
+	Symbol string `json:"symbol"`
+	// Decimals is the token's value base-10 exponent, i.e. 1 token = 10**Decimals base units.
+	Decimals uint8 `json:"decimals"`
+}
+
+// NewContext returns a new context carrying the given token metadata so that amounts
+// pretty-printed with the returned context are displayed in tokens.
+func NewContext(ctx context.Context, m *Metadata) context.Context {
+	ctx = context.WithValue(ctx, prettyprint.ContextKeyTokenSymbol, m.Symbol)
+	return context.WithValue(ctx, prettyprint.ContextKeyTokenValueExponent, m.Decimals)
+}
+
+// FormatAmount returns a human-readable representation of the given amount in base units.
+//
+// The amount is formatted in tokens if the token metadata is valid and in base units otherwise.
+func (m *Metadata) FormatAmount(amount quantity.Quantity) string {
+	var b strings.Builder
+	PrettyPrintAmount(NewContext(context.Background(), m), amount, &b)
+	return b.String()
+}
@@ -0,0 +1,35 @@
+package token
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/prettyprint"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+)
+
+func TestMetadata(t *testing.T) {
+	require := require.New(t)
+
+	meta := &Metadata{Symbol: "TEST", Decimals: 18}
+	ctx := NewContext(context.Background(), meta)
+	require.Equal("TEST", ctx.Value(prettyprint.ContextKeyTokenSymbol))
+	require.Equal(uint8(18), ctx.Value(prettyprint.ContextKeyTokenValueExponent))
+
+	for _, tc := range []struct {
+		meta     *Metadata
+		amount   *quantity.Quantity
+		expected string
+	}{
+		{meta, quantity.NewFromUint64(1_500_000_000_000_000_000), "1.5 TEST"},
+		{&Metadata{Symbol: "ROSE", Decimals: 9}, quantity.NewFromUint64(1_500_000_000_000_000_000), "1500000000.0 ROSE"},
+		{&Metadata{Symbol: "ROSE"}, quantity.NewFromUint64(42), "42.0 ROSE"},
+		// Invalid metadata should fall back to base units.
+		{&Metadata{Decimals: 9}, quantity.NewFromUint64(42), "42 base units"},
+		{&Metadata{Symbol: "ROSE", Decimals: 21}, quantity.NewFromUint64(42), "42 base units"},
+	} {
+		require.Equal(tc.expected, tc.meta.FormatAmount(*tc.amount))
+	}
+}
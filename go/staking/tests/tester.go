@@ -148,6 +148,7 @@ func StakingImplementationTests(
 		fn func(*testing.T, *stakingTestsState, api.Backend, consensusAPI.Backend)
 	}{
 		{"Thresholds", testThresholds},
+		{"TokenMetadata", testTokenMetadata},
 		{"CommonPool", testCommonPool},
 		{"LastBlockFees", testLastBlockFees},
 		{"GovernanceDeposits", testGovernanceDeposits},
@@ -178,6 +179,7 @@ func StakingClientImplementationTests(t *testing.T, backend api.Backend, consens
 		fn func(*testing.T, *stakingTestsState, api.Backend, consensusAPI.Backend)
 	}{
 		{"Thresholds", testThresholds},
+		{"TokenMetadata", testTokenMetadata},
 		{"LastBlockFees", testLastBlockFees},
 		{"Delegations", testDelegations},
 		{"Transfer", testTransfer},
@@ -210,6 +212,21 @@ func testThresholds(t *testing.T, _ *stakingTestsState, backend api.Backend, _ c
 	}
 }
 
+func testTokenMetadata(t *testing.T, _ *stakingTestsState, backend api.Backend, _ consensusAPI.Backend) {
+	require := require.New(t)
+
+	meta, err := backend.TokenMetadata(context.Background(), consensusAPI.HeightLatest)
+	require.NoError(err, "TokenMetadata")
+
+	symbol, err := backend.TokenSymbol(context.Background(), consensusAPI.HeightLatest)
+	require.NoError(err, "TokenSymbol")
+	require.Equal(symbol, meta.Symbol, "TokenMetadata - symbol")
+
+	exp, err := backend.TokenValueExponent(context.Background(), consensusAPI.HeightLatest)
+	require.NoError(err, "TokenValueExponent")
+	require.Equal(exp, meta.Decimals, "TokenMetadata - decimals")
+}
+
 func testCommonPool(t *testing.T, _ *stakingTestsState, backend api.Backend, _ consensusAPI.Backend) {
 	require := require.New(t)
 
@@ -1228,6 +1245,18 @@ func testSlashConsensusEquivocation(
 		t.Fatalf("failed to receive escrow event")
 	}
 
+	// Simulating the slash should predict slashing all of the stake.
+	sim, err := backend.SimulateSlash(ctx, &api.SlashSimulationQuery{
+		Height: consensusAPI.HeightLatest,
+		Owner:  entAddr,
+		Reason: api.SlashConsensusEquivocation,
+	})
+	require.NoError(err, "SimulateSlash")
+	require.Equal(entAcc.Escrow.Active.Balance, sim.ActiveSlashed, "SimulateSlash - all stake slashed")
+	require.NotZero(sim.FreezeEndTime, "SimulateSlash - nodes frozen")
+	require.Contains(sim.Delegators, accData.Address, "SimulateSlash - delegator affected")
+	require.True(sim.Delegators[accData.Address].ActiveAfter.IsZero(), "SimulateSlash - delegation slashed")
+
 	// Broadcast evidence. This is CometBFT-specific, if we ever have more than one
 	// consensus backend, we need to change this part.
 	blk, err := consensus.GetBlock(ctx, 1)
@@ -50,6 +50,15 @@ type CheckpointerConfig struct {
 	//
 	// This must return exactly RootsPerVersion roots.
 	GetRoots func(context.Context, uint64) ([]node.Root, error)
+
+	// ShouldDefer can be used to defer regular checkpoint creation while it returns true, e.g.,
+	// when the node is busy with more important duties. Any skipped checkpoints are created on
+	// the next check once it returns false. Forced checkpoints are never deferred.
+	ShouldDefer func() bool
+
+	// OnCheckpointCreated can be used to get notified with the metadata of all checkpoints created
+	// for a version once all of them have been successfully created.
+	OnCheckpointCreated func(context.Context, []*Metadata)
 }
 
 // CreationParameters are the checkpoint creation parameters used by the checkpointer.
@@ -171,13 +180,15 @@ func (c *checkpointer) checkpoint(ctx context.Context, version uint64, params *C
 		"num_roots", len(roots),
 	)
 
+	metas := make([]*Metadata, 0, len(roots))
 	for _, root := range roots {
 		c.logger.Info("creating new checkpoint",
 			"root", root,
 			"chunk_size", params.ChunkSize,
 		)
 
-		_, err = c.creator.CreateCheckpoint(ctx, root, params.ChunkSize)
+		var meta *Metadata
+		meta, err = c.creator.CreateCheckpoint(ctx, root, params.ChunkSize)
 		if err != nil {
 			c.logger.Error("failed to create checkpoint",
 				"root", root,
@@ -185,6 +196,11 @@ func (c *checkpointer) checkpoint(ctx context.Context, version uint64, params *C
 			)
 			return fmt.Errorf("checkpointer: failed to create checkpoint: %w", err)
 		}
+		metas = append(metas, meta)
+	}
+
+	if c.cfg.OnCheckpointCreated != nil {
+		c.cfg.OnCheckpointCreated(ctx, metas)
 	}
 	return nil
 }
@@ -354,6 +370,11 @@ func (c *checkpointer) worker(ctx context.Context) {
 			continue
 		case c.cfg.CheckInterval == CheckIntervalDisabled:
 			continue
+		case c.cfg.ShouldDefer != nil && c.cfg.ShouldDefer():
+			c.logger.Debug("deferring checkpoint creation",
+				"version", version,
+			)
+			continue
 		default:
 		}
 
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -167,6 +168,98 @@ func testCheckpointer(t *testing.T, factory dbApi.Factory, earliestVersion, inte
 	}
 }
 
+func testCheckpointerDeferred(t *testing.T, factory dbApi.Factory) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	dir := t.TempDir()
+	ndb, err := factory.New(&dbApi.Config{
+		DB:           filepath.Join(dir, "db"),
+		Namespace:    testNs,
+		MaxCacheSize: 16 * 1024 * 1024,
+	})
+	require.NoError(err, "New")
+	defer ndb.Close()
+
+	fc, err := NewFileCreator(filepath.Join(dir, "checkpoints"), ndb)
+	require.NoError(err, "NewFileCreator")
+
+	var deferred atomic.Bool
+	deferred.Store(true)
+	cp, err := NewCheckpointer(ctx, ndb, fc, CheckpointerConfig{
+		Name:            "test",
+		Namespace:       testNs,
+		CheckInterval:   testCheckInterval,
+		RootsPerVersion: 1,
+		Parameters: &CreationParameters{
+			Interval:  1,
+			NumKept:   testNumKept,
+			ChunkSize: 16 * 1024,
+		},
+		ShouldDefer: deferred.Load,
+	})
+	require.NoError(err, "NewCheckpointer")
+
+	var root node.Root
+	root.Empty()
+	root.Namespace = testNs
+	root.Type = node.RootTypeState
+
+	finalize := func(round uint64) {
+		tree := mkvs.NewWithRoot(nil, ndb, root)
+		err = tree.Insert(ctx, []byte(fmt.Sprintf("round %d", round)), []byte(fmt.Sprintf("value %d", round)))
+		require.NoError(err, "Insert")
+
+		var rootHash hash.Hash
+		_, rootHash, err = tree.Commit(ctx, testNs, round)
+		require.NoError(err, "Commit")
+
+		root.Version = round
+		root.Hash = rootHash
+
+		err = ndb.Finalize([]node.Root{root})
+		require.NoError(err, "Finalize")
+		cp.NotifyNewVersion(round)
+	}
+	getCheckpoints := func() []*Metadata {
+		cps, gerr := fc.GetCheckpoints(ctx, &GetCheckpointsRequest{
+			Version:   checkpointVersion,
+			Namespace: testNs,
+		})
+		require.NoError(gerr, "GetCheckpoints")
+		return cps
+	}
+
+	// Checkpoints should not be created while deferred.
+	finalize(0)
+	select {
+	case <-cp.(*checkpointer).statusCh:
+		t.Fatalf("checkpoint should have been deferred")
+	case <-time.After(3 * testCheckInterval):
+	}
+	require.Empty(getCheckpoints(), "no checkpoints should be created while deferred")
+
+	// Forced checkpoints should not be deferred.
+	cp.ForceCheckpoint(0)
+	select {
+	case <-cp.(*checkpointer).statusCh:
+	case <-time.After(2 * testCheckInterval):
+		t.Fatalf("failed to wait for checkpointer to checkpoint")
+	}
+	require.Len(getCheckpoints(), 1, "forced checkpoint should be created while deferred")
+
+	// Skipped checkpoints should be created once no longer deferred.
+	finalize(1)
+	deferred.Store(false)
+	finalize(2)
+	select {
+	case <-cp.(*checkpointer).statusCh:
+	case <-time.After(2 * testCheckInterval):
+		t.Fatalf("failed to wait for checkpointer to checkpoint")
+	}
+	require.Len(getCheckpoints(), 1+testNumKept, "deferred checkpoints should be created")
+}
+
 func TestCheckpointer(t *testing.T) {
 	dbTesting.TestMultipleBackends(t, db.Backends, testCheckpointerWithBackend)
 }
@@ -187,4 +280,7 @@ func testCheckpointerWithBackend(t *testing.T, factory dbApi.Factory) {
 	t.Run("ForceCheckpoint", func(t *testing.T) {
 		testCheckpointer(t, factory, 0, 10, false)
 	})
+	t.Run("Deferred", func(t *testing.T) {
+		testCheckpointerDeferred(t, factory)
+	})
 }
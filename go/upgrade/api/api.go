@@ -26,6 +26,8 @@ const (
 	// LogEventConsensusUpgrade is a log event value that signals the consensus upgrade handler was
 	// called.
 	LogEventConsensusUpgrade = "upgrade/consensus-upgrade"
+	// LogEventUpgradeCancelled is a log event value that signals a pending upgrade was cancelled.
+	LogEventUpgradeCancelled = "upgrade/upgrade-cancelled"
 )
 
 // UpgradeStage is used in the upgrade descriptor to store completed stages.
@@ -204,6 +206,15 @@ type PendingUpgrade struct {
 
 	// LastCompletedStage is the last upgrade stage that was successfully completed.
 	LastCompletedStage UpgradeStage `json:"last_completed_stage"`
+
+	// ProposalID is the identifier of the governance proposal that scheduled the upgrade
+	// (or zero if the upgrade was submitted manually).
+	ProposalID uint64 `json:"proposal_id,omitempty"`
+}
+
+// IsInProgress checks if the upgrade epoch was already reached.
+func (pu PendingUpgrade) IsInProgress() bool {
+	return pu.UpgradeHeight != InvalidUpgradeHeight || pu.HasAnyStages()
 }
 
 // IsCompleted checks if all upgrade stages were already completed.
@@ -235,6 +246,11 @@ type Backend interface {
 	// which then schedules and manages the upgrade.
 	SubmitDescriptor(*Descriptor) error
 
+	// SubmitProposalDescriptor submits the descriptor of an upgrade scheduled by the governance
+	// proposal with the given identifier. In case the same upgrade is already pending, it is
+	// linked to the proposal.
+	SubmitProposalDescriptor(uint64, *Descriptor) error
+
 	// PendingUpgrades returns pending upgrades.
 	PendingUpgrades() ([]*PendingUpgrade, error)
 
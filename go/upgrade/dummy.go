@@ -13,6 +13,10 @@ func (u *dummyUpgradeManager) SubmitDescriptor(*api.Descriptor) error {
 	return nil
 }
 
+func (u *dummyUpgradeManager) SubmitProposalDescriptor(uint64, *api.Descriptor) error {
+	return nil
+}
+
 func (u *dummyUpgradeManager) PendingUpgrades() ([]*api.PendingUpgrade, error) {
 	return nil, nil
 }
@@ -0,0 +1,78 @@
+package migrations
+
+import (
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/common/version"
+	consensusState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/abci/state"
+	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+)
+
+// Consensus250 is the name of the upgrade that enables features introduced in Oasis Core 25.0.
+//
+// This upgrade includes:
+//   - The `FreezeNode` registry transaction, which allows entities to freeze their own nodes,
+//     and the recording of freeze reasons in node statuses.
+//   - The `AddEscrowBatch` staking transaction, which escrows stake to multiple accounts
+//     atomically.
+//   - The `TransferBatch` staking transaction, which transfers stake to multiple accounts
+//     atomically.
+//   - The `TransferAt` and `CancelScheduledTransfer` staking transactions, which schedule
+//     transfers to be released at a future epoch.
+//   - Entity node authorizations, which restrict entity nodes to specific roles and runtimes.
+//   - Runtime slashing for repeated discrepancies, and roothash slashed events.
+//   - The `EvidenceBatch` roothash transaction, which submits multiple pieces of evidence
+//     atomically.
+//   - Incoming message results in `InMsgProcessed` events and last round results.
+//   - Registry `NodeExpired` events.
+//   - Per-runtime scheduler eligibility lists, managed via governance.
+//   - Staking dust handling, which sweeps remaining balances below a threshold into the common pool.
+//   - Allowance limits, which bound allowances with an expiration epoch and a per-epoch withdrawal limit.
+const Consensus250 = "consensus250"
+
+// Version250 is the Oasis Core 25.0 version.
+var Version250 = version.MustFromString("25.0")
+
+var _ Handler = (*Handler250)(nil)
+
+// Handler250 is the upgrade handler that transitions Oasis Core from version 24.x to 25.0.
+type Handler250 struct{}
+
+// HasStartupUpgrade implements Handler.
+func (h *Handler250) HasStartupUpgrade() bool {
+	return false
+}
+
+// StartupUpgrade implements Handler.
+func (h *Handler250) StartupUpgrade() error {
+	return nil
+}
+
+// ConsensusUpgrade implements Handler.
+func (h *Handler250) ConsensusUpgrade(privateCtx interface{}) error {
+	abciCtx := privateCtx.(*abciAPI.Context)
+	switch abciCtx.Mode() {
+	case abciAPI.ContextBeginBlock:
+		// Nothing to do.
+	case abciAPI.ContextEndBlock:
+		// Consensus parameters.
+		consState := consensusState.NewMutableState(abciCtx.State())
+		consParams, err := consState.ConsensusParameters(abciCtx)
+		if err != nil {
+			return fmt.Errorf("failed to load consensus parameters: %w", err)
+		}
+
+		consParams.FeatureVersion = &Version250
+
+		if err = consState.SetConsensusParameters(abciCtx, consParams); err != nil {
+			return fmt.Errorf("failed to set consensus parameters: %w", err)
+		}
+	default:
+		return fmt.Errorf("upgrade handler called in unexpected context: %s", abciCtx.Mode())
+	}
+	return nil
+}
+
+func init() {
+	Register(Consensus250, &Handler250{})
+}
@@ -38,6 +38,15 @@ type upgradeManager struct {
 
 // Implements api.Backend.
 func (u *upgradeManager) SubmitDescriptor(descriptor *api.Descriptor) error {
+	return u.submitDescriptor(0, descriptor)
+}
+
+// Implements api.Backend.
+func (u *upgradeManager) SubmitProposalDescriptor(proposalID uint64, descriptor *api.Descriptor) error {
+	return u.submitDescriptor(proposalID, descriptor)
+}
+
+func (u *upgradeManager) submitDescriptor(proposalID uint64, descriptor *api.Descriptor) error {
 	if descriptor == nil {
 		return api.ErrBadDescriptor
 	}
@@ -46,20 +55,34 @@ func (u *upgradeManager) SubmitDescriptor(descriptor *api.Descriptor) error {
 	defer u.Unlock()
 
 	for _, pu := range u.pending {
-		if pu.Descriptor.Equals(descriptor) {
+		if !pu.Descriptor.Equals(descriptor) {
+			continue
+		}
+		if proposalID == 0 || pu.ProposalID != 0 {
 			return api.ErrAlreadyPending
 		}
+
+		// Link a manually submitted upgrade to the proposal that scheduled it.
+		pu.ProposalID = proposalID
+		u.logger.Info("linked pending upgrade to governance proposal",
+			"handler", pu.Descriptor.Handler,
+			"epoch", pu.Descriptor.Epoch,
+			"proposal_id", proposalID,
+		)
+		return u.flushDescriptorLocked()
 	}
 
 	pending := &api.PendingUpgrade{
 		Versioned:  cbor.NewVersioned(api.LatestPendingUpgradeVersion),
 		Descriptor: descriptor,
+		ProposalID: proposalID,
 	}
 	u.pending = append(u.pending, pending)
 
 	u.logger.Info("received upgrade descriptor, scheduling shutdown",
 		"handler", pending.Descriptor.Handler,
 		"epoch", pending.Descriptor.Epoch,
+		"proposal_id", proposalID,
 	)
 
 	return u.flushDescriptorLocked()
@@ -105,15 +128,19 @@ func (u *upgradeManager) CancelUpgrade(descriptor *api.Descriptor) error {
 		return u.flushDescriptorLocked()
 	}
 
-	var pending []*api.PendingUpgrade
+	var (
+		pending   []*api.PendingUpgrade
+		cancelled *api.PendingUpgrade
+	)
 	for _, pu := range u.pending {
 		if !pu.Descriptor.Equals(descriptor) {
 			pending = append(pending, pu)
 			continue
 		}
-		if pu.UpgradeHeight != api.InvalidUpgradeHeight || pu.HasAnyStages() {
+		if pu.IsInProgress() {
 			return api.ErrUpgradeInProgress
 		}
+		cancelled = pu
 	}
 	oldPending := u.pending
 	u.pending = pending
@@ -121,6 +148,15 @@ func (u *upgradeManager) CancelUpgrade(descriptor *api.Descriptor) error {
 		u.pending = oldPending
 		return err
 	}
+
+	if cancelled != nil {
+		u.logger.Info("pending upgrade cancelled",
+			"handler", cancelled.Descriptor.Handler,
+			"epoch", cancelled.Descriptor.Epoch,
+			"proposal_id", cancelled.ProposalID,
+			logging.LogEvent, api.LogEventUpgradeCancelled,
+		)
+	}
 	return nil
 }
 
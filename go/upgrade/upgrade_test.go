@@ -0,0 +1,77 @@
+package upgrade
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/persistent"
+	"github.com/oasisprotocol/oasis-core/go/common/version"
+	"github.com/oasisprotocol/oasis-core/go/upgrade/api"
+)
+
+func TestProposalUpgrades(t *testing.T) {
+	require := require.New(t)
+
+	dataDir := t.TempDir()
+	store, err := persistent.NewCommonStore(dataDir)
+	require.NoError(err, "NewCommonStore")
+	defer store.Close()
+
+	upgrader, err := New(store, dataDir, true)
+	require.NoError(err, "New")
+
+	newDescriptor := func(epoch beacon.EpochTime) *api.Descriptor {
+		return &api.Descriptor{
+			Versioned: cbor.NewVersioned(api.LatestDescriptorVersion),
+			Handler:   "test-handler",
+			Target:    version.Versions,
+			Epoch:     epoch,
+		}
+	}
+	desc1 := newDescriptor(10)
+	desc2 := newDescriptor(20)
+
+	// Upgrades scheduled by proposals should record their origin.
+	err = upgrader.SubmitProposalDescriptor(5, desc1)
+	require.NoError(err, "SubmitProposalDescriptor")
+	err = upgrader.SubmitProposalDescriptor(5, desc1)
+	require.ErrorIs(err, api.ErrAlreadyPending)
+	pu, err := upgrader.GetUpgrade(desc1)
+	require.NoError(err, "GetUpgrade")
+	require.EqualValues(5, pu.ProposalID)
+
+	// Manually submitted upgrades should be linked to the proposal once it is executed.
+	err = upgrader.SubmitDescriptor(desc2)
+	require.NoError(err, "SubmitDescriptor")
+	pu, err = upgrader.GetUpgrade(desc2)
+	require.NoError(err, "GetUpgrade")
+	require.Zero(pu.ProposalID)
+	err = upgrader.SubmitProposalDescriptor(7, desc2)
+	require.NoError(err, "SubmitProposalDescriptor")
+	pu, err = upgrader.GetUpgrade(desc2)
+	require.NoError(err, "GetUpgrade")
+	require.EqualValues(7, pu.ProposalID)
+
+	// The origin should be persisted.
+	upgrader.(*upgradeManager).pending = nil
+	require.NoError(upgrader.(*upgradeManager).checkStatus(), "checkStatus")
+	pending, err := upgrader.PendingUpgrades()
+	require.NoError(err, "PendingUpgrades")
+	require.Len(pending, 2)
+	require.EqualValues(5, pending[0].ProposalID)
+	require.EqualValues(7, pending[1].ProposalID)
+
+	// Cancelled upgrades should be unscheduled unless in progress.
+	require.NoError(upgrader.CancelUpgrade(desc1), "CancelUpgrade")
+	_, err = upgrader.GetUpgrade(desc1)
+	require.ErrorIs(err, api.ErrUpgradeNotFound)
+
+	pu, err = upgrader.GetUpgrade(desc2)
+	require.NoError(err, "GetUpgrade")
+	pu.UpgradeHeight = 42
+	require.True(pu.IsInProgress())
+	require.ErrorIs(upgrader.CancelUpgrade(desc2), api.ErrUpgradeInProgress)
+}
@@ -154,3 +154,12 @@ var DefaultGasCosts = transaction.Costs{
 	GasOpAuthorizeAction: 5000,
 	GasOpCancelAction:    5000,
 }
+
+func init() {
+	transaction.RegisterGasOps(
+		ModuleName,
+		GasOpCreate,
+		GasOpAuthorizeAction,
+		GasOpCancelAction,
+	)
+}
@@ -3,6 +3,7 @@ package committee
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
@@ -10,8 +11,11 @@ import (
 	"github.com/eapache/channels"
 
 	cmnBackoff "github.com/oasisprotocol/oasis-core/go/common/backoff"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/config"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
 	runtime "github.com/oasisprotocol/oasis-core/go/runtime/api"
 	"github.com/oasisprotocol/oasis-core/go/runtime/bundle/component"
@@ -20,6 +24,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/runtime/host/protocol"
 	"github.com/oasisprotocol/oasis-core/go/runtime/transaction"
 	"github.com/oasisprotocol/oasis-core/go/runtime/txpool"
+	storage "github.com/oasisprotocol/oasis-core/go/storage/api"
 	"github.com/oasisprotocol/oasis-core/go/worker/common/committee"
 )
 
@@ -160,7 +165,8 @@ func (n *Node) CheckTx(ctx context.Context, tx []byte) (*protocol.CheckTxResult,
 	return n.commonNode.TxPool.SubmitTx(ctx, tx, &txpool.TransactionMeta{Local: true, Discard: true})
 }
 
-func (n *Node) Query(ctx context.Context, round uint64, method string, args []byte, comp *component.ID) ([]byte, error) {
+// Query performs a runtime query at the given runtime round.
+func (n *Node) Query(ctx context.Context, round uint64, method string, args []byte, comp *component.ID) (*api.QueryResponse, error) {
 	hrt := n.commonNode.GetHostedRuntime()
 	if hrt == nil {
 		return nil, api.ErrNoHostedRuntime
@@ -208,7 +214,266 @@ func (n *Node) Query(ctx context.Context, round uint64, method string, args []by
 		hrt = host.NewRichRuntime(rt)
 	}
 
-	return hrt.Query(ctx, annBlk.Block, lb, epoch, maxMessages, method, args)
+	data, err := hrt.Query(ctx, annBlk.Block, lb, epoch, maxMessages, method, args)
+	if err != nil {
+		return nil, err
+	}
+	return &api.QueryResponse{
+		Data:            data,
+		Round:           annBlk.Block.Header.Round,
+		ConsensusHeight: annBlk.Height,
+	}, nil
+}
+
+// RoundAtConsensusHeight returns the latest runtime round that has been finalized at or before
+// the given consensus height.
+func (n *Node) RoundAtConsensusHeight(ctx context.Context, height int64) (uint64, error) {
+	blk, err := n.commonNode.Consensus.RootHash().GetLatestBlock(ctx, &roothash.RuntimeRequest{
+		RuntimeID: n.commonNode.Runtime.ID(),
+		Height:    height,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("client: failed to get latest block at height %d: %w", height, err)
+	}
+	return blk.Header.Round, nil
+}
+
+// GetRuntimeParameters returns the operational parameters of the hosted runtime.
+func (n *Node) GetRuntimeParameters(ctx context.Context) (*api.RuntimeParameters, error) {
+	hrt := n.commonNode.GetHostedRuntime()
+	if hrt == nil {
+		return nil, api.ErrNoHostedRuntime
+	}
+
+	n.commonNode.CrossNode.Lock()
+	dsc := n.commonNode.CurrentDescriptor
+	blk := n.commonNode.CurrentBlock
+	n.commonNode.CrossNode.Unlock()
+
+	if dsc == nil || blk == nil {
+		return nil, api.ErrNoHostedRuntime
+	}
+
+	info, err := hrt.GetInfo(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("client: failed to get runtime info: %w", err)
+	}
+
+	params := &api.RuntimeParameters{
+		RuntimeID:         dsc.ID,
+		Round:             blk.Header.Round,
+		RuntimeVersion:    info.RuntimeVersion,
+		Features:          info.Features,
+		MaxBatchSize:      dsc.TxnScheduler.MaxBatchSize,
+		MaxBatchSizeBytes: dsc.TxnScheduler.MaxBatchSizeBytes,
+		MaxMessages:       dsc.Executor.MaxMessages,
+	}
+
+	// Runtimes are not required to report their parameters, so failures are not fatal.
+	rsp, err := n.Query(ctx, blk.Header.Round, api.QueryMethodRuntimeParameters, cbor.Marshal(nil), nil)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		n.logger.Debug("runtime does not report its parameters",
+			"err", err,
+		)
+		return params, nil
+	}
+
+	var reported api.ReportedRuntimeParameters
+	if err = cbor.Unmarshal(rsp.Data, &reported); err != nil {
+		n.logger.Debug("runtime reported malformed parameters",
+			"err", err,
+		)
+		return params, nil
+	}
+	params.Reported = &reported
+
+	return params, nil
+}
+
+// ReplayBatch re-executes the batch of the given past round using the hosted runtime.
+func (n *Node) ReplayBatch(ctx context.Context, round uint64) (*api.ReplayBatchResponse, error) {
+	hrt := n.commonNode.GetHostedRuntime()
+	if hrt == nil {
+		return nil, api.ErrNoHostedRuntime
+	}
+	if round == 0 {
+		return nil, fmt.Errorf("client: cannot replay the genesis round")
+	}
+
+	history := n.commonNode.Runtime.History()
+	annBlk, err := history.GetAnnotatedBlock(ctx, round)
+	if err != nil {
+		return nil, fmt.Errorf("client: failed to fetch annotated block from history: %w", err)
+	}
+	if annBlk.Block.Header.HeaderType != block.Normal {
+		return nil, fmt.Errorf("client: round %d is not a normal round (type: %d)", round, annBlk.Block.Header.HeaderType)
+	}
+	if _, err = history.WaitRoundSynced(ctx, round); err != nil {
+		return nil, fmt.Errorf("client: failed to wait for round to be synced: %w", err)
+	}
+
+	// The batch was executed on top of the previous block using the consensus state at the
+	// height at which the previous block was finalized.
+	prevBlk, err := history.GetAnnotatedBlock(ctx, round-1)
+	if err != nil {
+		return nil, fmt.Errorf("client: failed to fetch previous annotated block from history: %w", err)
+	}
+	height := prevBlk.Height
+
+	lb, err := n.commonNode.Consensus.GetLightBlock(ctx, height)
+	if err != nil {
+		return nil, fmt.Errorf("client: failed to get light block at height %d: %w", height, err)
+	}
+	epoch, err := n.commonNode.Consensus.Beacon().GetEpoch(ctx, height)
+	if err != nil {
+		return nil, fmt.Errorf("client: failed to get epoch at height %d: %w", height, err)
+	}
+	rq := &roothash.RuntimeRequest{
+		RuntimeID: n.commonNode.Runtime.ID(),
+		Height:    height,
+	}
+	state, err := n.commonNode.Consensus.RootHash().GetRuntimeState(ctx, rq)
+	if err != nil {
+		return nil, fmt.Errorf("client: failed to get runtime state at height %d: %w", height, err)
+	}
+	roundResults, err := n.commonNode.Consensus.RootHash().GetLastRoundResults(ctx, rq)
+	if err != nil {
+		return nil, fmt.Errorf("client: failed to get last round results at height %d: %w", height, err)
+	}
+	inMsgs, err := n.commonNode.Consensus.RootHash().GetIncomingMessageQueue(ctx, &roothash.InMessageQueueRequest{
+		RuntimeID: n.commonNode.Runtime.ID(),
+		Height:    height,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("client: failed to get incoming message queue at height %d: %w", height, err)
+	}
+
+	// Fetch the committed transactions and reconstruct the input batch.
+	ioTree := transaction.NewTree(n.commonNode.Runtime.Storage(), annBlk.Block.Header.StorageRootIO())
+	defer ioTree.Close()
+
+	txs, err := ioTree.GetTransactions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("client: failed to get transactions: %w", err)
+	}
+	sort.Slice(txs, func(i, j int) bool { return txs[i].BatchOrder < txs[j].BatchOrder })
+
+	emptyRoot := storage.Root{
+		Namespace: annBlk.Block.Header.Namespace,
+		Version:   round,
+		Type:      storage.RootTypeIO,
+	}
+	emptyRoot.Hash.Empty()
+
+	inputTree := transaction.NewTree(nil, emptyRoot)
+	defer inputTree.Close()
+
+	inputs := make(transaction.RawBatch, 0, len(txs))
+	for _, tx := range txs {
+		if err = inputTree.AddTransaction(ctx, transaction.Transaction{Input: tx.Input, BatchOrder: tx.BatchOrder}, nil); err != nil {
+			return nil, fmt.Errorf("client: failed to reconstruct input batch: %w", err)
+		}
+		inputs = append(inputs, tx.Input)
+	}
+	_, inputRoot, err := inputTree.Commit(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("client: failed to reconstruct input batch: %w", err)
+	}
+
+	n.logger.Info("replaying batch",
+		"round", round,
+		"height", height,
+		"batch_size", len(inputs),
+	)
+
+	rsp, err := hrt.Call(ctx, &protocol.Body{
+		RuntimeExecuteTxBatchRequest: &protocol.RuntimeExecuteTxBatchRequest{
+			Mode:           protocol.ExecutionModeExecute,
+			ConsensusBlock: *lb,
+			RoundResults:   roundResults,
+			IORoot:         inputRoot,
+			Inputs:         inputs,
+			InMessages:     inMsgs,
+			Block:          *prevBlk.Block,
+			Epoch:          epoch,
+			MaxMessages:    state.Runtime.Executor.MaxMessages,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("client: failed to replay batch: %w", err)
+	}
+	if rsp.RuntimeExecuteTxBatchResponse == nil {
+		return nil, fmt.Errorf("client: malformed response from runtime")
+	}
+	batch := rsp.RuntimeExecuteTxBatchResponse.Batch
+
+	// Extract the replayed outputs.
+	replayTree := transaction.NewTree(nil, emptyRoot)
+	defer replayTree.Close()
+
+	if err = replayTree.ApplyWriteLog(ctx, batch.IOWriteLog); err != nil {
+		return nil, fmt.Errorf("client: malformed replayed I/O write log: %w", err)
+	}
+	replayed, err := replayTree.GetTransactions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("client: malformed replayed I/O write log: %w", err)
+	}
+	outputs := make(map[hash.Hash][]byte, len(replayed))
+	for _, tx := range replayed {
+		outputs[tx.Hash()] = tx.Output
+	}
+
+	result := &api.ReplayBatchResponse{
+		Committed:     annBlk.Block.Header,
+		Computed:      batch.Header,
+		IOWriteLog:    batch.IOWriteLog,
+		StateWriteLog: batch.StateWriteLog,
+		Messages:      batch.Messages,
+	}
+	for _, tx := range txs {
+		result.Transactions = append(result.Transactions, &api.ReplayedTransaction{
+			Tx:              tx.Input,
+			CommittedResult: tx.Output,
+			Result:          outputs[tx.Hash()],
+		})
+	}
+
+	if mismatches := result.Mismatches(); len(mismatches) > 0 {
+		n.logger.Warn("replayed batch differs from committed results",
+			"round", round,
+			"mismatches", mismatches,
+		)
+	}
+	for i, tx := range result.Transactions {
+		if !tx.ResultMatches() {
+			n.logger.Warn("replayed transaction result differs from committed result",
+				"round", round,
+				"index", i,
+				"tx_hash", txs[i].Hash(),
+			)
+		}
+	}
+
+	return result, nil
+}
+
+func (n *Node) indexBlock(ctx context.Context, tree *transaction.Tree, blk *block.Block) error {
+	txs, err := tree.GetTransactions(ctx)
+	if err != nil {
+		return fmt.Errorf("error getting block I/O from storage: %w", err)
+	}
+
+	index := make(map[hash.Hash]uint32, len(txs))
+	for _, tx := range txs {
+		index[tx.Hash()] = tx.BatchOrder
+	}
+	if err = n.commonNode.Runtime.History().IndexTransactions(blk.Header.Round, index); err != nil {
+		return fmt.Errorf("error indexing transactions: %w", err)
+	}
+	return nil
 }
 
 func (n *Node) checkBlock(ctx context.Context, blk *block.Block, pending map[hash.Hash]*pendingTx) error {
@@ -216,14 +481,24 @@ func (n *Node) checkBlock(ctx context.Context, blk *block.Block, pending map[has
 		return nil
 	}
 
-	// If there's no pending transactions, we can skip the check.
-	if len(pending) == 0 {
+	// If there's no pending transactions and transactions are not indexed, we can skip the check.
+	indexTxs := config.GlobalConfig.Runtime.IndexTransactions
+	if len(pending) == 0 && !indexTxs {
 		return nil
 	}
 
 	tree := transaction.NewTree(n.commonNode.Runtime.Storage(), blk.Header.StorageRootIO())
 	defer tree.Close()
 
+	if indexTxs {
+		if err := n.indexBlock(ctx, tree, blk); err != nil {
+			return err
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
 	// Check if there's anything interesting in this block.
 	var txHashes []hash.Hash
 	for txHash := range pending {
@@ -8,6 +8,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/errors"
 	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
+	"github.com/oasisprotocol/oasis-core/go/config"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
@@ -25,6 +26,10 @@ type service struct {
 }
 
 func (s *service) submitTx(ctx context.Context, request *api.SubmitTxRequest) (*committee.SubmitTxSubscription, *protocol.Error, error) {
+	if config.GlobalConfig.Common.ReadOnly {
+		return nil, nil, api.ErrReadOnly
+	}
+
 	rt := s.w.runtimes[request.RuntimeID]
 	if rt == nil {
 		return nil, nil, api.ErrNoHostedRuntime
@@ -292,6 +297,16 @@ func (s *service) GetEvents(ctx context.Context, request *api.GetEventsRequest)
 	return events, nil
 }
 
+// Implements api.RuntimeClient.
+func (s *service) GetRuntimeParameters(ctx context.Context, runtimeID common.Namespace) (*api.RuntimeParameters, error) {
+	rt := s.w.runtimes[runtimeID]
+	if rt == nil {
+		return nil, api.ErrNoHostedRuntime
+	}
+
+	return rt.GetRuntimeParameters(ctx)
+}
+
 // Implements api.RuntimeClient.
 func (s *service) Query(ctx context.Context, request *api.QueryRequest) (*api.QueryResponse, error) {
 	rt := s.w.runtimes[request.RuntimeID]
@@ -299,11 +314,51 @@ func (s *service) Query(ctx context.Context, request *api.QueryRequest) (*api.Qu
 		return nil, api.ErrNoHostedRuntime
 	}
 
-	data, err := rt.Query(ctx, request.Round, request.Method, request.Args, request.Component)
+	round := request.Round
+	if request.ConsensusHeight != 0 {
+		var err error
+		if round, err = rt.RoundAtConsensusHeight(ctx, request.ConsensusHeight); err != nil {
+			return nil, err
+		}
+	}
+
+	return rt.Query(ctx, round, request.Method, request.Args, request.Component)
+}
+
+// Implements api.RuntimeClient.
+func (s *service) ReplayBatch(ctx context.Context, request *api.ReplayBatchRequest) (*api.ReplayBatchResponse, error) {
+	if !config.GlobalConfig.Runtime.DebugReplay {
+		return nil, api.ErrReplayDisabled
+	}
+
+	rt := s.w.runtimes[request.RuntimeID]
+	if rt == nil {
+		return nil, api.ErrNoHostedRuntime
+	}
+
+	return rt.ReplayBatch(ctx, request.Round)
+}
+
+// Implements api.RuntimeClient.
+func (s *service) GetRoundByTxHash(ctx context.Context, request *api.GetRoundByTxHashRequest) (*api.GetRoundByTxHashResponse, error) {
+	if !config.GlobalConfig.Runtime.IndexTransactions {
+		return nil, api.ErrTxIndexDisabled
+	}
+
+	rt, err := s.w.commonWorker.RuntimeRegistry.GetRuntime(request.RuntimeID)
+	if err != nil {
+		return nil, err
+	}
+
+	loc, err := rt.History().GetTransactionLocation(ctx, request.TxHash)
 	if err != nil {
 		return nil, err
 	}
-	return &api.QueryResponse{Data: data}, nil
+
+	return &api.GetRoundByTxHashResponse{
+		Round:      loc.Round,
+		BatchOrder: loc.BatchOrder,
+	}, nil
 }
 
 // Implements api.RuntimeClient.
@@ -0,0 +1,36 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/config"
+	"github.com/oasisprotocol/oasis-core/go/runtime/client/api"
+)
+
+func TestSubmitTxReadOnly(t *testing.T) {
+	require := require.New(t)
+
+	config.GlobalConfig.Common.ReadOnly = true
+	defer func() {
+		config.GlobalConfig.Common.ReadOnly = false
+	}()
+
+	ctx := context.Background()
+	svc := &service{w: &Worker{}}
+	req := &api.SubmitTxRequest{Data: []byte("tx")}
+
+	_, err := svc.SubmitTx(ctx, req)
+	require.ErrorIs(err, api.ErrReadOnly, "SubmitTx should be rejected in read-only mode")
+	_, err = svc.SubmitTxMeta(ctx, req)
+	require.ErrorIs(err, api.ErrReadOnly, "SubmitTxMeta should be rejected in read-only mode")
+	err = svc.SubmitTxNoWait(ctx, req)
+	require.ErrorIs(err, api.ErrReadOnly, "SubmitTxNoWait should be rejected in read-only mode")
+
+	// Outside read-only mode the request should proceed and fail due to the missing runtime.
+	config.GlobalConfig.Common.ReadOnly = false
+	err = svc.SubmitTxNoWait(ctx, req)
+	require.ErrorIs(err, api.ErrNoHostedRuntime)
+}
@@ -1,33 +1,133 @@
 package client
 
 import (
+	"bytes"
 	"context"
+	"fmt"
 	"io"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cache/lru"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/p2p/rpc"
 	storage "github.com/oasisprotocol/oasis-core/go/storage/api"
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/checkpoint"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/syncer"
 	storagePub "github.com/oasisprotocol/oasis-core/go/worker/storage/p2p/pub"
 )
 
+// proofEntryOverhead is the approximate per-entry memory overhead of a cached proof.
+const proofEntryOverhead = 24
+
+type cachedProof struct {
+	rsp  *storage.ProofResponse
+	size uint64
+}
+
+// Size implements lru.Sizeable.
+func (cp *cachedProof) Size() uint64 {
+	return cp.size
+}
+
+func newCachedProof(rsp *storage.ProofResponse) *cachedProof {
+	size := uint64(len(rsp.Proof.UntrustedRoot))
+	for _, entry := range rsp.Proof.Entries {
+		size += uint64(len(entry)) + proofEntryOverhead
+	}
+	return &cachedProof{
+		rsp:  copyProofResponse(rsp),
+		size: size,
+	}
+}
+
+// copyProofResponse returns a deep copy of the given proof response, so that callers cannot
+// modify cached proofs.
+func copyProofResponse(rsp *storage.ProofResponse) *storage.ProofResponse {
+	cp := &storage.ProofResponse{Proof: rsp.Proof}
+	cp.Proof.Entries = make([][]byte, len(rsp.Proof.Entries))
+	for i, entry := range rsp.Proof.Entries {
+		cp.Proof.Entries[i] = bytes.Clone(entry)
+	}
+	return cp
+}
+
 type statelessStorage struct {
 	rpc storagePub.Client
+
+	verifier syncer.ProofVerifier
+	cache    *lru.Cache
+}
+
+// verifyProof verifies that the proof is valid for the given tree. Proofs can either be for
+// the subtree at the given position or for the whole tree.
+func (s *statelessStorage) verifyProof(ctx context.Context, tree *storage.TreeID, proof *syncer.Proof) error {
+	var expectedRoot hash.Hash
+	switch {
+	case !tree.Position.IsEmpty() && proof.UntrustedRoot.Equal(&tree.Position):
+		expectedRoot = tree.Position
+	case proof.UntrustedRoot.Equal(&tree.Root.Hash):
+		expectedRoot = tree.Root.Hash
+	default:
+		return fmt.Errorf("client/stateless: got proof for unexpected root (%s)", proof.UntrustedRoot)
+	}
+
+	if _, err := s.verifier.VerifyProof(ctx, expectedRoot, proof); err != nil {
+		return fmt.Errorf("client/stateless: invalid proof: %w", err)
+	}
+	return nil
+}
+
+// sync performs the given sync request, serving it from the cache when possible. Fetched proofs
+// are verified before being cached so that peers cannot poison the cache.
+func (s *statelessStorage) sync(
+	ctx context.Context,
+	method string,
+	tree *storage.TreeID,
+	request any,
+	fetch func() (*storage.ProofResponse, rpc.PeerFeedback, error),
+) (*storage.ProofResponse, error) {
+	if s.cache == nil {
+		rsp, _, err := fetch()
+		return rsp, err
+	}
+
+	key := hash.NewFromBytes([]byte(method), cbor.Marshal(request))
+	if cached, ok := s.cache.Get(key); ok {
+		return copyProofResponse(cached.(*cachedProof).rsp), nil
+	}
+
+	rsp, pf, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	if err = s.verifyProof(ctx, tree, &rsp.Proof); err != nil {
+		pf.RecordBadPeer()
+		return nil, err
+	}
+
+	// Proofs that are too large to be cached are still returned.
+	_ = s.cache.Put(key, newCachedProof(rsp))
+
+	return rsp, nil
 }
 
 func (s *statelessStorage) SyncGet(ctx context.Context, request *storage.GetRequest) (*storage.ProofResponse, error) {
-	rsp, _, err := s.rpc.Get(ctx, request)
-	return rsp, err
+	return s.sync(ctx, storagePub.MethodGet, &request.Tree, request, func() (*storage.ProofResponse, rpc.PeerFeedback, error) {
+		return s.rpc.Get(ctx, request)
+	})
 }
 
 func (s *statelessStorage) SyncGetPrefixes(ctx context.Context, request *storage.GetPrefixesRequest) (*storage.ProofResponse, error) {
-	rsp, _, err := s.rpc.GetPrefixes(ctx, request)
-	return rsp, err
+	return s.sync(ctx, storagePub.MethodGetPrefixes, &request.Tree, request, func() (*storage.ProofResponse, rpc.PeerFeedback, error) {
+		return s.rpc.GetPrefixes(ctx, request)
+	})
 }
 
 func (s *statelessStorage) SyncIterate(ctx context.Context, request *storage.IterateRequest) (*storage.ProofResponse, error) {
-	rsp, _, err := s.rpc.Iterate(ctx, request)
-	return rsp, err
+	return s.sync(ctx, storagePub.MethodIterate, &request.Tree, request, func() (*storage.ProofResponse, rpc.PeerFeedback, error) {
+		return s.rpc.Iterate(ctx, request)
+	})
 }
 
 func (s *statelessStorage) GetDiff(context.Context, *storage.GetDiffRequest) (storage.WriteLogIterator, error) {
@@ -53,8 +153,19 @@ func (s *statelessStorage) Initialized() <-chan struct{} {
 
 // NewStatelessStorage creates a stateless storage backend that uses the P2P transport and the
 // storagepub protocol to query storage state.
-func NewStatelessStorage(p2p rpc.P2P, chainContext string, runtimeID common.Namespace) storage.Backend {
-	return &statelessStorage{
-		rpc: storagePub.NewClient(p2p, chainContext, runtimeID),
+//
+// Verified proofs are kept in a cache of at most cacheSize bytes. Caching is disabled if
+// cacheSize is zero.
+func NewStatelessStorage(p2p rpc.P2P, chainContext string, runtimeID common.Namespace, cacheSize uint64) storage.Backend {
+	return newStatelessStorage(storagePub.NewClient(p2p, chainContext, runtimeID), cacheSize)
+}
+
+func newStatelessStorage(client storagePub.Client, cacheSize uint64) *statelessStorage {
+	s := &statelessStorage{
+		rpc: client,
+	}
+	if cacheSize > 0 {
+		s.cache = lru.New(lru.Capacity(cacheSize, true))
 	}
+	return s
 }
@@ -0,0 +1,135 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/p2p/rpc"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/syncer"
+	storagePub "github.com/oasisprotocol/oasis-core/go/worker/storage/p2p/pub"
+)
+
+type testPeerFeedback struct {
+	badPeer bool
+}
+
+func (pf *testPeerFeedback) RecordSuccess() {}
+
+func (pf *testPeerFeedback) RecordFailure() {}
+
+func (pf *testPeerFeedback) RecordBadPeer() {
+	pf.badPeer = true
+}
+
+func (pf *testPeerFeedback) PeerID() core.PeerID {
+	return ""
+}
+
+type testStoragePubClient struct {
+	tree   mkvs.Tree
+	pf     testPeerFeedback
+	calls  int
+	poison bool
+}
+
+func (c *testStoragePubClient) Get(ctx context.Context, request *storagePub.GetRequest) (*storagePub.ProofResponse, rpc.PeerFeedback, error) {
+	c.calls++
+	rsp, err := c.tree.SyncGet(ctx, request)
+	if err != nil {
+		return nil, nil, err
+	}
+	if c.poison {
+		rsp.Proof.Entries = rsp.Proof.Entries[:1]
+	}
+	return rsp, &c.pf, nil
+}
+
+func (c *testStoragePubClient) GetPrefixes(ctx context.Context, request *storagePub.GetPrefixesRequest) (*storagePub.ProofResponse, rpc.PeerFeedback, error) {
+	c.calls++
+	rsp, err := c.tree.SyncGetPrefixes(ctx, request)
+	return rsp, &c.pf, err
+}
+
+func (c *testStoragePubClient) Iterate(ctx context.Context, request *storagePub.IterateRequest) (*storagePub.ProofResponse, rpc.PeerFeedback, error) {
+	c.calls++
+	rsp, err := c.tree.SyncIterate(ctx, request)
+	return rsp, &c.pf, err
+}
+
+func TestStatelessStorageCache(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	// Prepare a tree serving as the remote storage.
+	var ns common.Namespace
+	tree := mkvs.New(nil, nil, node.RootTypeState)
+	for _, key := range []string{"foo", "bar", "baz"} {
+		err := tree.Insert(ctx, []byte(key), []byte("value of "+key))
+		require.NoError(err, "Insert")
+	}
+	_, rootHash, err := tree.Commit(ctx, ns, 0)
+	require.NoError(err, "Commit")
+	root := node.Root{
+		Namespace: ns,
+		Version:   0,
+		Type:      node.RootTypeState,
+		Hash:      rootHash,
+	}
+
+	client := &testStoragePubClient{tree: tree}
+	storage := newStatelessStorage(client, 1024*1024)
+
+	// Queries against the stateless storage should be verified and cached.
+	remote := mkvs.NewWithRoot(storage, nil, root)
+	value, err := remote.Get(ctx, []byte("foo"))
+	require.NoError(err, "Get")
+	require.Equal([]byte("value of foo"), value)
+	require.Equal(1, client.calls, "proof should be fetched from the remote storage")
+	remote.Close()
+
+	remote = mkvs.NewWithRoot(storage, nil, root)
+	value, err = remote.Get(ctx, []byte("foo"))
+	require.NoError(err, "Get")
+	require.Equal([]byte("value of foo"), value)
+	require.Equal(1, client.calls, "proof should be served from the cache")
+	remote.Close()
+
+	// Invalid proofs should be rejected and not cached.
+	numCached := len(storage.cache.Keys())
+	client.poison = true
+	_, err = storage.SyncGet(ctx, &syncer.GetRequest{
+		Tree: syncer.TreeID{
+			Root:     root,
+			Position: rootHash,
+		},
+		Key: []byte("bar"),
+	})
+	require.Error(err, "invalid proofs should be rejected")
+	require.True(client.pf.badPeer, "peers serving invalid proofs should be reported")
+	require.Equal(2, client.calls)
+	require.Len(storage.cache.Keys(), numCached, "invalid proofs should not be cached")
+
+	// Modifying served proofs should not affect the cache.
+	client.poison = false
+	request := &syncer.GetRequest{
+		Tree: syncer.TreeID{
+			Root:     root,
+			Position: rootHash,
+		},
+		Key: []byte("baz"),
+	}
+	rsp, err := storage.SyncGet(ctx, request)
+	require.NoError(err, "SyncGet")
+	rsp.Proof.Entries[0] = nil
+	calls := client.calls
+	rsp, err = storage.SyncGet(ctx, request)
+	require.NoError(err, "SyncGet")
+	require.Equal(calls, client.calls, "proof should be served from the cache")
+	require.NotNil(rsp.Proof.Entries[0], "cached proof should not be modified")
+}
@@ -150,7 +150,8 @@ func (w *Worker) registerRuntime(commonNode *committeeCommon.Node) error {
 
 	// If we are running in stateless client mode, register remote storage.
 	if config.GlobalConfig.Mode == config.ModeStatelessClient {
-		commonNode.Runtime.RegisterStorage(NewStatelessStorage(commonNode.P2P, w.commonWorker.ChainContext, id))
+		cacheSize := uint64(config.ParseSizeInBytes(config.GlobalConfig.Storage.StatelessProofCacheSize))
+		commonNode.Runtime.RegisterStorage(NewStatelessStorage(commonNode.P2P, w.commonWorker.ChainContext, id, cacheSize))
 	}
 
 	commonNode.AddHooks(node)
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/oasisprotocol/oasis-core/go/common/version"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
 	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
 )
 
@@ -114,6 +115,8 @@ type Status struct {
 	LatestRound uint64 `json:"latest_round"`
 	// LatestHeight is the consensus layer height containing the runtime block for the latest round.
 	LatestHeight int64 `json:"latest_height"`
+	// HistoryReindex is the progress of an ongoing runtime history reindex (if any).
+	HistoryReindex *roothash.HistoryReindexProgress `json:"history_reindex,omitempty"`
 
 	// ExecutorRoles are the node's roles in the executor committee.
 	ExecutorRoles []scheduler.Role `json:"executor_roles"`
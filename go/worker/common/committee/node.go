@@ -280,6 +280,8 @@ func (n *Node) GetStatus() (*api.Status, error) {
 		status.LatestHeight = n.CurrentBlockHeight
 	}
 
+	status.HistoryReindex = n.Runtime.History().ReindexProgress()
+
 	if n.CurrentDescriptor != nil {
 		activeDeploy := n.CurrentDescriptor.ActiveDeployment(n.CurrentEpoch)
 		if activeDeploy != nil {
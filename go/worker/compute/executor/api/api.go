@@ -1,6 +1,14 @@
 package api
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+)
 
 // StatusState is the concise status state of the common runtime worker.
 type StatusState uint8
@@ -61,4 +69,39 @@ func (s *StatusState) UnmarshalText(text []byte) error {
 type Status struct {
 	// Status is a concise status of the committee node.
 	Status StatusState `json:"status"`
+
+	// CommitteeAudit is true iff committee message auditing is enabled.
+	CommitteeAudit bool `json:"committee_audit,omitempty"`
+}
+
+// CommitteeAuditRecord is an audit record of an authenticated committee message.
+type CommitteeAuditRecord struct {
+	// Sequence is the local sequence number of the record, increasing in the order in which the
+	// messages were handled.
+	Sequence uint64 `json:"seq"`
+	// Time is the time at which the message was handled.
+	Time time.Time `json:"time"`
+
+	// RuntimeID is the identifier of the runtime.
+	RuntimeID common.Namespace `json:"runtime_id"`
+	// Epoch is the epoch the message belongs to.
+	Epoch beacon.EpochTime `json:"epoch"`
+	// PeerID is the P2P public key of the committee member that published the message.
+	PeerID signature.PublicKey `json:"peer_id"`
+	// Own is true iff the message was published by the local node.
+	Own bool `json:"own,omitempty"`
+
+	// Kind is the kind of the message.
+	Kind string `json:"kind"`
+	// NodeID is the public key of the node that signed the message, if any.
+	NodeID *signature.PublicKey `json:"node_id,omitempty"`
+	// Round is the runtime round the message refers to, if any.
+	Round *uint64 `json:"round,omitempty"`
+	// PreviousHash is the hash of the block header the message is based on, if any.
+	PreviousHash *hash.Hash `json:"previous_hash,omitempty"`
+	// BatchHash is the hash of the proposed batch, if any.
+	BatchHash *hash.Hash `json:"batch_hash,omitempty"`
+
+	// Error is the error returned while handling the message, if any.
+	Error string `json:"error,omitempty"`
 }
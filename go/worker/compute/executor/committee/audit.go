@@ -0,0 +1,183 @@
+package committee
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	p2p "github.com/oasisprotocol/oasis-core/go/p2p/api"
+	"github.com/oasisprotocol/oasis-core/go/worker/compute/executor/api"
+)
+
+const (
+	// committeeAuditLogFile is the name of the committee message audit log in the runtime's data
+	// directory.
+	committeeAuditLogFile = "committee-audit.jsonl"
+
+	committeeAuditKindProposal = "proposal"
+	committeeAuditKindUnknown  = "unknown"
+)
+
+// SetCommitteeAudit enables or disables auditing of authenticated committee messages.
+func (n *Node) SetCommitteeAudit(enabled bool) error {
+	return n.audit.SetEnabled(enabled)
+}
+
+// committeeAuditor records authenticated committee messages for later investigation.
+type committeeAuditor struct {
+	mu sync.Mutex
+
+	runtimeID common.Namespace
+	enabled   bool
+	seq       uint64
+
+	path string
+	file *os.File
+
+	logger *logging.Logger
+}
+
+func newCommitteeAuditor(runtimeID common.Namespace, dataDir string, persist bool, logger *logging.Logger) *committeeAuditor {
+	a := &committeeAuditor{
+		runtimeID: runtimeID,
+		logger:    logger,
+	}
+	if persist {
+		a.path = filepath.Join(dataDir, committeeAuditLogFile)
+	}
+	return a
+}
+
+// Enabled returns true iff auditing is enabled.
+func (a *committeeAuditor) Enabled() bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.enabled
+}
+
+// SetEnabled enables or disables auditing.
+func (a *committeeAuditor) SetEnabled(enabled bool) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if enabled == a.enabled {
+		return nil
+	}
+
+	switch enabled {
+	case true:
+		if a.path != "" {
+			f, err := os.OpenFile(a.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+			if err != nil {
+				return fmt.Errorf("failed to open committee audit log: %w", err)
+			}
+			a.file = f
+		}
+	case false:
+		if err := a.closeLocked(); err != nil {
+			a.logger.Error("failed to close committee audit log",
+				"err", err,
+			)
+		}
+	}
+	a.enabled = enabled
+
+	a.logger.Info("committee message auditing toggled",
+		"enabled", enabled,
+		"audit_log", a.path,
+	)
+
+	return nil
+}
+
+// Record records the given committee message, in case auditing is enabled.
+func (a *committeeAuditor) Record(peerID signature.PublicKey, cm *p2p.CommitteeMessage, isOwn bool, handleErr error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if !a.enabled {
+		return
+	}
+
+	a.seq++
+	rec := api.CommitteeAuditRecord{
+		Sequence:  a.seq,
+		Time:      time.Now().UTC(),
+		RuntimeID: a.runtimeID,
+		Epoch:     cm.Epoch,
+		PeerID:    peerID,
+		Own:       isOwn,
+		Kind:      committeeAuditKindUnknown,
+	}
+	if p := cm.Proposal; p != nil {
+		rec.Kind = committeeAuditKindProposal
+		rec.NodeID = &p.NodeID
+		rec.Round = &p.Header.Round
+		rec.PreviousHash = &p.Header.PreviousHash
+		rec.BatchHash = &p.Header.BatchHash
+	}
+	if handleErr != nil {
+		rec.Error = handleErr.Error()
+	}
+
+	fields := []interface{}{
+		"seq", rec.Sequence,
+		"epoch", rec.Epoch,
+		"peer_id", rec.PeerID,
+		"own", rec.Own,
+		"kind", rec.Kind,
+	}
+	if p := cm.Proposal; p != nil {
+		fields = append(fields,
+			"node_id", p.NodeID,
+			"round", p.Header.Round,
+			"previous_hash", p.Header.PreviousHash,
+			"batch_hash", p.Header.BatchHash,
+		)
+	}
+	if handleErr != nil {
+		fields = append(fields, "handle_err", handleErr)
+	}
+	a.logger.Info("committee message audit", fields...)
+
+	if a.file == nil {
+		return
+	}
+	data, err := json.Marshal(&rec)
+	if err != nil {
+		a.logger.Error("failed to marshal committee audit record",
+			"err", err,
+		)
+		return
+	}
+	if _, err = a.file.Write(append(data, '\n')); err != nil {
+		a.logger.Error("failed to persist committee audit record",
+			"err", err,
+			"seq", rec.Sequence,
+		)
+	}
+}
+
+// Close closes the audit log, if any.
+func (a *committeeAuditor) Close() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.closeLocked()
+}
+
+func (a *committeeAuditor) closeLocked() error {
+	if a.file == nil {
+		return nil
+	}
+	err := a.file.Close()
+	a.file = nil
+	return err
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
+	"github.com/oasisprotocol/oasis-core/go/config"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	p2p "github.com/oasisprotocol/oasis-core/go/p2p/api"
 	p2pProtocol "github.com/oasisprotocol/oasis-core/go/p2p/protocol"
@@ -99,6 +100,8 @@ type Node struct { // nolint: maligned
 	poolRank      uint64
 	proposedBatch *proposedBatch
 
+	audit *committeeAuditor
+
 	logger *logging.Logger
 }
 
@@ -702,9 +705,11 @@ func (n *Node) proposeBatch(
 			RAKSignature: &rakSig,
 		},
 	}
-	// If we are the transaction scheduler also include all the emitted messages.
+	// If we are the transaction scheduler also include all the emitted messages and the
+	// incoming message results.
 	if ec.NodeID.Equal(ec.Header.SchedulerID) {
 		ec.Messages = batch.Messages
+		ec.InMessagesResults = batch.InMessagesResults
 	}
 
 	// Commit I/O and state write logs to storage.
@@ -1284,6 +1289,13 @@ func (n *Node) drainChannels(ctx context.Context) {
 func (n *Node) worker() {
 	defer close(n.quitCh)
 	defer (n.cancelCtx)()
+	defer func() {
+		if err := n.audit.Close(); err != nil {
+			n.logger.Error("failed to close committee audit log",
+				"err", err,
+			)
+		}
+	}()
 
 	// Wait for the common node to be initialized.
 	select {
@@ -1571,6 +1583,16 @@ func NewNode(
 		logger:           logging.GetLogger("worker/executor/committee").With("runtime_id", commonNode.Runtime.ID()),
 	}
 
+	// Configure committee message auditing.
+	auditCfg := config.GlobalConfig.Runtime.CommitteeAudit
+	n.audit = newCommitteeAuditor(commonNode.Runtime.ID(), commonNode.Runtime.DataDir(), auditCfg.Persist, n.logger)
+	if auditCfg.Enabled {
+		if err := n.audit.SetEnabled(true); err != nil {
+			cancel()
+			return nil, err
+		}
+	}
+
 	// Register prune handler.
 	commonNode.Runtime.History().Pruner().RegisterHandler(&pruneHandler{commonNode: commonNode})
 
@@ -56,9 +56,18 @@ func (h *committeeMsgHandler) AuthorizeMessage(_ context.Context, peerID signatu
 	return nil
 }
 
-func (h *committeeMsgHandler) HandleMessage(_ context.Context, _ signature.PublicKey, msg interface{}, isOwn bool) error {
+func (h *committeeMsgHandler) HandleMessage(ctx context.Context, peerID signature.PublicKey, msg interface{}, isOwn bool) (err error) {
 	cm := msg.(*p2p.CommitteeMessage) // Ensured by DecodeMessage.
 
+	// Audit all messages that passed authorization, including the outcome of handling them.
+	defer func() {
+		h.n.audit.Record(peerID, cm, isOwn, err)
+	}()
+
+	return h.handleMessage(ctx, cm, isOwn)
+}
+
+func (h *committeeMsgHandler) handleMessage(_ context.Context, cm *p2p.CommitteeMessage, isOwn bool) error {
 	switch {
 	case cm.Proposal != nil:
 		// Ignore own messages as those are handled separately.
@@ -18,6 +18,7 @@ func (n *Node) GetStatus() (*api.Status, error) {
 	default:
 		status.Status = api.StatusStateReady
 	}
+	status.CommitteeAudit = n.audit.Enabled()
 
 	return &status, nil
 }
@@ -15,6 +15,10 @@ type Config struct {
 
 	// EntityID to use as the node owner in registrations (public key).
 	EntityID string `yaml:"entity_id"`
+
+	// Metadata is the optional node metadata (e.g., operator contact, website, hardware class,
+	// region) to include in node registrations.
+	Metadata map[string]string `yaml:"metadata,omitempty"`
 }
 
 // Validate validates the configuration settings.
@@ -831,6 +831,10 @@ func (w *Worker) gatherConsensusAddresses(sentryConsensusAddrs []node.ConsensusA
 }
 
 func (w *Worker) registerNode(epoch beacon.EpochTime, hook RegisterNodeHook) (err error) {
+	if config.GlobalConfig.Common.ReadOnly {
+		return consensus.ErrReadOnly
+	}
+
 	identityPublic := w.identity.NodeSigner.Public()
 	w.logger.Info("performing node (re-)registration",
 		"epoch", epoch,
@@ -855,6 +859,7 @@ func (w *Worker) registerNode(epoch beacon.EpochTime, hook RegisterNodeHook) (er
 			ID: w.identity.VRFSigner.Public(),
 		},
 		SoftwareVersion: node.SoftwareVersion(version.SoftwareVersion),
+		Metadata:        node.Metadata(config.GlobalConfig.Registration.Metadata),
 	}
 
 	// Update the registration status on successful or failed registration.
@@ -916,6 +921,7 @@ func (w *Worker) registerNode(epoch beacon.EpochTime, hook RegisterNodeHook) (er
 	// Add P2P Addresses if required.
 	if nodeDesc.HasRoles(registry.P2PAddressRequiredRoles) {
 		nodeDesc.P2P.Addresses = w.p2p.Addresses()
+		nodeDesc.P2P.Relays = w.p2p.Relays()
 	}
 
 	nodeSigners := []signature.Signer{
@@ -1009,7 +1015,7 @@ func (w *Worker) RequestDeregistration() error {
 
 // WillNeverRegister returns true iff the worker will never register.
 func (w *Worker) WillNeverRegister() bool {
-	return !w.entityID.IsValid() || w.registrationSigner == nil
+	return config.GlobalConfig.Common.ReadOnly || !w.entityID.IsValid() || w.registrationSigner == nil
 }
 
 // GetRegistrationSigner loads the signing credentials as configured by this package's flags.
@@ -1075,8 +1081,12 @@ func New(
 	}
 
 	var storedDeregister bool
-	err = serviceStore.GetCBOR(deregistrationRequestStoreKey, &storedDeregister)
-	if err != nil && err != persistent.ErrNotFound {
+	deregister, err := newDeregistrationStore(serviceStore).Get(deregistrationRequestStoreKey)
+	switch err {
+	case nil:
+		storedDeregister = *deregister
+	case persistent.ErrNotFound:
+	default:
 		return nil, err
 	}
 
@@ -1128,7 +1138,11 @@ func (w *Worker) Start() error {
 
 	// HACK: This can be ok in certain configurations.
 	if w.WillNeverRegister() {
-		w.logger.Warn("no entity/signer for this node, registration will NEVER succeed")
+		if config.GlobalConfig.Common.ReadOnly {
+			w.logger.Info("node is in read-only mode, registration is disabled")
+		} else {
+			w.logger.Warn("no entity/signer for this node, registration will NEVER succeed")
+		}
 		// Make sure the node is stopped on quit and that it can still respond to
 		// shutdown requests from the control api.
 		go func() {
@@ -1173,6 +1187,14 @@ func init() {
 	})
 }
 
+// SetForcedDeregister persists whether the node should be deregistered.
 func SetForcedDeregister(store *persistent.ServiceStore, deregister bool) error {
-	return store.PutCBOR(deregistrationRequestStoreKey, &deregister)
+	return newDeregistrationStore(store).Put(deregistrationRequestStoreKey, &deregister)
+}
+
+func newDeregistrationStore(store *persistent.ServiceStore) *persistent.TypedStore[bool] {
+	// Version 0 is the unversioned flag persisted by earlier versions which has the same
+	// serialization as version 1.
+	return persistent.NewTypedStore[bool](store, 1).
+		WithMigration(0, func(raw []byte) ([]byte, error) { return raw, nil })
 }
@@ -0,0 +1,36 @@
+package registration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/config"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+)
+
+func TestReadOnly(t *testing.T) {
+	require := require.New(t)
+
+	signer := memorySigner.NewTestSigner("registration test signer")
+	w := &Worker{
+		entityID:           signer.Public(),
+		registrationSigner: signer,
+	}
+	require.False(w.WillNeverRegister(), "worker with a registration signer should register")
+
+	config.GlobalConfig.Common.ReadOnly = true
+	defer func() {
+		config.GlobalConfig.Common.ReadOnly = false
+	}()
+
+	require.True(w.WillNeverRegister(), "worker should never register in read-only mode")
+	err := w.registerNode(0, nil)
+	require.ErrorIs(err, consensus.ErrReadOnly, "registration should be rejected in read-only mode")
+
+	w.registrationSigner = nil
+	w.entityID = signature.PublicKey{}
+	require.True(w.WillNeverRegister())
+}
@@ -10,6 +10,9 @@ import (
 	"sync"
 	"time"
 
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	roothashApi "github.com/oasisprotocol/oasis-core/go/roothash/api"
 	storageApi "github.com/oasisprotocol/oasis-core/go/storage/api"
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/checkpoint"
 	storageSync "github.com/oasisprotocol/oasis-core/go/worker/storage/p2p/sync"
@@ -289,10 +292,18 @@ func (n *Node) getCheckpointList() ([]*storageSync.Checkpoint, error) {
 		return nil, err
 	}
 
+	// Prefer checkpoints with manifests published in the consensus layer as their roots have
+	// been verified against the runtime's past roots.
+	published := n.getPublishedCheckpoints(ctx)
+
 	// Sort checkpoints by version, descending.
 	sort.Slice(list, func(i, j int) bool {
 		// Descending!
 		if list[j].Root.Version == list[i].Root.Version {
+			pi, pj := published[list[i].EncodedHash()], published[list[j].EncodedHash()]
+			if pi != pj {
+				return pi
+			}
 			return bytes.Compare(list[j].Root.Hash[:], list[i].Root.Hash[:]) < 0
 		}
 		return list[j].Root.Version < list[i].Root.Version
@@ -300,6 +311,27 @@ func (n *Node) getCheckpointList() ([]*storageSync.Checkpoint, error) {
 	return list, nil
 }
 
+// getPublishedCheckpoints returns the set of checkpoint metadata hashes for which manifests have
+// been published in the consensus layer.
+func (n *Node) getPublishedCheckpoints(ctx context.Context) map[hash.Hash]bool {
+	manifests, err := n.commonNode.Consensus.RootHash().GetCheckpointManifests(ctx, &roothashApi.RuntimeRequest{
+		RuntimeID: n.commonNode.Runtime.ID(),
+		Height:    consensus.HeightLatest,
+	})
+	if err != nil {
+		n.logger.Debug("failed to retrieve published checkpoint manifests",
+			"err", err,
+		)
+		return nil
+	}
+
+	published := make(map[hash.Hash]bool, len(manifests))
+	for _, m := range manifests {
+		published[m.Hash] = true
+	}
+	return published
+}
+
 func (n *Node) checkCheckpointUsable(cp *storageSync.Checkpoint, remainingMask outstandingMask, genesisRound uint64) bool {
 	namespace := n.commonNode.Runtime.ID()
 	if !namespace.Equal(&cp.Root.Namespace) {
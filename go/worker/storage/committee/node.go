@@ -132,6 +132,8 @@ type Node struct { // nolint: maligned
 	checkpointer         checkpoint.Checkpointer
 	checkpointSyncCfg    *CheckpointSyncConfig
 	checkpointSyncForced bool
+	// checkpointDeferredSince is only accessed from the checkpointer worker.
+	checkpointDeferredSince time.Time
 
 	syncedLock  sync.RWMutex
 	syncedState blockSummary
@@ -244,6 +246,12 @@ func NewNode(
 			return blk.Header.StorageRoots(), nil
 		},
 	}
+	if config.GlobalConfig.Storage.Checkpointer.DeferDuringExecutorDuty {
+		checkpointerCfg.ShouldDefer = n.shouldDeferCheckpoint
+	}
+	if config.GlobalConfig.Storage.Checkpointer.PublishManifests {
+		checkpointerCfg.OnCheckpointCreated = n.publishCheckpointManifests
+	}
 	var err error
 	n.checkpointer, err = checkpoint.NewCheckpointer(
 		n.ctx,
@@ -338,6 +346,50 @@ func (n *Node) PauseCheckpointer(pause bool) error {
 	return nil
 }
 
+// shouldDeferCheckpoint returns true iff checkpoint creation should be deferred as the node is
+// a member of the executor committee and could otherwise miss its duties.
+func (n *Node) shouldDeferCheckpoint() bool {
+	epoch := n.commonNode.Group.GetEpochSnapshot()
+	if !epoch.IsExecutorMember() {
+		n.checkpointDeferredSince = time.Time{}
+		return false
+	}
+
+	now := time.Now()
+	if n.checkpointDeferredSince.IsZero() {
+		n.checkpointDeferredSince = now
+	}
+	maxDeferral := config.GlobalConfig.Storage.Checkpointer.MaxDeferral
+	if maxDeferral > 0 && now.Sub(n.checkpointDeferredSince) >= maxDeferral {
+		n.logger.Info("checkpoint creation deferred for too long, no longer deferring",
+			"deferred_since", n.checkpointDeferredSince,
+		)
+		n.checkpointDeferredSince = time.Time{}
+		return false
+	}
+	return true
+}
+
+// publishCheckpointManifests publishes the manifests of the given checkpoints in the consensus
+// layer so that syncing nodes can discover them.
+func (n *Node) publishCheckpointManifests(ctx context.Context, metas []*checkpoint.Metadata) {
+	for _, meta := range metas {
+		tx := roothashApi.NewPublishCheckpointTx(0, nil, roothashApi.NewPublishCheckpointFromMetadata(meta))
+		if err := consensus.SignAndSubmitTx(ctx, n.commonNode.Consensus, n.commonNode.Identity.NodeSigner, tx); err != nil {
+			n.logger.Warn("failed to publish checkpoint manifest",
+				"root", meta.Root,
+				"err", err,
+			)
+			continue
+		}
+
+		n.logger.Debug("published checkpoint manifest",
+			"root", meta.Root,
+			"num_chunks", len(meta.Chunks),
+		)
+	}
+}
+
 // GetLocalStorage returns the local storage backend used by this storage node.
 func (n *Node) GetLocalStorage() storageApi.LocalBackend {
 	return n.localStorage
@@ -2,6 +2,7 @@
 package config
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/db"
@@ -13,6 +14,9 @@ type Config struct {
 	Backend string `yaml:"backend"`
 	// Maximum in-memory cache size.
 	MaxCacheSize string `yaml:"max_cache_size"`
+	// Maximum size of the cache of verified remote storage proofs in stateless client mode
+	// (zero disables the cache).
+	StatelessProofCacheSize string `yaml:"stateless_proof_cache_size,omitempty"`
 	// Number of concurrent storage diff fetchers.
 	FetcherCount uint `yaml:"fetcher_count"`
 
@@ -31,10 +35,19 @@ type CheckpointerConfig struct {
 	Enabled bool `yaml:"enabled"`
 	// Storage checkpointer check interval.
 	CheckInterval time.Duration `yaml:"check_interval"`
+	// Defer checkpoint creation while the node is a member of the runtime's executor committee.
+	DeferDuringExecutorDuty bool `yaml:"defer_during_executor_duty,omitempty"`
+	// Maximum duration for which checkpoint creation can be deferred (zero means no limit).
+	MaxDeferral time.Duration `yaml:"max_deferral,omitempty"`
+	// Publish manifests of created checkpoints in the consensus layer.
+	PublishManifests bool `yaml:"publish_manifests,omitempty"`
 }
 
 // Validate validates the configuration settings.
 func (c *Config) Validate() error {
+	if c.Checkpointer.MaxDeferral < 0 {
+		return fmt.Errorf("checkpointer.max_deferral must not be negative")
+	}
 	if c.Backend != "auto" {
 		_, err := db.GetBackendByName(c.Backend)
 		return err
@@ -45,14 +58,18 @@ func (c *Config) Validate() error {
 // DefaultConfig returns the default configuration settings.
 func DefaultConfig() Config {
 	return Config{
-		Backend:                "auto",
-		MaxCacheSize:           "64mb",
-		FetcherCount:           4,
-		PublicRPCEnabled:       false,
-		CheckpointSyncDisabled: false,
+		Backend:                 "auto",
+		MaxCacheSize:            "64mb",
+		StatelessProofCacheSize: "0",
+		FetcherCount:            4,
+		PublicRPCEnabled:        false,
+		CheckpointSyncDisabled:  false,
 		Checkpointer: CheckpointerConfig{
-			Enabled:       false,
-			CheckInterval: 1 * time.Minute,
+			Enabled:                 false,
+			CheckInterval:           1 * time.Minute,
+			DeferDuringExecutorDuty: false,
+			MaxDeferral:             30 * time.Minute,
+			PublishManifests:        false,
 		},
 	}
 }
@@ -1,3 +1,9 @@
+//! Consensus beacon structures.
+use crate::common::{crypto::hash::Hash, namespace::Namespace};
+
+/// Domain separation context used when deriving round entropy.
+pub const ROUND_ENTROPY_CONTEXT: &[u8] = b"oasis-core/runtime: round entropy";
+
 /// The number of intervals (epochs) since a fixed instant in time/block height (epoch date/height).
 pub type EpochTime = u64;
 
@@ -10,3 +16,16 @@ pub struct EpochTimeState {
     pub epoch: EpochTime,
     pub height: i64,
 }
+
+/// Derive the entropy for the given runtime round from the consensus beacon.
+///
+/// The result only depends on its arguments so all executors of the same round derive the same
+/// value. The entropy is public and must not be used for secrets.
+pub fn derive_round_entropy(runtime_id: &Namespace, round: u64, beacon: &[u8]) -> Hash {
+    Hash::digest_bytes_list(&[
+        ROUND_ENTROPY_CONTEXT,
+        runtime_id.as_ref(),
+        &round.to_be_bytes(),
+        beacon,
+    ])
+}
@@ -388,6 +388,10 @@ pub struct Node {
     /// Node's oasis-node software version.
     #[cbor(optional)]
     pub software_version: Option<String>,
+
+    /// Optional operator-provided node metadata.
+    #[cbor(optional)]
+    pub metadata: BTreeMap<String, String>,
 }
 
 impl Node {
@@ -573,6 +577,11 @@ pub struct RuntimeStakingParameters {
     /// message to be queued.
     #[cbor(optional)]
     pub min_in_message_fee: quantity::Quantity,
+
+    /// The maximum number of executor discrepancies a node may cause in an epoch before it is
+    /// slashed for repeated discrepancies. Zero disables the check.
+    #[cbor(optional)]
+    pub max_discrepancies: u64,
 }
 
 /// Policy that allows only whitelisted entities' nodes to register.
@@ -593,6 +602,11 @@ pub struct EntityWhitelistConfig {
     /// means no nodes allowed), any missing roles imply zero nodes.
     #[cbor(optional)]
     pub max_nodes: BTreeMap<RolesMask, u16>,
+
+    /// Epoch at which the entity is removed from the whitelist. Zero means
+    /// that the entity never expires.
+    #[cbor(optional)]
+    pub expiration: EpochTime,
 }
 
 /// A per-entity whitelist configuration for a given role.
@@ -903,6 +917,7 @@ fn staking_params_are_empty(p: &RuntimeStakingParameters) -> bool {
         && p.reward_equivocation == 0
         && p.reward_bad_results == 0
         && p.min_in_message_fee.is_zero()
+        && p.max_discrepancies == 0
 }
 
 impl Runtime {
@@ -988,6 +1003,7 @@ mod tests {
                         reward_equivocation: 0,
                         reward_bad_results: 0,
                         min_in_message_fee: Quantity::from(0u32),
+                        max_discrepancies: 0,
                     },
                     ..Default::default()
                 },
@@ -1006,6 +1022,7 @@ mod tests {
                         reward_equivocation: 0,
                         reward_bad_results: 10,
                         min_in_message_fee: Quantity::from(0u32),
+                        max_discrepancies: 0,
                     },
                     ..Default::default()
                 },
@@ -1098,6 +1115,7 @@ mod tests {
                         reward_equivocation: 0,
                         reward_bad_results: 10,
                         min_in_message_fee: Quantity::from(0u32),
+                        max_discrepancies: 0,
                     },
                     governance_model: RuntimeGovernanceModel::GovernanceConsensus,
                 },
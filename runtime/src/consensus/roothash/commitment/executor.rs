@@ -13,7 +13,7 @@ use crate::{
         },
         namespace::Namespace,
     },
-    consensus::roothash::{Header, Message},
+    consensus::roothash::{Header, IncomingMessageResult, Message},
 };
 
 use super::OpenCommitment;
@@ -65,6 +65,10 @@ pub struct ComputeResultsHeader {
     /// The number of processed incoming messages.
     #[cbor(optional)]
     pub in_msgs_count: u32,
+    /// The hash of the results of processed incoming messages. It is absent in case the runtime
+    /// does not report incoming message results.
+    #[cbor(optional)]
+    pub in_msgs_results_hash: Option<Hash>,
 }
 
 impl ComputeResultsHeader {
@@ -156,6 +160,14 @@ pub struct ExecutorCommitment {
     // the commitment being submitted as equivocation evidence, this field should be omitted.
     #[cbor(optional)]
     pub messages: Vec<Message>,
+
+    // The results of processing incoming messages by the runtime.
+    //
+    // This field is only present in case this commitment belongs to the proposer and the runtime
+    // reports incoming message results. In case of the commitment being submitted as
+    // equivocation evidence, this field should be omitted.
+    #[cbor(optional)]
+    pub in_msgs_results: Vec<IncomingMessageResult>,
 }
 
 impl ExecutorCommitment {
@@ -229,9 +241,10 @@ impl ExecutorCommitment {
                 }
                 if self.header.header.in_msgs_hash.is_some()
                     || self.header.header.in_msgs_count != 0
+                    || self.header.header.in_msgs_results_hash.is_some()
                 {
                     return Err(anyhow!(
-                        "failure indicating commitment includes InMessagesHash/Count"
+                        "failure indicating commitment includes InMessagesHash/Count/ResultsHash"
                     ));
                 }
                 // In case of failure indicating commitment make sure RAK signature is empty.
@@ -239,7 +252,7 @@ impl ExecutorCommitment {
                     return Err(anyhow!("failure indicating body includes RAK signature"));
                 }
                 // In case of failure indicating commitment make sure messages are empty.
-                if !self.messages.is_empty() {
+                if !self.messages.is_empty() || !self.in_msgs_results.is_empty() {
                     return Err(anyhow!("failure indicating body includes messages"));
                 }
             }
@@ -288,6 +301,7 @@ mod tests {
             messages_hash: Some(Hash::empty_hash()),
             in_msgs_hash: Some(Hash::empty_hash()),
             in_msgs_count: 0,
+            in_msgs_results_hash: None,
         };
         assert_eq!(
             populated.encoded_hash(),
@@ -314,11 +328,13 @@ mod tests {
                     messages_hash: Some(Hash::empty_hash()),
                     in_msgs_hash: Some(Hash::empty_hash()),
                     in_msgs_count: 0,
+                    in_msgs_results_hash: None,
                 },
                 failure: ExecutorCommitmentFailure::FailureNone,
                 rak_signature: None,
             },
             messages: vec![],
+            in_msgs_results: vec![],
             node_id: PublicKey::default(),
             signature: Signature::default(),
         };
@@ -633,6 +633,7 @@ mod tests {
                     messages_hash: Some(msgs_hash),
                     in_msgs_hash: Some(in_msgs_hash),
                     in_msgs_count: 0,
+                    in_msgs_results_hash: None,
                 },
                 failure: ExecutorCommitmentFailure::FailureNone,
                 rak_signature: None,
@@ -640,6 +641,7 @@ mod tests {
             node_id: PublicKey::default(),
             signature: Signature::default(),
             messages: vec![],
+            in_msgs_results: vec![],
         };
 
         (child_blk, parent_blk, ec)
@@ -165,6 +165,33 @@ impl IncomingMessage {
     }
 }
 
+/// Result of processing an incoming message by the runtime.
+#[derive(Clone, Debug, Default, PartialEq, Eq, Hash, cbor::Encode, cbor::Decode)]
+pub struct IncomingMessageResult {
+    /// Module name of the error returned by the runtime, if any.
+    #[cbor(optional)]
+    pub module: String,
+    /// Error code returned by the runtime, if any.
+    #[cbor(optional)]
+    pub code: u32,
+}
+
+impl IncomingMessageResult {
+    /// Returns true if the incoming message was successfully processed.
+    pub fn is_success(&self) -> bool {
+        self.code == 0
+    }
+
+    /// Returns a hash of provided incoming runtime message results.
+    pub fn in_messages_results_hash(results: &[IncomingMessageResult]) -> Hash {
+        if results.is_empty() {
+            // Special case if there are no results.
+            return Hash::empty_hash();
+        }
+        Hash::digest_bytes(&cbor::to_vec(results.to_vec()))
+    }
+}
+
 #[cfg(test)]
 mod tests {
     use std::collections::BTreeMap;
@@ -11,7 +11,7 @@ use crate::{
         crypto::{hash::Hash, signature::PublicKey},
         namespace::Namespace,
     },
-    consensus::{registry::Runtime, scheduler::Committee, state::StateError},
+    consensus::{address::Address, registry::Runtime, scheduler::Committee, state::StateError},
 };
 
 // Modules.
@@ -111,6 +111,23 @@ impl MessageEvent {
     }
 }
 
+/// Result of an incoming message being processed by the runtime.
+#[derive(Clone, Debug, Default, PartialEq, Eq, cbor::Encode, cbor::Decode)]
+pub struct InMsgProcessedEvent {
+    /// Unique incoming message identifier.
+    pub id: u64,
+    /// Round where the incoming message was processed.
+    pub round: u64,
+    /// Incoming message submitter address.
+    pub caller: Address,
+    /// Optional tag provided by the caller.
+    #[cbor(optional)]
+    pub tag: u64,
+    /// Result of processing the incoming message, if reported by the runtime.
+    #[cbor(optional)]
+    pub result: Option<IncomingMessageResult>,
+}
+
 /// Per-runtime state.
 #[derive(Clone, Debug, Default, PartialEq, Eq, cbor::Encode, cbor::Decode)]
 #[cbor(allow_unknown)]
@@ -171,6 +188,15 @@ pub struct LivenessStatistics {
     /// The list is ordered according to the committee arrangement (i.e., the counter at index i
     /// holds the value for the node at index i in the committee).
     pub missed_proposals: Vec<u64>,
+
+    /// A list that records the number of rounds in which a node submitted a commitment that
+    /// disagreed with the result of discrepancy resolution.
+    ///
+    /// The list is ordered according to the committee arrangement (i.e., the counter at index i
+    /// holds the value for the node at index i in the committee). It is only populated for
+    /// runtimes that slash nodes for repeated discrepancies.
+    #[cbor(optional)]
+    pub discrepancies_caused: Vec<u64>,
 }
 
 /// Information about how a particular round was executed by the consensus layer.
@@ -180,6 +206,10 @@ pub struct RoundResults {
     #[cbor(optional)]
     pub messages: Vec<MessageEvent>,
 
+    /// Results of processing incoming runtime messages.
+    #[cbor(optional)]
+    pub in_messages: Vec<InMsgProcessedEvent>,
+
     /// Public keys of compute nodes' controlling entities that positively contributed to the round
     /// by replicating the computation correctly.
     #[cbor(optional)]
@@ -232,6 +262,7 @@ mod tests {
                     bad_compute_entities: vec![
                         "0000000000000000000000000000000000000000000000000000000000000001".into(),
                     ],
+                    ..Default::default()
                 }),
         ];
         for (encoded_base64, rr) in tcs {
@@ -78,6 +78,25 @@ pub struct GeneralAccount {
 
     #[cbor(optional)]
     pub allowances: BTreeMap<Address, Quantity>,
+
+    #[cbor(optional)]
+    pub allowance_limits: BTreeMap<Address, AllowanceLimits>,
+}
+
+/// Optional limits bounding the exposure of a beneficiary allowance.
+#[derive(Clone, Debug, Default, PartialEq, Eq, Hash, cbor::Encode, cbor::Decode)]
+pub struct AllowanceLimits {
+    #[cbor(optional)]
+    pub expiration: EpochTime,
+
+    #[cbor(optional)]
+    pub epoch_limit: Quantity,
+
+    #[cbor(optional)]
+    pub epoch: EpochTime,
+
+    #[cbor(optional)]
+    pub withdrawn: Quantity,
 }
 
 /// Escrow account.
@@ -182,6 +201,9 @@ pub enum SlashReason {
     RuntimeEquivocation = 0x81,
     /// Slashing due to not doing the required work.
     RuntimeLiveness = 0x82,
+    /// Slashing due to causing more executor discrepancies in an epoch than allowed by the
+    /// runtime.
+    RuntimeRepeatedDiscrepancy = 0x83,
 }
 
 /// Per-reason slashing configuration.
@@ -25,6 +25,7 @@ impl<'a, T: ImmutableMKVS> ImmutableState<'a, T> {
 
 key_format!(CurrentEpochKeyFmt, 0x40, ());
 key_format!(FutureEpochKeyFmt, 0x41, ());
+key_format!(BeaconKeyFmt, 0x42, ());
 
 impl<'a, T: ImmutableMKVS> ImmutableState<'a, T> {
     /// Returns the current epoch number.
@@ -62,6 +63,15 @@ impl<'a, T: ImmutableMKVS> ImmutableState<'a, T> {
             Err(err) => Err(StateError::Unavailable(anyhow!(err))),
         }
     }
+
+    /// Returns the current random beacon.
+    pub fn beacon(&self) -> Result<Vec<u8>, StateError> {
+        match self.mkvs.get(&BeaconKeyFmt(()).encode()) {
+            Ok(Some(b)) => Ok(b),
+            Ok(None) => Err(StateError::Unavailable(anyhow!("beacon not available"))),
+            Err(err) => Err(StateError::Unavailable(anyhow!(err))),
+        }
+    }
 }
 
 /// Mutable consensus beacon state wrapper.
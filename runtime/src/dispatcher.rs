@@ -714,6 +714,13 @@ impl Dispatcher {
                 &in_msgs[..results.in_msgs_count],
             )),
             in_msgs_count: results.in_msgs_count.try_into().unwrap(),
+            in_msgs_results_hash: if results.in_msgs_results.is_empty() {
+                None
+            } else {
+                Some(roothash::IncomingMessageResult::in_messages_results_hash(
+                    &results.in_msgs_results,
+                ))
+            },
         };
 
         debug!(self.logger, "Transaction batch execution complete";
@@ -739,6 +746,7 @@ impl Dispatcher {
                 state_write_log,
                 rak_sig,
                 messages: results.messages,
+                in_msgs_results: results.in_msgs_results,
             },
             tx_hashes: hashes,
             tx_reject_hashes: results.tx_reject_hashes,
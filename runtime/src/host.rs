@@ -3,7 +3,11 @@ use async_trait::async_trait;
 use thiserror::Error;
 
 use crate::{
-    common::{crypto::signature::PublicKey, namespace::Namespace},
+    common::{
+        crypto::{hash::Hash, signature::PublicKey},
+        namespace::Namespace,
+    },
+    consensus::beacon::derive_round_entropy,
     protocol::Protocol,
     storage::mkvs::sync,
     types::{self, Body},
@@ -63,6 +67,23 @@ pub trait Host: Send + Sync {
 
     /// Register for receiving notifications.
     async fn register_notify(&self, opts: RegisterNotifyOpts) -> Result<(), Error>;
+
+    /// Returns the UNIX timestamp (in seconds) of the consensus block at the given height.
+    ///
+    /// The time only depends on the height so all runtime instances asking for the same height
+    /// observe the same value. As the value is provided by the untrusted host, it should be
+    /// verified against a verified consensus block when used in state transitions.
+    async fn consensus_time(&self, height: u64) -> Result<u64, Error>;
+
+    /// Returns the entropy for the given round, derived from the consensus beacon at the given
+    /// height.
+    ///
+    /// The entropy only depends on the runtime identifier, the round and the beacon so all
+    /// executors of the same round observe the same value. As the beacon is provided by the
+    /// untrusted host, it should be verified against verified consensus state (see
+    /// `consensus::state::beacon::ImmutableState::beacon`) when used in state transitions. The
+    /// entropy is public and must not be used for secrets.
+    async fn round_entropy(&self, height: u64, round: u64) -> Result<(Vec<u8>, Hash), Error>;
 }
 
 #[async_trait]
@@ -125,4 +146,30 @@ impl Host for Protocol {
             _ => Err(Error::BadResponse),
         }
     }
+
+    async fn consensus_time(&self, height: u64) -> Result<u64, Error> {
+        match self
+            .call_host_async(Body::HostFetchConsensusTimeRequest { height })
+            .await?
+        {
+            Body::HostFetchConsensusTimeResponse { time } => Ok(time),
+            _ => Err(Error::BadResponse),
+        }
+    }
+
+    async fn round_entropy(&self, height: u64, round: u64) -> Result<(Vec<u8>, Hash), Error> {
+        match self
+            .call_host_async(Body::HostFetchRoundEntropyRequest { height, round })
+            .await?
+        {
+            Body::HostFetchRoundEntropyResponse { beacon, entropy } => {
+                // Make sure the entropy was derived from the returned beacon.
+                if entropy != derive_round_entropy(&self.get_runtime_id(), round, &beacon) {
+                    return Err(Error::BadResponse);
+                }
+                Ok((beacon, entropy))
+            }
+            _ => Err(Error::BadResponse),
+        }
+    }
 }
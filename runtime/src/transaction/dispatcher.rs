@@ -180,6 +180,7 @@ pub struct ExecuteTxResult {
 }
 
 /// Result of processing a batch of ExecuteTx.
+#[derive(Default)]
 pub struct ExecuteBatchResult {
     /// Per-transaction execution results.
     pub results: Vec<ExecuteTxResult>,
@@ -187,6 +188,11 @@ pub struct ExecuteBatchResult {
     pub messages: Vec<roothash::Message>,
     /// Number of processed incoming messages.
     pub in_msgs_count: usize,
+    /// Results of processing incoming messages.
+    ///
+    /// If non-empty, there must be exactly one result for each processed incoming message. If
+    /// empty, no incoming message results are reported to the consensus layer.
+    pub in_msgs_results: Vec<roothash::IncomingMessageResult>,
     /// Block emitted tags (not emitted by a specific transaction).
     pub block_tags: Tags,
     /// Hashes of transactions to reject.
@@ -218,6 +224,7 @@ impl Dispatcher for NoopDispatcher {
             messages: Vec::new(),
             block_tags: Tags::new(),
             in_msgs_count: in_msgs.len(),
+            in_msgs_results: Vec::new(),
             tx_reject_hashes: Vec::new(),
         })
     }
@@ -233,6 +240,7 @@ impl Dispatcher for NoopDispatcher {
             messages: Vec::new(),
             block_tags: Tags::new(),
             in_msgs_count: in_msgs.len(),
+            in_msgs_results: Vec::new(),
             tx_reject_hashes: Vec::new(),
         })
     }
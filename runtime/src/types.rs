@@ -42,6 +42,9 @@ pub struct ComputedBatch {
     pub rak_sig: Signature,
     /// Messages emitted by the runtime.
     pub messages: Vec<roothash::Message>,
+    /// Results of processing incoming messages, if reported by the runtime.
+    #[cbor(optional)]
+    pub in_msgs_results: Vec<roothash::IncomingMessageResult>,
 }
 
 /// Storage sync request.
@@ -285,6 +288,20 @@ pub enum Body {
     HostFetchGenesisHeightResponse {
         height: u64,
     },
+    HostFetchConsensusTimeRequest {
+        height: u64,
+    },
+    HostFetchConsensusTimeResponse {
+        time: u64,
+    },
+    HostFetchRoundEntropyRequest {
+        height: u64,
+        round: u64,
+    },
+    HostFetchRoundEntropyResponse {
+        beacon: Vec<u8>,
+        entropy: Hash,
+    },
     HostProveFreshnessRequest {
         blob: Vec<u8>,
     },
@@ -1,3 +0,0 @@
-#!/bin/bash
-
-exec ./scripts/e2e-coverage-wrapper-arg.sh ${E2E_COVERAGE_BINARY} "$@"
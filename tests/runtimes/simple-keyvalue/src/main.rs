@@ -14,7 +14,7 @@ use oasis_core_runtime::{
     common::{crypto::hash::Hash, version::Version},
     config::Config,
     consensus::{
-        roothash::{IncomingMessage, Message},
+        roothash::{IncomingMessage, IncomingMessageResult, Message},
         verifier::{TrustRoot, Verifier},
     },
     dispatcher::{PostInitState, PreInitState},
@@ -133,6 +133,21 @@ impl Dispatcher {
     fn dispatch_tx(ctx: &mut TxContext, tx: Call) -> Result<cbor::Value, String> {
         Methods::check_nonce(ctx, tx.nonce)?;
 
+        if tx.method == "encrypted" {
+            // The nonce of the outer call protects against replays, so the encrypted call's
+            // nonce is ignored.
+            return Self::dispatch_call(ctx, tx.args, |ctx, args| {
+                Methods::encrypted_call(ctx, args, |ctx, call| match call.method.as_str() {
+                    "encrypted" => Err("nested encrypted calls not allowed".to_string()),
+                    _ => Self::dispatch_method(ctx, call),
+                })
+            });
+        }
+
+        Self::dispatch_method(ctx, tx)
+    }
+
+    fn dispatch_method(ctx: &mut TxContext, tx: Call) -> Result<cbor::Value, String> {
         match tx.method.as_str() {
             "get_runtime_id" => Self::dispatch_call(ctx, tx.args, Methods::get_runtime_id),
             "consensus_accounts" => Self::dispatch_call(ctx, tx.args, Methods::consensus_accounts),
@@ -146,6 +161,7 @@ impl Dispatcher {
             }
             "update_runtime" => Self::dispatch_call(ctx, tx.args, Methods::update_runtime),
             "insert" => Self::dispatch_call(ctx, tx.args, Methods::insert),
+            "insert_many" => Self::dispatch_call(ctx, tx.args, Methods::insert_many),
             "get" => Self::dispatch_call(ctx, tx.args, Methods::get),
             "remove" => Self::dispatch_call(ctx, tx.args, Methods::remove),
             "enc_insert" => Self::dispatch_call(ctx, tx.args, Methods::enc_insert_using_secrets),
@@ -187,9 +203,26 @@ impl Dispatcher {
         }
     }
 
-    fn execute_in_msg(ctx: &mut Context<'_, '_>, msg: &IncomingMessage) {
-        // Process incoming messages as transactions and ignore results.
-        let _ = Self::execute_tx(ctx, &msg.data);
+    fn execute_in_msg(ctx: &mut Context<'_, '_>, msg: &IncomingMessage) -> IncomingMessageResult {
+        // Process incoming messages as transactions and report whether they succeeded.
+        let tx = match Self::decode_tx(&msg.data) {
+            Ok(tx) => tx,
+            Err(_) => {
+                return IncomingMessageResult {
+                    module: "test".to_string(),
+                    code: 1,
+                }
+            }
+        };
+
+        let mut tx_ctx = TxContext::new(ctx, false);
+        match Self::dispatch_tx(&mut tx_ctx, tx) {
+            Ok(_) => IncomingMessageResult::default(),
+            Err(_) => IncomingMessageResult {
+                module: "test".to_string(),
+                code: 2,
+            },
+        }
     }
 
     fn check_tx(ctx: &mut Context<'_, '_>, tx: &[u8]) -> Result<CheckTxResult, RuntimeError> {
@@ -299,9 +332,10 @@ impl TxnDispatcher for Dispatcher {
 
         // Execute incoming messages. A real implementation should allocate resources for incoming
         // messages and only execute as many messages as fits.
-        for in_msg in in_msgs {
-            Self::execute_in_msg(&mut ctx, in_msg);
-        }
+        let in_msgs_results = in_msgs
+            .iter()
+            .map(|in_msg| Self::execute_in_msg(&mut ctx, in_msg))
+            .collect();
 
         // Execute transactions.
         let mut results = vec![];
@@ -313,6 +347,7 @@ impl TxnDispatcher for Dispatcher {
             results,
             messages: ctx.messages,
             in_msgs_count: in_msgs.len(),
+            in_msgs_results,
             block_tags: vec![],
             tx_reject_hashes: vec![],
         })
@@ -336,9 +371,10 @@ impl TxnDispatcher for Dispatcher {
 
         // Execute incoming messages. A real implementation should allocate resources for incoming
         // messages and only execute as many messages as fits.
-        for in_msg in in_msgs {
-            Self::execute_in_msg(&mut ctx, in_msg);
-        }
+        let in_msgs_results = in_msgs
+            .iter()
+            .map(|in_msg| Self::execute_in_msg(&mut ctx, in_msg))
+            .collect();
 
         // Execute transactions.
         // TODO: Actually do some batch reordering.
@@ -367,6 +403,7 @@ impl TxnDispatcher for Dispatcher {
             results,
             messages: ctx.messages,
             in_msgs_count: in_msgs.len(),
+            in_msgs_results,
             block_tags: vec![],
             tx_reject_hashes,
         })
@@ -29,6 +29,14 @@ use oasis_core_runtime::{
     types::{Error as RuntimeError, EventKind},
 };
 
+/// Additional data used when sealing encrypted calls.
+const ENCRYPTED_CALL_CONTEXT: &[u8] = b"simple-keyvalue: encrypted call";
+/// Additional data used when sealing encrypted call results.
+const ENCRYPTED_CALL_RESULT_CONTEXT: &[u8] = b"simple-keyvalue: encrypted call result";
+
+/// Maximum number of key/value pairs inserted by a single insert_many call.
+const INSERT_MANY_MAX_COUNT: u64 = 10_000;
+
 /// Implementation of the transaction methods supported by the test runtime.
 pub struct Methods;
 
@@ -223,6 +231,40 @@ impl Methods {
             .map_err(|err| err.to_string())
     }
 
+    /// Insert many generated key/value pairs.
+    ///
+    /// Keys are derived from the prefix and the index of the pair, and values by repeating
+    /// the key up to the requested size, so that the resulting state can be verified
+    /// without knowing the transactions.
+    pub fn insert_many(ctx: &mut TxContext, args: InsertMany) -> Result<u64, String> {
+        if args.count > INSERT_MANY_MAX_COUNT {
+            return Err("Too many key/value pairs to be inserted.".to_string());
+        }
+        if args.value_size > 128 {
+            return Err("Value too big to be inserted.".to_string());
+        }
+        if ctx.is_check_only() {
+            return Ok(0);
+        }
+        ctx.emit_tag(b"kv_op", b"insert_many");
+
+        for idx in args.start..args.start.saturating_add(args.count) {
+            let key = format!("{}{}", args.prefix, idx);
+            let value: Vec<u8> = key
+                .bytes()
+                .cycle()
+                .take(args.value_size as usize)
+                .collect();
+
+            ctx.parent
+                .core
+                .runtime_state
+                .insert(key.as_bytes(), &value);
+        }
+
+        Ok(args.count)
+    }
+
     /// Retrieve a key/value pair.
     pub fn get(ctx: &mut TxContext, args: Get) -> Result<Option<String>, String> {
         if ctx.is_check_only() {
@@ -418,6 +460,70 @@ impl Methods {
         Self::enc_remove(ctx, args.key, state_key)
     }
 
+    /// Opens an encrypted call, dispatches it and seals the result for the caller.
+    pub fn encrypted_call<F>(
+        ctx: &mut TxContext,
+        args: EncryptedCall,
+        dispatch: F,
+    ) -> Result<Option<EncryptedCallResult>, String>
+    where
+        F: FnOnce(&mut TxContext, Call) -> Result<cbor::Value, String>,
+    {
+        let nonce: [u8; NONCE_SIZE] = args
+            .nonce
+            .as_slice()
+            .try_into()
+            .map_err(|_| "invalid nonce".to_string())?;
+
+        // Fetch private key.
+        let future = ctx
+            .parent
+            .key_manager
+            .get_or_create_ephemeral_keys(args.key_pair_id, args.epoch);
+        let long_term_sk = block_on(future)
+            .map_err(|err| format!("private ephemeral key not available: {err}"))?;
+
+        // Open the call.
+        let plaintext = deoxysii::box_open(
+            &nonce,
+            args.data,
+            ENCRYPTED_CALL_CONTEXT.to_vec(),
+            &args.pk.0,
+            &long_term_sk.input_keypair.sk.0,
+        )
+        .map_err(|err| format!("failed to decrypt call: {err}"))?;
+        let call: Call =
+            cbor::from_slice(&plaintext).map_err(|_| "malformed encrypted call".to_string())?;
+
+        // The call is opened and dispatched in check-only mode as well so that invalid calls
+        // are rejected before being scheduled.
+        let result = dispatch(ctx, call)?;
+        if ctx.is_check_only() {
+            return Ok(None);
+        }
+
+        // Seal the result under a nonce derived from the call's nonce, as the same key pair
+        // was used to seal the call.
+        let result_nonce: [u8; NONCE_SIZE] =
+            Hash::digest_bytes_list(&[ENCRYPTED_CALL_RESULT_CONTEXT, &nonce[..]])
+                .truncated(NONCE_SIZE)
+                .try_into()
+                .unwrap();
+        let data = deoxysii::box_seal(
+            &result_nonce,
+            cbor::to_vec(result),
+            ENCRYPTED_CALL_RESULT_CONTEXT.to_vec(),
+            &args.pk.0,
+            &long_term_sk.input_keypair.sk.0,
+        )
+        .map_err(|err| format!("failed to encrypt call result: {err}"))?;
+
+        Ok(Some(EncryptedCallResult {
+            nonce: result_nonce.to_vec(),
+            data,
+        }))
+    }
+
     /// ElGamal encryption.
     pub fn encrypt(ctx: &mut TxContext, args: Encrypt) -> Result<Option<Vec<u8>>, String> {
         if ctx.is_check_only() {
@@ -3,9 +3,10 @@ use std::io::Cursor;
 
 use byteorder::{BigEndian, ReadBytesExt, WriteBytesExt};
 
+use oasis_core_keymanager::crypto::KeyPairId;
 use oasis_core_runtime::{
-    common::key_format::KeyFormat,
-    consensus::{registry, staking},
+    common::{crypto::x25519, key_format::KeyFormat},
+    consensus::{beacon::EpochTime, registry, staking},
 };
 
 /// Test transaction call.
@@ -54,6 +55,15 @@ pub struct Insert {
     pub churp_id: u8,
 }
 
+/// Insert many generated key-value pairs call.
+#[derive(Clone, Debug, Default, cbor::Encode, cbor::Decode)]
+pub struct InsertMany {
+    pub prefix: String,
+    pub start: u64,
+    pub count: u64,
+    pub value_size: u64,
+}
+
 /// Encrypt plaintext call.
 #[derive(Clone, Debug, Default, cbor::Encode, cbor::Decode)]
 pub struct Encrypt {
@@ -70,6 +80,34 @@ pub struct Decrypt {
     pub ciphertext: Vec<u8>,
 }
 
+/// Encrypted call.
+///
+/// The call is sealed using an X25519-DeoxysII box between the caller's ephemeral key and
+/// the runtime's ephemeral key for the given key pair ID and epoch.
+#[derive(Clone, Debug, cbor::Encode, cbor::Decode)]
+#[cbor(no_default)]
+pub struct EncryptedCall {
+    /// Key pair ID of the runtime's ephemeral key.
+    pub key_pair_id: KeyPairId,
+    /// Epoch of the runtime's ephemeral key.
+    pub epoch: EpochTime,
+    /// Caller's ephemeral public key.
+    pub pk: x25519::PublicKey,
+    /// Box nonce.
+    pub nonce: Vec<u8>,
+    /// Sealed CBOR-encoded call.
+    pub data: Vec<u8>,
+}
+
+/// Encrypted call result.
+#[derive(Clone, Debug, Default, cbor::Encode, cbor::Decode)]
+pub struct EncryptedCallResult {
+    /// Box nonce.
+    pub nonce: Vec<u8>,
+    /// Sealed CBOR-encoded call result.
+    pub data: Vec<u8>,
+}
+
 /// Withdraw call.
 #[derive(Clone, Debug, Default, cbor::Encode, cbor::Decode)]
 pub struct Withdraw {